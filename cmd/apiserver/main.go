@@ -4,9 +4,11 @@ import (
 	"fantom-api-graphql/cmd/apiserver/build"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/graphql/resolvers"
+	gqlSchema "fantom-api-graphql/internal/graphql/schema"
 	"fantom-api-graphql/internal/handlers"
 	"flag"
 	"net/http"
+	"strings"
 	"time"
 
 	/* "fantom-api-graphql/internal/handlers" */
@@ -34,6 +36,9 @@ func main() {
 	// make sure to capture version request and rescan depth
 	versionRequest := flag.Bool("v", false, "get the application version")
 
+	// is this a database snapshot command?
+	snapshotCmd := snapshotCommand()
+
 	// get the configuration to prepare the server
 	cfg, err := config.Load()
 	if nil != err {
@@ -49,6 +54,12 @@ func main() {
 	// make logger
 	lg := logger.New(cfg)
 
+	// export, or import database snapshot instead of running the server
+	if snapshotCmd != "" {
+		runSnapshot(snapshotCmd, cfg, lg)
+		return
+	}
+
 	// create repository for data exchange with the blockchain full node and local persistent storage
 	repository.SetConfig(cfg)
 	repository.SetLogger(lg)
@@ -80,13 +91,19 @@ func startHttpServer(cfg *config.Config, log logger.Logger) {
 	log.Infof("listening for requests on [%s]", cfg.Server.BindAddress)
 
 	// start the server
-	log.Fatal(srv.ListenAndServe())
+	log.Fatal(listenAndServe(srv, &cfg.Server.TLS, log))
 }
 
 // setupHandlers initializes an array of handlers for our HTTP API end-points.
 func setupHandlers(mux *http.ServeMux, cfg *config.Config, log logger.Logger) resolvers.ApiResolver {
+	// remove disabled feature groups from the schema before it's used
+	if len(cfg.Features.Disabled) > 0 {
+		log.Noticef("API feature groups disabled: %s", strings.Join(cfg.Features.Disabled, ", "))
+		gqlSchema.Disable(cfg.Features.Disabled)
+	}
+
 	// create root resolver
-	rs := resolvers.New(cfg, log)
+	rs := resolvers.New(cfg, log.ModuleLogger(logger.ModuleResolvers))
 	log.Notice("initialized, going live")
 
 	// setup GraphQL API handler
@@ -95,10 +112,26 @@ func setupHandlers(mux *http.ServeMux, cfg *config.Config, log logger.Logger) re
 	mux.Handle("/graphql", h)
 
 	// setup REST API
-	mux.Handle("/json/gas", handlers.GasPrice(log))
+	mux.Handle("/json/gas", handlers.Secure(cfg, log, handlers.GasPrice(log)))
 
-	// handle GraphiQL interface
-	mux.Handle("/graphi", handlers.GraphiHandler(cfg.Server.DomainAddress, log))
+	// setup embeddable widget end-points; they are public with permissive CORS
+	mux.Handle("/widget/price", handlers.WidgetPrice(log))
+	mux.Handle("/widget/tps", handlers.WidgetTps(log))
+	mux.Handle("/widget/supply", handlers.WidgetSupply(log))
+
+	// serve compilation artifacts of validated contracts
+	mux.Handle(handlers.ContractArtifactPath, handlers.Secure(cfg, log, handlers.ContractArtifact(log)))
+
+	// export the API usage report; a privileged API key is required
+	mux.Handle("/usage/export", handlers.Secure(cfg, log, handlers.UsageExport(cfg, log)))
+
+	// serve the schema SDL for client code generators
+	mux.Handle("/schema.graphql", handlers.Secure(cfg, log, handlers.SchemaHandler(log)))
+
+	// handle GraphQL playground interface, if enabled
+	if cfg.Server.Playground.Enabled {
+		mux.Handle("/graphi", handlers.PlaygroundHandler(cfg, log))
+	}
 	return rs
 }
 
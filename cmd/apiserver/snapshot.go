@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository/db"
+	"flag"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// database snapshot commands
+const (
+	cmdSnapshotExport = "export"
+	cmdSnapshotImport = "import"
+)
+
+// database snapshot command options
+var (
+	snapshotCollections = flag.String("collections", "", "Comma separated list of collections to export, or import; all if empty.")
+	snapshotOut         = flag.String("out", "snapshot", "Directory the off-chain database snapshot is exported to.")
+	snapshotIn          = flag.String("in", "snapshot", "Directory the off-chain database snapshot is imported from.")
+	snapshotFormat      = flag.String("format", db.SnapshotFormatBSON, "Format of the exported documents; bson, or json.")
+	snapshotDrop        = flag.Bool("drop", false, "Drop existing collections before the import.")
+)
+
+// snapshotCommand detects the database snapshot command and removes it from the arguments
+// so the command options can be parsed along with the rest of the configuration flags.
+func snapshotCommand() string {
+	if len(os.Args) < 2 || (os.Args[1] != cmdSnapshotExport && os.Args[1] != cmdSnapshotImport) {
+		return ""
+	}
+
+	cmd := os.Args[1]
+	os.Args = append(os.Args[:1], os.Args[2:]...)
+	return cmd
+}
+
+// runSnapshot executes the database snapshot command.
+func runSnapshot(cmd string, cfg *config.Config, log logger.Logger) {
+	// connect the database directly, we don't need the rest of the repository
+	dbBridge, err := db.New(cfg, log)
+	if err != nil {
+		log.Fatalf("can not connect the database; %s", err.Error())
+		return
+	}
+	defer dbBridge.Close()
+
+	// which collections
+	var names []string
+	if *snapshotCollections != "" {
+		names = strings.Split(*snapshotCollections, ",")
+		for i := range names {
+			names[i] = strings.TrimSpace(names[i])
+		}
+	}
+
+	// the snapshot may take long; it runs until done, or interrupted
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sig)
+	go func() {
+		select {
+		case <-sig:
+			log.Noticef("database %s interrupted", cmd)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var man *db.SnapshotManifest
+	if cmd == cmdSnapshotExport {
+		man, err = dbBridge.ExportSnapshot(ctx, *snapshotOut, names, *snapshotFormat)
+	} else {
+		man, err = dbBridge.ImportSnapshot(ctx, *snapshotIn, names, *snapshotDrop)
+	}
+
+	if err != nil {
+		log.Fatalf("database %s failed; %s", cmd, err.Error())
+		return
+	}
+	log.Noticef("database %s of %d collections done", cmd, len(man.Collections))
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"golang.org/x/crypto/acme/autocert"
+	"net/http"
+)
+
+// listenAndServe starts the server listening on plain HTTP, or on TLS
+// with provided, or automatically issued certificates.
+// HTTP/2 is enabled by the server automatically on TLS connections.
+func listenAndServe(srv *http.Server, cfg *config.ServerTLS, log logger.Logger) error {
+	// automatic certificates from Let's Encrypt
+	if cfg.AutoCert {
+		return listenAndServeAutoCert(srv, cfg, log)
+	}
+
+	// certificates provided by the configuration
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		log.Noticef("TLS termination enabled with certificate %s", cfg.CertFile)
+		return srv.ListenAndServeTLS(cfg.CertFile, cfg.KeyFile)
+	}
+
+	// plain HTTP
+	return srv.ListenAndServe()
+}
+
+// listenAndServeAutoCert starts the server on TLS with certificates
+// obtained automatically using ACME protocol.
+func listenAndServeAutoCert(srv *http.Server, cfg *config.ServerTLS, log logger.Logger) error {
+	// make the certificate manager
+	mgr := autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.Domains...),
+		Cache:      autocert.DirCache(cfg.CacheDir),
+		Email:      cfg.Email,
+	}
+	srv.TLSConfig = mgr.TLSConfig()
+
+	// HTTP-01 challenge responder; it also redirects plain HTTP to HTTPS
+	if cfg.HttpBind != "" {
+		go func() {
+			log.Noticef("ACME challenge listener on [%s]", cfg.HttpBind)
+			if err := http.ListenAndServe(cfg.HttpBind, mgr.HTTPHandler(nil)); err != nil {
+				log.Errorf("ACME challenge listener failed; %s", err.Error())
+			}
+		}()
+	}
+
+	log.Noticef("TLS termination enabled with automatic certificates for %v", cfg.Domains)
+	return srv.ListenAndServeTLS("", "")
+}
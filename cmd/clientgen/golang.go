@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	gqlSchema "fantom-api-graphql/internal/graphql/schema"
+	"fmt"
+	"go/format"
+	"strings"
+	"unicode"
+
+	"github.com/graph-gophers/graphql-go/introspection"
+)
+
+// goScalars maps the built-in GraphQL scalars to Go types;
+// custom scalars of the API are transported as strings.
+var goScalars = map[string]string{
+	"Int":     "int32",
+	"Float":   "float64",
+	"String":  "string",
+	"Boolean": "bool",
+	"ID":      "string",
+}
+
+// goBindings builds the Go source code of the schema types.
+func goBindings(pkg string, types []*introspection.Type) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by clientgen from the API GraphQL schema; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "// Package %s provides typed bindings of the API GraphQL schema.\n", pkg)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintf(&b, "import \"encoding/json\"\n\n")
+	fmt.Fprintf(&b, "// SchemaVersion is the version of the schema the bindings were generated from.\n")
+	fmt.Fprintf(&b, "const SchemaVersion = %q\n\n", gqlSchema.Version())
+	fmt.Fprintf(&b, "// make sure the json package is used even if there are no abstract types\n")
+	fmt.Fprintf(&b, "var _ json.RawMessage\n")
+
+	for _, t := range types {
+		// built-in scalars map to Go types directly
+		name := *t.Name()
+		if _, ok := goScalars[name]; ok {
+			continue
+		}
+
+		b.WriteString("\n")
+		goComment(&b, t.Description())
+		switch t.Kind() {
+		case "SCALAR":
+			fmt.Fprintf(&b, "type %s = string\n", name)
+
+		case "ENUM":
+			fmt.Fprintf(&b, "type %s string\n\n", name)
+			fmt.Fprintf(&b, "// Values of the %s enum.\n", name)
+			b.WriteString("const (\n")
+			for _, v := range *t.EnumValues(&struct{ IncludeDeprecated bool }{true}) {
+				fmt.Fprintf(&b, "%s%s %s = %q\n", name, goName(v.Name()), name, v.Name())
+			}
+			b.WriteString(")\n")
+
+		case "UNION", "INTERFACE":
+			// the concrete type is resolved by the client from the __typename
+			fmt.Fprintf(&b, "type %s = json.RawMessage\n", name)
+
+		case "INPUT_OBJECT":
+			fmt.Fprintf(&b, "type %s struct {\n", name)
+			names := make(map[string]bool)
+			for _, f := range *t.InputFields() {
+				goComment(&b, f.Description())
+				typ, tag := goType(f.Type(), true), f.Name()
+				if f.Type().Kind() != "NON_NULL" {
+					tag += ",omitempty"
+				}
+				fmt.Fprintf(&b, "%s %s `json:%q`\n", goFieldName(f.Name(), names), typ, tag)
+			}
+			b.WriteString("}\n")
+
+		case "OBJECT":
+			// any field may be missing in the response if not selected
+			fmt.Fprintf(&b, "type %s struct {\n", name)
+			names := make(map[string]bool)
+			for _, f := range typeFields(t) {
+				goComment(&b, f.Description())
+				fmt.Fprintf(&b, "%s %s `json:\"%s,omitempty\"`\n", goFieldName(f.Name(), names), goType(f.Type(), false), f.Name())
+			}
+			b.WriteString("}\n")
+		}
+	}
+	return format.Source(b.Bytes())
+}
+
+// goType provides the Go type expression of the given schema type.
+// Required values of input types are not pointers, so they are always sent.
+func goType(t *introspection.Type, required bool) string {
+	switch t.Kind() {
+	case "NON_NULL":
+		if required {
+			return goValueType(t.OfType())
+		}
+		return goType(t.OfType(), false)
+	case "LIST":
+		return "[]" + goType(t.OfType(), false)
+	default:
+		return "*" + goValueType(t)
+	}
+}
+
+// goValueType provides the Go type of the given schema type value.
+func goValueType(t *introspection.Type) string {
+	switch t.Kind() {
+	case "LIST":
+		return "[]" + goType(t.OfType(), true)
+	case "NON_NULL":
+		return goValueType(t.OfType())
+	}
+
+	if s, ok := goScalars[*t.Name()]; ok {
+		return s
+	}
+	return *t.Name()
+}
+
+// goName converts the schema name into an exported Go identifier.
+func goName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_'
+	})
+	for i, p := range parts {
+		r := []rune(strings.ToLower(p))
+		if strings.ToUpper(p) != p {
+			r = []rune(p)
+		}
+		r[0] = unicode.ToUpper(r[0])
+		parts[i] = string(r)
+	}
+	return strings.Join(parts, "")
+}
+
+// goFieldName provides unique exported Go identifier of a struct field.
+func goFieldName(name string, used map[string]bool) string {
+	id := goName(name)
+	for used[id] {
+		id += "_"
+	}
+	used[id] = true
+	return id
+}
+
+// goComment writes the description of a schema element as a doc comment.
+func goComment(b *bytes.Buffer, desc *string) {
+	for _, l := range description(desc) {
+		fmt.Fprintf(b, "// %s\n", strings.TrimSpace(l))
+	}
+}
@@ -0,0 +1,103 @@
+// Package main implements generator of client bindings of the API GraphQL schema.
+// The bindings are written into a directory named by the schema version, so clients
+// can be pinned to the schema version deployed on the API server.
+package main
+
+import (
+	gqlSchema "fantom-api-graphql/internal/graphql/schema"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/introspection"
+)
+
+// main generates the client bindings of the API schema.
+func main() {
+	out := flag.String("out", "build/clients", "Directory the versioned client bindings are written to.")
+	pkg := flag.String("pkg", "fantomapi", "Name of the generated Go package.")
+	flag.Parse()
+
+	// parse the schema; we don't need resolvers to inspect it
+	schema := graphql.MustParseSchema(gqlSchema.Schema(), nil)
+	types := schemaTypes(schema.Inspect())
+
+	dir := filepath.Join(*out, gqlSchema.Version())
+	introspect, err := schema.ToJSON()
+	if err != nil {
+		log.Fatalf("can not build schema introspection; %s", err.Error())
+	}
+
+	goCode, err := goBindings(*pkg, types)
+	if err != nil {
+		log.Fatalf("can not build Go bindings; %s", err.Error())
+	}
+
+	files := map[string][]byte{
+		"schema.graphql":                         []byte(gqlSchema.Schema()),
+		"schema.json":                            introspect,
+		filepath.Join("typescript", "schema.ts"): tsBindings(types),
+		filepath.Join("go", *pkg, "schema.go"):   goCode,
+	}
+	for name, data := range files {
+		if err := writeFile(filepath.Join(dir, name), data); err != nil {
+			log.Fatalf("can not write %s; %s", name, err.Error())
+		}
+	}
+	log.Printf("client bindings of schema %s written to %s", gqlSchema.Version(), dir)
+}
+
+// writeFile writes the given content into the file, creating the directory if needed.
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// schemaTypes provides the named types of the schema the bindings are generated for,
+// sorted by their name. Introspection and federation specific types are skipped.
+func schemaTypes(schema *introspection.Schema) []*introspection.Type {
+	list := make([]*introspection.Type, 0)
+	for _, t := range schema.Types() {
+		if t.Name() == nil || strings.HasPrefix(*t.Name(), "_") {
+			continue
+		}
+		list = append(list, t)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return *list[i].Name() < *list[j].Name()
+	})
+	return list
+}
+
+// typeFields provides the fields of an object type.
+func typeFields(t *introspection.Type) []*introspection.Field {
+	fields := t.Fields(&struct{ IncludeDeprecated bool }{true})
+	if fields == nil {
+		return nil
+	}
+
+	// federation fields are not part of the client API
+	list := make([]*introspection.Field, 0, len(*fields))
+	for _, f := range *fields {
+		if !strings.HasPrefix(f.Name(), "_") {
+			list = append(list, f)
+		}
+	}
+	return list
+}
+
+// description provides the description of the schema element as a list of comment lines.
+func description(desc *string) []string {
+	if desc == nil || strings.TrimSpace(*desc) == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSpace(*desc), "\n")
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	gqlSchema "fantom-api-graphql/internal/graphql/schema"
+	"fmt"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/introspection"
+)
+
+// tsScalars maps the built-in GraphQL scalars to TypeScript types;
+// custom scalars of the API are transported as strings.
+var tsScalars = map[string]string{
+	"Int":     "number",
+	"Float":   "number",
+	"String":  "string",
+	"Boolean": "boolean",
+	"ID":      "string",
+}
+
+// tsBindings builds the TypeScript declarations of the schema types.
+func tsBindings(types []*introspection.Type) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by clientgen from the API GraphQL schema; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "// SCHEMA_VERSION is the version of the schema the bindings were generated from.\n")
+	fmt.Fprintf(&b, "export const SCHEMA_VERSION = %q;\n", gqlSchema.Version())
+
+	for _, t := range types {
+		b.WriteString("\n")
+		tsComment(&b, "", t.Description())
+
+		name := *t.Name()
+		switch t.Kind() {
+		case "SCALAR":
+			if _, ok := tsScalars[name]; !ok {
+				fmt.Fprintf(&b, "export type %s = string;\n", name)
+			} else {
+				fmt.Fprintf(&b, "export type %s = %s;\n", name, tsScalars[name])
+			}
+
+		case "ENUM":
+			values := make([]string, 0)
+			for _, v := range *t.EnumValues(&struct{ IncludeDeprecated bool }{true}) {
+				values = append(values, fmt.Sprintf("%q", v.Name()))
+			}
+			fmt.Fprintf(&b, "export type %s = %s;\n", name, strings.Join(values, " | "))
+
+		case "UNION":
+			members := make([]string, 0)
+			for _, m := range *t.PossibleTypes() {
+				members = append(members, *m.Name())
+			}
+			fmt.Fprintf(&b, "export type %s = %s;\n", name, strings.Join(members, " | "))
+
+		case "INPUT_OBJECT":
+			fmt.Fprintf(&b, "export interface %s {\n", name)
+			for _, f := range *t.InputFields() {
+				tsComment(&b, "  ", f.Description())
+				opt := ""
+				if f.Type().Kind() != "NON_NULL" {
+					opt = "?"
+				}
+				fmt.Fprintf(&b, "  %s%s: %s;\n", f.Name(), opt, tsType(f.Type()))
+			}
+			b.WriteString("}\n")
+
+		case "OBJECT", "INTERFACE":
+			// any field may be missing in the response if not selected
+			fmt.Fprintf(&b, "export interface %s {\n", name)
+			for _, f := range typeFields(t) {
+				tsComment(&b, "  ", f.Description())
+				fmt.Fprintf(&b, "  %s?: %s;\n", f.Name(), tsType(f.Type()))
+			}
+			b.WriteString("}\n")
+		}
+	}
+	return b.Bytes()
+}
+
+// tsType provides the TypeScript type expression of the given schema type.
+func tsType(t *introspection.Type) string {
+	switch t.Kind() {
+	case "NON_NULL":
+		return strings.TrimSuffix(tsType(t.OfType()), " | null")
+	case "LIST":
+		return fmt.Sprintf("Array<%s> | null", tsType(t.OfType()))
+	default:
+		return *t.Name() + " | null"
+	}
+}
+
+// tsComment writes the description of a schema element as a doc comment.
+func tsComment(b *bytes.Buffer, indent string, desc *string) {
+	lines := description(desc)
+	if len(lines) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "%s/**\n", indent)
+	for _, l := range lines {
+		fmt.Fprintf(b, "%s * %s\n", indent, strings.TrimSpace(l))
+	}
+	fmt.Fprintf(b, "%s */\n", indent)
+}
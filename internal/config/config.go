@@ -19,6 +19,9 @@ type Config struct {
 	// Server configuration
 	Server Server `mapstructure:"server"`
 
+	// Auth configuration
+	Auth Auth `mapstructure:"auth"`
+
 	// Logger configuration
 	Log Log `mapstructure:"log"`
 
@@ -46,6 +49,24 @@ type Config struct {
 	// Governance configuration
 	Governance Governance `mapstructure:"governance"`
 
+	// NameService configuration
+	NameService NameService `mapstructure:"fns"`
+
+	// Moderation configuration
+	Moderation Moderation `mapstructure:"moderation"`
+
+	// Compliance configuration
+	Compliance Compliance `mapstructure:"compliance"`
+
+	// TokenList configuration
+	TokenList TokenList `mapstructure:"token_list"`
+
+	// Multisig configuration
+	Multisig Multisig `mapstructure:"multisig"`
+
+	// Features configuration
+	Features Features `mapstructure:"features"`
+
 	// TokenLogoFilePath contains the path to JSON file with the map
 	// of known ERC20 tokens to their logo URLs.
 	// The file will be loaded on configuration loading.
@@ -69,16 +90,89 @@ type RepoCmd struct {
 
 // Server represents the GraphQL server configuration
 type Server struct {
-	BindAddress     string   `mapstructure:"bind"`
-	DomainAddress   string   `mapstructure:"domain"`
-	Origin          string   `mapstructure:"origin"`
-	Peers           []string `mapstructure:"peers"`
-	CorsOrigin      []string `mapstructure:"cors_origins"`
-	ReadTimeout     int64    `mapstructure:"read_timeout"`
-	WriteTimeout    int64    `mapstructure:"write_timeout"`
-	IdleTimeout     int64    `mapstructure:"idle_timeout"`
-	HeaderTimeout   int64    `mapstructure:"header_timeout"`
-	ResolverTimeout int64    `mapstructure:"resolver_timeout"`
+	BindAddress      string       `mapstructure:"bind"`
+	DomainAddress    string       `mapstructure:"domain"`
+	Origin           string       `mapstructure:"origin"`
+	Peers            []string     `mapstructure:"peers"`
+	CorsOrigin       []string     `mapstructure:"cors_origins"`
+	CorsMethods      []string     `mapstructure:"cors_methods"`
+	CorsHeaders      []string     `mapstructure:"cors_headers"`
+	CorsMaxAge       int          `mapstructure:"cors_max_age"`
+	SecurityHeaders  bool         `mapstructure:"security_headers"`
+	ReadTimeout      int64        `mapstructure:"read_timeout"`
+	WriteTimeout     int64        `mapstructure:"write_timeout"`
+	IdleTimeout      int64        `mapstructure:"idle_timeout"`
+	HeaderTimeout    int64        `mapstructure:"header_timeout"`
+	ResolverTimeout  int64        `mapstructure:"resolver_timeout"`
+	ExecutionTimeout int64        `mapstructure:"execution_timeout"`
+	Maintenance      bool         `mapstructure:"maintenance"`
+	TLS              ServerTLS    `mapstructure:"tls"`
+	AccessLog        AccessLog    `mapstructure:"access_log"`
+	Playground       Playground   `mapstructure:"playground"`
+	LoadShedding     LoadShedding `mapstructure:"load_shedding"`
+}
+
+// LoadShedding represents the configuration of rejecting expensive queries
+// while the block chain node, or the database responds slowly.
+type LoadShedding struct {
+	// NodeLatency and DbLatency are the 95th percentile latency thresholds
+	// of the node and the database calls; zero disables the check.
+	NodeLatency time.Duration `mapstructure:"node_latency"`
+	DbLatency   time.Duration `mapstructure:"db_latency"`
+
+	// Fields lists the query fields of the expensive queries rejected
+	// while any of the thresholds is exceeded.
+	Fields []string `mapstructure:"fields"`
+}
+
+// Playground represents the configuration of the in-browser GraphQL IDE.
+type Playground struct {
+	// Enabled exposes the IDE; production deployments may want to switch it off.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Assets is the base URL the IDE scripts and styles are loaded from,
+	// it may point to a self-hosted mirror of the npm packages.
+	Assets string `mapstructure:"assets"`
+}
+
+// AccessLog represents the configuration of the API requests access log.
+type AccessLog struct {
+	// SlowThreshold is the duration of a request above which
+	// the request details are always logged.
+	SlowThreshold time.Duration `mapstructure:"slow_threshold"`
+
+	// SampleRate is the fraction of requests, between 0 and 1, logged
+	// with full details regardless of their duration.
+	SampleRate float64 `mapstructure:"sample_rate"`
+}
+
+// ServerTLS represents the TLS termination configuration of the server.
+type ServerTLS struct {
+	// CertFile and KeyFile are paths to the certificate and its private key
+	// used to terminate TLS connections.
+	CertFile string `mapstructure:"cert"`
+	KeyFile  string `mapstructure:"key"`
+
+	// AutoCert enables automatic certificates issued by Let's Encrypt
+	// for the listed domains; certificates are stored in the cache directory.
+	AutoCert bool     `mapstructure:"auto"`
+	Domains  []string `mapstructure:"domains"`
+	CacheDir string   `mapstructure:"cache_dir"`
+	Email    string   `mapstructure:"email"`
+
+	// HttpBind is the plain HTTP binding address used to respond to ACME
+	// challenges and redirect clients to HTTPS.
+	HttpBind string `mapstructure:"http_bind"`
+}
+
+// Auth represents the API access authorization configuration.
+type Auth struct {
+	// AdminKeys is the list of API keys authorized to execute privileged operations.
+	AdminKeys []string `mapstructure:"admin_keys"`
+
+	// ClientKeys is the list of API keys of registered clients, i.e. clients
+	// allowed to keep their own address watch lists.
+	ClientKeys []string `mapstructure:"client_keys"`
 }
 
 // ServerSignature represents the signature used by this server
@@ -92,17 +186,95 @@ type ServerSignature struct {
 type Log struct {
 	Level  string `mapstructure:"level"`
 	Format string `mapstructure:"format"`
+
+	// Modules maps logging modules to their own logging levels.
+	Modules map[string]string `mapstructure:"modules"`
 }
 
 // Lachesis represents the Lachesis node access configuration
 type Lachesis struct {
 	Url string `mapstructure:"url"`
+
+	// Multicall is the address of the Multicall2 contract used
+	// to batch read-only calls; batching is disabled if not set.
+	Multicall common.Address `mapstructure:"multicall"`
+
+	// Timeout is the max duration of a single node call.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Retries is the number of retries of a node call failed
+	// on the node not responding; RetryDelay is the base of the jittered backoff.
+	Retries    int           `mapstructure:"retries"`
+	RetryDelay time.Duration `mapstructure:"retry_delay"`
+
+	// BreakerThreshold is the number of consecutive failed node calls
+	// opening the circuit breaker; calls are rejected for BreakerCooldown then.
+	BreakerThreshold int           `mapstructure:"breaker_threshold"`
+	BreakerCooldown  time.Duration `mapstructure:"breaker_cooldown"`
+
+	// Fixtures configures recording of the node responses into a fixtures file,
+	// or replaying them from the file instead of calling the node.
+	Fixtures NodeFixtures `mapstructure:"fixtures"`
 }
 
+// NodeFixtures represents the configuration of the node responses record/replay.
+type NodeFixtures struct {
+	// Mode is either "record", or "replay"; the node is called directly if empty.
+	Mode string `mapstructure:"mode"`
+
+	// Path is the path of the fixtures file.
+	Path string `mapstructure:"path"`
+}
+
+// Off-chain storage drivers selectable by the configuration.
+const (
+	// DbDriverMongo keeps the off-chain data in Mongo database.
+	DbDriverMongo = "mongo"
+
+	// DbDriverMemory keeps the off-chain data in memory only; it's meant for development
+	// and the data are lost when the API server stops.
+	DbDriverMemory = "memory"
+)
+
 // Database represents the database access configuration.
 type Database struct {
+	// Driver is the off-chain storage driver, i.e. mongo, or memory.
+	Driver string `mapstructure:"driver"`
+
 	Url    string `mapstructure:"url"`
 	DbName string `mapstructure:"db"`
+
+	// ConnectTimeout is the max duration of opening a new database connection.
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+
+	// SocketTimeout is the max time a database operation waits for a read, or a write
+	// on the connection; zero waits as long as the operation context allows.
+	SocketTimeout time.Duration `mapstructure:"socket_timeout"`
+
+	// MinPoolSize is the min number of connections kept open to each database server.
+	MinPoolSize uint64 `mapstructure:"min_pool"`
+
+	// MaxPoolSize is the max number of connections open to each database server.
+	MaxPoolSize uint64 `mapstructure:"max_pool"`
+
+	// MaxIdleTime is the max time a connection stays idle in the pool before it's closed.
+	MaxIdleTime time.Duration `mapstructure:"max_idle"`
+
+	// ReadPreference is the default read preference, i.e. primary, primaryPreferred,
+	// secondary, secondaryPreferred, or nearest.
+	ReadPreference string `mapstructure:"read_preference"`
+
+	// WriteConcern is the acknowledgement required for writes, i.e. majority,
+	// or the number of nodes; empty uses the default of the database.
+	WriteConcern string `mapstructure:"write_concern"`
+
+	// AnalyticsReadPreference is the read preference of heavy analytical queries,
+	// typically secondaryPreferred; empty keeps them on the default read preference.
+	AnalyticsReadPreference string `mapstructure:"analytics_read_preference"`
+
+	// AnalyticsCollections is the list of collections whose analytical queries
+	// are routed by the analytics read preference.
+	AnalyticsCollections []string `mapstructure:"analytics_collections"`
 }
 
 // Cache represents the cache sub-system configuration.
@@ -120,6 +292,28 @@ type Compiler struct {
 // Repository represents the repository configuration.
 type Repository struct {
 	MonitorStakers bool `mapstructure:"stakers"`
+
+	// TraceCreations enables call tracing of transactions to detect contracts
+	// deployed by other contracts and internal value transfers changing
+	// account balances; the node must provide the trace API.
+	TraceCreations bool `mapstructure:"trace_creations"`
+
+	// ScanWorkers is the number of workers of each stage of the block
+	// pipeline fetching, decoding, and classifying blocks during the block scan.
+	ScanWorkers int `mapstructure:"scan_workers"`
+
+	// Scheduler configures the periodic background services.
+	Scheduler Scheduler `mapstructure:"scheduler"`
+}
+
+// Scheduler represents the configuration of periodic background services.
+type Scheduler struct {
+	// Jitter is the max relative deviation applied to each scheduling period
+	// so API peers sharing the database don't run the same jobs in sync.
+	Jitter float64 `mapstructure:"jitter"`
+
+	// Periods maps the periodic services to their scheduling periods.
+	Periods map[string]time.Duration `mapstructure:"periods"`
 }
 
 // Staking represents the PoS Staking module configuration.
@@ -128,6 +322,10 @@ type Staking struct {
 	StiContract         common.Address `mapstructure:"sti"`
 	TokenizerContract   common.Address `mapstructure:"tokenizer"`
 	TokenizedStakeToken common.Address `mapstructure:"token"`
+
+	// InfoUrl is the address of the staker information registry used for stakers
+	// without information in the STI contract; the {id} is replaced with the staker ID.
+	InfoUrl string `mapstructure:"info_url"`
 }
 
 // DeFi represents the DeFi and financial contracts configuration.
@@ -141,6 +339,10 @@ type DeFi struct {
 // DeFiFMint represents the fMint DeFi module configuration.
 type DeFiFMint struct {
 	AddressProvider common.Address `mapstructure:"address_provider"`
+
+	// WarningRatio4 is the collateral ratio, represented in 4 digits,
+	// below which an fMint account is considered at risk.
+	WarningRatio4 uint64 `mapstructure:"warning_ratio"`
 }
 
 // DeFiUniswap represents the Uniswap protocol DeFi module configuration.
@@ -148,11 +350,20 @@ type DeFiUniswap struct {
 	Core           common.Address   `mapstructure:"core"`
 	Router         common.Address   `mapstructure:"router"`
 	PairsWhiteList []common.Address `mapstructure:"whitelist"`
+
+	// WrappedNative is the wrapped FTM token used to price tokens in FTM.
+	WrappedNative common.Address `mapstructure:"wftm"`
+
+	// StableTokens is the list of tokens pegged to USD used to price tokens in USD.
+	StableTokens []common.Address `mapstructure:"stables"`
 }
 
 // Governance represents the governance module configuration.
 type Governance struct {
 	Contracts []GovernanceContract `mapstructure:"contracts"`
+
+	// Ballots is the list of simple community ballot contracts
+	Ballots []common.Address `mapstructure:"ballots"`
 }
 
 // GovernanceContract represents a single Governance contract configuration.
@@ -164,6 +375,85 @@ type GovernanceContract struct {
 	Type       string         `mapstructure:"type"`
 }
 
+// NameService represents the Fantom name service (FNS) configuration.
+type NameService struct {
+	Registry common.Address `mapstructure:"registry"`
+}
+
+// Moderation represents the configuration of known scam and phishing contracts.
+type Moderation struct {
+	Flagged []RiskFlag `mapstructure:"flagged"`
+}
+
+// Compliance represents the configuration of the compliance screening
+// of addresses involved in the API calls.
+type Compliance struct {
+	// Screener is the name of the screening implementation; empty disables the screening.
+	// The built-in "denylist" screener checks addresses against the denylist collection.
+	Screener string `mapstructure:"screener"`
+
+	// Collection is the name of the database collection with the denied addresses
+	// used by the built-in screener. The collection is maintained by the operator.
+	Collection string `mapstructure:"collection"`
+
+	// ScreenQueries enables the screening of account data queries,
+	// submitted transactions are always screened.
+	ScreenQueries bool `mapstructure:"screen_queries"`
+}
+
+// TokenList represents the configuration of the curated token metadata registry.
+type TokenList struct {
+	// Url is the address of the curated token list in the common token list
+	// JSON format; the list is merged into the token metadata collection.
+	Url string `mapstructure:"url"`
+}
+
+// Multisig represents the configuration of multisig wallets support.
+type Multisig struct {
+	// TxServiceUrl is the base address of the Safe transaction service API
+	// used to count pending multisig transactions; not counted if empty.
+	TxServiceUrl string `mapstructure:"tx_service"`
+}
+
+// Feature groups which can be disabled by the configuration.
+const (
+	// FeatureDeFi covers the DeFi functions depending on fMint, fLend, and Uniswap contracts.
+	FeatureDeFi = "defi"
+
+	// FeatureGovernance covers the governance contracts and ballots.
+	FeatureGovernance = "governance"
+
+	// FeatureErc covers the ERC20 tokens queries.
+	FeatureErc = "erc"
+
+	// FeatureMutations covers all the mutations of the API.
+	FeatureMutations = "mutations"
+)
+
+// Features represents the configuration of optional API feature groups.
+type Features struct {
+	// Disabled is the list of feature groups removed from the API schema,
+	// i.e. defi, governance, erc, or mutations.
+	Disabled []string `mapstructure:"disabled"`
+}
+
+// IsDisabled checks if the given feature group is disabled.
+func (f *Features) IsDisabled(name string) bool {
+	for _, d := range f.Disabled {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RiskFlag represents a single flagged contract, or token configuration.
+type RiskFlag struct {
+	Address common.Address `mapstructure:"address"`
+	Level   string         `mapstructure:"level"`
+	Reason  string         `mapstructure:"reason"`
+}
+
 // DeFiFLend represents the fLend DeFi module configuration.
 type DeFiFLend struct {
 	LendingPool common.Address `mapstructure:"lending_pool"`
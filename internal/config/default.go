@@ -29,6 +29,25 @@ const (
 	defIdleTimeout     = 1
 	defHeaderTimeout   = 1
 	defResolverTimeout = 30
+	defExecTimeout     = 12
+
+	// defCorsMaxAge holds default CORS preflight cache duration in seconds
+	defCorsMaxAge = 300
+
+	// defAccessLogSlowThreshold holds default duration of a request logged as slow
+	defAccessLogSlowThreshold = 2 * time.Second
+
+	// defAccessLogSampleRate holds default fraction of requests logged regardless of their duration
+	defAccessLogSampleRate = 0.01
+
+	// defPlaygroundAssets holds default base URL of the GraphQL playground scripts and styles
+	defPlaygroundAssets = "https://unpkg.com"
+
+	// defTlsCacheDir holds default path for automatically issued certificates
+	defTlsCacheDir = "/var/cache/apiserver/certs"
+
+	// defTlsHttpBind holds default binding address for ACME HTTP challenges
+	defTlsHttpBind = ":80"
 
 	// defServerDomain holds default API server domain address
 	defServerDomain = "localhost:16761"
@@ -44,12 +63,52 @@ const (
 	// defLachesisUrl holds default Lachesis connection string
 	defLachesisUrl = "~/.lachesis/data/lachesis.ipc"
 
+	// defNodeCallTimeout holds default timeout of a single node call; all the retries
+	// of a call need to fit into the server write timeout
+	defNodeCallTimeout = 4 * time.Second
+
+	// defNodeCallRetries holds default number of retries of a failed node call
+	defNodeCallRetries = 2
+
+	// defNodeCallRetryDelay holds default base delay between node call retries
+	defNodeCallRetryDelay = 200 * time.Millisecond
+
+	// defNodeBreakerThreshold holds default number of consecutive failed node calls
+	// opening the circuit breaker
+	defNodeBreakerThreshold = 10
+
+	// defNodeBreakerCooldown holds default time the circuit breaker stays open
+	defNodeBreakerCooldown = 30 * time.Second
+
 	// defMongoUrl holds default MongoDB connection string
 	defMongoUrl = "mongodb://localhost:27017"
 
 	// defMongoDatabase holds the default name of the API persistent database
 	defMongoDatabase = "fantom"
 
+	// defMongoConnectTimeout holds the default max duration of opening a new database connection
+	defMongoConnectTimeout = 10 * time.Second
+
+	// defMongoSocketTimeout holds the default max time a database operation waits
+	// for a read, or a write on the connection
+	defMongoSocketTimeout = 15 * time.Second
+
+	// defMongoMinPool holds the default min number of connections kept open to each database server
+	defMongoMinPool = 10
+
+	// defMongoMaxPool holds the default max number of connections open to each database server;
+	// explorer-scale read volume needs more than the driver default
+	defMongoMaxPool = 200
+
+	// defMongoMaxIdle holds the default max time a database connection stays idle in the pool
+	defMongoMaxIdle = 5 * time.Minute
+
+	// defMongoReadPreference holds the default read preference of the database
+	defMongoReadPreference = "primary"
+
+	// defMongoAnalyticsReadPreference holds the default read preference of heavy analytical queries
+	defMongoAnalyticsReadPreference = "secondaryPreferred"
+
 	// defCacheEvictionTime holds default time for in-memory eviction periods
 	defCacheEvictionTime = 15 * time.Minute
 
@@ -71,17 +130,33 @@ const (
 	// defDefiFMintAddressProvider represents the address of the fMintAddressProvider
 	defDefiFMintAddressProvider = "0x730e27f6c52d07b1a6ab39b639b617dc566c91af"
 
+	// defDefiFMintWarningRatio represents the collateral ratio of an fMint account
+	// in 4 digits below which the account is considered at risk, i.e. 350%
+	defDefiFMintWarningRatio = 35000
+
 	// defDefiFMintAddressProvider represents the address of the fMintAddressProvider
 	defDefiUniswapCore = EmptyAddress
 
 	// defDefiFMintAddressProvider represents the address of the fMintAddressProvider
 	defDefiUniswapRouter = EmptyAddress
 
+	// defDefiUniswapWrappedNative represents the address of the wrapped native FTM token
+	defDefiUniswapWrappedNative = "0x21be370D5312f44cB42ce377BC9b8a0cEF1A4C83"
+
 	// defTokenLogoFilePath represents the default path to the tokens map file
 	defTokenLogoFilePath = "tokens.json"
 
 	// defBlockScanRescanDepth represents the amount of blocks re-scanned on server start
 	defBlockScanRescanDepth = 50
+
+	// defBlockScanWorkers represents the number of workers loading blocks for the block scanner
+	defBlockScanWorkers = 4
+
+	// defSchedulerJitter represents the default relative jitter of background services periods
+	defSchedulerJitter = 0.1
+
+	// defComplianceCollection represents the default name of the denied addresses collection
+	defComplianceCollection = "denylist"
 )
 
 // default list of API peers
@@ -90,6 +165,23 @@ var defApiPeers = []string{"https://localhost:16761/api"}
 // defCorsAllowOrigins holds CORS default allowed origins.
 var defCorsAllowOrigins = []string{"*"}
 
+// defCorsAllowMethods holds CORS default allowed methods.
+var defCorsAllowMethods = []string{"HEAD", "GET", "POST"}
+
+// defCorsAllowHeaders holds CORS default allowed headers.
+var defCorsAllowHeaders = []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "Authorization", "X-Api-Key", "X-Api-Debug"}
+
+// defMongoAnalyticsCollections holds the default list of collections whose analytical
+// queries are routed by the analytics read preference.
+var defMongoAnalyticsCollections = []string{"transaction", "trx_volume", "rewards_daily", "usage"}
+
+// defLoadSheddingFields holds the default list of query fields rejected under load.
+var defLoadSheddingFields = []string{
+	"transactions", "txList", "erc20TxList", "contracts", "trxVolume", "finalityStats",
+	"defiTimeVolumes", "defiTimePrices", "defiTimeReserves", "defiUniswapActions",
+	"largeTransfers", "largeErc20Transfers", "relatedAddresses",
+}
+
 // default list of API peers
 var defVotingSources = make([]string, 0)
 
@@ -98,6 +190,27 @@ var defERC20Logo = map[common.Address]string{
 	common.HexToAddress(EmptyAddress): "https://repository.fantom.network/logos/erc20.svg",
 }
 
+// defSchedulerPeriods holds default scheduling periods of the periodic background services.
+var defSchedulerPeriods = map[string]time.Duration{
+	"trx_flow_updater":      7 * time.Minute,
+	"epoch_stats_updater":   time.Minute,
+	"delegators_indexer":    2 * time.Minute,
+	"stakers_snapshot":      2 * time.Minute,
+	"staking_stats_updater": 10 * time.Minute,
+	"supply_tracker":        time.Minute,
+	"uniswap_price_router":  5 * time.Minute,
+	"token_meta_registry":   30 * time.Minute,
+	"contract_classifier":   30 * time.Second,
+	"address_linker":        time.Minute,
+	"usage_recorder":        time.Minute,
+	"validation_propagator": 10 * time.Minute,
+	"watch_digest_builder":  5 * time.Minute,
+	"price_recorder":        15 * time.Minute,
+	"alert_monitor":         5 * time.Second,
+	"fmint_aggregator":      time.Minute,
+	"stm_monitor":           5 * time.Second,
+}
+
 // applyDefaults sets default values for configuration options.
 func applyDefaults(cfg *viper.Viper) {
 	// set simple details
@@ -109,14 +222,36 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault(keyLoggingLevel, defLoggingLevel)
 	cfg.SetDefault(keyLoggingFormat, defLoggingFormat)
 	cfg.SetDefault(keyLachesisUrl, defLachesisUrl)
+	cfg.SetDefault(keyNodeCallTimeout, defNodeCallTimeout)
+	cfg.SetDefault(keyNodeCallRetries, defNodeCallRetries)
+	cfg.SetDefault(keyNodeCallRetryDelay, defNodeCallRetryDelay)
+	cfg.SetDefault(keyNodeBreakerThreshold, defNodeBreakerThreshold)
+	cfg.SetDefault(keyNodeBreakerCooldown, defNodeBreakerCooldown)
+	cfg.SetDefault(keyDbDriver, DbDriverMongo)
 	cfg.SetDefault(keyMongoUrl, defMongoUrl)
 	cfg.SetDefault(keyMongoDatabase, defMongoDatabase)
+	cfg.SetDefault(keyMongoConnectTimeout, defMongoConnectTimeout)
+	cfg.SetDefault(keyMongoSocketTimeout, defMongoSocketTimeout)
+	cfg.SetDefault(keyMongoMinPool, defMongoMinPool)
+	cfg.SetDefault(keyMongoMaxPool, defMongoMaxPool)
+	cfg.SetDefault(keyMongoMaxIdle, defMongoMaxIdle)
+	cfg.SetDefault(keyMongoReadPreference, defMongoReadPreference)
+	cfg.SetDefault(keyMongoWriteConcern, "")
+	cfg.SetDefault(keyMongoAnalyticsReadPreference, defMongoAnalyticsReadPreference)
+	cfg.SetDefault(keyMongoAnalyticsCollections, defMongoAnalyticsCollections)
+	cfg.SetDefault(keyRepoScanWorkers, defBlockScanWorkers)
 	cfg.SetDefault(keySolCompilerPath, defSolCompilerPath)
 	cfg.SetDefault(keyApiPeers, defApiPeers)
 	cfg.SetDefault(keyApiStateOrigin, defApiStateOrigin)
 	cfg.SetDefault(keyErc20TokenMapFilePath, defTokenLogoFilePath)
 	cfg.SetDefault(keyErc20Logos, defERC20Logo)
 
+	// background services schedule
+	cfg.SetDefault(keySchedulerJitter, defSchedulerJitter)
+	for name, period := range defSchedulerPeriods {
+		cfg.SetDefault(keySchedulerPeriods+"."+name, period)
+	}
+
 	// in-memory cache
 	cfg.SetDefault(keyCacheEvictionTime, defCacheEvictionTime)
 	cfg.SetDefault(keyCacheMaxSize, defCacheMaxSize)
@@ -127,12 +262,38 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault(keyTimeoutHeader, defHeaderTimeout)
 	cfg.SetDefault(keyTimeoutIdle, defIdleTimeout)
 	cfg.SetDefault(keyTimeoutResolver, defResolverTimeout)
+	cfg.SetDefault(keyTimeoutExec, defExecTimeout)
 
 	// no voting sources by default
 	cfg.SetDefault(keyVotingSources, defVotingSources)
 
 	// cors
 	cfg.SetDefault(keyCorsAllowOrigins, defCorsAllowOrigins)
+	cfg.SetDefault(keyCorsAllowMethods, defCorsAllowMethods)
+	cfg.SetDefault(keyCorsAllowHeaders, defCorsAllowHeaders)
+	cfg.SetDefault(keyCorsMaxAge, defCorsMaxAge)
+	cfg.SetDefault(keySecurityHeaders, true)
+
+	// TLS termination is left to a reverse proxy by default
+	cfg.SetDefault(keyTlsAutoCert, false)
+	cfg.SetDefault(keyTlsCacheDir, defTlsCacheDir)
+	cfg.SetDefault(keyTlsHttpBind, defTlsHttpBind)
+
+	// access log
+	cfg.SetDefault(keyAccessLogSlowThreshold, defAccessLogSlowThreshold)
+	cfg.SetDefault(keyAccessLogSampleRate, defAccessLogSampleRate)
+
+	// GraphQL playground is available by default
+	cfg.SetDefault(keyPlaygroundEnabled, true)
+	cfg.SetDefault(keyPlaygroundAssets, defPlaygroundAssets)
+	cfg.SetDefault(keyLoadSheddingFields, defLoadSheddingFields)
+
+	// maintenance mode is off by default
+	cfg.SetDefault(keyMaintenance, false)
+
+	// no privileged access by default
+	cfg.SetDefault(keyAuthAdminKeys, []string{})
+	cfg.SetDefault(keyAuthClientKeys, []string{})
 
 	// staking configuration defaults
 	cfg.SetDefault(keyStakingSfcContract, defSfcContract)
@@ -142,6 +303,16 @@ func applyDefaults(cfg *viper.Viper) {
 
 	// DeFi configuration
 	cfg.SetDefault(keyDefiFMintAddressProvider, defDefiFMintAddressProvider)
+	cfg.SetDefault(keyDefiFMintWarningRatio, defDefiFMintWarningRatio)
 	cfg.SetDefault(keyDefiUniswapCore, defDefiUniswapCore)
 	cfg.SetDefault(keyDefiUniswapRouter, defDefiUniswapRouter)
+	cfg.SetDefault(keyDefiUniswapWrappedNative, defDefiUniswapWrappedNative)
+
+	// name service is disabled by default
+	cfg.SetDefault(keyFnsRegistry, EmptyAddress)
+
+	// compliance screening is disabled by default
+	cfg.SetDefault(keyComplianceScreener, "")
+	cfg.SetDefault(keyComplianceCollection, defComplianceCollection)
+	cfg.SetDefault(keyComplianceScreenQueries, false)
 }
@@ -19,6 +19,31 @@ const (
 	keyApiPeers         = "server.peers"
 	keyApiStateOrigin   = "server.origin"
 	keyCorsAllowOrigins = "server.cors_origins"
+	keyCorsAllowMethods = "server.cors_methods"
+	keyCorsAllowHeaders = "server.cors_headers"
+	keyCorsMaxAge       = "server.cors_max_age"
+	keySecurityHeaders  = "server.security_headers"
+	keyMaintenance      = "server.maintenance"
+
+	// access log related keys
+	keyAccessLogSlowThreshold = "server.access_log.slow_threshold"
+	keyAccessLogSampleRate    = "server.access_log.sample_rate"
+
+	// GraphQL playground related keys
+	keyPlaygroundEnabled = "server.playground.enabled"
+	keyPlaygroundAssets  = "server.playground.assets"
+
+	// load shedding
+	keyLoadSheddingFields = "server.load_shedding.fields"
+
+	// TLS termination related keys
+	keyTlsAutoCert = "server.tls.auto"
+	keyTlsCacheDir = "server.tls.cache_dir"
+	keyTlsHttpBind = "server.tls.http_bind"
+
+	// access authorization keys
+	keyAuthAdminKeys  = "auth.admin_keys"
+	keyAuthClientKeys = "auth.client_keys"
 
 	// server time out related keys
 	keyTimeoutRead     = "server.read_timeout"
@@ -26,6 +51,7 @@ const (
 	keyTimeoutIdle     = "server.idle_timeout"
 	keyTimeoutHeader   = "server.header_timeout"
 	keyTimeoutResolver = "server.resolver_timeout"
+	keyTimeoutExec     = "server.execution_timeout"
 
 	// API server signature related keys
 	keySignatureAddress    = "me.address"
@@ -38,9 +64,34 @@ const (
 	// node connection related options
 	keyLachesisUrl = "lachesis.url"
 
+	// node calls policy options
+	keyNodeCallTimeout      = "node.timeout"
+	keyNodeCallRetries      = "node.retries"
+	keyNodeCallRetryDelay   = "node.retry_delay"
+	keyNodeBreakerThreshold = "node.breaker_threshold"
+	keyNodeBreakerCooldown  = "node.breaker_cooldown"
+
 	// off-chain database related options
-	keyMongoUrl      = "db.url"
-	keyMongoDatabase = "db.db"
+	keyDbDriver            = "db.driver"
+	keyMongoUrl            = "db.url"
+	keyMongoDatabase       = "db.db"
+	keyMongoConnectTimeout = "db.connect_timeout"
+	keyMongoSocketTimeout  = "db.socket_timeout"
+	keyMongoMinPool        = "db.min_pool"
+	keyMongoMaxPool        = "db.max_pool"
+	keyMongoMaxIdle        = "db.max_idle"
+	keyMongoReadPreference = "db.read_preference"
+	keyMongoWriteConcern   = "db.write_concern"
+
+	keyMongoAnalyticsReadPreference = "db.analytics_read_preference"
+	keyMongoAnalyticsCollections    = "db.analytics_collections"
+
+	// repository related options
+	keyRepoScanWorkers = "repository.scan_workers"
+
+	// background services scheduler options
+	keySchedulerJitter  = "repository.scheduler.jitter"
+	keySchedulerPeriods = "repository.scheduler.periods"
 
 	// cache related options
 	keyCacheEvictionTime = "cache.eviction"
@@ -62,6 +113,16 @@ const (
 
 	// defi related configs
 	keyDefiFMintAddressProvider = "defi.fmint.address_provider"
+	keyDefiFMintWarningRatio    = "defi.fmint.warning_ratio"
 	keyDefiUniswapCore          = "defi.uniswap.core"
 	keyDefiUniswapRouter        = "defi.uniswap.router"
+	keyDefiUniswapWrappedNative = "defi.uniswap.wftm"
+
+	// name service related configs
+	keyFnsRegistry = "fns.registry"
+
+	// compliance screening related configs
+	keyComplianceScreener      = "compliance.screener"
+	keyComplianceCollection    = "compliance.collection"
+	keyComplianceScreenQueries = "compliance.screen_queries"
 )
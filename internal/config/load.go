@@ -34,6 +34,13 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	// check the consistency of the configuration
+	if err = validate(&config); err != nil {
+		log.Println("invalid API server configuration")
+		log.Println(err.Error())
+		return nil, err
+	}
+
 	// try to load the logo map file
 	loadErc20LogMap(&config)
 
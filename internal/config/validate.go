@@ -0,0 +1,98 @@
+// Package config handles API server configuration binding and loading.
+package config
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// validate checks the loaded configuration for inconsistent values
+// and adjusts them where a safe value can be derived.
+func validate(cfg *Config) error {
+	checkNodeCallBudget(cfg)
+	checkExecutionTimeout(&cfg.Server)
+	if err := checkDbDriver(&cfg.Db); err != nil {
+		return err
+	}
+	return checkRiskFlags(&cfg.Moderation)
+}
+
+// checkDbDriver makes sure the configured off-chain storage driver is known;
+// the driver name is normalized to lower case.
+func checkDbDriver(cfg *Database) error {
+	drv := strings.ToLower(strings.TrimSpace(cfg.Driver))
+	switch drv {
+	case DbDriverMongo:
+	case DbDriverMemory:
+		log.Printf("in-memory off-chain storage configured, the data will not survive restart")
+	default:
+		return fmt.Errorf("unknown off-chain storage driver %s", cfg.Driver)
+	}
+	cfg.Driver = drv
+	return nil
+}
+
+// checkRiskFlags makes sure all the configured moderation flags use a known
+// risk level; the levels are normalized to upper case.
+func checkRiskFlags(cfg *Moderation) error {
+	for i := range cfg.Flagged {
+		lvl := strings.ToUpper(strings.TrimSpace(cfg.Flagged[i].Level))
+		if !types.IsValidRiskLevel(lvl) {
+			return fmt.Errorf("unknown risk level %s of flagged address %s", cfg.Flagged[i].Level, cfg.Flagged[i].Address.String())
+		}
+		cfg.Flagged[i].Level = lvl
+	}
+	return nil
+}
+
+// checkNodeCallBudget makes sure a node call including all its retries finishes
+// before the server stops writing the response; otherwise the client gets
+// a broken response while the node call is still being retried.
+// The number of retries is lowered to fit into the server write timeout.
+func checkNodeCallBudget(cfg *Config) {
+	if cfg.Server.WriteTimeout <= 0 || cfg.Lachesis.Timeout <= 0 {
+		return
+	}
+
+	limit := time.Duration(cfg.Server.WriteTimeout) * time.Second
+	for cfg.Lachesis.Retries > 0 && nodeCallBudget(&cfg.Lachesis) >= limit {
+		cfg.Lachesis.Retries--
+		log.Printf("node call retries lowered to %d to fit into the server write timeout", cfg.Lachesis.Retries)
+	}
+
+	if nodeCallBudget(&cfg.Lachesis) >= limit {
+		log.Printf("node call timeout %s exceeds the server write timeout %s", cfg.Lachesis.Timeout, limit)
+	}
+}
+
+// checkExecutionTimeout makes sure a GraphQL operation is stopped before
+// the resolver timeout and before the server stops writing the response,
+// so the client receives the partial result of the operation.
+func checkExecutionTimeout(cfg *Server) {
+	if cfg.ExecutionTimeout <= 0 {
+		return
+	}
+
+	if cfg.ResolverTimeout > 0 && cfg.ExecutionTimeout > cfg.ResolverTimeout {
+		cfg.ExecutionTimeout = cfg.ResolverTimeout
+		log.Printf("execution timeout lowered to %ds to fit into the resolver timeout", cfg.ExecutionTimeout)
+	}
+
+	if cfg.WriteTimeout > 1 && cfg.ExecutionTimeout >= cfg.WriteTimeout {
+		cfg.ExecutionTimeout = cfg.WriteTimeout - 1
+		log.Printf("execution timeout lowered to %ds to fit into the server write timeout", cfg.ExecutionTimeout)
+	}
+}
+
+// nodeCallBudget calculates the max duration of a node call with all its retries
+// and the longest jittered backoff between them.
+func nodeCallBudget(cfg *Lachesis) time.Duration {
+	total := cfg.Timeout * time.Duration(cfg.Retries+1)
+	for attempt := 1; attempt <= cfg.Retries; attempt++ {
+		total += cfg.RetryDelay<<uint(attempt-1) + cfg.RetryDelay
+	}
+	return total
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -27,26 +28,46 @@ func NewAccount(acc *types.Account) *Account {
 }
 
 // Account resolves blockchain account by address.
-func (rs *rootResolver) Account(args struct{ Address common.Address }) (*Account, error) {
-	// simply pull the block by hash
-	acc, err := repository.R().Account(&args.Address)
+func (rs *rootResolver) Account(ctx context.Context, args struct{ Address common.Address }) (*Account, error) {
+	// screen the account, if the screening of queries is enabled
+	if err := repository.R().WithContext(ctx).ScreenQuery(&args.Address); err != nil {
+		return nil, complianceError(err)
+	}
+
+	// simply pull the account by address
+	acc, err := batchLoad(ctx, "account/"+args.Address.String(), func() (interface{}, error) {
+		return repository.R().WithContext(ctx).Account(&args.Address)
+	})
 	if err != nil {
 		rs.log.Errorf("could not get the specified account")
 		return nil, err
 	}
-	return NewAccount(acc), nil
+	return NewAccount(acc.(*types.Account)), nil
+}
+
+// ResolveName resolves the address assigned to the given FNS domain name.
+func (rs *rootResolver) ResolveName(ctx context.Context, args struct{ Name string }) (*common.Address, error) {
+	return repository.R().WithContext(ctx).ResolveName(args.Name)
 }
 
 // AccountsActive resolves total number of active accounts on the blockchain.
-func (rs *rootResolver) AccountsActive() (hexutil.Uint64, error) {
-	return repository.R().AccountsActive()
+func (rs *rootResolver) AccountsActive(ctx context.Context) (hexutil.Uint64, error) {
+	return repository.R().WithContext(ctx).AccountsActive()
+}
+
+// Interfaces resolves the list of interfaces detected on a contract account.
+func (acc *Account) Interfaces() []string {
+	if acc.Account.Interfaces == nil {
+		return []string{}
+	}
+	return acc.Account.Interfaces
 }
 
 // Balance resolves total balance of the account.
-func (acc *Account) Balance() (hexutil.Big, error) {
+func (acc *Account) Balance(ctx context.Context) (hexutil.Big, error) {
 	// get the balance
 	val, err, _ := acc.cg.Do("balance", func() (interface{}, error) {
-		return repository.R().AccountBalance(&acc.Address)
+		return repository.R().WithContext(ctx).AccountBalance(&acc.Address)
 	})
 
 	// can not get the balance?
@@ -56,29 +77,48 @@ func (acc *Account) Balance() (hexutil.Big, error) {
 	return *val.(*hexutil.Big), nil
 }
 
-// TotalValue resolves account total value including delegated amount and pending rewards.
-func (acc *Account) TotalValue() (hexutil.Big, error) {
+// WftmBalance resolves the balance of the account in the wrapped FTM token.
+func (acc *Account) WftmBalance(ctx context.Context) (hexutil.Big, error) {
+	val, err, _ := acc.cg.Do("wftm", func() (interface{}, error) {
+		wftm := repository.R().WithContext(ctx).WrappedNativeAddress()
+		bal, err := repository.R().WithContext(ctx).Erc20BalanceOf(&wftm, &acc.Address)
+		return &bal, err
+	})
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return *val.(*hexutil.Big), nil
+}
+
+// TotalValue resolves account total value including wrapped FTM, delegated amount and pending rewards.
+func (acc *Account) TotalValue(ctx context.Context) (hexutil.Big, error) {
 	// get the balance
-	balance, err := acc.Balance()
+	balance, err := acc.Balance(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+
+	// the wrapped FTM is the same value as the native one
+	wrapped, err := acc.WftmBalance(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
 
 	// try to pull the delegations details
-	delegated, rewards, err := acc.delegationsTotal()
+	delegated, rewards, err := acc.delegationsTotal(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
 
 	// calc the sum
-	val := new(big.Int).Add(new(big.Int).Add(balance.ToInt(), delegated), rewards)
+	val := new(big.Int).Add(new(big.Int).Add(balance.ToInt(), wrapped.ToInt()), new(big.Int).Add(delegated, rewards))
 	return hexutil.Big(*val), nil
 }
 
 // TxCount resolves the number of transaction sent by the account, also known as nonce.
-func (acc *Account) TxCount() (hexutil.Uint64, error) {
+func (acc *Account) TxCount(ctx context.Context) (hexutil.Uint64, error) {
 	// get the sender by address
-	bal, err := repository.R().AccountNonce(&acc.Address)
+	bal, err := repository.R().WithContext(ctx).AccountNonce(&acc.Address)
 	if err != nil {
 		return hexutil.Uint64(0), err
 	}
@@ -86,8 +126,47 @@ func (acc *Account) TxCount() (hexutil.Uint64, error) {
 	return *bal, nil
 }
 
+// Nonce resolves the number of transactions sent by the account. If pending
+// is requested, transactions waiting in the node transaction pool are included.
+func (acc *Account) Nonce(ctx context.Context, args struct{ Pending bool }) (hexutil.Uint64, error) {
+	// pick the right source based on the pending flag
+	var val *hexutil.Uint64
+	var err error
+	if args.Pending {
+		setCacheHint(ctx, 0)
+		val, err = repository.R().WithContext(ctx).AccountPendingNonce(&acc.Address)
+	} else {
+		val, err = repository.R().WithContext(ctx).AccountNonce(&acc.Address)
+	}
+
+	// any error?
+	if err != nil {
+		return hexutil.Uint64(0), err
+	}
+	return *val, nil
+}
+
+// PendingTransactions resolves the list of transactions sent by the account
+// waiting in the node transaction pool to be processed.
+func (acc *Account) PendingTransactions(ctx context.Context) ([]*Transaction, error) {
+	setCacheHint(ctx, 0)
+
+	// pull the list from repository
+	tl, err := repository.R().WithContext(ctx).AccountPendingTransactions(&acc.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to resolvable transactions
+	list := make([]*Transaction, len(tl))
+	for i, trx := range tl {
+		list[i] = NewTransaction(trx)
+	}
+	return list, nil
+}
+
 // TxList resolves list of transaction associated with the account.
-func (acc *Account) TxList(args struct {
+func (acc *Account) TxList(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 }) (*TransactionList, error) {
@@ -96,7 +175,7 @@ func (acc *Account) TxList(args struct {
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get the transaction hash list from repository
-	bl, err := repository.R().AccountTransactions(&acc.Address, (*string)(args.Cursor), args.Count)
+	bl, err := repository.R().WithContext(ctx).AccountTransactions(&acc.Address, (*string)(args.Cursor), args.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +184,7 @@ func (acc *Account) TxList(args struct {
 }
 
 // Erc20TxList resolves list of ERC20 transactions associated with the account.
-func (acc *Account) Erc20TxList(args struct {
+func (acc *Account) Erc20TxList(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 	Token  *common.Address
@@ -116,7 +195,7 @@ func (acc *Account) Erc20TxList(args struct {
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get the transaction hash list from repository
-	tl, err := repository.R().Erc20Transactions(args.Token, &acc.Address, types.Erc20TrxTypeByName(args.TxType), (*string)(args.Cursor), args.Count)
+	tl, err := repository.R().WithContext(ctx).Erc20Transactions(args.Token, &acc.Address, types.Erc20TrxTypeByName(args.TxType), (*string)(args.Cursor), args.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -125,9 +204,9 @@ func (acc *Account) Erc20TxList(args struct {
 }
 
 // Staker resolves the account staker detail, if the account is a staker.
-func (acc *Account) Staker() (*Staker, error) {
+func (acc *Account) Staker(ctx context.Context) (*Staker, error) {
 	// get the staker
-	st, err := repository.R().ValidatorByAddress(&acc.Address)
+	st, err := repository.R().WithContext(ctx).ValidatorByAddress(&acc.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -140,7 +219,7 @@ func (acc *Account) Staker() (*Staker, error) {
 }
 
 // Delegations resolves a list of account delegations, if the account is a delegator.
-func (acc *Account) Delegations(args *struct {
+func (acc *Account) Delegations(ctx context.Context, args *struct {
 	Cursor *Cursor
 	Count  int32
 }) (*DelegationList, error) {
@@ -149,7 +228,7 @@ func (acc *Account) Delegations(args *struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// pull the list
-	dl, err := repository.R().DelegationsByAddress(&acc.Address, (*string)(args.Cursor), args.Count)
+	dl, err := repository.R().WithContext(ctx).DelegationsByAddress(&acc.Address, (*string)(args.Cursor), args.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -158,16 +237,26 @@ func (acc *Account) Delegations(args *struct {
 	return NewDelegationList(dl), nil
 }
 
+// DomainName resolves the primary FNS domain name of the account, if any.
+func (acc *Account) DomainName(ctx context.Context) (*string, error) {
+	return repository.R().WithContext(ctx).DomainName(&acc.Address)
+}
+
+// Label resolves the public label of the account, if any.
+func (acc *Account) Label(ctx context.Context) (*AddressLabel, error) {
+	return addressLabel(ctx, &acc.Address)
+}
+
 // Contract resolves the account smart contract detail,
 // if the account is a smart contract address.
-func (acc *Account) Contract() (*Contract, error) {
+func (acc *Account) Contract(ctx context.Context) (*Contract, error) {
 	// is this actually a contract account?
 	if acc.ContractTx == nil {
 		return nil, nil
 	}
 
 	// get new contract
-	con, err := repository.R().Contract(&acc.Address)
+	con, err := repository.R().WithContext(ctx).Contract(&acc.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -176,9 +265,9 @@ func (acc *Account) Contract() (*Contract, error) {
 
 // delegationsTotal calculates total sum of delegations of the given account including
 // pending rewards for those delegations.
-func (acc *Account) delegationsTotal() (amount *big.Int, rewards *big.Int, err error) {
+func (acc *Account) delegationsTotal(ctx context.Context) (amount *big.Int, rewards *big.Int, err error) {
 	// pull all the delegations of the account
-	list, err := repository.R().DelegationsByAddressAll(&acc.Address)
+	list, err := repository.R().WithContext(ctx).DelegationsByAddressAll(&acc.Address)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -193,7 +282,7 @@ func (acc *Account) delegationsTotal() (amount *big.Int, rewards *big.Int, err e
 		}
 
 		// get pending rewards for this delegation (can be stashed)
-		rw, err := repository.R().PendingRewards(&acc.Address, dlg.ToStakerId)
+		rw, err := repository.R().WithContext(ctx).PendingRewards(&acc.Address, dlg.ToStakerId)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -0,0 +1,175 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// Admin represents resolvable namespace of privileged API operations.
+// The same structure resolves both the admin queries and mutations.
+type Admin struct {
+	rs *rootResolver
+}
+
+// LogLevel represents resolvable logging level of a logging module.
+type LogLevel struct {
+	Module string
+	Level  string
+}
+
+// ServiceState represents resolvable state of an internal API service.
+type ServiceState struct {
+	types.ServiceState
+}
+
+// Admin resolves the namespace of privileged operations. The namespace
+// is available only to clients with a privileged API key.
+func (rs *rootResolver) Admin(ctx context.Context) (*Admin, error) {
+	if !rs.isAdmin(ctx) {
+		return nil, ErrAccessDenied
+	}
+	return &Admin{rs: rs}, nil
+}
+
+// Services resolves the current state of internal services of the API server.
+func (adm *Admin) Services(ctx context.Context) []*ServiceState {
+	// get the list
+	list := repository.R().WithContext(ctx).ServiceStates()
+
+	// make the resolvable list
+	res := make([]*ServiceState, len(list))
+	for i, st := range list {
+		res[i] = &ServiceState{ServiceState: st}
+	}
+	return res
+}
+
+// LogLevels resolves the current logging level of each logging module.
+func (adm *Admin) LogLevels() []*LogLevel {
+	list := logger.Modules()
+	res := make([]*LogLevel, len(list))
+	for i, m := range list {
+		res[i] = &LogLevel{Module: m, Level: logger.Level(m)}
+	}
+	return res
+}
+
+// PurgeCache removes the entry with the given key from the in-memory cache.
+func (adm *Admin) PurgeCache(ctx context.Context, args *struct{ Key string }) bool {
+	adm.rs.log.Noticef("cache entry %s purge requested", args.Key)
+	return repository.R().WithContext(ctx).PurgeCache(args.Key)
+}
+
+// UpdateTrxFlow forces the update of the aggregated transaction flow.
+func (adm *Admin) UpdateTrxFlow(ctx context.Context) bool {
+	adm.rs.log.Notice("trx flow update requested")
+	repository.R().WithContext(ctx).TrxFlowUpdate()
+	return true
+}
+
+// RevalidateContract re-runs the validation of a previously validated contract
+// against its stored source code.
+func (adm *Admin) RevalidateContract(ctx context.Context, args *struct{ Address common.Address }) (*Contract, error) {
+	// get the contract
+	sc, err := repository.R().WithContext(ctx).Contract(&args.Address)
+	if err != nil {
+		adm.rs.log.Errorf("contract [%s] not found", args.Address.String())
+		return nil, err
+	}
+
+	// we need the source code to re-validate
+	if len(sc.SourceCode) == 0 {
+		return nil, errNotFound("contract %s source code is not known", args.Address.String())
+	}
+
+	// do the validation
+	adm.rs.log.Noticef("contract %s re-validation requested", args.Address.String())
+	if err := repository.R().WithContext(ctx).ValidateContract(sc); err != nil {
+		adm.rs.log.Errorf("contract re-validation failed; %s", err.Error())
+		return nil, err
+	}
+	return NewContract(sc), nil
+}
+
+// SetMaintenance switches the maintenance mode of the API server on, or off.
+func (adm *Admin) SetMaintenance(args *struct{ Enabled bool }) bool {
+	adm.rs.setMaintenance(args.Enabled)
+	return args.Enabled
+}
+
+// SetLogLevel changes the logging level of the given logging module.
+func (adm *Admin) SetLogLevel(args *struct {
+	Module string
+	Level  string
+}) (string, error) {
+	if err := logger.SetLevel(args.Module, args.Level); err != nil {
+		return "", err
+	}
+
+	adm.rs.log.Noticef("logging level of %s set to %s", args.Module, args.Level)
+	return logger.Level(args.Module), nil
+}
+
+// PauseService pauses scheduled runs of a periodic service.
+func (adm *Admin) PauseService(ctx context.Context, args *struct{ Name string }) (bool, error) {
+	if err := repository.R().WithContext(ctx).PauseService(args.Name, true); err != nil {
+		return false, err
+	}
+
+	adm.rs.log.Noticef("service %s paused", args.Name)
+	return true, nil
+}
+
+// ResumeService resumes scheduled runs of a paused periodic service.
+func (adm *Admin) ResumeService(ctx context.Context, args *struct{ Name string }) (bool, error) {
+	if err := repository.R().WithContext(ctx).PauseService(args.Name, false); err != nil {
+		return false, err
+	}
+
+	adm.rs.log.Noticef("service %s resumed", args.Name)
+	return true, nil
+}
+
+// TriggerService runs a periodic service right away.
+func (adm *Admin) TriggerService(ctx context.Context, args *struct{ Name string }) (bool, error) {
+	if err := repository.R().WithContext(ctx).TriggerService(args.Name); err != nil {
+		return false, err
+	}
+
+	adm.rs.log.Noticef("service %s run triggered", args.Name)
+	return true, nil
+}
+
+// LastRun resolves the time stamp of the last run of a periodic service.
+func (st *ServiceState) LastRun() *hexutil.Uint64 {
+	if st.ServiceState.LastRun.IsZero() {
+		return nil
+	}
+	ts := hexutil.Uint64(st.ServiceState.LastRun.Unix())
+	return &ts
+}
+
+// QueueLength resolves the number of items waiting in the service queue.
+func (st *ServiceState) QueueLength() int32 {
+	return int32(st.ServiceState.QueueLength)
+}
+
+// QueueCapacity resolves the capacity of the service queue.
+func (st *ServiceState) QueueCapacity() int32 {
+	return int32(st.ServiceState.QueueCapacity)
+}
+
+// Period resolves the scheduling period of a periodic service in seconds.
+func (st *ServiceState) Period() *hexutil.Uint64 {
+	if st.ServiceState.Period == 0 {
+		return nil
+	}
+	sec := hexutil.Uint64(st.ServiceState.Period / time.Second)
+	return &sec
+}
@@ -0,0 +1,199 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// alertRulesMaxCount is the max number of alert rules of a single client.
+	alertRulesMaxCount = 25
+
+	// alertsMaxCount is the max number of alerts loaded at once.
+	alertsMaxCount = 100
+
+	// alertWebhookMaxLength is the max length of an alert rule webhook URL.
+	alertWebhookMaxLength = 256
+)
+
+// AlertRule represents resolvable alert rule of an API client.
+type AlertRule struct {
+	types.AlertRule
+}
+
+// Alert represents resolvable alert raised by an alert rule.
+type Alert struct {
+	types.Alert
+}
+
+// Webhook resolves the URL the alerts of the rule are posted to.
+func (ar *AlertRule) Webhook() *string {
+	if ar.AlertRule.Webhook == "" {
+		return nil
+	}
+	return &ar.AlertRule.Webhook
+}
+
+// Created resolves the time the rule was registered.
+func (ar *AlertRule) Created() hexutil.Uint64 {
+	return hexutil.Uint64(ar.AlertRule.Created.Unix())
+}
+
+// Block resolves the number of the last block the rule was evaluated on.
+func (ar *AlertRule) Block() hexutil.Uint64 {
+	return hexutil.Uint64(ar.AlertRule.Block)
+}
+
+// Block resolves the number of the block the alert was raised on.
+func (al *Alert) Block() hexutil.Uint64 {
+	return hexutil.Uint64(al.Alert.Block)
+}
+
+// Time resolves the time the alert was raised.
+func (al *Alert) Time() hexutil.Uint64 {
+	return hexutil.Uint64(al.Alert.Time.Unix())
+}
+
+// AlertRules resolves the alert rules of the API client.
+func (rs *rootResolver) AlertRules(ctx context.Context) ([]*AlertRule, error) {
+	owner, err := rs.clientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := repository.R().WithContext(ctx).AlertRules(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*AlertRule, len(rules))
+	for i, ar := range rules {
+		list[i] = &AlertRule{AlertRule: *ar}
+	}
+	return list, nil
+}
+
+// Alerts resolves the alerts of the API client raised since the given time.
+func (rs *rootResolver) Alerts(ctx context.Context, args *struct{ Since hexutil.Uint64 }) ([]*Alert, error) {
+	owner, err := rs.clientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	al, err := repository.R().WithContext(ctx).Alerts(owner, time.Unix(int64(args.Since), 0).UTC(), alertsMaxCount)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*Alert, len(al))
+	for i, a := range al {
+		list[i] = &Alert{Alert: *a}
+	}
+	return list, nil
+}
+
+// AddAlertRule registers a new alert rule of the API client.
+func (rs *rootResolver) AddAlertRule(ctx context.Context, args *struct {
+	Type      string
+	Address   common.Address
+	Threshold *hexutil.Big
+	Topic     *common.Hash
+	Webhook   *string
+}) (*AlertRule, error) {
+	owner, err := rs.clientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid()
+	if err != nil {
+		return nil, err
+	}
+
+	ar := types.AlertRule{
+		Id:      id,
+		Owner:   owner,
+		Type:    args.Type,
+		Address: args.Address,
+		Created: time.Now().UTC(),
+	}
+
+	// validate the condition of the rule
+	switch args.Type {
+	case types.AlertRuleBalanceBelow:
+		if args.Threshold == nil || args.Threshold.ToInt().Sign() <= 0 {
+			return nil, errInvalidArgument("positive threshold required for %s rule", args.Type)
+		}
+		ar.Threshold = args.Threshold
+	case types.AlertRuleEventEmitted:
+		ar.Topic = args.Topic
+	case types.AlertRuleOwnerChanged:
+	default:
+		return nil, errInvalidArgument("unknown alert rule type %s", args.Type)
+	}
+
+	if args.Webhook != nil {
+		ar.Webhook = strings.TrimSpace(*args.Webhook)
+		if err := validateWebhook(ar.Webhook); err != nil {
+			return nil, err
+		}
+	}
+
+	// check the number of rules
+	size, err := repository.R().WithContext(ctx).AlertRulesSize(owner)
+	if err != nil {
+		return nil, err
+	}
+	if size >= alertRulesMaxCount {
+		return nil, errInvalidArgument("too many alert rules, max %d rules allowed", alertRulesMaxCount)
+	}
+
+	if err := repository.R().WithContext(ctx).AddAlertRule(&ar); err != nil {
+		rs.log.Errorf("can not add alert rule for %s; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+	return &AlertRule{AlertRule: ar}, nil
+}
+
+// RemoveAlertRule removes the alert rule of the API client.
+func (rs *rootResolver) RemoveAlertRule(ctx context.Context, args *struct{ Id string }) (bool, error) {
+	owner, err := rs.clientId(ctx)
+	if err != nil {
+		return false, err
+	}
+	return repository.R().WithContext(ctx).RemoveAlertRule(owner, args.Id)
+}
+
+// validateWebhook checks the alert rule webhook is a valid HTTPS URL.
+func validateWebhook(hook string) error {
+	if hook == "" {
+		return nil
+	}
+	if len(hook) > alertWebhookMaxLength {
+		return errInvalidArgument("webhook too long, max %d characters allowed", alertWebhookMaxLength)
+	}
+
+	u, err := url.Parse(hook)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errInvalidArgument("webhook must be a valid https URL")
+	}
+
+	// names are checked on delivery, after they resolve; literal addresses right away
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return errInvalidArgument("webhook must not point to a local host")
+	}
+	if ip := net.ParseIP(host); ip != nil && !repository.IsPublicIP(ip) {
+		return errInvalidArgument("webhook must point to a public address")
+	}
+	return nil
+}
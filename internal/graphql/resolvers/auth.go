@@ -0,0 +1,81 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fantom-api-graphql/internal/config"
+)
+
+// ctxKey represents a key of a value stored in the request context.
+type ctxKey string
+
+// ctxKeyApiKey is the context key of the API key provided by the client.
+const ctxKeyApiKey ctxKey = "api_key"
+
+// ErrAccessDenied represents an error returned on privileged operation
+// requested without a valid API key.
+var ErrAccessDenied error = &ApiError{Code: ErrCodeAccessDenied, Message: "access denied, valid API key required"}
+
+// ContextWithApiKey creates a new request context carrying the given client API key.
+func ContextWithApiKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, ctxKeyApiKey, key)
+}
+
+// apiKey extracts the client API key from the request context, if available.
+func apiKey(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	key, ok := ctx.Value(ctxKeyApiKey).(string)
+	if !ok {
+		return ""
+	}
+	return key
+}
+
+// isAdmin checks if the request context carries an API key
+// authorized to execute privileged operations.
+func (rs *rootResolver) isAdmin(ctx context.Context) bool {
+	return IsAdminKey(&rs.cfg.Auth, apiKey(ctx))
+}
+
+// clientId provides the identifier of the registered API client the request context
+// belongs to. Admin keys are accepted as well. The key itself is never stored,
+// the identifier is derived from it.
+func (rs *rootResolver) clientId(ctx context.Context) (string, error) {
+	id, ok := ClientId(&rs.cfg.Auth, apiKey(ctx))
+	if !ok {
+		return "", ErrAccessDenied
+	}
+	return id, nil
+}
+
+// IsAdminKey checks if the given API key is authorized to execute privileged operations.
+func IsAdminKey(cfg *config.Auth, key string) bool {
+	return key != "" && hasKey(cfg.AdminKeys, key)
+}
+
+// ClientId derives the identifier of the registered API client from the given API key.
+// Admin keys are accepted as well; false is returned for unknown keys.
+func ClientId(cfg *config.Auth, key string) (string, bool) {
+	if key == "" || (!hasKey(cfg.ClientKeys, key) && !hasKey(cfg.AdminKeys, key)) {
+		return "", false
+	}
+
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:]), true
+}
+
+// hasKey checks if the given key is in the list of configured keys.
+func hasKey(list []string, key string) bool {
+	for _, k := range list {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			return true
+		}
+	}
+	return false
+}
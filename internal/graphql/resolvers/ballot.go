@@ -0,0 +1,106 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// Ballot represents resolvable community ballot.
+type Ballot struct {
+	types.Ballot
+}
+
+// BallotProposal represents resolvable option of a community ballot.
+type BallotProposal struct {
+	types.BallotProposal
+	ballot common.Address
+	index  int32
+}
+
+// Ballots resolves the list of configured community ballots.
+func (rs *rootResolver) Ballots(ctx context.Context) ([]*Ballot, error) {
+	bl, err := repository.R().WithContext(ctx).Ballots()
+	if err != nil {
+		rs.log.Errorf("can not load ballots; %s", err.Error())
+		return nil, err
+	}
+
+	list := make([]*Ballot, len(bl))
+	for i, bt := range bl {
+		list[i] = &Ballot{Ballot: *bt}
+	}
+	return list, nil
+}
+
+// Ballot resolves the community ballot on the given address.
+func (rs *rootResolver) Ballot(ctx context.Context, args *struct{ Address common.Address }) (*Ballot, error) {
+	bt, err := repository.R().WithContext(ctx).Ballot(&args.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &Ballot{Ballot: *bt}, nil
+}
+
+// IsOpen resolves the flag of the ballot being open for voting right now.
+func (bt Ballot) IsOpen() bool {
+	now := uint64(time.Now().UTC().Unix())
+	return !bt.Finalized && uint64(bt.Start) <= now && now < uint64(bt.End)
+}
+
+// IsFinalized resolves the flag of the ballot being finalized.
+func (bt Ballot) IsFinalized() bool {
+	return bt.Finalized
+}
+
+// Winner resolves the index of the winning proposal of a finalized ballot.
+func (bt Ballot) Winner() *int32 {
+	if !bt.Finalized {
+		return nil
+	}
+	w := int32(bt.Ballot.Winner)
+	return &w
+}
+
+// Proposals resolves the list of proposals of the ballot with their totals.
+func (bt Ballot) Proposals() []BallotProposal {
+	list := make([]BallotProposal, len(bt.Ballot.Proposals))
+	for i, p := range bt.Ballot.Proposals {
+		list[i] = BallotProposal{BallotProposal: p, ballot: bt.Address, index: int32(i)}
+	}
+	return list
+}
+
+// Vote resolves the index of the proposal the given address voted for, if any.
+func (bt Ballot) Vote(ctx context.Context, args struct{ Address common.Address }) (*int32, error) {
+	bv, err := repository.R().WithContext(ctx).BallotVote(&bt.Address, &args.Address)
+	if err != nil || bv == nil {
+		return nil, err
+	}
+	p := int32(bv.Proposal)
+	return &p, nil
+}
+
+// Voted resolves the flag of the given address having voted on the ballot.
+func (bt Ballot) Voted(ctx context.Context, args struct{ Address common.Address }) (bool, error) {
+	p, err := bt.Vote(ctx, args)
+	return p != nil, err
+}
+
+// Index resolves the index of the proposal in the ballot.
+func (bp BallotProposal) Index() int32 {
+	return bp.index
+}
+
+// Voters resolves the number of indexed voters of the proposal.
+func (bp BallotProposal) Voters(ctx context.Context) (hexutil.Uint64, error) {
+	vc, err := repository.R().WithContext(ctx).BallotVotersCount(&bp.ballot)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(vc[uint64(bp.index)]), nil
+}
@@ -0,0 +1,66 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"sync"
+)
+
+// ctxKeyBatchLoader is the context key of the loader shared by the operations of a batch.
+const ctxKeyBatchLoader ctxKey = "batch_loader"
+
+// BatchLoader shares objects loaded by resolvers between the operations of a batch request.
+// An object requested by several operations is loaded only once; concurrent requests
+// wait for the first one to finish. Failed loads are not remembered.
+type BatchLoader struct {
+	mu    sync.Mutex
+	calls map[string]*loaderCall
+}
+
+// loaderCall represents a single load of an object, either finished or in flight.
+type loaderCall struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+}
+
+// ContextWithBatchLoader creates a new batch context sharing the loaded objects.
+func ContextWithBatchLoader(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyBatchLoader, &BatchLoader{calls: make(map[string]*loaderCall)})
+}
+
+// batchLoad provides the object of the given key loaded by the given function. The object
+// is shared with other operations of the batch, if the context belongs to a batch.
+func batchLoad(ctx context.Context, key string, load func() (interface{}, error)) (interface{}, error) {
+	bl, ok := ctx.Value(ctxKeyBatchLoader).(*BatchLoader)
+	if !ok {
+		return load()
+	}
+
+	bl.mu.Lock()
+	if c, ok := bl.calls[key]; ok {
+		bl.mu.Unlock()
+
+		select {
+		case <-c.done:
+			return c.val, c.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	c := &loaderCall{done: make(chan struct{})}
+	bl.calls[key] = c
+	bl.mu.Unlock()
+
+	c.val, c.err = load()
+
+	// failed loads can be retried by the following operations
+	if c.err != nil {
+		bl.mu.Lock()
+		delete(bl.calls, key)
+		bl.mu.Unlock()
+	}
+	close(c.done)
+	return c.val, c.err
+}
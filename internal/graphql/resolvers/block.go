@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -19,26 +20,50 @@ func NewBlock(blk *types.Block) *Block {
 }
 
 // Block resolves blockchain block by number or by hash. If neither is provided, the most recent block is given.
-func (rs *rootResolver) Block(args *struct {
+func (rs *rootResolver) Block(ctx context.Context, args *struct {
 	Number *hexutil.Uint64
 	Hash   *common.Hash
 }) (*Block, error) {
 	// do we have the number, or hash is not given?
 	if args.Number != nil || args.Hash == nil {
-		b, err := repository.R().BlockByNumber(args.Number)
-		return NewBlock(b), err
+		// the latest block changes with each new head
+		if args.Number == nil {
+			setCacheHint(ctx, 1)
+		}
+
+		key := "block/latest"
+		if args.Number != nil {
+			key = "block/" + args.Number.String()
+		}
+
+		b, err := batchLoad(ctx, key, func() (interface{}, error) {
+			return repository.R().WithContext(ctx).BlockByNumber(args.Number)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return NewBlock(b.(*types.Block)), nil
 	}
 
 	// simply pull the block by hash
-	b, err := repository.R().BlockByHash(args.Hash)
-	return NewBlock(b), err
+	return blockByHash(ctx, args.Hash)
 }
 
 // Parent resolves parent block information to the given block.
-func (blk *Block) Parent() (*Block, error) {
+func (blk *Block) Parent(ctx context.Context) (*Block, error) {
 	// get the parent block by hash
-	parent, err := repository.R().BlockByHash(&blk.ParentHash)
-	return NewBlock(parent), err
+	return blockByHash(ctx, &blk.ParentHash)
+}
+
+// blockByHash loads the block of the given hash, shared within the batch.
+func blockByHash(ctx context.Context, hash *common.Hash) (*Block, error) {
+	b, err := batchLoad(ctx, "block/"+hash.String(), func() (interface{}, error) {
+		return repository.R().WithContext(ctx).BlockByHash(hash)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewBlock(b.(*types.Block)), nil
 }
 
 // TxHashList resolves list of hashes of transaction bundled in the block.
@@ -52,13 +77,13 @@ func (blk *Block) TxHashList() []common.Hash {
 }
 
 // TxList resolves list of transaction details of the transactions bundled in the block.
-func (blk *Block) TxList() ([]*Transaction, error) {
+func (blk *Block) TxList(ctx context.Context) ([]*Transaction, error) {
 	// make the container
 	txs := make([]*Transaction, len(blk.Txs))
 
 	// loop the hashes and extract transactions
 	for i, hash := range blk.Txs {
-		trx, err := repository.R().Transaction(hash)
+		trx, err := repository.R().WithContext(ctx).Transaction(hash)
 		if err != nil {
 			return nil, err
 		}
@@ -2,11 +2,16 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
+// blockRangeMaxSpan is the max number of blocks resolved by a single block range query.
+const blockRangeMaxSpan = 100
+
 // BlockList represents resolvable list of blockchain block edges structure.
 type BlockList struct {
 	list       *types.BlockList
@@ -28,22 +33,27 @@ func NewBlockList(blocks *types.BlockList, totalCount *hexutil.Big) *BlockList {
 }
 
 // Blocks resolves list of blockchain blocks encapsulated in a listable structure.
-func (rs *rootResolver) Blocks(args *struct {
+func (rs *rootResolver) Blocks(ctx context.Context, args *struct {
 	Cursor *Cursor
 	Count  int32
 }) (*BlockList, error) {
 	// do we have a cursor? try to decode it into an actual block number
 	var num *uint64
 	if args.Cursor != nil {
-		val, err := hexutil.DecodeUint64(string(*args.Cursor))
-		if err != nil {
-			rs.log.Errorf("invalid block cursor [%s]; %s", args.Cursor, err.Error())
+		val, _, ok := types.DecodePositionCursor(string(*args.Cursor))
+		if !ok {
+			// legacy cursor is the hex block number
+			var err error
+			if val, err = hexutil.DecodeUint64(string(*args.Cursor)); err != nil {
+				rs.log.Errorf("invalid block cursor [%s]; %s", *args.Cursor, err.Error())
+				return nil, errInvalidArgument("invalid block cursor")
+			}
 		}
 		num = &val
 	}
 
 	// get the first block so we know the total
-	bh, err := repository.R().BlockHeight()
+	bh, err := repository.R().WithContext(ctx).BlockHeight()
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +63,7 @@ func (rs *rootResolver) Blocks(args *struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the block list from repository
-	bl, err := repository.R().Blocks(num, args.Count)
+	bl, err := repository.R().WithContext(ctx).Blocks(num, args.Count)
 	if err != nil {
 		rs.log.Errorf("can not get blocks list; %s", err.Error())
 		return nil, err
@@ -62,6 +72,44 @@ func (rs *rootResolver) Blocks(args *struct {
 	return NewBlockList(bl, bh), nil
 }
 
+// BlockRange resolves blocks between the given block numbers in ascending order.
+func (rs *rootResolver) BlockRange(ctx context.Context, args *struct {
+	From hexutil.Uint64
+	To   hexutil.Uint64
+}) ([]*Block, error) {
+	// check the range
+	if args.To < args.From {
+		return nil, errInvalidArgument("invalid block range")
+	}
+	if uint64(args.To-args.From) >= uint64(blockRangeMaxSpan) {
+		return nil, errInvalidArgument("block range exceeds %d blocks", blockRangeMaxSpan)
+	}
+
+	bl, err := repository.R().WithContext(ctx).BlocksRange(uint64(args.From), uint64(args.To))
+	if err != nil {
+		rs.log.Errorf("can not get blocks range; %s", err.Error())
+		return nil, err
+	}
+
+	list := make([]*Block, len(bl))
+	for i, b := range bl {
+		list[i] = NewBlock(b)
+	}
+	return list, nil
+}
+
+// AccountHasActivityIn resolves if the account may have any activity in the given range of blocks.
+func (rs *rootResolver) AccountHasActivityIn(ctx context.Context, args *struct {
+	Address   common.Address
+	FromBlock hexutil.Uint64
+	ToBlock   hexutil.Uint64
+}) (bool, error) {
+	if args.ToBlock < args.FromBlock {
+		return false, errInvalidArgument("invalid block range")
+	}
+	return repository.R().WithContext(ctx).AccountHasActivityIn(&args.Address, uint64(args.FromBlock), uint64(args.ToBlock))
+}
+
 // PageInfo resolves the current page information for the blocks list.
 func (bl *BlockList) PageInfo() (*ListPageInfo, error) {
 	// do we have any items?
@@ -70,8 +118,8 @@ func (bl *BlockList) PageInfo() (*ListPageInfo, error) {
 	}
 
 	// get the first and last elements
-	first := Cursor(bl.list.Collection[0].Number.String())
-	last := Cursor(bl.list.Collection[len(bl.list.Collection)-1].Number.String())
+	first := Cursor(types.PositionCursor(uint64(bl.list.Collection[0].Number), 0))
+	last := Cursor(types.PositionCursor(uint64(bl.list.Collection[len(bl.list.Collection)-1].Number), 0))
 	return NewListPageInfo(&first, &last, !bl.list.IsEnd, !bl.list.IsStart)
 }
 
@@ -88,7 +136,7 @@ func (bl *BlockList) Edges() []*BlockListEdge {
 		// make the element
 		edge := BlockListEdge{
 			Block:  NewBlock(b),
-			Cursor: Cursor(b.Number.String()),
+			Cursor: Cursor(types.PositionCursor(uint64(b.Number), 0)),
 		}
 
 		// add it to the list
@@ -0,0 +1,51 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"sync"
+)
+
+// ctxKeyCacheHint is the context key of the dynamic cache hint of the request.
+const ctxKeyCacheHint ctxKey = "cache_hint"
+
+// CacheHint collects dynamic cache hints set by resolvers during the request processing.
+// Resolvers may only lower the max age derived from the schema annotations.
+type CacheHint struct {
+	mu     sync.Mutex
+	maxAge int32
+	set    bool
+}
+
+// ContextWithCacheHint creates a new request context collecting dynamic cache hints.
+func ContextWithCacheHint(ctx context.Context) (context.Context, *CacheHint) {
+	ch := new(CacheHint)
+	return context.WithValue(ctx, ctxKeyCacheHint, ch), ch
+}
+
+// MaxAge provides the lowest max age hinted by the resolvers; the second value
+// signals if any hint has been set at all.
+func (ch *CacheHint) MaxAge() (int32, bool) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	return ch.maxAge, ch.set
+}
+
+// setCacheHint lowers the max age of the request response to the given number of seconds.
+func setCacheHint(ctx context.Context, maxAge int32) {
+	if ctx == nil {
+		return
+	}
+
+	ch, ok := ctx.Value(ctxKeyCacheHint).(*CacheHint)
+	if !ok {
+		return
+	}
+
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	if !ch.set || maxAge < ch.maxAge {
+		ch.maxAge = maxAge
+		ch.set = true
+	}
+}
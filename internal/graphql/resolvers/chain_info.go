@@ -0,0 +1,37 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ChainInfo represents resolvable identification and capabilities of the chain.
+type ChainInfo struct {
+	types.ChainInfo
+}
+
+// ChainInfo resolves the identification and capabilities of the chain and the connected node.
+func (rs *rootResolver) ChainInfo(ctx context.Context) (*ChainInfo, error) {
+	ci, err := repository.R().WithContext(ctx).ChainInfo()
+	if err != nil {
+		return nil, err
+	}
+	return &ChainInfo{ChainInfo: *ci}, nil
+}
+
+// ChainId resolves the identifier of the chain used to sign transactions.
+func (ci *ChainInfo) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(ci.ChainInfo.ChainId)
+}
+
+// Network resolves the name of the network, if known.
+func (ci *ChainInfo) Network() *string {
+	if ci.ChainInfo.Network == "" {
+		return nil
+	}
+	return &ci.ChainInfo.Network
+}
@@ -0,0 +1,96 @@
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// compoundingMaxPeriodDays is the longest period of the compounding estimation in days.
+const compoundingMaxPeriodDays = 3650
+
+// CompoundingEstimation represents resolvable projection of a delegation balance
+// with rewards periodically re-staked at the current reward rate.
+type CompoundingEstimation struct {
+	Staked           hexutil.Big
+	PeriodDays       int32
+	RestakeFrequency int32
+	Restakes         int32
+	ProjectedBalance hexutil.Big
+	Rewards          hexutil.Big
+	SimpleRewards    hexutil.Big
+}
+
+// EstimateCompounding resolves the projected balance of the delegation of the address
+// to the staker with rewards re-staked every <restakeFrequency> days.
+// The rewards are estimated the same way the EstimateRewards does, e.g. at the current
+// base reward rate of the last sealed epoch and the current total staked amount.
+func (rs *rootResolver) EstimateCompounding(ctx context.Context, args *struct {
+	Address          common.Address
+	Staker           hexutil.Big
+	PeriodDays       int32
+	RestakeFrequency int32
+}) (*CompoundingEstimation, error) {
+	// validate the parameters
+	if args.PeriodDays < 1 || args.PeriodDays > compoundingMaxPeriodDays {
+		return nil, errInvalidArgument("period must be between 1 and %d days", compoundingMaxPeriodDays)
+	}
+	if args.RestakeFrequency < 1 || args.RestakeFrequency > args.PeriodDays {
+		return nil, errInvalidArgument("restake frequency must be between 1 day and the period")
+	}
+
+	// get the delegation
+	dl, err := repository.R().WithContext(ctx).Delegation(&args.Address, &args.Staker)
+	if err != nil {
+		return nil, err
+	}
+	if dl.AmountDelegated == nil || dl.AmountDelegated.ToInt().Sign() <= 0 {
+		return nil, errNotFound("delegation %s to #%d has no active amount", args.Address.String(), args.Staker.ToInt().Uint64())
+	}
+
+	// get the reward rate base
+	ep, err := repository.R().WithContext(ctx).CurrentSealedEpoch()
+	if err != nil {
+		rs.log.Errorf("can not get the current sealed epoch information; %s", err.Error())
+		return nil, fmt.Errorf("current sealed epoch not found")
+	}
+	total, err := repository.R().WithContext(ctx).TotalStaked()
+	if err != nil {
+		rs.log.Errorf("can not get the current total staked amount; %s", err.Error())
+		return nil, fmt.Errorf("current total staked amount not found")
+	}
+	if total.ToInt().Sign() <= 0 {
+		return nil, fmt.Errorf("current total staked amount not available")
+	}
+
+	est := CompoundingEstimation{
+		Staked:           *dl.AmountDelegated,
+		PeriodDays:       args.PeriodDays,
+		RestakeFrequency: args.RestakeFrequency,
+		Restakes:         args.PeriodDays / args.RestakeFrequency,
+	}
+
+	// reward of the given balance for the given number of days
+	reward := func(balance *big.Int, days int32) *big.Int {
+		val := new(big.Int).Mul(balance, ep.BaseRewardPerSecond.ToInt())
+		val.Mul(val, new(big.Int).SetUint64(uint64(days)*erwSecondsInDay))
+		return val.Div(val, total.ToInt())
+	}
+
+	// re-stake rewards of each full re-stake period; the rest is collected, but not re-staked
+	balance := new(big.Int).Set(dl.AmountDelegated.ToInt())
+	for i := int32(0); i < est.Restakes; i++ {
+		balance.Add(balance, reward(balance, args.RestakeFrequency))
+	}
+	if rest := args.PeriodDays % args.RestakeFrequency; rest > 0 {
+		balance.Add(balance, reward(balance, rest))
+	}
+
+	est.ProjectedBalance = hexutil.Big(*balance)
+	est.Rewards = hexutil.Big(*new(big.Int).Sub(balance, dl.AmountDelegated.ToInt()))
+	est.SimpleRewards = hexutil.Big(*reward(dl.AmountDelegated.ToInt(), args.PeriodDays))
+	return &est, nil
+}
@@ -2,11 +2,12 @@
 package resolvers
 
 import (
+	"context"
 	"crypto/sha256"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
-	"fmt"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"html"
 	"regexp"
 )
@@ -25,6 +26,10 @@ const (
 	// scMaxSupportLinkLength is the maximum accepted length of smart contract
 	// support link.
 	scMaxSupportLinkLength = 64
+
+	// contractsSameCodeMaxCount is the max number of contracts with identical byte code
+	// provided in a single query.
+	contractsSameCodeMaxCount = 500
 )
 
 // scVersionSyntaxRegexp represents a regular expression for testing smart contract
@@ -77,9 +82,75 @@ func NewContract(con *types.Contract) *Contract {
 	return &Contract{Contract: *con}
 }
 
+// VerificationLevel resolves the byte code match level of the validated contract.
+func (con *Contract) VerificationLevel() *string {
+	if con.MatchLevel == "" {
+		return nil
+	}
+	return &con.MatchLevel
+}
+
+// MetadataHash resolves the compiler metadata hash of the validated contract.
+func (con *Contract) MetadataHash() *hexutil.Bytes {
+	if con.Contract.MetadataHash == nil {
+		return nil
+	}
+	return &con.Contract.MetadataHash
+}
+
+// VerifiedFrom resolves the contract the validation was copied from, if any.
+func (con *Contract) VerifiedFrom(ctx context.Context) (*Contract, error) {
+	if con.Contract.VerifiedFrom == nil {
+		return nil, nil
+	}
+
+	sc, err := repository.R().WithContext(ctx).Contract(con.Contract.VerifiedFrom)
+	if err != nil || sc == nil {
+		return nil, err
+	}
+	return NewContract(sc), nil
+}
+
+// Artifact resolves the compilation artifact of the validated contract, if any.
+func (con *Contract) Artifact(ctx context.Context) (*ContractArtifact, error) {
+	ca, err := repository.R().WithContext(ctx).ContractArtifact(&con.Address)
+	if err != nil || ca == nil {
+		return nil, err
+	}
+	return &ContractArtifact{ContractArtifact: *ca}, nil
+}
+
+// Label resolves the public label of the contract, if any.
+func (con *Contract) Label(ctx context.Context) (*AddressLabel, error) {
+	return addressLabel(ctx, &con.Address)
+}
+
+// RiskLevel resolves the moderation risk level of the contract.
+func (con *Contract) RiskLevel(ctx context.Context) (string, error) {
+	return repository.R().WithContext(ctx).RiskLevel(&con.Address)
+}
+
+// Type resolves the type of the contract detected by the contract classifier.
+func (con *Contract) Type(ctx context.Context) (string, error) {
+	acc, err := repository.R().WithContext(ctx).Account(&con.Address)
+	if err != nil {
+		return "", err
+	}
+	return acc.Type, nil
+}
+
+// Interfaces resolves the list of interfaces detected on the contract.
+func (con *Contract) Interfaces(ctx context.Context) ([]string, error) {
+	acc, err := repository.R().WithContext(ctx).Account(&con.Address)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccount(acc).Interfaces(), nil
+}
+
 // DeployedBy resolves the deployment transaction of the contract.
-func (con *Contract) DeployedBy() (*Transaction, error) {
-	tr, err := repository.R().Transaction(&con.TransactionHash)
+func (con *Contract) DeployedBy(ctx context.Context) (*Transaction, error) {
+	tr, err := repository.R().WithContext(ctx).Transaction(&con.TransactionHash)
 	return NewTransaction(tr), err
 }
 
@@ -105,7 +176,7 @@ func sanitizeStringOption(o *string, length int) (bool, *string) {
 func isValidationValid(in *ContractValidationInput) error {
 	// source code must be at least defined number of glyphs long
 	if len(in.SourceCode) < scMinSourceCodeLength {
-		return fmt.Errorf("contract source code is too short to be valid")
+		return errInvalidArgument("contract source code is too short to be valid")
 	}
 
 	// collect sanitize result
@@ -113,27 +184,27 @@ func isValidationValid(in *ContractValidationInput) error {
 
 	// check the name of the contract
 	if res, in.Name = sanitizeStringOption(in.Name, scMaxNameLength); !res {
-		return fmt.Errorf("contract name is too long to be valid")
+		return errInvalidArgument("contract name is too long to be valid")
 	}
 
 	// check the version of the contract
 	if res, in.Version = sanitizeStringOption(in.Version, scMaxVersionLength); !res {
-		return fmt.Errorf("contract version is too long to be valid")
+		return errInvalidArgument("contract version is too long to be valid")
 	}
 
 	// check the contact information of the contract
 	if res, in.SupportContact = sanitizeStringOption(in.SupportContact, scMaxSupportLinkLength); !res {
-		return fmt.Errorf("contract contact information is too long to be valid")
+		return errInvalidArgument("contract contact information is too long to be valid")
 	}
 
 	// validate the version syntax
 	if in.Version != nil && !scVersionSyntaxRegexp.MatchString(*in.Version) {
-		return fmt.Errorf("invalid version information provided")
+		return errInvalidArgument("invalid version information provided")
 	}
 
 	// validate the version syntax
 	if in.OptimizeRuns < 0 {
-		return fmt.Errorf("invalid number of optimization runs provided")
+		return errInvalidArgument("invalid number of optimization runs provided")
 	}
 
 	return nil
@@ -178,7 +249,12 @@ func updateContractFromInput(con *ContractValidationInput, sc *types.Contract) {
 // ValidateContract resolves smart contract source code vs. deployed byte code and marks
 // the contract as validated if the match is found. Peer API points are ringed on success
 // to notify them about the change.
-func (rs *rootResolver) ValidateContract(args *struct{ Contract ContractValidationInput }) (*Contract, error) {
+func (rs *rootResolver) ValidateContract(ctx context.Context, args *struct{ Contract ContractValidationInput }) (*Contract, error) {
+	// no validations during maintenance
+	if rs.InMaintenance() {
+		return nil, ErrMaintenance
+	}
+
 	// validate the input
 	if err := isValidationValid(&args.Contract); err != nil {
 		rs.log.Errorf("can not validate contract, validation request is not valid; %s", err.Error())
@@ -186,7 +262,7 @@ func (rs *rootResolver) ValidateContract(args *struct{ Contract ContractValidati
 	}
 
 	// get a contract to be validated if any
-	sc, err := repository.R().Contract(&args.Contract.Address)
+	sc, err := repository.R().WithContext(ctx).Contract(&args.Contract.Address)
 	if err != nil {
 		rs.log.Errorf("contract [%s] not found", args.Contract.Address.String())
 		return nil, err
@@ -204,7 +280,7 @@ func (rs *rootResolver) ValidateContract(args *struct{ Contract ContractValidati
 	updateContractFromInput(&args.Contract, sc)
 
 	// do the validation
-	if err := repository.R().ValidateContract(sc); err != nil {
+	if err := repository.R().WithContext(ctx).ValidateContract(sc); err != nil {
 		rs.log.Errorf("contract validation failed; %s", err.Error())
 		return nil, err
 	}
@@ -216,3 +292,42 @@ func (rs *rootResolver) ValidateContract(args *struct{ Contract ContractValidati
 	// return the final updated contract
 	return NewContract(sc), nil
 }
+
+// ContractsWithSameBytecode resolves contracts with the runtime byte code identical to the given contract.
+func (rs *rootResolver) ContractsWithSameBytecode(ctx context.Context, args *struct {
+	Address common.Address
+	Count   int32
+}) ([]*Contract, error) {
+	// limit the count
+	count := args.Count
+	if count <= 0 || count > contractsSameCodeMaxCount {
+		count = contractsSameCodeMaxCount
+	}
+
+	list, err := repository.R().WithContext(ctx).ContractsWithSameBytecode(&args.Address, int64(count))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Contract, len(list))
+	for i, sc := range list {
+		res[i] = NewContract(sc)
+	}
+	return res, nil
+}
+
+// PropagateContractValidation copies the validation of the given contract
+// to not validated contracts with identical runtime byte code.
+func (rs *rootResolver) PropagateContractValidation(ctx context.Context, args *struct{ Address common.Address }) (int32, error) {
+	// no validations during maintenance
+	if rs.InMaintenance() {
+		return 0, ErrMaintenance
+	}
+
+	count, err := repository.R().WithContext(ctx).PropagateContractValidation(&args.Address)
+	if err != nil {
+		rs.log.Errorf("contract validation propagation failed; %s", err.Error())
+		return 0, err
+	}
+	return int32(count), nil
+}
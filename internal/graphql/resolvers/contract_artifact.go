@@ -0,0 +1,9 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import "fantom-api-graphql/internal/types"
+
+// ContractArtifact represents resolvable compilation artifact of a validated contract.
+type ContractArtifact struct {
+	types.ContractArtifact
+}
@@ -0,0 +1,84 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// contractsCreatedByMaxCount is the max number of contract creations provided in a single query.
+const contractsCreatedByMaxCount = 500
+
+// ContractCreation represents resolvable contract deployed by another contract.
+type ContractCreation struct {
+	types.ContractCreation
+}
+
+// ContractsCreatedBy resolves the most recent contracts deployed by the given contract.
+func (rs *rootResolver) ContractsCreatedBy(ctx context.Context, args *struct {
+	Address common.Address
+	Count   int32
+}) ([]*ContractCreation, error) {
+	// limit the count
+	count := args.Count
+	if count <= 0 || count > contractsCreatedByMaxCount {
+		count = contractsCreatedByMaxCount
+	}
+
+	list, err := repository.R().WithContext(ctx).ContractCreationsBy(&args.Address, int64(count))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ContractCreation, len(list))
+	for i, cc := range list {
+		res[i] = &ContractCreation{ContractCreation: *cc}
+	}
+	return res, nil
+}
+
+// PredictCreate2Address resolves the address of a contract deployed by CREATE2
+// from the given deployer, salt and the hash of the contract init code.
+func (rs *rootResolver) PredictCreate2Address(args *struct {
+	Deployer     common.Address
+	Salt         common.Hash
+	InitCodeHash common.Hash
+}) common.Address {
+	return crypto.CreateAddress2(args.Deployer, args.Salt, args.InitCodeHash.Bytes())
+}
+
+// Creation resolves the deployment detail of the contract, if deployed by another contract.
+func (con *Contract) Creation(ctx context.Context) (*ContractCreation, error) {
+	cc, err := repository.R().WithContext(ctx).ContractCreation(&con.Address)
+	if err != nil || cc == nil {
+		return nil, err
+	}
+	return &ContractCreation{ContractCreation: *cc}, nil
+}
+
+// Transaction resolves the transaction the contract was deployed in.
+func (cc *ContractCreation) Transaction(ctx context.Context) (*Transaction, error) {
+	trx, err := repository.R().WithContext(ctx).Transaction(&cc.ContractCreation.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransaction(trx), nil
+}
+
+// Timestamp resolves the unix timestamp of the deployment.
+func (cc *ContractCreation) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(cc.TimeStamp.Unix())
+}
+
+// Contract resolves the deployed contract detail, if known.
+func (cc *ContractCreation) Contract(ctx context.Context) (*Contract, error) {
+	con, err := repository.R().WithContext(ctx).Contract(&cc.Address)
+	if err != nil || con == nil {
+		return nil, err
+	}
+	return NewContract(con), nil
+}
@@ -0,0 +1,207 @@
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// contractEventStatsMaxDays is the max number of days of contract event statistics.
+	contractEventStatsMaxDays = 365
+
+	// contractTopEventsMaxLimit is the max number of top events of a contract.
+	contractTopEventsMaxLimit = 100
+)
+
+// ContractEvent represents resolvable event emitted by a contract.
+type ContractEvent struct {
+	types.ContractEvent
+	abi *abi.ABI
+}
+
+// ContractEventStat represents resolvable daily counter of events of a contract.
+type ContractEventStat struct {
+	types.ContractEventStat
+	abi *abi.ABI
+}
+
+// ContractEventSummary represents resolvable total number of events
+// of a signature emitted by a contract.
+type ContractEventSummary struct {
+	Topic common.Hash
+	Count hexutil.Uint64
+	abi   *abi.ABI
+}
+
+// NewContractEvent creates new instance of resolvable contract event.
+// The ABI of the contract is used to resolve the event name, if available.
+func NewContractEvent(ev *types.ContractEvent, ab *abi.ABI) *ContractEvent {
+	return &ContractEvent{ContractEvent: *ev, abi: ab}
+}
+
+// Data resolves the non-indexed data of the event.
+func (ev *ContractEvent) Data() hexutil.Bytes {
+	return ev.ContractEvent.Data
+}
+
+// Name resolves the name of the event from the contract ABI, if available.
+func (ev *ContractEvent) Name() *string {
+	return eventName(ev.abi, ev.Topic())
+}
+
+// TrxHash resolves the hash of the transaction emitting the event.
+func (ev *ContractEvent) TrxHash() common.Hash {
+	return ev.Trx
+}
+
+// Transaction resolves the transaction emitting the event.
+func (ev *ContractEvent) Transaction(ctx context.Context) (*Transaction, error) {
+	trx, err := repository.R().WithContext(ctx).Transaction(&ev.Trx)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransaction(trx), nil
+}
+
+// BlockNumber resolves the number of the block the event was emitted in.
+func (ev *ContractEvent) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(ev.ContractEvent.BlockNumber)
+}
+
+// LogIndex resolves the index of the event log in the block.
+func (ev *ContractEvent) LogIndex() int32 {
+	return int32(ev.ContractEvent.LogIndex)
+}
+
+// Day resolves the day of the counter as the unix timestamp of the day start.
+func (st *ContractEventStat) Day() hexutil.Uint64 {
+	return hexutil.Uint64(st.ContractEventStat.Day.Unix())
+}
+
+// Count resolves the number of events of the day.
+func (st *ContractEventStat) Count() hexutil.Uint64 {
+	return hexutil.Uint64(st.ContractEventStat.Count)
+}
+
+// Name resolves the name of the event from the contract ABI, if available.
+func (st *ContractEventStat) Name() *string {
+	return eventName(st.abi, st.Topic)
+}
+
+// Name resolves the name of the event from the contract ABI, if available.
+func (sum *ContractEventSummary) Name() *string {
+	return eventName(sum.abi, sum.Topic)
+}
+
+// Events resolves list of events emitted by the contract.
+func (con *Contract) Events(ctx context.Context, args struct {
+	Cursor *Cursor
+	Count  int32
+}) (*ContractEventList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	// pull list of events
+	el, err := repository.R().WithContext(ctx).ContractEvents(&con.Address, (*string)(args.Cursor), args.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	// return the final resolvable list
+	return NewContractEventList(el, con.eventsAbi()), nil
+}
+
+// EventStats resolves daily counters of events emitted by the contract in the given number of days.
+func (con *Contract) EventStats(ctx context.Context, args struct{ Days int32 }) ([]*ContractEventStat, error) {
+	if args.Days < 1 || args.Days > contractEventStatsMaxDays {
+		return nil, errInvalidArgument("days must be between 1 and %d", contractEventStatsMaxDays)
+	}
+
+	stats, err := repository.R().WithContext(ctx).ContractEventStats(&con.Address, int(args.Days))
+	if err != nil {
+		return nil, err
+	}
+
+	ab := con.eventsAbi()
+	list := make([]*ContractEventStat, len(stats))
+	for i, st := range stats {
+		list[i] = &ContractEventStat{ContractEventStat: *st, abi: ab}
+	}
+	return list, nil
+}
+
+// TopEvents resolves the most frequent events emitted by the contract in the given number of days.
+func (con *Contract) TopEvents(ctx context.Context, args struct {
+	Days  int32
+	Limit int32
+}) ([]*ContractEventSummary, error) {
+	if args.Days < 1 || args.Days > contractEventStatsMaxDays {
+		return nil, errInvalidArgument("days must be between 1 and %d", contractEventStatsMaxDays)
+	}
+	if args.Limit < 1 || args.Limit > contractTopEventsMaxLimit {
+		return nil, errInvalidArgument("limit must be between 1 and %d", contractTopEventsMaxLimit)
+	}
+
+	stats, err := repository.R().WithContext(ctx).ContractEventStats(&con.Address, int(args.Days))
+	if err != nil {
+		return nil, err
+	}
+
+	// sum the daily counters by the event signature
+	ab := con.eventsAbi()
+	index := make(map[common.Hash]*ContractEventSummary)
+	list := make([]*ContractEventSummary, 0)
+	for _, st := range stats {
+		sum, ok := index[st.Topic]
+		if !ok {
+			sum = &ContractEventSummary{Topic: st.Topic, abi: ab}
+			index[st.Topic] = sum
+			list = append(list, sum)
+		}
+		sum.Count += hexutil.Uint64(st.Count)
+	}
+
+	// the most frequent first
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Count > list[j].Count
+	})
+	if len(list) > int(args.Limit) {
+		list = list[:args.Limit]
+	}
+	return list, nil
+}
+
+// eventsAbi parses the ABI of the contract so the names of its events can be resolved.
+// Returns nil if the ABI is not available.
+func (con *Contract) eventsAbi() *abi.ABI {
+	if con.Abi == "" {
+		return nil
+	}
+
+	ab, err := abi.JSON(strings.NewReader(con.Abi))
+	if err != nil {
+		return nil
+	}
+	return &ab
+}
+
+// eventName provides the name of the event of the given signature topic from the ABI.
+func eventName(ab *abi.ABI, topic common.Hash) *string {
+	if ab == nil {
+		return nil
+	}
+
+	ev, err := ab.EventByID(topic)
+	if err != nil {
+		return nil
+	}
+	return &ev.Name
+}
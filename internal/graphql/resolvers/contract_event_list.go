@@ -0,0 +1,61 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ContractEventList represents resolvable list of contract event edges structure.
+type ContractEventList struct {
+	types.ContractEventList
+	abi *abi.ABI
+}
+
+// ContractEventListEdge represents a single edge of a contract event list structure.
+type ContractEventListEdge struct {
+	Event  *ContractEvent
+	Cursor Cursor
+}
+
+// NewContractEventList builds new resolvable list of contract events.
+func NewContractEventList(ol *types.ContractEventList, ab *abi.ABI) *ContractEventList {
+	return &ContractEventList{ContractEventList: *ol, abi: ab}
+}
+
+// TotalCount resolves the total number of events in the list.
+func (ol *ContractEventList) TotalCount() hexutil.Uint64 {
+	return hexutil.Uint64(ol.Total)
+}
+
+// PageInfo resolves the current page information for the contract events list.
+func (ol *ContractEventList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if ol.Collection == nil || len(ol.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(types.ListCursor(ol.Collection[0].OrdinalIndex()))
+	last := Cursor(types.ListCursor(ol.Collection[len(ol.Collection)-1].OrdinalIndex()))
+	return NewListPageInfo(&first, &last, !ol.IsEnd, !ol.IsStart)
+}
+
+// Edges resolves list of contract event list edges.
+func (ol *ContractEventList) Edges() []*ContractEventListEdge {
+	// do we have any items? return empty list if not
+	if ol.Collection == nil || len(ol.Collection) == 0 {
+		return make([]*ContractEventListEdge, 0)
+	}
+
+	// make the list
+	edges := make([]*ContractEventListEdge, len(ol.Collection))
+	for i, op := range ol.Collection {
+		edges[i] = &ContractEventListEdge{
+			Event:  NewContractEvent(op, ol.abi),
+			Cursor: Cursor(types.ListCursor(op.OrdinalIndex())),
+		}
+	}
+	return edges
+}
@@ -2,11 +2,11 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
-	"strconv"
 )
 
 // ContractList represents resolvable list of blockchain smart contract edges structure.
@@ -26,7 +26,7 @@ func NewContractList(cl *types.ContractList) *ContractList {
 }
 
 // Contracts resolves list of blockchain smart contracts encapsulated in a listable structure.
-func (rs *rootResolver) Contracts(args *struct {
+func (rs *rootResolver) Contracts(ctx context.Context, args *struct {
 	ValidatedOnly bool
 	Cursor        *Cursor
 	Count         int32
@@ -36,7 +36,7 @@ func (rs *rootResolver) Contracts(args *struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the contract list from repository
-	cl, err := repository.R().Contracts(args.ValidatedOnly, (*string)(args.Cursor), args.Count)
+	cl, err := repository.R().WithContext(ctx).Contracts(args.ValidatedOnly, (*string)(args.Cursor), args.Count)
 	if err != nil {
 		rs.log.Errorf("can not get contracts list; %s", err.Error())
 		return nil, err
@@ -58,8 +58,8 @@ func (cl *ContractList) PageInfo() (*ListPageInfo, error) {
 	}
 
 	// get the first and last elements
-	first := Cursor(strconv.FormatUint(cl.First, 10))
-	last := Cursor(strconv.FormatUint(cl.Last, 10))
+	first := Cursor(types.ListCursor(cl.First))
+	last := Cursor(types.ListCursor(cl.Last))
 	return NewListPageInfo(&first, &last, !cl.IsEnd, !cl.IsStart)
 }
 
@@ -76,7 +76,7 @@ func (cl *ContractList) Edges() []*ContractListEdge {
 		// make the element
 		edge := ContractListEdge{
 			Contract: NewContract(c),
-			Cursor:   Cursor(strconv.FormatUint(c.Uid(), 10)),
+			Cursor:   Cursor(types.ListCursor(c.Uid())),
 		}
 
 		// add it to the list
@@ -2,8 +2,10 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -21,37 +23,58 @@ func (rs *rootResolver) State() (CurrentState, error) {
 // SealedEpoch resolves the most recent sealed epoch details.
 func (cst CurrentState) SealedEpoch() (Epoch, error) {
 	// get the sealed epoch
-	e, err := repository.R().CurrentSealedEpoch()
+	e, err := lookups.do(lookupState, "sealed_epoch", func() (interface{}, error) {
+		return repository.R().CurrentSealedEpoch()
+	})
 	if err != nil {
 		return Epoch{}, err
 	}
-	return Epoch{*e}, nil
+	return Epoch{*e.(*types.Epoch)}, nil
 }
 
 // Validators resolves the number of validators active in the network.
 func (cst CurrentState) Validators() (hexutil.Uint64, error) {
-	val, err := repository.R().ValidatorsCount()
-	return hexutil.Uint64(val), err
+	val, err := lookups.do(lookupState, "validators", func() (interface{}, error) {
+		return repository.R().ValidatorsCount()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(val.(uint64)), nil
 }
 
 // Accounts resolves the number of accounts participating on chain transactions.
 func (cst CurrentState) Accounts() (hexutil.Uint64, error) {
-	return repository.R().AccountsActive()
+	val, err := lookups.do(lookupState, "accounts", func() (interface{}, error) {
+		return repository.R().AccountsActive()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return val.(hexutil.Uint64), nil
 }
 
 // Blocks resolves the total number of blocks in the chain.
 func (cst CurrentState) Blocks() (hexutil.Big, error) {
 	// get the block height of the chain
-	h, err := repository.R().BlockHeight()
+	h, err := lookups.do(lookupState, "blocks", func() (interface{}, error) {
+		return repository.R().BlockHeight()
+	})
 	if err != nil {
 		return hexutil.Big{}, err
 	}
-	return *h, nil
+	return *h.(*hexutil.Big), nil
 }
 
 // Transactions resolves the total number of transactions in the chain.
 func (cst CurrentState) Transactions() (hexutil.Uint64, error) {
-	return repository.R().EstimateTransactionsCount()
+	val, err := lookups.do(lookupState, "transactions", func() (interface{}, error) {
+		return repository.R().EstimateTransactionsCount()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return val.(hexutil.Uint64), nil
 }
 
 // SfcContractAddress resolves address of the SFC contract.
@@ -60,11 +83,11 @@ func (cst CurrentState) SfcContractAddress() common.Address {
 }
 
 // SfcLockingEnabled indicates if the stake locking has been enabled in SFC contract.
-func (cst CurrentState) SfcLockingEnabled() (bool, error) {
-	return repository.R().LockingAllowed()
+func (cst CurrentState) SfcLockingEnabled(ctx context.Context) (bool, error) {
+	return repository.R().WithContext(ctx).LockingAllowed()
 }
 
 // SfcVersion resolves the current version of the SFC contract on the connected node.
-func (cst CurrentState) SfcVersion() (hexutil.Uint64, error) {
-	return repository.R().SfcVersion()
+func (cst CurrentState) SfcVersion(ctx context.Context) (hexutil.Uint64, error) {
+	return repository.R().WithContext(ctx).SfcVersion()
 }
@@ -0,0 +1,182 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// dashboardListSize is the number of the latest blocks and transactions on the dashboard.
+	dashboardListSize = 10
+
+	// dashboardTrxSpeedRange is the range in seconds the dashboard TPS is calculated for.
+	dashboardTrxSpeedRange = 1200
+
+	// dashboardCacheTTL is the time a loaded dashboard snapshot is shared by all the requests.
+	dashboardCacheTTL = time.Second
+)
+
+// Dashboard represents resolvable snapshot of the network state
+// bundling the content of the explorer homepage.
+type Dashboard struct {
+	block        *types.Block
+	tps          float64
+	gasPrice     *types.GasPrice
+	price        types.Price
+	stakingStats *types.StakingStats
+	blocks       []*types.Block
+	transactions []*types.Transaction
+
+	// loaded is the time the snapshot was loaded
+	loaded time.Time
+}
+
+// GasPriceTiers represents resolvable gas price tiers in GWei.
+type GasPriceTiers struct {
+	types.GasPrice
+}
+
+// Dashboard resolves the snapshot of the network state for the explorer homepage.
+// The parts of the snapshot are loaded concurrently and the snapshot is shared
+// by requests coming in within a short period.
+func (rs *rootResolver) Dashboard(args *struct{ To string }) (*Dashboard, error) {
+	// is the requested denomination even reasonable
+	if !reExpectedPriceSymbol.Match([]byte(args.To)) {
+		return nil, errInvalidArgument("invalid denomination received")
+	}
+	sym := strings.ToUpper(args.To)
+
+	// do we have a recent snapshot
+	rs.dashboardMu.Lock()
+	if db, ok := rs.dashboards[sym]; ok && time.Since(db.loaded) < dashboardCacheTTL {
+		rs.dashboardMu.Unlock()
+		return db, nil
+	}
+	rs.dashboardMu.Unlock()
+
+	// load the snapshot only once for concurrent requests
+	db, err, _ := rs.dashboardGroup.Do(sym, func() (interface{}, error) {
+		return loadDashboard(sym)
+	})
+	if err != nil {
+		rs.log.Errorf("can not load dashboard; %s", err.Error())
+		return nil, err
+	}
+
+	rs.dashboardMu.Lock()
+	rs.dashboards[sym] = db.(*Dashboard)
+	rs.dashboardMu.Unlock()
+	return db.(*Dashboard), nil
+}
+
+// loadDashboard loads parts of the dashboard snapshot concurrently.
+func loadDashboard(sym string) (*Dashboard, error) {
+	var db Dashboard
+	var wg sync.WaitGroup
+	errs := make(chan error, 7)
+
+	// load runs the loader in parallel with the others
+	load := func(fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	load(func() (err error) {
+		db.block, err = repository.R().BlockByNumber(nil)
+		return err
+	})
+	load(func() (err error) {
+		db.tps, err = repository.R().TrxFlowSpeed(dashboardTrxSpeedRange)
+		return err
+	})
+	load(func() (err error) {
+		db.gasPrice, err = repository.R().GasPriceExtended()
+		return err
+	})
+	load(func() (err error) {
+		db.price, err = repository.R().Price(sym)
+		return err
+	})
+	load(func() (err error) {
+		db.stakingStats, err = repository.R().StakingStats()
+		return err
+	})
+	load(func() error {
+		bl, err := repository.R().Blocks(nil, dashboardListSize)
+		if err != nil {
+			return err
+		}
+		db.blocks = bl.Collection
+		return nil
+	})
+	load(func() error {
+		tl, err := repository.R().Transactions(nil, dashboardListSize)
+		if err != nil {
+			return err
+		}
+		db.transactions = tl.Collection
+		return nil
+	})
+
+	// wait for all the parts
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+
+	db.loaded = time.Now()
+	return &db, nil
+}
+
+// Block resolves the latest block of the chain.
+func (db *Dashboard) Block() *Block {
+	return NewBlock(db.block)
+}
+
+// Tps resolves the recent speed of the network in transactions per second.
+func (db *Dashboard) Tps() float64 {
+	return db.tps
+}
+
+// GasPrice resolves the current gas price tiers.
+func (db *Dashboard) GasPrice() *GasPriceTiers {
+	return &GasPriceTiers{*db.gasPrice}
+}
+
+// Price resolves the current price of FTM in the requested target symbol.
+func (db *Dashboard) Price() types.Price {
+	return db.price
+}
+
+// StakingStats resolves the recent aggregated staking statistics of the network.
+func (db *Dashboard) StakingStats() *StakingStats {
+	return &StakingStats{*db.stakingStats}
+}
+
+// Blocks resolves the latest blocks of the chain.
+func (db *Dashboard) Blocks() []*Block {
+	list := make([]*Block, len(db.blocks))
+	for i, b := range db.blocks {
+		list[i] = NewBlock(b)
+	}
+	return list
+}
+
+// Transactions resolves the latest transactions of the chain.
+func (db *Dashboard) Transactions() []*Transaction {
+	list := make([]*Transaction, len(db.transactions))
+	for i, trx := range db.transactions {
+		list[i] = NewTransaction(trx)
+	}
+	return list
+}
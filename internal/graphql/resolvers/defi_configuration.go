@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
@@ -23,9 +24,9 @@ func NewDefiConfiguration(cf *types.DefiSettings, cfg *config.Config) *DefiConfi
 }
 
 // DefiConfiguration resolves the current DeFi contract settings.
-func (rs *rootResolver) DefiConfiguration() (*DefiConfiguration, error) {
+func (rs *rootResolver) DefiConfiguration(ctx context.Context) (*DefiConfiguration, error) {
 	// pass the call to repository
-	st, err := repository.R().DefiConfiguration()
+	st, err := repository.R().WithContext(ctx).DefiConfiguration()
 	if err != nil {
 		return nil, err
 	}
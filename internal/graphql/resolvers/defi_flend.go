@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 
@@ -18,19 +19,19 @@ func (rs *rootResolver) FLendLendingPool() (*LendingPool, error) {
 }
 
 // ReserveData resolves asset reserve data from lending pool
-func (lp *LendingPool) ReserveData(args *struct{ Address common.Address }) (*types.ReserveData, error) {
-	return repository.R().FLendGetLendingPoolReserveData(&args.Address)
+func (lp *LendingPool) ReserveData(ctx context.Context, args *struct{ Address common.Address }) (*types.ReserveData, error) {
+	return repository.R().WithContext(ctx).FLendGetLendingPoolReserveData(&args.Address)
 }
 
 // ReserveList resolves list of assets in lending pool
-func (lp *LendingPool) ReserveList() ([]common.Address, error) {
-	return repository.R().FLendGetReserveList()
+func (lp *LendingPool) ReserveList(ctx context.Context) ([]common.Address, error) {
+	return repository.R().WithContext(ctx).FLendGetReserveList()
 }
 
 // ReserveDataList resolves list of assets data in lending pool
-func (lp *LendingPool) ReserveDataList() ([]*types.ReserveData, error) {
+func (lp *LendingPool) ReserveDataList(ctx context.Context) ([]*types.ReserveData, error) {
 	// get the list
-	rl, err := repository.R().FLendGetReserveList()
+	rl, err := repository.R().WithContext(ctx).FLendGetReserveList()
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +39,7 @@ func (lp *LendingPool) ReserveDataList() ([]*types.ReserveData, error) {
 	// make the container
 	rdl := make([]*types.ReserveData, len(rl))
 	for i, adr := range rl {
-		rdl[i], err = repository.R().FLendGetLendingPoolReserveData(&adr)
+		rdl[i], err = repository.R().WithContext(ctx).FLendGetLendingPoolReserveData(&adr)
 		if err != nil {
 			return nil, err
 		}
@@ -47,14 +48,14 @@ func (lp *LendingPool) ReserveDataList() ([]*types.ReserveData, error) {
 }
 
 // UserAccountData resolves user account data from lending pool
-func (lp *LendingPool) UserAccountData(args *struct{ Address common.Address }) (*types.FLendUserAccountData, error) {
-	return repository.R().FLendGetUserAccountData(&args.Address)
+func (lp *LendingPool) UserAccountData(ctx context.Context, args *struct{ Address common.Address }) (*types.FLendUserAccountData, error) {
+	return repository.R().WithContext(ctx).FLendGetUserAccountData(&args.Address)
 }
 
 // UserDepositHistory resolves user account deposit history data from lending pool
-func (lp *LendingPool) UserDepositHistory(args *struct {
+func (lp *LendingPool) UserDepositHistory(ctx context.Context, args *struct {
 	Address *common.Address
 	Asset   *common.Address
 }) ([]*types.FLendDeposit, error) {
-	return repository.R().FLendGetUserDepositHistory(args.Address, args.Asset)
+	return repository.R().WithContext(ctx).FLendGetUserDepositHistory(args.Address, args.Asset)
 }
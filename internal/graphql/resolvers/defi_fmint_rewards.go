@@ -0,0 +1,98 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FMintRewards represents resolvable rewards claiming schedule
+// of an fMint account.
+type FMintRewards struct {
+	types.FMintRewardsSchedule
+}
+
+// Rewards resolves the rewards claiming schedule of the fMint account.
+func (fac *FMintAccount) Rewards(ctx context.Context) (*FMintRewards, error) {
+	rs, err := repository.R().WithContext(ctx).FMintRewardsSchedule(&fac.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &FMintRewards{FMintRewardsSchedule: *rs}, nil
+}
+
+// EpochLength resolves the length of a reward epoch in seconds.
+func (fr *FMintRewards) EpochLength() hexutil.Uint64 {
+	return hexutil.Uint64(fr.FMintRewardsSchedule.EpochLength.ToInt().Uint64())
+}
+
+// EpochEnds resolves the time stamp of the current reward epoch end.
+func (fr *FMintRewards) EpochEnds() hexutil.Uint64 {
+	return hexutil.Uint64(fr.FMintRewardsSchedule.EpochEnds.ToInt().Uint64())
+}
+
+// LastPush resolves the time stamp of the last rewards push.
+func (fr *FMintRewards) LastPush() hexutil.Uint64 {
+	return hexutil.Uint64(fr.FMintRewardsSchedule.LastPush.ToInt().Uint64())
+}
+
+// NextPush resolves the earliest time stamp a new rewards push can be made.
+func (fr *FMintRewards) NextPush() hexutil.Uint64 {
+	return fr.LastPush() + hexutil.Uint64(fr.MinPushInterval.ToInt().Uint64())
+}
+
+// NextUnlock resolves the time stamp of the next rewards unlock, which is
+// the end of the current reward epoch, or the next rewards push if the epoch
+// is already over.
+func (fr *FMintRewards) NextUnlock() hexutil.Uint64 {
+	if now := hexutil.Uint64(time.Now().UTC().Unix()); now < fr.EpochEnds() {
+		return fr.EpochEnds()
+	}
+	return fr.NextPush()
+}
+
+// Earned resolves the total amount of rewards earned by the account.
+func (fr *FMintRewards) Earned() hexutil.Big {
+	return fr.FMintRewardsSchedule.Earned
+}
+
+// Claimable resolves the amount of earned rewards the account can claim now.
+func (fr *FMintRewards) Claimable() hexutil.Big {
+	if !fr.CanClaim {
+		return hexutil.Big{}
+	}
+	return fr.FMintRewardsSchedule.Earned
+}
+
+// Locked resolves the amount of earned rewards the account can not claim
+// until its collateral to debt ratio allows it.
+func (fr *FMintRewards) Locked() hexutil.Big {
+	if fr.CanClaim {
+		return hexutil.Big{}
+	}
+	return fr.FMintRewardsSchedule.Earned
+}
+
+// Pending resolves the estimated amount of rewards the account is going to earn
+// till the end of the current reward epoch, based on its current principal share.
+func (fr *FMintRewards) Pending() hexutil.Big {
+	// the remaining time of the epoch
+	from := fr.Updated.ToInt().Int64()
+	if now := time.Now().UTC().Unix(); now > from {
+		from = now
+	}
+	left := fr.FMintRewardsSchedule.EpochEnds.ToInt().Int64() - from
+	if left <= 0 || fr.PrincipalTotal.ToInt().Sign() == 0 {
+		return hexutil.Big{}
+	}
+
+	// principal * rate * left / total
+	val := new(big.Int).Mul(fr.Principal.ToInt(), fr.RewardRate.ToInt())
+	val.Mul(val, big.NewInt(left))
+	return hexutil.Big(*val.Div(val, fr.PrincipalTotal.ToInt()))
+}
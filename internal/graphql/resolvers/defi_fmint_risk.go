@@ -0,0 +1,105 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FMintAccountAtRisk represents resolvable state of an fMint account with a debt.
+type FMintAccountAtRisk struct {
+	types.FMintAccountState
+}
+
+// FMintAccountAtRiskList represents resolvable list of fMint accounts at risk.
+type FMintAccountAtRiskList struct {
+	types.FMintAccountStateList
+}
+
+// FMintAccountAtRiskListEdge represents a single edge of the fMint accounts at risk list.
+type FMintAccountAtRiskListEdge struct {
+	Account *FMintAccountAtRisk
+	Cursor  Cursor
+}
+
+// FMintAccountsAtRisk resolves the list of fMint accounts with a debt and the collateral
+// ratio below the given max ratio, sorted from the lowest ratio.
+func (rs *rootResolver) FMintAccountsAtRisk(ctx context.Context, args *struct {
+	MaxRatio *float64
+	Cursor   *Cursor
+	Count    int32
+}) (*FMintAccountAtRiskList, error) {
+	if args.Count <= 0 {
+		return nil, errInvalidArgument("count must be positive")
+	}
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	// convert the ratio to 4 digits
+	var ratio4 *uint64
+	if args.MaxRatio != nil {
+		if *args.MaxRatio <= 0 {
+			return nil, errInvalidArgument("max ratio must be positive")
+		}
+		val := uint64(*args.MaxRatio * 10000)
+		ratio4 = &val
+	}
+
+	list, err := repository.R().WithContext(ctx).FMintAccountsAtRisk(ratio4, (*string)(args.Cursor), args.Count)
+	if err != nil {
+		return nil, err
+	}
+	return &FMintAccountAtRiskList{FMintAccountStateList: *list}, nil
+}
+
+// TotalCount resolves the total number of accounts in the list.
+func (al *FMintAccountAtRiskList) TotalCount() hexutil.Uint64 {
+	return hexutil.Uint64(al.Total)
+}
+
+// PageInfo resolves the current page information for the accounts list.
+func (al *FMintAccountAtRiskList) PageInfo() (*ListPageInfo, error) {
+	if len(al.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	first := Cursor(types.ListCursor(al.Collection[0].OrdinalIndex()))
+	last := Cursor(types.ListCursor(al.Collection[len(al.Collection)-1].OrdinalIndex()))
+	return NewListPageInfo(&first, &last, !al.IsEnd, false)
+}
+
+// Edges resolves list of the accounts list edges.
+func (al *FMintAccountAtRiskList) Edges() []*FMintAccountAtRiskListEdge {
+	edges := make([]*FMintAccountAtRiskListEdge, len(al.Collection))
+	for i, fa := range al.Collection {
+		edges[i] = &FMintAccountAtRiskListEdge{
+			Account: &FMintAccountAtRisk{FMintAccountState: *fa},
+			Cursor:  Cursor(types.ListCursor(fa.OrdinalIndex())),
+		}
+	}
+	return edges
+}
+
+// Collateral resolves the collateral positions of the account.
+func (fa *FMintAccountAtRisk) Collateral() []*FMintTokenTotal {
+	return fMintTokenTotals(fa.FMintAccountState.Collateral)
+}
+
+// Debt resolves the debt positions of the account.
+func (fa *FMintAccountAtRisk) Debt() []*FMintTokenTotal {
+	return fMintTokenTotals(fa.FMintAccountState.Debt)
+}
+
+// CollateralRatio resolves the ratio between the collateral and the debt value.
+func (fa *FMintAccountAtRisk) CollateralRatio() float64 {
+	val, _ := new(big.Float).Quo(new(big.Float).SetUint64(fa.Ratio4), big.NewFloat(10000)).Float64()
+	return val
+}
+
+// Updated resolves the time the account state was refreshed.
+func (fa *FMintAccountAtRisk) Updated() hexutil.Uint64 {
+	return hexutil.Uint64(fa.FMintAccountState.Updated.Unix())
+}
@@ -0,0 +1,95 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// fMintDebtSymbol is the symbol of the fMint USD stable token.
+const fMintDebtSymbol = "FUSD"
+
+// FMintStats represents resolvable snapshot of the protocol level fMint metrics.
+type FMintStats struct {
+	types.FMintStats
+}
+
+// FMintTokenTotal represents resolvable total of a DeFi token in an fMint pool.
+type FMintTokenTotal struct {
+	types.FMintTokenTotal
+}
+
+// FMintStats resolves the latest snapshot of the protocol level fMint metrics.
+func (rs *rootResolver) FMintStats(ctx context.Context) (*FMintStats, error) {
+	fs, err := repository.R().WithContext(ctx).FMintStats()
+	if err != nil || fs == nil {
+		return nil, err
+	}
+	return &FMintStats{FMintStats: *fs}, nil
+}
+
+// Time resolves the time of the snapshot.
+func (fs *FMintStats) Time() hexutil.Uint64 {
+	return hexutil.Uint64(fs.FMintStats.Time.Unix())
+}
+
+// Collateral resolves the collateral totals by token.
+func (fs *FMintStats) Collateral() []*FMintTokenTotal {
+	return fMintTokenTotals(fs.FMintStats.Collateral)
+}
+
+// Debt resolves the minted debt totals by token.
+func (fs *FMintStats) Debt() []*FMintTokenTotal {
+	return fMintTokenTotals(fs.FMintStats.Debt)
+}
+
+// FUsdMinted resolves the total amount of fUSD minted.
+func (fs *FMintStats) FUsdMinted(ctx context.Context) (hexutil.Big, error) {
+	for _, t := range fs.FMintStats.Debt {
+		tk, err := repository.R().WithContext(ctx).DefiToken(&t.Token)
+		if err != nil {
+			return hexutil.Big{}, err
+		}
+		if strings.EqualFold(tk.Symbol, fMintDebtSymbol) {
+			return t.Amount, nil
+		}
+	}
+	return hexutil.Big{}, nil
+}
+
+// CollateralRatio resolves the global ratio between the collateral and the debt value.
+func (fs *FMintStats) CollateralRatio() *float64 {
+	if fs.DebtValue.ToInt().Sign() == 0 {
+		return nil
+	}
+	val, _ := new(big.Float).Quo(new(big.Float).SetInt(fs.CollateralValue.ToInt()), new(big.Float).SetInt(fs.DebtValue.ToInt())).Float64()
+	return &val
+}
+
+// WarningRatio resolves the collateral ratio below which an account is considered at risk.
+func (fs *FMintStats) WarningRatio() float64 {
+	return float64(fs.WarningRatio4) / 10000
+}
+
+// Token resolves the DeFi token of the total.
+func (ft *FMintTokenTotal) Token(ctx context.Context) (*DefiToken, error) {
+	tk, err := repository.R().WithContext(ctx).DefiToken(&ft.FMintTokenTotal.Token)
+	if err != nil {
+		return nil, err
+	}
+	return NewDefiToken(tk), nil
+}
+
+// fMintTokenTotals converts the token totals into their resolvable form.
+func fMintTokenTotals(list []types.FMintTokenTotal) []*FMintTokenTotal {
+	out := make([]*FMintTokenTotal, len(list))
+	for i := range list {
+		out[i] = &FMintTokenTotal{FMintTokenTotal: list[i]}
+	}
+	return out
+}
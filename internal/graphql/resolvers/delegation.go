@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -27,12 +28,12 @@ func NewDelegation(d *types.Delegation) *Delegation {
 }
 
 // Delegation resolves details of a delegator by it's address.
-func (rs *rootResolver) Delegation(args *struct {
+func (rs *rootResolver) Delegation(ctx context.Context, args *struct {
 	Address common.Address
 	Staker  hexutil.Big
 }) (*Delegation, error) {
 	// get the delegator detail from backend
-	d, err := repository.R().Delegation(&args.Address, &args.Staker)
+	d, err := repository.R().WithContext(ctx).Delegation(&args.Address, &args.Staker)
 	if err != nil {
 		return nil, err
 	}
@@ -57,15 +58,15 @@ func (del Delegation) ToStakerId() hexutil.Big {
 }
 
 // Amount returns total delegated amount for the delegator.
-func (del Delegation) Amount() (hexutil.Big, error) {
+func (del Delegation) Amount(ctx context.Context) (hexutil.Big, error) {
 	// get the base amount delegated
-	base, err := repository.R().DelegationAmountStaked(&del.Address, del.Delegation.ToStakerId)
+	base, err := repository.R().WithContext(ctx).DelegationAmountStaked(&del.Address, del.Delegation.ToStakerId)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
 
 	// get the sum of all pending withdrawals
-	wd, err := del.pendingWithdrawalsValue()
+	wd, err := del.pendingWithdrawalsValue(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -75,10 +76,10 @@ func (del Delegation) Amount() (hexutil.Big, error) {
 
 // pendingWithdrawalsValue returns total amount of tokens
 // locked in pending withdrawals for the delegation.
-func (del Delegation) pendingWithdrawalsValue() (*big.Int, error) {
+func (del Delegation) pendingWithdrawalsValue(ctx context.Context) (*big.Int, error) {
 	// call for it only once
 	val, err, _ := del.cg.Do("withdraw-total", func() (interface{}, error) {
-		return repository.R().WithdrawRequestsPendingTotal(&del.Address, del.Delegation.ToStakerId)
+		return repository.R().WithContext(ctx).WithdrawRequestsPendingTotal(&del.Address, del.Delegation.ToStakerId)
 	})
 	if err != nil {
 		return nil, err
@@ -87,8 +88,8 @@ func (del Delegation) pendingWithdrawalsValue() (*big.Int, error) {
 }
 
 // AmountInWithdraw returns total delegated amount in pending withdrawals for the delegator.
-func (del Delegation) AmountInWithdraw() (hexutil.Big, error) {
-	val, err := del.pendingWithdrawalsValue()
+func (del Delegation) AmountInWithdraw(ctx context.Context) (hexutil.Big, error) {
+	val, err := del.pendingWithdrawalsValue(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -96,8 +97,8 @@ func (del Delegation) AmountInWithdraw() (hexutil.Big, error) {
 }
 
 // PendingRewards resolves pending rewards for the delegator account.
-func (del Delegation) PendingRewards() (types.PendingRewards, error) {
-	r, err := repository.R().PendingRewards(&del.Address, del.Delegation.ToStakerId)
+func (del Delegation) PendingRewards(ctx context.Context) (types.PendingRewards, error) {
+	r, err := repository.R().WithContext(ctx).PendingRewards(&del.Address, del.Delegation.ToStakerId)
 	if err != nil {
 		return types.PendingRewards{}, err
 	}
@@ -105,8 +106,8 @@ func (del Delegation) PendingRewards() (types.PendingRewards, error) {
 }
 
 // ClaimedReward resolves the total amount of rewards received on the delegation.
-func (del Delegation) ClaimedReward() (hexutil.Big, error) {
-	val, err := repository.R().RewardsClaimed(&del.Address, (*big.Int)(del.Delegation.ToStakerId))
+func (del Delegation) ClaimedReward(ctx context.Context) (hexutil.Big, error) {
+	val, err := repository.R().WithContext(ctx).RewardsClaimed(&del.Address, (*big.Int)(del.Delegation.ToStakerId))
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -114,7 +115,7 @@ func (del Delegation) ClaimedReward() (hexutil.Big, error) {
 }
 
 // WithdrawRequests resolves partial withdraw requests of the delegator.
-func (del Delegation) WithdrawRequests(args struct {
+func (del Delegation) WithdrawRequests(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 }) ([]WithdrawRequest, error) {
@@ -123,7 +124,7 @@ func (del Delegation) WithdrawRequests(args struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// pull list of withdrawals
-	wr, err := repository.R().WithdrawRequests(&del.Address, del.Delegation.ToStakerId, (*string)(args.Cursor), args.Count)
+	wr, err := repository.R().WithContext(ctx).WithdrawRequests(&del.Address, del.Delegation.ToStakerId, (*string)(args.Cursor), args.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +140,7 @@ func (del Delegation) WithdrawRequests(args struct {
 }
 
 // RewardClaims resolves list of reward claims of the delegation.
-func (del Delegation) RewardClaims(args struct {
+func (del Delegation) RewardClaims(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 }) (*RewardClaimList, error) {
@@ -148,7 +149,7 @@ func (del Delegation) RewardClaims(args struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// pull list of withdrawals
-	cl, err := repository.R().RewardClaims(&del.Address, (*big.Int)(del.Delegation.ToStakerId), (*string)(args.Cursor), args.Count)
+	cl, err := repository.R().WithContext(ctx).RewardClaims(&del.Address, (*big.Int)(del.Delegation.ToStakerId), (*string)(args.Cursor), args.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -157,11 +158,30 @@ func (del Delegation) RewardClaims(args struct {
 	return NewRewardClaimList(cl), nil
 }
 
+// History resolves list of operations of the delegation.
+func (del Delegation) History(ctx context.Context, args struct {
+	Cursor *Cursor
+	Count  int32
+}) (*DelegationOperationList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	// pull list of operations
+	ol, err := repository.R().WithContext(ctx).DelegationOperations(&del.Address, del.Delegation.ToStakerId, (*string)(args.Cursor), args.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	// return the final resolvable list
+	return NewDelegationOperationList(ol), nil
+}
+
 // DelegationLock returns information about delegation lock
-func (del Delegation) DelegationLock() (*types.DelegationLock, error) {
+func (del Delegation) DelegationLock(ctx context.Context) (*types.DelegationLock, error) {
 	// load the delegations lock only once
 	dl, err, _ := del.cg.Do("lock", func() (interface{}, error) {
-		return repository.R().DelegationLock(&del.Address, del.Delegation.ToStakerId)
+		return repository.R().WithContext(ctx).DelegationLock(&del.Address, del.Delegation.ToStakerId)
 	})
 	if err != nil {
 		return nil, err
@@ -170,8 +190,8 @@ func (del Delegation) DelegationLock() (*types.DelegationLock, error) {
 }
 
 // IsDelegationLocked signals if the delegation is locked right now.
-func (del Delegation) IsDelegationLocked() (bool, error) {
-	lock, err := del.DelegationLock()
+func (del Delegation) IsDelegationLocked(ctx context.Context) (bool, error) {
+	lock, err := del.DelegationLock(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -179,13 +199,13 @@ func (del Delegation) IsDelegationLocked() (bool, error) {
 }
 
 // IsFluidStakingActive signals if the delegation is upgraded to Fluid Staking model.
-func (del Delegation) IsFluidStakingActive() (bool, error) {
-	return repository.R().DelegationFluidStakingActive(&del.Address, del.Delegation.ToStakerId)
+func (del Delegation) IsFluidStakingActive(ctx context.Context) (bool, error) {
+	return repository.R().WithContext(ctx).DelegationFluidStakingActive(&del.Address, del.Delegation.ToStakerId)
 }
 
 // LockedUntil resolves the end time of delegation.
-func (del Delegation) LockedUntil() (hexutil.Uint64, error) {
-	lock, err := del.DelegationLock()
+func (del Delegation) LockedUntil(ctx context.Context) (hexutil.Uint64, error) {
+	lock, err := del.DelegationLock(ctx)
 	if err != nil {
 		return hexutil.Uint64(0), err
 	}
@@ -197,8 +217,8 @@ func (del Delegation) LockedUntil() (hexutil.Uint64, error) {
 }
 
 // LockedFromEpoch resolves the epoch om which the lock has been created.
-func (del Delegation) LockedFromEpoch() (hexutil.Uint64, error) {
-	lock, err := del.DelegationLock()
+func (del Delegation) LockedFromEpoch(ctx context.Context) (hexutil.Uint64, error) {
+	lock, err := del.DelegationLock(ctx)
 	if err != nil {
 		return hexutil.Uint64(0), err
 	}
@@ -210,8 +230,8 @@ func (del Delegation) LockedFromEpoch() (hexutil.Uint64, error) {
 }
 
 // LockedAmount resolves the total amount of delegation locked.
-func (del Delegation) LockedAmount() (hexutil.Big, error) {
-	lock, err := del.DelegationLock()
+func (del Delegation) LockedAmount(ctx context.Context) (hexutil.Big, error) {
+	lock, err := del.DelegationLock(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -220,20 +240,20 @@ func (del Delegation) LockedAmount() (hexutil.Big, error) {
 
 // UnlockedAmount resolves the total amount of unlocked delegation
 // which is available for un-delegate.
-func (del Delegation) UnlockedAmount() (hexutil.Big, error) {
-	return repository.R().DelegationAmountUnlocked(&del.Address, (*big.Int)(del.Delegation.ToStakerId))
+func (del Delegation) UnlockedAmount(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).DelegationAmountUnlocked(&del.Address, (*big.Int)(del.Delegation.ToStakerId))
 }
 
 // UnlockPenalty resolves the amount of penalty applied to the stake
 // on premature unlock request.
-func (del Delegation) UnlockPenalty(args struct{ Amount hexutil.Big }) (hexutil.Big, error) {
-	return repository.R().DelegationUnlockPenalty(&del.Address, (*big.Int)(del.Delegation.ToStakerId), (*big.Int)(&args.Amount))
+func (del Delegation) UnlockPenalty(ctx context.Context, args struct{ Amount hexutil.Big }) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).DelegationUnlockPenalty(&del.Address, (*big.Int)(del.Delegation.ToStakerId), (*big.Int)(&args.Amount))
 }
 
 // OutstandingSFTM resolves the amount of outstanding sFTM tokens
 // minted for this account.
-func (del Delegation) OutstandingSFTM() (hexutil.Big, error) {
-	val, err := repository.R().DelegationOutstandingSFTM(&del.Address, del.Delegation.ToStakerId)
+func (del Delegation) OutstandingSFTM(ctx context.Context) (hexutil.Big, error) {
+	val, err := repository.R().WithContext(ctx).DelegationOutstandingSFTM(&del.Address, del.Delegation.ToStakerId)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -242,9 +262,9 @@ func (del Delegation) OutstandingSFTM() (hexutil.Big, error) {
 
 // TokenizerAllowedToWithdraw resolves the tokenizer approval
 // of the delegation withdrawal.
-func (del Delegation) TokenizerAllowedToWithdraw() (bool, error) {
+func (del Delegation) TokenizerAllowedToWithdraw(ctx context.Context) (bool, error) {
 	// check the tokenizer lock status
-	lock, err := repository.R().DelegationTokenizerUnlocked(&del.Address, del.Delegation.ToStakerId)
+	lock, err := repository.R().WithContext(ctx).DelegationTokenizerUnlocked(&del.Address, del.Delegation.ToStakerId)
 	if err != nil {
 		return false, err
 	}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -37,8 +38,8 @@ func (dl *DelegationList) PageInfo() (*ListPageInfo, error) {
 	}
 
 	// get the first and last elements
-	first := Cursor(hexutil.Uint64(dl.Collection[0].OrdinalIndex()).String())
-	last := Cursor(hexutil.Uint64(dl.Collection[len(dl.Collection)-1].OrdinalIndex()).String())
+	first := Cursor(types.ListCursor(dl.Collection[0].OrdinalIndex()))
+	last := Cursor(types.ListCursor(dl.Collection[len(dl.Collection)-1].OrdinalIndex()))
 	return NewListPageInfo(&first, &last, !dl.IsEnd, !dl.IsStart)
 }
 
@@ -54,14 +55,14 @@ func (dl *DelegationList) Edges() []*DelegationListEdge {
 	for i, d := range dl.Collection {
 		edges[i] = &DelegationListEdge{
 			Delegation: NewDelegation(d),
-			Cursor:     Cursor(hexutil.Uint64(d.OrdinalIndex()).String()),
+			Cursor:     Cursor(types.ListCursor(d.OrdinalIndex())),
 		}
 	}
 	return edges
 }
 
 // DelegationsOf resolves a list of delegations information of a staker.
-func (rs *rootResolver) DelegationsOf(args *struct {
+func (rs *rootResolver) DelegationsOf(ctx context.Context, args *struct {
 	Staker hexutil.Big
 	Cursor *Cursor
 	Count  int32
@@ -71,7 +72,7 @@ func (rs *rootResolver) DelegationsOf(args *struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the list
-	dl, err := repository.R().DelegationsOfValidator(&args.Staker, (*string)(args.Cursor), args.Count)
+	dl, err := repository.R().WithContext(ctx).DelegationsOfValidator(&args.Staker, (*string)(args.Cursor), args.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +82,7 @@ func (rs *rootResolver) DelegationsOf(args *struct {
 }
 
 // DelegationsByAddress resolves a list of own delegations by the account address.
-func (rs *rootResolver) DelegationsByAddress(args *struct {
+func (rs *rootResolver) DelegationsByAddress(ctx context.Context, args *struct {
 	Address common.Address
 	Cursor  *Cursor
 	Count   int32
@@ -91,7 +92,7 @@ func (rs *rootResolver) DelegationsByAddress(args *struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the list of delegations
-	dl, err := repository.R().DelegationsByAddress(&args.Address, (*string)(args.Cursor), args.Count)
+	dl, err := repository.R().WithContext(ctx).DelegationsByAddress(&args.Address, (*string)(args.Cursor), args.Count)
 	if err != nil {
 		return nil, err
 	}
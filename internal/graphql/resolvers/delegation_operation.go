@@ -0,0 +1,43 @@
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DelegationOperation represents resolvable delegation operation detail.
+type DelegationOperation struct {
+	types.DelegationOperation
+}
+
+// NewDelegationOperation creates new instance of resolvable delegation operation.
+func NewDelegationOperation(op *types.DelegationOperation) *DelegationOperation {
+	return &DelegationOperation{DelegationOperation: *op}
+}
+
+// Address resolves the address of the delegator.
+func (op DelegationOperation) Address() common.Address {
+	return op.Delegator
+}
+
+// ToStakerId resolves the ID of the validator of the operation.
+func (op DelegationOperation) ToStakerId() hexutil.Big {
+	return op.ToValidatorId
+}
+
+// TrxHash resolves the hash of the operation transaction.
+func (op DelegationOperation) TrxHash() common.Hash {
+	return op.Trx
+}
+
+// Transaction resolves the transaction executing the operation.
+func (op DelegationOperation) Transaction(ctx context.Context) (*Transaction, error) {
+	trx, err := repository.R().WithContext(ctx).Transaction(&op.Trx)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransaction(trx), nil
+}
@@ -0,0 +1,59 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DelegationOperationList represents resolvable list of delegation operation edges structure.
+type DelegationOperationList struct {
+	types.DelegationOperationList
+}
+
+// DelegationOperationListEdge represents a single edge of a delegation operation list structure.
+type DelegationOperationListEdge struct {
+	Operation *DelegationOperation
+	Cursor    Cursor
+}
+
+// NewDelegationOperationList builds new resolvable list of delegation operations.
+func NewDelegationOperationList(ol *types.DelegationOperationList) *DelegationOperationList {
+	return &DelegationOperationList{*ol}
+}
+
+// TotalCount resolves the total number of operations in the list.
+func (ol *DelegationOperationList) TotalCount() hexutil.Uint64 {
+	return hexutil.Uint64(ol.Total)
+}
+
+// PageInfo resolves the current page information for the delegation operations list.
+func (ol *DelegationOperationList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if ol.Collection == nil || len(ol.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(types.ListCursor(ol.Collection[0].OrdinalIndex()))
+	last := Cursor(types.ListCursor(ol.Collection[len(ol.Collection)-1].OrdinalIndex()))
+	return NewListPageInfo(&first, &last, !ol.IsEnd, !ol.IsStart)
+}
+
+// Edges resolves list of delegation operation list edges.
+func (ol *DelegationOperationList) Edges() []*DelegationOperationListEdge {
+	// do we have any items? return empty list if not
+	if ol.Collection == nil || len(ol.Collection) == 0 {
+		return make([]*DelegationOperationListEdge, 0)
+	}
+
+	// make the list
+	edges := make([]*DelegationOperationListEdge, len(ol.Collection))
+	for i, op := range ol.Collection {
+		edges[i] = &DelegationOperationListEdge{
+			Operation: NewDelegationOperation(op),
+			Cursor:    Cursor(types.ListCursor(op.OrdinalIndex())),
+		}
+	}
+	return edges
+}
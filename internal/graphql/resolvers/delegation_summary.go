@@ -0,0 +1,106 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// DelegationsSummary represents resolvable aggregated portfolio
+// of all the delegations of an account.
+type DelegationsSummary struct {
+	Address             common.Address
+	TotalStaked         hexutil.Big
+	TotalPendingRewards hexutil.Big
+	TotalLocked         hexutil.Big
+	Validators          []*DelegationBreakdown
+}
+
+// DelegationBreakdown represents resolvable summary of a single delegation
+// of an account to a validator.
+type DelegationBreakdown struct {
+	ToStakerId     hexutil.Big
+	Staked         hexutil.Big
+	PendingRewards hexutil.Big
+	Locked         hexutil.Big
+	Delegation     *Delegation
+}
+
+// DelegationsSummary resolves aggregated portfolio of all the delegations of the given account.
+func (rs *rootResolver) DelegationsSummary(ctx context.Context, args *struct{ Address common.Address }) (*DelegationsSummary, error) {
+	// get the list of delegations
+	dl, err := repository.R().WithContext(ctx).DelegationsByAddressAll(&args.Address)
+	if err != nil {
+		rs.log.Errorf("can not load delegations of %s; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+
+	// resolve breakdown of each delegation in parallel
+	var wg sync.WaitGroup
+	list := make([]*DelegationBreakdown, len(dl))
+	errs := make([]error, len(dl))
+	for i, d := range dl {
+		wg.Add(1)
+		go func(i int, d *types.Delegation) {
+			defer wg.Done()
+			list[i], errs[i] = newDelegationBreakdown(ctx, d)
+		}(i, d)
+	}
+	wg.Wait()
+
+	// calculate the totals
+	sum := DelegationsSummary{Address: args.Address, Validators: list}
+	staked, rewards, locked := new(big.Int), new(big.Int), new(big.Int)
+	for i, br := range list {
+		if errs[i] != nil {
+			rs.log.Errorf("can not resolve delegation of %s to #%d; %s", args.Address.String(), dl[i].ToStakerId.ToInt().Uint64(), errs[i].Error())
+			return nil, errs[i]
+		}
+		staked.Add(staked, br.Staked.ToInt())
+		rewards.Add(rewards, br.PendingRewards.ToInt())
+		locked.Add(locked, br.Locked.ToInt())
+	}
+	sum.TotalStaked = hexutil.Big(*staked)
+	sum.TotalPendingRewards = hexutil.Big(*rewards)
+	sum.TotalLocked = hexutil.Big(*locked)
+	return &sum, nil
+}
+
+// newDelegationBreakdown loads summary values of the given delegation.
+func newDelegationBreakdown(ctx context.Context, d *types.Delegation) (*DelegationBreakdown, error) {
+	// the amount staked
+	staked, err := repository.R().WithContext(ctx).DelegationAmountStaked(&d.Address, d.ToStakerId)
+	if err != nil {
+		return nil, err
+	}
+
+	// pending rewards
+	rw, err := repository.R().WithContext(ctx).PendingRewards(&d.Address, d.ToStakerId)
+	if err != nil {
+		return nil, err
+	}
+
+	// locked amount, if the lock is still active
+	lock, err := repository.R().WithContext(ctx).DelegationLock(&d.Address, d.ToStakerId)
+	if err != nil {
+		return nil, err
+	}
+	locked := hexutil.Big{}
+	if lock != nil && uint64(lock.LockedUntil) > uint64(time.Now().UTC().Unix()) {
+		locked = lock.LockedAmount
+	}
+
+	return &DelegationBreakdown{
+		ToStakerId:     *d.ToStakerId,
+		Staked:         hexutil.Big(*staked),
+		PendingRewards: rw.Amount,
+		Locked:         locked,
+		Delegation:     NewDelegation(d),
+	}, nil
+}
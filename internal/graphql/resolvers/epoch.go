@@ -2,9 +2,11 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
 )
 
 // Epoch represents a resolvable Epoch representation
@@ -13,8 +15,13 @@ type Epoch struct {
 }
 
 // Epoch resolves information about epoch of the given id.
-func (rs *rootResolver) Epoch(args *struct{ Id *hexutil.Uint64 }) (Epoch, error) {
-	epo, err := repository.R().Epoch(args.Id)
+func (rs *rootResolver) Epoch(ctx context.Context, args *struct{ Id *hexutil.Uint64 }) (Epoch, error) {
+	// the latest sealed epoch changes with each new epoch
+	if args.Id == nil {
+		setCacheHint(ctx, 60)
+	}
+
+	epo, err := repository.R().WithContext(ctx).Epoch(args.Id)
 	if err != nil {
 		return Epoch{}, err
 	}
@@ -22,7 +29,7 @@ func (rs *rootResolver) Epoch(args *struct{ Id *hexutil.Uint64 }) (Epoch, error)
 }
 
 // Duration resolves the time length of the given epoch
-func (ep Epoch) Duration() hexutil.Uint64 {
+func (ep Epoch) Duration(ctx context.Context) hexutil.Uint64 {
 	// no length for the first epochs
 	if uint64(ep.Id) < 2 {
 		return 0
@@ -30,7 +37,7 @@ func (ep Epoch) Duration() hexutil.Uint64 {
 
 	// get the previous epoch so we can compare end times
 	pid := uint64(ep.Id) - 1
-	prev, err := repository.R().Epoch((*hexutil.Uint64)(&pid))
+	prev, err := repository.R().WithContext(ctx).Epoch((*hexutil.Uint64)(&pid))
 	if err != nil {
 		return 0
 	}
@@ -41,3 +48,70 @@ func (ep Epoch) Duration() hexutil.Uint64 {
 	}
 	return ep.EndTime - prev.EndTime
 }
+
+// stats loads aggregated statistics of the epoch, if available.
+func (ep Epoch) stats(ctx context.Context) *types.EpochStats {
+	st, err := repository.R().WithContext(ctx).EpochStats(ep.Id)
+	if err != nil {
+		return nil
+	}
+	return st
+}
+
+// TotalTxCount resolves the number of transactions processed in the epoch.
+func (ep Epoch) TotalTxCount(ctx context.Context) *hexutil.Uint64 {
+	st := ep.stats(ctx)
+	if st == nil {
+		return nil
+	}
+	return (*hexutil.Uint64)(&st.TxCount)
+}
+
+// TotalGasUsed resolves the amount of gas consumed by transactions of the epoch.
+func (ep Epoch) TotalGasUsed(ctx context.Context) *hexutil.Uint64 {
+	st := ep.stats(ctx)
+	if st == nil {
+		return nil
+	}
+	return (*hexutil.Uint64)(&st.GasUsed)
+}
+
+// TotalFees resolves the amount of fees collected in the epoch.
+func (ep Epoch) TotalFees() hexutil.Big {
+	return ep.EpochFee
+}
+
+// TotalRewards resolves the total amount of rewards distributed for the epoch.
+// It's the base reward for the epoch duration plus the collected fees.
+func (ep Epoch) TotalRewards(ctx context.Context) hexutil.Big {
+	val := new(big.Int).Mul(ep.BaseRewardPerSecond.ToInt(), new(big.Int).SetUint64(uint64(ep.Duration(ctx))))
+	return hexutil.Big(*val.Add(val, ep.EpochFee.ToInt()))
+}
+
+// AverageTPS resolves the average number of transactions per second in the epoch.
+func (ep Epoch) AverageTPS(ctx context.Context) *float64 {
+	st := ep.stats(ctx)
+	if st == nil || st.Duration == 0 {
+		return nil
+	}
+	tps := float64(st.TxCount) / float64(st.Duration)
+	return &tps
+}
+
+// Validators resolves the number of validators participating in the epoch.
+func (ep Epoch) Validators(ctx context.Context) *hexutil.Uint64 {
+	st := ep.stats(ctx)
+	if st == nil {
+		return nil
+	}
+	return (*hexutil.Uint64)(&st.Validators)
+}
+
+// OfflineValidators resolves the number of validators being offline in the epoch.
+func (ep Epoch) OfflineValidators(ctx context.Context) *hexutil.Uint64 {
+	st := ep.stats(ctx)
+	if st == nil {
+		return nil
+	}
+	return (*hexutil.Uint64)(&st.OfflineValidators)
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -18,12 +19,14 @@ type ERC20Token struct {
 // before making a resolvable instance.
 func NewErc20Token(adr *common.Address) *ERC20Token {
 	// get the total supply of the token and validate the token existence
-	erc20, err := repository.R().Erc20Token(adr)
-	if err != nil {
+	erc20, err := lookups.do(lookupToken, adr.String(), func() (interface{}, error) {
+		return repository.R().Erc20Token(adr)
+	})
+	if err != nil || erc20.(*types.Erc20Token) == nil {
 		return nil
 	}
 	// make the instance of the token
-	return &ERC20Token{*erc20}
+	return &ERC20Token{*erc20.(*types.Erc20Token)}
 }
 
 // Erc20Token resolves an instance of ERC20 token if available.
@@ -33,66 +36,118 @@ func (rs *rootResolver) Erc20Token(args *struct{ Token common.Address }) *ERC20T
 
 // FMintTokenAllowance resolves the amount of ERC20 tokens unlocked
 // by the token owner for DeFi operations.
-func (rs *rootResolver) FMintTokenAllowance(args *struct {
+func (rs *rootResolver) FMintTokenAllowance(ctx context.Context, args *struct {
 	Owner common.Address
 	Token common.Address
 }) (hexutil.Big, error) {
-	return repository.R().Erc20Allowance(&args.Token, &args.Owner, nil)
+	return repository.R().WithContext(ctx).Erc20Allowance(&args.Token, &args.Owner, nil)
 }
 
 // ErcTotalSupply resolves the current total supply of the specified token.
-func (rs *rootResolver) ErcTotalSupply(args *struct{ Token common.Address }) (hexutil.Big, error) {
-	return repository.R().Erc20TotalSupply(&args.Token)
+func (rs *rootResolver) ErcTotalSupply(ctx context.Context, args *struct{ Token common.Address }) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).Erc20TotalSupply(&args.Token)
 }
 
 // ErcTokenBalance resolves the current available balance of the specified token
 // for the specified owner.
-func (rs *rootResolver) ErcTokenBalance(args *struct {
+func (rs *rootResolver) ErcTokenBalance(ctx context.Context, args *struct {
 	Owner common.Address
 	Token common.Address
 }) (hexutil.Big, error) {
-	return repository.R().Erc20BalanceOf(&args.Token, &args.Owner)
+	return repository.R().WithContext(ctx).Erc20BalanceOf(&args.Token, &args.Owner)
 }
 
 // ErcTokenAllowance resolves the current amount of ERC20 tokens unlocked
 // by the token owner for the spender to be manipulated with.
-func (rs *rootResolver) ErcTokenAllowance(args *struct {
+func (rs *rootResolver) ErcTokenAllowance(ctx context.Context, args *struct {
 	Token   common.Address
 	Owner   common.Address
 	Spender common.Address
 }) (hexutil.Big, error) {
-	return repository.R().Erc20Allowance(&args.Token, &args.Owner, &args.Spender)
+	return repository.R().WithContext(ctx).Erc20Allowance(&args.Token, &args.Owner, &args.Spender)
+}
+
+// RiskLevel resolves the moderation risk level of the token.
+func (token *ERC20Token) RiskLevel(ctx context.Context) (string, error) {
+	return repository.R().WithContext(ctx).RiskLevel(&token.Address)
 }
 
 // TotalSupply resolves the total supply of the given ERC20 token.
-func (token *ERC20Token) TotalSupply() (hexutil.Big, error) {
-	return repository.R().Erc20TotalSupply(&token.Address)
+func (token *ERC20Token) TotalSupply(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).Erc20TotalSupply(&token.Address)
 }
 
 // BalanceOf resolves the available balance of the given ERC20 token to a user.
-func (token *ERC20Token) BalanceOf(args *struct{ Owner common.Address }) (hexutil.Big, error) {
-	return repository.R().Erc20BalanceOf(&token.Address, &args.Owner)
+func (token *ERC20Token) BalanceOf(ctx context.Context, args *struct{ Owner common.Address }) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).Erc20BalanceOf(&token.Address, &args.Owner)
 }
 
 // Allowance resolves the unlocked allowance of the given ERC20 token from the owner to spender.
-func (token *ERC20Token) Allowance(args *struct {
+func (token *ERC20Token) Allowance(ctx context.Context, args *struct {
 	Owner   common.Address
 	Spender common.Address
 }) (hexutil.Big, error) {
-	return repository.R().Erc20Allowance(&token.Address, &args.Owner, &args.Spender)
+	return repository.R().WithContext(ctx).Erc20Allowance(&token.Address, &args.Owner, &args.Spender)
 }
 
 // LogoURL resolves an URL of the token logo.
-func (token *ERC20Token) LogoURL() string {
-	return repository.R().Erc20LogoURL(&token.Address)
+func (token *ERC20Token) LogoURL(ctx context.Context) string {
+	return repository.R().WithContext(ctx).Erc20LogoURL(&token.Address)
+}
+
+// Description resolves the curated description of the token, if available.
+func (token *ERC20Token) Description() (*string, error) {
+	tm, err := tokenMeta(&token.Address)
+	if err != nil || tm == nil || tm.Description == "" {
+		return nil, err
+	}
+	return &tm.Description, nil
+}
+
+// Website resolves the curated website of the token, if available.
+func (token *ERC20Token) Website() (*string, error) {
+	tm, err := tokenMeta(&token.Address)
+	if err != nil || tm == nil || tm.Website == "" {
+		return nil, err
+	}
+	return &tm.Website, nil
+}
+
+// IsWhitelisted resolves the flag of the token being on the curated token list.
+func (token *ERC20Token) IsWhitelisted() (bool, error) {
+	tm, err := tokenMeta(&token.Address)
+	if err != nil || tm == nil {
+		return false, err
+	}
+	return tm.Whitelisted, nil
 }
 
 // TotalDeposit represents the total amount of tokens deposited to fMint as collateral.
-func (token *ERC20Token) TotalDeposit() (hexutil.Big, error) {
-	return repository.R().FMintTokenTotalBalance(&token.Address, types.DefiTokenTypeCollateral)
+func (token *ERC20Token) TotalDeposit(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).FMintTokenTotalBalance(&token.Address, types.DefiTokenTypeCollateral)
 }
 
 // TotalDebt represents the total amount of tokens borrowed/minted on fMint.
-func (token *ERC20Token) TotalDebt() (hexutil.Big, error) {
-	return repository.R().FMintTokenTotalBalance(&token.Address, types.DefiTokenTypeDebt)
+func (token *ERC20Token) TotalDebt(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).FMintTokenTotalBalance(&token.Address, types.DefiTokenTypeDebt)
+}
+
+// Price resolves the price of the token in the given target symbol routed through Uniswap pairs.
+func (token *ERC20Token) Price(ctx context.Context, args struct{ To string }) (*types.TokenPrice, error) {
+	// is the requested denomination even reasonable
+	if !reExpectedPriceSymbol.Match([]byte(args.To)) {
+		return nil, errInvalidArgument("invalid denomination received")
+	}
+	return repository.R().WithContext(ctx).Erc20TokenPrice(&token.Address, args.To)
+}
+
+// tokenMeta provides the curated meta information of the token, if available.
+func tokenMeta(adr *common.Address) (*types.TokenMeta, error) {
+	tm, err := lookups.do(lookupToken, "meta/"+adr.String(), func() (interface{}, error) {
+		return repository.R().TokenMeta(adr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tm.(*types.TokenMeta), nil
 }
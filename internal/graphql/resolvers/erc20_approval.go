@@ -0,0 +1,74 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ERC20Approval represents a resolvable allowance granted by a token owner to a spender.
+type ERC20Approval struct {
+	Trx       *types.Erc20Transaction
+	Allowance hexutil.Big
+}
+
+// Erc20Approvals resolves the list of allowances granted by the given owner
+// on ERC20 tokens. If activeOnly is set, allowances already used up,
+// or revoked are not listed.
+func (rs *rootResolver) Erc20Approvals(ctx context.Context, args struct {
+	Owner      common.Address
+	ActiveOnly bool
+}) ([]*ERC20Approval, error) {
+	// get the latest approvals
+	al, err := repository.R().WithContext(ctx).Erc20Approvals(&args.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	// check the current allowance of each
+	list := make([]*ERC20Approval, 0, len(al))
+	for _, trx := range al {
+		val, err := repository.R().WithContext(ctx).Erc20Allowance(&trx.TokenAddress, &trx.Sender, &trx.Recipient)
+		if err != nil {
+			return nil, err
+		}
+		if args.ActiveOnly && val.ToInt().Sign() == 0 {
+			continue
+		}
+		list = append(list, &ERC20Approval{Trx: trx, Allowance: val})
+	}
+	return list, nil
+}
+
+// Owner resolves the address of the token owner granting the allowance.
+func (ap *ERC20Approval) Owner() common.Address {
+	return ap.Trx.Sender
+}
+
+// Spender resolves the address allowed to spend the tokens.
+func (ap *ERC20Approval) Spender() common.Address {
+	return ap.Trx.Recipient
+}
+
+// TokenAddress resolves the address of the ERC20 token.
+func (ap *ERC20Approval) TokenAddress() common.Address {
+	return ap.Trx.TokenAddress
+}
+
+// Token resolves the ERC20 token of the allowance.
+func (ap *ERC20Approval) Token() *ERC20Token {
+	return NewErc20Token(&ap.Trx.TokenAddress)
+}
+
+// Approved resolves the amount of tokens approved by the latest approval.
+func (ap *ERC20Approval) Approved() hexutil.Big {
+	return ap.Trx.Amount
+}
+
+// LastUpdate resolves the latest approval transaction of the allowance.
+func (ap *ERC20Approval) LastUpdate() *ERC20Transaction {
+	return NewErc20Transaction(ap.Trx)
+}
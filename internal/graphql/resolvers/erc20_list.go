@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -10,13 +11,13 @@ import (
 const maxAssetTokensToLoad = 1000
 
 // Erc20TokenList resolves an instance of ERC20 token list if available.
-func (rs *rootResolver) Erc20TokenList(args struct{ Count int32 }) ([]*ERC20Token, error) {
+func (rs *rootResolver) Erc20TokenList(ctx context.Context, args struct{ Count int32 }) ([]*ERC20Token, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the list of addresses of active tokens
-	al, err := repository.R().Erc20TokensList(args.Count)
+	al, err := repository.R().WithContext(ctx).Erc20TokensList(args.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +32,7 @@ func (rs *rootResolver) Erc20TokenList(args struct{ Count int32 }) ([]*ERC20Toke
 }
 
 // Erc20Assets resolves a list of instances of ERC20 tokens for the given owner.
-func (rs *rootResolver) Erc20Assets(args struct {
+func (rs *rootResolver) Erc20Assets(ctx context.Context, args struct {
 	Owner common.Address
 	Count int32
 }) ([]*ERC20Token, error) {
@@ -40,17 +41,23 @@ func (rs *rootResolver) Erc20Assets(args struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the list of addresses of active tokens
-	al, err := repository.R().Erc20TokensList(maxAssetTokensToLoad)
+	al, err := repository.R().WithContext(ctx).Erc20TokensList(maxAssetTokensToLoad)
+	if err != nil {
+		return nil, err
+	}
+
+	// load balances of all the tokens at once
+	bal, err := repository.R().WithContext(ctx).Erc20BalancesOf(al, &args.Owner)
 	if err != nil {
 		return nil, err
 	}
 
 	// make the container and build the list (limit to recognized assets)
 	list := make([]*ERC20Token, 0)
-	for _, token := range al {
+	for i := range al {
 		// is there any balance of the token for the owner?
-		if rs.ownsErc20Asset(&token, &args.Owner) {
-			list = append(list, NewErc20Token(&token))
+		if 0 < bal[i].ToInt().Sign() {
+			list = append(list, NewErc20Token(&al[i]))
 			if args.Count == int32(len(list)) {
 				break
 			}
@@ -59,16 +66,3 @@ func (rs *rootResolver) Erc20Assets(args struct {
 
 	return list, nil
 }
-
-// ownsErc20Asset checks if the given owner has any tokens of the given ERC20.
-func (rs *rootResolver) ownsErc20Asset(token *common.Address, owner *common.Address) bool {
-	// get the balance for the owner
-	val, err := repository.R().Erc20BalanceOf(token, owner)
-	if err != nil {
-		rs.log.Errorf("token %s balance can not be loaded for %s; %s", token.String(), owner.String(), err.Error())
-		return false
-	}
-
-	// any balance?
-	return 0 < val.ToInt().Uint64()
-}
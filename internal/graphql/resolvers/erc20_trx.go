@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
@@ -23,9 +24,9 @@ func (trx *ERC20Transaction) TrxHash() common.Hash {
 }
 
 // Transaction resolves an instance of the transaction executing the ERC20 call.
-func (trx *ERC20Transaction) Transaction() (*Transaction, error) {
+func (trx *ERC20Transaction) Transaction(ctx context.Context) (*Transaction, error) {
 	// get the transaction from repo
-	tx, err := repository.R().Transaction(&trx.Erc20Transaction.Transaction)
+	tx, err := repository.R().WithContext(ctx).Transaction(&trx.Erc20Transaction.Transaction)
 	if err != nil {
 		return nil, err
 	}
@@ -35,8 +35,8 @@ func (txl *ERC20TransactionList) PageInfo() (*ListPageInfo, error) {
 	}
 
 	// get the first and last elements
-	first := Cursor(txl.Collection[0].ID)
-	last := Cursor(txl.Collection[len(txl.Collection)-1].ID)
+	first := Cursor(types.ListCursor(txl.Collection[0].OrdinalIndex()))
+	last := Cursor(types.ListCursor(txl.Collection[len(txl.Collection)-1].OrdinalIndex()))
 	return NewListPageInfo(&first, &last, !txl.IsEnd, !txl.IsStart)
 }
 
@@ -62,5 +62,5 @@ func (txl *ERC20TransactionList) Edges() []*ERC20TransactionListEdge {
 
 // Cursor resolves the ERC20 transaction cursor in the edges list.
 func (tle *ERC20TransactionListEdge) Cursor() Cursor {
-	return Cursor(tle.Trx.ID)
+	return Cursor(types.ListCursor(tle.Trx.OrdinalIndex()))
 }
@@ -0,0 +1,124 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/repository/rpc"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Error codes provided to clients in the extensions of GraphQL errors.
+const (
+	ErrCodeNotFound        = "NOT_FOUND"
+	ErrCodeRateLimited     = "RATE_LIMITED"
+	ErrCodeNodeUnavailable = "NODE_UNAVAILABLE"
+	ErrCodeInvalidArgument = "INVALID_ARGUMENT"
+	ErrCodeAccessDenied    = "ACCESS_DENIED"
+	ErrCodeMaintenance     = "MAINTENANCE"
+	ErrCodeRetryLater      = "RETRY_LATER"
+	ErrCodeTimeout         = "TIMEOUT"
+	ErrCodeCompliance      = "COMPLIANCE_DENIED"
+	ErrCodeInternal        = "INTERNAL"
+)
+
+// node RPC error codes translated to the API error codes
+const (
+	rpcCodeInvalidParams = -32602
+	rpcCodeLimitExceeded = -32005
+)
+
+// ApiError represents an error with machine readable code
+// the clients can use to branch on the error type.
+// Optional details are provided along with the code.
+type ApiError struct {
+	Code    string
+	Message string
+	Details map[string]interface{}
+}
+
+// Error returns the text of the error.
+func (e *ApiError) Error() string {
+	return e.Message
+}
+
+// Extensions provides the error code for the GraphQL response.
+func (e *ApiError) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"code": e.Code}
+	for k, v := range e.Details {
+		ext[k] = v
+	}
+	return ext
+}
+
+// errInvalidArgument creates a new error signalling invalid input of the API call.
+func errInvalidArgument(format string, args ...interface{}) error {
+	return &ApiError{Code: ErrCodeInvalidArgument, Message: fmt.Sprintf(format, args...)}
+}
+
+// errNotFound creates a new error signalling the requested entity does not exist.
+func errNotFound(format string, args ...interface{}) error {
+	return &ApiError{Code: ErrCodeNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+// complianceError translates the compliance screening rejection into the API error
+// with the details of the denied address; other errors are returned as they are.
+func complianceError(err error) error {
+	var ce *repository.ComplianceError
+	if !errors.As(err, &ce) {
+		return err
+	}
+	return &ApiError{Code: ErrCodeCompliance, Message: ce.Error(), Details: map[string]interface{}{
+		"address": ce.Match.Address.String(),
+		"list":    ce.Match.List,
+		"reason":  ce.Match.Reason,
+	}}
+}
+
+// ErrorCode translates the error of a resolver to the API error code.
+// Errors of the repository layer without any code attached are recognized
+// by their type; unknown errors are considered internal.
+func ErrorCode(err error) string {
+	// the code may already be provided by the error
+	var ext interface{ Extensions() map[string]interface{} }
+	if errors.As(err, &ext) {
+		if code, ok := ext.Extensions()["code"].(string); ok {
+			return code
+		}
+	}
+
+	// translate repository errors
+	var nde *rpc.NodeDegradedError
+	var ce *repository.ComplianceError
+	var rpe ethrpc.Error
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments), errors.Is(err, ethereum.NotFound):
+		return ErrCodeNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrCodeTimeout
+	case errors.Is(err, repository.ErrRateLimited):
+		return ErrCodeRateLimited
+	case errors.As(err, &ce):
+		return ErrCodeCompliance
+	case errors.As(err, &nde):
+		return ErrCodeNodeUnavailable
+	case errors.As(err, &rpe):
+		return rpcErrorCode(rpe)
+	}
+	return ErrCodeInternal
+}
+
+// rpcErrorCode translates the error code of a node RPC call to the API error code.
+func rpcErrorCode(err ethrpc.Error) string {
+	switch err.ErrorCode() {
+	case rpcCodeInvalidParams:
+		return ErrCodeInvalidArgument
+	case rpcCodeLimitExceeded:
+		return ErrCodeRateLimited
+	}
+	return ErrCodeInternal
+}
@@ -0,0 +1,219 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"errors"
+	gqlSchema "fantom-api-graphql/internal/graphql/schema"
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FederationAny represents an entity representation sent by a federation gateway.
+type FederationAny map[string]interface{}
+
+// FederationService represents resolvable subgraph information.
+type FederationService struct {
+	SDL *string
+}
+
+// FederationEntity represents resolvable union of federated entities.
+type FederationEntity struct {
+	entity interface{}
+}
+
+// ImplementsGraphQLType notifies the GraphQL that this type resolves _Any scalar.
+func (FederationAny) ImplementsGraphQLType(name string) bool {
+	return name == "_Any"
+}
+
+// UnmarshalGraphQL unmarshal incoming entity representation into a local variable.
+func (fa *FederationAny) UnmarshalGraphQL(input interface{}) error {
+	rep, ok := input.(map[string]interface{})
+	if !ok {
+		return errors.New("wrong entity representation type")
+	}
+
+	*fa = rep
+	return nil
+}
+
+// field provides a string value of the given representation field.
+func (fa FederationAny) field(name string) (string, error) {
+	val, ok := fa[name]
+	if !ok {
+		return "", errInvalidArgument("entity key %s not found", name)
+	}
+
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return hexutil.EncodeUint64(uint64(v)), nil
+	case int32:
+		return hexutil.EncodeUint64(uint64(v)), nil
+	}
+	return "", errInvalidArgument("invalid entity key %s", name)
+}
+
+// Service resolves the subgraph information for federation gateways.
+func (rs *rootResolver) Service() FederationService {
+	sdl := gqlSchema.FederationSDL()
+	return FederationService{SDL: &sdl}
+}
+
+// Entities resolves the list of entities referenced by a federation gateway.
+// Entities which can not be resolved are null at their position in the list,
+// only failures of the backends fail the whole list.
+func (rs *rootResolver) Entities(ctx context.Context, args struct{ Representations []FederationAny }) ([]*FederationEntity, error) {
+	list := make([]*FederationEntity, len(args.Representations))
+	for i, rep := range args.Representations {
+		ent, err := rs.entity(ctx, rep)
+		if err != nil {
+			rs.log.Errorf("can not resolve federated entity; %s", err.Error())
+			return nil, err
+		}
+		list[i] = ent
+	}
+	return list, nil
+}
+
+// entity resolves a single federated entity from its representation.
+// It returns nil if the representation does not identify a known entity.
+func (rs *rootResolver) entity(ctx context.Context, rep FederationAny) (*FederationEntity, error) {
+	tn, err := rep.field("__typename")
+	if err != nil {
+		rs.log.Warningf("invalid federated entity representation; %s", err.Error())
+		return nil, nil
+	}
+
+	// the type defines the key used
+	var ent interface{}
+	switch tn {
+	case "Account":
+		ent, err = rs.accountEntity(ctx, rep)
+	case "Transaction":
+		ent, err = rs.transactionEntity(ctx, rep)
+	case "Block":
+		ent, err = rs.blockEntity(ctx, rep)
+	case "ERC20Token":
+		ent, err = rs.erc20TokenEntity(rep)
+	default:
+		rs.log.Warningf("unknown federated entity type %s", tn)
+		return nil, nil
+	}
+
+	if err != nil || ent == nil {
+		return nil, err
+	}
+	return &FederationEntity{entity: ent}, nil
+}
+
+// accountEntity resolves the federated account; accounts denied by the compliance
+// screening are not resolved.
+func (rs *rootResolver) accountEntity(ctx context.Context, rep FederationAny) (interface{}, error) {
+	adr, err := rep.field("address")
+	if err != nil || !common.IsHexAddress(adr) {
+		rs.log.Warningf("invalid federated account representation")
+		return nil, nil
+	}
+
+	acc, err := rs.Account(ctx, struct{ Address common.Address }{Address: common.HexToAddress(adr)})
+	if err != nil {
+		var ae *ApiError
+		if errors.As(err, &ae) && ae.Code == ErrCodeCompliance {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return acc, nil
+}
+
+// transactionEntity resolves the federated transaction, nil if the transaction is not known.
+func (rs *rootResolver) transactionEntity(ctx context.Context, rep FederationAny) (interface{}, error) {
+	val, err := rep.field("hash")
+	if err != nil {
+		rs.log.Warningf("invalid federated transaction representation; %s", err.Error())
+		return nil, nil
+	}
+
+	// the node responds with an empty transaction if the hash is not known
+	hash := common.HexToHash(val)
+	trx, err := repository.R().WithContext(ctx).Transaction(&hash)
+	if err == repository.ErrTransactionNotFound || (err == nil && (trx == nil || trx.Hash != hash)) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// pending transaction will change once it's processed
+	if trx.BlockNumber == nil {
+		setCacheHint(ctx, 0)
+	}
+	return NewTransaction(trx), nil
+}
+
+// blockEntity resolves the federated block, nil if the block is not known.
+func (rs *rootResolver) blockEntity(ctx context.Context, rep FederationAny) (interface{}, error) {
+	num, err := rep.field("number")
+	if err != nil {
+		rs.log.Warningf("invalid federated block representation; %s", err.Error())
+		return nil, nil
+	}
+	val, err := hexutil.DecodeUint64(num)
+	if err != nil {
+		rs.log.Warningf("invalid federated block number %s", num)
+		return nil, nil
+	}
+
+	blk, err := repository.R().WithContext(ctx).BlockByNumber((*hexutil.Uint64)(&val))
+	if err == repository.ErrBlockNotFound || (err == nil && blk == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewBlock(blk), nil
+}
+
+// erc20TokenEntity resolves the federated ERC20 token, nil if the address is not a known token.
+func (rs *rootResolver) erc20TokenEntity(rep FederationAny) (interface{}, error) {
+	adr, err := rep.field("address")
+	if err != nil || !common.IsHexAddress(adr) {
+		rs.log.Warningf("invalid federated token representation")
+		return nil, nil
+	}
+
+	addr := common.HexToAddress(adr)
+	token := NewErc20Token(&addr)
+	if token == nil {
+		return nil, nil
+	}
+	return token, nil
+}
+
+// ToAccount resolves the entity as an account, if applicable.
+func (fe *FederationEntity) ToAccount() (*Account, bool) {
+	acc, ok := fe.entity.(*Account)
+	return acc, ok && acc != nil
+}
+
+// ToTransaction resolves the entity as a transaction, if applicable.
+func (fe *FederationEntity) ToTransaction() (*Transaction, bool) {
+	trx, ok := fe.entity.(*Transaction)
+	return trx, ok && trx != nil
+}
+
+// ToBlock resolves the entity as a block, if applicable.
+func (fe *FederationEntity) ToBlock() (*Block, bool) {
+	blk, ok := fe.entity.(*Block)
+	return blk, ok && blk != nil
+}
+
+// ToERC20Token resolves the entity as an ERC20 token, if applicable.
+func (fe *FederationEntity) ToERC20Token() (*ERC20Token, bool) {
+	token, ok := fe.entity.(*ERC20Token)
+	return token, ok && token != nil
+}
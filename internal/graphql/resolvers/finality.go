@@ -0,0 +1,70 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// FinalityStats represents resolvable finality latency statistics.
+type FinalityStats struct {
+	types.FinalityStats
+}
+
+// FinalityStats resolves the finality latency statistics of blocks in the given time range.
+func (rs *rootResolver) FinalityStats(ctx context.Context, args *struct{ Range hexutil.Uint64 }) (*FinalityStats, error) {
+	fs, err := repository.R().WithContext(ctx).FinalityStats(time.Duration(args.Range) * time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return &FinalityStats{FinalityStats: *fs}, nil
+}
+
+// TimeToFinality resolves the finality latency of the block in milliseconds.
+func (blk *Block) TimeToFinality(ctx context.Context) (*hexutil.Uint64, error) {
+	bf, err := repository.R().WithContext(ctx).BlockFinality(uint64(blk.Number))
+	if err != nil || bf == nil {
+		return nil, err
+	}
+
+	val := hexutil.Uint64(bf.TimeToFinality)
+	return &val, nil
+}
+
+// Blocks resolves the number of blocks with known finality latency.
+func (fs *FinalityStats) Blocks() hexutil.Uint64 {
+	return hexutil.Uint64(fs.FinalityStats.Blocks)
+}
+
+// AvgBlockTime resolves the average time between blocks.
+func (fs *FinalityStats) AvgBlockTime() hexutil.Uint64 {
+	return hexutil.Uint64(fs.FinalityStats.AvgBlockTime)
+}
+
+// Min resolves the lowest observed time to finality.
+func (fs *FinalityStats) Min() hexutil.Uint64 {
+	return hexutil.Uint64(fs.FinalityStats.Min)
+}
+
+// Max resolves the highest observed time to finality.
+func (fs *FinalityStats) Max() hexutil.Uint64 {
+	return hexutil.Uint64(fs.FinalityStats.Max)
+}
+
+// Avg resolves the average time to finality.
+func (fs *FinalityStats) Avg() hexutil.Uint64 {
+	return hexutil.Uint64(fs.FinalityStats.Avg)
+}
+
+// Median resolves the median time to finality.
+func (fs *FinalityStats) Median() hexutil.Uint64 {
+	return hexutil.Uint64(fs.FinalityStats.Median)
+}
+
+// P95 resolves the 95th percentile of time to finality.
+func (fs *FinalityStats) P95() hexutil.Uint64 {
+	return hexutil.Uint64(fs.FinalityStats.P95)
+}
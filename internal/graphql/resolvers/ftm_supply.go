@@ -0,0 +1,95 @@
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ftmSupplyDefaultHistory is the number of epochs provided by the supply history by default.
+const ftmSupplyDefaultHistory = 100
+
+// FtmSupply represents resolvable state of the native FTM token supply.
+type FtmSupply struct {
+	types.FtmSupply
+}
+
+// FtmSupplyEpoch represents resolvable state of the FTM supply on a sealed epoch.
+type FtmSupplyEpoch struct {
+	types.FtmSupply
+}
+
+// FtmSupply resolves the current state of the native FTM token supply.
+func (rs *rootResolver) FtmSupply(ctx context.Context) (*FtmSupply, error) {
+	fs, err := repository.R().WithContext(ctx).FtmSupply()
+	if err != nil {
+		rs.log.Errorf("can not get ftm supply; %s", err.Error())
+		return nil, err
+	}
+	return &FtmSupply{*fs}, nil
+}
+
+// TimeStamp resolves the time stamp of the epoch sealing.
+func (fs *FtmSupply) TimeStamp() hexutil.Uint64 {
+	return fs.EndTime
+}
+
+// Total resolves the total supply of FTM tokens.
+func (fs *FtmSupply) Total() hexutil.Big {
+	return fs.TotalSupply
+}
+
+// Burned resolves the cumulative amount of burned fees.
+func (fs *FtmSupply) Burned() hexutil.Big {
+	return fs.TotalBurned
+}
+
+// History resolves the supply history of the given range of epochs.
+func (fs *FtmSupply) History(ctx context.Context, args struct {
+	From *hexutil.Uint64
+	To   *hexutil.Uint64
+}) ([]*FtmSupplyEpoch, error) {
+	// the range ends on the latest tracked epoch by default
+	to := uint64(fs.Epoch)
+	if args.To != nil {
+		to = uint64(*args.To)
+	}
+
+	// the range starts a number of epochs before the end
+	var from uint64
+	if to > ftmSupplyDefaultHistory {
+		from = to - ftmSupplyDefaultHistory + 1
+	}
+	if args.From != nil {
+		from = uint64(*args.From)
+	}
+
+	// make sure the from is before to
+	if from > to {
+		return nil, errInvalidArgument("invalid epoch range received")
+	}
+
+	// load data
+	hs, err := repository.R().WithContext(ctx).FtmSupplyHistory(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// load the list
+	list := make([]*FtmSupplyEpoch, len(hs))
+	for i, v := range hs {
+		list[i] = &FtmSupplyEpoch{*v}
+	}
+	return list, nil
+}
+
+// TimeStamp resolves the time stamp of the epoch sealing.
+func (fse *FtmSupplyEpoch) TimeStamp() hexutil.Uint64 {
+	return fse.EndTime
+}
+
+// Total resolves the total supply of FTM tokens on the epoch end.
+func (fse *FtmSupplyEpoch) Total() hexutil.Big {
+	return fse.TotalSupply
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
@@ -22,9 +23,9 @@ type GovernanceContract struct {
 }
 
 // GovContract resolves a governance contract details recognized by the API by address.
-func (rs *rootResolver) GovContract(args struct{ Address common.Address }) (*GovernanceContract, error) {
+func (rs *rootResolver) GovContract(ctx context.Context, args struct{ Address common.Address }) (*GovernanceContract, error) {
 	// get the contract by the address
-	gc, err := repository.R().GovernanceContractBy(&args.Address)
+	gc, err := repository.R().WithContext(ctx).GovernanceContractBy(&args.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -57,17 +58,28 @@ func (rs *rootResolver) GovContracts() ([]*GovernanceContract, error) {
 	return res, nil
 }
 
+// GovVotingPower resolves the voting weight of an address in the given governance
+// contract at a past point identified by a block, or by an epoch.
+func (rs *rootResolver) GovVotingPower(ctx context.Context, args *struct {
+	Address  common.Address
+	Contract common.Address
+	Block    *hexutil.Uint64
+	Epoch    *hexutil.Uint64
+}) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).GovernanceVotingPower(&args.Contract, &args.Address, args.Block, args.Epoch)
+}
+
 // TotalProposals resolves the number of proposals registered within
 // the governance contract.
-func (gc *GovernanceContract) TotalProposals() (hexutil.Big, error) {
-	return repository.R().GovernanceProposalsCount(&gc.Address)
+func (gc *GovernanceContract) TotalProposals(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).GovernanceProposalsCount(&gc.Address)
 }
 
 // Proposal resolves single proposal of the Governance contract specified
 // by the proposal id inside the contract.
-func (gc *GovernanceContract) Proposal(args *struct{ Id hexutil.Big }) (*GovernanceProposal, error) {
+func (gc *GovernanceContract) Proposal(ctx context.Context, args *struct{ Id hexutil.Big }) (*GovernanceProposal, error) {
 	// get the proposal
-	prop, err := repository.R().GovernanceProposal(&gc.Address, &args.Id)
+	prop, err := repository.R().WithContext(ctx).GovernanceProposal(&gc.Address, &args.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +89,7 @@ func (gc *GovernanceContract) Proposal(args *struct{ Id hexutil.Big }) (*Governa
 }
 
 // Proposals resolves list of Governance contract proposals encapsulated in a listable structure.
-func (gc *GovernanceContract) Proposals(args *struct {
+func (gc *GovernanceContract) Proposals(ctx context.Context, args *struct {
 	Cursor     *Cursor
 	Count      int32
 	ActiveOnly bool
@@ -87,7 +99,7 @@ func (gc *GovernanceContract) Proposals(args *struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the list of all proposals
-	list, err := repository.R().GovernanceProposals([]*common.Address{&gc.Address}, (*string)(args.Cursor), args.Count, args.ActiveOnly)
+	list, err := repository.R().WithContext(ctx).GovernanceProposals([]*common.Address{&gc.Address}, (*string)(args.Cursor), args.Count, args.ActiveOnly)
 	if err != nil {
 		return nil, err
 	}
@@ -97,35 +109,35 @@ func (gc *GovernanceContract) Proposals(args *struct {
 
 // DelegationsBy resolves list of delegations an address has in context of the given
 // governance contract.
-func (gc *GovernanceContract) DelegationsBy(args struct{ From common.Address }) ([]common.Address, error) {
+func (gc *GovernanceContract) DelegationsBy(ctx context.Context, args struct{ From common.Address }) ([]common.Address, error) {
 	// decide by the contract type
 	switch gc.Type {
 	case "sfc":
-		return gc.sfcDelegationsBy(args.From)
+		return gc.sfcDelegationsBy(ctx, args.From)
 	}
 
 	// no delegations by default
-	repository.R().Log().Debugf("unknown governance type of %s", gc.Address.Hex())
+	repository.R().WithContext(ctx).Log().Debugf("unknown governance type of %s", gc.Address.Hex())
 	return []common.Address{}, nil
 }
 
 // CanVote resolves if the given address can post votes in context of the given governance contract.
-func (gc *GovernanceContract) CanVote(args struct{ From common.Address }) (bool, error) {
+func (gc *GovernanceContract) CanVote(ctx context.Context, args struct{ From common.Address }) (bool, error) {
 	// decide by the contract type
 	switch gc.Type {
 	case "sfc":
-		return gc.sfcCanVote(args.From)
+		return gc.sfcCanVote(ctx, args.From)
 	}
 
 	// voting disabled by default
-	repository.R().Log().Debugf("unknown governance type of %s", gc.Address.Hex())
+	repository.R().WithContext(ctx).Log().Debugf("unknown governance type of %s", gc.Address.Hex())
 	return false, nil
 }
 
 // sfcDelegationsBy resolves delegations of the SFC type.
-func (gc *GovernanceContract) sfcDelegationsBy(addr common.Address) ([]common.Address, error) {
+func (gc *GovernanceContract) sfcDelegationsBy(ctx context.Context, addr common.Address) ([]common.Address, error) {
 	// get SFC delegations list
-	dl, err := repository.R().DelegationsByAddressAll(&addr)
+	dl, err := repository.R().WithContext(ctx).DelegationsByAddressAll(&addr)
 	if err != nil {
 		return nil, err
 	}
@@ -135,30 +147,30 @@ func (gc *GovernanceContract) sfcDelegationsBy(addr common.Address) ([]common.Ad
 	for _, d := range dl {
 		// is the delegation ok for voting?
 		if 0 == d.AmountDelegated.ToInt().Uint64() {
-			repository.R().Log().Debugf("delegation to %d from address %s is deactivated", d.ToStakerId, addr.String())
+			repository.R().WithContext(ctx).Log().Debugf("delegation to %d from address %s is deactivated", d.ToStakerId, addr.String())
 			continue
 		}
 		res = append(res, d.ToStakerAddress)
 	}
 
 	// log delegations found
-	repository.R().Log().Debugf("%d delegations on %s", len(res), addr.String())
+	repository.R().WithContext(ctx).Log().Debugf("%d delegations on %s", len(res), addr.String())
 	return res, nil
 }
 
 // sfcCanVote resolves if a given address can vote in SFC governance context.
-func (gc *GovernanceContract) sfcCanVote(addr common.Address) (bool, error) {
+func (gc *GovernanceContract) sfcCanVote(ctx context.Context, addr common.Address) (bool, error) {
 	// even validators are actually delegating to themself on SFCv3
-	return repository.R().IsDelegating(&addr)
+	return repository.R().WithContext(ctx).IsDelegating(&addr)
 }
 
 // ProposalFee resolves the fee required by the Governance contract to allow
 // new proposal to be placed.
-func (gc *GovernanceContract) ProposalFee() (hexutil.Big, error) {
-	return repository.R().GovernanceProposalFee(&gc.Address)
+func (gc *GovernanceContract) ProposalFee(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).GovernanceProposalFee(&gc.Address)
 }
 
 // TotalVotingPower resolves the total available voting power.
-func (gc *GovernanceContract) TotalVotingPower() (hexutil.Big, error) {
-	return repository.R().GovernanceTotalWeight(&gc.Address)
+func (gc *GovernanceContract) TotalVotingPower(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).GovernanceTotalWeight(&gc.Address)
 }
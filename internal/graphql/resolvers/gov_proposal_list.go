@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -28,7 +29,7 @@ func NewGovernanceProposalList(gps *types.GovernanceProposalList) *GovernancePro
 
 // GovProposals resolves list of proposals across all the known governance
 // contracts in a browsable structure.
-func (rs *rootResolver) GovProposals(args struct {
+func (rs *rootResolver) GovProposals(ctx context.Context, args struct {
 	Cursor     *Cursor
 	Count      int32
 	ActiveOnly bool
@@ -44,7 +45,7 @@ func (rs *rootResolver) GovProposals(args struct {
 	}
 
 	// get the list of all proposals
-	list, err := repository.R().GovernanceProposals(gcl, (*string)(args.Cursor), args.Count, args.ActiveOnly)
+	list, err := repository.R().WithContext(ctx).GovernanceProposals(gcl, (*string)(args.Cursor), args.Count, args.ActiveOnly)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,161 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BackendHealth represents resolvable health of a backend of the API server.
+type BackendHealth struct {
+	types.BackendHealth
+}
+
+// NodeHealth represents resolvable health of the block chain node.
+type NodeHealth struct {
+	types.NodeHealth
+}
+
+// CacheStats represents resolvable statistics of the in-memory cache.
+type CacheStats struct {
+	types.CacheStats
+}
+
+// DbPoolStats represents resolvable statistics of the database connection pool.
+type DbPoolStats struct {
+	types.DbPoolStats
+}
+
+// IndexerLag resolves the number of blocks the off-chain database is behind the chain head.
+func (cst CurrentState) IndexerLag(ctx context.Context) (hexutil.Uint64, error) {
+	lag, err := repository.R().WithContext(ctx).IndexerLag()
+	return hexutil.Uint64(lag), err
+}
+
+// Database resolves the health of the off-chain database.
+func (cst CurrentState) Database(ctx context.Context) *BackendHealth {
+	return &BackendHealth{BackendHealth: repository.R().WithContext(ctx).DatabaseHealth()}
+}
+
+// Node resolves the health of the block chain node.
+func (cst CurrentState) Node(ctx context.Context) *NodeHealth {
+	return &NodeHealth{NodeHealth: repository.R().WithContext(ctx).NodeHealth()}
+}
+
+// Cache resolves the statistics of the in-memory cache.
+func (cst CurrentState) Cache(ctx context.Context) *CacheStats {
+	return &CacheStats{CacheStats: repository.R().WithContext(ctx).CacheStats()}
+}
+
+// DatabasePool resolves the statistics of the database connection pool.
+func (cst CurrentState) DatabasePool(ctx context.Context) *DbPoolStats {
+	return &DbPoolStats{DbPoolStats: repository.R().WithContext(ctx).DatabasePoolStats()}
+}
+
+// Services resolves the list of internal services of the API server.
+func (cst CurrentState) Services(ctx context.Context) []*ServiceState {
+	list := repository.R().WithContext(ctx).ServiceStates()
+	res := make([]*ServiceState, len(list))
+	for i, st := range list {
+		res[i] = &ServiceState{ServiceState: st}
+	}
+	return res
+}
+
+// Latency resolves the time in milliseconds the backend took to respond.
+func (bh *BackendHealth) Latency() hexutil.Uint64 {
+	return hexutil.Uint64(bh.BackendHealth.Latency.Milliseconds())
+}
+
+// Error resolves the reason the backend is not healthy, if any.
+func (bh *BackendHealth) Error() *string {
+	if bh.BackendHealth.Error == "" {
+		return nil
+	}
+	return &bh.BackendHealth.Error
+}
+
+// Latency resolves the time in milliseconds the node took to respond.
+func (nh *NodeHealth) Latency() hexutil.Uint64 {
+	return hexutil.Uint64(nh.NodeHealth.Latency.Milliseconds())
+}
+
+// Error resolves the reason the node is not healthy, if any.
+func (nh *NodeHealth) Error() *string {
+	if nh.NodeHealth.Error == "" {
+		return nil
+	}
+	return &nh.NodeHealth.Error
+}
+
+// Height resolves the number of the most recent block known to the node.
+func (nh *NodeHealth) Height() hexutil.Uint64 {
+	return hexutil.Uint64(nh.NodeHealth.Height)
+}
+
+// Peers resolves the number of peers the node is connected to.
+func (nh *NodeHealth) Peers() hexutil.Uint64 {
+	return hexutil.Uint64(nh.NodeHealth.Peers)
+}
+
+// Entries resolves the number of entries stored in the cache.
+func (cs *CacheStats) Entries() hexutil.Uint64 {
+	return hexutil.Uint64(cs.CacheStats.Entries)
+}
+
+// Capacity resolves the size of the memory allocated by the cache.
+func (cs *CacheStats) Capacity() hexutil.Uint64 {
+	return hexutil.Uint64(cs.CacheStats.Capacity)
+}
+
+// Hits resolves the number of successful cache lookups.
+func (cs *CacheStats) Hits() hexutil.Uint64 {
+	return hexutil.Uint64(cs.CacheStats.Hits)
+}
+
+// Misses resolves the number of cache lookups of missing entries.
+func (cs *CacheStats) Misses() hexutil.Uint64 {
+	return hexutil.Uint64(cs.CacheStats.Misses)
+}
+
+// Collisions resolves the number of cache key collisions.
+func (cs *CacheStats) Collisions() hexutil.Uint64 {
+	return hexutil.Uint64(cs.CacheStats.Collisions)
+}
+
+// Open resolves the number of open connections in the pool.
+func (ps *DbPoolStats) Open() hexutil.Uint64 {
+	return hexutil.Uint64(ps.DbPoolStats.Open)
+}
+
+// InUse resolves the number of connections checked out of the pool.
+func (ps *DbPoolStats) InUse() hexutil.Uint64 {
+	return hexutil.Uint64(ps.DbPoolStats.InUse)
+}
+
+// Idle resolves the number of open connections waiting in the pool.
+func (ps *DbPoolStats) Idle() hexutil.Uint64 {
+	return hexutil.Uint64(ps.DbPoolStats.Idle)
+}
+
+// MaxSize resolves the configured max size of the pool.
+func (ps *DbPoolStats) MaxSize() hexutil.Uint64 {
+	return hexutil.Uint64(ps.DbPoolStats.MaxSize)
+}
+
+// CheckOuts resolves the number of successful connection check outs.
+func (ps *DbPoolStats) CheckOuts() hexutil.Uint64 {
+	return hexutil.Uint64(ps.DbPoolStats.CheckOuts)
+}
+
+// CheckOutFailures resolves the number of failed connection check outs.
+func (ps *DbPoolStats) CheckOutFailures() hexutil.Uint64 {
+	return hexutil.Uint64(ps.DbPoolStats.CheckOutFails)
+}
+
+// Cleared resolves the number of times the pool was cleared.
+func (ps *DbPoolStats) Cleared() hexutil.Uint64 {
+	return hexutil.Uint64(ps.DbPoolStats.Cleared)
+}
@@ -0,0 +1,172 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strings"
+)
+
+const (
+	// labelMaxNameLength is the maximum accepted length of an address label name.
+	labelMaxNameLength = 64
+
+	// labelMaxWebsiteLength is the maximum accepted length of an address label website.
+	labelMaxWebsiteLength = 128
+
+	// labelMaxNoteLength is the maximum accepted length of an address label note.
+	labelMaxNoteLength = 256
+)
+
+// AddressLabel represents resolvable public label of an address.
+type AddressLabel struct {
+	types.AddressLabel
+}
+
+// AddressLabelInput represents an input structure used
+// to assign a public label to an address.
+type AddressLabelInput struct {
+	// Address represents the labeled address.
+	Address common.Address `json:"address"`
+
+	// Name represents the public name of the address owner.
+	Name string `json:"name"`
+
+	// Category represents the category of the address.
+	Category string `json:"category"`
+
+	// Website represents an optional website of the address owner.
+	Website *string `json:"website,omitempty"`
+
+	// Note represents an optional note about the address.
+	Note *string `json:"note,omitempty"`
+}
+
+// NewAddressLabel creates a new instance of resolvable address label.
+func NewAddressLabel(al *types.AddressLabel) *AddressLabel {
+	if al == nil {
+		return nil
+	}
+	return &AddressLabel{AddressLabel: *al}
+}
+
+// Updated resolves the unix timestamp of the latest label update.
+func (al *AddressLabel) Updated() hexutil.Uint64 {
+	return hexutil.Uint64(al.AddressLabel.Updated.Unix())
+}
+
+// Website resolves the optional website of the address owner.
+func (al *AddressLabel) Website() *string {
+	if al.AddressLabel.Website == "" {
+		return nil
+	}
+	return &al.AddressLabel.Website
+}
+
+// Note resolves the optional note about the address.
+func (al *AddressLabel) Note() *string {
+	if al.AddressLabel.Note == "" {
+		return nil
+	}
+	return &al.AddressLabel.Note
+}
+
+// addressLabel resolves the public label of the given address, if any.
+func addressLabel(ctx context.Context, addr *common.Address) (*AddressLabel, error) {
+	al, err := repository.R().WithContext(ctx).AddressLabel(addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewAddressLabel(al), nil
+}
+
+// AddressLabel resolves the public label assigned to the given address.
+func (rs *rootResolver) AddressLabel(ctx context.Context, args *struct{ Address common.Address }) (*AddressLabel, error) {
+	return addressLabel(ctx, &args.Address)
+}
+
+// SetAddressLabel assigns a public label to an address. The operation
+// requires a privileged API key.
+func (rs *rootResolver) SetAddressLabel(ctx context.Context, args *struct{ Label AddressLabelInput }) (*AddressLabel, error) {
+	// only admins can manage labels
+	if !rs.isAdmin(ctx) {
+		return nil, ErrAccessDenied
+	}
+
+	// validate the input
+	al, err := addressLabelFromInput(&args.Label)
+	if err != nil {
+		return nil, err
+	}
+
+	// store the label
+	if err := repository.R().WithContext(ctx).StoreAddressLabel(al); err != nil {
+		rs.log.Errorf("can not store label of %s; %s", al.Address.String(), err.Error())
+		return nil, err
+	}
+
+	rs.log.Noticef("address %s labeled as %s [%s]", al.Address.String(), al.Name, al.Category)
+	return NewAddressLabel(al), nil
+}
+
+// RemoveAddressLabel removes the public label of an address. The operation
+// requires a privileged API key.
+func (rs *rootResolver) RemoveAddressLabel(ctx context.Context, args *struct{ Address common.Address }) (bool, error) {
+	// only admins can manage labels
+	if !rs.isAdmin(ctx) {
+		return false, ErrAccessDenied
+	}
+
+	// remove the label
+	if err := repository.R().WithContext(ctx).RemoveAddressLabel(&args.Address); err != nil {
+		rs.log.Errorf("can not remove label of %s; %s", args.Address.String(), err.Error())
+		return false, err
+	}
+
+	rs.log.Noticef("label of address %s removed", args.Address.String())
+	return true, nil
+}
+
+// addressLabelFromInput validates the label input and builds the address label from it.
+func addressLabelFromInput(in *AddressLabelInput) (*types.AddressLabel, error) {
+	// check the category
+	cat := strings.ToUpper(in.Category)
+	if !types.IsValidAddressLabelCategory(cat) {
+		return nil, errInvalidArgument("unknown label category %s", in.Category)
+	}
+
+	// check the name
+	name := strings.TrimSpace(in.Name)
+	ok, nm := sanitizeStringOption(&name, labelMaxNameLength)
+	if !ok || len(*nm) == 0 {
+		return nil, errInvalidArgument("label name is empty or too long to be valid")
+	}
+
+	// check the website
+	ok, web := sanitizeStringOption(in.Website, labelMaxWebsiteLength)
+	if !ok {
+		return nil, errInvalidArgument("label website is too long to be valid")
+	}
+
+	// check the note
+	ok, note := sanitizeStringOption(in.Note, labelMaxNoteLength)
+	if !ok {
+		return nil, errInvalidArgument("label note is too long to be valid")
+	}
+
+	al := types.AddressLabel{
+		Address:  in.Address,
+		Name:     *nm,
+		Category: cat,
+	}
+	if web != nil {
+		al.Website = *web
+	}
+	if note != nil {
+		al.Note = *note
+	}
+	return &al, nil
+}
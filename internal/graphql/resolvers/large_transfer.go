@@ -0,0 +1,217 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// onLargeTransferChannelCapacity is the number of large transfer events held in memory for being broadcast to subscriber.
+const onLargeTransferChannelCapacity = 100
+
+// erc20TransferTopic is the topic of the ERC20 Transfer(address,address,uint256) event.
+var erc20TransferTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+// LargeTransfer represents resolvable transfer of native tokens,
+// or an ERC20 token above a subscribed threshold.
+type LargeTransfer struct {
+	Token     *common.Address
+	TrxHash   common.Hash
+	From      common.Address
+	To        common.Address
+	Amount    hexutil.Big
+	TimeStamp hexutil.Uint64
+}
+
+// subscriptOnLargeTransfer represents reference to a subscriber to onLargeTransfer events broadcast.
+type subscriptOnLargeTransfer struct {
+	minValue *big.Int
+	token    *common.Address
+	stop     <-chan struct{}
+	events   chan<- *LargeTransfer
+}
+
+// LargeTransfers resolves list of native token transfers of at least the given value.
+func (rs *rootResolver) LargeTransfers(ctx context.Context, args *struct {
+	MinValue hexutil.Big
+	Cursor   *Cursor
+	Count    int32
+}) (*TransactionList, error) {
+	if args.MinValue.ToInt().Sign() <= 0 {
+		return nil, errInvalidArgument("minimal value must be positive")
+	}
+
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	txs, err := repository.R().WithContext(ctx).LargeTransfers(args.MinValue.ToInt(), (*string)(args.Cursor), args.Count)
+	if err != nil {
+		rs.log.Errorf("can not get large transfers list; %s", err.Error())
+		return nil, err
+	}
+	return NewTransactionList(txs), nil
+}
+
+// LargeErc20Transfers resolves list of transfers of the given ERC20 token of at least the given amount.
+func (rs *rootResolver) LargeErc20Transfers(ctx context.Context, args *struct {
+	Token    common.Address
+	MinValue hexutil.Big
+	Cursor   *Cursor
+	Count    int32
+}) (*ERC20TransactionList, error) {
+	if args.MinValue.ToInt().Sign() <= 0 {
+		return nil, errInvalidArgument("minimal value must be positive")
+	}
+
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	tl, err := repository.R().WithContext(ctx).LargeErc20Transfers(&args.Token, args.MinValue.ToInt(), (*string)(args.Cursor), args.Count)
+	if err != nil {
+		rs.log.Errorf("can not get large ERC20 transfers list; %s", err.Error())
+		return nil, err
+	}
+	return NewERC20TransactionList(tl), nil
+}
+
+// OnLargeTransfer resolves subscription to transfers of native tokens, or the given
+// ERC20 token, of at least the given value.
+func (rs *rootResolver) OnLargeTransfer(ctx context.Context, args *struct {
+	MinValue hexutil.Big
+	Token    *common.Address
+}) (<-chan *LargeTransfer, error) {
+	if args.MinValue.ToInt().Sign() <= 0 {
+		return nil, errInvalidArgument("minimal value must be positive")
+	}
+
+	// make the stream
+	c := make(chan *LargeTransfer, onLargeTransferChannelCapacity)
+
+	// subscribe to event dispatch
+	rs.subscribeOnLargeTransfer <- &subscriptOnLargeTransfer{
+		minValue: args.MinValue.ToInt(),
+		token:    args.Token,
+		stop:     ctx.Done(),
+		events:   c,
+	}
+	return c, nil
+}
+
+// Transaction resolves the transaction carrying the transfer.
+func (lt *LargeTransfer) Transaction(ctx context.Context) (*Transaction, error) {
+	trx, err := repository.R().WithContext(ctx).Transaction(&lt.TrxHash)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransaction(trx), nil
+}
+
+// addLargeTransferSubscriber adds a new subscription to onLargeTransfer events.
+func (rs *rootResolver) addLargeTransferSubscriber(sub *subscriptOnLargeTransfer) {
+	id, err := uuid()
+	if err == nil {
+		// add the subscriber to the map
+		rs.largeTransferSubscribers[id] = sub
+	} else {
+		// log critical issue
+		rs.log.Critical("can not generate UUID for new onLargeTransfer subscriber")
+		rs.log.Critical(err)
+	}
+}
+
+// transfersOf extracts native and ERC20 token transfers of the given transaction.
+func transfersOf(trx *types.Transaction) []*LargeTransfer {
+	list := make([]*LargeTransfer, 0)
+	ts := hexutil.Uint64(trx.TimeStamp.Unix())
+
+	// native tokens transfer
+	if trx.To != nil && trx.Value.ToInt().Sign() > 0 {
+		list = append(list, &LargeTransfer{
+			TrxHash:   trx.Hash,
+			From:      trx.From,
+			To:        *trx.To,
+			Amount:    trx.Value,
+			TimeStamp: ts,
+		})
+	}
+
+	// ERC20 transfers; ERC721 transfers carry the token id in topics instead of the amount
+	for i := range trx.Logs {
+		lg := &trx.Logs[i]
+		if len(lg.Topics) != 3 || len(lg.Data) != 32 || lg.Topics[0] != erc20TransferTopic {
+			continue
+		}
+
+		token := lg.Address
+		list = append(list, &LargeTransfer{
+			Token:     &token,
+			TrxHash:   trx.Hash,
+			From:      common.BytesToAddress(lg.Topics[1].Bytes()),
+			To:        common.BytesToAddress(lg.Topics[2].Bytes()),
+			Amount:    hexutil.Big(*new(big.Int).SetBytes(lg.Data)),
+			TimeStamp: ts,
+		})
+	}
+	return list
+}
+
+// matches checks if the transfer is subscribed by the subscriber.
+func (sub *subscriptOnLargeTransfer) matches(lt *LargeTransfer) bool {
+	if (sub.token == nil) != (lt.Token == nil) {
+		return false
+	}
+	if sub.token != nil && *sub.token != *lt.Token {
+		return false
+	}
+	return lt.Amount.ToInt().Cmp(sub.minValue) >= 0
+}
+
+// dispatchOnLargeTransfer dispatches onLargeTransfer events of the transaction to registered subscribers.
+func (rs *rootResolver) dispatchOnLargeTransfer(trx *types.Transaction) {
+	// nothing to do without subscribers
+	if len(rs.largeTransferSubscribers) == 0 {
+		return
+	}
+
+	list := transfersOf(trx)
+	for id, sub := range rs.largeTransferSubscribers {
+		for _, lt := range list {
+			if sub.matches(lt) {
+				go rs.notifyOnLargeTransfer(lt, sub, id)
+			}
+		}
+	}
+}
+
+// notifyOnLargeTransfer broadcasts onLargeTransfer event to given subscriber.
+func (rs *rootResolver) notifyOnLargeTransfer(lt *LargeTransfer, sub *subscriptOnLargeTransfer, id string) {
+	// check if the context isn't already closed in which case we just unsub and leave
+	select {
+	case <-sub.stop:
+		rs.unsubscribeOnLargeTransfer <- id
+		return
+	default:
+	}
+
+	// broadcast
+	select {
+	case <-sub.stop:
+		// just unsub on broken context
+		rs.unsubscribeOnLargeTransfer <- id
+
+	case sub.events <- lt:
+		// push the transfer to subscriber
+
+	case <-time.After(time.Second):
+		// timeout reached without response? just remove the subscriber
+		rs.unsubscribeOnLargeTransfer <- id
+	}
+}
@@ -0,0 +1,71 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"golang.org/x/sync/singleflight"
+	"sync"
+	"time"
+)
+
+// lookupConcurrencyLimit represents the max number of distinct lookups
+// of the same kind executed at once.
+const lookupConcurrencyLimit = 16
+
+// lookupSlotTimeout represents the max time a lookup waits for a free execution slot.
+const lookupSlotTimeout = 5 * time.Second
+
+// kinds of the expensive lookups
+const (
+	lookupState   = "state"
+	lookupStakers = "stakers"
+	lookupPairs   = "pairs"
+	lookupToken   = "token"
+)
+
+// ErrBusy represents an error returned if an expensive lookup
+// could not get an execution slot in time.
+var ErrBusy error = &ApiError{Code: ErrCodeRateLimited, Message: "too many concurrent requests, please try again later"}
+
+// lookupGroup deduplicates identical concurrent lookups and caps the number
+// of distinct lookups of the same kind running at once, so a burst of requests
+// doesn't turn into a burst of calls to the node.
+type lookupGroup struct {
+	cg    singleflight.Group
+	mu    sync.Mutex
+	slots map[string]chan bool
+}
+
+// lookups represents the lookup group shared by all the resolvers.
+var lookups = lookupGroup{slots: make(map[string]chan bool)}
+
+// do executes the lookup of the given kind identified by the key. Identical lookups
+// executed concurrently share a single execution and its result.
+func (lg *lookupGroup) do(kind string, key string, fn func() (interface{}, error)) (interface{}, error) {
+	val, err, _ := lg.cg.Do(kind+"/"+key, func() (interface{}, error) {
+		slot := lg.slot(kind)
+
+		// wait for a free slot
+		select {
+		case slot <- true:
+		case <-time.After(lookupSlotTimeout):
+			return nil, ErrBusy
+		}
+
+		defer func() { <-slot }()
+		return fn()
+	})
+	return val, err
+}
+
+// slot provides the execution slots of the given lookup kind.
+func (lg *lookupGroup) slot(kind string) chan bool {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+
+	slot, ok := lg.slots[kind]
+	if !ok {
+		slot = make(chan bool, lookupConcurrencyLimit)
+		lg.slots[kind] = slot
+	}
+	return slot
+}
@@ -0,0 +1,56 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"sync/atomic"
+)
+
+// ErrMaintenance represents an error returned on mutation requested
+// while the API server is in maintenance mode.
+var ErrMaintenance error = &ApiError{Code: ErrCodeMaintenance, Message: "maintenance; the API server is read-only, please try again later"}
+
+// ApiStatus represents resolvable operational status of the API server.
+type ApiStatus struct {
+	// Version is the version of the API server.
+	Version string
+
+	// Maintenance signals the API server is in maintenance mode.
+	Maintenance bool
+
+	// Storage describes the off-chain storage of the API server.
+	Storage *StorageInfo
+}
+
+// StorageInfo represents resolvable description of the off-chain storage.
+type StorageInfo struct {
+	types.StorageInfo
+}
+
+// Status resolves the current operational status of the API server.
+func (rs *rootResolver) Status() ApiStatus {
+	return ApiStatus{
+		Version:     rs.Version(),
+		Maintenance: rs.InMaintenance(),
+		Storage:     &StorageInfo{StorageInfo: repository.R().StorageInfo()},
+	}
+}
+
+// InMaintenance checks if the API server is in maintenance mode.
+func (rs *rootResolver) InMaintenance() bool {
+	return atomic.LoadInt32(&rs.maintenance) != 0
+}
+
+// setMaintenance switches the maintenance mode on, or off.
+func (rs *rootResolver) setMaintenance(on bool) {
+	var val int32
+	if on {
+		val = 1
+	}
+
+	// log the change, if any
+	if atomic.SwapInt32(&rs.maintenance, val) != val {
+		rs.log.Noticef("maintenance mode switched to %t", on)
+	}
+}
@@ -0,0 +1,57 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Multisig represents resolvable configuration of a multisig wallet.
+type Multisig struct {
+	types.Multisig
+}
+
+// Multisig resolves the multisig wallet configuration of the account.
+// Contracts not classified yet are checked directly on chain.
+func (acc *Account) Multisig(ctx context.Context) (*Multisig, error) {
+	// wallets and contracts of other types are not multisig
+	if acc.ContractTx == nil || !acc.mayBeMultisig() {
+		return nil, nil
+	}
+
+	// try to load the configuration; failure means the contract is not a multisig
+	ms, err := repository.R().WithContext(ctx).Multisig(&acc.Address)
+	if err != nil {
+		if acc.Type == types.AccountTypeGnosisSafe {
+			return nil, err
+		}
+		return nil, nil
+	}
+	return &Multisig{Multisig: *ms}, nil
+}
+
+// mayBeMultisig checks if the account classification allows the account to be a multisig.
+func (acc *Account) mayBeMultisig() bool {
+	if acc.Type == types.AccountTypeGnosisSafe || acc.Account.Interfaces == nil {
+		return true
+	}
+	for _, i := range acc.Account.Interfaces {
+		if i == types.AccountTypeGnosisSafe {
+			return true
+		}
+	}
+	return false
+}
+
+// PendingTxCount resolves the number of proposed, but not executed transactions of the multisig.
+func (ms *Multisig) PendingTxCount(ctx context.Context) (*hexutil.Uint64, error) {
+	val, err := repository.R().WithContext(ctx).MultisigPendingTxCount(&ms.Address, uint64(ms.Nonce))
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	cnt := hexutil.Uint64(*val)
+	return &cnt, nil
+}
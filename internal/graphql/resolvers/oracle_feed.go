@@ -0,0 +1,91 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// OracleFeed represents resolvable on-chain price feed of a DeFi token.
+type OracleFeed struct {
+	types.OracleFeed
+}
+
+// OracleFeeds resolves the on-chain price feeds of all the active fMint DeFi tokens.
+func (rs *rootResolver) OracleFeeds(ctx context.Context) ([]*OracleFeed, error) {
+	feeds, err := repository.R().WithContext(ctx).OracleFeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*OracleFeed, len(feeds))
+	for i, f := range feeds {
+		list[i] = &OracleFeed{OracleFeed: *f}
+	}
+	return list, nil
+}
+
+// OraclePrice resolves the on-chain price feed of the fMint DeFi token with the given symbol.
+func (rs *rootResolver) OraclePrice(ctx context.Context, args *struct{ Symbol string }) (*OracleFeed, error) {
+	feed, err := repository.R().WithContext(ctx).OraclePrice(args.Symbol)
+	if err != nil || feed == nil {
+		return nil, err
+	}
+	return &OracleFeed{OracleFeed: *feed}, nil
+}
+
+// Token resolves the priced DeFi token.
+func (of *OracleFeed) Token(ctx context.Context) (*DefiToken, error) {
+	tk, err := repository.R().WithContext(ctx).DefiToken(&of.OracleFeed.Token)
+	if err != nil {
+		return nil, err
+	}
+	return NewDefiToken(tk), nil
+}
+
+// Round resolves the identifier of the latest feed round, if known.
+func (of *OracleFeed) Round() *hexutil.Uint64 {
+	if of.OracleFeed.Round == 0 {
+		return nil
+	}
+	val := hexutil.Uint64(of.OracleFeed.Round)
+	return &val
+}
+
+// Updated resolves the time of the last price update of the feed, if known.
+func (of *OracleFeed) Updated() *hexutil.Uint64 {
+	if of.OracleFeed.Updated.IsZero() {
+		return nil
+	}
+	val := hexutil.Uint64(of.OracleFeed.Updated.Unix())
+	return &val
+}
+
+// Value resolves the price of the token as a decimal number.
+func (of *OracleFeed) Value() float64 {
+	val, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(of.OracleFeed.Price.ToInt()),
+		new(big.Float).SetFloat64(math.Pow10(int(of.PriceDecimals))),
+	).Float64()
+	return val
+}
+
+// ReferencePrice resolves the USD price of the token from an off-chain source, if available.
+func (of *OracleFeed) ReferencePrice() *float64 {
+	return of.Reference
+}
+
+// Deviation resolves the deviation of the on-chain price from the off-chain reference price
+// in percents, if the reference price is available.
+func (of *OracleFeed) Deviation() *float64 {
+	if of.Reference == nil || *of.Reference == 0 {
+		return nil
+	}
+	dev := (of.Value() - *of.Reference) / *of.Reference * 100
+	return &dev
+}
@@ -0,0 +1,45 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+)
+
+// relatedAddressesMaxCount is the max number of related addresses provided in a single query.
+const relatedAddressesMaxCount = 100
+
+// RelatedAddress represents resolvable address related to an account by the clustering heuristics.
+type RelatedAddress struct {
+	types.RelatedAddress
+}
+
+// RelatedAddresses resolves addresses likely controlled by the same entity as the account.
+func (acc *Account) RelatedAddresses(ctx context.Context, args struct{ Limit int32 }) ([]*RelatedAddress, error) {
+	// limit the count
+	limit := args.Limit
+	if limit <= 0 || limit > relatedAddressesMaxCount {
+		limit = relatedAddressesMaxCount
+	}
+
+	list, err := repository.R().WithContext(ctx).RelatedAddresses(&acc.Address, int(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*RelatedAddress, len(list))
+	for i, ra := range list {
+		res[i] = &RelatedAddress{RelatedAddress: *ra}
+	}
+	return res, nil
+}
+
+// Account resolves the related account detail.
+func (ra *RelatedAddress) Account(ctx context.Context) (*Account, error) {
+	acc, err := repository.R().WithContext(ctx).Account(&ra.Address)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccount(acc), nil
+}
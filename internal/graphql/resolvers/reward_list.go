@@ -35,8 +35,8 @@ func (rl *RewardClaimList) PageInfo() (*ListPageInfo, error) {
 	}
 
 	// get the first and last elements
-	first := Cursor(rl.Collection[0].Pk())
-	last := Cursor(rl.Collection[len(rl.Collection)-1].Pk())
+	first := Cursor(types.ListCursor(rl.Collection[0].OrdinalIndex()))
+	last := Cursor(types.ListCursor(rl.Collection[len(rl.Collection)-1].OrdinalIndex()))
 	return NewListPageInfo(&first, &last, !rl.IsEnd, !rl.IsStart)
 }
 
@@ -52,7 +52,7 @@ func (rl *RewardClaimList) Edges() []*RewardClaimListEdge {
 	for i, d := range rl.Collection {
 		edges[i] = &RewardClaimListEdge{
 			Claim:  NewRewardClaim(d),
-			Cursor: Cursor(d.Pk()),
+			Cursor: Cursor(types.ListCursor(d.OrdinalIndex())),
 		}
 	}
 	return edges
@@ -0,0 +1,47 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"strings"
+)
+
+// riskMaxReasonLength is the maximum accepted length of a risk flag reason.
+const riskMaxReasonLength = 256
+
+// SetRiskLevel flags a contract, or a token with the given moderation risk level.
+// The NONE level removes the flag.
+func (adm *Admin) SetRiskLevel(ctx context.Context, args *struct {
+	Address common.Address
+	Level   string
+	Reason  *string
+}) (bool, error) {
+	// check the level
+	lvl := strings.ToUpper(args.Level)
+	if !types.IsValidRiskLevel(lvl) {
+		return false, errInvalidArgument("unknown risk level %s", args.Level)
+	}
+
+	// check the reason
+	ok, why := sanitizeStringOption(args.Reason, riskMaxReasonLength)
+	if !ok {
+		return false, errInvalidArgument("risk flag reason is too long to be valid")
+	}
+
+	rf := types.RiskFlag{Address: args.Address, Level: lvl}
+	if why != nil {
+		rf.Reason = *why
+	}
+
+	// store the flag
+	if err := repository.R().WithContext(ctx).StoreRiskFlag(&rf); err != nil {
+		adm.rs.log.Errorf("can not flag %s; %s", args.Address.String(), err.Error())
+		return false, err
+	}
+
+	adm.rs.log.Noticef("address %s flagged with risk level %s", args.Address.String(), lvl)
+	return true, nil
+}
@@ -10,8 +10,10 @@ import (
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
 	"golang.org/x/sync/singleflight"
 	"sync"
+	"sync/atomic"
 )
 
 const (
@@ -39,104 +41,226 @@ type ApiResolver interface {
 	// Version resolves current version of the API server.
 	Version() string
 
+	// ChainInfo resolves the identification and capabilities of the chain and the connected node.
+	ChainInfo(context.Context) (*ChainInfo, error)
+
 	// Epochs resolves a list of epochs for the given cursor and count.
-	Epochs(args struct {
+	Epochs(ctx context.Context, args struct {
 		Cursor *Cursor
 		Count  int32
 	}) (*EpochList, error)
 
 	// Account resolves blockchain account by address.
-	Account(struct{ Address common.Address }) (*Account, error)
+	Account(context.Context, struct{ Address common.Address }) (*Account, error)
 
 	// Contracts resolves list of blockchain smart contracts encapsulated in a listable structure.
-	Contracts(*struct {
+	Contracts(context.Context, *struct {
 		ValidatedOnly bool
 		Cursor        *Cursor
 		Count         int32
 	}) (*ContractList, error)
 
+	// ContractsCreatedBy resolves the most recent contracts deployed by the given contract.
+	ContractsCreatedBy(context.Context, *struct {
+		Address common.Address
+		Count   int32
+	}) ([]*ContractCreation, error)
+
+	// ContractsWithSameBytecode resolves contracts with the runtime byte code identical to the given contract.
+	ContractsWithSameBytecode(context.Context, *struct {
+		Address common.Address
+		Count   int32
+	}) ([]*Contract, error)
+
+	// PredictCreate2Address resolves the address of a contract deployed by CREATE2.
+	PredictCreate2Address(*struct {
+		Deployer     common.Address
+		Salt         common.Hash
+		InitCodeHash common.Hash
+	}) common.Address
+
 	// ValidateContract resolves smart contract source code vs. deployed byte code and marks
 	// the contract as validated if the match is found. Peer API points are ringed on success
 	// to notify them about the change.
-	ValidateContract(*struct{ Contract ContractValidationInput }) (*Contract, error)
+	ValidateContract(context.Context, *struct{ Contract ContractValidationInput }) (*Contract, error)
+
+	// PropagateContractValidation copies the validation of the given contract
+	// to not validated contracts with identical runtime byte code.
+	PropagateContractValidation(context.Context, *struct{ Address common.Address }) (int32, error)
 
 	// Block resolves blockchain block by number or by hash. If neither is provided, the most recent block is given.
-	Block(*struct {
+	Block(context.Context, *struct {
 		Number *hexutil.Uint64
 		Hash   *common.Hash
 	}) (*Block, error)
 
 	// Blocks resolves list of blockchain blocks encapsulated in a listable structure.
-	Blocks(*struct {
+	Blocks(context.Context, *struct {
 		Cursor *Cursor
 		Count  int32
 	}) (*BlockList, error)
 
+	// BlockRange resolves blocks between the given block numbers in ascending order.
+	BlockRange(context.Context, *struct {
+		From hexutil.Uint64
+		To   hexutil.Uint64
+	}) ([]*Block, error)
+
+	// AccountHasActivityIn resolves if the account may have any activity in the given range of blocks.
+	AccountHasActivityIn(context.Context, *struct {
+		Address   common.Address
+		FromBlock hexutil.Uint64
+		ToBlock   hexutil.Uint64
+	}) (bool, error)
+
+	// FinalityStats resolves the finality latency statistics of blocks in the given time range.
+	FinalityStats(context.Context, *struct{ Range hexutil.Uint64 }) (*FinalityStats, error)
+
 	// Transaction resolves blockchain transaction by hash.
-	Transaction(*struct{ Hash common.Hash }) (*Transaction, error)
+	Transaction(context.Context, *struct{ Hash common.Hash }) (*Transaction, error)
 
 	// Transactions resolves list of blockchain transactions encapsulated in a listable structure.
-	Transactions(*struct {
+	Transactions(context.Context, *struct {
 		Cursor *Cursor
 		Count  int32
 	}) (*TransactionList, error)
 
-	// OnBlock resolves subscription to new blocks event broadcast.
-	OnBlock(ctx context.Context) <-chan *Block
+	// LargeTransfers resolves list of native token transfers of at least the given value.
+	LargeTransfers(context.Context, *struct {
+		MinValue hexutil.Big
+		Cursor   *Cursor
+		Count    int32
+	}) (*TransactionList, error)
 
-	// OnTransaction resolves subscription to new transactions event broadcast.
-	OnTransaction(ctx context.Context) <-chan *Transaction
+	// LargeErc20Transfers resolves list of transfers of the given ERC20 token of at least the given amount.
+	LargeErc20Transfers(context.Context, *struct {
+		Token    common.Address
+		MinValue hexutil.Big
+		Cursor   *Cursor
+		Count    int32
+	}) (*ERC20TransactionList, error)
+
+	// OnBlock resolves subscription to new blocks event broadcast,
+	// optionally resumed after the given block.
+	OnBlock(ctx context.Context, args *struct{ SinceBlock *hexutil.Uint64 }) (<-chan *Block, error)
+
+	// OnBlockHeader resolves subscription to the headers of new blocks,
+	// optionally resumed after the given block.
+	OnBlockHeader(ctx context.Context, args *struct{ SinceBlock *hexutil.Uint64 }) (<-chan *BlockHeader, error)
+
+	// OnEpoch resolves subscription to sealed epochs event broadcast.
+	OnEpoch(ctx context.Context) <-chan Epoch
+
+	// OnTransaction resolves subscription to new transactions event broadcast,
+	// optionally resumed after the given block.
+	OnTransaction(ctx context.Context, args *struct{ SinceBlock *hexutil.Uint64 }) (<-chan *Transaction, error)
+
+	// OnUniswapPairCreated resolves subscription to new Uniswap pairs event broadcast.
+	OnUniswapPairCreated(ctx context.Context) <-chan *UniswapPairCreated
+
+	// OnFMintHealth resolves subscription to the collateral ratio
+	// of an fMint account crossing the given threshold.
+	OnFMintHealth(ctx context.Context, args *struct {
+		Owner     common.Address
+		Threshold float64
+	}) <-chan *FMintHealth
+
+	// OnQueryResult resolves subscription to the result of a read only query
+	// re-evaluated on new blocks.
+	OnQueryResult(ctx context.Context, args *struct {
+		Query     string
+		Variables *string
+		Interval  int32
+	}) (<-chan *QueryResult, error)
+
+	// OnLargeTransfer resolves subscription to transfers of native tokens,
+	// or the given ERC20 token, of at least the given value.
+	OnLargeTransfer(ctx context.Context, args *struct {
+		MinValue hexutil.Big
+		Token    *common.Address
+	}) (<-chan *LargeTransfer, error)
+
+	// SetSchema registers the parsed API schema used to evaluate live queries.
+	SetSchema(*graphql.Schema)
+
+	// InMaintenance checks if the API server is in maintenance mode; mutations
+	// are rejected and reads are served without the block chain node.
+	InMaintenance() bool
 
 	// CurrentEpoch resolves id of the current epoch.
-	CurrentEpoch() (hexutil.Uint64, error)
+	CurrentEpoch(context.Context) (hexutil.Uint64, error)
 
 	// Epoch resolves information about epoch of the given id.
-	Epoch(*struct{ Id *hexutil.Uint64 }) (Epoch, error)
+	Epoch(context.Context, *struct{ Id *hexutil.Uint64 }) (Epoch, error)
 
 	// LastStakerId resolves the last staker id in Opera blockchain.
-	LastStakerId() (hexutil.Uint64, error)
+	LastStakerId(context.Context) (hexutil.Uint64, error)
 
 	// StakersNum resolves the number of stakers in Opera blockchain.
-	StakersNum() (hexutil.Uint64, error)
+	StakersNum(context.Context) (hexutil.Uint64, error)
 
 	// Staker resolves a staker information from SFC smart contract.
-	Staker(struct {
+	Staker(context.Context, struct {
 		Id      *hexutil.Big
 		Address *common.Address
 	}) (*Staker, error)
 
 	// Stakers resolves a list of staker information from SFC smart contract.
-	Stakers() ([]*Staker, error)
+	Stakers(*struct {
+		OrderBy    string
+		Direction  string
+		OnlyActive bool
+		Cursor     *Cursor
+		Count      *int32
+	}) ([]*Staker, error)
 
 	// Delegation resolves details of a delegator by it's address.
-	Delegation(*struct {
+	Delegation(context.Context, *struct {
 		Address common.Address
 		Staker  hexutil.Big
 	}) (*Delegation, error)
 
 	// DelegationsOf a list of delegations information of a staker.
-	DelegationsOf(*struct {
+	DelegationsOf(context.Context, *struct {
 		Staker hexutil.Big
 		Cursor *Cursor
 		Count  int32
 	}) (*DelegationList, error)
 
 	// DelegationsByAddress a list of own delegations by the account address.
-	DelegationsByAddress(*struct {
+	DelegationsByAddress(context.Context, *struct {
 		Address common.Address
 		Cursor  *Cursor
 		Count   int32
 	}) (*DelegationList, error)
 
+	// DelegationsSummary resolves aggregated portfolio of all the delegations of the given account.
+	DelegationsSummary(context.Context, *struct{ Address common.Address }) (*DelegationsSummary, error)
+
+	// StakingStats resolves aggregated staking statistics of the network.
+	StakingStats(context.Context) (*StakingStats, error)
+
+	// FtmSupply resolves the current state of the native FTM token supply.
+	FtmSupply(context.Context) (*FtmSupply, error)
+
 	// Price resolves price details of the Opera blockchain token for the given target symbols.
-	Price(*struct{ To string }) (types.Price, error)
+	Price(context.Context, *struct{ To string }) (types.Price, error)
+
+	// Dashboard resolves the snapshot of the network state for the explorer homepage.
+	Dashboard(*struct{ To string }) (*Dashboard, error)
+
+	// PriceAt resolves the historical price of FTM in the given target symbol at the given time.
+	PriceAt(context.Context, *struct {
+		To   string
+		Time hexutil.Uint64
+	}) (*float64, error)
 
 	// GasPrice resolves the current amount of WEI for single Gas.
-	GasPrice() (hexutil.Uint64, error)
+	GasPrice(context.Context) (hexutil.Uint64, error)
 
 	// EstimateGas resolves the estimated amount of Gas required to perform
 	// transaction described by the input params.
-	EstimateGas(struct {
+	EstimateGas(context.Context, struct {
 		From  *common.Address
 		To    *common.Address
 		Value *hexutil.Big
@@ -144,50 +268,110 @@ type ApiResolver interface {
 	}) (*hexutil.Uint64, error)
 
 	// EstimateRewards resolves reward estimation for the given address or amount staked.
-	EstimateRewards(*struct {
+	EstimateRewards(context.Context, *struct {
 		Address *common.Address
 		Amount  *hexutil.Uint64
 	}) (EstimatedRewards, error)
 
+	// EstimateCompounding resolves the projected balance of the delegation with rewards re-staked.
+	EstimateCompounding(context.Context, *struct {
+		Address          common.Address
+		Staker           hexutil.Big
+		PeriodDays       int32
+		RestakeFrequency int32
+	}) (*CompoundingEstimation, error)
+
 	// SendTransaction sends raw signed and RLP encoded transaction to the block chain.
-	SendTransaction(*struct{ Tx hexutil.Bytes }) (*Transaction, error)
+	SendTransaction(context.Context, *struct{ Tx hexutil.Bytes }) (*Transaction, error)
+
+	// SendTransactionBatch sends a list of raw signed and RLP encoded transactions to the block chain in order.
+	SendTransactionBatch(context.Context, *struct{ Txs []hexutil.Bytes }) ([]*TransactionSubmission, error)
 
 	// DefiConfiguration resolves the current DeFi contract settings.
-	DefiConfiguration() (*DefiConfiguration, error)
+	DefiConfiguration(context.Context) (*DefiConfiguration, error)
 
 	// DefiTokens resolves list of DeFi tokens available for the DeFi functions.
-	DefiTokens() ([]*DefiToken, error)
+	DefiTokens(context.Context) ([]*DefiToken, error)
+
+	// WftmAddress resolves the address of the configured wrapped FTM token contract.
+	WftmAddress(context.Context) common.Address
+
+	// FMintStats resolves the latest snapshot of the protocol level fMint metrics.
+	FMintStats(context.Context) (*FMintStats, error)
+
+	// FMintAccountsAtRisk resolves the list of fMint accounts with a debt and the collateral
+	// ratio below the given max ratio, sorted from the lowest ratio.
+	FMintAccountsAtRisk(context.Context, *struct {
+		MaxRatio *float64
+		Cursor   *Cursor
+		Count    int32
+	}) (*FMintAccountAtRiskList, error)
+
+	// OracleFeeds resolves the on-chain price feeds of all the active fMint DeFi tokens.
+	OracleFeeds(context.Context) ([]*OracleFeed, error)
+
+	// OraclePrice resolves the on-chain price feed of the fMint DeFi token with the given symbol.
+	OraclePrice(context.Context, *struct{ Symbol string }) (*OracleFeed, error)
 
 	// DefiUniswapPairs resolves a list of all pairs managed by the Uniswap core.
 	DefiUniswapPairs() []*UniswapPair
 
+	// UniswapPositions resolves the list of liquidity positions
+	// the given owner holds on Uniswap pairs.
+	UniswapPositions(context.Context, *struct{ Owner common.Address }) ([]*UniswapPosition, error)
+
 	// DefiUniswapAmountsOut resolves a list of output amounts for the given
 	// input amount and a list of tokens to be used to make the swap operation.
-	DefiUniswapAmountsOut(*struct {
+	DefiUniswapAmountsOut(context.Context, *struct {
 		AmountIn hexutil.Big
 		Tokens   []common.Address
 	}) ([]hexutil.Big, error)
 
 	// DefiUniswapAmountsIn resolves a list of input amounts for the given
 	// output amount and a list of tokens to be used to make the swap operation.
-	DefiUniswapAmountsIn(*struct {
+	DefiUniswapAmountsIn(context.Context, *struct {
 		AmountOut hexutil.Big
 		Tokens    []common.Address
 	}) ([]hexutil.Big, error)
 
+	// DefiUniswapQuoteOut resolves a quote of a swap operation for the given
+	// input amount and a list of tokens to be used to make the swap operation.
+	DefiUniswapQuoteOut(context.Context, *struct {
+		AmountIn hexutil.Big
+		Tokens   []common.Address
+		Slippage float64
+	}) (*UniswapSwapQuote, error)
+
+	// DefiUniswapQuoteIn resolves a quote of a swap operation for the given
+	// output amount and a list of tokens to be used to make the swap operation.
+	DefiUniswapQuoteIn(context.Context, *struct {
+		AmountOut hexutil.Big
+		Tokens    []common.Address
+		Slippage  float64
+	}) (*UniswapSwapQuote, error)
+
+	// DefiUniswapBestRoute resolves the swap route providing the highest output amount
+	// for the given input amount over the known Uniswap pairs.
+	DefiUniswapBestRoute(context.Context, *struct {
+		TokenIn  common.Address
+		TokenOut common.Address
+		AmountIn hexutil.Big
+		MaxHops  int32
+	}) (*UniswapRoute, error)
+
 	// DefiUniswapQuoteLiquidity resolves a list of optimal amounts of tokens
 	// to be added to both sides of a pair on addLiquidity call.
-	DefiUniswapQuoteLiquidity(*struct {
+	DefiUniswapQuoteLiquidity(context.Context, *struct {
 		Tokens    []common.Address
 		AmountsIn []hexutil.Big
 	}) ([]hexutil.Big, error)
 
 	// FMintAccount resolves details of a specified DeFi account.
-	FMintAccount(*struct{ Owner common.Address }) (*FMintAccount, error)
+	FMintAccount(context.Context, *struct{ Owner common.Address }) (*FMintAccount, error)
 
 	// FMintTokenAllowance resolves the amount of ERC20 tokens unlocked
 	// by the token owner for DeFi/fMint protocol operations.
-	FMintTokenAllowance(args *struct {
+	FMintTokenAllowance(ctx context.Context, args *struct {
 		Owner common.Address
 		Token common.Address
 	}) (hexutil.Big, error)
@@ -196,40 +380,61 @@ type ApiResolver interface {
 	Erc20Token(*struct{ Token common.Address }) *ERC20Token
 
 	// Erc20TokenList resolves a list of instances of ERC20 tokens.
-	Erc20TokenList(struct{ Count int32 }) ([]*ERC20Token, error)
+	Erc20TokenList(context.Context, struct{ Count int32 }) ([]*ERC20Token, error)
 
 	// Erc20Assets resolves a list of instances of ERC20 tokens for the given owner.
-	Erc20Assets(struct {
+	Erc20Assets(context.Context, struct {
 		Owner common.Address
 		Count int32
 	}) ([]*ERC20Token, error)
 
+	// Erc20Approvals resolves the list of allowances granted by the given owner.
+	Erc20Approvals(context.Context, struct {
+		Owner      common.Address
+		ActiveOnly bool
+	}) ([]*ERC20Approval, error)
+
 	// ErcTokenBalance resolves the current available balance of the specified token
 	// for the specified owner.
-	ErcTokenBalance(args *struct {
+	ErcTokenBalance(ctx context.Context, args *struct {
 		Owner common.Address
 		Token common.Address
 	}) (hexutil.Big, error)
 
 	// ErcTotalSupply resolves the current total supply of the specified token.
-	ErcTotalSupply(args *struct{ Token common.Address }) (hexutil.Big, error)
+	ErcTotalSupply(ctx context.Context, args *struct{ Token common.Address }) (hexutil.Big, error)
 
 	// ErcTokenAllowance resolves the current amount of ERC20 tokens unlocked
 	// by the token owner for the spender to be manipulated with.
-	ErcTokenAllowance(args *struct {
+	ErcTokenAllowance(ctx context.Context, args *struct {
 		Token   common.Address
 		Owner   common.Address
 		Spender common.Address
 	}) (hexutil.Big, error)
 
+	// Ballots resolves the list of configured community ballots.
+	Ballots(context.Context) ([]*Ballot, error)
+
+	// Ballot resolves the community ballot on the given address.
+	Ballot(context.Context, *struct{ Address common.Address }) (*Ballot, error)
+
 	// GovContracts resolves list of governance contracts details recognized by the API.
 	GovContracts() ([]*GovernanceContract, error)
 
 	// GovContract provides a specific Governance contract information by its address.
-	GovContract(struct{ Address common.Address }) (*GovernanceContract, error)
+	GovContract(context.Context, struct{ Address common.Address }) (*GovernanceContract, error)
+
+	// GovVotingPower resolves the voting weight of an address in the given governance
+	// contract at a past point identified by a block, or by an epoch.
+	GovVotingPower(context.Context, *struct {
+		Address  common.Address
+		Contract common.Address
+		Block    *hexutil.Uint64
+		Epoch    *hexutil.Uint64
+	}) (hexutil.Big, error)
 
 	// GovProposals represents list of joined proposals across all the Governance contracts.
-	GovProposals(struct {
+	GovProposals(context.Context, struct {
 		Cursor     *Cursor
 		Count      int32
 		ActiveOnly bool
@@ -237,19 +442,19 @@ type ApiResolver interface {
 
 	// TrxVolume resolves list of daily aggregations
 	// of the network transaction flow.
-	TrxVolume(args struct {
+	TrxVolume(ctx context.Context, args struct {
 		From *string
 		To   *string
 	}) ([]*DailyTrxVolume, error)
 
 	// TrxSpeed resolves the recent speed of the network in transactions processed per second.
-	TrxSpeed(args struct {
+	TrxSpeed(ctx context.Context, args struct {
 		Range int32
 	}) (float64, error)
 
 	// TrxGasSpeed resolves the gas consumption speed speed
 	// of the network in transactions processed per second.
-	TrxGasSpeed(args struct {
+	TrxGasSpeed(ctx context.Context, args struct {
 		Range int32
 		To    *string
 	}) (float64, error)
@@ -265,7 +470,6 @@ type rootResolver struct {
 
 	// service terminator
 	wg      sync.WaitGroup
-	cg      singleflight.Group
 	sigStop chan bool
 
 	// blocks subscriptions management
@@ -279,6 +483,44 @@ type rootResolver struct {
 	unsubscribeOnTrx chan string
 	trxSubscribers   map[string]*subscriptOnTrx
 	onTrxEvents      chan *types.Transaction
+
+	// epoch subscriptions management
+	subscribeOnEpoch   chan *subscriptOnEpoch
+	unsubscribeOnEpoch chan string
+	epochSubscribers   map[string]*subscriptOnEpoch
+	onEpochEvents      chan *types.Epoch
+
+	// uniswap pair subscriptions management
+	subscribeOnUniswapPair   chan *subscriptOnUniswapPair
+	unsubscribeOnUniswapPair chan string
+	uniswapPairSubscribers   map[string]*subscriptOnUniswapPair
+	onUniswapPairEvents      chan *types.UniswapPairCreated
+
+	// fMint health subscriptions management
+	subscribeOnFMintHealth   chan *subscriptOnFMintHealth
+	unsubscribeOnFMintHealth chan string
+	fMintHealthSubscribers   map[string]*subscriptOnFMintHealth
+
+	// live query subscriptions management
+	subscribeOnQueryResult   chan *subscriptOnQueryResult
+	unsubscribeOnQueryResult chan string
+	queryResultSubscribers   map[string]*subscriptOnQueryResult
+
+	// large transfer subscriptions management
+	subscribeOnLargeTransfer   chan *subscriptOnLargeTransfer
+	unsubscribeOnLargeTransfer chan string
+	largeTransferSubscribers   map[string]*subscriptOnLargeTransfer
+
+	// schema is the parsed API schema used to evaluate live queries
+	schema atomic.Value
+
+	// maintenance mode state; non-zero value means mutations are disabled
+	maintenance int32
+
+	// dashboard snapshots shared by concurrent requests
+	dashboardMu    sync.Mutex
+	dashboardGroup singleflight.Group
+	dashboards     map[string]*Dashboard
 }
 
 // New creates a new root resolver instance and initializes it's internal structure.
@@ -302,12 +544,49 @@ func New(cfg *config.Config, log logger.Logger) ApiResolver {
 		unsubscribeOnTrx: make(chan string, subscriptionQueueCapacity),
 		trxSubscribers:   make(map[string]*subscriptOnTrx, subscriptionInitialCapacity),
 		onTrxEvents:      make(chan *types.Transaction, onBlockChannelCapacity),
+
+		// epoch events subscription basics
+		subscribeOnEpoch:   make(chan *subscriptOnEpoch, subscriptionQueueCapacity),
+		unsubscribeOnEpoch: make(chan string, subscriptionQueueCapacity),
+		epochSubscribers:   make(map[string]*subscriptOnEpoch, subscriptionInitialCapacity),
+		onEpochEvents:      make(chan *types.Epoch, onEpochChannelCapacity),
+
+		// uniswap pair events subscription basics
+		subscribeOnUniswapPair:   make(chan *subscriptOnUniswapPair, subscriptionQueueCapacity),
+		unsubscribeOnUniswapPair: make(chan string, subscriptionQueueCapacity),
+		uniswapPairSubscribers:   make(map[string]*subscriptOnUniswapPair, subscriptionInitialCapacity),
+		onUniswapPairEvents:      make(chan *types.UniswapPairCreated, onUniswapPairChannelCapacity),
+
+		// fMint health subscription basics
+		subscribeOnFMintHealth:   make(chan *subscriptOnFMintHealth, subscriptionQueueCapacity),
+		unsubscribeOnFMintHealth: make(chan string, subscriptionQueueCapacity),
+		fMintHealthSubscribers:   make(map[string]*subscriptOnFMintHealth, subscriptionInitialCapacity),
+
+		// live query subscription basics
+		subscribeOnQueryResult:   make(chan *subscriptOnQueryResult, subscriptionQueueCapacity),
+		unsubscribeOnQueryResult: make(chan string, subscriptionQueueCapacity),
+		queryResultSubscribers:   make(map[string]*subscriptOnQueryResult, subscriptionInitialCapacity),
+
+		// large transfer subscription basics
+		subscribeOnLargeTransfer:   make(chan *subscriptOnLargeTransfer, subscriptionQueueCapacity),
+		unsubscribeOnLargeTransfer: make(chan string, subscriptionQueueCapacity),
+		largeTransferSubscribers:   make(map[string]*subscriptOnLargeTransfer, subscriptionInitialCapacity),
+
+		// dashboard snapshots by the price symbol
+		dashboards: make(map[string]*Dashboard),
+	}
+
+	// maintenance mode may be requested by the config
+	if cfg.Server.Maintenance {
+		rs.setMaintenance(true)
 	}
 
 	// register event channels with repository
 	repo := repository.R()
 	repo.SetBlockChannel(rs.onBlockEvents)
 	repo.SetTrxChannel(rs.onTrxEvents)
+	repo.SetEpochChannel(rs.onEpochEvents)
+	repo.SetUniswapPairChannel(rs.onUniswapPairEvents)
 
 	// handle broadcast and subscriptions in a separate routine
 	rs.wg.Add(1)
@@ -350,17 +629,56 @@ func (rs *rootResolver) run() {
 		case id := <-rs.unsubscribeOnTrx:
 			delete(rs.trxSubscribers, id)
 
+		case id := <-rs.unsubscribeOnEpoch:
+			delete(rs.epochSubscribers, id)
+
+		case id := <-rs.unsubscribeOnUniswapPair:
+			delete(rs.uniswapPairSubscribers, id)
+
+		case id := <-rs.unsubscribeOnFMintHealth:
+			delete(rs.fMintHealthSubscribers, id)
+
+		case id := <-rs.unsubscribeOnQueryResult:
+			delete(rs.queryResultSubscribers, id)
+
+		case id := <-rs.unsubscribeOnLargeTransfer:
+			delete(rs.largeTransferSubscribers, id)
+
 		case sub := <-rs.subscribeOnBlock:
 			rs.addBlockSubscriber(sub)
 
 		case sub := <-rs.subscribeOnTrx:
 			rs.addTrxSubscriber(sub)
 
+		case sub := <-rs.subscribeOnEpoch:
+			rs.addEpochSubscriber(sub)
+
+		case sub := <-rs.subscribeOnUniswapPair:
+			rs.addUniswapPairSubscriber(sub)
+
+		case sub := <-rs.subscribeOnFMintHealth:
+			rs.addFMintHealthSubscriber(sub)
+
+		case sub := <-rs.subscribeOnQueryResult:
+			rs.addQueryResultSubscriber(sub)
+
+		case sub := <-rs.subscribeOnLargeTransfer:
+			rs.addLargeTransferSubscriber(sub)
+
 		case evt := <-rs.onBlockEvents:
 			rs.dispatchOnBlock(evt)
+			rs.dispatchOnFMintHealth(evt)
+			rs.dispatchOnQueryResult(evt)
 
 		case evt := <-rs.onTrxEvents:
 			rs.dispatchOnTransaction(evt)
+			rs.dispatchOnLargeTransfer(evt)
+
+		case evt := <-rs.onEpochEvents:
+			rs.dispatchOnEpoch(evt)
+
+		case evt := <-rs.onUniswapPairEvents:
+			rs.dispatchOnUniswapPair(evt)
 		}
 	}
 }
@@ -2,19 +2,20 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // CurrentEpoch resolves the id of the current epoch of the Opera blockchain.
-func (rs *rootResolver) CurrentEpoch() (hexutil.Uint64, error) {
-	return repository.R().CurrentEpoch()
+func (rs *rootResolver) CurrentEpoch(ctx context.Context) (hexutil.Uint64, error) {
+	return repository.R().WithContext(ctx).CurrentEpoch()
 }
 
 // LastStakerId resolves the last staker id in Opera blockchain.
-func (rs *rootResolver) LastStakerId() (hexutil.Uint64, error) {
-	val, err := repository.R().LastValidatorId()
+func (rs *rootResolver) LastStakerId(ctx context.Context) (hexutil.Uint64, error) {
+	val, err := repository.R().WithContext(ctx).LastValidatorId()
 	if err != nil {
 		return 0, err
 	}
@@ -22,8 +23,8 @@ func (rs *rootResolver) LastStakerId() (hexutil.Uint64, error) {
 }
 
 // StakersNum resolves the number of stakers in Opera blockchain.
-func (rs *rootResolver) StakersNum() (hexutil.Uint64, error) {
-	val, err := repository.R().ValidatorsCount()
+func (rs *rootResolver) StakersNum(ctx context.Context) (hexutil.Uint64, error) {
+	val, err := repository.R().WithContext(ctx).ValidatorsCount()
 	if err != nil {
 		return 0, err
 	}
@@ -31,20 +32,20 @@ func (rs *rootResolver) StakersNum() (hexutil.Uint64, error) {
 }
 
 // Staker resolves a validator information from SFC smart contract.
-func (rs *rootResolver) Staker(args struct {
+func (rs *rootResolver) Staker(ctx context.Context, args struct {
 	Id      *hexutil.Big
 	Address *common.Address
 }) (*Staker, error) {
 	// by ID or by address?
 	if args.Id != nil {
-		st, err := repository.R().Validator(args.Id)
+		st, err := repository.R().WithContext(ctx).Validator(args.Id)
 		if err != nil {
 			return nil, err
 		}
 		return NewStaker(st), err
 	}
 
-	st, err := repository.R().ValidatorByAddress(args.Address)
+	st, err := repository.R().WithContext(ctx).ValidatorByAddress(args.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,31 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SignatureVerification represents resolvable result of a message signature verification.
+type SignatureVerification struct {
+	types.SignatureVerification
+}
+
+// VerifySignature resolves the verification of a message signature
+// against the expected signer address.
+func (rs *rootResolver) VerifySignature(ctx context.Context, args *struct {
+	Address   common.Address
+	Message   string
+	Signature hexutil.Bytes
+	TypedData bool
+}) (*SignatureVerification, error) {
+	// do the verification
+	sv, err := repository.R().WithContext(ctx).VerifySignature(&args.Address, args.Message, args.Signature, args.TypedData)
+	if err != nil {
+		return nil, err
+	}
+	return &SignatureVerification{*sv}, nil
+}
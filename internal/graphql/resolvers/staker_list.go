@@ -2,63 +2,135 @@ package resolvers
 
 import (
 	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"math/big"
 	"sort"
+	"strings"
 )
 
+// stakersOrder maps the list order name to the comparison of two staker snapshots.
+// The comparison returns negative value if the first staker is lower than the other one.
+var stakersOrder = map[string]func(a, b *types.ValidatorSnapshot) int{
+	"stake": func(a, b *types.ValidatorSnapshot) int {
+		return a.TotalStake.ToInt().Cmp(b.TotalStake.ToInt())
+	},
+	"uptime": func(a, b *types.ValidatorSnapshot) int {
+		return cmpFloat(a.Uptime, b.Uptime)
+	},
+	"apr": func(a, b *types.ValidatorSnapshot) int {
+		return cmpFloat(a.Apr, b.Apr)
+	},
+	"commission": func(a, b *types.ValidatorSnapshot) int {
+		return cmpFloat(a.Commission, b.Commission)
+	},
+}
+
 // Stakers resolves a list of staker information from SFC smart contract.
-func (rs *rootResolver) Stakers() ([]*Staker, error) {
-	// get the number
-	num, err := repository.R().LastValidatorId()
+// The list is served from a periodically refreshed snapshot of stakers
+// sorted by the requested order and direction.
+func (rs *rootResolver) Stakers(args *struct {
+	OrderBy    string
+	Direction  string
+	OnlyActive bool
+	Cursor     *Cursor
+	Count      *int32
+}) ([]*Staker, error) {
+	// validate the ordering
+	cmp, ok := stakersOrder[strings.ToLower(args.OrderBy)]
+	if !ok {
+		return nil, errInvalidArgument("unknown stakers order %s", args.OrderBy)
+	}
+	desc := strings.ToUpper(args.Direction) != "ASC"
+
+	// get the snapshot
+	snap, err := validatorsSnapshot()
 	if err != nil {
-		rs.log.Errorf("can not get the highest staker id; %s", err.Error())
+		rs.log.Errorf("can not get stakers snapshot; %s", err.Error())
 		return nil, err
 	}
 
-	// make the list
-	list := make([]*Staker, 0)
-	for i := uint64(1); i <= num; i++ {
-		// extract the staker info
-		st, err := repository.R().Validator((*hexutil.Big)(new(big.Int).SetUint64(i)))
-		if err != nil {
-			rs.log.Criticalf("can not extract staker #%d information; %s", i, err.Error())
-			continue
+	// filter the list; we don't modify the snapshot itself
+	list := make([]*types.ValidatorSnapshot, 0, len(snap))
+	for _, st := range snap {
+		if !args.OnlyActive || st.Status == 0 {
+			list = append(list, st)
 		}
+	}
 
-		// staker not valid?
-		if st.Id.ToInt().Uint64() == 0 {
-			rs.log.Debugf("staker #%d has invalid ID", i)
-			continue
+	// sort the list; ties are resolved by the staker ID to keep the order stable
+	sort.Slice(list, func(i, j int) bool {
+		c := cmp(list[i], list[j])
+		if c == 0 {
+			return list[i].Id.ToInt().Cmp(list[j].Id.ToInt()) < 0
 		}
-
-		// store the staker into the list
-		list = append(list, NewStaker(st))
-	}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
 
 	// inform
 	rs.log.Debugf("found %d stakers", len(list))
-
-	// sort the list by total amount delegated and return the result
-	sort.Sort(StakesByTotalStaked(list))
-	return list, nil
+	return stakersPage(list, args.Cursor, args.Count), nil
 }
 
-// StakesByTotalStaked represents a list of staking sortable by their total staked amount.
-type StakesByTotalStaked []*Staker
+// stakersPage builds the resolvable page of the sorted stakers list starting
+// after the staker identified by the cursor.
+func stakersPage(list []*types.ValidatorSnapshot, cursor *Cursor, count *int32) []*Staker {
+	// skip to the cursor
+	if cursor != nil {
+		for i, st := range list {
+			if st.Id.String() == string(*cursor) {
+				list = list[i+1:]
+				break
+			}
+		}
+	}
+
+	// limit the size
+	if count != nil && *count > 0 && int(*count) < len(list) {
+		list = list[:*count]
+	}
+
+	res := make([]*Staker, len(list))
+	for i, st := range list {
+		res[i] = NewStaker(&st.Validator)
+	}
+	return res
+}
 
-// Len returns size of the stakers list.
-func (s StakesByTotalStaked) Len() int {
-	return len(s)
+// stakerSnapshot finds the snapshot of the given staker.
+func stakerSnapshot(id *hexutil.Big) *types.ValidatorSnapshot {
+	snap, err := validatorsSnapshot()
+	if err != nil {
+		return nil
+	}
+	for _, st := range snap {
+		if st.Id.ToInt().Cmp(id.ToInt()) == 0 {
+			return st
+		}
+	}
+	return nil
 }
 
-// Less compares two stakers and returns true if the first is lower than the last.
-// We use it to sort stakers by the total amount on stake.
-func (s StakesByTotalStaked) Less(i, j int) bool {
-	return s[i].TotalStake.ToInt().Cmp(s[j].TotalStake.ToInt()) > 0
+// validatorsSnapshot provides the current snapshot of stakers.
+func validatorsSnapshot() ([]*types.ValidatorSnapshot, error) {
+	snap, err := lookups.do(lookupStakers, "snapshot", func() (interface{}, error) {
+		return repository.R().ValidatorsSnapshot()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snap.([]*types.ValidatorSnapshot), nil
 }
 
-// Swap changes position of two stakers in the list.
-func (s StakesByTotalStaked) Swap(i, j int) {
-	s[i], s[j] = s[j], s[i]
+// cmpFloat compares two float values.
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
 }
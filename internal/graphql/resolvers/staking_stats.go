@@ -0,0 +1,75 @@
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// StakingStats represents resolvable aggregated staking statistics.
+type StakingStats struct {
+	types.StakingStats
+}
+
+// DailyRewards represents resolvable single day aggregation of reward claims.
+type DailyRewards struct {
+	types.DailyRewards
+}
+
+// StakingStats resolves aggregated staking statistics of the network.
+func (rs *rootResolver) StakingStats(ctx context.Context) (*StakingStats, error) {
+	st, err := repository.R().WithContext(ctx).StakingStats()
+	if err != nil {
+		rs.log.Errorf("can not get staking stats; %s", err.Error())
+		return nil, err
+	}
+	return &StakingStats{*st}, nil
+}
+
+// ActiveValidators resolves the number of active validators.
+func (st *StakingStats) ActiveValidators() hexutil.Uint64 {
+	return hexutil.Uint64(st.StakingStats.ActiveValidators)
+}
+
+// ActiveDelegations resolves the number of active delegations.
+func (st *StakingStats) ActiveDelegations() hexutil.Uint64 {
+	return hexutil.Uint64(st.StakingStats.ActiveDelegations)
+}
+
+// DailyRewards resolves list of daily aggregations of claimed rewards.
+func (st *StakingStats) DailyRewards(ctx context.Context, args struct {
+	From *string
+	To   *string
+}) ([]*DailyRewards, error) {
+	// get the date range
+	from, to, err := trxVolumeRange(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// load data
+	dr, err := repository.R().WithContext(ctx).DailyRewards(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// load the list
+	list := make([]*DailyRewards, len(dr))
+	for i, v := range dr {
+		list[i] = &DailyRewards{*v}
+	}
+	return list, nil
+}
+
+// Claims resolves the number of reward claims in Int format.
+func (dr *DailyRewards) Claims() int32 {
+	return int32(dr.DailyRewards.Claims)
+}
+
+// Amount resolves the amount of rewards claimed on the day.
+func (dr *DailyRewards) Amount() hexutil.Big {
+	val := new(big.Int).Mul(new(big.Int).SetInt64(dr.DailyRewards.AmountAdjusted), types.RewardDecimalsCorrection)
+	return hexutil.Big(*val)
+}
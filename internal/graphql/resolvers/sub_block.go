@@ -3,13 +3,19 @@ package resolvers
 
 import (
 	"context"
+	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // onBlockChannelCapacity is the number of new block events held in memory for being broadcast to subscriber.
 const onBlockChannelCapacity = 500
 
+// subscriptionMaxReplay is the max number of blocks replayed to a resumed subscription.
+const subscriptionMaxReplay = 1000
+
 // subscriptOnBlock represents reference to a subscriber to onBlock events broadcast.
 type subscriptOnBlock struct {
 	stop   <-chan struct{}
@@ -17,16 +23,88 @@ type subscriptOnBlock struct {
 }
 
 // OnBlock resolves subscription to new blocks event broadcast.
-func (rs *rootResolver) OnBlock(ctx context.Context) <-chan *Block {
+// If the last block received by the client is provided, the blocks
+// following it are replayed before the new blocks are streamed.
+func (rs *rootResolver) OnBlock(ctx context.Context, args *struct{ SinceBlock *hexutil.Uint64 }) (<-chan *Block, error) {
 	// make the stream
 	c := make(chan *Block, onBlockChannelCapacity)
+	if args.SinceBlock == nil {
+		rs.subscribeOnBlock <- &subscriptOnBlock{stop: ctx.Done(), events: c}
+		return c, nil
+	}
+
+	// resumed stream subscribes first so no new block is missed while the head
+	// is being read; new blocks are kept aside until the replay is done
+	sctx, cancel := context.WithCancel(ctx)
+	live := make(chan *Block, onBlockChannelCapacity)
+	rs.subscribeOnBlock <- &subscriptOnBlock{stop: sctx.Done(), events: live}
+
+	head, err := replayHead(sctx, uint64(*args.SinceBlock))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go rs.resumeOnBlock(sctx, cancel, uint64(*args.SinceBlock), head, live, c)
+	return c, nil
+}
+
+// replayHead validates the block a subscription is resumed from
+// and provides the last block to be replayed.
+func replayHead(ctx context.Context, since uint64) (uint64, error) {
+	height, err := repository.R().WithContext(ctx).BlockHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	head := height.ToInt().Uint64()
+	if since > head {
+		return 0, errInvalidArgument("block #%d not found", since)
+	}
+	if head-since > subscriptionMaxReplay {
+		return 0, errInvalidArgument("can not resume more than %d blocks back", subscriptionMaxReplay)
+	}
+	return head, nil
+}
+
+// resumeOnBlock replays blocks following the given block up to the head,
+// and then forwards new blocks; blocks replayed already are dropped.
+func (rs *rootResolver) resumeOnBlock(ctx context.Context, cancel context.CancelFunc, since uint64, head uint64, live <-chan *Block, out chan<- *Block) {
+	defer cancel()
+
+	for n := since + 1; n <= head; n++ {
+		num := hexutil.Uint64(n)
+		blk, err := repository.R().WithContext(ctx).BlockByNumber(&num)
+		if err != nil {
+			rs.log.Errorf("can not replay block #%d; %s", n, err.Error())
+			continue
+		}
+
+		select {
+		case out <- NewBlock(blk):
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	last := head
+	for {
+		select {
+		case blk := <-live:
+			if uint64(blk.Number) <= last {
+				continue
+			}
+			last = uint64(blk.Number)
 
-	// subscribe to event dispatch
-	rs.subscribeOnBlock <- &subscriptOnBlock{
-		stop:   ctx.Done(),
-		events: c,
+			select {
+			case out <- blk:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
 	}
-	return c
 }
 
 // addBlockSubscriber adds a new subscription to onBlock events.
@@ -0,0 +1,55 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockHeader represents resolvable lightweight summary of a block.
+type BlockHeader struct {
+	Number    hexutil.Uint64
+	Hash      common.Hash
+	TxCount   int32
+	Timestamp hexutil.Uint64
+}
+
+// NewBlockHeader builds new resolvable block header from the given block.
+func NewBlockHeader(blk *Block) *BlockHeader {
+	return &BlockHeader{
+		Number:    blk.Number,
+		Hash:      blk.Hash,
+		TxCount:   int32(len(blk.Txs)),
+		Timestamp: blk.TimeStamp,
+	}
+}
+
+// OnBlockHeader resolves subscription to the headers of new blocks.
+// The headers are derived from the onBlock events, so resuming the stream
+// works the same way.
+func (rs *rootResolver) OnBlockHeader(ctx context.Context, args *struct{ SinceBlock *hexutil.Uint64 }) (<-chan *BlockHeader, error) {
+	blocks, err := rs.OnBlock(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// make the stream
+	c := make(chan *BlockHeader, onBlockChannelCapacity)
+	go func() {
+		for {
+			select {
+			case blk := <-blocks:
+				select {
+				case c <- NewBlockHeader(blk):
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c, nil
+}
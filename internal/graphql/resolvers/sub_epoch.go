@@ -0,0 +1,79 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"time"
+)
+
+// onEpochChannelCapacity is the number of new epoch events held in memory for being broadcast to subscriber.
+const onEpochChannelCapacity = 50
+
+// subscriptOnEpoch represents reference to a subscriber to onEpoch events broadcast.
+type subscriptOnEpoch struct {
+	stop   <-chan struct{}
+	events chan<- Epoch
+}
+
+// OnEpoch resolves subscription to sealed epochs event broadcast.
+func (rs *rootResolver) OnEpoch(ctx context.Context) <-chan Epoch {
+	// make the stream
+	c := make(chan Epoch, onEpochChannelCapacity)
+
+	// subscribe to event dispatch
+	rs.subscribeOnEpoch <- &subscriptOnEpoch{
+		stop:   ctx.Done(),
+		events: c,
+	}
+	return c
+}
+
+// addEpochSubscriber adds a new subscription to onEpoch events.
+func (rs *rootResolver) addEpochSubscriber(sub *subscriptOnEpoch) {
+	id, err := uuid()
+	if err == nil {
+		// add the subscriber to the map
+		rs.epochSubscribers[id] = sub
+	} else {
+		// log critical issue
+		rs.log.Critical("can not generate UUID for new onEpoch subscriber")
+		rs.log.Critical(err)
+	}
+}
+
+// dispatchOnEpoch dispatches onEpoch event to registered subscribers.
+func (rs *rootResolver) dispatchOnEpoch(ep *types.Epoch) {
+	// prep the epoch
+	epoch := Epoch{*ep}
+
+	// broadcast the event in separate go routines so we don't block here
+	for id, sub := range rs.epochSubscribers {
+		go rs.notifyOnEpoch(epoch, sub, id)
+	}
+}
+
+// notifyOnEpoch broadcasts onEpoch event to given subscriber.
+func (rs *rootResolver) notifyOnEpoch(epoch Epoch, sub *subscriptOnEpoch, id string) {
+	// check if the context isn't already closed in which case we just unsub and leave
+	select {
+	case <-sub.stop:
+		rs.unsubscribeOnEpoch <- id
+		return
+	default:
+	}
+
+	// broadcast
+	select {
+	case <-sub.stop:
+		// just unsub on broken context
+		rs.unsubscribeOnEpoch <- id
+
+	case sub.events <- epoch:
+		// push the epoch to subscriber
+
+	case <-time.After(time.Second):
+		// timeout reached without response? just remove the subscriber
+		rs.unsubscribeOnEpoch <- id
+	}
+}
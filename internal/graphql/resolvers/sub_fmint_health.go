@@ -0,0 +1,157 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// onFMintHealthChannelCapacity is the number of fMint health events held in memory for being broadcast to subscriber.
+const onFMintHealthChannelCapacity = 10
+
+// FMintHealth represents resolvable state of the collateral ratio
+// of an fMint account relative to a client provided threshold.
+type FMintHealth struct {
+	Owner     common.Address
+	Ratio     *float64
+	Threshold float64
+	IsBelow   bool
+	Block     hexutil.Uint64
+}
+
+// subscriptOnFMintHealth represents reference to a subscriber to onFMintHealth events broadcast.
+type subscriptOnFMintHealth struct {
+	owner     common.Address
+	threshold float64
+	stop      <-chan struct{}
+	events    chan<- *FMintHealth
+
+	// below is the last known side of the threshold, nil if not evaluated yet
+	below *bool
+
+	// busy signals an evaluation of the subscriber being in progress
+	busy int32
+}
+
+// OnFMintHealth resolves subscription to the collateral ratio of an fMint account
+// crossing the given threshold. The current state is sent on the first evaluation,
+// any following event means the ratio crossed the threshold.
+func (rs *rootResolver) OnFMintHealth(ctx context.Context, args *struct {
+	Owner     common.Address
+	Threshold float64
+}) <-chan *FMintHealth {
+	// make the stream
+	c := make(chan *FMintHealth, onFMintHealthChannelCapacity)
+
+	// subscribe to event dispatch
+	rs.subscribeOnFMintHealth <- &subscriptOnFMintHealth{
+		owner:     args.Owner,
+		threshold: args.Threshold,
+		stop:      ctx.Done(),
+		events:    c,
+	}
+	return c
+}
+
+// Account resolves the fMint account of the health event.
+func (fh *FMintHealth) Account(ctx context.Context) (*FMintAccount, error) {
+	ac, err := repository.R().WithContext(ctx).FMintAccount(fh.Owner)
+	if err != nil {
+		return nil, err
+	}
+	return NewFMintAccount(ac), nil
+}
+
+// addFMintHealthSubscriber adds a new subscription to onFMintHealth events.
+func (rs *rootResolver) addFMintHealthSubscriber(sub *subscriptOnFMintHealth) {
+	id, err := uuid()
+	if err == nil {
+		// add the subscriber to the map
+		rs.fMintHealthSubscribers[id] = sub
+	} else {
+		// log critical issue
+		rs.log.Critical("can not generate UUID for new onFMintHealth subscriber")
+		rs.log.Critical(err)
+	}
+}
+
+// dispatchOnFMintHealth re-evaluates collateral ratio of all the subscribed
+// fMint accounts on a new block; the block reflects both price and position updates.
+func (rs *rootResolver) dispatchOnFMintHealth(blk *types.Block) {
+	for id, sub := range rs.fMintHealthSubscribers {
+		// skip the subscriber if the previous evaluation is still running
+		if !atomic.CompareAndSwapInt32(&sub.busy, 0, 1) {
+			continue
+		}
+		go rs.notifyOnFMintHealth(blk.Number, sub, id)
+	}
+}
+
+// notifyOnFMintHealth evaluates the collateral ratio of the subscribed account
+// and broadcasts onFMintHealth event if the threshold has been crossed.
+func (rs *rootResolver) notifyOnFMintHealth(block hexutil.Uint64, sub *subscriptOnFMintHealth, id string) {
+	defer atomic.StoreInt32(&sub.busy, 0)
+
+	// check if the context isn't already closed in which case we just unsub and leave
+	select {
+	case <-sub.stop:
+		rs.unsubscribeOnFMintHealth <- id
+		return
+	default:
+	}
+
+	// get the current state of the account
+	ac, err := repository.R().FMintAccount(sub.owner)
+	if err != nil {
+		rs.log.Errorf("can not evaluate fMint health of %s; %s", sub.owner.String(), err.Error())
+		return
+	}
+
+	// did we cross the threshold?
+	evt := newFMintHealth(ac, sub.threshold, block)
+	if sub.below != nil && *sub.below == evt.IsBelow {
+		return
+	}
+	sub.below = &evt.IsBelow
+
+	// broadcast
+	select {
+	case <-sub.stop:
+		// just unsub on broken context
+		rs.unsubscribeOnFMintHealth <- id
+
+	case sub.events <- evt:
+		// push the event to subscriber
+
+	case <-time.After(time.Second):
+		// timeout reached without response? just remove the subscriber
+		rs.unsubscribeOnFMintHealth <- id
+	}
+}
+
+// newFMintHealth creates a new health event for the given fMint account state.
+// Accounts without debt are always healthy and their ratio is not available.
+func newFMintHealth(ac *types.FMintAccount, threshold float64, block hexutil.Uint64) *FMintHealth {
+	evt := FMintHealth{
+		Owner:     ac.Address,
+		Threshold: threshold,
+		Block:     block,
+	}
+
+	// no debt means no ratio
+	if ac.DebtValue.ToInt().Sign() == 0 {
+		return &evt
+	}
+
+	ratio, _ := new(big.Rat).SetFrac(ac.CollateralValue.ToInt(), ac.DebtValue.ToInt()).Float64()
+	evt.Ratio = &ratio
+	evt.IsBelow = ratio < threshold
+	return &evt
+}
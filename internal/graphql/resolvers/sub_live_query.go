@@ -0,0 +1,225 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/types"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+)
+
+const (
+	// onQueryResultChannelCapacity is the number of live query results held in memory for being broadcast to subscriber.
+	onQueryResultChannelCapacity = 10
+
+	// liveQueryMaxInterval is the max number of blocks between live query evaluations.
+	liveQueryMaxInterval = 600
+)
+
+// QueryResult represents resolvable result of a live query evaluation.
+type QueryResult struct {
+	Block  hexutil.Uint64
+	Data   string
+	Errors []string
+}
+
+// subscriptOnQueryResult represents reference to a subscriber to onQueryResult events broadcast.
+type subscriptOnQueryResult struct {
+	ctx       context.Context
+	query     string
+	variables map[string]interface{}
+	interval  uint64
+	events    chan<- *QueryResult
+
+	// next is the number of the block the query is evaluated on next; zero for the first block
+	next uint64
+
+	// last is the result of the last evaluation pushed to the subscriber
+	last []byte
+
+	// busy signals an evaluation of the subscriber being in progress
+	busy int32
+}
+
+// SetSchema registers the parsed API schema used to evaluate live queries.
+func (rs *rootResolver) SetSchema(schema *graphql.Schema) {
+	rs.schema.Store(schema)
+}
+
+// OnQueryResult resolves subscription to the result of a read only query
+// re-evaluated on new blocks; a result is pushed only if it changed since
+// the previous one. The first result is sent on the first evaluation.
+func (rs *rootResolver) OnQueryResult(ctx context.Context, args *struct {
+	Query     string
+	Variables *string
+	Interval  int32
+}) (<-chan *QueryResult, error) {
+	// validate the query and the interval
+	if !isReadOnlyQuery(args.Query) {
+		return nil, errInvalidArgument("only read queries can be subscribed")
+	}
+	if args.Interval < 1 || args.Interval > liveQueryMaxInterval {
+		return nil, errInvalidArgument("interval must be between 1 and %d blocks", liveQueryMaxInterval)
+	}
+
+	// decode variables, if any
+	var vars map[string]interface{}
+	if args.Variables != nil && *args.Variables != "" {
+		if err := json.Unmarshal([]byte(*args.Variables), &vars); err != nil {
+			return nil, errInvalidArgument("invalid query variables; %s", err.Error())
+		}
+	}
+
+	// make the stream
+	c := make(chan *QueryResult, onQueryResultChannelCapacity)
+
+	// subscribe to event dispatch
+	rs.subscribeOnQueryResult <- &subscriptOnQueryResult{
+		ctx:       ctx,
+		query:     args.Query,
+		variables: vars,
+		interval:  uint64(args.Interval),
+		events:    c,
+	}
+	return c, nil
+}
+
+// addQueryResultSubscriber adds a new subscription to onQueryResult events.
+func (rs *rootResolver) addQueryResultSubscriber(sub *subscriptOnQueryResult) {
+	id, err := uuid()
+	if err == nil {
+		// add the subscriber to the map
+		rs.queryResultSubscribers[id] = sub
+	} else {
+		// log critical issue
+		rs.log.Critical("can not generate UUID for new onQueryResult subscriber")
+		rs.log.Critical(err)
+	}
+}
+
+// dispatchOnQueryResult re-evaluates live queries of the subscribers due on the new block.
+func (rs *rootResolver) dispatchOnQueryResult(blk *types.Block) {
+	schema, ok := rs.schema.Load().(*graphql.Schema)
+	if !ok || schema == nil {
+		return
+	}
+
+	for id, sub := range rs.queryResultSubscribers {
+		// the query is not due yet
+		if uint64(blk.Number) < sub.next {
+			continue
+		}
+
+		// skip the subscriber if the previous evaluation is still running
+		if !atomic.CompareAndSwapInt32(&sub.busy, 0, 1) {
+			continue
+		}
+		sub.next = uint64(blk.Number) + sub.interval
+		go rs.notifyOnQueryResult(schema, blk.Number, sub, id)
+	}
+}
+
+// notifyOnQueryResult evaluates the live query of the subscriber
+// and broadcasts onQueryResult event if the result changed.
+func (rs *rootResolver) notifyOnQueryResult(schema *graphql.Schema, block hexutil.Uint64, sub *subscriptOnQueryResult, id string) {
+	defer atomic.StoreInt32(&sub.busy, 0)
+
+	// check if the context isn't already closed in which case we just unsub and leave
+	select {
+	case <-sub.ctx.Done():
+		rs.unsubscribeOnQueryResult <- id
+		return
+	default:
+	}
+
+	// evaluate the query in the context of the subscription so the access rights apply
+	res := schema.Exec(sub.ctx, sub.query, "", sub.variables)
+	evt := QueryResult{Block: block, Data: string(res.Data), Errors: make([]string, 0, len(res.Errors))}
+	for _, e := range res.Errors {
+		evt.Errors = append(evt.Errors, e.Error())
+	}
+
+	// did the result change?
+	key, err := json.Marshal(struct {
+		Data   json.RawMessage
+		Errors []string
+	}{res.Data, evt.Errors})
+	if err != nil {
+		rs.log.Errorf("can not encode live query result; %s", err.Error())
+		return
+	}
+	if sub.last != nil && bytes.Equal(sub.last, key) {
+		return
+	}
+	sub.last = key
+
+	// broadcast
+	select {
+	case <-sub.ctx.Done():
+		// just unsub on broken context
+		rs.unsubscribeOnQueryResult <- id
+
+	case sub.events <- &evt:
+		// push the event to subscriber
+
+	case <-time.After(time.Second):
+		// timeout reached without response? just remove the subscriber
+		rs.unsubscribeOnQueryResult <- id
+	}
+}
+
+// isReadOnlyQuery checks the GraphQL document contains only query operations,
+// e.g. no mutations and no subscriptions can be evaluated as live queries.
+func isReadOnlyQuery(doc string) bool {
+	var depth int
+	for i := 0; i < len(doc); i++ {
+		switch c := doc[i]; {
+		case c == '#':
+			// skip comments
+			for i < len(doc) && doc[i] != '\n' {
+				i++
+			}
+		case c == '"':
+			// skip strings, including the block ones
+			if strings.HasPrefix(doc[i:], `"""`) {
+				end := strings.Index(doc[i+3:], `"""`)
+				if end < 0 {
+					return false
+				}
+				i += end + 5
+				continue
+			}
+			for i++; i < len(doc) && doc[i] != '"'; i++ {
+				if doc[i] == '\\' {
+					i++
+				}
+			}
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		case depth == 0 && isNameByte(c):
+			// read the name; operation keywords are only meaningful on the top level
+			j := i
+			for j < len(doc) && isNameByte(doc[j]) {
+				j++
+			}
+			if name := doc[i:j]; name == "mutation" || name == "subscription" {
+				return false
+			}
+			i = j - 1
+		}
+	}
+	return strings.TrimSpace(doc) != ""
+}
+
+// isNameByte checks if the character can be a part of a GraphQL name.
+func isNameByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -3,8 +3,11 @@ package resolvers
 
 import (
 	"context"
+	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // onTrxChannelCapacity is the number of new transaction events held in memory for being broadcast to subscriber.
@@ -16,18 +19,78 @@ type subscriptOnTrx struct {
 	events chan<- *Transaction
 }
 
-// OnBlock resolves subscription to new blocks event broadcast.
-func (rs *rootResolver) OnTransaction(ctx context.Context) <-chan *Transaction {
+// OnTransaction resolves subscription to new transactions event broadcast.
+// If the last block received by the client is provided, the transactions
+// of the blocks following it are replayed before the new transactions are streamed.
+func (rs *rootResolver) OnTransaction(ctx context.Context, args *struct{ SinceBlock *hexutil.Uint64 }) (<-chan *Transaction, error) {
 	// make the stream
 	c := make(chan *Transaction, onTrxChannelCapacity)
+	if args.SinceBlock == nil {
+		rs.subscribeOnTrx <- &subscriptOnTrx{stop: ctx.Done(), events: c}
+		return c, nil
+	}
+
+	// resumed stream subscribes first so no new transaction is missed while the head
+	// is being read; new transactions are kept aside until the replay is done
+	sctx, cancel := context.WithCancel(ctx)
+	live := make(chan *Transaction, onTrxChannelCapacity)
+	rs.subscribeOnTrx <- &subscriptOnTrx{stop: sctx.Done(), events: live}
+
+	head, err := replayHead(sctx, uint64(*args.SinceBlock))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go rs.resumeOnTransaction(sctx, cancel, uint64(*args.SinceBlock), head, live, c)
+	return c, nil
+}
+
+// resumeOnTransaction replays transactions of the blocks following the given block
+// up to the head, and then forwards new transactions; transactions of the blocks
+// replayed already are dropped.
+func (rs *rootResolver) resumeOnTransaction(ctx context.Context, cancel context.CancelFunc, since uint64, head uint64, live <-chan *Transaction, out chan<- *Transaction) {
+	defer cancel()
+
+	for n := since + 1; n <= head; n++ {
+		num := hexutil.Uint64(n)
+		blk, err := repository.R().WithContext(ctx).BlockByNumber(&num)
+		if err != nil {
+			rs.log.Errorf("can not replay transactions of block #%d; %s", n, err.Error())
+			continue
+		}
 
-	// subscribe to event dispatch
-	rs.subscribeOnTrx <- &subscriptOnTrx{
-		stop:   ctx.Done(),
-		events: c,
+		for _, hash := range blk.Txs {
+			trx, err := repository.R().WithContext(ctx).Transaction(hash)
+			if err != nil {
+				rs.log.Errorf("can not replay transaction %s; %s", hash.String(), err.Error())
+				continue
+			}
+
+			select {
+			case out <- NewTransaction(trx):
+			case <-ctx.Done():
+				return
+			}
+		}
 	}
 
-	return c
+	for {
+		select {
+		case trx := <-live:
+			if trx.BlockNumber != nil && uint64(*trx.BlockNumber) <= head {
+				continue
+			}
+
+			select {
+			case out <- trx:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
 // addTrxSubscriber adds a new subscription to onTransaction events.
@@ -0,0 +1,99 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"time"
+)
+
+// onUniswapPairChannelCapacity is the number of new Uniswap pair events held in memory for being broadcast to subscriber.
+const onUniswapPairChannelCapacity = 50
+
+// UniswapPairCreated represents resolvable Uniswap pair creation event.
+type UniswapPairCreated struct {
+	types.UniswapPairCreated
+}
+
+// Pair resolves the newly created Uniswap pair.
+func (upc *UniswapPairCreated) Pair() *UniswapPair {
+	return NewUniswapPair(&upc.UniswapPairCreated.Pair)
+}
+
+// Token0 resolves the first token of the pair.
+func (upc *UniswapPairCreated) Token0() *ERC20Token {
+	return NewErc20Token(&upc.UniswapPairCreated.Token0)
+}
+
+// Token1 resolves the second token of the pair.
+func (upc *UniswapPairCreated) Token1() *ERC20Token {
+	return NewErc20Token(&upc.UniswapPairCreated.Token1)
+}
+
+// subscriptOnUniswapPair represents reference to a subscriber to onUniswapPairCreated events broadcast.
+type subscriptOnUniswapPair struct {
+	stop   <-chan struct{}
+	events chan<- *UniswapPairCreated
+}
+
+// OnUniswapPairCreated resolves subscription to new Uniswap pairs event broadcast.
+func (rs *rootResolver) OnUniswapPairCreated(ctx context.Context) <-chan *UniswapPairCreated {
+	// make the stream
+	c := make(chan *UniswapPairCreated, onUniswapPairChannelCapacity)
+
+	// subscribe to event dispatch
+	rs.subscribeOnUniswapPair <- &subscriptOnUniswapPair{
+		stop:   ctx.Done(),
+		events: c,
+	}
+	return c
+}
+
+// addUniswapPairSubscriber adds a new subscription to onUniswapPairCreated events.
+func (rs *rootResolver) addUniswapPairSubscriber(sub *subscriptOnUniswapPair) {
+	id, err := uuid()
+	if err == nil {
+		// add the subscriber to the map
+		rs.uniswapPairSubscribers[id] = sub
+	} else {
+		// log critical issue
+		rs.log.Critical("can not generate UUID for new onUniswapPairCreated subscriber")
+		rs.log.Critical(err)
+	}
+}
+
+// dispatchOnUniswapPair dispatches onUniswapPairCreated event to registered subscribers.
+func (rs *rootResolver) dispatchOnUniswapPair(up *types.UniswapPairCreated) {
+	// prep the event
+	evt := &UniswapPairCreated{*up}
+
+	// broadcast the event in separate go routines so we don't block here
+	for id, sub := range rs.uniswapPairSubscribers {
+		go rs.notifyOnUniswapPair(evt, sub, id)
+	}
+}
+
+// notifyOnUniswapPair broadcasts onUniswapPairCreated event to given subscriber.
+func (rs *rootResolver) notifyOnUniswapPair(evt *UniswapPairCreated, sub *subscriptOnUniswapPair, id string) {
+	// check if the context isn't already closed in which case we just unsub and leave
+	select {
+	case <-sub.stop:
+		rs.unsubscribeOnUniswapPair <- id
+		return
+	default:
+	}
+
+	// broadcast
+	select {
+	case <-sub.stop:
+		// just unsub on broken context
+		rs.unsubscribeOnUniswapPair <- id
+
+	case sub.events <- evt:
+		// push the event to subscriber
+
+	case <-time.After(time.Second):
+		// timeout reached without response? just remove the subscriber
+		rs.unsubscribeOnUniswapPair <- id
+	}
+}
@@ -2,10 +2,13 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
 )
 
 // Transaction represents resolvable blockchain transaction structure.
@@ -21,33 +24,54 @@ func NewTransaction(trx *types.Transaction) *Transaction {
 }
 
 // Transaction resolves blockchain transaction by transaction hash.
-func (rs *rootResolver) Transaction(args *struct{ Hash common.Hash }) (*Transaction, error) {
+func (rs *rootResolver) Transaction(ctx context.Context, args *struct{ Hash common.Hash }) (*Transaction, error) {
 	// get the transaction from repository
-	trx, err := repository.R().Transaction(&args.Hash)
+	val, err := batchLoad(ctx, "trx/"+args.Hash.String(), func() (interface{}, error) {
+		return repository.R().WithContext(ctx).Transaction(&args.Hash)
+	})
 	if err != nil {
 		rs.log.Warningf("can not get transaction %s", args.Hash)
 		return nil, err
 	}
+	trx := val.(*types.Transaction)
 
+	// pending transaction will change once it's processed
+	if trx == nil || trx.BlockNumber == nil {
+		setCacheHint(ctx, 0)
+	}
 	return NewTransaction(trx), nil
 }
 
 // SendTransaction sends raw signed and RLP encoded transaction to the block chain.
-func (rs *rootResolver) SendTransaction(args *struct{ Tx hexutil.Bytes }) (*Transaction, error) {
+func (rs *rootResolver) SendTransaction(ctx context.Context, args *struct{ Tx hexutil.Bytes }) (*Transaction, error) {
+	// no transactions during maintenance
+	if rs.InMaintenance() {
+		return nil, ErrMaintenance
+	}
+
 	// get the transaction from repository
-	trx, err := repository.R().SendTransaction(args.Tx)
+	trx, err := repository.R().WithContext(ctx).SendTransaction(args.Tx)
 	if err != nil {
 		rs.log.Warningf("can not send transaction %s", err.Error())
-		return nil, err
+		return nil, complianceError(err)
 	}
 
 	return NewTransaction(trx), nil
 }
 
+// RevertReason resolves the reason of a failed transaction. Transactions indexed
+// before the reason was captured get it by replaying the call on demand.
+func (trx *Transaction) RevertReason(ctx context.Context) (*string, error) {
+	if trx.Transaction.RevertReason != nil {
+		return trx.Transaction.RevertReason, nil
+	}
+	return repository.R().WithContext(ctx).TransactionRevertReason(&trx.Transaction)
+}
+
 // Sender resolves sender's account of the transaction.
-func (trx *Transaction) Sender() (*Account, error) {
+func (trx *Transaction) Sender(ctx context.Context) (*Account, error) {
 	// get the sender by address
-	acc, err := repository.R().Account(&trx.From)
+	acc, err := repository.R().WithContext(ctx).Account(&trx.From)
 	if err != nil {
 		return nil, err
 	}
@@ -56,14 +80,14 @@ func (trx *Transaction) Sender() (*Account, error) {
 }
 
 // Recipient resolves recipient's account of the transaction.
-func (trx *Transaction) Recipient() (*Account, error) {
+func (trx *Transaction) Recipient(ctx context.Context) (*Account, error) {
 	// no recipient available
 	if trx.To == nil {
 		return nil, nil
 	}
 
 	// get the recipient by address
-	acc, err := repository.R().Account(trx.To)
+	acc, err := repository.R().WithContext(ctx).Account(trx.To)
 	if err != nil {
 		return nil, err
 	}
@@ -71,18 +95,81 @@ func (trx *Transaction) Recipient() (*Account, error) {
 	return NewAccount(acc), nil
 }
 
+// FromLabel resolves the public label of the transaction sender, if any.
+func (trx *Transaction) FromLabel(ctx context.Context) (*AddressLabel, error) {
+	return addressLabel(ctx, &trx.From)
+}
+
+// ToLabel resolves the public label of the transaction recipient, if any.
+func (trx *Transaction) ToLabel(ctx context.Context) (*AddressLabel, error) {
+	// no recipient available
+	if trx.To == nil {
+		return nil, nil
+	}
+	return addressLabel(ctx, trx.To)
+}
+
 // Block resolves block the transaction is bundled in, nil if it's pending and not added to a block yet.
-func (trx *Transaction) Block() (*Block, error) {
+func (trx *Transaction) Block(ctx context.Context) (*Block, error) {
 	// no recipient available
 	if trx.BlockNumber == nil {
 		return nil, nil
 	}
 
 	// get the sender by address
-	blk, err := repository.R().BlockByNumber(trx.BlockNumber)
+	blk, err := repository.R().WithContext(ctx).BlockByNumber(trx.BlockNumber)
 	if err != nil {
 		return nil, err
 	}
 
 	return NewBlock(blk), nil
 }
+
+// Fee resolves the fee paid for processing the transaction, nil if it's pending.
+func (trx *Transaction) Fee() *hexutil.Big {
+	if trx.GasUsed == nil {
+		return nil
+	}
+
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(uint64(*trx.GasUsed)), trx.GasPrice.ToInt())
+	return (*hexutil.Big)(fee)
+}
+
+// FeeValue resolves the fee of the transaction in the given target symbol
+// using the historical price of FTM at the time of the transaction.
+func (trx *Transaction) FeeValue(ctx context.Context, args *struct{ To string }) (*float64, error) {
+	fee := trx.Fee()
+	if fee == nil {
+		return nil, nil
+	}
+
+	// transactions loaded from the node don't carry the time stamp
+	ts, err := trx.timeStamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the price at the time of the transaction
+	ps, err := repository.R().WithContext(ctx).PriceAt(args.To, ts)
+	if err != nil || ps == nil {
+		return nil, err
+	}
+
+	val, _ := new(big.Float).Quo(new(big.Float).SetInt(fee.ToInt()), new(big.Float).SetInt(weiToFtmDecimals)).Float64()
+	val *= ps.Price
+	return &val, nil
+}
+
+// timeStamp provides the time stamp of the transaction,
+// falling back to the time stamp of its block.
+func (trx *Transaction) timeStamp(ctx context.Context) (time.Time, error) {
+	if !trx.TimeStamp.IsZero() || trx.BlockNumber == nil {
+		return trx.TimeStamp, nil
+	}
+
+	blk, err := repository.R().WithContext(ctx).BlockByNumber(trx.BlockNumber)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(blk.TimeStamp), 0).UTC(), nil
+}
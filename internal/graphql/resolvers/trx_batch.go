@@ -0,0 +1,67 @@
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// sendTransactionBatchMaxSize is the max number of transactions accepted in a single batch.
+const sendTransactionBatchMaxSize = 100
+
+// TransactionSubmission represents resolvable result of a transaction submitted in a batch.
+type TransactionSubmission struct {
+	types.TransactionSubmission
+}
+
+// SendTransactionBatch sends a list of raw signed and RLP encoded transactions to the block chain in order.
+func (rs *rootResolver) SendTransactionBatch(ctx context.Context, args *struct{ Txs []hexutil.Bytes }) ([]*TransactionSubmission, error) {
+	// no transactions during maintenance
+	if rs.InMaintenance() {
+		return nil, ErrMaintenance
+	}
+
+	// check the batch size
+	if len(args.Txs) == 0 || len(args.Txs) > sendTransactionBatchMaxSize {
+		return nil, errInvalidArgument("batch must contain between 1 and %d transactions", sendTransactionBatchMaxSize)
+	}
+
+	res := repository.R().WithContext(ctx).SendTransactionBatch(args.Txs)
+	list := make([]*TransactionSubmission, len(res))
+	for i, ts := range res {
+		if ts.Error != nil {
+			rs.log.Warningf("can not send batch transaction #%d; %s", ts.Index, ts.Error.Error())
+		}
+		list[i] = &TransactionSubmission{TransactionSubmission: *ts}
+	}
+	return list, nil
+}
+
+// Index resolves the position of the transaction in the batch.
+func (ts *TransactionSubmission) Index() int32 {
+	return int32(ts.TransactionSubmission.Index)
+}
+
+// Hash resolves the hash of the submitted transaction, if it could be decoded.
+func (ts *TransactionSubmission) Hash() *common.Hash {
+	return ts.TransactionSubmission.Hash
+}
+
+// Transaction resolves the submitted transaction; nil if the submission failed.
+func (ts *TransactionSubmission) Transaction() *Transaction {
+	if ts.TransactionSubmission.Transaction == nil {
+		return nil
+	}
+	return NewTransaction(ts.TransactionSubmission.Transaction)
+}
+
+// Error resolves the reason the transaction was not submitted, if so.
+func (ts *TransactionSubmission) Error() *string {
+	if ts.TransactionSubmission.Error == nil {
+		return nil
+	}
+	msg := ts.TransactionSubmission.Error.Error()
+	return &msg
+}
@@ -2,9 +2,9 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
-	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
 	"time"
@@ -19,7 +19,7 @@ type DailyTrxVolume struct {
 }
 
 // TrxVolume resolves list of daily aggregations of the network transaction flow.
-func (rs *rootResolver) TrxVolume(args struct {
+func (rs *rootResolver) TrxVolume(ctx context.Context, args struct {
 	From *string
 	To   *string
 }) ([]*DailyTrxVolume, error) {
@@ -30,7 +30,7 @@ func (rs *rootResolver) TrxVolume(args struct {
 	}
 
 	// load data
-	dv, err := repository.R().TrxFlowVolume(from, to)
+	dv, err := repository.R().WithContext(ctx).TrxFlowVolume(from, to)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +45,7 @@ func (rs *rootResolver) TrxVolume(args struct {
 
 // TrxGasSpeed resolves the gas consumption speed speed
 // of the network in transactions processed per second.
-func (rs *rootResolver) TrxGasSpeed(args struct {
+func (rs *rootResolver) TrxGasSpeed(ctx context.Context, args struct {
 	Range int32
 	To    *string
 }) (val float64, err error) {
@@ -68,18 +68,18 @@ func (rs *rootResolver) TrxGasSpeed(args struct {
 
 	// log what we do
 	rs.log.Noticef("calculating gas speed from %s to %s", from.String(), to.String())
-	return repository.R().TrxGasSpeed(&from, &to)
+	return repository.R().WithContext(ctx).TrxGasSpeed(&from, &to)
 }
 
 // TrxSpeed resolves the recent speed of the network in transactions processed per second.
-func (rs *rootResolver) TrxSpeed(args struct {
+func (rs *rootResolver) TrxSpeed(ctx context.Context, args struct {
 	Range int32
 }) (float64, error) {
 	// make sure to obey the minimal range
 	if args.Range < 60 {
 		args.Range = 60
 	}
-	return repository.R().TrxFlowSpeed(args.Range)
+	return repository.R().WithContext(ctx).TrxFlowSpeed(args.Range)
 }
 
 // trxVolumeRange generates the time range for trx volume resolver.
@@ -111,7 +111,7 @@ func trxVolumeRange(args struct {
 
 	// make sure the from is before to
 	if from.After(to) {
-		return nil, nil, fmt.Errorf("invalid date range received")
+		return nil, nil, errInvalidArgument("invalid date range received")
 	}
 	return &from, &to, nil
 }
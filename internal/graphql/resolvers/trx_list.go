@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -27,7 +28,7 @@ func NewTransactionList(txs *types.TransactionList) *TransactionList {
 }
 
 // Transactions resolves list of blockchain transactions encapsulated in a listable structure.
-func (rs *rootResolver) Transactions(args *struct {
+func (rs *rootResolver) Transactions(ctx context.Context, args *struct {
 	Cursor *Cursor
 	Count  int32
 }) (*TransactionList, error) {
@@ -36,7 +37,7 @@ func (rs *rootResolver) Transactions(args *struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the transaction hash list from repository
-	txs, err := repository.R().Transactions((*string)(args.Cursor), args.Count)
+	txs, err := repository.R().WithContext(ctx).Transactions((*string)(args.Cursor), args.Count)
 	if err != nil {
 		rs.log.Errorf("can not get transactions list; %s", err.Error())
 		return nil, err
@@ -58,8 +59,8 @@ func (tl *TransactionList) PageInfo() (*ListPageInfo, error) {
 	}
 
 	// get the first and last elements
-	first := Cursor(tl.Collection[0].Hash.String())
-	last := Cursor(tl.Collection[len(tl.Collection)-1].Hash.String())
+	first := Cursor(types.ListCursor(tl.Collection[0].Uid()))
+	last := Cursor(types.ListCursor(tl.Collection[len(tl.Collection)-1].Uid()))
 	return NewListPageInfo(&first, &last, !tl.IsEnd, !tl.IsStart)
 }
 
@@ -76,7 +77,7 @@ func (tl *TransactionList) Edges() []*TransactionListEdge {
 		// make the element
 		edges[i] = &TransactionListEdge{
 			Transaction: NewTransaction(t),
-			Cursor:      Cursor(t.Hash.String()),
+			Cursor:      Cursor(types.ListCursor(t.Uid())),
 		}
 	}
 	return edges
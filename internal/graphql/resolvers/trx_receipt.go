@@ -0,0 +1,77 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// TransactionReceipt represents resolvable receipt of a processed transaction.
+type TransactionReceipt struct {
+	types.TransactionReceipt
+	trx *Transaction
+}
+
+// TransactionLog represents resolvable log record of a transaction.
+type TransactionLog struct {
+	retypes.Log
+}
+
+// Raw resolves the RLP encoded transaction loaded from the node.
+func (trx *Transaction) Raw(ctx context.Context) (hexutil.Bytes, error) {
+	return repository.R().WithContext(ctx).TransactionRaw(&trx.Hash)
+}
+
+// Receipt resolves the receipt of the transaction loaded from the node.
+func (trx *Transaction) Receipt(ctx context.Context) (*TransactionReceipt, error) {
+	// pending transactions don't have any receipt
+	if trx.BlockHash == nil {
+		return nil, nil
+	}
+
+	rec, err := repository.R().WithContext(ctx).TransactionReceipt(&trx.Hash)
+	if err != nil || rec == nil {
+		return nil, err
+	}
+	return &TransactionReceipt{TransactionReceipt: *rec, trx: trx}, nil
+}
+
+// EffectiveGasPrice resolves the price paid per unit of gas; the gas price of the transaction
+// is used if the node does not provide the effective price.
+func (rec *TransactionReceipt) EffectiveGasPrice() hexutil.Big {
+	if rec.TransactionReceipt.EffectiveGasPrice == nil {
+		return rec.trx.GasPrice
+	}
+	return *rec.TransactionReceipt.EffectiveGasPrice
+}
+
+// Logs resolves the list of log records created by the transaction.
+func (rec *TransactionReceipt) Logs() []*TransactionLog {
+	list := make([]*TransactionLog, len(rec.TransactionReceipt.Logs))
+	for i, l := range rec.TransactionReceipt.Logs {
+		list[i] = &TransactionLog{Log: l}
+	}
+	return list
+}
+
+// Topics resolves the list of topics of the log record.
+func (tl *TransactionLog) Topics() []common.Hash {
+	if tl.Log.Topics == nil {
+		return []common.Hash{}
+	}
+	return tl.Log.Topics
+}
+
+// Data resolves the data of the log record.
+func (tl *TransactionLog) Data() hexutil.Bytes {
+	return tl.Log.Data
+}
+
+// LogIndex resolves the index of the log record in the block.
+func (tl *TransactionLog) LogIndex() hexutil.Uint64 {
+	return hexutil.Uint64(tl.Index)
+}
@@ -2,9 +2,9 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
-	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -47,7 +47,7 @@ func NewUniswapPair(adr *common.Address) *UniswapPair {
 // defiUniswapPairs load list of Uniswap pairs once in concurrent threads.
 func (rs *rootResolver) defiUniswapPairs() []*UniswapPair {
 	// make sure to do this only once
-	list, err, _ := rs.cg.Do("uniswap-pairs", func() (interface{}, error) {
+	list, err := lookups.do(lookupPairs, "list", func() (interface{}, error) {
 		// get the list of pair addresses
 		pairs, err := repository.R().UniswapPairs()
 		if err != nil || pairs == nil {
@@ -74,58 +74,58 @@ func (rs *rootResolver) DefiUniswapPairs() []*UniswapPair {
 
 // DefiUniswapAmountsOut resolves a list of output amounts for the given
 // input amount and a list of tokens to be used to make the swap operation.
-func (rs *rootResolver) DefiUniswapAmountsOut(args *struct {
+func (rs *rootResolver) DefiUniswapAmountsOut(ctx context.Context, args *struct {
 	AmountIn hexutil.Big
 	Tokens   []common.Address
 }) ([]hexutil.Big, error) {
-	return repository.R().UniswapAmountsOut(args.AmountIn, args.Tokens)
+	return repository.R().WithContext(ctx).UniswapAmountsOut(args.AmountIn, args.Tokens)
 }
 
 // DefiUniswapAmountsOut resolves a list of input amounts for the given
 // output amount and a list of tokens to be used to make the swap operation.
-func (rs *rootResolver) DefiUniswapAmountsIn(args *struct {
+func (rs *rootResolver) DefiUniswapAmountsIn(ctx context.Context, args *struct {
 	AmountOut hexutil.Big
 	Tokens    []common.Address
 }) ([]hexutil.Big, error) {
-	return repository.R().UniswapAmountsIn(args.AmountOut, args.Tokens)
+	return repository.R().WithContext(ctx).UniswapAmountsIn(args.AmountOut, args.Tokens)
 }
 
 // DefiUniswapQuoteLiquidity resolves a list of optimal amounts of tokens
 // to be added to both sides of a pair on addLiquidity call.
-func (rs *rootResolver) DefiUniswapQuoteLiquidity(args *struct {
+func (rs *rootResolver) DefiUniswapQuoteLiquidity(ctx context.Context, args *struct {
 	Tokens    []common.Address
 	AmountsIn []hexutil.Big
 }) ([]hexutil.Big, error) {
 	// make sure the number of tokens make sense
 	if args.Tokens == nil || len(args.Tokens) != 2 {
-		return nil, fmt.Errorf("invalid tokens pair given")
+		return nil, errInvalidArgument("invalid tokens pair given")
 	}
 
 	// make sure the number of input prices make sense
 	if args.AmountsIn == nil || len(args.AmountsIn) != 2 {
-		return nil, fmt.Errorf("invalid input amounts pair given")
+		return nil, errInvalidArgument("invalid input amounts pair given")
 	}
 
 	// get the pair address for the given set of tokens
-	pair, err := repository.R().UniswapPair(&args.Tokens[0], &args.Tokens[1])
+	pair, err := repository.R().WithContext(ctx).UniswapPair(&args.Tokens[0], &args.Tokens[1])
 	if err != nil {
 		return nil, err
 	}
 
 	// get normalized tokens order
-	tokens, err := repository.R().UniswapTokens(pair)
+	tokens, err := repository.R().WithContext(ctx).UniswapTokens(pair)
 	if err != nil {
 		return nil, err
 	}
 
 	// make sure to call the amounts correctly
 	if tokens[0] == args.Tokens[0] {
-		return rs.uniswapOptimalLiquidity(pair, &args.AmountsIn[0], &args.AmountsIn[1])
+		return rs.uniswapOptimalLiquidity(ctx, pair, &args.AmountsIn[0], &args.AmountsIn[1])
 	}
 
 	// tokens came in in reversed order
 	if tokens[0] == args.Tokens[1] {
-		val, err := rs.uniswapOptimalLiquidity(pair, &args.AmountsIn[1], &args.AmountsIn[0])
+		val, err := rs.uniswapOptimalLiquidity(ctx, pair, &args.AmountsIn[1], &args.AmountsIn[0])
 		if err != nil {
 			return nil, err
 		}
@@ -135,17 +135,18 @@ func (rs *rootResolver) DefiUniswapQuoteLiquidity(args *struct {
 	}
 
 	// sanity check, tokens don't match the original pair?
-	return nil, fmt.Errorf("the pair tokens don't match with input tokens")
+	return nil, errInvalidArgument("the pair tokens don't match with input tokens")
 }
 
 // uniswapQuoteLiquidity calculates the optimal liquidity advance on addLiquidity call.
 func (rs *rootResolver) uniswapOptimalLiquidity(
+	ctx context.Context,
 	pair *common.Address,
 	amountAIn *hexutil.Big,
 	amountBIn *hexutil.Big,
 ) ([]hexutil.Big, error) {
 	// get amount of reserves
-	reserves, err := repository.R().UniswapReserves(pair)
+	reserves, err := repository.R().WithContext(ctx).UniswapReserves(pair)
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +157,7 @@ func (rs *rootResolver) uniswapOptimalLiquidity(
 	}
 
 	// get side B optimal
-	optimalB, err := repository.R().UniswapQuoteInput(*amountAIn, reserves[0], reserves[1])
+	optimalB, err := repository.R().WithContext(ctx).UniswapQuoteInput(*amountAIn, reserves[0], reserves[1])
 	if err != nil {
 		return nil, err
 	}
@@ -167,23 +168,23 @@ func (rs *rootResolver) uniswapOptimalLiquidity(
 	}
 
 	// optimal B si higher than the input offered; calculate optimal A from the reversed reserves
-	optimalA, err := repository.R().UniswapQuoteInput(*amountBIn, reserves[1], reserves[0])
+	optimalA, err := repository.R().WithContext(ctx).UniswapQuoteInput(*amountBIn, reserves[1], reserves[0])
 	if err != nil {
 		return nil, err
 	}
 
 	// optimal A must be lower or same as the desired input
 	if 0 < optimalA.ToInt().Cmp(amountAIn.ToInt()) {
-		return nil, fmt.Errorf("neither optimal value matches inputs")
+		return nil, errInvalidArgument("neither optimal value matches inputs")
 	}
 
 	return []hexutil.Big{optimalA, *amountBIn}, nil
 }
 
 // Tokens resolves a list of tokens of the given Uniswap pair.
-func (up *UniswapPair) Tokens() ([]*ERC20Token, error) {
+func (up *UniswapPair) Tokens(ctx context.Context) ([]*ERC20Token, error) {
 	// load addresses
-	tokens, err := repository.R().UniswapTokens(&up.PairAddress)
+	tokens, err := repository.R().WithContext(ctx).UniswapTokens(&up.PairAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +192,7 @@ func (up *UniswapPair) Tokens() ([]*ERC20Token, error) {
 	// make a sanity check, the pair should contain exactly two tokens
 	// since it's called pair for a good reason
 	if 2 != len(tokens) {
-		return nil, fmt.Errorf("invalid pair tokens list")
+		return nil, errInvalidArgument("invalid pair tokens list")
 	}
 
 	// make the list container
@@ -204,7 +205,7 @@ func (up *UniswapPair) Tokens() ([]*ERC20Token, error) {
 }
 
 // DefiUniswapVolumes returns all swap pairs and their information for swap volumes
-func (rs *rootResolver) DefiUniswapVolumes() []*UniswapPairVolume {
+func (rs *rootResolver) DefiUniswapVolumes(ctx context.Context) []*UniswapPairVolume {
 	// get all the pairs
 	pairs := rs.defiUniswapPairs()
 
@@ -212,14 +213,14 @@ func (rs *rootResolver) DefiUniswapVolumes() []*UniswapPairVolume {
 	list := make([]*UniswapPairVolume, len(pairs))
 	for i, pair := range pairs {
 		// get thr pair tokens
-		tl, err := repository.R().UniswapTokens(&pair.PairAddress)
+		tl, err := repository.R().WithContext(ctx).UniswapTokens(&pair.PairAddress)
 		if err != nil {
 			return list
 		}
 
 		// get token price for denomination
 		isDenominated := true
-		tokenAPrice, err := repository.R().DefiTokenPrice(&tl[0])
+		tokenAPrice, err := repository.R().WithContext(ctx).DefiTokenPrice(&tl[0])
 		if err != nil {
 			tokenAPrice = hexutil.Big{}
 			isDenominated = false
@@ -236,9 +237,9 @@ func (rs *rootResolver) DefiUniswapVolumes() []*UniswapPairVolume {
 	return list
 }
 
-func (upv *UniswapPairVolume) getVolumeTillNow(fromTime int64) (hexutil.Big, error) {
+func (upv *UniswapPairVolume) getVolumeTillNow(ctx context.Context, fromTime int64) (hexutil.Big, error) {
 	toTime := time.Now().UTC().Unix()
-	swapVolume, err := repository.R().UniswapVolume(&upv.PairAddress, fromTime, toTime)
+	swapVolume, err := repository.R().WithContext(ctx).UniswapVolume(&upv.PairAddress, fromTime, toTime)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -246,27 +247,27 @@ func (upv *UniswapPairVolume) getVolumeTillNow(fromTime int64) (hexutil.Big, err
 }
 
 // DailyVolume returns swap volume for last 24 hours
-func (upv *UniswapPairVolume) DailyVolume() (hexutil.Big, error) {
+func (upv *UniswapPairVolume) DailyVolume(ctx context.Context) (hexutil.Big, error) {
 	fromTime := time.Now().UTC().AddDate(0, 0, -1).Unix()
-	return upv.getVolumeTillNow(fromTime)
+	return upv.getVolumeTillNow(ctx, fromTime)
 }
 
 // WeeklyVolume returns swap volume for last 7 days
-func (upv *UniswapPairVolume) WeeklyVolume() (hexutil.Big, error) {
+func (upv *UniswapPairVolume) WeeklyVolume(ctx context.Context) (hexutil.Big, error) {
 	fromTime := time.Now().UTC().AddDate(0, 0, -7).Unix()
-	return upv.getVolumeTillNow(fromTime)
+	return upv.getVolumeTillNow(ctx, fromTime)
 }
 
 // MonthlyVolume returns swap volume for last month
-func (upv *UniswapPairVolume) MonthlyVolume() (hexutil.Big, error) {
+func (upv *UniswapPairVolume) MonthlyVolume(ctx context.Context) (hexutil.Big, error) {
 	fromTime := time.Now().UTC().AddDate(0, -1, 0).Unix()
-	return upv.getVolumeTillNow(fromTime)
+	return upv.getVolumeTillNow(ctx, fromTime)
 }
 
 // YearlyVolume returns swap volume for last year
-func (upv *UniswapPairVolume) YearlyVolume() (hexutil.Big, error) {
+func (upv *UniswapPairVolume) YearlyVolume(ctx context.Context) (hexutil.Big, error) {
 	fromTime := time.Now().UTC().AddDate(-1, 0, 0).Unix()
-	return upv.getVolumeTillNow(fromTime)
+	return upv.getVolumeTillNow(ctx, fromTime)
 }
 
 // IsInFUSD indicates if TokenA from the pair has a price value to be able
@@ -277,7 +278,7 @@ func (upv *UniswapPairVolume) IsInFUSD() (bool, error) {
 
 // DefiTimeVolumes resolves swap volumes for given pair
 // If dates are not given, then it returns last month values
-func (rs *rootResolver) DefiTimeVolumes(args *struct {
+func (rs *rootResolver) DefiTimeVolumes(ctx context.Context, args *struct {
 	Address    common.Address
 	Resolution *string
 	FromDate   *int32
@@ -299,7 +300,7 @@ func (rs *rootResolver) DefiTimeVolumes(args *struct {
 	}
 
 	// get volumes from DB repository
-	swapVolumes, err := repository.R().UniswapTimeVolumes(&args.Address, resolution, fDate, tDate)
+	swapVolumes, err := repository.R().WithContext(ctx).UniswapTimeVolumes(&args.Address, resolution, fDate, tDate)
 	if err != nil {
 		rs.log.Errorf("Can not get swap volumes from DB repository: %s", err.Error())
 		return make([]*DefiTimeVolume, 0)
@@ -319,7 +320,7 @@ func (rs *rootResolver) DefiTimeVolumes(args *struct {
 
 // DefiTimePrices resolves swap prices for given pair
 // If dates are not given, then it returns last month values
-func (rs *rootResolver) DefiTimePrices(args *struct {
+func (rs *rootResolver) DefiTimePrices(ctx context.Context, args *struct {
 	Address    common.Address
 	Resolution *string
 	FromDate   *int32
@@ -348,7 +349,7 @@ func (rs *rootResolver) DefiTimePrices(args *struct {
 	}
 
 	// get prices from DB repository
-	swapPrices, err := repository.R().UniswapTimePrices(&args.Address, resolution, fDate, tDate, dir)
+	swapPrices, err := repository.R().WithContext(ctx).UniswapTimePrices(&args.Address, resolution, fDate, tDate, dir)
 	if err != nil {
 		rs.log.Errorf("Can not get uniswap prices from DB repository: %s", err.Error())
 		return make([]types.DefiTimePrice, 0)
@@ -357,36 +358,36 @@ func (rs *rootResolver) DefiTimePrices(args *struct {
 }
 
 // Reserves resolves a list of token reserves of the given Uniswap pair.
-func (up *UniswapPair) Reserves() ([]hexutil.Big, error) {
-	return repository.R().UniswapReserves(&up.PairAddress)
+func (up *UniswapPair) Reserves(ctx context.Context) ([]hexutil.Big, error) {
+	return repository.R().WithContext(ctx).UniswapReserves(&up.PairAddress)
 }
 
 // ReservesTimeStamp resolves reserves of the given Uniswap pair.
-func (up *UniswapPair) ReservesTimeStamp() (hexutil.Uint64, error) {
-	return repository.R().UniswapReservesTimeStamp(&up.PairAddress)
+func (up *UniswapPair) ReservesTimeStamp(ctx context.Context) (hexutil.Uint64, error) {
+	return repository.R().WithContext(ctx).UniswapReservesTimeStamp(&up.PairAddress)
 }
 
 // CumulativePrices resolves a list of token cumulative prices
 // of the given Uniswap pair.
-func (up *UniswapPair) CumulativePrices() ([]hexutil.Big, error) {
-	return repository.R().UniswapCumulativePrices(&up.PairAddress)
+func (up *UniswapPair) CumulativePrices(ctx context.Context) ([]hexutil.Big, error) {
+	return repository.R().WithContext(ctx).UniswapCumulativePrices(&up.PairAddress)
 }
 
 // TotalSupply resolves the total amount of pair tokens, e.g. the share pool
 // of the given Uniswap pair.
-func (up *UniswapPair) TotalSupply() (hexutil.Big, error) {
-	return repository.R().Erc20TotalSupply(&up.PairAddress)
+func (up *UniswapPair) TotalSupply(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).Erc20TotalSupply(&up.PairAddress)
 }
 
 // TotalSupply resolves the total amount of pair tokens, e.g. the share pool
 // of the given Uniswap pair.
-func (up *UniswapPair) ShareOf(args *struct{ User common.Address }) (hexutil.Big, error) {
-	return repository.R().Erc20BalanceOf(&up.PairAddress, &args.User)
+func (up *UniswapPair) ShareOf(ctx context.Context, args *struct{ User common.Address }) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).Erc20BalanceOf(&up.PairAddress, &args.User)
 }
 
 // LastKValue resolves the last value of the pool control coefficient.
-func (up *UniswapPair) LastKValue() (hexutil.Big, error) {
-	return repository.R().UniswapLastKValue(&up.PairAddress)
+func (up *UniswapPair) LastKValue(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().WithContext(ctx).UniswapLastKValue(&up.PairAddress)
 }
 
 func checkDate(tdate *int32) int64 {
@@ -398,7 +399,7 @@ func checkDate(tdate *int32) int64 {
 
 // DefiTimeReserves resolves uniswap reserves for given pair
 // If dates are not given, then it returns last month values
-func (rs *rootResolver) DefiTimeReserves(args *struct {
+func (rs *rootResolver) DefiTimeReserves(ctx context.Context, args *struct {
 	Address    common.Address
 	Resolution *string
 	FromDate   *int32
@@ -420,7 +421,7 @@ func (rs *rootResolver) DefiTimeReserves(args *struct {
 	}
 
 	// get reserves from DB repository
-	timeReserves, err := repository.R().UniswapTimeReserves(&args.Address, resolution, fDate, tDate)
+	timeReserves, err := repository.R().WithContext(ctx).UniswapTimeReserves(&args.Address, resolution, fDate, tDate)
 	if err != nil {
 		rs.log.Errorf("Can not get uniswap reserves from DB repository: %s", err.Error())
 		return make([]DefiTimeReserve, 0)
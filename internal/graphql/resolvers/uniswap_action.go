@@ -2,12 +2,12 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
-	"strconv"
 )
 
 // UniswapAction represents resolvable blockchain uniswap action structure.
@@ -41,7 +41,7 @@ func NewUniswapActionList(ual *types.UniswapActionList) *UniswapActionList {
 }
 
 // DefiUniswapActions resolves list of blockchain uniswap actions encapsulated in a listable structure.
-func (rs *rootResolver) DefiUniswapActions(args *struct {
+func (rs *rootResolver) DefiUniswapActions(ctx context.Context, args *struct {
 	Cursor      *Cursor
 	Count       int32
 	PairAddress *common.Address
@@ -56,7 +56,7 @@ func (rs *rootResolver) DefiUniswapActions(args *struct {
 	}
 
 	// get the uniswap action list from repository
-	al, err := repository.R().UniswapActions(args.PairAddress, (*string)(args.Cursor), args.Count, *args.ActionType)
+	al, err := repository.R().WithContext(ctx).UniswapActions(args.PairAddress, (*string)(args.Cursor), args.Count, *args.ActionType)
 	if err != nil {
 		rs.log.Errorf("can not get uniswap action list; %s", err.Error())
 		return nil, err
@@ -78,8 +78,8 @@ func (cl *UniswapActionList) PageInfo() (*ListPageInfo, error) {
 	}
 
 	// get the first and last elements
-	first := Cursor(strconv.FormatUint(cl.First, 10))
-	last := Cursor(strconv.FormatUint(cl.Last, 10))
+	first := Cursor(types.ListCursor(cl.First))
+	last := Cursor(types.ListCursor(cl.Last))
 	return NewListPageInfo(&first, &last, !cl.IsEnd, !cl.IsStart)
 }
 
@@ -95,7 +95,7 @@ func (cl *UniswapActionList) Edges() []*UniswapActionListEdge {
 	for i, c := range cl.Collection {
 		edges[i] = &UniswapActionListEdge{
 			UniswapAction: NewUniswapAction(c, NewUniswapPair(&c.PairAddress)),
-			Cursor:        Cursor(strconv.FormatUint(c.OrdIndex, 10)),
+			Cursor:        Cursor(types.ListCursor(c.OrdIndex)),
 		}
 	}
 
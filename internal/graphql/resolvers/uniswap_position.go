@@ -0,0 +1,148 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// UniswapPosition represents a liquidity position of a provider
+// on a single Uniswap pair.
+type UniswapPosition struct {
+	types.UniswapPositionEntry
+	Owner       common.Address
+	Liquidity   hexutil.Big
+	TotalSupply hexutil.Big
+	Reserves    []hexutil.Big
+}
+
+// UniswapPositions resolves the list of liquidity positions the given owner
+// holds on Uniswap pairs. Only pairs with a non-zero liquidity are listed.
+func (rs *rootResolver) UniswapPositions(ctx context.Context, args *struct{ Owner common.Address }) ([]*UniswapPosition, error) {
+	// get the aggregated entries
+	entries, err := repository.R().WithContext(ctx).UniswapPositionEntries(&args.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	// build the positions with the current pool state
+	list := make([]*UniswapPosition, 0, len(entries))
+	for _, e := range entries {
+		pos, err := newUniswapPosition(ctx, e, &args.Owner)
+		if err != nil {
+			return nil, err
+		}
+		if pos != nil {
+			list = append(list, pos)
+		}
+	}
+	return list, nil
+}
+
+// newUniswapPosition creates a new position for the given entry
+// loading the current liquidity and reserves of the pair.
+// Nil is returned if the owner does not hold any liquidity on the pair.
+func newUniswapPosition(ctx context.Context, e *types.UniswapPositionEntry, owner *common.Address) (*UniswapPosition, error) {
+	// get the current liquidity of the owner
+	liq, err := repository.R().WithContext(ctx).Erc20BalanceOf(&e.Pair, owner)
+	if err != nil {
+		return nil, err
+	}
+	if liq.ToInt().Sign() == 0 {
+		return nil, nil
+	}
+
+	// get the pool state
+	ts, err := repository.R().WithContext(ctx).Erc20TotalSupply(&e.Pair)
+	if err != nil {
+		return nil, err
+	}
+	res, err := repository.R().WithContext(ctx).UniswapReserves(&e.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UniswapPosition{
+		UniswapPositionEntry: *e,
+		Owner:                *owner,
+		Liquidity:            liq,
+		TotalSupply:          ts,
+		Reserves:             res,
+	}, nil
+}
+
+// Pair resolves the Uniswap pair of the position.
+func (pos *UniswapPosition) Pair() *UniswapPair {
+	return NewUniswapPair(&pos.UniswapPositionEntry.Pair)
+}
+
+// ShareOfPool resolves the share of the position on the pool liquidity
+// as a fraction between 0 and 1.
+func (pos *UniswapPosition) ShareOfPool() float64 {
+	if pos.TotalSupply.ToInt().Sign() == 0 {
+		return 0
+	}
+	share, _ := new(big.Rat).SetFrac(pos.Liquidity.ToInt(), pos.TotalSupply.ToInt()).Float64()
+	return share
+}
+
+// Amounts resolves the amounts of the underlying tokens
+// represented by the position.
+func (pos *UniswapPosition) Amounts() []hexutil.Big {
+	list := make([]hexutil.Big, len(pos.Reserves))
+	for i, r := range pos.Reserves {
+		if pos.TotalSupply.ToInt().Sign() == 0 {
+			continue
+		}
+		am := new(big.Int).Mul(r.ToInt(), pos.Liquidity.ToInt())
+		list[i] = hexutil.Big(*am.Div(am, pos.TotalSupply.ToInt()))
+	}
+	return list
+}
+
+// Deposited resolves the total amounts of tokens added to the pool by the owner.
+func (pos *UniswapPosition) Deposited() []hexutil.Big {
+	return []hexutil.Big{hexutil.Big(*pos.Deposited0), hexutil.Big(*pos.Deposited1)}
+}
+
+// Withdrawn resolves the total amounts of tokens removed from the pool by the owner.
+func (pos *UniswapPosition) Withdrawn() []hexutil.Big {
+	return []hexutil.Big{hexutil.Big(*pos.Withdrawn0), hexutil.Big(*pos.Withdrawn1)}
+}
+
+// ImpermanentLoss resolves the estimated impermanent loss of the position
+// versus simply holding the tokens deposited. Both values are compared
+// at the current pool price denominated in the second token of the pair.
+// The value is a fraction, i.e. -0.05 means the position is worth 5% less
+// than the held tokens would be. Collected trading fees are included.
+func (pos *UniswapPosition) ImpermanentLoss() float64 {
+	if len(pos.Reserves) != 2 || pos.Reserves[0].ToInt().Sign() == 0 {
+		return 0
+	}
+
+	// the net amounts still provided to the pool
+	net0 := new(big.Int).Sub(pos.Deposited0, pos.Withdrawn0)
+	net1 := new(big.Int).Sub(pos.Deposited1, pos.Withdrawn1)
+	if net0.Sign() < 0 || net1.Sign() < 0 {
+		return 0
+	}
+
+	// current price of the first token in the second token
+	price := new(big.Rat).SetFrac(pos.Reserves[1].ToInt(), pos.Reserves[0].ToInt())
+
+	// value of held tokens vs. the value of the position
+	am := pos.Amounts()
+	hold := new(big.Rat).Add(new(big.Rat).Mul(new(big.Rat).SetInt(net0), price), new(big.Rat).SetInt(net1))
+	if hold.Sign() == 0 {
+		return 0
+	}
+	value := new(big.Rat).Add(new(big.Rat).Mul(new(big.Rat).SetInt(am[0].ToInt()), price), new(big.Rat).SetInt(am[1].ToInt()))
+
+	il, _ := new(big.Rat).Sub(new(big.Rat).Quo(value, hold), big.NewRat(1, 1)).Float64()
+	return il
+}
@@ -0,0 +1,172 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// uniswapMaxSlippage is the highest slippage tolerance accepted by swap quotes.
+	uniswapMaxSlippage = 0.5
+
+	// uniswapSlippageDecimals is the precision of the slippage tolerance
+	// applied to swap limit amounts, 1e6 means 0.0001%.
+	uniswapSlippageDecimals = 1000000
+
+	// uniswapFeeRatio is the ratio of a swap input passed to the pool after the LP fee of 0.3% is deducted.
+	uniswapFeeRatio = 0.997
+)
+
+// UniswapSwapQuote represents a quote of a swap operation over a path of Uniswap pairs.
+type UniswapSwapQuote struct {
+	// Amounts is the list of amounts of tokens on each step of the swap path.
+	Amounts []hexutil.Big
+
+	// AmountOutMin is the minimal output amount received with the slippage tolerance applied.
+	AmountOutMin hexutil.Big
+
+	// AmountInMax is the maximal input amount sold with the slippage tolerance applied.
+	AmountInMax hexutil.Big
+
+	// PriceImpact is the ratio of the output lost to the reserves shift caused by the swap.
+	PriceImpact float64
+
+	// Slippage is the slippage tolerance used to calculate the limit amounts.
+	Slippage float64
+}
+
+// DefiUniswapQuoteOut resolves a quote of a swap operation for the given
+// input amount and a list of tokens to be used to make the swap operation.
+func (rs *rootResolver) DefiUniswapQuoteOut(ctx context.Context, args *struct {
+	AmountIn hexutil.Big
+	Tokens   []common.Address
+	Slippage float64
+}) (*UniswapSwapQuote, error) {
+	if err := uniswapQuoteCheck(args.Tokens, args.Slippage); err != nil {
+		return nil, err
+	}
+
+	amounts, err := repository.R().WithContext(ctx).UniswapAmountsOut(args.AmountIn, args.Tokens)
+	if err != nil {
+		return nil, err
+	}
+	return uniswapSwapQuote(ctx, amounts, args.Tokens, args.Slippage)
+}
+
+// DefiUniswapQuoteIn resolves a quote of a swap operation for the given
+// output amount and a list of tokens to be used to make the swap operation.
+func (rs *rootResolver) DefiUniswapQuoteIn(ctx context.Context, args *struct {
+	AmountOut hexutil.Big
+	Tokens    []common.Address
+	Slippage  float64
+}) (*UniswapSwapQuote, error) {
+	if err := uniswapQuoteCheck(args.Tokens, args.Slippage); err != nil {
+		return nil, err
+	}
+
+	amounts, err := repository.R().WithContext(ctx).UniswapAmountsIn(args.AmountOut, args.Tokens)
+	if err != nil {
+		return nil, err
+	}
+	return uniswapSwapQuote(ctx, amounts, args.Tokens, args.Slippage)
+}
+
+// uniswapQuoteCheck validates the swap path and the slippage tolerance of a swap quote.
+func uniswapQuoteCheck(tokens []common.Address, slippage float64) error {
+	if len(tokens) < 2 {
+		return errInvalidArgument("at least two tokens expected on the swap path, %d given", len(tokens))
+	}
+	if slippage < 0 || slippage > uniswapMaxSlippage {
+		return errInvalidArgument("slippage tolerance must be between 0 and %.2f", uniswapMaxSlippage)
+	}
+	return nil
+}
+
+// uniswapSwapQuote builds the swap quote from the amounts on the swap path.
+func uniswapSwapQuote(ctx context.Context, amounts []hexutil.Big, tokens []common.Address, slippage float64) (*UniswapSwapQuote, error) {
+	if len(amounts) != len(tokens) {
+		return nil, errInvalidArgument("swap amounts don't match the swap path")
+	}
+
+	impact, err := uniswapPriceImpact(ctx, amounts, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	// apply the slippage tolerance to the amounts on both ends of the path
+	tol := big.NewInt(int64(slippage * uniswapSlippageDecimals))
+	dec := big.NewInt(uniswapSlippageDecimals)
+
+	out := new(big.Int).Mul(amounts[len(amounts)-1].ToInt(), new(big.Int).Sub(dec, tol))
+	in := new(big.Int).Mul(amounts[0].ToInt(), new(big.Int).Add(dec, tol))
+
+	return &UniswapSwapQuote{
+		Amounts:      amounts,
+		AmountOutMin: hexutil.Big(*out.Quo(out, dec)),
+		AmountInMax:  hexutil.Big(*in.Quo(in, dec)),
+		PriceImpact:  impact,
+		Slippage:     slippage,
+	}, nil
+}
+
+// uniswapPriceImpact calculates the price impact of a swap comparing the swap output
+// with the output expected at the mid prices of the pairs on the swap path after LP fees.
+func uniswapPriceImpact(ctx context.Context, amounts []hexutil.Big, tokens []common.Address) (float64, error) {
+	mid := new(big.Float).SetInt(amounts[0].ToInt())
+	for i := 0; i < len(tokens)-1; i++ {
+		rIn, rOut, err := uniswapPathReserves(ctx, &tokens[i], &tokens[i+1])
+		if err != nil {
+			return 0, err
+		}
+
+		mid.Mul(mid, new(big.Float).SetInt(rOut))
+		mid.Quo(mid, new(big.Float).SetInt(rIn))
+		mid.Mul(mid, big.NewFloat(uniswapFeeRatio))
+	}
+
+	// no output expected at all?
+	if mid.Sign() <= 0 {
+		return 0, nil
+	}
+
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(amounts[len(amounts)-1].ToInt()), mid).Float64()
+	if ratio >= 1 {
+		return 0, nil
+	}
+	return 1 - ratio, nil
+}
+
+// uniswapPathReserves loads the reserves of the pair of the given tokens
+// in the direction of the swap step.
+func uniswapPathReserves(ctx context.Context, tokenIn *common.Address, tokenOut *common.Address) (*big.Int, *big.Int, error) {
+	pair, err := repository.R().WithContext(ctx).UniswapPair(tokenIn, tokenOut)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := repository.R().WithContext(ctx).UniswapTokens(pair)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reserves, err := repository.R().WithContext(ctx).UniswapReserves(pair)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// reserves are in the order of the pair tokens
+	rIn, rOut := reserves[0].ToInt(), reserves[1].ToInt()
+	if tokens[0] != *tokenIn {
+		rIn, rOut = rOut, rIn
+	}
+
+	if rIn.Sign() == 0 {
+		return nil, nil, errInvalidArgument("no liquidity on pair %s", pair.String())
+	}
+	return rIn, rOut, nil
+}
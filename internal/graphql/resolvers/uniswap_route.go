@@ -0,0 +1,58 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// uniswapRouteMaxHops is the highest number of swap steps searched for the best route.
+const uniswapRouteMaxHops = 4
+
+// UniswapRoute represents resolvable swap route over a path of Uniswap pairs.
+type UniswapRoute struct {
+	types.UniswapRoute
+}
+
+// DefiUniswapBestRoute resolves the swap route providing the highest output amount
+// for the given input amount over the known Uniswap pairs.
+func (rs *rootResolver) DefiUniswapBestRoute(ctx context.Context, args *struct {
+	TokenIn  common.Address
+	TokenOut common.Address
+	AmountIn hexutil.Big
+	MaxHops  int32
+}) (*UniswapRoute, error) {
+	if args.TokenIn == args.TokenOut {
+		return nil, errInvalidArgument("input and output tokens must differ")
+	}
+	if args.MaxHops < 1 || args.MaxHops > uniswapRouteMaxHops {
+		return nil, errInvalidArgument("max hops must be between 1 and %d", uniswapRouteMaxHops)
+	}
+	if args.AmountIn.ToInt().Sign() <= 0 {
+		return nil, errInvalidArgument("input amount must be positive")
+	}
+
+	route, err := repository.R().WithContext(ctx).UniswapBestRoute(args.TokenIn, args.TokenOut, args.AmountIn, int(args.MaxHops))
+	if err != nil || route == nil {
+		return nil, err
+	}
+	return &UniswapRoute{*route}, nil
+}
+
+// Pairs resolves the Uniswap pairs used on each step of the route.
+func (ur *UniswapRoute) Pairs() []*UniswapPair {
+	list := make([]*UniswapPair, len(ur.UniswapRoute.Pairs))
+	for i := range ur.UniswapRoute.Pairs {
+		list[i] = NewUniswapPair(&ur.UniswapRoute.Pairs[i])
+	}
+	return list
+}
+
+// AmountOut resolves the output amount of the route.
+func (ur *UniswapRoute) AmountOut() hexutil.Big {
+	return ur.Amounts[len(ur.Amounts)-1]
+}
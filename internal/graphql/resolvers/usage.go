@@ -0,0 +1,81 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strings"
+	"time"
+)
+
+// usagePeriods maps the usage report periods to the number of hourly windows they cover.
+var usagePeriods = map[string]int{
+	"HOUR":  1,
+	"DAY":   24,
+	"WEEK":  7 * 24,
+	"MONTH": 30 * 24,
+}
+
+// UsageRecord represents resolvable API usage of a client origin.
+type UsageRecord struct {
+	types.UsageRecord
+}
+
+// UsageRange provides the time range of the usage report period, i.e. HOUR, DAY, WEEK, or MONTH.
+// The range ends with the current hourly window.
+func UsageRange(period string) (time.Time, time.Time, error) {
+	hours, ok := usagePeriods[strings.ToUpper(period)]
+	if !ok {
+		return time.Time{}, time.Time{}, errInvalidArgument("unknown usage period %s, use HOUR, DAY, WEEK, or MONTH", period)
+	}
+
+	to := time.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+	return to.Add(-time.Duration(hours) * time.Hour), to, nil
+}
+
+// UsageReport resolves the API usage of all the client origins in the given period,
+// the heaviest users by the compute cost go first.
+func (adm *Admin) UsageReport(ctx context.Context, args *struct{ Period string }) ([]*UsageRecord, error) {
+	from, to, err := UsageRange(args.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := repository.R().WithContext(ctx).UsageReport(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*UsageRecord, len(list))
+	for i, ur := range list {
+		res[i] = &UsageRecord{UsageRecord: *ur}
+	}
+	return res, nil
+}
+
+// From resolves the unix timestamp of the beginning of the usage period.
+func (ur *UsageRecord) From() hexutil.Uint64 {
+	return hexutil.Uint64(ur.UsageRecord.From.Unix())
+}
+
+// To resolves the unix timestamp of the end of the usage period.
+func (ur *UsageRecord) To() hexutil.Uint64 {
+	return hexutil.Uint64(ur.UsageRecord.To.Unix())
+}
+
+// Requests resolves the number of operations executed for the origin.
+func (ur *UsageRecord) Requests() hexutil.Uint64 {
+	return hexutil.Uint64(ur.UsageRecord.Requests)
+}
+
+// Bytes resolves the data volume exchanged with the origin.
+func (ur *UsageRecord) Bytes() hexutil.Uint64 {
+	return hexutil.Uint64(ur.UsageRecord.Bytes)
+}
+
+// Cost resolves the compute cost of the origin requests in milliseconds.
+func (ur *UsageRecord) Cost() hexutil.Uint64 {
+	return hexutil.Uint64(ur.UsageRecord.Cost)
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"crypto/rand"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
@@ -10,34 +11,52 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"io"
 	"regexp"
+	"time"
 )
 
 // reExpectedPriceSymbol represents a price symbol expected to be resolved
 var reExpectedPriceSymbol = regexp.MustCompile(`^[\w]{2,4}$`)
 
 // Price resolves price details of the Opera blockchain token for the given target symbols.
-func (rs *rootResolver) Price(args *struct{ To string }) (types.Price, error) {
+func (rs *rootResolver) Price(ctx context.Context, args *struct{ To string }) (types.Price, error) {
 	// is the requested denomination even reasonable
 	if !reExpectedPriceSymbol.Match([]byte(args.To)) {
-		return types.Price{}, fmt.Errorf("invalid denomination received")
+		return types.Price{}, errInvalidArgument("invalid denomination received")
 	}
-	return repository.R().Price(args.To)
+	return repository.R().WithContext(ctx).Price(args.To)
+}
+
+// PriceAt resolves the historical price of FTM in the given target symbol at the given time.
+func (rs *rootResolver) PriceAt(ctx context.Context, args *struct {
+	To   string
+	Time hexutil.Uint64
+}) (*float64, error) {
+	// is the requested denomination even reasonable
+	if !reExpectedPriceSymbol.Match([]byte(args.To)) {
+		return nil, errInvalidArgument("invalid denomination received")
+	}
+
+	ps, err := repository.R().WithContext(ctx).PriceAt(args.To, time.Unix(int64(args.Time), 0).UTC())
+	if err != nil || ps == nil {
+		return nil, err
+	}
+	return &ps.Price, nil
 }
 
 // GasPrice resolves the current amount of WEI for single Gas.
-func (rs *rootResolver) GasPrice() (hexutil.Uint64, error) {
-	return repository.R().GasPrice()
+func (rs *rootResolver) GasPrice(ctx context.Context) (hexutil.Uint64, error) {
+	return repository.R().WithContext(ctx).GasPrice()
 }
 
 // EstimateGas resolves the estimated amount of Gas required to perform
 // transaction described by the input params.
-func (rs *rootResolver) EstimateGas(args struct {
+func (rs *rootResolver) EstimateGas(ctx context.Context, args struct {
 	From  *common.Address
 	To    *common.Address
 	Value *hexutil.Big
 	Data  *string
 }) (*hexutil.Uint64, error) {
-	return repository.R().GasEstimate(&args)
+	return repository.R().WithContext(ctx).GasEstimate(&args)
 }
 
 // uuid generates new random subscription UUID
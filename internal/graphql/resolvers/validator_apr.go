@@ -0,0 +1,87 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// validatorAprMaxEpochs is the max number of epochs of a validator APR history resolved at once.
+const validatorAprMaxEpochs = 1000
+
+// ValidatorApr represents resolvable realized APR of a validator in a sealed epoch.
+type ValidatorApr struct {
+	Epoch      hexutil.Uint64
+	Time       hexutil.Uint64
+	Apr        float64
+	NetworkApr float64
+	Commission *float64
+}
+
+// CommissionChange represents resolvable commission of a validator taking effect on a sealed epoch.
+type CommissionChange struct {
+	Epoch      hexutil.Uint64
+	Time       hexutil.Uint64
+	Commission float64
+}
+
+// NewValidatorApr creates a new resolvable validator APR record.
+func NewValidatorApr(va *types.ValidatorApr) *ValidatorApr {
+	return &ValidatorApr{
+		Epoch:      hexutil.Uint64(va.Epoch),
+		Time:       hexutil.Uint64(va.Time.Unix()),
+		Apr:        va.Apr,
+		NetworkApr: va.NetworkApr,
+		Commission: va.Commission,
+	}
+}
+
+// AprHistory resolves the realized APR of the staker in the given range of sealed epochs.
+func (st Staker) AprHistory(ctx context.Context, args struct {
+	From hexutil.Uint64
+	To   hexutil.Uint64
+}) ([]*ValidatorApr, error) {
+	if args.To < args.From || args.To-args.From >= validatorAprMaxEpochs {
+		return nil, errInvalidArgument("epochs range must contain 1 to %d epochs", validatorAprMaxEpochs)
+	}
+
+	list, err := repository.R().WithContext(ctx).ValidatorAprHistory(&st.Id, args.From, args.To)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ValidatorApr, len(list))
+	for i, va := range list {
+		res[i] = NewValidatorApr(va)
+	}
+	return res, nil
+}
+
+// CommissionHistory resolves the commission of the staker at the start of the given range
+// of sealed epochs and each change of it within the range.
+func (st Staker) CommissionHistory(ctx context.Context, args struct {
+	From hexutil.Uint64
+	To   hexutil.Uint64
+}) ([]*CommissionChange, error) {
+	if args.To < args.From || args.To-args.From >= validatorAprMaxEpochs {
+		return nil, errInvalidArgument("epochs range must contain 1 to %d epochs", validatorAprMaxEpochs)
+	}
+
+	list, err := repository.R().WithContext(ctx).ValidatorCommissionHistory(&st.Id, args.From, args.To)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*CommissionChange, len(list))
+	for i, cc := range list {
+		res[i] = &CommissionChange{
+			Epoch:      hexutil.Uint64(cc.Epoch),
+			Time:       hexutil.Uint64(cc.Time.Unix()),
+			Commission: cc.Commission,
+		}
+	}
+	return res, nil
+}
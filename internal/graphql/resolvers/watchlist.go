@@ -0,0 +1,164 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// watchListMaxSize is the max number of addresses on a single watch list.
+	watchListMaxSize = 100
+
+	// watchListMaxLabelLength is the max length of a watched address label.
+	watchListMaxLabelLength = 64
+)
+
+// WatchedAddress represents resolvable address on the client watch list.
+type WatchedAddress struct {
+	types.WatchedAddress
+}
+
+// WatchListDigest represents resolvable aggregated activity of a watched address.
+type WatchListDigest struct {
+	types.WatchDigest
+}
+
+// Added resolves the time the address was added to the watch list.
+func (wa *WatchedAddress) Added() hexutil.Uint64 {
+	return hexutil.Uint64(wa.WatchedAddress.Added.Unix())
+}
+
+// From resolves the start of the digest period.
+func (wd *WatchListDigest) From() hexutil.Uint64 {
+	return hexutil.Uint64(wd.WatchDigest.From.Unix())
+}
+
+// To resolves the end of the digest period.
+func (wd *WatchListDigest) To() hexutil.Uint64 {
+	return hexutil.Uint64(wd.WatchDigest.To.Unix())
+}
+
+// TrxSent resolves the number of transactions sent by the address.
+func (wd *WatchListDigest) TrxSent() int32 {
+	return int32(wd.WatchDigest.TrxSent)
+}
+
+// TrxReceived resolves the number of transactions received by the address.
+func (wd *WatchListDigest) TrxReceived() int32 {
+	return int32(wd.WatchDigest.TrxReceived)
+}
+
+// ValueSent resolves the amount of FTM sent by the address.
+func (wd *WatchListDigest) ValueSent() hexutil.Big {
+	return digestValue(wd.WatchDigest.ValueSent)
+}
+
+// ValueReceived resolves the amount of FTM received by the address.
+func (wd *WatchListDigest) ValueReceived() hexutil.Big {
+	return digestValue(wd.WatchDigest.ValueReceived)
+}
+
+// Erc20Sent resolves the number of token transfers sent by the address.
+func (wd *WatchListDigest) Erc20Sent() int32 {
+	return int32(wd.WatchDigest.Erc20Sent)
+}
+
+// Erc20Received resolves the number of token transfers received by the address.
+func (wd *WatchListDigest) Erc20Received() int32 {
+	return int32(wd.WatchDigest.Erc20Received)
+}
+
+// digestValue converts the digest value back to WEI.
+func digestValue(val int64) hexutil.Big {
+	return (hexutil.Big)(*new(big.Int).Mul(big.NewInt(val), types.TransactionDecimalsCorrection))
+}
+
+// Watchlist resolves the watch list of the API client.
+func (rs *rootResolver) Watchlist(ctx context.Context) ([]*WatchedAddress, error) {
+	owner, err := rs.clientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wl, err := repository.R().WithContext(ctx).WatchList(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*WatchedAddress, len(wl))
+	for i, wa := range wl {
+		list[i] = &WatchedAddress{WatchedAddress: *wa}
+	}
+	return list, nil
+}
+
+// WatchlistDigest resolves the aggregated activity of addresses on the watch list
+// of the API client since the given time.
+func (rs *rootResolver) WatchlistDigest(ctx context.Context, args *struct{ Since hexutil.Uint64 }) ([]*WatchListDigest, error) {
+	owner, err := rs.clientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wd, err := repository.R().WithContext(ctx).WatchListDigest(owner, time.Unix(int64(args.Since), 0).UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*WatchListDigest, len(wd))
+	for i, d := range wd {
+		list[i] = &WatchListDigest{WatchDigest: *d}
+	}
+	return list, nil
+}
+
+// AddToWatchlist adds the address to the watch list of the API client.
+func (rs *rootResolver) AddToWatchlist(ctx context.Context, args *struct {
+	Address common.Address
+	Label   *string
+}) (*WatchedAddress, error) {
+	owner, err := rs.clientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wa := types.WatchedAddress{Owner: owner, Address: args.Address, Added: time.Now().UTC()}
+	if args.Label != nil {
+		wa.Label = strings.TrimSpace(*args.Label)
+		if len(wa.Label) > watchListMaxLabelLength {
+			return nil, errInvalidArgument("label too long, max %d characters allowed", watchListMaxLabelLength)
+		}
+	}
+
+	// check the list size
+	size, err := repository.R().WithContext(ctx).WatchListSize(owner)
+	if err != nil {
+		return nil, err
+	}
+	if size >= watchListMaxSize {
+		return nil, errInvalidArgument("watch list is full, max %d addresses allowed", watchListMaxSize)
+	}
+
+	if err := repository.R().WithContext(ctx).AddWatchedAddress(&wa); err != nil {
+		rs.log.Errorf("can not add %s to watch list; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+	return &WatchedAddress{WatchedAddress: wa}, nil
+}
+
+// RemoveFromWatchlist removes the address from the watch list of the API client.
+func (rs *rootResolver) RemoveFromWatchlist(ctx context.Context, args *struct{ Address common.Address }) (bool, error) {
+	owner, err := rs.clientId(ctx)
+	if err != nil {
+		return false, err
+	}
+	return repository.R().WithContext(ctx).RemoveWatchedAddress(owner, &args.Address)
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -20,7 +21,7 @@ func NewWithdrawRequest(wr *types.WithdrawRequest) WithdrawRequest {
 
 // Id resolves unique internal identifier of the Withdraw request.
 func (wr WithdrawRequest) Id() Cursor {
-	return Cursor(wr.RequestTrx.String())
+	return Cursor(types.ListCursor(wr.OrdinalIndex()))
 }
 
 // WithdrawRequestID resolves the SFC identifier of the request
@@ -49,9 +50,9 @@ func (wr WithdrawRequest) Amount() hexutil.Big {
 }
 
 // Account resolves the account detail of the partial withdraw request.
-func (wr WithdrawRequest) Account() (*Account, error) {
+func (wr WithdrawRequest) Account(ctx context.Context) (*Account, error) {
 	// get the account detail by address
-	acc, err := repository.R().Account(&wr.Address)
+	acc, err := repository.R().WithContext(ctx).Account(&wr.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -61,9 +62,9 @@ func (wr WithdrawRequest) Account() (*Account, error) {
 }
 
 // Staker resolves the withdraw request staker detail, if available.
-func (wr WithdrawRequest) Staker() (*Staker, error) {
+func (wr WithdrawRequest) Staker(ctx context.Context) (*Staker, error) {
 	// get staker detail by the staker id
-	st, err := repository.R().Validator(wr.WithdrawRequest.StakerID)
+	st, err := repository.R().WithContext(ctx).Validator(wr.WithdrawRequest.StakerID)
 	if err != nil {
 		return nil, err
 	}
@@ -299,7 +299,7 @@ type ListPageInfo {
     hasPrevious: Boolean!
 }
 # Transaction is an Opera block chain transaction.
-type Transaction {
+type Transaction @key(fields: "hash") {
     # Hash is the unique hash of this transaction.
     hash: Bytes32!
 
@@ -320,6 +320,12 @@ type Transaction {
     # This is null for contract creating transactions.
     to: Address
 
+    # fromLabel is the public label of the sender address, if any.
+    fromLabel: AddressLabel
+
+    # toLabel is the public label of the recipient address, if any.
+    toLabel: AddressLabel
+
     # contractAddress represents the address of smart contract
     # deployed by this transaction;
     # null if the transaction is not contract creation
@@ -342,6 +348,17 @@ type Transaction {
     # If the transaction is pending, this field will be null.
     gasUsed: Long
 
+    # fee is the fee paid for processing the transaction in WEI,
+    # e.g. the gas used multiplied by the gas price.
+    # If the transaction is pending, this field will be null.
+    fee: BigInt
+
+    # feeValue is the fee of the transaction converted to the given target symbol,
+    # e.g. USD, using the price of FTM at the time of the transaction.
+    # Null if the transaction is pending, or the price history does not reach
+    # the transaction time.
+    feeValue(to: String!): Float
+
     # InputData is the data supplied to the target of the transaction.
     # Contains smart contract byte code if this is contract creation.
     # Contains encoded contract state mutating function call if recipient
@@ -365,10 +382,22 @@ type Transaction {
     # running out of gas). If the transaction has not yet been processed, this
     # field will be null.
     status: Long
+
+    # revertReason is the reason the transaction failed, e.g. the message
+    # of the Solidity revert, or "out of gas". It's null for successful
+    # and pending transactions, and if the reason could not be recovered.
+    revertReason: String
+
+    # raw is the RLP encoded transaction as provided by the block chain node.
+    raw: Bytes!
+
+    # receipt is the transaction receipt as provided by the block chain node.
+    # Null if the transaction is pending.
+    receipt: TransactionReceipt
 }
 
 # Block is an Opera block chain block.
-type Block {
+type Block @key(fields: "number") {
     # Number is the number of this block, starting at 0 for the genesis block.
     number: Long!
 
@@ -396,6 +425,11 @@ type Block {
 
     # txList is a list of transactions assigned to the block.
     txList: [Transaction!]!
+
+    # timeToFinality is the time in milliseconds between the creation
+    # of the block Atropos event and the block being observed as final.
+    # Null if the block was not observed as the new head by the API server.
+    timeToFinality: Long
 }
 
 # SfcConfig represents the configuration of the SFC contract
@@ -430,7 +464,7 @@ type SfcConfig {
 }
 
 # ERC20Token represents a generic ERC20 token.
-type ERC20Token {
+type ERC20Token @key(fields: "address") {
     # address of the token is used as the token's unique identifier.
     address: Address!
 
@@ -451,6 +485,20 @@ type ERC20Token {
     # provided, but unknown tokens have this set to a generic logo file.
     logoURL: String!
 
+    # description represents a curated description of the token, if available.
+    description: String
+
+    # website represents a curated URL address of the token project website, if available.
+    website: String
+
+    # isWhitelisted signals the token is on the curated token list.
+    isWhitelisted: Boolean!
+
+    # riskLevel is the moderation risk level of the token.
+    # It's one of NONE, LOW, MEDIUM, or HIGH for known scam and phishing tokens.
+    # Wallets are expected to hide, or warn about flagged tokens.
+    riskLevel: String!
+
     # balanceOf represents the total available balance of the token
     # on the account regardless of the DeFi usage of the token.
     # It's effectively the amount available held by the ERC20 token
@@ -466,6 +514,29 @@ type ERC20Token {
 
     # totalDebt represents total amount of borrowed/minted tokens on fMint.
     totalDebt: BigInt!
+
+    # price represents the price of a single token in the given target symbol,
+    # i.e. USD, or FTM. The price is routed through the indexed Uniswap pairs
+    # to the wrapped FTM, or to a USD stable token, using the route
+    # with the highest liquidity. It's empty if no route is available.
+    price(to: String!): ERC20TokenPrice
+}
+
+# ERC20TokenPrice represents a price of an ERC20 token derived from Uniswap pairs.
+type ERC20TokenPrice {
+    # symbol represents the target symbol of the price.
+    symbol: String!
+
+    # price represents the value of a single whole token in the target symbol.
+    price: Float!
+
+    # route represents the list of tokens the price has been routed through,
+    # starting with the priced token.
+    route: [Address!]!
+
+    # liquidity represents the lowest liquidity along the route
+    # in the target symbol; zero if the token is priced directly.
+    liquidity: Float!
 }
 
 # DelegationList is a list of delegations edges provided by sequential access request.
@@ -526,6 +597,10 @@ type Delegation {
     # of the delegation as a scrollable list of edges with details of claims.
     rewardClaims(cursor: Cursor, count: Int = 25): RewardClaimList!
 
+    # history provides a list of operations on the delegation, e.g. stake,
+    # un-stake, and rewards claims, sorted from the newest to the oldest.
+    history(cursor: Cursor, count: Int = 25): DelegationOperationList!
+
     # isFluidStakingActive indicates if the delegation is upgraded to fluid staking.
     isFluidStakingActive: Boolean!
 
@@ -615,6 +690,33 @@ type Epoch {
 
     # Total supply amount.
     totalSupply: BigInt!
+
+    # Number of transactions processed in the epoch.
+    # Null if the epoch statistics have not been calculated yet.
+    totalTxCount: Long
+
+    # Amount of gas consumed by transactions of the epoch.
+    # Null if the epoch statistics have not been calculated yet.
+    totalGasUsed: Long
+
+    # Total amount of fees collected in the epoch.
+    totalFees: BigInt!
+
+    # Total amount of rewards distributed for the epoch;
+    # base reward for the epoch duration plus the collected fees.
+    totalRewards: BigInt!
+
+    # Average number of transactions per second in the epoch.
+    # Null if the epoch statistics have not been calculated yet.
+    averageTPS: Float
+
+    # Number of validators participating in the epoch.
+    # Null if the epoch statistics have not been calculated yet.
+    validators: Long
+
+    # Number of validators offline in the epoch.
+    # Null if the epoch statistics have not been calculated yet.
+    offlineValidators: Long
 }
 
 # Contract defines block-chain smart contract information container
@@ -625,6 +727,12 @@ type Contract {
     "DeployedBy represents the smart contract deployment transaction reference."
     deployedBy: Transaction!
 
+    "Type is the type of the contract detected by the contract classifier."
+    type: String!
+
+    "Interfaces is the list of interfaces detected on the contract. Empty if not classified yet."
+    interfaces: [String!]!
+
     "transactionHash represents the smart contract deployment transaction hash."
     transactionHash: Bytes32!
 
@@ -658,8 +766,74 @@ type Contract {
     """
     validated: Long
 
+    """
+    VerificationLevel is the quality of the byte code match reached
+    by the source code validation. Null if not validated, or validated
+    before the match levels were recorded.
+    """
+    verificationLevel: ContractVerificationLevel
+
+    """
+    MetadataHash is the compiler metadata hash (IPFS/Swarm) embedded
+    in the validated byte code. Null if not available.
+    """
+    metadataHash: Bytes
+
+    """
+    VerifiedFrom is the directly validated contract with identical runtime byte code
+    the validation of this contract was copied from. Null if the contract
+    was validated directly, or not validated at all.
+    """
+    verifiedFrom: Contract
+
+    "CodeHash is the hash of the deployed runtime byte code. Null if not known yet."
+    codeHash: Bytes32
+
+    "Artifact is the full compilation output of the validated contract. Null if not available."
+    artifact: ContractArtifact
+
     "Timestamp is the unix timestamp at which this smart contract was deployed."
     timestamp: Long!
+
+    "Label is the public label of the contract address. Null if not labeled."
+    label: AddressLabel
+
+    """
+    RiskLevel is the moderation risk level of the contract.
+    It's one of NONE, LOW, MEDIUM, or HIGH for known scam and phishing contracts.
+    """
+    riskLevel: String!
+
+    "Creation is the deployment detail of the contract, if deployed by another contract."
+    creation: ContractCreation
+
+    "Events is the list of events emitted by the contract, the latest first."
+    events(cursor: Cursor, count: Int = 25): ContractEventList!
+
+    """
+    EventStats is the list of daily counters of events emitted by the contract
+    by the event signature for the given number of days, including today.
+    """
+    eventStats(days: Int = 30): [ContractEventStat!]!
+
+    """
+    TopEvents is the list of the most frequent events emitted by the contract
+    in the given number of days, including today.
+    """
+    topEvents(days: Int = 30, limit: Int = 10): [ContractEventSummary!]!
+}
+
+# ContractVerificationLevel represents the quality of the byte code match
+# between the validated source code and the deployed contract.
+enum ContractVerificationLevel {
+    # Compiled byte code is identical to the deployed one, including the compiler metadata.
+    EXACT
+
+    # Compiled byte code matches the deployed one with the compiler metadata removed.
+    METADATA_STRIPPED
+
+    # Compiled byte code matches the deployed one except for linked library addresses.
+    PARTIAL
 }
 
 # ContractValidationInput represents a set of data sent from client
@@ -750,6 +924,9 @@ scalar Long
 scalar Bytes
 
 # Cursor is a string representing position in a sequential list of edges.
+# List cursors are opaque tokens of a stable position of the edge (e.g. block
+# and index of a transaction), so new blocks arriving between page requests
+# do not shift the pages. Clients should not try to interpret the value.
 scalar Cursor
 
 # CurrentState represents the current active state
@@ -782,6 +959,25 @@ type CurrentState {
 
     # sfcLockingEnabled indicates if the SFC locking feature is enabled.
     sfcLockingEnabled: Boolean!
+
+    # indexerLag is the number of blocks the off-chain database
+    # of the API server is behind the chain head.
+    indexerLag: Long!
+
+    # database is the health of the off-chain database.
+    database: BackendHealth!
+
+    # node is the health of the block chain node the API server is connected to.
+    node: NodeHealth!
+
+    # cache provides statistics of the in-memory cache.
+    cache: CacheStats!
+
+    # databasePool provides statistics of the database connection pool.
+    databasePool: DbPoolStats!
+
+    # services is the list of internal services of the API server.
+    services: [ServiceState!]!
 }
 # UniswapActionList is a list of uniswap action edges provided by sequential access request.
 type UniswapActionList {
@@ -872,6 +1068,24 @@ type Staker {
     # This value depends on the amount of self staked tokens.
     delegatedLimit: BigInt!
 
+    # Amount of tokens which can still be delegated to the staker in WEI
+    # before the max delegated ratio is reached.
+    capacityLeft: BigInt!
+
+    # Ratio of the self staked amount to the total amount staked
+    # to the staker, in the range of 0.0 to 1.0.
+    selfStakeRatio: Float!
+
+    # Number of active delegators of the staker.
+    delegatorCount: Long!
+
+    # Ratio of time the staker was online recently, in the range of 0.0 to 1.0.
+    uptime: Float!
+
+    # Estimated annual rate of return of unlocked delegations to the staker
+    # based on recent epochs rewards, e.g. 0.05 represents 5%.
+    apr: Float!
+
     # Is the staker active.
     isActive: Boolean!
 
@@ -920,8 +1134,23 @@ type Staker {
     # Ok = 0, bin 1 = Fork Detected, bin 256 = Validator Offline
     status: Long!
 
+    # aprHistory provides the realized APR of the staker on each sealed epoch
+    # in the given range of epoch ids along with the network average.
+    # Up to 1000 epochs can be loaded at once.
+    aprHistory(from: Long!, to: Long!): [ValidatorApr!]!
+
+    # commissionHistory provides the commission of the staker at the start
+    # of the given range of epoch ids and each change of it within the range.
+    # Epochs sealed before the commission was recorded are not included.
+    # Up to 1000 epochs can be loaded at once.
+    commissionHistory(from: Long!, to: Long!): [CommissionChange!]!
+
+    # info represents extended staker information, e.g. the name and the logo,
+    # registered with the staker info contract, or the configured registry.
+    info: StakerInfo
+
     # StakerInfo represents extended staker information from smart contract.
-    stakerInfo: StakerInfo
+    stakerInfo: StakerInfo @deprecated(reason: "Use info instead.")
 }
 
 # FMintAccount represents an informastion about account details
@@ -976,6 +1205,47 @@ type FMintAccount {
     # inside the reward distribution and can be pushed into
     # the system to distribute them among eligible accounts.
     canPushNewRewards: Boolean!
+
+    # rewards represents the rewards claiming schedule of the account.
+    rewards: FMintRewards!
+}
+
+# FMintRewards represents the rewards claiming schedule
+# of an fMint account based on the rewards distribution state.
+type FMintRewards {
+    # epochLength represents the length of a reward epoch in seconds.
+    epochLength: Long!
+
+    # epochEnds represents the time stamp of the current reward epoch end.
+    epochEnds: Long!
+
+    # lastPush represents the time stamp of the last rewards push.
+    lastPush: Long!
+
+    # nextPush represents the earliest time stamp
+    # a new rewards push can be made.
+    nextPush: Long!
+
+    # nextUnlock represents the time stamp of the next rewards unlock,
+    # which is the end of the current reward epoch, or the next rewards push
+    # if the current epoch is already over.
+    nextUnlock: Long!
+
+    # earned represents the total amount of rewards earned by the account.
+    earned: BigInt!
+
+    # claimable represents the amount of earned rewards
+    # the account can claim right now.
+    claimable: BigInt!
+
+    # locked represents the amount of earned rewards the account
+    # can not claim until the collateral to debt ratio allows it.
+    locked: BigInt!
+
+    # pending represents the estimated amount of rewards the account
+    # is going to earn till the end of the current reward epoch
+    # based on its current share on the principal.
+    pending: BigInt!
 }
 
 # FMintTokenBalance represents a balance of a specific DeFi token
@@ -999,6 +1269,30 @@ type FMintTokenBalance {
     value: BigInt!
 }
 
+# FMintHealth represents the state of the collateral to debt ratio
+# of an fMint account relative to a client provided threshold.
+type FMintHealth {
+    # owner represents the address of the fMint account.
+    owner: Address!
+
+    # account represents the fMint account details.
+    account: FMintAccount!
+
+    # ratio represents the current collateral to debt ratio,
+    # i.e. 3.0 means 300%. The ratio is not available
+    # for accounts without any debt.
+    ratio: Float
+
+    # threshold represents the threshold the ratio is evaluated against.
+    threshold: Float!
+
+    # isBelow signals the ratio is below the threshold.
+    isBelow: Boolean!
+
+    # block represents the number of the block the ratio was evaluated on.
+    block: Long!
+}
+
 # DefiSettings represents the set of current settings and limits
 # applied to DeFi operations.
 type DefiSettings {
@@ -1258,6 +1552,69 @@ type DefiTimeReserve {
     # with the token position.
     reserveClose: [BigInt!]!
 }
+# UniswapPairCreated represents the information about a new pair
+# created by the Uniswap factory.
+type UniswapPairCreated {
+    # pair represents the newly created Uniswap pair.
+    pair: UniswapPair!
+
+    # token0 represents the first token of the pair.
+    token0: ERC20Token!
+
+    # token1 represents the second token of the pair.
+    token1: ERC20Token!
+
+    # block represents the number of the block the pair was created in.
+    block: Long!
+
+    # trx represents the hash of the transaction creating the pair.
+    trx: Bytes32!
+}
+
+# UniswapSwapQuote represents the quote of a swap operation
+# over a path of Uniswap pairs.
+type UniswapSwapQuote {
+    # amounts represents the amounts of tokens on each step of the swap path,
+    # the same values as provided by defiUniswapAmountsOut/defiUniswapAmountsIn.
+    amounts: [BigInt!]!
+
+    # amountOutMin represents the minimal amount received on the output
+    # with the slippage tolerance applied. Use it as the amountOutMin
+    # of an exact input swap call on the router.
+    amountOutMin: BigInt!
+
+    # amountInMax represents the maximal amount sold on the input
+    # with the slippage tolerance applied. Use it as the amountInMax
+    # of an exact output swap call on the router.
+    amountInMax: BigInt!
+
+    # priceImpact represents the ratio of the output lost due to the swap
+    # moving the pair reserves, compared to the mid prices
+    # of the pairs on the path, i.e. 0.01 means 1%.
+    # The LP fees are not part of the price impact.
+    priceImpact: Float!
+
+    # slippage represents the slippage tolerance applied to the limit amounts.
+    slippage: Float!
+}
+
+# UniswapRoute represents a swap route over a path of Uniswap pairs.
+type UniswapRoute {
+    # tokens represents the path of tokens of the swap, including
+    # the input and the output token. The list can be used as the path
+    # of a swap call on the router.
+    tokens: [Address!]!
+
+    # pairs represents the Uniswap pairs used on each step of the swap.
+    pairs: [UniswapPair!]!
+
+    # amounts represents the amounts of tokens on each step of the swap path.
+    amounts: [BigInt!]!
+
+    # amountOut represents the output amount of the swap.
+    amountOut: BigInt!
+}
+
 # LendingPool represents a lendingpool instance.
 type LendingPool {
 
@@ -1421,20 +1778,40 @@ type RewardClaim {
     trxHash: Bytes32!
 }
 # Account defines block-chain account information container
-type Account {
+type Account @key(fields: "address") {
     # Address is the address of the account.
     address: Address!
 
+    # Type is the type of the account, i.e. wallet, contract, ERC20, ERC721,
+    # ERC1155, GnosisSafe, UniswapPair, proxy, or SFC.
+    type: String!
+
+    # Interfaces is the list of interfaces detected on a contract account.
+    # Empty for wallets and contracts not classified yet.
+    interfaces: [String!]!
+
     # Balance is the current balance of the Account in WEI.
-    balance: BigInt!
+    balance: BigInt! @cacheControl(maxAge: 1)
+
+    # wftmBalance is the current balance of the Account in the wrapped FTM token in WEI.
+    wftmBalance: BigInt! @cacheControl(maxAge: 1)
 
     # TotalValue is the current total value of the account in WEI.
-    # It includes available balance, delegated amount and pending rewards.
+    # It includes available balance, wrapped FTM balance, delegated amount and pending rewards.
     # NOTE: This values is slow to calculate.
-    totalValue: BigInt!
+    totalValue: BigInt! @cacheControl(maxAge: 1)
 
     # txCount represents number of transaction sent from the account (Nonce).
-    txCount: Long!
+    txCount: Long! @cacheControl(maxAge: 1)
+
+    # nonce represents the number of transactions sent from the account.
+    # If pending is set, transactions waiting in the node transaction pool
+    # are included so the value can be used as the nonce of a follow-up transaction.
+    nonce(pending: Boolean = false): Long!
+
+    # pendingTransactions represents the list of transactions sent from the account
+    # waiting in the node transaction pool to be processed, ordered by nonce.
+    pendingTransactions: [Transaction!]!
 
     # txList represents list of transactions of the account in form of TransactionList.
     txList (cursor:Cursor, count:Int!): TransactionList!
@@ -1448,8 +1825,24 @@ type Account {
     # List of delegations of the account, if the account is a delegator.
     delegations(cursor:Cursor, count:Int = 25): DelegationList!
 
+    # domainName is the primary FNS domain name assigned to the account.
+    # Null if the account doesn't have any name, or the name service is not available.
+    domainName: String
+
+    # label is the public label of the account, if any.
+    label: AddressLabel
+
     # Details about smart contract, if the account is a smart contract.
     contract: Contract
+
+    # multisig is the configuration of a Safe-style multisig wallet,
+    # if the account is a multisig wallet.
+    multisig: Multisig
+
+    # relatedAddresses is the list of addresses likely controlled by the same entity
+    # as the account, linked by funding relationships and shared deployment patterns.
+    # The most confident relations go first; max 100 addresses are provided.
+    relatedAddresses(limit: Int = 25): [RelatedAddress!]!
 }
 
 # GovernanceContract represents basic information
@@ -1605,6 +1998,24 @@ type GovernanceProposal {
     # the Proposal is rejected and will not be settled in any way (no winner option is selectable).
     votingMustEnd: Long!
 
+    # lifecycle represents the computed lifecycle state of the Proposal
+    # derived from the contract state and the current block time.
+    lifecycle: GovernanceProposalLifecycle!
+
+    # votingStart is the time stamp of the voting start.
+    votingStart: Long!
+
+    # votingEnd is the time stamp of the latest voting end.
+    votingEnd: Long!
+
+    # executionEta is the earliest time stamp an executable Proposal
+    # can be executed. It's null for non-executable, or settled Proposals.
+    executionEta: Long
+
+    # executionDeadline is the time stamp up to which an executable Proposal
+    # has to be executed, it expires afterwards. It's null for non-executable Proposals.
+    executionDeadline: Long
+
     # optionStates is the list of states of all the options in the Proposal.
     # Warning: This is an expensive call, use with caution.
     optionStates: [OptionState!]!
@@ -1619,6 +2030,21 @@ type GovernanceProposal {
     vote(from: Address!, delegatedTo: Address): GovernanceVote
 }
 
+# GovernanceProposalLifecycle represents the lifecycle state of a proposal.
+# PENDING proposals wait for the voting to start, VOTING proposals are open for votes,
+# ACCEPTED and EXECUTED proposals were settled with a winner, REJECTED proposals
+# failed to collect enough votes, CANCELED proposals were withdrawn by the author,
+# and EXPIRED proposals were not executed in time.
+enum GovernanceProposalLifecycle {
+    PENDING
+    VOTING
+    ACCEPTED
+    REJECTED
+    EXECUTED
+    CANCELED
+    EXPIRED
+}
+
 # ProposalState represents the state of the whole proposal.
 type ProposalState {
     # isResolved signals if the Proposal is already resolved.
@@ -1679,234 +2105,1760 @@ type GovernanceVote {
     # presented.
     choices: [Long!]!
 }
-# Root schema definition
-schema {
-    query: Query
-    mutation: Mutation
-    subscription: Subscription
+# SignatureVerification represents the result of a message signature verification.
+type SignatureVerification {
+    # address is the address expected to have signed the message.
+    address: Address!
+
+    # signer is the address recovered from the signature.
+    # It's null if the signature is malformed and no signer can be recovered.
+    signer: Address
+
+    # messageHash is the hash of the message the signature has been verified against.
+    messageHash: Bytes32!
+
+    # type is the signature scheme used to verify the signature.
+    # It's one of EIP191 (personal_sign), EIP712 (typed data)
+    # or EIP1271 (contract wallet).
+    type: String!
+
+    # isValid signals the signature of the message matches the expected address.
+    isValid: Boolean!
 }
 
-# Entry points for querying the API
-type Query {
-    # version represents the API server version responding to your requests.
-    version: String!
+# AddressLabel represents a public tag assigned to a well known address,
+# i.e. an exchange wallet, a team multisig, or a reported scam address.
+type AddressLabel {
+    # address is the labeled address.
+    address: Address!
 
-    # State represents the current state of the blockchain and network.
-    state: CurrentState!
+    # name is the public name of the address owner.
+    name: String!
 
-    # sfcConfig provides the current configuration
-    # of the SFC contract managing the block chain staking economy.
-    sfcConfig: SfcConfig!
+    # category of the address.
+    # It's one of EXCHANGE, TEAM, SCAM, or OTHER.
+    category: String!
 
-    # Total number of accounts active on the Opera blockchain.
-    accountsActive:Long!
+    # website of the address owner, if known.
+    website: String
 
-    # Get an Account information by hash address.
-    account(address:Address!):Account!
+    # note provides additional information about the address, if any.
+    note: String
 
-    # Get list of Contracts with at most <count> edges.
-    # If <count> is positive, return edges after the cursor,
-    # if negative, return edges before the cursor.
-    # For undefined cursor, positive <count> starts the list from top,
-    # negative <count> starts the list from bottom.
-    # ValidatedOnly specifies if the list should contain all the Contracts,
-    # or just contracts with validated byte code and available source/ABI.
-    contracts(validatedOnly: Boolean = false, cursor:Cursor, count:Int!):ContractList!
+    # updated is the unix timestamp of the latest label update.
+    updated: Long!
+}
 
-    # Get block information by number or by hash.
-    # If neither is provided, the most recent block is given.
-    block(number:Long, hash: Bytes32):Block
+# AddressLabelInput represents a set of data sent from client
+# to assign a public label to an address.
+input AddressLabelInput {
+    # address being labeled.
+    address: Address!
 
-    # Get list of Blocks with at most <count> edges.
-    # If <count> is positive, return edges after the cursor,
-    # if negative, return edges before the cursor.
-    # For undefined cursor, positive <count> starts the list from top,
-    # negative <count> starts the list from bottom.
-    blocks(cursor:Cursor, count:Int!):BlockList!
+    # name is the public name of the address owner.
+    # Maximum allowed length is 64 characters.
+    name: String!
 
-    # Get transaction information for given transaction hash.
-    transaction(hash:Bytes32!):Transaction
+    # category of the address; one of EXCHANGE, TEAM, SCAM, or OTHER.
+    category: String!
 
-    # Get list of Transactions with at most <count> edges.
-    # If <count> is positive, return edges after the cursor,
-    # if negative, return edges before the cursor.
-    # For undefined cursor, positive <count> starts the list from top,
-    # negative <count> starts the list from bottom.
-    transactions(cursor:Cursor, count:Int!):TransactionList!
+    # website of the address owner. Maximum allowed length is 128 characters.
+    website: String
 
-    # Get the id of the current epoch of the Opera blockchain.
-    currentEpoch:Long!
+    # note about the address. Maximum allowed length is 256 characters.
+    note: String
+}
 
-    # Get information about specified epoch. Returns current epoch information
-    # if id is not provided.
-    epoch(id: Long): Epoch!
+# AdminQuery represents the namespace of privileged API queries.
+type AdminQuery {
+    # services provides the current state of internal services of the API server.
+    services: [ServiceState!]!
 
-    # Get a scrollable list of epochs sorted from the last one back by default.
-    epochs(cursor: Cursor, count: Int = 25): EpochList!
+    # logLevels provides the current logging level of each logging module.
+    logLevels: [LogLevel!]!
 
-    # The last staker id in Opera blockchain.
-    lastStakerId: Long!
+    # usageReport provides the API usage of all the client origins in the given period,
+    # i.e. HOUR, DAY, WEEK, or MONTH ending with the current hour.
+    # The heaviest users by the compute cost go first.
+    usageReport(period: String = "DAY"): [UsageRecord!]!
+}
 
-    # The number of stakers in Opera blockchain.
-    stakersNum: Long!
+# AdminMutation represents the namespace of privileged API operations.
+type AdminMutation {
+    # purgeCache removes the entry with the given key from the in-memory cache.
+    # Returns TRUE if the entry existed and has been removed.
+    purgeCache(key: String!): Boolean!
 
-    # Staker information. The staker is loaded either by numeric ID,
-    # or by address. null if none is provided.
-    staker(id: BigInt, address: Address): Staker
+    # updateTrxFlow forces the update of the aggregated transaction flow.
+    updateTrxFlow: Boolean!
 
-    # List of staker information from SFC smart contract.
-    stakers: [Staker!]!
+    # revalidateContract re-runs the validation of a contract
+    # against its previously submitted source code.
+    revalidateContract(address: Address!): Contract!
 
-    # The list of delegations for the given staker ID.
-    # Cursor is used to obtain specific slice of the staker's delegations.
-    # The most recent delegations are provided if cursor is omitted.
-    delegationsOf(staker:BigInt!, cursor: Cursor, count: Int = 25): DelegationList!
+    # setMaintenance switches the maintenance mode of the API server on, or off.
+    # Returns the new state of the maintenance mode.
+    setMaintenance(enabled: Boolean!): Boolean!
 
-    # Get the details of a specific delegation by it's delegator address
-    # and staker the delegation belongs to.
-    delegation(address:Address!, staker: BigInt!): Delegation
+    # setLogLevel changes the logging level of the given logging module at runtime.
+    # The "default" module controls all the modules without explicitly set level.
+    # Returns the new logging level of the module.
+    setLogLevel(module: String!, level: String!): String!
 
-    # Get the list of all delegations by it's delegator address.
-    delegationsByAddress(address:Address!, cursor: Cursor, count: Int = 25): DelegationList!
+    # pauseService pauses scheduled runs of a periodic service.
+    # The service is identified by its name, or its scheduler configuration key.
+    pauseService(name: String!): Boolean!
 
-    # Returns the current price per gas in WEI units.
-    gasPrice: Long!
+    # resumeService resumes scheduled runs of a paused periodic service.
+    resumeService(name: String!): Boolean!
 
-    # estimateGas returns the estimated amount of gas required
-    # for the transaction described by the parameters of the call.
-    estimateGas(from: Address, to: Address, value: BigInt, data: String): Long
+    # triggerService runs a periodic service right away, even if paused.
+    triggerService(name: String!): Boolean!
 
-    # Get price details of the Opera blockchain token for the given target symbols.
-    price(to:String!):Price!
+    # setRiskLevel flags a contract, or a token with a moderation risk level.
+    # The level is one of NONE, LOW, MEDIUM, or HIGH; NONE removes the flag.
+    # Other API instances sharing the database may keep serving the previous
+    # level from their cache for up to a minute.
+    setRiskLevel(address: Address!, level: String!, reason: String): Boolean!
+}
 
-    # Get calculated staking rewards for an account or given
-    # staking amount in FTM tokens.
-    # At least one of the address and amount parameters must be provided.
-    # If you provide both, the address takes precedence and the amount is ignored.
-    estimateRewards(address:Address, amount:Long):EstimatedRewards!
+# ServiceState represents the state of an internal service of the API server.
+type ServiceState {
+    # name of the service.
+    name: String!
 
-    # defiConfiguration exposes the current DeFi contract setup.
-    defiConfiguration:DefiSettings!
+    # running signals the service has not been signaled to stop.
+    running: Boolean!
 
-    # defiTokens represents a list of all available DeFi tokens.
-    defiTokens:[DefiToken!]!
+    # queueLength is the number of items waiting in the service queue.
+    # It's zero for services not processing any queue.
+    queueLength: Int!
 
-    # defiNativeToken represents the information about the native token
-    # wrapper ERC20 contract. Returns NULL if the native token wraper
-    # is not available.
-    defiNativeToken: ERC20Token
+    # queueCapacity is the capacity of the service queue.
+    # It's zero for services not processing any queue.
+    queueCapacity: Int!
 
-    # fMintAccount provides DeFi/fMint information about an account on fMint protocol.
-    fMintAccount(owner: Address!):FMintAccount!
+    # lastRun is the UNIX time stamp of the last run of a periodic service.
+    # It's null for services not running periodically, or not run yet.
+    lastRun: Long
 
-    # fMintTokenAllowance resolves the amount of ERC20 tokens unlocked
-    # by the token owner for DeFi/fMint operations.
-    fMintTokenAllowance(owner: Address!, token: Address!):BigInt!
+    # period is the scheduling period of a periodic service in seconds.
+    # It's null for services not running periodically.
+    period: Long
 
-    # defiUniswapPairs represents a list of all pairs managed
-    # by the Uniswap Core contract on Opera blockchain.
-    defiUniswapPairs: [UniswapPair!]!
+    # paused signals scheduled runs of a periodic service are paused.
+    paused: Boolean!
 
-    # defiUniswapAmountsOut calculates the expected output amounts
-    # required to finalize a swap operation specified by a list of
-    # tokens involved in the swap steps and the input amount.
-    # At least two addresses of tokens must be given
-    # for the calculation to succeed.
-    defiUniswapAmountsOut(amountIn: BigInt!, tokens:[Address!]!): [BigInt!]!
+    # leader signals the service holds the lock of its exclusive jobs
+    # across API peers sharing the database.
+    leader: Boolean!
+}
 
-    # defiUniswapAmountsIn calculates the expected input amounts
-    # required to finalize a swap operation specified by a list of
-    # tokens involved in the swap steps and the output amount.
-    # At least two addresses of tokens must be given
-    # for the calculation to succeed.
-    defiUniswapAmountsIn(amountOut: BigInt!, tokens:[Address!]!): [BigInt!]!
+# LogLevel represents the logging level of a logging module.
+type LogLevel {
+    # module is the name of the logging module.
+    module: String!
 
-    # defiUniswapQuoteLiquidity calculates optimal amount of tokens
-    # of an Uniswap pair defined by a pair of tokens for the given amount
-    # of both tokens desired to be added to the liquidity pool.
-    # The function can be used to calculate minimal amount of tokens expected
-    # to be added to the pool on both sides on addLiquidity call.
-    # Please note "amountsIn" must be in the same order as are the tokens.
-    defiUniswapQuoteLiquidity(tokens:[Address!]!, amountsIn:[BigInt!]!): [BigInt!]!
+    # level is the current logging level of the module.
+    level: String!
+}
 
-    # defiUniswapVolumes represents a list of pairs and their historical values
-    # of traded volumes
-    defiUniswapVolumes:[DefiUniswapVolume!]!
+# ApiStatus represents the current operational status of the API server.
+type ApiStatus {
+    # version is the version of the API server.
+    version: String!
 
-    # defiTimeVolumes returns volumes for specified pair, time resolution and interval.
-    # Address is pair address and is mandatory.
-    # Resolution can be {month, day, 4h, 1h, 30m 15m, 5m, 1m}, is optional, default is a day.
-    # Dates are in unix UTC number and are optional. When not provided
-    # then it takes period for last month till now.
-    defiTimeVolumes(address:Address!, resolution:String, fromDate:Int, toDate:Int):[DefiTimeVolume!]!
+    # maintenance signals the API server is in maintenance mode.
+    # Mutations changing the block chain, or the off-chain data
+    # are rejected with the "maintenance" error in this mode,
+    # queries are served from the available data.
+    maintenance: Boolean!
 
-    # defiTimePrices returns prices for specified pair, time resolution and interval.
-    # Address is pair address and is mandatory.
-    # Resolution can be {month, day, 4h, 1h, 30m 15m, 5m, 1m}, is optional, default is a day.
-    # Direction specifies price calculation, default 0 is for TokenA/TokenB otherwise TokenB/TokenA
-    # Dates are in unix UTC number and are optional. When not provided
-    # then it takes period for last month till now.
-    defiTimePrices(address:Address!, resolution:String, fromDate:Int, toDate:Int, direction:Int):[DefiTimePrice!]!
+    # storage describes the off-chain storage the API server keeps its data in.
+    storage: StorageInfo!
+}
 
-    # defiTimeReserves returns reserves for specified pair, time resolution and interval.
-    # Address is pair address and is mandatory.
-    # Resolution can be {month, day, 4h, 1h, 30m 15m, 5m, 1m}, is optional, default is a day.
-    # Dates are in unix UTC number and are optional. When not provided
-    # then it takes period for last month till now.
-    defiTimeReserves(address:Address!, resolution:String, fromDate:Int, toDate:Int):[DefiTimeReserve!]!
+# StorageInfo describes the off-chain storage of the API server.
+type StorageInfo {
+    # driver is the name of the storage driver, i.e. mongo, or memory.
+    driver: String!
 
-    # Get list of Uniswap actions with at most <count> edges.
-    # If <count> is positive, return edges after the cursor,
-    # if negative, return edges before the cursor.
-    # For undefined cursor, positive <count> starts the list from top,
+    # persistent signals the stored data survive restart of the API server.
+    persistent: Boolean!
+
+    # limits lists the features the storage does not provide;
+    # empty for the full featured Mongo database storage.
+    limits: [String!]!
+}
+
+# Apollo Federation support definitions so the API can be composed
+# as a subgraph into a federated gateway.
+
+# _FieldSet is a selection of fields used by federation directives.
+scalar _FieldSet
+
+# _Any is an entity representation sent by the federation gateway.
+scalar _Any
+
+# key marks the fields uniquely identifying an entity across subgraphs.
+directive @key(fields: _FieldSet!) on OBJECT | INTERFACE
+
+# external marks a field as owned by another subgraph.
+directive @external on FIELD_DEFINITION
+
+# requires specifies fields of the entity needed to resolve the field.
+directive @requires(fields: _FieldSet!) on FIELD_DEFINITION
+
+# provides specifies fields of the returned entity resolved by the field.
+directive @provides(fields: _FieldSet!) on FIELD_DEFINITION
+
+# extends marks a type as an extension of an entity owned by another subgraph.
+directive @extends on OBJECT | INTERFACE
+
+# _Entity is a union of all the entity types the API is able to resolve.
+union _Entity = Account | Transaction | Block | ERC20Token
+
+# _Service provides the subgraph information to the federation gateway.
+type _Service {
+    # sdl is the schema definition of the subgraph.
+    sdl: String
+}
+
+# DelegationsSummary represents an aggregated portfolio
+# of all the delegations of an account.
+type DelegationsSummary {
+    # Address of the delegator account.
+    address: Address!
+
+    # Total amount staked on all the delegations in WEI.
+    totalStaked: BigInt!
+
+    # Total amount of pending rewards on all the delegations in WEI.
+    totalPendingRewards: BigInt!
+
+    # Total amount of currently locked stake on all the delegations in WEI.
+    totalLocked: BigInt!
+
+    # Breakdown of the portfolio by the validators delegated to.
+    validators: [DelegationBreakdown!]!
+}
+
+# DelegationBreakdown represents a summary of a single delegation
+# of an account to a validator.
+type DelegationBreakdown {
+    # Identifier of the staker the delegation belongs to.
+    toStakerId: BigInt!
+
+    # Amount staked on the delegation in WEI.
+    staked: BigInt!
+
+    # Amount of pending rewards of the delegation in WEI.
+    pendingRewards: BigInt!
+
+    # Amount of currently locked stake of the delegation in WEI.
+    locked: BigInt!
+
+    # Full detail of the delegation.
+    delegation: Delegation!
+}
+
+# Ballot represents a simple community voting contract.
+type Ballot {
+    # Address of the ballot contract.
+    address: Address!
+
+    # Name of the ballot.
+    name: String!
+
+    # URL of the ballot details.
+    url: String!
+
+    # Timestamp of the voting start.
+    start: Long!
+
+    # Timestamp of the voting end.
+    end: Long!
+
+    # Is the ballot open for voting right now.
+    isOpen: Boolean!
+
+    # Is the ballot finalized.
+    isFinalized: Boolean!
+
+    # Index of the winning proposal; null until the ballot is finalized.
+    winner: Int
+
+    # List of proposals of the ballot with their totals.
+    proposals: [BallotProposal!]!
+
+    # Index of the proposal the given address voted for;
+    # null if the address did not vote on the ballot.
+    vote(address: Address!): Int
+
+    # Signals if the given address voted on the ballot.
+    voted(address: Address!): Boolean!
+}
+
+# BallotProposal represents a single option of a community ballot.
+type BallotProposal {
+    # Index of the proposal in the ballot.
+    index: Int!
+
+    # Name of the proposal.
+    name: String!
+
+    # Total weight of votes received by the proposal on chain.
+    votes: BigInt!
+
+    # Number of voters of the proposal.
+    voters: Long!
+}
+
+# UniswapPosition represents a liquidity position of a provider
+# on a single Uniswap pair.
+type UniswapPosition {
+    # pair represents the Uniswap pair of the position.
+    pair: UniswapPair!
+
+    # owner represents the address of the liquidity provider.
+    owner: Address!
+
+    # liquidity is the amount of the pair tokens held by the owner.
+    liquidity: BigInt!
+
+    # totalSupply represents the total amount of the pair tokens in circulation.
+    totalSupply: BigInt!
+
+    # shareOfPool is the share of the position on the pool
+    # as a fraction between 0 and 1.
+    shareOfPool: Float!
+
+    # amounts represent the current amounts of the underlying tokens
+    # of the position. The amount index corresponds with the token
+    # position inside the pair.
+    amounts: [BigInt!]!
+
+    # deposited represent the total amounts of tokens added
+    # to the pool by the owner.
+    deposited: [BigInt!]!
+
+    # withdrawn represent the total amounts of tokens removed
+    # from the pool by the owner.
+    withdrawn: [BigInt!]!
+
+    # impermanentLoss is the estimated change of the position value
+    # versus holding the net deposited tokens, both valued at the current
+    # pool price. I.e. -0.05 means the position is worth 5% less.
+    # Trading fees collected by the position are included in the estimate.
+    impermanentLoss: Float!
+}
+
+# ERC20Approval represents an allowance granted by a token owner
+# to a spender on an ERC20 token.
+type ERC20Approval {
+    # owner represents the address of the token owner granting the allowance.
+    owner: Address!
+
+    # spender represents the address allowed to spend the tokens.
+    spender: Address!
+
+    # tokenAddress represents the address of the ERC20 token contract.
+    tokenAddress: Address!
+
+    # token represents the token detail involved.
+    token: ERC20Token
+
+    # allowance represents the current amount of tokens
+    # the spender is allowed to move on the owner's behalf.
+    allowance: BigInt!
+
+    # approved represents the amount of tokens granted
+    # by the latest approval of the allowance.
+    approved: BigInt!
+
+    # lastUpdate represents the latest approval transaction of the allowance.
+    lastUpdate: ERC20Transaction!
+}
+
+# Multisig represents the configuration of a Safe-style multisig wallet.
+type Multisig {
+    # owners is the list of owners of the wallet.
+    owners: [Address!]!
+
+    # threshold is the number of owners' confirmations needed to execute a transaction.
+    threshold: Long!
+
+    # nonce is the nonce of the next transaction to be executed by the wallet.
+    nonce: Long!
+
+    # version is the version of the wallet contract. Empty if not available.
+    version: String!
+
+    # pendingTxCount is the number of transactions proposed to the wallet,
+    # but not executed yet. Null if the transaction service is not available.
+    pendingTxCount: Long
+}
+
+# FinalityStats represents the finality latency statistics of blocks in a time range.
+# All the durations are in milliseconds.
+type FinalityStats {
+    # blocks is the number of blocks with known finality latency in the range.
+    blocks: Long!
+
+    # avgBlockTime is the average time between blocks.
+    avgBlockTime: Long!
+
+    # min is the lowest observed time to finality.
+    min: Long!
+
+    # max is the highest observed time to finality.
+    max: Long!
+
+    # avg is the average time to finality.
+    avg: Long!
+
+    # median is the median time to finality.
+    median: Long!
+
+    # p95 is the 95th percentile of time to finality.
+    p95: Long!
+}
+
+# TransactionReceipt represents the receipt of a processed transaction
+# as provided by the block chain node.
+type TransactionReceipt {
+    # status is the return status of the transaction; 1 on success, 0 on failure.
+    status: Long!
+
+    # gasUsed is the amount of gas used by the transaction.
+    gasUsed: Long!
+
+    # cumulativeGasUsed is the total amount of gas used in the block
+    # up to and including the transaction.
+    cumulativeGasUsed: Long!
+
+    # effectiveGasPrice is the price paid per unit of gas in WEI.
+    effectiveGasPrice: BigInt!
+
+    # contractAddress is the address of the contract created by the transaction, if any.
+    contractAddress: Address
+
+    # logsBloom is the bloom filter of the transaction logs.
+    logsBloom: Bytes!
+
+    # logs is the list of log records created by the transaction.
+    logs: [TransactionLog!]!
+}
+
+# TransactionLog represents a log record created by a transaction.
+type TransactionLog {
+    # address is the address of the contract which created the log record.
+    address: Address!
+
+    # topics is the list of indexed topics of the log record.
+    topics: [Bytes32!]!
+
+    # data is the non-indexed data of the log record.
+    data: Bytes!
+
+    # logIndex is the index of the log record in the block.
+    logIndex: Long!
+
+    # removed signals the log record was reverted due to a chain reorganisation.
+    removed: Boolean!
+}
+
+# ContractCreation represents a contract deployed by another contract, i.e. by a factory.
+type ContractCreation {
+    # address is the address of the deployed contract.
+    address: Address!
+
+    # creator is the address of the contract which deployed the contract.
+    creator: Address!
+
+    # transaction is the transaction the contract was deployed in.
+    transaction: Transaction!
+
+    # method is the deployment opcode, either CREATE, or CREATE2.
+    method: String!
+
+    # salt is the CREATE2 salt; null if not known.
+    salt: Bytes32
+
+    # initCodeHash is the hash of the contract init code.
+    initCodeHash: Bytes32!
+
+    # timestamp is the unix timestamp of the deployment.
+    timestamp: Long!
+
+    # contract is the deployed contract detail, if known.
+    contract: Contract
+}
+
+# Cache control support definitions.
+# The max age of a response is the lowest max age of the fields requested.
+# Root fields without the directive are not cached at all, nested fields
+# without the directive don't limit the max age of the response.
+directive @cacheControl(maxAge: Int!) on FIELD_DEFINITION
+
+# BackendHealth represents the health of a backend the API server depends on.
+type BackendHealth {
+    # healthy signals the backend responded successfully.
+    healthy: Boolean!
+
+    # latency is the time in milliseconds the backend took to respond.
+    latency: Long!
+
+    # error is the reason the backend is not healthy, if any.
+    error: String
+}
+
+# NodeHealth represents the health of the block chain node.
+type NodeHealth {
+    # healthy signals the node responded successfully.
+    healthy: Boolean!
+
+    # latency is the time in milliseconds the node took to respond.
+    latency: Long!
+
+    # error is the reason the node is not healthy, if any.
+    error: String
+
+    # height is the number of the most recent block known to the node.
+    height: Long!
+
+    # peers is the number of peers the node is connected to.
+    peers: Long!
+}
+
+# CacheStats represents the statistics of the in-memory cache.
+type CacheStats {
+    # entries is the number of entries stored in the cache.
+    entries: Long!
+
+    # capacity is the size of the memory allocated by the cache in bytes.
+    capacity: Long!
+
+    # hits is the number of successful cache lookups.
+    hits: Long!
+
+    # misses is the number of cache lookups of missing entries.
+    misses: Long!
+
+    # collisions is the number of key collisions.
+    collisions: Long!
+}
+
+# DbPoolStats represents the statistics of the database connection pool.
+type DbPoolStats {
+    # open is the number of open connections.
+    open: Long!
+
+    # inUse is the number of connections currently checked out of the pool.
+    inUse: Long!
+
+    # idle is the number of open connections waiting in the pool.
+    idle: Long!
+
+    # maxSize is the configured max size of the pool; zero for the driver default.
+    maxSize: Long!
+
+    # checkOuts is the number of successful connection check outs.
+    checkOuts: Long!
+
+    # checkOutFailures is the number of failed connection check outs.
+    checkOutFailures: Long!
+
+    # cleared is the number of times the pool was cleared after a server error.
+    cleared: Long!
+}
+
+# DelegationOperationType represents the type of an operation on a delegation.
+enum DelegationOperationType {
+    DELEGATE
+    INCREASE
+    CLAIM
+    RESTAKE
+    UNDELEGATE
+    WITHDRAW
+}
+
+# DelegationOperation represents a single operation on a delegation,
+# e.g. a new stake, claimed rewards, or un-delegation of the stake.
+type DelegationOperation {
+    # type represents the type of the operation.
+    type: DelegationOperationType!
+
+    # address represents the address of the delegator.
+    address: Address!
+
+    # toStakerId represents the ID of the validator the delegation
+    # is placed on.
+    toStakerId: BigInt!
+
+    # amount represents the amount of tokens involved in the operation.
+    amount: BigInt!
+
+    # timeStamp represents the time stamp of the operation
+    # in Unix Epoch units, e.g. number of seconds from the Unix Epoch start.
+    timeStamp: Long!
+
+    # trxHash represents the hash of the transaction executing the operation.
+    trxHash: Bytes32!
+
+    # transaction represents the transaction executing the operation.
+    transaction: Transaction!
+}
+
+# DelegationOperationList is a list of operations of a delegation.
+type DelegationOperationList {
+    # Edges contains provided edges of the sequential list.
+    edges: [DelegationOperationListEdge!]!
+
+    # TotalCount is the maximum number of delegation operations
+    # available for sequential access.
+    totalCount: Long!
+
+    # PageInfo is an information about the current page
+    # of delegation operation edges.
+    pageInfo: ListPageInfo!
+}
+
+# DelegationOperationListEdge is a single edge in a sequential list
+# of delegation operations.
+type DelegationOperationListEdge {
+    # Cursor defines a scroll key to this edge.
+    cursor: Cursor!
+
+    # operation represents the delegation operation detail provided by this list edge.
+    operation: DelegationOperation!
+}
+
+# StakingStats represents aggregated staking statistics of the network.
+type StakingStats {
+    # totalStaked represents the total amount of tokens staked in WEI.
+    totalStaked: BigInt!
+
+    # totalSelfStaked represents the amount staked by validators
+    # on their own validator nodes in WEI.
+    totalSelfStaked: BigInt!
+
+    # totalDelegated represents the amount delegated
+    # to validators by delegators in WEI.
+    totalDelegated: BigInt!
+
+    # totalRewardsClaimed represents the cumulative amount of staking rewards
+    # claimed by delegators and validators in WEI.
+    totalRewardsClaimed: BigInt!
+
+    # totalSupply represents the total supply of native tokens
+    # on the latest sealed epoch in WEI.
+    totalSupply: BigInt!
+
+    # averageApr represents the stake weighted average annual rate of return
+    # of active validators, i.e. 0.05 for 5%.
+    averageApr: Float!
+
+    # participationRate represents the ratio of staked tokens
+    # to the total supply, i.e. 0.45 for 45%.
+    participationRate: Float!
+
+    # activeValidators represents the number of active validators.
+    activeValidators: Long!
+
+    # activeDelegations represents the number of delegations with non-zero stake.
+    activeDelegations: Long!
+
+    # updated represents the time stamp of the statistics calculation
+    # in Unix Epoch units, e.g. number of seconds from the Unix Epoch start.
+    updated: Long!
+
+    # dailyRewards provides a list of daily aggregations of claimed rewards.
+    # If boundaries are not defined, last 90 days of aggregated rewards are provided.
+    # Boundaries are defined in format YYYY-MM-DD, i.e. 2021-01-23 for January 23rd, 2021.
+    dailyRewards(from:String, to:String): [DailyRewards!]!
+}
+
+# DailyRewards represents an aggregation of staking rewards
+# claimed on the network on specific day.
+type DailyRewards {
+    # day represents the day of the aggregation in format YYYY-MM-DD
+    # i.e. 2021-01-23 for January 23rd, 2021
+    day: String!
+
+    # claims represents the number of reward claims made on the day.
+    claims: Int!
+
+    # amount represents the total amount of rewards claimed on the day in WEI.
+    amount: BigInt!
+}
+
+# FtmSupply represents the current state of the native FTM token supply
+# based on the latest sealed epoch tracked by the API server.
+type FtmSupply {
+    # epoch represents the id of the latest tracked sealed epoch.
+    epoch: Long!
+
+    # timeStamp represents the time stamp of the epoch sealing
+    # in Unix Epoch units, e.g. number of seconds from the Unix Epoch start.
+    timeStamp: Long!
+
+    # total represents the total supply of FTM tokens in WEI.
+    total: BigInt!
+
+    # staked represents the amount of FTM tokens staked in the SFC in WEI.
+    staked: BigInt!
+
+    # circulating represents the amount of FTM tokens not staked in the SFC in WEI.
+    circulating: BigInt!
+
+    # burned represents the cumulative amount of transaction fees
+    # removed from circulation in WEI.
+    burned: BigInt!
+
+    # history provides the supply changes of a range of sealed epochs
+    # sorted from the oldest to the newest epoch. If boundaries are not defined,
+    # the last 100 tracked epochs are provided. Up to 1000 epochs are provided at once.
+    history(from: Long, to: Long): [FtmSupplyEpoch!]!
+}
+
+# FtmSupplyEpoch represents the state of the FTM supply on a sealed epoch
+# along with the supply changes made by the epoch.
+type FtmSupplyEpoch {
+    # epoch represents the id of the sealed epoch.
+    epoch: Long!
+
+    # timeStamp represents the time stamp of the epoch sealing
+    # in Unix Epoch units, e.g. number of seconds from the Unix Epoch start.
+    timeStamp: Long!
+
+    # total represents the total supply of FTM tokens on the epoch end in WEI.
+    total: BigInt!
+
+    # staked represents the amount of FTM tokens staked on the epoch end in WEI.
+    staked: BigInt!
+
+    # circulating represents the amount of FTM tokens not staked on the epoch end in WEI.
+    circulating: BigInt!
+
+    # burned represents the amount of transaction fees removed
+    # from circulation by the epoch in WEI.
+    burned: BigInt!
+
+    # minted represents the amount of new tokens minted by the epoch in WEI.
+    minted: BigInt!
+
+    # totalBurned represents the cumulative amount of fees burned
+    # up to the epoch end in WEI.
+    totalBurned: BigInt!
+}
+
+# ContractArtifact represents the full compilation output of a validated contract.
+# The artifact can also be downloaded as a JSON file from the /contract/artifact/{address} end-point.
+type ContractArtifact {
+    # address is the address of the validated contract.
+    address: Address!
+
+    # name is the name of the compiled contract.
+    name: String!
+
+    # compiler is the compiler language and version identifier.
+    compiler: String!
+
+    # compilerVersion is the full version of the compiler used.
+    compilerVersion: String!
+
+    # compilerOptions are the command line options of the compiler.
+    compilerOptions: String!
+
+    # abi is the JSON encoded ABI definition of the contract.
+    abi: String!
+
+    # bytecode is the hex encoded creation code; linked libraries are kept as placeholders.
+    bytecode: String!
+
+    # deployedBytecode is the hex encoded runtime code.
+    deployedBytecode: String!
+
+    # sourceMap is the source map of the creation code.
+    sourceMap: String!
+
+    # deployedSourceMap is the source map of the runtime code.
+    deployedSourceMap: String!
+
+    # metadata is the JSON encoded compiler metadata.
+    metadata: String!
+
+    # created is the unix timestamp the artifact was created at.
+    created: Long!
+}
+
+# WatchedAddress represents an address on the watch list of an API client.
+type WatchedAddress {
+    # address is the watched address.
+    address: Address!
+
+    # label is the private label of the address given by the client; empty if not set.
+    label: String!
+
+    # added is the unix timestamp the address was added to the watch list at.
+    added: Long!
+}
+
+# WatchListDigest represents the aggregated activity of a watched address
+# in the digest period. The activity is aggregated in hourly periods.
+type WatchListDigest {
+    # address is the watched address.
+    address: Address!
+
+    # from is the unix timestamp of the start of the first period with an activity.
+    from: Long!
+
+    # to is the unix timestamp of the end of the last period with an activity.
+    to: Long!
+
+    # trxSent is the number of transactions sent by the address.
+    trxSent: Int!
+
+    # trxReceived is the number of transactions received by the address.
+    trxReceived: Int!
+
+    # valueSent is the amount of FTM sent by the address in WEI units.
+    # The amount is aggregated with the precision of 10^-9 FTM.
+    valueSent: BigInt!
+
+    # valueReceived is the amount of FTM received by the address in WEI units.
+    # The amount is aggregated with the precision of 10^-9 FTM.
+    valueReceived: BigInt!
+
+    # erc20Sent is the number of ERC20 token transfers sent by the address.
+    erc20Sent: Int!
+
+    # erc20Received is the number of ERC20 token transfers received by the address.
+    erc20Received: Int!
+}
+
+# Dashboard represents a snapshot of the network state
+# bundling the content of the explorer homepage.
+type Dashboard {
+    # block is the latest block of the chain.
+    block: Block!
+
+    # tps is the recent speed of the network in transactions per second.
+    tps: Float!
+
+    # gasPrice provides the current gas price tiers.
+    gasPrice: GasPriceTiers!
+
+    # price is the current price of FTM in the requested target symbol.
+    price: Price!
+
+    # stakingStats provides recent aggregated staking statistics of the network.
+    stakingStats: StakingStats!
+
+    # blocks is the list of the latest blocks of the chain, newest first.
+    blocks: [Block!]!
+
+    # transactions is the list of the latest transactions of the chain, newest first.
+    transactions: [Transaction!]!
+}
+
+# GasPriceTiers represents the gas price tiers in GWei.
+type GasPriceTiers {
+    # safeLow is the gas price expected to be processed eventually.
+    safeLow: Float!
+
+    # average is the gas price expected to be processed in a reasonable time.
+    average: Float!
+
+    # fast is the gas price expected to be processed quickly.
+    fast: Float!
+
+    # fastest is the gas price expected to be processed right away.
+    fastest: Float!
+}
+
+# CompoundingEstimation represents a projection of a delegation balance
+# with rewards periodically re-staked at the current reward rate.
+type CompoundingEstimation {
+    # staked is the current active amount of the delegation in WEI units.
+    staked: BigInt!
+
+    # periodDays is the length of the projected period in days.
+    periodDays: Int!
+
+    # restakeFrequency is the number of days between rewards re-staking.
+    restakeFrequency: Int!
+
+    # restakes is the number of rewards re-stakes within the period.
+    restakes: Int!
+
+    # projectedBalance is the projected delegation balance at the end of the period
+    # including rewards of the last incomplete re-stake period, in WEI units.
+    projectedBalance: BigInt!
+
+    # rewards is the total amount of rewards earned with compounding in WEI units.
+    rewards: BigInt!
+
+    # simpleRewards is the amount of rewards earned in the period without
+    # any re-staking, in WEI units; provided for comparison.
+    simpleRewards: BigInt!
+}
+
+# TransactionSubmission represents the result of a transaction
+# submitted to the block chain as a part of a batch.
+type TransactionSubmission {
+    # index is the position of the transaction in the submitted batch.
+    index: Int!
+
+    # hash is the hash of the transaction; null if the transaction
+    # could not be decoded.
+    hash: Bytes32
+
+    # transaction is the submitted transaction; null if the transaction
+    # was not submitted.
+    transaction: Transaction
+
+    # error describes why the transaction was not submitted; null on success.
+    error: String
+}
+
+# QueryResult represents the result of a live query evaluation.
+type QueryResult {
+    # block is the number of the block the query was evaluated on.
+    block: Long!
+
+    # data is the JSON encoded data of the query result.
+    data: String!
+
+    # errors is the list of errors raised by the query evaluation.
+    errors: [String!]!
+}
+
+# ContractEvent represents a single event log record emitted by a contract.
+type ContractEvent {
+    # contract is the address of the contract emitting the event.
+    contract: Address!
+
+    # name is the name of the event resolved from the validated contract ABI.
+    # Null if the contract is not validated, or the event is not in its ABI.
+    name: String
+
+    # topics is the list of the event topics; the first topic
+    # is the event signature hash, except anonymous events.
+    topics: [Bytes32!]!
+
+    # data is the non-indexed data of the event.
+    data: Bytes!
+
+    # trxHash is the hash of the transaction emitting the event.
+    trxHash: Bytes32!
+
+    # transaction is the transaction emitting the event.
+    transaction: Transaction!
+
+    # blockNumber is the number of the block the event was emitted in.
+    blockNumber: Long!
+
+    # logIndex is the index of the event log in the block.
+    logIndex: Int!
+
+    # timeStamp is the time stamp of the event in Unix Epoch units.
+    timeStamp: Long!
+}
+
+# ContractEventList is a list of events emitted by a contract.
+type ContractEventList {
+    # Edges contains provided edges of the sequential list.
+    edges: [ContractEventListEdge!]!
+
+    # TotalCount is the maximum number of contract events
+    # available for sequential access.
+    totalCount: Long!
+
+    # PageInfo is an information about the current page
+    # of contract event edges.
+    pageInfo: ListPageInfo!
+}
+
+# ContractEventListEdge is a single edge in a sequential list
+# of contract events.
+type ContractEventListEdge {
+    # Cursor defines a scroll key to this edge.
+    cursor: Cursor!
+
+    # event represents the contract event detail provided by this list edge.
+    event: ContractEvent!
+}
+
+# ContractEventStat represents the number of events of a signature
+# emitted by a contract on a single day.
+type ContractEventStat {
+    # topic is the signature hash of the event; zero hash for anonymous events.
+    topic: Bytes32!
+
+    # name is the name of the event resolved from the validated contract ABI, if available.
+    name: String
+
+    # day is the Unix Epoch time stamp of the start of the day (UTC).
+    day: Long!
+
+    # count is the number of events emitted on the day.
+    count: Long!
+}
+
+# ContractEventSummary represents the total number of events of a signature
+# emitted by a contract in a period.
+type ContractEventSummary {
+    # topic is the signature hash of the event; zero hash for anonymous events.
+    topic: Bytes32!
+
+    # name is the name of the event resolved from the validated contract ABI, if available.
+    name: String
+
+    # count is the number of events emitted in the period.
+    count: Long!
+}
+
+# AlertRuleType represents the type of condition monitored by an alert rule.
+enum AlertRuleType {
+    # BALANCE_BELOW raises an alert when the balance of the address drops below the threshold.
+    BALANCE_BELOW
+
+    # EVENT_EMITTED raises an alert for each event emitted by the contract;
+    # the events may be limited to the given event signature topic.
+    EVENT_EMITTED
+
+    # OWNER_CHANGED raises an alert when the owner of an Ownable contract changes.
+    OWNER_CHANGED
+}
+
+# AlertRule represents a condition registered by an API client
+# and evaluated on new blocks.
+type AlertRule {
+    # id is the unique identifier of the rule.
+    id: String!
+
+    # type is the type of the monitored condition.
+    type: AlertRuleType!
+
+    # address is the monitored account, or contract.
+    address: Address!
+
+    # threshold is the balance threshold in WEI units of the BALANCE_BELOW rule.
+    threshold: BigInt
+
+    # topic is the event signature the EVENT_EMITTED rule is limited to, if any.
+    topic: Bytes32
+
+    # webhook is the HTTPS URL alerts of the rule are posted to, if any.
+    webhook: String
+
+    # created is the unix timestamp the rule was registered at.
+    created: Long!
+
+    # block is the number of the last block the rule was evaluated on.
+    block: Long!
+}
+
+# Alert represents an alert raised by an alert rule.
+type Alert {
+    # rule is the identifier of the rule raising the alert.
+    rule: String!
+
+    # type is the type of the rule raising the alert.
+    type: AlertRuleType!
+
+    # address is the monitored account, or contract.
+    address: Address!
+
+    # block is the number of the block the alert was raised on.
+    block: Long!
+
+    # trx is the hash of the transaction causing the alert, if known.
+    trx: Bytes32
+
+    # message describes the alert.
+    message: String!
+
+    # time is the unix timestamp the alert was raised at.
+    time: Long!
+
+    # delivered signals the alert has been posted to the webhook of the rule.
+    delivered: Boolean!
+}
+
+# OracleSource represents the type of an on-chain price feed adapter.
+enum OracleSource {
+    CHAINLINK
+    BAND
+    UNKNOWN
+}
+
+# OracleFeed represents an on-chain price feed used by the fMint protocol
+# to value a DeFi token.
+type OracleFeed {
+    # token is the priced DeFi token.
+    token: DefiToken!
+
+    # symbol is the symbol of the priced token.
+    symbol: String!
+
+    # oracle is the address of the price feed contract of the token.
+    oracle: Address!
+
+    # source is the type of the price feed adapter.
+    source: OracleSource!
+
+    # description is the description of the feed provided by the feed contract; may be empty.
+    description: String!
+
+    # price is the price of the token the fMint protocol uses,
+    # as provided by the price oracle proxy with priceDecimals digits.
+    price: BigInt!
+
+    # priceDecimals is the number of decimals of the price.
+    priceDecimals: Int!
+
+    # value is the price of the token as a decimal number.
+    value: Float!
+
+    # round is the identifier of the latest feed round; null if not known.
+    round: Long
+
+    # updated is the unix timestamp of the last price update of the feed; null if not known.
+    updated: Long
+
+    # referencePrice is the USD price of the token from an off-chain source; null if not available.
+    referencePrice: Float
+
+    # deviation is the deviation of the on-chain price from the off-chain reference price
+    # in percents; null if the reference price is not available.
+    deviation: Float
+}
+
+# FMintStats represents a snapshot of the protocol level fMint metrics
+# aggregated over the fMint accounts.
+type FMintStats {
+    # time is the unix timestamp of the snapshot.
+    time: Long!
+
+    # collateral is the list of total collateral amounts by token.
+    collateral: [FMintTokenTotal!]!
+
+    # debt is the list of total minted amounts by token.
+    debt: [FMintTokenTotal!]!
+
+    # collateralValue is the total value of the collateral in fUSD.
+    collateralValue: BigInt!
+
+    # debtValue is the total value of the minted tokens in fUSD.
+    debtValue: BigInt!
+
+    # fUsdMinted is the total amount of fUSD tokens minted.
+    fUsdMinted: BigInt!
+
+    # collateralRatio is the global ratio between the collateral and the debt value,
+    # i.e. 3.0 means 300%; null if there is no debt.
+    collateralRatio: Float
+
+    # warningRatio is the collateral ratio below which an account is considered at risk.
+    warningRatio: Float!
+
+    # accounts is the number of known fMint accounts.
+    accounts: Int!
+
+    # activeAccounts is the number of fMint accounts with a debt.
+    activeAccounts: Int!
+
+    # accountsAtRisk is the number of fMint accounts with the collateral ratio
+    # below the warning ratio.
+    accountsAtRisk: Int!
+}
+
+# FMintTokenTotal represents the total amount of a DeFi token in an fMint pool.
+type FMintTokenTotal {
+    # token is the DeFi token.
+    token: DefiToken!
+
+    # amount is the total amount of the token in the pool.
+    amount: BigInt!
+
+    # value is the value of the amount in fUSD.
+    value: BigInt!
+}
+
+# FMintAccountAtRisk represents the last known state of an fMint account with a debt.
+type FMintAccountAtRisk {
+    # address is the address of the account owner.
+    address: Address!
+
+    # collateral is the list of the collateral positions of the account.
+    collateral: [FMintTokenTotal!]!
+
+    # debt is the list of the debt positions of the account.
+    debt: [FMintTokenTotal!]!
+
+    # collateralValue is the total value of the collateral in fUSD.
+    collateralValue: BigInt!
+
+    # debtValue is the total value of the debt in fUSD.
+    debtValue: BigInt!
+
+    # collateralRatio is the ratio between the collateral and the debt value,
+    # i.e. 3.0 means 300%.
+    collateralRatio: Float!
+
+    # updated is the unix timestamp the account state was refreshed at.
+    updated: Long!
+}
+
+# FMintAccountAtRiskList is a list of fMint accounts at risk
+# sorted from the lowest collateral ratio.
+type FMintAccountAtRiskList {
+    # Edges contains provided edges of the sequential list.
+    edges: [FMintAccountAtRiskListEdge!]!
+
+    # TotalCount is the number of the accounts matching the filter.
+    totalCount: Long!
+
+    # PageInfo is an information about the current page of the list.
+    pageInfo: ListPageInfo!
+}
+
+# FMintAccountAtRiskListEdge is a single edge in the list of fMint accounts at risk.
+type FMintAccountAtRiskListEdge {
+    # Cursor defines a scroll key to this edge.
+    cursor: Cursor!
+
+    # account represents the account state provided by this list edge.
+    account: FMintAccountAtRisk!
+}
+
+# ChainInfo represents the identification of the block chain network
+# and the capabilities of the node and the API server.
+type ChainInfo {
+    # chainId is the identifier of the chain used to sign transactions.
+    chainId: Long!
+
+    # network is the name of the network, if known.
+    network: String
+
+    # clientVersion is the version of the node client software.
+    # Empty if the node doesn't provide it.
+    clientVersion: String!
+
+    # modules is the list of RPC API modules enabled on the node.
+    modules: [String!]!
+
+    # features is the list of optional API features enabled
+    # by the configuration of the API server, i.e. fmint, flend, uniswap,
+    # governance, ballots, fns, multicall, trace_creations, token_list,
+    # watch_lists, or playground.
+    features: [String!]!
+}
+
+# Feature groups support definitions.
+# Fields annotated with the directive are removed from the schema
+# if the feature group is disabled by the API server configuration.
+directive @feature(name: String!) on FIELD_DEFINITION
+
+# BlockHeader represents the lightweight summary of a block
+# pushed to the block header subscribers.
+type BlockHeader {
+    # number is the number of the block.
+    number: Long!
+
+    # hash is the unique hash of the block.
+    hash: Bytes32!
+
+    # txCount is the number of transactions in the block.
+    txCount: Int!
+
+    # timestamp is the unix timestamp at which the block was mined.
+    timestamp: Long!
+}
+
+# ValidatorApr represents the realized annual percentage rate
+# of the rewards of a validator in a sealed epoch.
+type ValidatorApr {
+    # epoch is the id of the sealed epoch.
+    epoch: Long!
+
+    # time is the unix timestamp of the epoch being sealed.
+    time: Long!
+
+    # apr is the reward per staked token earned in the epoch
+    # extrapolated to the whole year, i.e. 0.1 means 10%.
+    apr: Float!
+
+    # networkApr is the average realized APR of all the validators
+    # participating in the epoch.
+    networkApr: Float!
+
+    # commission is the ratio of rewards taken by the validator in the epoch,
+    # i.e. 0.15 means 15%; null if the commission was not recorded.
+    commission: Float
+}
+
+# CommissionChange represents the commission of a validator
+# taking effect on a sealed epoch.
+type CommissionChange {
+    # epoch is the id of the first sealed epoch with the commission.
+    epoch: Long!
+
+    # time is the unix timestamp of the epoch being sealed.
+    time: Long!
+
+    # commission is the ratio of rewards taken by the validator,
+    # i.e. 0.15 means 15%.
+    commission: Float!
+}
+
+# LargeTransfer represents a transfer of native tokens,
+# or an ERC20 token above a subscribed threshold.
+type LargeTransfer {
+    # token is the address of the transferred ERC20 token; null for native tokens.
+    token: Address
+
+    # trxHash is the hash of the transaction carrying the transfer.
+    trxHash: Bytes32!
+
+    # transaction is the transaction carrying the transfer.
+    transaction: Transaction!
+
+    # from is the address of the sender.
+    from: Address!
+
+    # to is the address of the recipient.
+    to: Address!
+
+    # amount is the transferred amount in the smallest units of the token.
+    amount: BigInt!
+
+    # timeStamp is the unix timestamp of the transfer.
+    timeStamp: Long!
+}
+
+# RelatedAddress represents an address likely controlled by the same entity
+# as an account, as detected by funding and deployment heuristics.
+type RelatedAddress {
+    # address is the related address.
+    address: Address!
+
+    # account is the detail of the related account.
+    account: Account!
+
+    # relations is the list of the relations linking the address to the account:
+    # FUNDER, FUNDED, SHARED_FUNDER, DEPLOYER, DEPLOYED, SHARED_DEPLOYER, or SHARED_CODE.
+    relations: [String!]!
+
+    # confidence is the heuristic confidence of the common control
+    # in the range 0 to 1; independent relations add up.
+    confidence: Float!
+}
+
+# UsageRecord represents the API usage of a client origin in a period.
+type UsageRecord {
+    # origin identifies the client; "key:" followed by the identifier of a registered
+    # API key, "origin:" followed by the browser origin, or "anonymous".
+    origin: String!
+
+    # from is the unix timestamp of the beginning of the period.
+    from: Long!
+
+    # to is the unix timestamp of the end of the period.
+    to: Long!
+
+    # requests is the number of GraphQL operations executed for the client.
+    requests: Long!
+
+    # bytes is the data volume of the requests and responses exchanged with the client.
+    bytes: Long!
+
+    # cost is the compute cost of the client requests as their execution time in milliseconds.
+    cost: Long!
+}
+
+# Root schema definition
+schema {
+    query: Query
+    mutation: Mutation
+    subscription: Subscription
+}
+
+# Entry points for querying the API
+type Query {
+    # version represents the API server version responding to your requests.
+    version: String!
+
+    # _service provides the subgraph information for Apollo Federation gateways.
+    _service: _Service!
+
+    # _entities resolves entities referenced by Apollo Federation gateways
+    # by their key fields representation.
+    _entities(representations: [_Any!]!): [_Entity]!
+
+    # status represents the current operational status of the API server.
+    status: ApiStatus!
+
+    # chainInfo represents the identification of the chain and the capabilities
+    # of the node and the API server, so clients can configure themselves
+    # for the deployment they are connected to.
+    chainInfo: ChainInfo! @cacheControl(maxAge: 60)
+
+    # State represents the current state of the blockchain and network.
+    state: CurrentState! @cacheControl(maxAge: 1)
+
+    # sfcConfig provides the current configuration
+    # of the SFC contract managing the block chain staking economy.
+    sfcConfig: SfcConfig! @cacheControl(maxAge: 60)
+
+    # Total number of accounts active on the Opera blockchain.
+    accountsActive:Long! @cacheControl(maxAge: 60)
+
+    # Get an Account information by hash address.
+    account(address:Address!):Account! @cacheControl(maxAge: 5)
+
+    # resolveName provides the address assigned to the given FNS domain name.
+    # Null if the name is not registered, or the name service is not available.
+    resolveName(name: String!): Address
+
+    # admin provides the namespace of privileged queries.
+    # It requires a privileged API key sent in the X-Api-Key header.
+    admin: AdminQuery!
+
+    # addressLabel provides the public label assigned to the given address.
+    # Null if the address is not labeled.
+    addressLabel(address: Address!): AddressLabel
+
+    # watchlist provides the addresses on the watch list of the API client.
+    # It requires a registered client API key sent in the X-Api-Key header.
+    watchlist: [WatchedAddress!]!
+
+    # watchlistDigest provides the aggregated activity of addresses on the watch list
+    # of the API client since the given unix timestamp. Addresses without
+    # any activity are not included. It requires a registered client API key
+    # sent in the X-Api-Key header.
+    watchlistDigest(since: Long!): [WatchListDigest!]!
+
+    # alertRules provides the alert rules registered by the API client.
+    # It requires a registered client API key sent in the X-Api-Key header.
+    alertRules: [AlertRule!]!
+
+    # alerts provides up to 100 oldest alerts raised by the alert rules of the API client
+    # since the given unix timestamp. Poll with the time of the last alert received
+    # to get the following ones. It requires a registered client API key
+    # sent in the X-Api-Key header.
+    alerts(since: Long!): [Alert!]!
+
+    # Get list of Contracts with at most <count> edges.
+    # If <count> is positive, return edges after the cursor,
+    # if negative, return edges before the cursor.
+    # For undefined cursor, positive <count> starts the list from top,
+    # negative <count> starts the list from bottom.
+    # ValidatedOnly specifies if the list should contain all the Contracts,
+    # or just contracts with validated byte code and available source/ABI.
+    contracts(validatedOnly: Boolean = false, cursor:Cursor, count:Int!):ContractList! @cacheControl(maxAge: 60)
+
+    # Get the most recent contracts deployed by the given contract, i.e. by a factory.
+    # Contracts deployed by other contracts are detected only if the call tracing is enabled.
+    contractsCreatedBy(address: Address!, count: Int = 50):[ContractCreation!]!
+
+    # Get contracts deployed with the runtime byte code identical to the contract
+    # at the given address, the newest deployments first. Validation of the contract
+    # can be copied to them by the propagateContractValidation mutation.
+    contractsWithSameBytecode(address: Address!, count: Int = 25):[Contract!]!
+
+    # Get the address of a contract deployed by the given deployer using CREATE2
+    # with the given salt and the hash of the contract init code.
+    predictCreate2Address(deployer: Address!, salt: Bytes32!, initCodeHash: Bytes32!):Address! @cacheControl(maxAge: 86400)
+
+    # Get block information by number or by hash.
+    # If neither is provided, the most recent block is given.
+    block(number:Long, hash: Bytes32):Block @cacheControl(maxAge: 86400)
+
+    # Get list of Blocks with at most <count> edges.
+    # If <count> is positive, return edges after the cursor,
+    # if negative, return edges before the cursor.
+    # For undefined cursor, positive <count> starts the list from top,
+    # negative <count> starts the list from bottom.
+    blocks(cursor:Cursor, count:Int!):BlockList! @cacheControl(maxAge: 1)
+
+    # Get blocks between the given block numbers, both inclusive, in ascending order.
+    # The range may span at most 100 blocks and is clipped to the current chain head.
+    blockRange(from:Long!, to:Long!):[Block!]! @cacheControl(maxAge: 5)
+
+    # Check if the account may have any activity in the given range of blocks, both inclusive.
+    # The check uses the bloom index of addresses involved in transactions of each block,
+    # including addresses in transaction logs. FALSE means the account definitely has no activity
+    # in the range, TRUE means it may have and a transaction scan is needed to be sure.
+    # Blocks not covered by the index yet are assumed to have the activity.
+    accountHasActivityIn(address:Address!, fromBlock:Long!, toBlock:Long!):Boolean! @cacheControl(maxAge: 5)
+
+    # Get finality latency statistics of blocks observed in the given range
+    # of seconds back from now. The range is limited to 24 hours.
+    finalityStats(range: Long = 3600):FinalityStats! @cacheControl(maxAge: 5)
+
+    # Get transaction information for given transaction hash.
+    transaction(hash:Bytes32!):Transaction @cacheControl(maxAge: 86400)
+
+    # Get list of Transactions with at most <count> edges.
+    # If <count> is positive, return edges after the cursor,
+    # if negative, return edges before the cursor.
+    # For undefined cursor, positive <count> starts the list from top,
+    # negative <count> starts the list from bottom.
+    transactions(cursor:Cursor, count:Int!):TransactionList! @cacheControl(maxAge: 1)
+
+    # Get list of native token transfers of at least <minValue> WEI with at most <count> edges.
+    # The paging works the same way as on the transactions list.
+    largeTransfers(minValue: BigInt!, cursor: Cursor, count: Int!): TransactionList! @cacheControl(maxAge: 5)
+
+    # Get list of transfers of the given ERC20 token of at least <minValue>
+    # in the smallest units of the token with at most <count> edges.
+    largeErc20Transfers(token: Address!, minValue: BigInt!, cursor: Cursor, count: Int!): ERC20TransactionList! @cacheControl(maxAge: 5)
+
+    # Get the id of the current epoch of the Opera blockchain.
+    currentEpoch:Long! @cacheControl(maxAge: 5)
+
+    # Get information about specified epoch. Returns current epoch information
+    # if id is not provided.
+    epoch(id: Long): Epoch! @cacheControl(maxAge: 86400)
+
+    # Get a scrollable list of epochs sorted from the last one back by default.
+    epochs(cursor: Cursor, count: Int = 25): EpochList! @cacheControl(maxAge: 60)
+
+    # The last staker id in Opera blockchain.
+    lastStakerId: Long! @cacheControl(maxAge: 60)
+
+    # The number of stakers in Opera blockchain.
+    stakersNum: Long! @cacheControl(maxAge: 60)
+
+    # Staker information. The staker is loaded either by numeric ID,
+    # or by address. null if none is provided.
+    staker(id: BigInt, address: Address): Staker @cacheControl(maxAge: 60)
+
+    # List of staker information from SFC smart contract.
+    # The list is served from a periodically refreshed snapshot.
+    # Stakers can be ordered by "stake", "uptime", "apr", or "commission"
+    # in either "DESC", or "ASC" direction. Cursor is the ID of the last
+    # staker received on the previous page; all the remaining stakers
+    # are provided if count is omitted.
+    stakers(orderBy: String = "stake", direction: String = "DESC", onlyActive: Boolean = false, cursor: Cursor, count: Int): [Staker!]! @cacheControl(maxAge: 60)
+
+    # The list of delegations for the given staker ID.
+    # Cursor is used to obtain specific slice of the staker's delegations.
+    # The most recent delegations are provided if cursor is omitted.
+    delegationsOf(staker:BigInt!, cursor: Cursor, count: Int = 25): DelegationList!
+
+    # Get the details of a specific delegation by it's delegator address
+    # and staker the delegation belongs to.
+    delegation(address:Address!, staker: BigInt!): Delegation
+
+    # Get the list of all delegations by it's delegator address.
+    delegationsByAddress(address:Address!, cursor: Cursor, count: Int = 25): DelegationList!
+
+    # Get aggregated summary of all delegations of the given delegator address
+    # including per-validator breakdown of the portfolio.
+    delegationsSummary(address:Address!): DelegationsSummary!
+
+    # Get aggregated staking statistics of the network for dashboards.
+    # The statistics are periodically refreshed by the API server.
+    stakingStats: StakingStats! @cacheControl(maxAge: 60)
+
+    # Get the current state of the native FTM token supply
+    # with the per-epoch history of minted and burned tokens.
+    ftmSupply: FtmSupply! @cacheControl(maxAge: 60)
+
+    # Returns the current price per gas in WEI units.
+    gasPrice: Long! @cacheControl(maxAge: 5)
+
+    # estimateGas returns the estimated amount of gas required
+    # for the transaction described by the parameters of the call.
+    estimateGas(from: Address, to: Address, value: BigInt, data: String): Long
+
+    # verifySignature verifies the signature of a message for the given signer address.
+    # The message is either a plain text signed using EIP-191 personal_sign,
+    # or a JSON encoded EIP-712 typed data structure if typedData is set.
+    # Contract wallets are verified using EIP-1271 isValidSignature call.
+    verifySignature(address: Address!, message: String!, signature: Bytes!, typedData: Boolean = false): SignatureVerification!
+
+    # dashboard provides a snapshot of the network state bundling the content
+    # of the explorer homepage in one round-trip. The FTM price is provided
+    # in the given target symbol.
+    dashboard(to: String = "USD"): Dashboard! @cacheControl(maxAge: 1)
+
+    # Get price details of the Opera blockchain token for the given target symbols.
+    price(to:String!):Price! @cacheControl(maxAge: 60)
+
+    # Get the price of the Opera blockchain token in the given target symbol at the given
+    # UNIX time, interpolated from the recorded price history.
+    # Null if the price history does not reach the given time.
+    priceAt(to:String!, time:Long!):Float @cacheControl(maxAge: 300)
+
+    # Get calculated staking rewards for an account or given
+    # staking amount in FTM tokens.
+    # At least one of the address and amount parameters must be provided.
+    # If you provide both, the address takes precedence and the amount is ignored.
+    estimateRewards(address:Address, amount:Long):EstimatedRewards!
+
+    # Get projected balance of the delegation of the address to the given staker
+    # with rewards re-staked every <restakeFrequency> days within <periodDays> days.
+    # Rewards are estimated at the current reward rate the same way
+    # the estimateRewards does, validator commission is not included.
+    estimateCompounding(address:Address!, staker:BigInt!, periodDays:Int!, restakeFrequency:Int!):CompoundingEstimation!
+
+    # defiConfiguration exposes the current DeFi contract setup.
+    defiConfiguration:DefiSettings! @feature(name: "defi")
+    # defiTokens represents a list of all available DeFi tokens.
+    defiTokens:[DefiToken!]! @feature(name: "defi")
+    # oracleFeeds represents the on-chain price feeds of all the active DeFi tokens
+    # the fMint protocol uses to value the tokens.
+    oracleFeeds: [OracleFeed!]! @feature(name: "defi")
+    # oraclePrice provides the on-chain price feed of the DeFi token with the given symbol.
+    # Null if there is no such token.
+    oraclePrice(symbol: String!): OracleFeed @feature(name: "defi")
+    # defiNativeToken represents the information about the native token
+    # wrapper ERC20 contract. Returns NULL if the native token wraper
+    # is not available.
+    defiNativeToken: ERC20Token @feature(name: "defi")
+    # wftmAddress represents the address of the wrapped FTM token contract
+    # configured on the API server. The wrapped FTM is treated as the native FTM
+    # in value calculations of the API, i.e. the account total value.
+    wftmAddress: Address!
+
+    # fMintAccount provides DeFi/fMint information about an account on fMint protocol.
+    fMintAccount(owner: Address!):FMintAccount! @feature(name: "defi")
+    # fMintStats provides the latest snapshot of the protocol level fMint metrics,
+    # i.e. total collateral, minted tokens, and accounts at risk.
+    # Null if the metrics have not been aggregated yet.
+    fMintStats: FMintStats @feature(name: "defi")
+    # fMintAccountsAtRisk provides the list of fMint accounts with a debt
+    # and the collateral ratio below the given max ratio, i.e. 3.0 means 300%,
+    # sorted from the lowest ratio. The configured warning ratio is used if the max ratio
+    # is not given. The account states are refreshed when the on-chain prices change.
+    # The list is scrolled forward only, the count must be positive.
+    fMintAccountsAtRisk(maxRatio: Float, cursor: Cursor, count: Int = 25): FMintAccountAtRiskList! @feature(name: "defi")
+    # fMintTokenAllowance resolves the amount of ERC20 tokens unlocked
+    # by the token owner for DeFi/fMint operations.
+    fMintTokenAllowance(owner: Address!, token: Address!):BigInt! @feature(name: "defi")
+    # defiUniswapPairs represents a list of all pairs managed
+    # by the Uniswap Core contract on Opera blockchain.
+    defiUniswapPairs: [UniswapPair!]! @feature(name: "defi")
+    # uniswapPositions provides a list of liquidity positions the given owner
+    # holds on Uniswap pairs, based on the liquidity added and removed by the owner.
+    uniswapPositions(owner: Address!): [UniswapPosition!]! @feature(name: "defi")
+    # defiUniswapAmountsOut calculates the expected output amounts
+    # required to finalize a swap operation specified by a list of
+    # tokens involved in the swap steps and the input amount.
+    # At least two addresses of tokens must be given
+    # for the calculation to succeed.
+    defiUniswapAmountsOut(amountIn: BigInt!, tokens:[Address!]!): [BigInt!]! @feature(name: "defi")
+    # defiUniswapAmountsIn calculates the expected input amounts
+    # required to finalize a swap operation specified by a list of
+    # tokens involved in the swap steps and the output amount.
+    # At least two addresses of tokens must be given
+    # for the calculation to succeed.
+    defiUniswapAmountsIn(amountOut: BigInt!, tokens:[Address!]!): [BigInt!]! @feature(name: "defi")
+    # defiUniswapQuoteOut calculates the quote of a swap operation
+    # with the given input amount over the path of tokens. Besides the amounts
+    # on the path, it provides the price impact of the swap and the minimal
+    # output amount with the slippage tolerance applied, i.e. 0.005 means 0.5%.
+    defiUniswapQuoteOut(amountIn: BigInt!, tokens:[Address!]!, slippage: Float = 0.005): UniswapSwapQuote! @feature(name: "defi")
+    # defiUniswapQuoteIn calculates the quote of a swap operation
+    # with the given output amount over the path of tokens. Besides the amounts
+    # on the path, it provides the price impact of the swap and the maximal
+    # input amount with the slippage tolerance applied, i.e. 0.005 means 0.5%.
+    defiUniswapQuoteIn(amountOut: BigInt!, tokens:[Address!]!, slippage: Float = 0.005): UniswapSwapQuote! @feature(name: "defi")
+    # defiUniswapBestRoute searches the known Uniswap pairs for the swap route
+    # providing the highest output amount for the given input amount.
+    # The route is limited to <maxHops> swap steps, at most 4.
+    # Null is returned if the tokens are not connected by any route.
+    defiUniswapBestRoute(tokenIn: Address!, tokenOut: Address!, amountIn: BigInt!, maxHops: Int = 3): UniswapRoute @feature(name: "defi")
+    # defiUniswapQuoteLiquidity calculates optimal amount of tokens
+    # of an Uniswap pair defined by a pair of tokens for the given amount
+    # of both tokens desired to be added to the liquidity pool.
+    # The function can be used to calculate minimal amount of tokens expected
+    # to be added to the pool on both sides on addLiquidity call.
+    # Please note "amountsIn" must be in the same order as are the tokens.
+    defiUniswapQuoteLiquidity(tokens:[Address!]!, amountsIn:[BigInt!]!): [BigInt!]! @feature(name: "defi")
+    # defiUniswapVolumes represents a list of pairs and their historical values
+    # of traded volumes
+    defiUniswapVolumes:[DefiUniswapVolume!]! @feature(name: "defi")
+    # defiTimeVolumes returns volumes for specified pair, time resolution and interval.
+    # Address is pair address and is mandatory.
+    # Resolution can be {month, day, 4h, 1h, 30m 15m, 5m, 1m}, is optional, default is a day.
+    # Dates are in unix UTC number and are optional. When not provided
+    # then it takes period for last month till now.
+    defiTimeVolumes(address:Address!, resolution:String, fromDate:Int, toDate:Int):[DefiTimeVolume!]! @cacheControl(maxAge: 300) @feature(name: "defi")
+    # defiTimePrices returns prices for specified pair, time resolution and interval.
+    # Address is pair address and is mandatory.
+    # Resolution can be {month, day, 4h, 1h, 30m 15m, 5m, 1m}, is optional, default is a day.
+    # Direction specifies price calculation, default 0 is for TokenA/TokenB otherwise TokenB/TokenA
+    # Dates are in unix UTC number and are optional. When not provided
+    # then it takes period for last month till now.
+    defiTimePrices(address:Address!, resolution:String, fromDate:Int, toDate:Int, direction:Int):[DefiTimePrice!]! @cacheControl(maxAge: 300) @feature(name: "defi")
+    # defiTimeReserves returns reserves for specified pair, time resolution and interval.
+    # Address is pair address and is mandatory.
+    # Resolution can be {month, day, 4h, 1h, 30m 15m, 5m, 1m}, is optional, default is a day.
+    # Dates are in unix UTC number and are optional. When not provided
+    # then it takes period for last month till now.
+    defiTimeReserves(address:Address!, resolution:String, fromDate:Int, toDate:Int):[DefiTimeReserve!]! @cacheControl(maxAge: 300) @feature(name: "defi")
+    # Get list of Uniswap actions with at most <count> edges.
+    # If <count> is positive, return edges after the cursor,
+    # if negative, return edges before the cursor.
+    # For undefined cursor, positive <count> starts the list from top,
     # negative <count> starts the list from bottom.
     # Address can be used for specifying actions for one Uniswap pair.
     # ActionType represents action type:
     # 0 - swap,
     # 1 - mint,
     # 2 - burn,
-    defiUniswapActions(pairAddress:Address, cursor:Cursor, count:Int!, actionType:Int):UniswapActionList!
-
+    defiUniswapActions(pairAddress:Address, cursor:Cursor, count:Int!, actionType:Int):UniswapActionList! @feature(name: "defi")
     # erc20Token provides the information about an ERC20 token specified by it's
     # address, if available. The resolver returns NULL if the token does not exist.
-    erc20Token(token: Address!):ERC20Token
-
+    erc20Token(token: Address!):ERC20Token @feature(name: "erc")
     # erc20TokenList provides list of the most active ERC20 tokens
     # deployed on the block chain.
-    erc20TokenList(count: Int = 50):[ERC20Token!]!
-
+    erc20TokenList(count: Int = 50):[ERC20Token!]! @feature(name: "erc")
     # erc20Assets provides list of tokens owned by the given
     # account address.
-    erc20Assets(owner: Address!, count: Int = 50):[ERC20Token!]!
-
+    erc20Assets(owner: Address!, count: Int = 50):[ERC20Token!]! @feature(name: "erc")
+    # erc20Approvals provides list of allowances granted by the given owner
+    # on ERC20 tokens with their current value, so the owner can see
+    # which contracts are able to move the tokens. Allowances already used up,
+    # or revoked are skipped unless activeOnly is set to false.
+    erc20Approvals(owner: Address!, activeOnly: Boolean = true):[ERC20Approval!]! @feature(name: "erc")
     # ercTotalSupply provides the current total supply amount of a specified ERC20 token
     # identified by it's ERC20 contract address.
-    ercTotalSupply(token: Address!):BigInt!
-
+    ercTotalSupply(token: Address!):BigInt! @feature(name: "erc")
     # ercTokenBalance provides the current available balance of a specified ERC20 token
     # identified by it's ERC20 contract address.
-    ercTokenBalance(owner: Address!, token: Address!):BigInt!
-
+    ercTokenBalance(owner: Address!, token: Address!):BigInt! @feature(name: "erc")
     # ercTokenAllowance provides the current amount of ERC20 tokens unlocked
     # by the token owner for the spender to be manipulated with.
-    ercTokenAllowance(token: Address!, owner: Address!, spender: Address!):BigInt!
-
+    ercTokenAllowance(token: Address!, owner: Address!, spender: Address!):BigInt! @feature(name: "erc")
+    # ballots provides list of configured community ballots.
+    ballots:[Ballot!]! @feature(name: "governance")
+    # ballot provides a specific community ballot by its address.
+    ballot(address: Address!): Ballot @feature(name: "governance")
     # govContracts provides list of governance contracts.
-    govContracts:[GovernanceContract!]!
-
+    govContracts:[GovernanceContract!]! @feature(name: "governance")
     # govContract provides a specific Governance contract information by its address.
-    govContract(address: Address!): GovernanceContract
-
+    govContract(address: Address!): GovernanceContract @feature(name: "governance")
+    # govVotingPower provides the voting weight of an address in the given governance
+    # contract at a past point, based on the stake at the snapshot. The point is given
+    # either by the block number, or by the epoch, in which case the state at the end
+    # of the epoch is used. The current voting weight is provided if neither is set.
+    govVotingPower(address: Address!, contract: Address!, block: Long, epoch: Long): BigInt! @feature(name: "governance")
     # govProposals represents list of joined proposals across all the Governance contracts.
-    govProposals(cursor:Cursor, count:Int!, activeOnly: Boolean = false):GovernanceProposalList!
-
+    govProposals(cursor:Cursor, count:Int!, activeOnly: Boolean = false):GovernanceProposalList! @feature(name: "governance")
     # fLendLendingPool represents an instance of an fLend Lending pool
-    fLendLendingPool: LendingPool!
-
+    fLendLendingPool: LendingPool! @feature(name: "defi")
     # trxVolume provides a list of daily aggregations of the network transaction flow.
     # If boundaries are not defined, last 90 days of aggregated trx flow is provided.
     # Boundaries are defined in format YYYY-MM-DD, i.e. 2021-01-23 for January 23rd, 2021.
-    trxVolume(from:String, to:String):[DailyTrxVolume!]!
+    trxVolume(from:String, to:String):[DailyTrxVolume!]! @cacheControl(maxAge: 300)
 
     # trxSpeed provides the recent speed of the network
     # as number of transactions processed per second
@@ -1926,23 +3878,108 @@ type Query {
 type Mutation {
     # SendTransaction submits a raw signed transaction into the block chain.
     # The tx parameter represents raw signed and RLP encoded transaction data.
+    # The mutation is rejected with the "maintenance" error if the API server
+    # is in maintenance mode.
     sendTransaction(tx: Bytes!):Transaction
 
+    # SendTransactionBatch submits a list of raw signed transactions into the block chain
+    # in the given order and returns the result of each of them. Transactions of each sender
+    # must form a continuous sequence of nonces following the sender's pending nonce;
+    # a transaction breaking the sequence, or following a failed transaction
+    # of the same sender, is not submitted. Up to 100 transactions are accepted
+    # in a batch. The mutation is rejected with the "maintenance" error
+    # if the API server is in maintenance mode.
+    sendTransactionBatch(txs: [Bytes!]!):[TransactionSubmission!]!
+
     # Validate a deployed contract byte code with the provided source code
     # so potential users can check the contract source code, access contract ABI
     # to be able to interact with the contract and get the right metadata.
     # Returns updated contract information. If the contract can not be validated,
-    # it raises a GraphQL error.
+    # it raises a GraphQL error. The mutation is rejected with the "maintenance"
+    # error if the API server is in maintenance mode.
     validateContract(contract: ContractValidationInput!): Contract!
+
+    # Copy the source code validation of the contract at the given address
+    # to not validated contracts deployed with identical runtime byte code.
+    # Returns the number of contracts updated. The mutation is rejected with
+    # the "maintenance" error if the API server is in maintenance mode.
+    propagateContractValidation(address: Address!): Int!
+
+    # setAddressLabel assigns a public label to an address, replacing any previous one.
+    # The mutation requires a privileged API key sent in the X-Api-Key header.
+    setAddressLabel(label: AddressLabelInput!): AddressLabel!
+
+    # removeAddressLabel removes the public label of an address.
+    # The mutation requires a privileged API key sent in the X-Api-Key header.
+    removeAddressLabel(address: Address!): Boolean!
+
+    # addToWatchlist adds an address to the watch list of the API client,
+    # or updates its label if the address is already watched.
+    # The mutation requires a registered client API key sent in the X-Api-Key header.
+    addToWatchlist(address: Address!, label: String): WatchedAddress!
+
+    # removeFromWatchlist removes an address from the watch list of the API client.
+    # Returns false if the address was not watched.
+    # The mutation requires a registered client API key sent in the X-Api-Key header.
+    removeFromWatchlist(address: Address!): Boolean!
+
+    # addAlertRule registers a new alert rule of the API client evaluated on new blocks.
+    # BALANCE_BELOW rules require the threshold in WEI units, EVENT_EMITTED rules
+    # may be limited to an event signature topic. Alerts are posted to the optional
+    # HTTPS webhook and are available in the alerts query. A client can have up to 25 rules.
+    # The mutation requires a registered client API key sent in the X-Api-Key header.
+    addAlertRule(type: AlertRuleType!, address: Address!, threshold: BigInt, topic: Bytes32, webhook: String): AlertRule!
+
+    # removeAlertRule removes an alert rule of the API client.
+    # Returns false if the rule was not found.
+    # The mutation requires a registered client API key sent in the X-Api-Key header.
+    removeAlertRule(id: String!): Boolean!
+
+    # admin provides the namespace of privileged operations.
+    # It requires a privileged API key sent in the X-Api-Key header.
+    admin: AdminMutation!
 }
 
 # Subscriptions to live events broadcasting
 type Subscription {
     # Subscribe to receive information about new blocks in the blockchain.
-    onBlock: Block!
+    # A client reconnecting after a drop may provide the last block received
+    # in <sinceBlock>; the blocks following it are replayed first, up to 1000 blocks back.
+    onBlock(sinceBlock: Long): Block!
+
+    # Subscribe to receive the lightweight headers of new blocks, e.g. for a block ticker.
+    # The full block is available through the block(number) query.
+    # The <sinceBlock> resumes the stream the same way as on the onBlock subscription.
+    onBlockHeader(sinceBlock: Long): BlockHeader!
 
     # Subscribe to receive information about new transactions in the blockchain.
-    onTransaction: Transaction!
+    # A client reconnecting after a drop may provide the last block received
+    # in <sinceBlock>; the transactions of the blocks following it are replayed first.
+    onTransaction(sinceBlock: Long): Transaction!
+
+    # Subscribe to receive information about new sealed epochs
+    # of the blockchain, e.g. to refresh staking rewards.
+    onEpoch: Epoch!
+
+    # Subscribe to receive information about new pairs
+    # created by the Uniswap factory, e.g. to start tracking new pools.
+    onUniswapPairCreated: UniswapPairCreated! @feature(name: "defi")
+    # Subscribe to receive notifications about the collateral to debt ratio
+    # of an fMint account crossing the given threshold, i.e. threshold 3.0 means 300%.
+    # The ratio is evaluated on each new block, so both price and position updates
+    # are reflected. The current state is sent first, any following event
+    # means the ratio crossed the threshold.
+    onFMintHealth(owner: Address!, threshold: Float!): FMintHealth! @feature(name: "defi")
+    # Subscribe to receive results of a read only query re-evaluated
+    # on every <interval> new blocks. The query may use any read endpoint
+    # of the API, variables are passed as a JSON encoded object.
+    # A result is pushed only if it differs from the previous one,
+    # the first evaluation always pushes the current result.
+    onQueryResult(query: String!, variables: String, interval: Int = 1): QueryResult!
+
+    # Subscribe to receive transfers of at least <minValue> of native tokens,
+    # or of the given ERC20 token in its smallest units, e.g. for whale monitoring.
+    onLargeTransfer(minValue: BigInt!, token: Address): LargeTransfer!
 }
 
 `
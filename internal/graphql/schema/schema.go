@@ -2,9 +2,175 @@
 // to validate requests and build responses on the API interface.
 package gqlschema
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
 //go:generate sh ./tools/make_bundle.sh
 
+// federationDefinitions is the list of patterns matching federation specific
+// definitions, which are not part of the subgraph SDL presented to a gateway.
+var federationDefinitions = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^# Apollo Federation support definitions.*\n(#.*\n)*`),
+	regexp.MustCompile(`(?m)^(#.*\n)*(scalar|union) _\w+.*\n`),
+	regexp.MustCompile(`(?m)^(#.*\n)*directive @(key|external|requires|provides|extends)\b.*\n`),
+	regexp.MustCompile(`(?m)^(#.*\n)*type _Service \{[^}]*}\n`),
+	regexp.MustCompile(`(?m)^([ \t]*#.*\n)*[ \t]*_(service|entities)\b.*\n\n?`),
+}
+
+// cacheControlHint matches the cache control directive of a field definition.
+var cacheControlHint = regexp.MustCompile(`@cacheControl\(maxAge:\s*(\d+)\)`)
+
+// compositeDefinition matches the definition of a type with fields, or an union.
+var compositeDefinition = regexp.MustCompile(`(?m)^(type|interface|union)\s+(\w+)`)
+
+// fieldDefinition matches the definition of a type field with its return type name.
+var fieldDefinition = regexp.MustCompile(`^[ \t]+(\w+)\s*(\([^)]*\))?\s*:\s*[\[\s]*(\w+)`)
+
+// rootTypes is the list of the root operation types of the schema.
+var rootTypes = map[string]bool{"Query": true, "Mutation": true, "Subscription": true}
+
+// mutationsFeature is the name of the feature group covering all the mutations.
+const mutationsFeature = "mutations"
+
+// mutationDefinitions is the list of patterns matching the mutation root type
+// and its reference in the root schema definition.
+var mutationDefinitions = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^(#.*\n)*type Mutation \{[^}]*}\n\n?`),
+	regexp.MustCompile(`(?m)^[ \t]*mutation\s*:\s*Mutation\s*\n`),
+}
+
+// active holds the schema content served by the API,
+// with the disabled feature groups removed.
+var active = schema
+
+// cacheHints holds the map of type fields to their cache max age once it's been built.
+var cacheHints struct {
+	once  sync.Once
+	hints map[string]int32
+}
+
+// federationSDL holds the subgraph SDL once it's been built.
+var federationSDL struct {
+	once sync.Once
+	sdl  string
+}
+
+// schemaVersion holds the version of the schema once it's been calculated.
+var schemaVersion struct {
+	once    sync.Once
+	version string
+}
+
 // Schema provides textual representation of the GraphQL schema content.
 func Schema() string {
-	return schema
+	return active
+}
+
+// Disable removes the fields of the given feature groups from the schema
+// served by the API. It must be called before the schema is used.
+func Disable(groups []string) {
+	active = Filtered(groups)
+}
+
+// Version provides the version of the schema content. The version is derived
+// from the schema itself, so clients generated from the same schema share it.
+func Version() string {
+	schemaVersion.once.Do(func() {
+		hash := sha256.Sum256([]byte(active))
+		schemaVersion.version = hex.EncodeToString(hash[:6])
+	})
+	return schemaVersion.version
+}
+
+// FederationSDL provides textual representation of the GraphQL schema
+// content as an Apollo Federation subgraph; the federation specific
+// definitions are stripped from the schema.
+func FederationSDL() string {
+	federationSDL.once.Do(func() {
+		sdl := active
+		for _, re := range federationDefinitions {
+			sdl = re.ReplaceAllString(sdl, "")
+		}
+		federationSDL.sdl = sdl
+	})
+	return federationSDL.sdl
+}
+
+// Filtered provides textual representation of the GraphQL schema content
+// with the fields of the given disabled feature groups removed.
+// Disabling the mutations group removes the mutation root type.
+func Filtered(disabled []string) string {
+	sdl := schema
+	for _, name := range disabled {
+		if name == mutationsFeature {
+			for _, re := range mutationDefinitions {
+				sdl = re.ReplaceAllString(sdl, "")
+			}
+			continue
+		}
+
+		re := regexp.MustCompile(fmt.Sprintf(`(?m)^([ \t]*#.*\n)*[ \t]+\w+\b[^\n#]*@feature\(name:\s*"%s"\)[^\n]*\n\n?`, regexp.QuoteMeta(name)))
+		sdl = re.ReplaceAllString(sdl, "")
+	}
+	return sdl
+}
+
+// CacheHints provides the max age in seconds of the schema fields keyed by the type
+// and the field name, i.e. "Query.block". Fields annotated with the cache control
+// directive use the annotated max age. Root fields and fields of object types
+// without the annotation are not cacheable and have zero max age. Other fields
+// are not listed, they inherit the max age of their parent field.
+func CacheHints() map[string]int32 {
+	cacheHints.once.Do(func() {
+		cacheHints.hints = cacheHintsOf(active)
+	})
+	return cacheHints.hints
+}
+
+// cacheHintsOf builds the map of cache hints of the given schema definition.
+func cacheHintsOf(sdl string) map[string]int32 {
+	composite := make(map[string]bool)
+	for _, m := range compositeDefinition.FindAllStringSubmatch(sdl, -1) {
+		composite[m[2]] = true
+	}
+
+	hints := make(map[string]int32)
+	var typeName string
+	for _, line := range strings.Split(sdl, "\n") {
+		// entering, or leaving a type definition?
+		if m := compositeDefinition.FindStringSubmatch(line); m != nil && strings.HasSuffix(strings.TrimSpace(line), "{") {
+			typeName = m[2]
+			continue
+		}
+		if strings.HasPrefix(line, "}") {
+			typeName = ""
+			continue
+		}
+
+		// skip comments and fields of inputs
+		m := fieldDefinition.FindStringSubmatch(line)
+		if typeName == "" || m == nil {
+			continue
+		}
+
+		key := typeName + "." + m[1]
+		if h := cacheControlHint.FindStringSubmatch(line); h != nil {
+			if age, err := strconv.ParseInt(h[1], 10, 32); err == nil {
+				hints[key] = int32(age)
+				continue
+			}
+		}
+
+		if rootTypes[typeName] || composite[m[3]] {
+			hints[key] = 0
+		}
+	}
+	return hints
 }
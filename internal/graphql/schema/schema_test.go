@@ -3,6 +3,7 @@
 package gqlschema
 
 import (
+	"github.com/graph-gophers/graphql-go"
 	"github.com/onsi/gomega"
 	"testing"
 )
@@ -36,11 +37,11 @@ var expectedContent = []bundleContentTest{
 		msg: "current state type must exists",
 	},
 	{
-		re:  "(?m)^type\\s+Account\\s+{",
+		re:  "(?m)^type\\s+Account\\s+(@key\\(.+\\)\\s+)?{",
 		msg: "account detail type must exists",
 	},
 	{
-		re:  "(?m)^type\\s+Block\\s+{",
+		re:  "(?m)^type\\s+Block\\s+(@key\\(.+\\)\\s+)?{",
 		msg: "block detail type must exists",
 	},
 	{
@@ -56,7 +57,7 @@ var expectedContent = []bundleContentTest{
 		msg: "SFC delegation detail type must exists",
 	},
 	{
-		re:  "(?m)^type\\s+ERC20Token\\s+{",
+		re:  "(?m)^type\\s+ERC20Token\\s+(@key\\(.+\\)\\s+)?{",
 		msg: "ERC20 token detail type must exists",
 	},
 	{
@@ -87,6 +88,10 @@ var expectedContent = []bundleContentTest{
 		re:  "(?m)^type\\s+GovernanceContract\\s+{",
 		msg: "governance contract type must exists",
 	},
+	{
+		re:  "(?m)^union\\s+_Entity\\s+=",
+		msg: "federation entity union must exists",
+	},
 }
 
 // TestBundleContent tests if the bundle contains all the expected elements
@@ -100,3 +105,32 @@ func TestBundleContent(t *testing.T) {
 		g.Expect(s).To(gomega.MatchRegexp(c.re))
 	}
 }
+
+// TestFiltered tests if disabled feature groups are removed from the schema
+// and the remaining schema is still valid.
+func TestFiltered(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := Filtered([]string{"defi", "governance", "erc", "mutations"})
+
+	g.Expect(s).NotTo(gomega.MatchRegexp(`@feature\(name:\s*"`))
+	g.Expect(s).NotTo(gomega.MatchRegexp(`(?m)^type\s+Mutation\s+{`))
+	g.Expect(s).NotTo(gomega.MatchRegexp(`(?m)^\s+mutation\s*:`))
+	g.Expect(s).To(gomega.MatchRegexp(`(?m)^\s+account\(address:Address!\)`))
+
+	_, err := graphql.ParseSchema(s, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+}
+
+// TestCacheHints tests if the cache hints are keyed by the type field and unhinted
+// root and object typed fields are not cacheable.
+func TestCacheHints(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	h := CacheHints()
+
+	g.Expect(h).To(gomega.HaveKeyWithValue("Query.block", int32(86400)))
+	g.Expect(h).To(gomega.HaveKeyWithValue("Query.version", int32(0)))
+	g.Expect(h).To(gomega.HaveKeyWithValue("Account.balance", int32(1)))
+	g.Expect(h).To(gomega.HaveKeyWithValue("Transaction.block", int32(0)))
+	g.Expect(h).NotTo(gomega.HaveKey("Block.number"))
+	g.Expect(h).NotTo(gomega.HaveKey("block"))
+}
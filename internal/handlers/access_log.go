@@ -0,0 +1,127 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	flogger "fantom-api-graphql/internal/logger"
+	"fmt"
+	"github.com/graph-gophers/graphql-go"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ctxKeyAccessRecord is the context key of the access log record of the request.
+type ctxKeyAccessRecord struct{}
+
+// accessRecord collects details of GraphQL operations executed by a single request.
+type accessRecord struct {
+	mu  sync.Mutex
+	ops []string
+}
+
+// AccessLogHandler defines HTTP handler middleware for logging details of API requests.
+// Requests slower than the configured threshold are always logged, the rest is sampled.
+type AccessLogHandler struct {
+	logger  flogger.Logger
+	cfg     *config.AccessLog
+	handler http.Handler
+}
+
+// ServeHTTP handles incoming request by measuring its duration and logging
+// the collected request details, if the request is slow, or sampled.
+func (h *AccessLogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	// pass request down the chain with an empty access record
+	rec := new(accessRecord)
+	h.handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKeyAccessRecord{}, rec)))
+	dur := time.Since(start)
+
+	// slow requests are always logged
+	if h.cfg.SlowThreshold > 0 && dur >= h.cfg.SlowThreshold {
+		h.logger.Warningf("slow request %s from %s; %s", dur.String(), clientIdentity(r), rec.String())
+		return
+	}
+
+	// is this request sampled?
+	if h.cfg.SampleRate > 0 && rand.Float64() < h.cfg.SampleRate {
+		h.logger.Infof("request %s from %s; %s", dur.String(), clientIdentity(r), rec.String())
+	}
+}
+
+// recordAccess adds the executed GraphQL operation to the access record of the request.
+func recordAccess(ctx context.Context, req *gqlRequest, res *graphql.Response) {
+	rec, ok := ctx.Value(ctxKeyAccessRecord{}).(*accessRecord)
+	if !ok {
+		return
+	}
+
+	// operation name
+	name := req.OperationName
+	if name == "" {
+		name = "anonymous"
+	}
+
+	// variables are hashed so they can be correlated without being exposed
+	vars := "-"
+	if len(req.Variables) > 0 {
+		if data, err := json.Marshal(req.Variables); err == nil {
+			hash := sha256.Sum256(data)
+			vars = hex.EncodeToString(hash[:8])
+		}
+	}
+
+	// error codes of failed resolvers
+	codes := make([]string, 0, len(res.Errors))
+	for _, e := range res.Errors {
+		code, ok := e.Extensions["code"].(string)
+		if !ok {
+			code = "ERROR"
+		}
+		codes = append(codes, code)
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.ops = append(rec.ops, fmt.Sprintf("%s vars:%s errors:[%s]", name, vars, strings.Join(codes, ",")))
+}
+
+// String formats the access record for the log.
+func (rec *accessRecord) String() string {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if len(rec.ops) == 0 {
+		return "no operation"
+	}
+	return strings.Join(rec.ops, "; ")
+}
+
+// clientIdentity provides the identity of the client for the access log.
+// The API key is never logged, only its short fingerprint.
+func clientIdentity(r *http.Request) string {
+	// the client address; we may be behind a proxy
+	addr := r.Header.Get("X-Forwarded-For")
+	if addr != "" {
+		addr = strings.TrimSpace(strings.Split(addr, ",")[0])
+	} else if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		addr = host
+	} else {
+		addr = r.RemoteAddr
+	}
+
+	// fingerprint of the API key
+	if key := requestApiKey(r); key != "" {
+		hash := sha256.Sum256([]byte(key))
+		return fmt.Sprintf("%s key:%s (%s)", addr, hex.EncodeToString(hash[:4]), r.UserAgent())
+	}
+	return fmt.Sprintf("%s (%s)", addr, r.UserAgent())
+}
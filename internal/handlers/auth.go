@@ -0,0 +1,44 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"net/http"
+	"strings"
+)
+
+// apiKeyHeader is the name of the HTTP header carrying the client API key.
+const apiKeyHeader = "X-Api-Key"
+
+// AuthHandler defines HTTP handler middleware for extracting client API key
+// from the incoming request so resolvers can authorize privileged operations.
+type AuthHandler struct {
+	handler http.Handler
+}
+
+// ServeHTTP handles incoming request by extracting the API key into the request context
+// and passing it to the next handler in the chain.
+func (h *AuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// do we have the key?
+	if key := requestApiKey(r); key != "" {
+		r = r.WithContext(resolvers.ContextWithApiKey(r.Context(), key))
+	}
+
+	// pass request down the chain
+	h.handler.ServeHTTP(w, r)
+}
+
+// requestApiKey extracts the API key from the request headers. The key can be sent
+// either in the X-Api-Key header, or as a Bearer token in the Authorization header.
+func requestApiKey(r *http.Request) string {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return key
+	}
+
+	// try the authorization header
+	auth := r.Header.Get("Authorization")
+	if strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return ""
+}
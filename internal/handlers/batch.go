@@ -0,0 +1,259 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// batchMaxOperations represents the max number of operations accepted in a single batch request.
+const batchMaxOperations = 20
+
+// gqlRequest represents a single GraphQL operation sent over HTTP.
+type gqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// BatchHandler defines HTTP handler executing GraphQL operations. It accepts
+// either a single operation, or an array of operations with an array of results
+// returned in the same order. Queries of the batch are executed concurrently,
+// mutations are executed one by one in the order of the batch.
+// Responses are marked cacheable using the schema field cache hints.
+// Expensive operations are rejected while the backends are overloaded.
+// Operations running over the timeout return partial results,
+// the database operations and the node calls still running are cancelled.
+// While the API server is in maintenance, mutations are rejected and reads
+// are served from the cache and the database without calling the node.
+// The usage of each request is accounted to the client origin.
+type BatchHandler struct {
+	rs      resolvers.ApiResolver
+	schema  *graphql.Schema
+	hints   map[string]int32
+	shed    *loadShedder
+	timeout time.Duration
+	auth    *config.Auth
+	log     logger.Logger
+}
+
+// ServeHTTP handles incoming GraphQL request.
+func (h *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	// read the request body
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// is this a batch?
+	var res interface{}
+	var age int32
+	ops := 1
+	if trimmed := bytes.TrimLeft(body, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		var list []*graphql.Response
+		list, age, err = h.batch(r, trimmed)
+		res, ops = list, len(list)
+	} else {
+		res, age, err = h.single(r, body)
+	}
+
+	// any error on input?
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// encode the response
+	data, err := json.Marshal(res)
+	if err != nil {
+		h.log.Errorf("can not encode GraphQL response; %s", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	writeCacheHeaders(w, r, age)
+	if _, err := w.Write(data); err != nil {
+		h.log.Errorf("can not write GraphQL response; %s", err.Error())
+	}
+
+	// account the usage
+	repository.R().RecordUsage(usageOrigin(h.auth, r), ops, len(body)+len(data), time.Since(start))
+}
+
+// single executes a single GraphQL operation.
+// It returns the response and the max age the response can be cached for.
+func (h *BatchHandler) single(r *http.Request, body []byte) (*graphql.Response, int32, error) {
+	var req gqlRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, 0, err
+	}
+
+	res, age := h.exec(r, &req)
+	return res, age, nil
+}
+
+// batch executes a list of GraphQL operations. Queries run concurrently, but
+// a mutation waits for all the preceding operations to finish and the following
+// operations wait for the mutation, so they observe its effects.
+// Objects loaded by the operations are shared within the batch until a mutation runs.
+// The whole batch can be cached for the lowest max age of the operations.
+func (h *BatchHandler) batch(r *http.Request, body []byte) ([]*graphql.Response, int32, error) {
+	var list []gqlRequest
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, 0, err
+	}
+
+	// check the batch size
+	if len(list) == 0 || len(list) > batchMaxOperations {
+		return nil, 0, fmt.Errorf("batch must contain 1 to %d operations", batchMaxOperations)
+	}
+
+	// execute the operations
+	res := make([]*graphql.Response, len(list))
+	ages := make([]int32, len(list))
+	br := r.WithContext(resolvers.ContextWithBatchLoader(r.Context()))
+
+	var wg sync.WaitGroup
+	for i := range list {
+		if _, _, mutation := queryFields(list[i].Query, list[i].OperationName); mutation {
+			wg.Wait()
+			res[i], ages[i] = h.exec(r, &list[i])
+
+			// objects loaded before the mutation may be stale now
+			br = r.WithContext(resolvers.ContextWithBatchLoader(r.Context()))
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, br *http.Request) {
+			defer wg.Done()
+			res[i], ages[i] = h.exec(br, &list[i])
+		}(i, br)
+	}
+	wg.Wait()
+
+	// find the lowest max age
+	age := ages[0]
+	for _, a := range ages[1:] {
+		if a < age {
+			age = a
+		}
+	}
+	return res, age, nil
+}
+
+// exec executes the GraphQL operation and calculates the max age of the response
+// from the static schema hints of the resolved fields and the hints set by resolvers
+// during the execution.
+func (h *BatchHandler) exec(r *http.Request, req *gqlRequest) (*graphql.Response, int32) {
+	// in maintenance, mutations are rejected and reads stay off the node
+	ctx := r.Context()
+	if h.rs.InMaintenance() {
+		if res := rejectMaintenance(req.Query, req.OperationName); res != nil {
+			recordAccess(r.Context(), req, res)
+			return res, 0
+		}
+		ctx = repository.WithoutNode(ctx)
+	}
+
+	if res := h.shed.reject(req.Query, req.OperationName); res != nil {
+		recordAccess(r.Context(), req, res)
+		return res, 0
+	}
+
+	ctx, hint := resolvers.ContextWithCacheHint(ctx)
+	ctx, ca := contextWithCacheAge(ctx, h.hints)
+
+	// fields not resolved by the deadline are reported with the timeout error
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+
+	// collect execution stats if asked for
+	var qs *queryStats
+	if r.Header.Get(queryStatsHeader) != "" {
+		ctx, qs = contextWithQueryStats(ctx)
+	}
+
+	res := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	attachErrorCodes(res, ctx.Err())
+	recordAccess(r.Context(), req, res)
+
+	// responses with stats are specific to the request
+	if qs != nil {
+		if res.Extensions == nil {
+			res.Extensions = make(map[string]interface{}, 1)
+		}
+		res.Extensions["stats"] = qs.report()
+		return res, 0
+	}
+
+	// failed responses are never cached
+	if len(res.Errors) > 0 {
+		return res, 0
+	}
+
+	age := ca.maxAge()
+	if dyn, ok := hint.MaxAge(); ok && dyn < age {
+		age = dyn
+	}
+	return res, age
+}
+
+// rejectMaintenance provides the error response of a mutation operation requested in maintenance,
+// nil if the operation is allowed. Privileged operations are allowed so the maintenance
+// can be switched off.
+func rejectMaintenance(query string, operation string) *graphql.Response {
+	root, _, mutation := queryFields(query, operation)
+	if !mutation {
+		return nil
+	}
+
+	for _, f := range root {
+		if f != "admin" {
+			return &graphql.Response{Errors: []*errors.QueryError{{
+				Message:    resolvers.ErrMaintenance.Error(),
+				Extensions: map[string]interface{}{"code": resolvers.ErrCodeMaintenance},
+			}}}
+		}
+	}
+	return nil
+}
+
+// attachErrorCodes makes sure all the errors of the response carry machine readable code.
+// Errors not raised by resolvers come from the query validation and signal invalid input,
+// or from the execution being stopped by the given context error.
+func attachErrorCodes(res *graphql.Response, ctxErr error) {
+	for _, e := range res.Errors {
+		code := resolvers.ErrCodeInvalidArgument
+		switch {
+		case e.ResolverError != nil:
+			code = resolvers.ErrorCode(e.ResolverError)
+		case ctxErr != nil && e.Message == ctxErr.Error():
+			code = resolvers.ErrorCode(ctxErr)
+		}
+
+		if e.Extensions == nil {
+			e.Extensions = make(map[string]interface{}, 1)
+		}
+		e.Extensions["code"] = code
+	}
+}
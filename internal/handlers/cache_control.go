@@ -0,0 +1,223 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// queryFields scans the GraphQL query for the names of the selected fields
+// of the operation of the given name; the only operation of the query is used
+// if no name is given. It returns the root fields of the operation, all the fields
+// selected by the operation and the fragments of the query, and a flag signalling
+// the operation is a mutation, or a subscription.
+// Fragments spread on the root level are reported as an empty root field name.
+func queryFields(query string, operation string) (root []string, all []string, mutation bool) {
+	var depth, parens int
+	var prev byte
+
+	// the definition being scanned; its kind, name, and if it's the selected operation
+	var kind, name string
+	var named, selected bool
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '#':
+			// skip comments
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '"':
+			i = skipString(query, i)
+		case c == '(':
+			parens++
+		case c == ')':
+			parens--
+		case c == '{' && parens == 0:
+			// the selection set of a definition; the query shorthand has no keyword
+			if depth == 0 {
+				if kind == "" {
+					kind = "query"
+				}
+				selected = kind != "fragment" && (operation == "" || operation == name)
+				if selected && kind != "query" {
+					mutation = true
+				}
+			}
+			depth++
+		case c == '}' && parens == 0:
+			depth--
+			if depth == 0 {
+				kind, name, named, selected = "", "", false, false
+			}
+		case c == '.' && strings.HasPrefix(query[i:], "...") && parens == 0:
+			// fragment spread on the root level makes the root fields unknown
+			if depth == 1 && selected {
+				root = append(root, "")
+			}
+			i += 2
+			c = '.'
+		case isNameStart(c):
+			// read the name
+			j := i
+			for j < len(query) && isNameChar(query[j]) {
+				j++
+			}
+			word := query[i:j]
+			i = j - 1
+
+			// names inside arguments, directives, and variables are not fields
+			if parens > 0 || prev == '@' || prev == '$' {
+				break
+			}
+
+			// names of spread fragments are not fields; inline fragments have type condition
+			if prev == '.' {
+				if word == "on" {
+					continue
+				}
+				break
+			}
+
+			// definition keywords and names
+			if depth == 0 {
+				switch {
+				case kind == "" && (word == "query" || word == "mutation" || word == "subscription" || word == "fragment"):
+					kind = word
+				case kind != "" && !named:
+					name, named = word, true
+				}
+				break
+			}
+
+			// fields of operations not executed are not relevant
+			if !selected && kind != "fragment" {
+				break
+			}
+
+			// aliases are followed by the colon; the field name comes next
+			if k := skipSpace(query, j); k < len(query) && query[k] == ':' {
+				break
+			}
+
+			// introspection fields are not relevant
+			if !strings.HasPrefix(word, "__") {
+				all = append(all, word)
+				if depth == 1 && selected {
+					root = append(root, word)
+				}
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			continue
+		}
+		prev = c
+	}
+	return root, all, mutation
+}
+
+// cacheAgeKey is the context key of the response max age collector.
+type cacheAgeKey struct{}
+
+// cacheAge collects the lowest cache max age of the schema fields
+// resolved to build a response.
+type cacheAge struct {
+	mu    sync.Mutex
+	hints map[string]int32
+	age   int32
+}
+
+// contextWithCacheAge attaches a new response max age collector to the context.
+// The given hints are keyed by the type and the field name.
+func contextWithCacheAge(ctx context.Context, hints map[string]int32) (context.Context, *cacheAge) {
+	ca := &cacheAge{hints: hints, age: math.MaxInt32}
+	return context.WithValue(ctx, cacheAgeKey{}, ca), ca
+}
+
+// field applies the cache hint of the resolved schema field, if any.
+func (ca *cacheAge) field(typeName string, fieldName string) {
+	h, ok := ca.hints[typeName+"."+fieldName]
+	if !ok {
+		return
+	}
+
+	ca.mu.Lock()
+	if h < ca.age {
+		ca.age = h
+	}
+	ca.mu.Unlock()
+}
+
+// maxAge provides the max age in seconds the response can be cached for.
+// Responses without any hinted field are not cached.
+func (ca *cacheAge) maxAge() int32 {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	if ca.age == math.MaxInt32 {
+		return 0
+	}
+	return ca.age
+}
+
+// writeCacheHeaders sets the cache control headers of the response with the given max age.
+// Responses to requests carrying the API key are cacheable by the client only.
+func writeCacheHeaders(w http.ResponseWriter, r *http.Request, maxAge int32) {
+	if maxAge <= 0 {
+		w.Header().Set("Cache-Control", "no-store")
+		return
+	}
+
+	scope := "public"
+	if requestApiKey(r) != "" {
+		scope = "private"
+	}
+	w.Header().Set("Cache-Control", fmt.Sprintf("%s, max-age=%d", scope, maxAge))
+	w.Header().Set("Age", "0")
+}
+
+// skipString skips the string literal starting at the given position
+// and returns the position of the closing quote.
+func skipString(s string, i int) int {
+	// block string
+	if strings.HasPrefix(s[i:], `"""`) {
+		end := strings.Index(s[i+3:], `"""`)
+		if end < 0 {
+			return len(s)
+		}
+		return i + 3 + end + 2
+	}
+
+	for i++; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return i
+}
+
+// skipSpace finds the next non-space character position starting at the given position.
+func skipSpace(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r' || s[i] == ',') {
+		i++
+	}
+	return i
+}
+
+// isNameStart checks if the character can start a GraphQL name.
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isNameChar checks if the character can be a part of a GraphQL name.
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestQueryFields verifies the fields of the selected operation are recognized
+// in documents with several operations, fragments, strings, and comments.
+func TestQueryFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		operation string
+		root      []string
+		all       []string
+		mutation  bool
+	}{
+		{
+			name:  "shorthand query",
+			query: `{ block { number hash } epoch(id: 5) { id } }`,
+			root:  []string{"block", "epoch"},
+			all:   []string{"block", "number", "hash", "epoch", "id"},
+		},
+		{
+			name: "mutation selected by operation name",
+			query: `query Read { block { number } }
+				mutation Send($tx: Bytes!) { sendTransaction(tx: $tx) { hash } }`,
+			operation: "Send",
+			root:      []string{"sendTransaction"},
+			all:       []string{"sendTransaction", "hash"},
+			mutation:  true,
+		},
+		{
+			name: "query selected by operation name",
+			query: `mutation Send($tx: Bytes!) { sendTransaction(tx: $tx) { hash } }
+				query Read { block { number } }`,
+			operation: "Read",
+			root:      []string{"block"},
+			all:       []string{"block", "number"},
+		},
+		{
+			name: "fragments",
+			query: `query Q { account(address: "0x0") { ...Acc } ... on Query { epoch { id } } }
+				fragment Acc on Account { balance delegations { totalCount } }`,
+			root: []string{"account", ""},
+			all:  []string{"account", "epoch", "id", "balance", "delegations", "totalCount"},
+		},
+		{
+			name:  "strings with braces",
+			query: `{ resolveName(name: "}{ mutation {") block(hash: """ { x } """) { number } }`,
+			root:  []string{"resolveName", "block"},
+			all:   []string{"resolveName", "block", "number"},
+		},
+		{
+			name: "comments",
+			query: `# mutation { sendTransaction }
+				{ block { # hash }
+					number } }`,
+			root: []string{"block"},
+			all:  []string{"block", "number"},
+		},
+		{
+			name:  "aliases and introspection",
+			query: `{ last: block { num: number } __typename }`,
+			root:  []string{"block"},
+			all:   []string{"block", "number"},
+		},
+	}
+
+	for _, tt := range tests {
+		root, all, mutation := queryFields(tt.query, tt.operation)
+		if !reflect.DeepEqual(root, tt.root) {
+			t.Errorf("%s: expected root fields %v, got %v", tt.name, tt.root, root)
+		}
+		if !reflect.DeepEqual(all, tt.all) {
+			t.Errorf("%s: expected fields %v, got %v", tt.name, tt.all, all)
+		}
+		if mutation != tt.mutation {
+			t.Errorf("%s: expected mutation %t, got %t", tt.name, tt.mutation, mutation)
+		}
+	}
+}
+
+// TestRejectMaintenance verifies only the mutation actually selected
+// by the operation name is rejected in maintenance.
+func TestRejectMaintenance(t *testing.T) {
+	query := `query Read { block { number } }
+		mutation Send($tx: Bytes!) { sendTransaction(tx: $tx) { hash } }
+		mutation Admin { admin { maintenance(on: false) } }`
+
+	if res := rejectMaintenance(query, "Read"); res != nil {
+		t.Errorf("expected query to pass, got %v", res.Errors)
+	}
+	if res := rejectMaintenance(query, "Send"); res == nil || len(res.Errors) != 1 {
+		t.Errorf("expected mutation to be rejected")
+	}
+	if res := rejectMaintenance(query, "Admin"); res != nil {
+		t.Errorf("expected admin mutation to pass, got %v", res.Errors)
+	}
+}
@@ -0,0 +1,152 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// compressMinLength represents the minimal length of a response to be compressed.
+// Smaller responses are sent as they are since the compression overhead
+// outweighs the gain.
+const compressMinLength = 1024
+
+// gzipWriterPool keeps gzip writers for reuse between responses.
+var gzipWriterPool = sync.Pool{
+	New: func() interface{} {
+		w, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return w
+	},
+}
+
+// CompressHandler defines HTTP handler middleware for compressing responses
+// of clients accepting gzip content encoding. Brotli is not offered, the standard
+// library has no encoder for it; clients accepting only br get identity responses.
+type CompressHandler struct {
+	handler http.Handler
+}
+
+// compressWriter implements http.ResponseWriter compressing the response body
+// once it's large enough to benefit from the compression.
+type compressWriter struct {
+	http.ResponseWriter
+	gz     *gzip.Writer
+	buf    []byte
+	status int
+}
+
+// ServeHTTP handles incoming request by wrapping the response writer
+// with compression if the client accepts it.
+func (h *CompressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the response may differ by accepted encoding
+	w.Header().Add("Vary", "Accept-Encoding")
+
+	// web socket upgrade, or no compression accepted?
+	if r.Header.Get("Upgrade") != "" || !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	// pass the request down the chain with compressing writer
+	cw := &compressWriter{ResponseWriter: w}
+	defer cw.close()
+	h.handler.ServeHTTP(cw, r)
+}
+
+// acceptsGzip checks if the Accept-Encoding header value allows gzip encoding.
+func acceptsGzip(hdr string) bool {
+	for _, enc := range strings.Split(hdr, ",") {
+		// split the encoding and its quality
+		parts := strings.Split(strings.TrimSpace(enc), ";")
+		name := strings.ToLower(strings.TrimSpace(parts[0]))
+		if name != "gzip" && name != "*" {
+			continue
+		}
+
+		// zero quality means the encoding is explicitly refused
+		for _, p := range parts[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "q=") {
+				if q, err := strconv.ParseFloat(p[2:], 64); err == nil && q == 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// WriteHeader captures the response status code; the header is sent
+// once we know if the response is going to be compressed.
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.status == 0 {
+		cw.status = status
+	}
+}
+
+// Write collects the response data and starts compression
+// once the minimal length is reached.
+func (cw *compressWriter) Write(data []byte) (int, error) {
+	// compression already running
+	if cw.gz != nil {
+		return cw.gz.Write(data)
+	}
+
+	// keep collecting until we know the response is large enough
+	cw.buf = append(cw.buf, data...)
+	if len(cw.buf) < compressMinLength {
+		return len(data), nil
+	}
+
+	// start the compression and flush collected data
+	if err := cw.start(); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// start sends the response header with compression encoding
+// and pushes collected data into the compressor.
+func (cw *compressWriter) start() error {
+	hdr := cw.Header()
+	hdr.Set("Content-Encoding", "gzip")
+	hdr.Del("Content-Length")
+	cw.sendHeader()
+
+	// make the compressor
+	cw.gz = gzipWriterPool.Get().(*gzip.Writer)
+	cw.gz.Reset(cw.ResponseWriter)
+
+	// write what we have so far
+	_, err := cw.gz.Write(cw.buf)
+	cw.buf = nil
+	return err
+}
+
+// sendHeader sends the response status code down the chain.
+func (cw *compressWriter) sendHeader() {
+	if cw.status != 0 {
+		cw.ResponseWriter.WriteHeader(cw.status)
+	}
+}
+
+// close finishes the response; small responses are sent uncompressed.
+func (cw *compressWriter) close() {
+	// compressed response needs to be finished
+	if cw.gz != nil {
+		_ = cw.gz.Close()
+		gzipWriterPool.Put(cw.gz)
+		cw.gz = nil
+		return
+	}
+
+	// send the small response as is
+	cw.sendHeader()
+	if len(cw.buf) > 0 {
+		_, _ = cw.ResponseWriter.Write(cw.buf)
+	}
+}
@@ -0,0 +1,50 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"net/http"
+	"strings"
+)
+
+// ContractArtifactPath is the path prefix of the contract artifact download end-point.
+// The address of the contract follows the prefix, i.e. /contract/artifact/0x...
+const ContractArtifactPath = "/contract/artifact/"
+
+// ContractArtifact constructs and return the HTTP handler for downloading
+// compilation artifacts of validated contracts.
+func ContractArtifact(log logger.Logger) http.Handler {
+	// build the handler function
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// decode the contract address; optional .json suffix is accepted
+		adr := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, ContractArtifactPath), ".json")
+		if !common.IsHexAddress(adr) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		addr := common.HexToAddress(adr)
+
+		// get the artifact
+		ca, err := repository.R().WithContext(r.Context()).ContractArtifact(&addr)
+		if err != nil {
+			log.Errorf("can not get contract %s artifact; %s", addr.String(), err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if ca == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// respond
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.json\"", addr.String()))
+		if err = json.NewEncoder(w).Encode(ca); err != nil {
+			log.Errorf("can not encode contract %s artifact; %s", addr.String(), err.Error())
+		}
+	})
+}
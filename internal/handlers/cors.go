@@ -0,0 +1,66 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/rs/cors"
+	"net/http"
+)
+
+// SecurityHandler defines HTTP handler middleware for setting standard security
+// headers on responses and for answering bare OPTIONS requests.
+type SecurityHandler struct {
+	enabled bool
+	handler http.Handler
+}
+
+// Secure wraps the given handler with configured CORS and security headers middleware.
+func Secure(cfg *config.Config, log logger.Logger, h http.Handler) http.Handler {
+	// Create new CORS handler and attach the logger into it so we get information on Debug level if needed
+	corsHandler := cors.New(corsOptions(cfg))
+	corsHandler.Log = log
+
+	return &SecurityHandler{
+		enabled: cfg.Server.SecurityHeaders,
+		handler: corsHandler.Handler(h),
+	}
+}
+
+// ServeHTTP handles incoming request by adding security headers to the response
+// and passing the request to the next handler in the chain.
+func (h *SecurityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// add security headers
+	if h.enabled {
+		hdr := w.Header()
+		hdr.Set("X-Content-Type-Options", "nosniff")
+		hdr.Set("X-Frame-Options", "DENY")
+		hdr.Set("Referrer-Policy", "no-referrer")
+
+		// enforce secure transport only if we are on it already
+		if r.TLS != nil {
+			hdr.Set("Strict-Transport-Security", "max-age=31536000")
+		}
+	}
+
+	// CORS handler responds to preflight requests; a bare OPTIONS request
+	// has nothing to be passed down the chain
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") == "" {
+		w.Header().Set("Allow", "OPTIONS, HEAD, GET, POST")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// pass request down the chain
+	h.handler.ServeHTTP(w, r)
+}
+
+// corsOptions constructs new set of options for the CORS handler based on provided configuration.
+func corsOptions(cfg *config.Config) cors.Options {
+	return cors.Options{
+		AllowedOrigins: cfg.Server.CorsOrigin,
+		AllowedMethods: cfg.Server.CorsMethods,
+		AllowedHeaders: cfg.Server.CorsHeaders,
+		MaxAge:         cfg.Server.CorsMaxAge,
+	}
+}
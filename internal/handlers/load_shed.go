@@ -0,0 +1,108 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+)
+
+// loadShedCheckPeriod is the period of re-evaluation of the backends latency.
+const loadShedCheckPeriod = time.Second
+
+// loadShedder rejects the expensive queries while the block chain node, or the database
+// responds slowly, so the cheap queries can still be served.
+type loadShedder struct {
+	cfg    *config.LoadShedding
+	fields map[string]bool
+	log    logger.Logger
+
+	mu      sync.Mutex
+	checked time.Time
+	reason  string
+}
+
+// newLoadShedder creates a new load shedder; nil is returned if the load shedding
+// is not configured.
+func newLoadShedder(cfg *config.LoadShedding, log logger.Logger) *loadShedder {
+	if (cfg.NodeLatency <= 0 && cfg.DbLatency <= 0) || len(cfg.Fields) == 0 {
+		return nil
+	}
+
+	ls := loadShedder{
+		cfg:    cfg,
+		fields: make(map[string]bool, len(cfg.Fields)),
+		log:    log,
+	}
+	for _, f := range cfg.Fields {
+		ls.fields[f] = true
+	}
+	return &ls
+}
+
+// reject checks if the operation of the query should be rejected under the current load.
+// It returns the error response for rejected queries, nil otherwise.
+func (ls *loadShedder) reject(query string, operation string) *graphql.Response {
+	if ls == nil {
+		return nil
+	}
+
+	reason := ls.overload()
+	if reason == "" {
+		return nil
+	}
+
+	// expensive fields may be selected on any level of the query
+	_, all, _ := queryFields(query, operation)
+	for _, f := range all {
+		if ls.fields[f] {
+			return &graphql.Response{Errors: []*errors.QueryError{{
+				Message:    fmt.Sprintf("%s is not available due to %s, retry later", f, reason),
+				Extensions: map[string]interface{}{"code": resolvers.ErrCodeRetryLater},
+			}}}
+		}
+	}
+	return nil
+}
+
+// overload provides the reason of the backends overload, empty if the backends respond in time.
+func (ls *loadShedder) overload() string {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if time.Since(ls.checked) < loadShedCheckPeriod {
+		return ls.reason
+	}
+	ls.checked = time.Now()
+
+	var reason string
+	if ls.cfg.NodeLatency > 0 {
+		if lat := repository.R().NodeLatency(); lat > ls.cfg.NodeLatency {
+			reason = fmt.Sprintf("slow block chain node (p95 %s)", lat)
+		}
+	}
+	if reason == "" && ls.cfg.DbLatency > 0 {
+		if lat := repository.R().DatabaseLatency(); lat > ls.cfg.DbLatency {
+			reason = fmt.Sprintf("slow database (p95 %s)", lat)
+		}
+	}
+
+	// log changes of the state
+	if reason != ls.reason {
+		if reason != "" {
+			ls.log.Warningf("shedding expensive queries; %s", reason)
+		} else {
+			ls.log.Notice("load shedding stopped")
+		}
+	}
+
+	ls.reason = reason
+	return reason
+}
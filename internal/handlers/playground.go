@@ -0,0 +1,142 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// playgroundTemplate represents the template of the GraphiQL 2 playground page.
+// The page is served by the API server itself, the IDE scripts and styles are loaded
+// from the configured assets location. Subscriptions use the legacy WebSocket protocol
+// of the API end-point.
+const playgroundTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8" />
+	<title>Fantom GraphQL API Playground</title>
+	<link rel="stylesheet" href="{{ .Assets }}/graphiql@2.4.7/graphiql.min.css" />
+	<script crossorigin src="{{ .Assets }}/react@17.0.2/umd/react.production.min.js"></script>
+	<script crossorigin src="{{ .Assets }}/react-dom@17.0.2/umd/react-dom.production.min.js"></script>
+	<script crossorigin src="{{ .Assets }}/graphiql@2.4.7/graphiql.min.js"></script>
+	<script crossorigin src="{{ .Assets }}/subscriptions-transport-ws@0.9.19/browser/client.js"></script>
+</head>
+<body style="width: 100%; height: 100vh; margin: 0; overflow: hidden;">
+	<div id="graphiql" style="height: 100vh;">Loading...</div>
+	<script>
+		var domain = {{ .Domain }} || window.location.host;
+		var subscriptions = (window.location.protocol === "https:" ? "wss://" : "ws://") + domain + "/graphql";
+		var fetcher = GraphiQL.createFetcher({
+			url: "/graphql",
+			legacyWsClient: new window.SubscriptionsTransportWs.SubscriptionClient(subscriptions, {reconnect: true})
+		});
+		ReactDOM.render(
+			React.createElement(GraphiQL, {
+				fetcher: fetcher,
+				defaultTabs: {{ .Examples }},
+				defaultEditorToolsVisibility: true,
+				shouldPersistHeaders: true
+			}),
+			document.getElementById("graphiql")
+		);
+	</script>
+</body>
+</html>
+`
+
+// playgroundExample represents an example query preloaded into the playground tabs.
+type playgroundExample struct {
+	Query     string `json:"query"`
+	Variables string `json:"variables,omitempty"`
+}
+
+// playgroundExamples represents the list of example queries preloaded into the playground.
+var playgroundExamples = []playgroundExample{
+	{Query: `# Welcome to the Fantom GraphQL API playground.
+# Browse the schema documentation using the Docs button on the left,
+# the other tabs show examples of staking and DeFi queries.
+query LatestBlocks {
+  state {
+    blocks
+    transactions
+    sealedEpoch { id endTime }
+  }
+  blocks(count: 5) {
+    pageInfo { first last hasNext }
+    edges {
+      cursor
+      block { number hash timestamp transactionCount gasUsed }
+    }
+  }
+}
+`},
+	{Query: `# Active validators ordered by their stake
+# and the delegations of the given account.
+query Staking($address: Address!) {
+  stakers(orderBy: "stake", onlyActive: true, count: 10) {
+    id
+    stakerAddress
+    stake
+    delegatedMe
+    isActive
+  }
+  delegationsByAddress(address: $address, count: 5) {
+    totalCount
+    edges {
+      delegation { toStakerId amount createdTime }
+    }
+  }
+}
+`, Variables: `{"address": "0xFC00FACE00000000000000000000000000000000"}`},
+	{Query: `# DeFi tokens and the fMint protocol configuration.
+query DeFi {
+  defiConfiguration {
+    mintFee4
+    minCollateralRatio4
+    rewardCollateralRatio4
+  }
+  defiTokens {
+    address
+    symbol
+    decimals
+    isActive
+  }
+}
+`},
+	{Query: `# New blocks are pushed to the client as they arrive.
+subscription NewBlocks {
+  onBlock { number hash timestamp transactionCount }
+}
+`},
+}
+
+// playgroundPage represents the data used to render the playground page.
+type playgroundPage struct {
+	Assets   string
+	Domain   string
+	Examples []playgroundExample
+}
+
+// PlaygroundHandler builds a HTTP handler function for the GraphiQL playground.
+func PlaygroundHandler(cfg *config.Config, log logger.Logger) http.Handler {
+	// parse the template, we don't expect it to fail
+	t := template.Must(template.New("playground").Parse(playgroundTemplate))
+	page := playgroundPage{
+		Assets:   strings.TrimSuffix(cfg.Server.Playground.Assets, "/"),
+		Domain:   cfg.Server.DomainAddress,
+		Examples: playgroundExamples,
+	}
+
+	// build the handler function
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := t.Execute(w, &page); err != nil {
+			// log and send 500 response to client
+			log.Criticalf("can not serve GraphQL playground; %s", err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository/opstats"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/trace"
+)
+
+// queryStatsHeader is the request header asking for the execution stats of the query
+// in the extensions of the response.
+const queryStatsHeader = "X-Api-Debug"
+
+// queryStatsTopFields is the max number of the slowest fields reported in the stats.
+const queryStatsTopFields = 10
+
+// queryStatsKey is the context key of the query execution stats.
+type queryStatsKey struct{}
+
+// queryStats collects the execution stats of a single GraphQL operation.
+type queryStats struct {
+	mu        sync.Mutex
+	start     time.Time
+	fields    int
+	resolvers int
+	timings   map[string]*fieldTiming
+
+	// calls counts the backend calls of the operation; the repository
+	// picks the counter from the context the resolvers pass to it
+	calls *opstats.Counter
+}
+
+// fieldTiming represents the accumulated execution time of a resolver of a schema field.
+type fieldTiming struct {
+	Field    string  `json:"field"`
+	Calls    int     `json:"calls"`
+	Duration float64 `json:"durationMs"`
+}
+
+// contextWithQueryStats attaches a new execution stats collector
+// and a new backend calls counter to the context.
+func contextWithQueryStats(ctx context.Context) (context.Context, *queryStats) {
+	ctx, calls := opstats.WithCounter(ctx)
+	qs := &queryStats{start: time.Now(), timings: make(map[string]*fieldTiming), calls: calls}
+	return context.WithValue(ctx, queryStatsKey{}, qs), qs
+}
+
+// field records the execution of a schema field. Fields resolved directly
+// from the parent structure are counted only, resolvers are timed.
+func (qs *queryStats) field(typeName string, fieldName string, trivial bool, dur time.Duration) {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	qs.fields++
+	if trivial {
+		return
+	}
+	qs.resolvers++
+
+	name := typeName + "." + fieldName
+	ft, ok := qs.timings[name]
+	if !ok {
+		ft = &fieldTiming{Field: name}
+		qs.timings[name] = ft
+	}
+	ft.Calls++
+	ft.Duration += float64(dur.Microseconds()) / 1000
+}
+
+// report builds the stats report attached to the response extensions.
+// The complexity is the number of fields resolved to build the response,
+// the calls are the numbers of database commands, node calls and cache calls made.
+func (qs *queryStats) report() map[string]interface{} {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	list := make([]*fieldTiming, 0, len(qs.timings))
+	for _, ft := range qs.timings {
+		list = append(list, ft)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Duration > list[j].Duration })
+	if len(list) > queryStatsTopFields {
+		list = list[:queryStatsTopFields]
+	}
+
+	return map[string]interface{}{
+		"durationMs": float64(time.Since(qs.start).Microseconds()) / 1000,
+		"complexity": qs.fields,
+		"resolvers":  qs.resolvers,
+		"calls":      qs.calls.Report(),
+		"slowest":    list,
+	}
+}
+
+// statsTracer implements GraphQL tracer collecting the execution stats
+// of operations which asked for them and the cache max age of the resolved
+// fields; the OpenTracing tracing is kept.
+type statsTracer struct {
+	trace.OpenTracingTracer
+}
+
+// TraceField traces the execution of a single schema field.
+func (st statsTracer) TraceField(ctx context.Context, label, typeName, fieldName string, trivial bool, args map[string]interface{}) (context.Context, trace.TraceFieldFinishFunc) {
+	tc, finish := st.OpenTracingTracer.TraceField(ctx, label, typeName, fieldName, trivial, args)
+
+	if ca, ok := ctx.Value(cacheAgeKey{}).(*cacheAge); ok {
+		ca.field(typeName, fieldName)
+	}
+
+	qs, ok := ctx.Value(queryStatsKey{}).(*queryStats)
+	if !ok {
+		return tc, finish
+	}
+
+	start := time.Now()
+	return tc, func(err *errors.QueryError) {
+		qs.field(typeName, fieldName, trivial, time.Since(start))
+		finish(err)
+	}
+}
@@ -0,0 +1,39 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	gqlSchema "fantom-api-graphql/internal/graphql/schema"
+	"fantom-api-graphql/internal/logger"
+	"net/http"
+)
+
+// SchemaHandler builds a HTTP handler serving the complete GraphQL schema SDL
+// so clients can generate typed bindings pinned to the deployed schema version.
+func SchemaHandler(log logger.Logger) http.Handler {
+	version := gqlSchema.Version()
+	etag := `"` + version + `"`
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("X-Schema-Version", version)
+
+		// the client already has this version of the schema
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/graphql; charset=utf-8")
+		if r.Method == http.MethodHead {
+			return
+		}
+		if _, err := w.Write([]byte(gqlSchema.Schema())); err != nil {
+			log.Errorf("can not serve schema SDL; %s", err.Error())
+		}
+	})
+}
@@ -0,0 +1,85 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"encoding/csv"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// usageOriginMaxLength is the max length of the browser origin accounted for the usage;
+// longer origins are not real browser origins and are accounted as anonymous.
+const usageOriginMaxLength = 128
+
+// usageOrigin identifies the client origin the API usage of the request is accounted to.
+// Registered API keys are identified by the identifier derived from the key, other
+// requests by the browser origin. Requests without any are anonymous.
+func usageOrigin(cfg *config.Auth, r *http.Request) string {
+	if id, ok := resolvers.ClientId(cfg, requestApiKey(r)); ok {
+		return "key:" + id
+	}
+
+	if org := r.Header.Get("Origin"); org != "" && len(org) <= usageOriginMaxLength {
+		return "origin:" + org
+	}
+	return "anonymous"
+}
+
+// UsageExport constructs and return the HTTP handler for exporting the API usage report
+// in the CSV format. The report period is given by the period query parameter,
+// DAY is used by default. A privileged API key is required.
+func UsageExport(cfg *config.Config, log logger.Logger) http.Handler {
+	// build the handler function
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !resolvers.IsAdminKey(&cfg.Auth, requestApiKey(r)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		// decode the period
+		period := r.URL.Query().Get("period")
+		if period == "" {
+			period = "DAY"
+		}
+		from, to, err := resolvers.UsageRange(period)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// get the report
+		list, err := repository.R().UsageReport(from, to)
+		if err != nil {
+			log.Errorf("can not get usage report; %s", err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// respond
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"usage-%s.csv\"", from.Format("2006-01-02T15")))
+
+		out := csv.NewWriter(w)
+		_ = out.Write([]string{"origin", "from", "to", "requests", "bytes", "cost_ms"})
+		for _, ur := range list {
+			_ = out.Write([]string{
+				ur.Origin,
+				strconv.FormatInt(ur.From.Unix(), 10),
+				strconv.FormatInt(ur.To.Unix(), 10),
+				strconv.FormatUint(ur.Requests, 10),
+				strconv.FormatUint(ur.Bytes, 10),
+				strconv.FormatUint(ur.Cost, 10),
+			})
+		}
+
+		out.Flush()
+		if err := out.Error(); err != nil {
+			log.Errorf("can not write usage report; %s", err.Error())
+		}
+	})
+}
@@ -0,0 +1,180 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// widgetCacheTTL represents the time a widget response is served from cache.
+	widgetCacheTTL = 10 * time.Second
+
+	// widgetDefaultSymbol is the target price symbol used if none is requested.
+	widgetDefaultSymbol = "USD"
+
+	// widgetTpsRange is the number of seconds the widget TPS is averaged for.
+	widgetTpsRange = 1200
+)
+
+// reWidgetSymbol is the expected format of the price widget target symbol.
+var reWidgetSymbol = regexp.MustCompile(`^[\w]{2,4}$`)
+
+// widgetLoader loads the data of a widget for the given key.
+type widgetLoader func(key string) (interface{}, error)
+
+// widgetResponse represents a cached widget response.
+type widgetResponse struct {
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+// WidgetPrice constructs the widget HTTP handler of the current FTM price.
+// The target symbol is selected by the "to" query parameter, USD by default.
+func WidgetPrice(log logger.Logger) http.Handler {
+	return widget(log, func(r *http.Request) (string, bool) {
+		sym := strings.ToUpper(r.URL.Query().Get("to"))
+		if sym == "" {
+			sym = widgetDefaultSymbol
+		}
+		return sym, reWidgetSymbol.MatchString(sym)
+	}, func(sym string) (interface{}, error) {
+		pri, err := repository.R().Price(sym)
+		if err != nil {
+			return nil, err
+		}
+		return struct {
+			Symbol    string         `json:"symbol"`
+			Price     float64        `json:"price"`
+			Change24  float64        `json:"change24"`
+			MarketCap float64        `json:"marketCap"`
+			Updated   hexutil.Uint64 `json:"updated"`
+		}{sym, pri.Price, pri.ChangePct24, pri.MarketCap, pri.LastUpdate}, nil
+	})
+}
+
+// WidgetTps constructs the widget HTTP handler of the current transactions per second rate.
+func WidgetTps(log logger.Logger) http.Handler {
+	return widget(log, nil, func(string) (interface{}, error) {
+		tps, err := repository.R().TrxFlowSpeed(widgetTpsRange)
+		if err != nil {
+			return nil, err
+		}
+		return struct {
+			Tps   float64 `json:"tps"`
+			Range int32   `json:"range"`
+		}{tps, widgetTpsRange}, nil
+	})
+}
+
+// WidgetSupply constructs the widget HTTP handler of the current FTM supply.
+func WidgetSupply(log logger.Logger) http.Handler {
+	return widget(log, nil, func(string) (interface{}, error) {
+		fs, err := repository.R().FtmSupply()
+		if err != nil {
+			return nil, err
+		}
+		return struct {
+			Epoch       hexutil.Uint64 `json:"epoch"`
+			Total       hexutil.Big    `json:"total"`
+			Circulating hexutil.Big    `json:"circulating"`
+			Staked      hexutil.Big    `json:"staked"`
+			Burned      hexutil.Big    `json:"burned"`
+		}{fs.Epoch, fs.TotalSupply, fs.Circulating(), fs.Staked, fs.TotalBurned}, nil
+	})
+}
+
+// widget constructs a cached JSON widget HTTP handler with permissive CORS
+// and ETag support. The optional key function selects the cache key
+// of the request and validates it.
+func widget(log logger.Logger, key func(r *http.Request) (string, bool), load widgetLoader) http.Handler {
+	var mu sync.Mutex
+	cache := make(map[string]*widgetResponse)
+
+	// get the response from cache, or load a new one
+	get := func(k string) (*widgetResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if res, ok := cache[k]; ok && time.Now().Before(res.expires) {
+			return res, nil
+		}
+
+		data, err := load(k)
+		if err != nil {
+			return nil, err
+		}
+		body, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(body)
+		res := &widgetResponse{
+			body:    body,
+			etag:    `"` + hex.EncodeToString(sum[:8]) + `"`,
+			expires: time.Now().Add(widgetCacheTTL),
+		}
+		cache[k] = res
+		return res, nil
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// widgets are embedded anywhere
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "If-None-Match")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		// select the cache key
+		k := ""
+		if key != nil {
+			var ok bool
+			if k, ok = key(r); !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		res, err := get(k)
+		if err != nil {
+			log.Errorf("can not load widget %s; %s", r.URL.Path, err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("ETag", res.etag)
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(widgetCacheTTL.Seconds())))
+		if r.Header.Get("If-None-Match") == res.etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodHead {
+			return
+		}
+		if _, err := w.Write(res.body); err != nil {
+			log.Errorf("can not serve widget %s; %s", r.URL.Path, err.Error())
+		}
+	})
+}
@@ -69,4 +69,7 @@ type Logger interface {
 
 	// Printf logs regular and detailed state change with formatting and placeholder constituents replacements.
 	Printf(string, ...interface{})
+
+	// ModuleLogger provides a sub-logger of the given module with independent logging level.
+	ModuleLogger(module string) Logger
 }
@@ -2,10 +2,30 @@ package logger
 
 import (
 	"fantom-api-graphql/internal/config"
+	"fmt"
 	"github.com/op/go-logging"
 	"os"
+	"strings"
 )
 
+// Modules of the API server with independently configurable logging level.
+const (
+	ModuleRpc       = "rpc"
+	ModuleDb        = "db"
+	ModuleResolvers = "resolvers"
+	ModuleValidator = "validator"
+)
+
+// ModuleDefault represents the module name used to control the default logging level
+// applied to all the modules without explicitly set level.
+const ModuleDefault = "default"
+
+// modules is the list of recognized logging modules.
+var modules = []string{ModuleDefault, ModuleRpc, ModuleDb, ModuleResolvers, ModuleValidator}
+
+// levels represents the leveled logging backend shared by all the loggers.
+var levels logging.LeveledBackend
+
 // ApiLogger defines extended logger with generic no-level logging option
 type ApiLogger struct {
 	logging.Logger
@@ -17,8 +37,14 @@ func (a ApiLogger) Printf(format string, args ...interface{}) {
 	a.Debugf(format, args...)
 }
 
+// ModuleLogger provides a sub-logger of the given module. The module logging level
+// can be set independently, it follows the default logging level otherwise.
+func (a ApiLogger) ModuleLogger(module string) Logger {
+	return &ApiLogger{*logging.MustGetLogger(module)}
+}
+
 // New provides pre-configured Logger with stderr output and leveled filtering.
-// Modules are not supported at the moment, but may be added in the future to make the logging setup more granular.
+// Modules listed in the configuration get their own logging level.
 func New(cfg *config.Config) Logger {
 	// Prep the backend for exporting the log records
 	// @todo Allow app to define different logging backend by configuration means.
@@ -33,12 +59,72 @@ func New(cfg *config.Config) Logger {
 	if err != nil {
 		level = logging.INFO
 	}
-	lvlBackend := logging.AddModuleLevel(fmtBackend)
-	lvlBackend.SetLevel(level, "")
+	levels = logging.AddModuleLevel(fmtBackend)
+	levels.SetLevel(level, "")
 
 	// assign the backend and return the new logger
-	logging.SetBackend(lvlBackend)
+	logging.SetBackend(levels)
 	l := logging.MustGetLogger(cfg.AppName)
 
+	// apply levels of modules
+	for module, lvl := range cfg.Log.Modules {
+		if err := SetLevel(module, lvl); err != nil {
+			l.Errorf("can not set logging level of %s; %s", module, err.Error())
+		}
+	}
 	return &ApiLogger{*l}
 }
+
+// Modules provides the list of logging modules with independent logging level.
+func Modules() []string {
+	return modules
+}
+
+// Level provides the current logging level of the given module.
+func Level(module string) string {
+	if levels == nil {
+		return ""
+	}
+	return levels.GetLevel(backendModule(module)).String()
+}
+
+// SetLevel changes the logging level of the given module.
+func SetLevel(module string, level string) error {
+	if levels == nil {
+		return fmt.Errorf("logger not initialized")
+	}
+
+	// check the module
+	module = strings.ToLower(module)
+	if !isModule(module) {
+		return fmt.Errorf("unknown logging module %s", module)
+	}
+
+	// parse the level
+	lvl, err := logging.LogLevel(level)
+	if err != nil {
+		return err
+	}
+
+	levels.SetLevel(lvl, backendModule(module))
+	return nil
+}
+
+// isModule checks if the given name is a known logging module.
+func isModule(name string) bool {
+	for _, m := range modules {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
+
+// backendModule translates the module name to the name used by the leveled backend.
+func backendModule(module string) string {
+	module = strings.ToLower(module)
+	if module == ModuleDefault {
+		return ""
+	}
+	return module
+}
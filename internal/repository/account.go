@@ -69,21 +69,58 @@ func (p *proxy) getAccount(addr *common.Address) (*types.Account, error) {
 
 // AccountBalance returns the current balance of an account at Opera blockchain.
 func (p *proxy) AccountBalance(addr *common.Address) (*hexutil.Big, error) {
-	return p.rpc.AccountBalance(addr)
+	// try the cache first; the value is evicted when the account is touched
+	if val := p.cache.PullAccountBalance(addr); val != nil {
+		return val, nil
+	}
+
+	val, err := p.rpc.AccountBalance(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	p.cache.PushAccountBalance(addr, val)
+	return val, nil
 }
 
 // AccountNonce returns the current number of sent transactions of an account at Opera blockchain.
 func (p *proxy) AccountNonce(addr *common.Address) (*hexutil.Uint64, error) {
+	// try the cache first; the value is evicted when the account is touched
+	if val := p.cache.PullAccountNonce(addr); val != nil {
+		nonce := hexutil.Uint64(*val)
+		return &nonce, nil
+	}
+
 	val, err := p.rpc.AccountNonce(addr)
 	if err != nil {
 		return nil, err
 	}
+	p.cache.PushAccountNonce(addr, val)
+
+	// make the value and return
+	nonce := hexutil.Uint64(val)
+	return &nonce, nil
+}
+
+// AccountPendingNonce returns the number of sent transactions of an account
+// including transactions waiting in the transaction pool.
+func (p *proxy) AccountPendingNonce(addr *common.Address) (*hexutil.Uint64, error) {
+	val, err := p.rpc.AccountPendingNonce(addr)
+	if err != nil {
+		return nil, err
+	}
 
 	// make the value and return
 	nonce := hexutil.Uint64(val)
 	return &nonce, nil
 }
 
+// AccountPendingTransactions returns the list of transactions of an account
+// waiting in the node transaction pool.
+func (p *proxy) AccountPendingTransactions(addr *common.Address) ([]*types.Transaction, error) {
+	return p.rpc.AccountPendingTransactions(addr)
+}
+
 // AccountTransactions returns slice of AccountTransaction structure for a given account at Opera blockchain.
 func (p *proxy) AccountTransactions(addr *common.Address, cursor *string, count int32) (*types.TransactionList, error) {
 	// do we have an account?
@@ -92,7 +129,18 @@ func (p *proxy) AccountTransactions(addr *common.Address, cursor *string, count
 	}
 
 	// go to the database for the list of hashes of transaction searched
-	return p.db.AccountTransactions(addr, cursor, count)
+	list, err := p.db.AccountTransactions(addr, cursor, count)
+	if err != nil || list == nil || cursor != nil || count < 0 {
+		return list, err
+	}
+
+	// the top of the list includes transactions not indexed yet
+	pending := p.pendingAccountTransactions(addr)
+	if len(pending) > 0 {
+		list.Collection = append(pending, list.Collection...)
+		list.Total += uint64(len(pending))
+	}
+	return list, nil
 }
 
 // AccountsActive returns total number of accounts known to repository.
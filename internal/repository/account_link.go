@@ -0,0 +1,285 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"sort"
+	"sync"
+)
+
+const (
+	// addressLinkerBatch represents the number of accounts linked in one round.
+	addressLinkerBatch = 200
+
+	// relatedFanOutLimit is the max number of accounts linked to a funder, or a deployer,
+	// to consider them siblings; addresses with more links are typically exchanges,
+	// faucets, or public factories and say nothing about the ownership.
+	relatedFanOutLimit = 50
+
+	// relatedCodeLookupLimit is the max number of deployed contracts and their identical
+	// deployments inspected for the shared code relation.
+	relatedCodeLookupLimit = 10
+)
+
+// addressLinker represents a service linking accounts to their funders
+// and deployers for the related addresses heuristics.
+type addressLinker struct {
+	service
+}
+
+// newAddressLinker creates a new address linker service.
+func newAddressLinker(repo Repository, log logger.Logger, wg *sync.WaitGroup) *addressLinker {
+	return &addressLinker{
+		service: newPeriodicService("address linker", repo, log, wg),
+	}
+}
+
+// run starts the address linker service
+func (al *addressLinker) run() {
+	al.wg.Add(1)
+	go al.schedule()
+}
+
+// schedule schedules regular account linking rounds.
+func (al *addressLinker) schedule() {
+	// inform about the service
+	al.log.Notice("address linker is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		al.log.Notice("address linker is closed")
+		al.wg.Done()
+	}()
+
+	// run on schedule
+	al.loop(func() {
+		al.exclusive(al.link)
+	})
+}
+
+// link links a batch of accounts not linked yet.
+func (al *addressLinker) link() {
+	list, err := al.repo.AccountsToLink(addressLinkerBatch)
+	if err != nil {
+		al.log.Errorf("can not load accounts to link; %s", err.Error())
+		return
+	}
+
+	for i := range list {
+		// check for the stop signal between accounts
+		select {
+		case <-al.sigStop:
+			return
+		default:
+		}
+
+		if err := al.repo.LinkAccount(&list[i]); err != nil {
+			al.log.Errorf("can not link account %s; %s", list[i].String(), err.Error())
+		}
+	}
+}
+
+// AccountsToLink loads a batch of accounts which have not been linked
+// to their funder and deployer yet.
+func (p *proxy) AccountsToLink(limit int64) ([]common.Address, error) {
+	return p.db.AccountsToLink(limit)
+}
+
+// LinkAccount finds the funder of the account at the given address and the deployer,
+// if the account is a contract, and stores them with the account.
+func (p *proxy) LinkAccount(addr *common.Address) error {
+	funder, err := p.db.FirstFunder(addr)
+	if err != nil {
+		return err
+	}
+
+	deployer, err := p.contractDeployer(addr)
+	if err != nil {
+		return err
+	}
+	return p.db.UpdateAccountLinks(addr, funder, deployer)
+}
+
+// contractDeployer provides the address which deployed the contract at the given address;
+// contracts deployed by factories are attributed to the factory. Nil for wallets.
+func (p *proxy) contractDeployer(addr *common.Address) (*common.Address, error) {
+	acc, err := p.Account(addr)
+	if err != nil {
+		return nil, err
+	}
+	if acc.ContractTx == nil {
+		return nil, nil
+	}
+
+	cc, err := p.db.ContractCreation(addr)
+	if err != nil {
+		return nil, err
+	}
+	if cc != nil {
+		return &cc.Creator, nil
+	}
+
+	trx, err := p.Transaction(acc.ContractTx)
+	if err != nil {
+		return nil, err
+	}
+	return &trx.From, nil
+}
+
+// RelatedAddresses provides addresses likely controlled by the same entity as the account
+// at the given address, ordered by the confidence of the relation.
+// The confidence of addresses related in several ways is combined.
+func (p *proxy) RelatedAddresses(addr *common.Address, limit int) ([]*types.RelatedAddress, error) {
+	rel := make(relatedSet)
+
+	funder, deployer, err := p.db.AccountLinks(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// funding relations
+	if err := p.relateByLink(rel, funder, types.RelationFunder, types.RelationSharedFunder, p.db.AccountsFundedBy); err != nil {
+		return nil, err
+	}
+	funded, err := p.db.AccountsFundedBy(addr, relatedFanOutLimit)
+	if err != nil {
+		return nil, err
+	}
+	rel.add(types.RelationFunded, funded...)
+
+	// deployment relations
+	if err := p.relateByLink(rel, deployer, types.RelationDeployer, types.RelationSharedDeployer, p.db.AccountsDeployedBy); err != nil {
+		return nil, err
+	}
+	deployed, err := p.db.AccountsDeployedBy(addr, relatedFanOutLimit)
+	if err != nil {
+		return nil, err
+	}
+	rel.add(types.RelationDeployed, deployed...)
+
+	// deployers of the same byte code
+	if err := p.relateBySharedCode(rel, deployed); err != nil {
+		return nil, err
+	}
+
+	delete(rel, *addr)
+	return rel.list(limit), nil
+}
+
+// relateByLink adds the given linked address and its other linked accounts into the set
+// of related addresses. Siblings are ignored if the linked address has too many links.
+func (p *proxy) relateByLink(rel relatedSet, link *common.Address, relation string, sibling string, siblings func(*common.Address, int64) ([]common.Address, error)) error {
+	if link == nil {
+		return nil
+	}
+	rel.add(relation, *link)
+
+	list, err := siblings(link, relatedFanOutLimit+1)
+	if err != nil {
+		return err
+	}
+	if len(list) <= relatedFanOutLimit {
+		rel.add(sibling, list...)
+	}
+	return nil
+}
+
+// relateBySharedCode adds the deployers of contracts with the byte code identical
+// to the given deployed contracts into the set of related addresses.
+func (p *proxy) relateBySharedCode(rel relatedSet, deployed []common.Address) error {
+	if len(deployed) > relatedCodeLookupLimit {
+		deployed = deployed[:relatedCodeLookupLimit]
+	}
+
+	for i := range deployed {
+		sc, err := p.Contract(&deployed[i])
+		if err != nil {
+			return err
+		}
+
+		// the contract is not known, or the code is not hashed yet
+		if sc == nil || sc.CodeHash == nil || *sc.CodeHash == emptyCodeHash {
+			continue
+		}
+
+		list, err := p.db.ContractsByCodeHash(sc.CodeHash, &deployed[i], relatedCodeLookupLimit)
+		if err != nil {
+			return err
+		}
+
+		for _, same := range list {
+			_, dpl, err := p.db.AccountLinks(&same.Address)
+			if err != nil {
+				return err
+			}
+			if dpl != nil {
+				rel.add(types.RelationSharedCode, *dpl)
+			}
+		}
+	}
+	return nil
+}
+
+// relatedSet collects relations of addresses to an account.
+type relatedSet map[common.Address]*types.RelatedAddress
+
+// add adds the given relation to the given addresses.
+func (rs relatedSet) add(relation string, list ...common.Address) {
+	for _, addr := range list {
+		ra, ok := rs[addr]
+		if !ok {
+			ra = &types.RelatedAddress{Address: addr}
+			rs[addr] = ra
+		}
+
+		if !hasRelation(ra, relation) {
+			ra.Relations = append(ra.Relations, relation)
+		}
+	}
+}
+
+// hasRelation checks if the related address already has the given relation.
+func hasRelation(ra *types.RelatedAddress, relation string) bool {
+	for _, r := range ra.Relations {
+		if r == relation {
+			return true
+		}
+	}
+	return false
+}
+
+// list provides the related addresses with the combined confidence, the most confident first.
+// Relations are treated as independent evidence, i.e. the confidence is the probability
+// of at least one of them being right.
+func (rs relatedSet) list(limit int) []*types.RelatedAddress {
+	list := make([]*types.RelatedAddress, 0, len(rs))
+	for _, ra := range rs {
+		miss := 1.0
+		for _, r := range ra.Relations {
+			miss *= 1 - types.RelationConfidence[r]
+		}
+		ra.Confidence = 1 - miss
+		list = append(list, ra)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Confidence != list[j].Confidence {
+			return list[i].Confidence > list[j].Confidence
+		}
+		return list[i].Address.Hex() < list[j].Address.Hex()
+	})
+
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
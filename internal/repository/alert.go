@@ -0,0 +1,366 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// alertEventsLag is the number of the latest blocks skipped by the evaluation
+	// of event rules, so the events of the blocks are indexed already.
+	alertEventsLag = 2
+
+	// alertEventsPerRun is the max number of events of a single rule processed on a run.
+	alertEventsPerRun = 50
+
+	// alertWebhookTimeout is the max time a webhook delivery of an alert can take.
+	alertWebhookTimeout = 5 * time.Second
+
+	// alertDeliveryQueueLength is the capacity of the queue of alerts waiting for delivery.
+	alertDeliveryQueueLength = 100
+
+	// alertDeliveryWorkers is the number of workers posting alerts to webhooks.
+	alertDeliveryWorkers = 4
+
+	// alertDeliveryBatch is the max number of undelivered alerts checked on a run.
+	alertDeliveryBatch = 1000
+
+	// alertDeliveryWindow is the max age of an alert still delivered to its webhook.
+	alertDeliveryWindow = time.Hour
+
+	// alertRetryDelay is the min time between delivery attempts of an alert.
+	alertRetryDelay = time.Minute
+
+	// states of the balance rule
+	alertStateBelow = "below"
+	alertStateAbove = "above"
+)
+
+// alertMonitor represents a service evaluating alert rules of API clients on new blocks.
+// Alerts with a webhook are posted by a pool of delivery workers so a slow webhook
+// does not hold the evaluation; undelivered alerts are retried until they get too old.
+type alertMonitor struct {
+	service
+	queue chan *types.Alert
+
+	// retry holds the earliest time of the next delivery attempt of known undelivered alerts
+	mu    sync.Mutex
+	retry map[string]time.Time
+}
+
+// newAlertMonitor creates a new alert monitor service.
+func newAlertMonitor(repo Repository, log logger.Logger, wg *sync.WaitGroup) *alertMonitor {
+	return &alertMonitor{
+		service: newPeriodicService("alert monitor", repo, log, wg),
+		queue:   make(chan *types.Alert, alertDeliveryQueueLength),
+		retry:   make(map[string]time.Time),
+	}
+}
+
+// run starts the alert monitor service
+func (am *alertMonitor) run() {
+	am.wg.Add(1 + alertDeliveryWorkers)
+	for i := 0; i < alertDeliveryWorkers; i++ {
+		go am.deliver()
+	}
+	go am.schedule()
+}
+
+// schedule schedules regular evaluation of alert rules.
+func (am *alertMonitor) schedule() {
+	// inform about the service
+	am.log.Notice("alert monitor is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		am.log.Notice("alert monitor is closed")
+		am.wg.Done()
+	}()
+
+	// run on schedule
+	am.loop(func() {
+		am.exclusive(func() {
+			if err := am.repo.EvaluateAlertRules(); err != nil {
+				am.log.Errorf("can not evaluate alert rules; %s", err.Error())
+			}
+			am.dispatch()
+		})
+	})
+}
+
+// dispatch queues undelivered alerts for delivery; both the new ones
+// and the ones failed before and due for another attempt.
+func (am *alertMonitor) dispatch() {
+	now := time.Now().UTC()
+	list, err := am.repo.UndeliveredAlerts(now.Add(-alertDeliveryWindow), alertDeliveryBatch)
+	if err != nil {
+		am.log.Errorf("can not load undelivered alerts; %s", err.Error())
+		return
+	}
+
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	// forget alerts not pending anymore
+	pending := make(map[string]bool, len(list))
+	for _, al := range list {
+		pending[al.Pk()] = true
+	}
+	for pk, at := range am.retry {
+		if !pending[pk] && at.Before(now) {
+			delete(am.retry, pk)
+		}
+	}
+
+	for _, al := range list {
+		if at, ok := am.retry[al.Pk()]; ok && now.Before(at) {
+			continue
+		}
+
+		select {
+		case am.queue <- al:
+			// block the next attempt until the delivery is done
+			am.retry[al.Pk()] = now.Add(alertDeliveryWindow)
+		default:
+			// the queue is full; the rest waits for the next run
+			return
+		}
+	}
+}
+
+// deliver posts queued alerts to their webhooks until the service is closed.
+func (am *alertMonitor) deliver() {
+	defer am.wg.Done()
+
+	for {
+		select {
+		case <-am.sigStop:
+			return
+		case al := <-am.queue:
+			err := am.repo.DeliverAlert(al)
+
+			am.mu.Lock()
+			if err != nil {
+				am.log.Warningf("can not deliver alert %s; %s", al.Pk(), err.Error())
+				am.retry[al.Pk()] = time.Now().UTC().Add(alertRetryDelay)
+			} else {
+				delete(am.retry, al.Pk())
+			}
+			am.mu.Unlock()
+		}
+	}
+}
+
+// AddAlertRule registers the alert rule of its owner.
+func (p *proxy) AddAlertRule(ar *types.AlertRule) error {
+	return p.db.AddAlertRule(ar)
+}
+
+// RemoveAlertRule removes the alert rule of the given owner.
+func (p *proxy) RemoveAlertRule(owner string, id string) (bool, error) {
+	return p.db.RemoveAlertRule(owner, id)
+}
+
+// AlertRules loads the alert rules of the given owner.
+func (p *proxy) AlertRules(owner string) ([]*types.AlertRule, error) {
+	return p.db.AlertRules(owner)
+}
+
+// AlertRulesSize provides the number of alert rules of the given owner.
+func (p *proxy) AlertRulesSize(owner string) (int64, error) {
+	return p.db.AlertRulesSize(owner)
+}
+
+// Alerts loads up to the given number of alerts of the given owner raised since the given time.
+func (p *proxy) Alerts(owner string, since time.Time, limit int64) ([]*types.Alert, error) {
+	return p.db.Alerts(owner, since, limit)
+}
+
+// UndeliveredAlerts loads up to the given number of alerts with a webhook raised
+// since the given time and not delivered yet.
+func (p *proxy) UndeliveredAlerts(since time.Time, limit int64) ([]*types.Alert, error) {
+	return p.db.UndeliveredAlerts(since, limit)
+}
+
+// DeliverAlert posts the alert to its webhook and marks it delivered.
+func (p *proxy) DeliverAlert(al *types.Alert) error {
+	if err := postAlert(al.Webhook, al); err != nil {
+		return err
+	}
+	return p.db.SetAlertDelivered(al)
+}
+
+// EvaluateAlertRules evaluates all the registered alert rules on the blocks
+// processed since the previous evaluation and raises alerts of the rules met.
+func (p *proxy) EvaluateAlertRules() error {
+	head, err := p.LastKnownBlock()
+	if err != nil {
+		return err
+	}
+
+	rules, err := p.db.AlertRules("")
+	if err != nil {
+		return err
+	}
+
+	for _, ar := range rules {
+		alerts, state, block, err := p.evaluateAlertRule(ar, head)
+		if err != nil {
+			p.log.Errorf("can not evaluate alert rule %s; %s", ar.Id, err.Error())
+			continue
+		}
+
+		for _, al := range alerts {
+			if _, err := p.db.AddAlert(al); err != nil {
+				return err
+			}
+		}
+
+		if state != ar.State || block != ar.Block {
+			if err := p.db.SetAlertRuleState(ar.Id, state, block); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// evaluateAlertRule evaluates the alert rule and provides the alerts raised,
+// the new state of the rule and the last block evaluated.
+func (p *proxy) evaluateAlertRule(ar *types.AlertRule, head uint64) ([]*types.Alert, string, uint64, error) {
+	switch ar.Type {
+	case types.AlertRuleBalanceBelow:
+		bal, err := p.AccountBalance(&ar.Address)
+		if err != nil {
+			return nil, ar.State, ar.Block, err
+		}
+
+		// raise the alert when the balance gets below the threshold
+		if bal.ToInt().Cmp(ar.Threshold.ToInt()) >= 0 {
+			return nil, alertStateAbove, head, nil
+		}
+		if ar.State == alertStateBelow {
+			return nil, ar.State, head, nil
+		}
+		return []*types.Alert{newAlert(ar, head, fmt.Sprintf("balance %s is below %s", bal.String(), ar.Threshold.String()))}, alertStateBelow, head, nil
+
+	case types.AlertRuleOwnerChanged:
+		owner := p.rpc.ContractOwner(&ar.Address)
+		if owner == nil {
+			return nil, ar.State, head, nil
+		}
+
+		// the first evaluation just records the current owner
+		if ar.State == "" || ar.State == owner.String() {
+			return nil, owner.String(), head, nil
+		}
+		return []*types.Alert{newAlert(ar, head, fmt.Sprintf("owner changed from %s to %s", ar.State, owner.String()))}, owner.String(), head, nil
+
+	case types.AlertRuleEventEmitted:
+		if head <= alertEventsLag {
+			return nil, ar.State, ar.Block, nil
+		}
+		to := head - alertEventsLag
+
+		// the first evaluation starts with the current block
+		if ar.Block == 0 || ar.Block >= to {
+			if ar.Block == 0 {
+				return nil, ar.State, to, nil
+			}
+			return nil, ar.State, ar.Block, nil
+		}
+
+		evs, err := p.db.ContractEventsIn(&ar.Address, ar.Topic, ar.Block+1, to, alertEventsPerRun)
+		if err != nil {
+			return nil, ar.State, ar.Block, err
+		}
+
+		// too many events; finish the last block loaded and continue with the rest on the next run
+		if len(evs) == alertEventsPerRun {
+			to = evs[len(evs)-1].BlockNumber
+
+			rest, err := p.db.ContractEventsIn(&ar.Address, ar.Topic, to, to, 0)
+			if err != nil {
+				return nil, ar.State, ar.Block, err
+			}
+
+			for len(evs) > 0 && evs[len(evs)-1].BlockNumber == to {
+				evs = evs[:len(evs)-1]
+			}
+			evs = append(evs, rest...)
+		}
+
+		list := make([]*types.Alert, len(evs))
+		for i, ev := range evs {
+			trx := ev.Trx
+			list[i] = newAlert(ar, ev.BlockNumber, fmt.Sprintf("event %s emitted", ev.Topic().String()))
+			list[i].Seq = ev.LogIndex
+			list[i].Trx = &trx
+		}
+		return list, ar.State, to, nil
+	}
+	return nil, ar.State, ar.Block, fmt.Errorf("unknown rule type %s", ar.Type)
+}
+
+// newAlert creates a new alert of the given rule.
+func newAlert(ar *types.AlertRule, block uint64, msg string) *types.Alert {
+	return &types.Alert{
+		Rule:    ar.Id,
+		Owner:   ar.Owner,
+		Type:    ar.Type,
+		Address: ar.Address,
+		Block:   block,
+		Message: msg,
+		Time:    time.Now().UTC(),
+		Webhook: ar.Webhook,
+	}
+}
+
+// postAlert posts the alert to the given webhook URL.
+func postAlert(url string, al *types.Alert) error {
+	body, err := json.Marshal(struct {
+		Rule    string  `json:"rule"`
+		Type    string  `json:"type"`
+		Address string  `json:"address"`
+		Block   uint64  `json:"block"`
+		Trx     *string `json:"trx,omitempty"`
+		Message string  `json:"message"`
+		Time    int64   `json:"time"`
+	}{al.Rule, al.Type, al.Address.String(), al.Block, trxString(al), al.Message, al.Time.Unix()})
+	if err != nil {
+		return err
+	}
+
+	res, err := webhookClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// trxString provides the hash of the alert transaction as a string, if any.
+func trxString(al *types.Alert) *string {
+	if al.Trx == nil {
+		return nil
+	}
+	trx := al.Trx.String()
+	return &trx
+}
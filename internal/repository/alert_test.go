@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository/memdb"
+	"fantom-api-graphql/internal/types"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// alertTestNode is a node providing the balance and the owner monitored by alert rules.
+type alertTestNode struct {
+	Node
+	balance *hexutil.Big
+	owner   *common.Address
+}
+
+// AccountBalance provides the configured balance.
+func (n *alertTestNode) AccountBalance(*common.Address) (*hexutil.Big, error) {
+	return n.balance, nil
+}
+
+// ContractOwner provides the configured contract owner.
+func (n *alertTestNode) ContractOwner(*common.Address) *common.Address {
+	return n.owner
+}
+
+// alertTestCache is a cache never holding an account balance.
+type alertTestCache struct {
+	ObjectCache
+}
+
+// PullAccountBalance never finds the balance.
+func (c alertTestCache) PullAccountBalance(*common.Address) *hexutil.Big {
+	return nil
+}
+
+// PushAccountBalance drops the balance.
+func (c alertTestCache) PushAccountBalance(*common.Address, *hexutil.Big) {}
+
+// newAlertTestProxy creates a proxy backed by the in-memory store and the test node.
+func newAlertTestProxy(t *testing.T) (*proxy, *alertTestNode) {
+	t.Helper()
+	log := logger.New(&config.Config{AppName: "test", Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+	node := &alertTestNode{balance: (*hexutil.Big)(big.NewInt(0))}
+	return &proxy{
+		cache: alertTestCache{},
+		db:    memoryStore{memdb.New(log)},
+		rpc:   node,
+		log:   log,
+	}, node
+}
+
+// setHead sets the last known block of the proxy.
+func setHead(t *testing.T, p *proxy, head uint64) {
+	t.Helper()
+	blk := hexutil.Uint64(head)
+	if err := p.db.UpdateLastKnownBlock(&blk); err != nil {
+		t.Fatalf("can not set head; %s", err.Error())
+	}
+}
+
+// addRule registers the alert rule.
+func addRule(t *testing.T, p *proxy, ar *types.AlertRule) {
+	t.Helper()
+	ar.Owner = "client"
+	ar.Address = common.HexToAddress("0x1000")
+	ar.Created = time.Now().UTC()
+	if err := p.AddAlertRule(ar); err != nil {
+		t.Fatalf("can not add rule; %s", err.Error())
+	}
+}
+
+// evaluate runs the evaluation and provides all the alerts raised so far and the rule state.
+func evaluate(t *testing.T, p *proxy) ([]*types.Alert, *types.AlertRule) {
+	t.Helper()
+	if err := p.EvaluateAlertRules(); err != nil {
+		t.Fatalf("evaluation failed; %s", err.Error())
+	}
+
+	alerts, err := p.Alerts("client", time.Time{}, 1000)
+	if err != nil {
+		t.Fatalf("can not load alerts; %s", err.Error())
+	}
+	rules, err := p.AlertRules("client")
+	if err != nil || len(rules) != 1 {
+		t.Fatalf("can not load rule; %v", err)
+	}
+	return alerts, rules[0]
+}
+
+// addEvents stores the given number of events of the topic emitted in the block.
+func addEvents(t *testing.T, p *proxy, block uint64, topic common.Hash, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		ev := types.ContractEvent{
+			Contract:    common.HexToAddress("0x1000"),
+			Topics:      []common.Hash{topic},
+			Trx:         common.BigToHash(new(big.Int).SetUint64(block)),
+			BlockNumber: block,
+			LogIndex:    uint(i),
+			TimeStamp:   hexutil.Uint64(time.Now().Unix()),
+		}
+		if err := p.db.AddContractEvent(&ev); err != nil {
+			t.Fatalf("can not add event; %s", err.Error())
+		}
+	}
+}
+
+func TestAlertBalanceBelow(t *testing.T) {
+	p, node := newAlertTestProxy(t)
+	addRule(t, p, &types.AlertRule{Id: "bal", Type: types.AlertRuleBalanceBelow, Threshold: (*hexutil.Big)(big.NewInt(100))})
+
+	steps := []struct {
+		head    uint64
+		balance int64
+		alerts  int
+		state   string
+	}{
+		{10, 150, 0, alertStateAbove},
+		{11, 50, 1, alertStateBelow},
+		{12, 20, 1, alertStateBelow},
+		{13, 100, 1, alertStateAbove},
+		{14, 99, 2, alertStateBelow},
+	}
+	for _, st := range steps {
+		setHead(t, p, st.head)
+		node.balance = (*hexutil.Big)(big.NewInt(st.balance))
+
+		alerts, ar := evaluate(t, p)
+		if len(alerts) != st.alerts || ar.State != st.state || ar.Block != st.head {
+			t.Fatalf("block %d: %d alerts, state %s at %d; expected %d alerts, state %s", st.head, len(alerts), ar.State, ar.Block, st.alerts, st.state)
+		}
+	}
+}
+
+func TestAlertOwnerChanged(t *testing.T) {
+	p, node := newAlertTestProxy(t)
+	addRule(t, p, &types.AlertRule{Id: "own", Type: types.AlertRuleOwnerChanged})
+
+	first, second := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	steps := []struct {
+		head   uint64
+		owner  *common.Address
+		alerts int
+		state  string
+	}{
+		{10, &first, 0, first.String()},
+		{11, &first, 0, first.String()},
+		{12, nil, 0, first.String()},
+		{13, &second, 1, second.String()},
+		{14, &second, 1, second.String()},
+	}
+	for _, st := range steps {
+		setHead(t, p, st.head)
+		node.owner = st.owner
+
+		alerts, ar := evaluate(t, p)
+		if len(alerts) != st.alerts || ar.State != st.state {
+			t.Fatalf("block %d: %d alerts, state %s; expected %d alerts, state %s", st.head, len(alerts), ar.State, st.alerts, st.state)
+		}
+	}
+
+	alerts, _ := evaluate(t, p)
+	if !strings.Contains(alerts[0].Message, first.String()) || !strings.Contains(alerts[0].Message, second.String()) {
+		t.Errorf("unexpected message %s", alerts[0].Message)
+	}
+}
+
+func TestAlertEventEmitted(t *testing.T) {
+	p, _ := newAlertTestProxy(t)
+	topic, other := common.HexToHash("0x01"), common.HexToHash("0x02")
+	addRule(t, p, &types.AlertRule{Id: "evt", Type: types.AlertRuleEventEmitted, Topic: &topic, Webhook: "https://example.com/hook"})
+
+	// the first evaluation starts at the head without the lag
+	setHead(t, p, 12)
+	addEvents(t, p, 9, topic, 1)
+	if alerts, ar := evaluate(t, p); len(alerts) != 0 || ar.Block != 10 {
+		t.Fatalf("first run raised %d alerts, block %d", len(alerts), ar.Block)
+	}
+
+	// events of the lagging blocks are left for later
+	addEvents(t, p, 11, topic, 2)
+	addEvents(t, p, 12, other, 1)
+	addEvents(t, p, 14, topic, 1)
+	setHead(t, p, 15)
+	alerts, ar := evaluate(t, p)
+	if len(alerts) != 2 || ar.Block != 13 {
+		t.Fatalf("second run raised %d alerts, block %d", len(alerts), ar.Block)
+	}
+	for i, al := range alerts {
+		if al.Block != 11 || al.Seq != uint(i) || al.Trx == nil || al.Webhook != "https://example.com/hook" || al.Delivered {
+			t.Errorf("unexpected alert %+v", al)
+		}
+	}
+
+	setHead(t, p, 16)
+	if alerts, ar := evaluate(t, p); len(alerts) != 3 || ar.Block != 14 {
+		t.Fatalf("third run raised %d alerts, block %d", len(alerts), ar.Block)
+	}
+}
+
+func TestAlertEventPaging(t *testing.T) {
+	p, _ := newAlertTestProxy(t)
+	topic := common.HexToHash("0x01")
+	addRule(t, p, &types.AlertRule{Id: "evt", Type: types.AlertRuleEventEmitted, Topic: &topic})
+
+	setHead(t, p, 12)
+	evaluate(t, p)
+
+	// the page ends inside a block; the block is finished on the same run
+	addEvents(t, p, 11, topic, 30)
+	addEvents(t, p, 12, topic, 40)
+	addEvents(t, p, 13, topic, 5)
+	setHead(t, p, 100)
+
+	alerts, ar := evaluate(t, p)
+	if len(alerts) != 70 || ar.Block != 12 {
+		t.Fatalf("first page raised %d alerts, block %d", len(alerts), ar.Block)
+	}
+
+	// a single block with more events than the page
+	addEvents(t, p, 20, topic, alertEventsPerRun+25)
+	alerts, ar = evaluate(t, p)
+	if len(alerts) != 75+alertEventsPerRun+25 || ar.Block != 20 {
+		t.Fatalf("second page raised %d alerts, block %d", len(alerts), ar.Block)
+	}
+
+	// the rest fits the page, the rule catches up with the head
+	alerts, ar = evaluate(t, p)
+	if len(alerts) != 75+alertEventsPerRun+25 || ar.Block != 98 {
+		t.Fatalf("last page raised %d alerts, block %d", len(alerts), ar.Block)
+	}
+
+	seen := make(map[string]bool, len(alerts))
+	for _, al := range alerts {
+		if seen[al.Pk()] {
+			t.Fatalf("alert %s raised twice", al.Pk())
+		}
+		seen[al.Pk()] = true
+	}
+}
+
+func TestAlertDispatch(t *testing.T) {
+	p, _ := newAlertTestProxy(t)
+	addRule(t, p, &types.AlertRule{Id: "bal", Type: types.AlertRuleBalanceBelow, Threshold: (*hexutil.Big)(big.NewInt(100)), Webhook: "https://127.0.0.1/hook"})
+
+	setHead(t, p, 10)
+	evaluate(t, p)
+
+	list, err := p.UndeliveredAlerts(time.Now().Add(-time.Minute), 10)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("expected one undelivered alert; %d, %v", len(list), err)
+	}
+
+	am := newAlertMonitor(p, p.log, new(sync.WaitGroup))
+	am.dispatch()
+	if len(am.queue) != 1 {
+		t.Fatalf("expected one queued alert, got %d", len(am.queue))
+	}
+
+	// the alert is not queued again while the delivery is pending
+	am.dispatch()
+	if len(am.queue) != 1 {
+		t.Fatalf("alert queued twice")
+	}
+
+	// the failed delivery is retried after the delay
+	am.setSchedule(time.Hour, 0)
+	am.run()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		am.mu.Lock()
+		at := am.retry[list[0].Pk()]
+		am.mu.Unlock()
+		if at.Before(time.Now().Add(alertRetryDelay + time.Second)) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("delivery not attempted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	am.close()
+	am.wg.Wait()
+
+	if list, _ := p.UndeliveredAlerts(time.Now().Add(-time.Minute), 10); len(list) != 1 {
+		t.Errorf("failed delivery marked delivered")
+	}
+}
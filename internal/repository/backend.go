@@ -0,0 +1,1316 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/repository/cache"
+	"fantom-api-graphql/internal/repository/db"
+	"fantom-api-graphql/internal/repository/memdb"
+	"fantom-api-graphql/internal/repository/rpc"
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fantom-api-graphql/internal/types"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ftm "github.com/ethereum/go-ethereum/rpc"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Backends represents the set of backends the repository is built on.
+// Each of them can be replaced by an alternative implementation;
+// backends not provided are connected using the configuration.
+type Backends struct {
+	Cache ObjectCache
+	Store Store
+	Node  Node
+}
+
+// Store represents the persistent storage backend of the repository.
+type Store interface {
+	BlockStore
+	TrxStore
+	AccountStore
+	ContractStore
+	StakingStore
+	DefiStore
+	ModerationStore
+	ServiceStore
+
+	// WithContext provides the store with the operations bound to the given context;
+	// a store not able to cancel its operations may return itself.
+	WithContext(ctx context.Context) Store
+}
+
+// Node represents the blockchain node backend of the repository.
+type Node interface {
+	ChainNode
+	StakingNode
+	TokenNode
+	GovernanceNode
+
+	// WithContext provides the node with the calls bound to the given context;
+	// a node not able to cancel its calls may return itself.
+	WithContext(ctx context.Context) Node
+}
+
+// mongoStore adapts the Mongo database bridge to the Store backend.
+type mongoStore struct {
+	*db.MongoDbBridge
+}
+
+// WithContext provides the store with the database operations bound to the given context.
+func (ms mongoStore) WithContext(ctx context.Context) Store {
+	return mongoStore{ms.MongoDbBridge.WithContext(ctx)}
+}
+
+// StorageInfo describes the Mongo database storage.
+func (ms mongoStore) StorageInfo() types.StorageInfo {
+	return types.StorageInfo{Driver: config.DbDriverMongo, Persistent: true, Limits: []string{}}
+}
+
+// memoryStore adapts the in-memory storage bridge to the Store backend.
+type memoryStore struct {
+	*memdb.MemDbBridge
+}
+
+// WithContext provides the store itself; the in-memory operations do not block
+// and need no cancellation.
+func (ms memoryStore) WithContext(_ context.Context) Store {
+	return ms
+}
+
+// ftmNode adapts the Lachesis RPC bridge to the Node backend.
+type ftmNode struct {
+	*rpc.FtmBridge
+}
+
+// WithContext provides the node with the calls bound to the given context.
+func (fn ftmNode) WithContext(ctx context.Context) Node {
+	return ftmNode{fn.FtmBridge.WithContext(ctx)}
+}
+
+// memCache adapts the BigCache bridge to the ObjectCache backend.
+type memCache struct {
+	*cache.MemBridge
+}
+
+// WithContext provides the cache with the calls counted for the operation of the given context.
+func (mc memCache) WithContext(ctx context.Context) ObjectCache {
+	return memCache{mc.MemBridge.WithContext(ctx)}
+}
+
+// BlockStore represents the persistent storage of block related off-chain data.
+type BlockStore interface {
+	// AddBlockBloom merges the given address bloom into the bloom of the block.
+	// The bloom words are merged by the database so the update is safe
+	// for concurrent transactions of the same block.
+	AddBlockBloom(block uint64, bl *types.AddressBloom) error
+
+	// BlockBloomFrom provides the first block with the address bloom indexed.
+	// The flag is FALSE if the bloom index was not started yet.
+	BlockBloomFrom() (uint64, bool, error)
+
+	// BlockBloomMatch checks if any block of the given inclusive range has the bloom
+	// matching all the bits of the given address bloom.
+	BlockBloomMatch(bl *types.AddressBloom, from uint64, to uint64) (bool, error)
+
+	// AddBlockFinality stores the given block finality record in the database.
+	AddBlockFinality(bf *types.BlockFinality) error
+
+	// BlockFinality loads the finality record of the given block.
+	// It returns nil if the finality of the block was not observed.
+	BlockFinality(block uint64) (*types.BlockFinality, error)
+
+	// BlockFinalityList loads the finality records of blocks created since the given time,
+	// ordered by the block number.
+	BlockFinalityList(since time.Time) ([]*types.BlockFinality, error)
+
+	// LastKnownBlock returns the last known block from the database.
+	LastKnownBlock() (uint64, error)
+
+	// UpdateLastKnownBlock stores the last known block into the config collection.
+	UpdateLastKnownBlock(blockNo *hexutil.Uint64) error
+}
+
+// TrxStore represents the persistent storage of transactions and their aggregations.
+type TrxStore interface {
+	// AddTransaction stores a transaction reference in connected persistent storage.
+	AddTransaction(block *types.Block, trx *types.Transaction) error
+
+	// LargeTransactions pulls list of transactions transferring at least the given value
+	// starting on the specified cursor.
+	LargeTransactions(minValue *big.Int, cursor *string, count int32) (*types.TransactionList, error)
+
+	// LastBlockBefore returns the number of the last known block containing
+	// a transaction stamped at or before the given time.
+	LastBlockBefore(ts time.Time) (uint64, error)
+
+	// Transactions pulls list of transaction hashes starting on the specified cursor.
+	Transactions(cursor *string, count int32, filter *bson.D) (*types.TransactionList, error)
+
+	// TransactionsCount returns the number of transactions stored in the database.
+	TransactionsCount() (uint64, error)
+
+	// AccountTransactions loads list of transactions of an account.
+	AccountTransactions(addr *common.Address, cursor *string, count int32) (*types.TransactionList, error)
+
+	// AddERC20Transaction stores an ERC20 transaction in the database if it doesn't exist.
+	AddERC20Transaction(trx *types.Erc20Transaction) error
+
+	// Erc20Approvals loads the latest approval of each token and spender pair
+	// granted by the given owner.
+	Erc20Approvals(owner *common.Address) ([]*types.Erc20Transaction, error)
+
+	// Erc20Transactions pulls list of ERC20 transactions starting at the specified cursor.
+	Erc20Transactions(cursor *string, count int32, filter *bson.D) (*types.Erc20TransactionList, error)
+
+	// TrxDailyFlowList loads a range of daily trx volumes from the database.
+	TrxDailyFlowList(from *time.Time, to *time.Time) ([]*types.DailyTrxVolume, error)
+
+	// TrxDailyFlowUpdate performs an update on the daily trx flow data
+	// for the given date range directly.
+	TrxDailyFlowUpdate(from time.Time) error
+
+	// TrxGasSpeed provides amount of gas consumed by transaction per second
+	// in the given time range.
+	TrxGasSpeed(from *time.Time, to *time.Time) (float64, error)
+
+	// TrxRangeStats calculates the number of transactions and the total amount of gas
+	// consumed by transactions with time stamp in the given time range (from, to].
+	TrxRangeStats(from time.Time, to time.Time) (uint64, uint64, error)
+
+	// TrxRecentTrxSpeed provides the number of transaction per second on the defined range in seconds.
+	TrxRecentTrxSpeed(sec int32) (float64, error)
+}
+
+// AccountStore represents the persistent storage of accounts and their relations.
+type AccountStore interface {
+	// Account tries to load an account identified by the address given from
+	// the off-chain database.
+	Account(addr *common.Address) (*types.Account, error)
+
+	// AccountCount calculates total number of accounts in the database.
+	AccountCount() (uint64, error)
+
+	// AccountMarkActivity marks the latest account activity in the repository.
+	AccountMarkActivity(addr *common.Address, ts uint64) error
+
+	// AccountsToClassify loads a batch of contract accounts
+	// which have not been classified yet.
+	AccountsToClassify(limit int64) ([]common.Address, error)
+
+	// AddAccount stores an account in the blockchain if not exists.
+	AddAccount(acc *types.Account) error
+
+	// Erc20TokensList returns a list of known ERC20 tokens ordered by their activity.
+	Erc20TokensList(count int32) ([]common.Address, error)
+
+	// IsAccountKnown checks if an account document already exists in the database.
+	IsAccountKnown(addr *common.Address) (bool, error)
+
+	// UpdateAccountClass updates the contract type and the list of interfaces
+	// detected on the given account.
+	UpdateAccountClass(addr *common.Address, cType string, ifs []string) error
+
+	// AccountLinks loads the funder and the deployer of the given account, if known.
+	AccountLinks(addr *common.Address) (funder *common.Address, deployer *common.Address, err error)
+
+	// AccountsDeployedBy loads contract accounts deployed by the given address.
+	AccountsDeployedBy(deployer *common.Address, limit int64) ([]common.Address, error)
+
+	// AccountsFundedBy loads accounts which received their first native tokens from the given address.
+	AccountsFundedBy(funder *common.Address, limit int64) ([]common.Address, error)
+
+	// AccountsToLink loads a batch of accounts which have not been linked
+	// to their funder and deployer yet.
+	AccountsToLink(limit int64) ([]common.Address, error)
+
+	// FirstFunder finds the sender of the first transaction transferring
+	// native tokens to the given address; nil if there is none.
+	FirstFunder(addr *common.Address) (*common.Address, error)
+
+	// UpdateAccountLinks stores the funder and the deployer of the given account.
+	// Accounts without a known funder are marked so they are not linked again.
+	UpdateAccountLinks(addr *common.Address, funder *common.Address, deployer *common.Address) error
+
+	// AddressLabel loads the label of the given address from the database.
+	// It returns nil if the address does not have any label assigned.
+	AddressLabel(addr *common.Address) (*types.AddressLabel, error)
+
+	// RemoveAddressLabel removes the label of the given address from the database.
+	RemoveAddressLabel(addr *common.Address) error
+
+	// StoreAddressLabel inserts, or replaces the given address label in the database.
+	StoreAddressLabel(al *types.AddressLabel) error
+
+	// AddWatchDigest stores the activity digest of a watched address.
+	AddWatchDigest(wd *types.WatchDigest) error
+
+	// AddWatchedAddress stores the address on the watch list of its owner.
+	// Adding an address already watched updates its label.
+	AddWatchedAddress(wa *types.WatchedAddress) error
+
+	// RemoveWatchedAddress removes the address from the watch list of the given owner.
+	// It returns false if the address was not on the list.
+	RemoveWatchedAddress(owner string, addr *common.Address) (bool, error)
+
+	// SetWatchDigestState stores the end of the last watch digest period processed.
+	SetWatchDigestState(to time.Time) error
+
+	// WatchDigestCompute aggregates the activity of the given address in the given period.
+	WatchDigestCompute(addr *common.Address, from time.Time, to time.Time) (*types.WatchDigest, error)
+
+	// WatchDigestState provides the end of the last watch digest period processed.
+	// Zero time is returned if no digest has been made yet.
+	WatchDigestState() (time.Time, error)
+
+	// WatchDigests sums the stored activity digests of the given addresses since the given time.
+	// Addresses without any activity in the period are not included.
+	WatchDigests(addrs []common.Address, since time.Time) ([]*types.WatchDigest, error)
+
+	// WatchList loads the watch list of the given owner, oldest addresses first.
+	WatchList(owner string) ([]*types.WatchedAddress, error)
+
+	// WatchListSize calculates the number of addresses on the watch list of the given owner.
+	WatchListSize(owner string) (int64, error)
+
+	// WatchedAddresses loads all the addresses watched by any of the clients.
+	WatchedAddresses() ([]common.Address, error)
+
+	// AddAlert stores the raised alert in the database.
+	// Returns FALSE if the same alert has already been raised.
+	AddAlert(al *types.Alert) (bool, error)
+
+	// AddAlertRule stores the alert rule in the database.
+	AddAlertRule(ar *types.AlertRule) error
+
+	// AlertRules loads the alert rules of the given owner; all the rules if the owner is empty.
+	AlertRules(owner string) ([]*types.AlertRule, error)
+
+	// AlertRulesSize calculates the number of alert rules of the given owner.
+	AlertRulesSize(owner string) (int64, error)
+
+	// Alerts loads alerts of the given owner raised since the given time, oldest first.
+	Alerts(owner string, since time.Time, limit int64) ([]*types.Alert, error)
+
+	// RemoveAlertRule removes the alert rule of the given owner from the database.
+	RemoveAlertRule(owner string, id string) (bool, error)
+
+	// SetAlertDelivered marks the alert as delivered to the webhook of its rule.
+	SetAlertDelivered(al *types.Alert) error
+
+	// SetAlertRuleState updates the last evaluated state of the alert rule.
+	SetAlertRuleState(id string, state string, block uint64) error
+
+	// UndeliveredAlerts loads alerts with a webhook raised since the given time
+	// and not delivered yet, oldest first.
+	UndeliveredAlerts(since time.Time, limit int64) ([]*types.Alert, error)
+}
+
+// ContractStore represents the persistent storage of smart contracts and their events.
+type ContractStore interface {
+	// AddContract stores a smart contract reference in connected persistent storage.
+	AddContract(sc *types.Contract) error
+
+	// Contract returns details of a smart contract stored in the Mongo database
+	// if available, or nil if contract does not exist.
+	Contract(addr *common.Address) (*types.Contract, error)
+
+	// ContractTransaction returns contract creation transaction hash if available.
+	ContractTransaction(addr *common.Address) (*common.Hash, error)
+
+	// Contracts provides list of smart contracts stored in the persistent storage.
+	Contracts(validatedOnly bool, cursor *string, count int32) (*types.ContractList, error)
+
+	// IsContractKnown checks if a smart contract document already exists in the database.
+	IsContractKnown(addr *common.Address) bool
+
+	// UpdateContract updates smart contract information in database to reflect
+	// new validation or similar changes passed from repository.
+	UpdateContract(sc *types.Contract) error
+
+	// ContractArtifact loads the compilation artifact of the given contract.
+	// Nil is returned if the contract has no artifact stored.
+	ContractArtifact(addr *common.Address) (*types.ContractArtifact, error)
+
+	// StoreContractArtifact stores the compilation artifact of a validated contract.
+	// An artifact of previous validation of the contract is replaced.
+	StoreContractArtifact(ca *types.ContractArtifact) error
+
+	// ContractsByCodeHash loads contracts with the given runtime byte code hash,
+	// except the given contract. The newest contracts go first.
+	ContractsByCodeHash(hash *common.Hash, except *common.Address, limit int64) ([]*types.Contract, error)
+
+	// ContractsToPropagate finds directly validated contracts which have not validated
+	// contracts with identical runtime byte code deployed. The given code hash is skipped.
+	ContractsToPropagate(skip *common.Hash, limit int64) ([]common.Address, error)
+
+	// ContractsWithoutCodeHash loads a batch of contracts which do not have
+	// the hash of their runtime byte code known yet.
+	ContractsWithoutCodeHash(limit int64) ([]common.Address, error)
+
+	// SetContractCodeHash stores the hash of the runtime byte code of the given contract.
+	SetContractCodeHash(addr *common.Address, hash *common.Hash) error
+
+	// UnvalidatedContractsByCodeHash loads not validated contracts with the given
+	// runtime byte code hash. The newest contracts go first.
+	UnvalidatedContractsByCodeHash(hash *common.Hash, limit int64) ([]*types.Contract, error)
+
+	// AddContractCreation stores the given contract creation in the database.
+	AddContractCreation(cc *types.ContractCreation) error
+
+	// ContractCreation loads the creation record of the given contract.
+	// It returns nil if the contract was not created by another contract.
+	ContractCreation(addr *common.Address) (*types.ContractCreation, error)
+
+	// ContractCreationsBy loads the most recent contracts created by the given creator contract.
+	ContractCreationsBy(creator *common.Address, limit int64) ([]*types.ContractCreation, error)
+
+	// AddContractEvent stores a contract event in the database and counts it
+	// in the daily statistics of the contract. The event is identified by the transaction
+	// and the log index, so re-processing the same event log doesn't count it again.
+	AddContractEvent(ev *types.ContractEvent) error
+
+	// ContractEventStats loads daily counters of events of the given contract since the given day,
+	// sorted by the day.
+	ContractEventStats(addr *common.Address, since time.Time) ([]*types.ContractEventStat, error)
+
+	// ContractEvents pulls list of contract events starting at the specified cursor.
+	ContractEvents(cursor *string, count int32, filter *bson.D) (*types.ContractEventList, error)
+
+	// ContractEventsIn loads events of the given signature emitted by the given contract
+	// in the given range of blocks, oldest first.
+	ContractEventsIn(addr *common.Address, topic *common.Hash, from uint64, to uint64, limit int64) ([]*types.ContractEvent, error)
+}
+
+// StakingStore represents the persistent storage of staking and epochs related data.
+type StakingStore interface {
+	// AddDelegation stores a delegation in the database if it doesn't exist.
+	AddDelegation(dl *types.Delegation) error
+
+	// Delegation returns details of a delegation from an address to a validator ID.
+	Delegation(addr *common.Address, valID *hexutil.Big) (*types.Delegation, error)
+
+	// Delegations pulls list of delegations starting at the specified cursor.
+	Delegations(cursor *string, count int32, filter *bson.D) (*types.DelegationList, error)
+
+	// DelegationsAll pulls list of delegations for the given filter un-paged.
+	DelegationsAll(filter *bson.D) ([]*types.Delegation, error)
+
+	// DelegationsCountFiltered calculates total number of delegations in the database for the given filter.
+	DelegationsCountFiltered(filter *bson.D) (uint64, error)
+
+	// DelegatorsCountByValidator calculates the number of active delegations of each validator.
+	// The resulting map is indexed by the hex encoded validator ID.
+	DelegatorsCountByValidator() (map[string]uint64, error)
+
+	// UpdateDelegationBalance updates the given delegation active balance in database to the given amount.
+	UpdateDelegationBalance(addr *common.Address, valID *hexutil.Big, amo *hexutil.Big) error
+
+	// AddDelegationOperation stores a delegation operation in the database.
+	// The operation is identified by the transaction and the log index, so re-processing
+	// the same event log just replaces the existing record.
+	AddDelegationOperation(op *types.DelegationOperation) error
+
+	// DelegationOperations pulls list of delegation operations starting at the specified cursor.
+	DelegationOperations(cursor *string, count int32, filter *bson.D) (*types.DelegationOperationList, error)
+
+	// AddEpoch stores an epoch reference in connected persistent storage.
+	AddEpoch(e *types.Epoch) error
+
+	// EpochStats loads aggregated statistics of the given epoch.
+	// It returns nil if the statistics are not available.
+	EpochStats(id hexutil.Uint64) (*types.EpochStats, error)
+
+	// Epochs pulls list of epochs starting at the specified cursor.
+	Epochs(cursor *string, count int32) (*types.EpochList, error)
+
+	// EpochsAfter loads a batch of stored epochs following the given epoch id
+	// sorted from the oldest to the newest.
+	EpochsAfter(id uint64, limit int64) ([]*types.Epoch, error)
+
+	// EpochsWithoutStats loads a batch of the oldest stored epochs
+	// not having aggregated statistics calculated yet.
+	EpochsWithoutStats() ([]*types.Epoch, error)
+
+	// LastKnownEpoch provides the number of the newest epoch stored in the database.
+	LastKnownEpoch() (uint64, error)
+
+	// SetEpochStats stores aggregated statistics of the given epoch.
+	SetEpochStats(id hexutil.Uint64, st *types.EpochStats) error
+
+	// AddRewardClaim stores a reward claim in the database if it doesn't exist.
+	AddRewardClaim(rc *types.RewardClaim) error
+
+	// RewardClaims pulls list of reward claims starting at the specified cursor.
+	RewardClaims(cursor *string, count int32, filter *bson.D) (*types.RewardClaimsList, error)
+
+	// RewardsSumValue calculates sum of values for all the reward claims by a filter.
+	RewardsSumValue(filter *bson.D) (*big.Int, error)
+
+	// RewardsDailyList loads a range of daily reward claims aggregations from the database.
+	RewardsDailyList(from *time.Time, to *time.Time) ([]*types.DailyRewards, error)
+
+	// RewardsDailyUpdate performs an update on the daily rewards aggregation
+	// for the reward claims made after the given time.
+	RewardsDailyUpdate(from time.Time) error
+
+	// StoreValidatorApr stores the realized APR of validators in a sealed epoch.
+	// Records of the same validator and epoch are replaced.
+	StoreValidatorApr(list []*types.ValidatorApr) error
+
+	// ValidatorAprHistory loads the realized APR of the given validator
+	// in the given range of sealed epochs sorted from the oldest epoch.
+	ValidatorAprHistory(valID uint64, from uint64, to uint64) ([]*types.ValidatorApr, error)
+
+	// AddWithdrawal stores a withdraw request in the database if it doesn't exist.
+	AddWithdrawal(wr *types.WithdrawRequest) error
+
+	// UpdateWithdrawal updates the given withdraw request in database.
+	UpdateWithdrawal(wr *types.WithdrawRequest) error
+
+	// Withdrawal returns details of a withdraw request specified by the request ID.
+	Withdrawal(addr *common.Address, valID *hexutil.Big, reqID *hexutil.Big) (*types.WithdrawRequest, error)
+
+	// Withdrawals pulls list of withdraw requests starting at the specified cursor.
+	Withdrawals(cursor *string, count int32, filter *bson.D) (*types.WithdrawRequestList, error)
+
+	// WithdrawalsSumValue calculates sum of values for all the withdrawals by a filter.
+	WithdrawalsSumValue(filter *bson.D) (*big.Int, error)
+
+	// AddFtmSupply stores the FTM supply record of an epoch in the database.
+	AddFtmSupply(fs *types.FtmSupply) error
+
+	// FtmSupplyHistory loads FTM supply records of the given range of epochs
+	// sorted from the oldest to the newest.
+	FtmSupplyHistory(from uint64, to uint64) ([]*types.FtmSupply, error)
+
+	// LastFtmSupply loads the FTM supply record of the latest tracked epoch.
+	// It returns nil if no supply has been tracked yet.
+	LastFtmSupply() (*types.FtmSupply, error)
+}
+
+// DefiStore represents the persistent storage of DeFi, token, and governance related data.
+type DefiStore interface {
+	// AddFMintAccount stores the address of an fMint account in the database, if it's not known yet.
+	AddFMintAccount(addr *common.Address) error
+
+	// AddFMintStats stores a snapshot of the fMint protocol metrics in the database.
+	AddFMintStats(fs *types.FMintStats) error
+
+	// FMintAccounts loads the addresses of all the indexed fMint accounts.
+	FMintAccounts() ([]common.Address, error)
+
+	// FMintAccountsBelowRatio loads a page of the states of fMint accounts with a debt
+	// and the collateral ratio below the given ratio, sorted from the lowest ratio.
+	// The page starts after the given cursor, if any.
+	FMintAccountsBelowRatio(ratio4 uint64, cursor *string, count int32) (*types.FMintAccountStateList, error)
+
+	// LastFMintStats loads the latest snapshot of the fMint protocol metrics.
+	// It returns nil if no snapshot has been aggregated yet.
+	LastFMintStats() (*types.FMintStats, error)
+
+	// SetFMintAccountState updates the last known state of an indexed fMint account.
+	SetFMintAccountState(fa *types.FMintAccountState) error
+
+	// LastKnownSwapBlock returns number of the last known block stored in the database.
+	LastKnownSwapBlock() (uint64, error)
+
+	// UniswapActions provides list of uniswap actions stored in the persistent storage.
+	UniswapActions(pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error)
+
+	// UniswapAdd stores a swap reference in connected persistent storage.
+	UniswapAdd(swap *types.Swap) error
+
+	// UniswapPositionEntries aggregates liquidity added and removed by the given
+	// owner on each Uniswap pair using the stored Mint and Burn events.
+	UniswapPositionEntries(owner *common.Address) ([]*types.UniswapPositionEntry, error)
+
+	// UniswapTimePrices resolves price of swap trades for specified pair grouped by date interval.
+	// If toTime is 0, then it calculates prices till now
+	UniswapTimePrices(pairAddress *common.Address, resolution string, fromTime int64, toTime int64, direction int32) ([]types.DefiTimePrice, error)
+
+	// UniswapTimeReserves resolves reserves of uniswap trades for specified pair grouped by date interval.
+	// If toTime is 0, then it calculates prices till now
+	UniswapTimeReserves(pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiTimeReserve, error)
+
+	// UniswapTimeVolumes resolves volumes of swap trades for specified pair grouped by date interval.
+	// If toTime is 0, then it calculates volumes till now
+	UniswapTimeVolumes(pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiSwapVolume, error)
+
+	// UniswapUpdateLastKnownSwapBlock stores a last correctly saved swap block number into persistent storage.
+	UniswapUpdateLastKnownSwapBlock(blkNumber uint64) error
+
+	// UniswapVolume resolves volume of swap trades for specified pair and date interval.
+	// If toTime is 0, then it calculates volumes till now
+	UniswapVolume(pairAddress *common.Address, fromTime int64, toTime int64) (types.DefiSwapVolume, error)
+
+	// AddUniswapPair registers a Uniswap pair created by the factory.
+	// It returns FALSE if the pair has already been registered.
+	AddUniswapPair(up *types.UniswapPairCreated) (bool, error)
+
+	// UniswapRegisteredPairs loads addresses of all the registered Uniswap pairs
+	// sorted by the block of their creation.
+	UniswapRegisteredPairs() ([]common.Address, error)
+
+	// AddPriceSample stores the price sample in the price history.
+	// A sample recorded in the same hour replaces the previous one.
+	AddPriceSample(ps *types.PriceSample) error
+
+	// PriceSampleAfter loads the earliest price sample of the target symbol recorded
+	// at, or after, the given time. It returns nil if no such sample is known.
+	PriceSampleAfter(sym string, ts time.Time) (*types.PriceSample, error)
+
+	// PriceSampleBefore loads the latest price sample of the target symbol recorded
+	// at, or before, the given time. It returns nil if no such sample is known.
+	PriceSampleBefore(sym string, ts time.Time) (*types.PriceSample, error)
+
+	// StoreTokenMeta inserts, or replaces the given token metadata in the database.
+	StoreTokenMeta(tm *types.TokenMeta) error
+
+	// TokenMeta loads the metadata of the given token from the database.
+	// It returns nil if the token is not known.
+	TokenMeta(addr *common.Address) (*types.TokenMeta, error)
+
+	// TokenMetaList loads the metadata of all the known tokens from the database.
+	TokenMetaList() ([]*types.TokenMeta, error)
+
+	// AddBallotVote stores the given ballot vote in the database.
+	// A previous vote of the same voter on the same ballot is replaced.
+	AddBallotVote(bv *types.BallotVote) error
+
+	// BallotVote loads the vote of the given voter on the given ballot.
+	// It returns nil if the voter did not vote on the ballot.
+	BallotVote(ballot *common.Address, voter *common.Address) (*types.BallotVote, error)
+
+	// BallotVotersCount calculates the number of voters of each proposal of the given ballot.
+	// The resulting map is indexed by the proposal index.
+	BallotVotersCount(ballot *common.Address) (map[uint64]uint64, error)
+}
+
+// ModerationStore represents the persistent storage of moderation and compliance data.
+type ModerationStore interface {
+	// RemoveRiskFlag removes the risk flag of the given address from the database.
+	RemoveRiskFlag(addr *common.Address) error
+
+	// RiskFlag loads the risk flag of the given address from the database.
+	// It returns nil if the address is not flagged.
+	RiskFlag(addr *common.Address) (*types.RiskFlag, error)
+
+	// StoreRiskFlag inserts, or replaces the given risk flag in the database.
+	StoreRiskFlag(rf *types.RiskFlag) error
+
+	// DenyListMatch checks the given addresses against the denylist stored
+	// in the given collection. It returns the record of the first denied address found,
+	// nil if none of the addresses is denied. The collection is maintained by the operator.
+	DenyListMatch(colName string, list []common.Address) (*types.ScreeningMatch, error)
+}
+
+// ServiceStore represents the persistent storage of the API server internal state.
+type ServiceStore interface {
+	// AcquireServiceLock tries to acquire, or renew, the lock of the given service for the holder.
+	// The lock is granted if it's free, expired, or already held by the same holder.
+	// Returns FALSE if a live lock is held by another holder.
+	AcquireServiceLock(name string, holder string, ttl time.Duration) (bool, error)
+
+	// ReleaseServiceLock releases the lock of the given service if it's held by the holder.
+	ReleaseServiceLock(name string, holder string) error
+
+	// ServiceResult loads the latest result of the given service shared by the leading API instance.
+	// It returns nil if the service did not share any result yet.
+	ServiceResult(name string) ([]byte, error)
+
+	// StoreServiceResult stores the latest result of the given service shared with peer API instances.
+	StoreServiceResult(name string, data []byte) error
+
+	// AddUsage adds the given usage records to the hourly usage of their origins.
+	// It returns the number of records stored.
+	AddUsage(list []*types.UsageRecord) (int, error)
+
+	// UsageReport aggregates the API usage of all the origins in the given time range,
+	// the heaviest users by the compute cost go first.
+	UsageReport(from time.Time, to time.Time) ([]*types.UsageRecord, error)
+
+	// Close will terminate or finish all operations and close the connection to Mongo database.
+	Close()
+
+	// Latency provides the 95th percentile of the latency of recent database commands.
+	Latency() time.Duration
+
+	// Ping checks the database connection and provides the time the database took to respond.
+	Ping() (time.Duration, error)
+
+	// PoolStats provides the statistics of the database connection pool.
+	PoolStats() types.DbPoolStats
+
+	// StorageInfo describes the storage driver and the features it does not provide.
+	StorageInfo() types.StorageInfo
+}
+
+// ChainNode represents the access to blocks, transactions, and accounts of the blockchain node.
+type ChainNode interface {
+	// Close will finish all pending operations and terminate the Lachesis RPC connection
+	Close()
+
+	// Connection returns open Opera/Lachesis connection.
+	Connection() *ftm.Client
+
+	// Latency provides the 95th percentile of the latency of recent node calls.
+	Latency() time.Duration
+
+	// Block returns information about a blockchain block by encoded hex number, or by a type tag.
+	// For tag based loading use predefined BlockType contacts.
+	Block(numTag *string) (*types.Block, error)
+
+	// BlockByHash returns information about a blockchain block by hash.
+	BlockByHash(hash *string) (*types.Block, error)
+
+	// BlockHeight returns the current block height of the Opera blockchain.
+	BlockHeight() (*hexutil.Big, error)
+
+	// ChainId provides the identifier of the chain used to sign transactions.
+	ChainId() (uint64, error)
+
+	// ClientVersion provides the version of the node client software.
+	ClientVersion() (string, error)
+
+	// RpcModules provides the sorted list of RPC API modules enabled on the node.
+	RpcModules() ([]string, error)
+
+	// AtroposCreationTime loads the creation time of the Atropos event of the block
+	// with the given hash in nanoseconds; the block hash is the Atropos event id.
+	AtroposCreationTime(block *common.Hash) (uint64, error)
+
+	// PeerCount provides the number of peers the node is connected to.
+	PeerCount() (uint64, error)
+
+	// AccountBalance reads balance of account from Lachesis node.
+	AccountBalance(addr *common.Address) (*hexutil.Big, error)
+
+	// AccountNonce returns the total number of transaction of account from Lachesis node.
+	AccountNonce(addr *common.Address) (uint64, error)
+
+	// AccountPendingNonce returns the total number of transaction of account from Lachesis node
+	// including transactions waiting in the node transaction pool.
+	AccountPendingNonce(addr *common.Address) (uint64, error)
+
+	// AccountPendingTransactions returns the list of transactions sent by the account
+	// waiting in the node transaction pool to be processed.
+	AccountPendingTransactions(addr *common.Address) ([]*types.Transaction, error)
+
+	// SendTransaction sends raw signed and RLP encoded transaction to the block chain.
+	SendTransaction(tx hexutil.Bytes) (*common.Hash, error)
+
+	// Transaction returns information about a blockchain transaction by hash.
+	Transaction(hash *common.Hash) (*types.Transaction, error)
+
+	// TransactionRaw loads the RLP encoded transaction of the given hash.
+	TransactionRaw(hash *common.Hash) (hexutil.Bytes, error)
+
+	// TransactionReceipt loads the receipt of the transaction of the given hash.
+	// It returns nil if the transaction has not been processed yet.
+	TransactionReceipt(hash *common.Hash) (*types.TransactionReceipt, error)
+
+	// TransactionRevertReason replays the call of the given failed transaction on the state
+	// of the previous block and extracts the revert reason from the error responded by the node.
+	// Returns empty string if the replayed call did not revert, e.g. the transaction
+	// depended on a state changed by an earlier transaction of the same block.
+	TransactionRevertReason(trx *types.Transaction) (string, error)
+
+	// TraceTransaction loads the call trace of the given transaction and collects
+	// contracts deployed by other contracts and accounts involved in internal value
+	// transfers during the transaction processing.
+	TraceTransaction(trx *types.Transaction) (*types.TransactionTrace, error)
+
+	// Erc1271IsValidSignature validates the given signature of the message hash
+	// against an EIP-1271 compliant contract wallet.
+	Erc1271IsValidSignature(wallet *common.Address, hash common.Hash, sig []byte) (bool, error)
+
+	// IsContract checks if the given address holds a deployed contract code.
+	IsContract(addr *common.Address) (bool, error)
+
+	// ContractCode loads the byte code deployed at the given address.
+	ContractCode(addr *common.Address) ([]byte, error)
+
+	// ContractOwner loads the owner of the contract at the given address using
+	// the Ownable owner() call. It returns nil if the contract doesn't respond to the call.
+	ContractOwner(addr *common.Address) *common.Address
+
+	// ProxyImplementation tries to find the implementation address
+	// of a proxy contract with the given byte code. It returns nil
+	// if the contract is not recognized as a proxy.
+	ProxyImplementation(addr *common.Address, code []byte) *common.Address
+
+	// SupportsInterface checks if the contract at the given address
+	// responds positively to ERC-165 query for the given interface id.
+	SupportsInterface(addr *common.Address, id [4]byte) bool
+
+	// GasEstimate calculates the estimated amount of Gas required to perform
+	// transaction described by the input params.
+	GasEstimate(trx *struct {
+		From  *common.Address
+		To    *common.Address
+		Value *hexutil.Big
+		Data  *string
+	}) (*hexutil.Uint64, error)
+
+	// GasPrice resolves the current amount of WEI for single Gas.
+	GasPrice() (hexutil.Uint64, error)
+
+	// FnsEnabled signals if the name service registry is configured.
+	FnsEnabled() bool
+
+	// FnsResolveName resolves the address assigned to the given domain name.
+	// It returns nil if the name is not registered, or no address is assigned to it.
+	FnsResolveName(name string) (*common.Address, error)
+
+	// FnsReverseName resolves the primary domain name of the given address.
+	// The name is verified by the forward resolution to make sure the name
+	// really belongs to the address. It returns empty string if no name is available.
+	FnsReverseName(addr *common.Address) (string, error)
+}
+
+// StakingNode represents the access to the SFC staking contract of the blockchain node.
+type StakingNode interface {
+	// CurrentEpoch extract the current epoch id from SFC smart contract.
+	CurrentEpoch() (hexutil.Uint64, error)
+
+	// CurrentSealedEpoch extract the current sealed epoch id from SFC smart contract.
+	CurrentSealedEpoch() (hexutil.Uint64, error)
+
+	// Epoch extract information about an epoch from SFC smart contract.
+	Epoch(id hexutil.Uint64) (*types.Epoch, error)
+
+	// EpochParticipation provides the number of validators participating on the given
+	// sealed epoch and the number of them being offline during the epoch.
+	EpochParticipation(id hexutil.Uint64) (uint64, uint64, error)
+
+	// EpochRewardPerToken provides the reward per token earned by the validators participating
+	// on the given sealed epoch, i.e. the increase of their accumulated reward per token
+	// since the previous epoch; the map is keyed by the validator ID.
+	EpochRewardPerToken(id hexutil.Uint64) (map[uint64]*big.Int, error)
+
+	// LockingAllowed indicates if the stake locking has been enabled in SFC.
+	LockingAllowed() (bool, error)
+
+	// RewardsAllowed returns if the rewards can be manipulated with.
+	RewardsAllowed() (bool, error)
+
+	// SfcMaxDelegatedRatio extracts a ratio between self delegation and received stake.
+	SfcMaxDelegatedRatio() (*big.Int, error)
+
+	// SfcMaxLockupDuration extracts a maximal lockup duration.
+	SfcMaxLockupDuration() (*big.Int, error)
+
+	// SfcMinLockupDuration extracts a minimal lockup duration.
+	SfcMinLockupDuration() (*big.Int, error)
+
+	// SfcMinValidatorStake extracts a value of minimal validator self stake.
+	SfcMinValidatorStake() (*big.Int, error)
+
+	// SfcVersion returns current version of the SFC contract as a single number.
+	SfcVersion() (hexutil.Uint64, error)
+
+	// SfcWithdrawalPeriodEpochs extracts a minimal number of epochs between un-delegate and withdraw.
+	SfcWithdrawalPeriodEpochs() (*big.Int, error)
+
+	// SfcWithdrawalPeriodTime extracts a minimal number of seconds between un-delegate and withdraw.
+	SfcWithdrawalPeriodTime() (*big.Int, error)
+
+	// TotalStaked returns the total amount of staked tokens.
+	TotalStaked() (*big.Int, error)
+
+	// AmountStakeUnlocked returns the current unlocked amount at stake for the given staker address and target validator.
+	AmountStakeUnlocked(addr *common.Address, valID *big.Int) (*big.Int, error)
+
+	// AmountStaked returns the current amount at stake for the given staker address and target validator
+	AmountStaked(addr *common.Address, valID *big.Int) (*big.Int, error)
+
+	// DelegationLock returns delegation lock information using SFC contract binding.
+	DelegationLock(addr *common.Address, valID *hexutil.Big) (dll *types.DelegationLock, err error)
+
+	// DelegationOutstandingSFTM returns the amount of sFTM tokens for the delegation
+	// identified by the delegator address and the stakerId.
+	DelegationOutstandingSFTM(addr *common.Address, valID *big.Int) (*big.Int, error)
+
+	// DelegationTokenizerUnlocked returns the status of SFC Tokenizer lock
+	// for a delegation identified by the address and staker id.
+	DelegationTokenizerUnlocked(addr *common.Address, valID *big.Int) (bool, error)
+
+	// PendingRewards returns a detail of delegation rewards waiting to be claimed for the given delegation.
+	PendingRewards(addr *common.Address, valID *big.Int) (*types.PendingRewards, error)
+
+	// StakeUnlockPenalty returns the expected penalty of a premature stake unlock.
+	StakeUnlockPenalty(addr *common.Address, valID *big.Int, amount *big.Int) (*big.Int, error)
+
+	// IsValidator returns if the given address is an SFC validator.
+	IsValidator(addr *common.Address) (bool, error)
+
+	// LastValidatorId returns the last staker id in Opera blockchain.
+	LastValidatorId() (uint64, error)
+
+	// SfcValidatorCommission provides the ratio of rewards taken by validators
+	// as their commission from delegations at the given block.
+	// Nil block means the latest state.
+	SfcValidatorCommission(block *big.Int) (*big.Int, error)
+
+	// Validator extract a staker information by numeric id.
+	Validator(valID *big.Int) (*types.Validator, error)
+
+	// ValidatorAddress extract a staker address for the given staker ID.
+	ValidatorAddress(valID *big.Int) (*common.Address, error)
+
+	// ValidatorByAddress extracts a validator information by address.
+	ValidatorByAddress(addr *common.Address) (*types.Validator, error)
+
+	// ValidatorDowntime pulls information about validator downtime from the RPC interface.
+	ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, error)
+
+	// ValidatorEpochAccumulated pulls the accumulated uptime and reward per token
+	// of the given validator at the given sealed epoch.
+	ValidatorEpochAccumulated(valID *hexutil.Big, epoch hexutil.Uint64) (*big.Int, *big.Int, error)
+
+	// ValidatorsCount returns the number of validators in Opera blockchain.
+	ValidatorsCount() (uint64, error)
+
+	// StakerInfo extracts an extended staker information from smart contact by their id.
+	StakerInfo(id *hexutil.Big) (*types.StakerInfo, error)
+}
+
+// TokenNode represents the access to tokens and DeFi contracts of the blockchain node.
+type TokenNode interface {
+	// Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
+	// contract by the token owner.
+	Erc20Allowance(token *common.Address, owner *common.Address, spender *common.Address) (hexutil.Big, error)
+
+	// Erc20BalanceOf loads the current available balance of and ERC20 token identified by the token
+	// contract address for an identified owner address.
+	Erc20BalanceOf(token *common.Address, owner *common.Address) (hexutil.Big, error)
+
+	// Erc20BalancesOf loads the current available balances of the given list of ERC20 tokens
+	// for the identified owner address. Balances are read in batches using the multicall
+	// contract, if available; tokens failing to provide the balance report zero.
+	Erc20BalancesOf(tokens []common.Address, owner *common.Address) ([]hexutil.Big, error)
+
+	// Erc20Decimals provides information about the decimals of the ERC20 token.
+	Erc20Decimals(token *common.Address) (int32, error)
+
+	// Erc20Name provides information about the name of the ERC20 token.
+	Erc20Name(token *common.Address) (string, error)
+
+	// Erc20Symbol provides information about the symbol of the ERC20 token.
+	Erc20Symbol(token *common.Address) (string, error)
+
+	// Erc20TotalSupply provides information about all available tokens
+	Erc20TotalSupply(token *common.Address) (hexutil.Big, error)
+
+	// UniswapPairs returns list of all token pairs managed by Uniswap core.
+	NativeTokenAddress() (*common.Address, error)
+
+	// UniswapAmountsIn resolves a list of input amounts for the given
+	// output amount and a list of tokens to be used to make the swap operation.
+	UniswapAmountsIn(amountOut hexutil.Big, tokens []common.Address) ([]hexutil.Big, error)
+
+	// UniswapAmountsOut resolves a list of output amounts for the given
+	// input amount and a list of tokens to be used to make the swap operation.
+	UniswapAmountsOut(amountIn hexutil.Big, tokens []common.Address) ([]hexutil.Big, error)
+
+	// UniswapCumulativePrices returns list of token cumulative prices of a Uniswap pair.
+	UniswapCumulativePrices(pair *common.Address) ([]hexutil.Big, error)
+
+	// UniswapFactoryContract returns an instance of an Uniswap factory
+	UniswapFactoryContract() (*contracts.UniswapFactory, error)
+
+	// UniswapLastKValue returns the last value of the pool control coefficient.
+	UniswapLastKValue(pair *common.Address) (hexutil.Big, error)
+
+	// UniswapPair returns an address of an Uniswap pair for the given tokens.
+	UniswapPair(tokenA *common.Address, tokenB *common.Address) (*common.Address, error)
+
+	// UniswapPairContract returns instance of this contract according to given pair address
+	UniswapPairContract(pairAddres *common.Address) (*contracts.UniswapPair, error)
+
+	// UniswapPairs returns list of all token pairs managed by Uniswap core.
+	UniswapPairs() ([]common.Address, error)
+
+	// UniswapQuoteInput calculates optimal input on sibling token based on input amount and
+	// self reserves of the analyzed token.
+	UniswapQuoteInput(
+		amountA hexutil.Big,
+		reserveA hexutil.Big,
+		reserveB hexutil.Big,
+	) (hexutil.Big, error)
+
+	// UniswapReserves returns list of token reserve amounts in a Uniswap pair.
+	UniswapReserves(pair *common.Address) ([]hexutil.Big, error)
+
+	// UniswapReservesTimeStamp returns the timestamp of the reserves of a Uniswap pair.
+	UniswapReservesTimeStamp(pair *common.Address) (hexutil.Uint64, error)
+
+	// UniswapTokens returns list of addresses of tokens involved in a Uniswap pair.
+	UniswapTokens(pair *common.Address) ([]common.Address, error)
+
+	// FLendGetLendingPool resolves Lending pool contract instance
+	FLendGetLendingPool() (*contracts.ILendingPool, error)
+
+	// FLendGetLendingPoolReserveData resolves reserve data
+	FLendGetLendingPoolReserveData(assetAddress *common.Address) (*types.ReserveData, error)
+
+	// FLendGetReserveList resolves list of reserve addresses
+	FLendGetReserveList() ([]common.Address, error)
+
+	// FLendGetUserAccountData resolves user account data for fLend
+	FLendGetUserAccountData(userAddress *common.Address) (*types.FLendUserAccountData, error)
+
+	// FLendGetUserDepositHistory resolves deposit event history data for specified user and asset address
+	FLendGetUserDepositHistory(userAddress *common.Address, assetAddress *common.Address) ([]*types.FLendDeposit, error)
+
+	// FMintAccount loads details of a DeFi/fMint protocol account identified by the owner address.
+	FMintAccount(owner *common.Address) (*types.FMintAccount, error)
+
+	// FMintCanClaimRewards resolves the fMint account flag for being allowed
+	// to claim earned rewards.
+	FMintCanClaimRewards(addr *common.Address) (bool, error)
+
+	// FMintCanPushRewards signals if there are any rewards unlocked
+	// on the rewards distribution contract and can be pushed to accounts.
+	FMintCanPushRewards() (bool, error)
+
+	// FMintCanReceiveRewards resolves the fMint account flag for being eligible
+	// to receive earned rewards. If the collateral to debt ration drop below
+	// certain value, earned rewards are burned.
+	FMintCanReceiveRewards(addr *common.Address) (bool, error)
+
+	// FMintRewardsEarned resolves the total amount of rewards
+	// accumulated on the account for the excessive collateral deposits.
+	FMintRewardsEarned(addr *common.Address) (hexutil.Big, error)
+
+	// FMintRewardsSchedule resolves the state of the rewards distribution
+	// relevant to the rewards claiming schedule of the given fMint account.
+	FMintRewardsSchedule(addr *common.Address) (*types.FMintRewardsSchedule, error)
+
+	// FMintRewardsStashed resolves the total amount of rewards
+	// accumulated on the account for the excessive collateral deposits.
+	FMintRewardsStashed(addr *common.Address) (hexutil.Big, error)
+
+	// FMintTokenBalance loads balance of a single DeFi token in fMint contract by it's address.
+	FMintTokenBalance(owner *common.Address, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error)
+
+	// DefiTokenPrice loads the current price of the given token from on-chain price oracle.
+	FMintTokenPrice(token *common.Address) (hexutil.Big, error)
+
+	// FMintTokenTotalBalance loads total balance of a single DeFi token by it's address.
+	FMintTokenTotalBalance(token *common.Address, tp types.DefiTokenType) (hexutil.Big, error)
+
+	// FMintTokenValue loads value of a single DeFi token by it's address in fUSD.
+	FMintTokenValue(owner *common.Address, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error)
+
+	// FMintAccountPositions loads the amounts of the tokens of the given account
+	// locked in the fMint pool of the given type along with their value in fUSD.
+	FMintAccountPositions(owner *common.Address, tp types.DefiTokenType) ([]types.FMintTokenTotal, error)
+
+	// FMintMinterAddress returns the address of the fMint minter contract,
+	// or an empty address if the address is not available.
+	FMintMinterAddress() common.Address
+
+	// FMintPoolTotals loads the total amounts of the tokens locked in the fMint pool
+	// of the given type along with their value and the total value of the pool in fUSD.
+	FMintPoolTotals(tp types.DefiTokenType) ([]types.FMintTokenTotal, hexutil.Big, error)
+
+	// DefiConfiguration resolves the current DeFi contract settings.
+	DefiConfiguration() (*types.DefiSettings, error)
+
+	// DefiToken loads details of a single DeFi token by it's address.
+	DefiToken(token *common.Address) (*types.DefiToken, error)
+
+	// DefiTokens resolves list of DeFi tokens available for the DeFi functions.
+	DefiTokens() ([]types.DefiToken, error)
+
+	// FMintOracleFeed loads the state of the on-chain price feed of the given DeFi token.
+	// The price is the one the fMint protocol uses, i.e. provided by the price oracle proxy;
+	// the feed adapter is detected to provide the time of the last update.
+	FMintOracleFeed(tk *types.DefiToken) (*types.OracleFeed, error)
+}
+
+// GovernanceNode represents the access to governance, ballot, and multisig contracts of the blockchain node.
+type GovernanceNode interface {
+	// GovernanceMaxExecutionPeriod returns the max period in seconds after the voting end
+	// in which an accepted proposal has to be executed in given Governance contract context.
+	GovernanceMaxExecutionPeriod(gov *common.Address) (hexutil.Uint64, error)
+
+	// GovernanceOptionState returns a state of the given option of a proposal.
+	GovernanceOptionState(gov *common.Address, propId *hexutil.Big, optId *hexutil.Big) (*types.GovernanceOptionState, error)
+
+	// GovernanceOptionStates returns a list of states of options of a proposal.
+	GovernanceOptionStates(gov *common.Address, propId *hexutil.Big) ([]*types.GovernanceOptionState, error)
+
+	// GovernanceProposal provides a detail of Proposal of a governance contract
+	// specified by its id.
+	GovernanceProposal(gov *common.Address, id *hexutil.Big) (*types.GovernanceProposal, error)
+
+	// GovernanceProposalFee returns the fee payable for a new proposal
+	// in given Governance contract context.
+	GovernanceProposalFee(gov *common.Address) (hexutil.Big, error)
+
+	// GovernanceProposalState provides a state of Proposal of a governance contract
+	// specified by its id.
+	GovernanceProposalState(gov *common.Address, id *hexutil.Big) (*types.GovernanceProposalState, error)
+
+	// GovernanceProposalsBy loads list of proposals of the given Governance contract.
+	GovernanceProposalsBy(gov *common.Address) ([]*types.GovernanceProposal, error)
+
+	// GovernanceProposalsCount provides the total number of proposals
+	// in a given Governance contract.
+	GovernanceProposalsCount(gov *common.Address) (hexutil.Big, error)
+
+	// GovernanceTotalWeight returns the total available voting weight for all proposals
+	// of a governance contract. The address given must be the Governable contract linked
+	// to the core Governance.
+	GovernanceTotalWeight(ge *common.Address) (*hexutil.Big, error)
+
+	// GovernanceVote provides a single vote in the Governance Proposal context.
+	GovernanceVote(
+		gov *common.Address,
+		propId *hexutil.Big,
+		from *common.Address,
+		delegatedTo *common.Address) (*types.GovernanceVote, error)
+
+	// GovernanceVotingPower returns the voting weight of the given address
+	// on the Governable adapter at the given block. The weight is composed
+	// of the weight received by the address and the weight it holds
+	// on the listed delegation targets. Nil block means the latest state.
+	GovernanceVotingPower(ge *common.Address, addr common.Address, to []common.Address, block *big.Int) (*big.Int, error)
+
+	// Ballot loads details of the ballot contract deployed on the given address.
+	Ballot(addr *common.Address) (*types.Ballot, error)
+
+	// Multisig loads the configuration of a Safe-style multisig wallet at the given address.
+	// The call fails if the contract does not implement the multisig interface.
+	Multisig(addr *common.Address) (*types.Multisig, error)
+}
+
+// ObjectCache represents the in-memory object cache of the repository.
+type ObjectCache interface {
+	// CheckAccountKnown verifies if the cache is aware of the account existence
+	// in the database.
+	CheckAccountKnown(addr *common.Address) *bool
+
+	// EvictAccount removes the account information from the in-memory cache.
+	EvictAccount(addr *common.Address)
+
+	// PullAccount extracts account information from the in-memory cache if available.
+	PullAccount(addr *common.Address) *types.Account
+
+	// PushAccount stores provided account in the in-memory cache.
+	PushAccount(acc *types.Account) error
+
+	// PushAccountKnown caches the known account state.
+	PushAccountKnown(addr *common.Address)
+
+	// OnAccountTouched consumes the account touched event; the state
+	// of the account we keep in the cache is stale and must be evicted.
+	OnAccountTouched(addr *common.Address)
+
+	// OnNewHead consumes the new head event to keep track of the chain head.
+	OnNewHead(blk *types.Block)
+
+	// PullAccountBalance extracts the balance of the given account from the in-memory cache.
+	PullAccountBalance(addr *common.Address) *hexutil.Big
+
+	// PullAccountNonce extracts the nonce of the given account from the in-memory cache.
+	PullAccountNonce(addr *common.Address) *uint64
+
+	// PushAccountBalance stores the balance of the given account in the in-memory cache.
+	PushAccountBalance(addr *common.Address, val *hexutil.Big)
+
+	// PushAccountNonce stores the nonce of the given account in the in-memory cache.
+	PushAccountNonce(addr *common.Address, val uint64)
+
+	// AddBlock adds a new block to the in-memory ring for fast load.
+	AddBlock(blk *types.Block)
+
+	// ListBlocks pulls the list of blocks from the block ring.
+	ListBlocks(length int) []*types.Block
+
+	// PullBlock extracts block information from the in-memory cache if available.
+	PullBlock(key string) *types.Block
+
+	// PushBlock stores provided block in the in-memory cache.
+	PushBlock(key string, blk *types.Block) error
+
+	// Evict removes an entry with the given key from the in-memory cache.
+	// It returns TRUE if the entry existed and has been removed.
+	Evict(key string) bool
+
+	// Stats provides the current statistics of the in-memory cache.
+	Stats() types.CacheStats
+
+	// EvictContract makes sure the contract of the given address
+	// is not kept in the cache.
+	EvictContract(addr *common.Address)
+
+	// PullContract extracts smart contract information from the in-memory cache if available.
+	PullContract(addr *common.Address) *types.Contract
+
+	// PushAccount stores provided account in the in-memory cache.
+	PushContract(sc *types.Contract) error
+
+	// PullDelegation tries to pull delegation from the given address to the given validator
+	// from internal in-memory cache.
+	PullDelegation(adr common.Address, valID *hexutil.Big) *types.Delegation
+
+	// PushDelegation stored the given delegation in memory cache.
+	PushDelegation(dlg *types.Delegation)
+
+	// PullEpoch extracts information about the given Epoch from the in-memory cache if available.
+	PullEpoch(id *hexutil.Uint64) *types.Epoch
+
+	// PullEpochStats extracts statistics of the given Epoch from the in-memory cache if available.
+	PullEpochStats(id hexutil.Uint64) *types.EpochStats
+
+	// PushLastEpoch stores provided latest sealed Epoch in the in-memory cache.
+	PushEpoch(ep *types.Epoch)
+
+	// PushEpochStats stores statistics of the given Epoch in the in-memory cache.
+	PushEpochStats(id hexutil.Uint64, st *types.EpochStats)
+
+	// PullErc20Token extracts ERC20 token information from the in-memory cache if available.
+	PullErc20Token(addr *common.Address) *types.Erc20Token
+
+	// PushErc20Token stores provided ERC20 token in the in-memory cache.
+	PushErc20Token(token *types.Erc20Token) error
+
+	// PullDomainAddress extracts the address of the given domain name from the in-memory cache.
+	PullDomainAddress(name string) *common.Address
+
+	// PullDomainName extracts the domain name of the given address from the in-memory cache.
+	// The returned nil value means the name is not known to the cache; empty name means
+	// the address does not have any domain name.
+	PullDomainName(addr *common.Address) *string
+
+	// PushDomainAddress stores the address of the given domain name in the in-memory cache.
+	PushDomainAddress(name string, addr *common.Address)
+
+	// PushDomainName stores the domain name of the given address in the in-memory cache.
+	PushDomainName(addr *common.Address, name string)
+
+	// PullGovernanceTotalWeight extracts governance total weight information
+	// from the in-memory cache if available.
+	PullGovernanceTotalWeight(gov *common.Address) *hexutil.Big
+
+	// PushGovernanceTotalWeight stores governance total weight information
+	// in the in-memory cache.
+	PushGovernanceTotalWeight(gov *common.Address, val *hexutil.Big) error
+
+	// EvictAddressLabel removes the label of the given address from the in-memory cache.
+	EvictAddressLabel(addr *common.Address)
+
+	// PullAddressLabel extracts the label of the given address from the in-memory cache.
+	// The second return value signals if the cache knows the address; a known address
+	// without a label is represented by nil label.
+	PullAddressLabel(addr *common.Address) (*types.AddressLabel, bool)
+
+	// PushAddressLabel stores the label of the given address in the in-memory cache.
+	// The nil label marks the address as known without any label assigned.
+	PushAddressLabel(addr *common.Address, al *types.AddressLabel)
+
+	// PullPrice extracts price information from the in-memory cache if available.
+	PullPrice(symbol string) *types.Price
+
+	// PushPrice stores provided price in the in-memory cache.
+	PushPrice(sym string, pri *types.Price) error
+
+	// EvictRiskFlag removes the risk flag of the given address from the in-memory cache.
+	EvictRiskFlag(addr *common.Address)
+
+	// PullRiskFlag extracts the risk flag of the given address from the in-memory cache.
+	// The second return value signals if the cache knows the address; a known address
+	// without any flag is represented by nil flag.
+	PullRiskFlag(addr *common.Address) (*types.RiskFlag, bool)
+
+	// PushRiskFlag stores the risk flag of the given address in the in-memory cache.
+	// The nil flag marks the address as known without any flag.
+	PushRiskFlag(addr *common.Address, rf *types.RiskFlag)
+
+	// PullSfcConfig extract the SFC configuration from cache, if possible.
+	PullSfcConfig() *types.SfcConfig
+
+	// PullSfcMaxDelegatedRatio extract the ratio from cache, if possible.
+	PullSfcMaxDelegatedRatio() *big.Int
+
+	// PullValidatorAddress tries to pull the validator address from memory cache.
+	PullValidatorAddress(valID *hexutil.Big) *common.Address
+
+	// PullValidatorDelegatorsCount tries to pull the number of delegators of the given validator
+	// from memory cache. The second value signals if the count was found.
+	PullValidatorDelegatorsCount(valID *hexutil.Big) (uint64, bool)
+
+	// PushSfcConfig stores the SFC configuration, if possible.
+	PushSfcConfig(val *types.SfcConfig)
+
+	// PushSfcMaxDelegatedRatio stores the ratio in cache, if possible.
+	PushSfcMaxDelegatedRatio(val *big.Int)
+
+	// PushValidatorAddress stores validator address in the memory cache.
+	PushValidatorAddress(valID *hexutil.Big, adr *common.Address)
+
+	// PushValidatorDelegatorsCount stores the number of delegators of the given validator in the memory cache.
+	PushValidatorDelegatorsCount(valID *hexutil.Big, count uint64)
+
+	// PullStakerInfo extracts staker information from the in-memory cache if available.
+	PullStakerInfo(id *hexutil.Big) *types.StakerInfo
+
+	// PullTotalStaked extracts total staked amount from the in-memory cache if available.
+	PullTotalStaked() *hexutil.Big
+
+	// PushStakerInfo stores provided staker information in the in-memory cache.
+	PushStakerInfo(id *hexutil.Big, sti *types.StakerInfo) error
+
+	// PushTotalStaked stores provided total staked amount information in the in-memory cache.
+	PushTotalStaked(amount *hexutil.Big) error
+
+	// EvictTokenMeta removes the metadata of the given token from the in-memory cache.
+	EvictTokenMeta(addr *common.Address)
+
+	// PullTokenMeta extracts the metadata of the given token from the in-memory cache.
+	// The second return value signals if the cache knows the token; a known token
+	// without any metadata is represented by nil.
+	PullTokenMeta(addr *common.Address) (*types.TokenMeta, bool)
+
+	// PushTokenMeta stores the metadata of the given token in the in-memory cache.
+	// The nil metadata marks the token as known without any metadata.
+	PushTokenMeta(addr *common.Address, tm *types.TokenMeta)
+
+	// PullTransaction extracts transaction information from the in-memory cache if available.
+	PullTransaction(hash *common.Hash) *types.Transaction
+
+	// PushTransaction stores provided transaction in the in-memory cache.
+	PushTransaction(trx *types.Transaction)
+
+	// AddTransaction adds a new transaction to the in-memory ring for fast load.
+	AddTransaction(trx *types.Transaction)
+
+	// ListTransactions pulls the list of transactions from the trx ring.
+	ListTransactions(length int) []*types.Transaction
+
+	// PullUniswapPairTokens tries to load a uniswap pair tokens from the cache.
+	PullUniswapPairTokens(pair *common.Address) []common.Address
+
+	// PushGovernanceTotalWeight stores governance total weight information
+	// in the in-memory cache.
+	PushUniswapPairTokens(pair *common.Address, tl []common.Address)
+
+	// WithContext provides the cache with the calls counted for the operation of the given context;
+	// a cache not counting its calls may return itself.
+	WithContext(ctx context.Context) ObjectCache
+}
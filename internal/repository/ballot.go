@@ -0,0 +1,99 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"time"
+)
+
+// Ballots provides the list of configured community ballots.
+func (p *proxy) Ballots() ([]*types.Ballot, error) {
+	list := make([]*types.Ballot, 0, len(p.cfg.Governance.Ballots))
+	for i := range p.cfg.Governance.Ballots {
+		bt, err := p.rpc.Ballot(&p.cfg.Governance.Ballots[i])
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, bt)
+	}
+	return list, nil
+}
+
+// Ballot provides details of the configured community ballot on the given address.
+func (p *proxy) Ballot(addr *common.Address) (*types.Ballot, error) {
+	if !p.IsBallot(addr) {
+		return nil, fmt.Errorf("ballot %s not found", addr.String())
+	}
+	return p.rpc.Ballot(addr)
+}
+
+// BallotVote provides the indexed vote of the given voter on the given ballot.
+// It returns nil if the voter did not vote on the ballot.
+func (p *proxy) BallotVote(ballot *common.Address, voter *common.Address) (*types.BallotVote, error) {
+	return p.db.BallotVote(ballot, voter)
+}
+
+// BallotVotersCount provides the number of voters of each proposal of the given ballot.
+func (p *proxy) BallotVotersCount(ballot *common.Address) (map[uint64]uint64, error) {
+	return p.db.BallotVotersCount(ballot)
+}
+
+// IsBallot checks if the given address is a configured community ballot.
+func (p *proxy) IsBallot(addr *common.Address) bool {
+	for _, bt := range p.cfg.Governance.Ballots {
+		if bt == *addr {
+			return true
+		}
+	}
+	return false
+}
+
+// handleBallotVoted handles a vote on a community ballot.
+// event Voted(address indexed voter, uint256 indexed proposal)
+func handleBallotVoted(log *retypes.Log, ld *logsDispatcher) {
+	// the same event signature may be used by other contracts
+	if !ld.repo.IsBallot(&log.Address) {
+		return
+	}
+
+	// sanity check for data
+	if len(log.Topics) != 3 {
+		ld.log.Errorf("%s log invalid for ballot vote; expected 3 topics, %d given", log.TxHash.String(), len(log.Topics))
+		return
+	}
+
+	// get the block
+	blk := hexutil.Uint64(log.BlockNumber)
+	block, err := ld.repo.BlockByNumber(&blk)
+	if err != nil {
+		ld.log.Errorf("can not decode block #%d from log record; %s", blk, err.Error())
+		return
+	}
+
+	// store the vote
+	if err := ld.repo.StoreBallotVote(&types.BallotVote{
+		Ballot:    log.Address,
+		Voter:     common.BytesToAddress(log.Topics[1].Bytes()),
+		Proposal:  log.Topics[2].Big().Uint64(),
+		Trx:       log.TxHash,
+		TimeStamp: time.Unix(int64(block.TimeStamp), 0),
+	}); err != nil {
+		ld.log.Errorf("can not store ballot vote; %s", err.Error())
+	}
+}
+
+// StoreBallotVote stores the given ballot vote in the persistent storage.
+func (p *proxy) StoreBallotVote(bv *types.BallotVote) error {
+	return p.db.AddBallotVote(bv)
+}
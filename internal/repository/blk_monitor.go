@@ -146,6 +146,10 @@ func (bm *blockMonitor) monitor() {
 
 			// add to the ring cache
 			bm.repo.CacheBlock(block)
+
+			// publish the new head
+			bm.repo.NotifyNewHead(block)
+			bm.markRun()
 		}
 	}
 }
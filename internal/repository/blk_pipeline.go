@@ -0,0 +1,267 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sync"
+	"time"
+)
+
+// blkPipelineWindowFactor represents the number of blocks per worker
+// the pipeline is allowed to load ahead of the ordered commit.
+const blkPipelineWindowFactor = 8
+
+// blockJob represents a single block passing through the block pipeline.
+type blockJob struct {
+	number uint64
+	block  *types.Block
+	trx    []*types.Transaction
+	traces []*types.TransactionTrace
+	err    error
+}
+
+// blockPipeline implements a staged block processing pipeline. Blocks pass
+// through the fetch, decode, and classify stages, each of them served by a pool
+// of workers concurrently. Classified blocks are committed for persisting in the order
+// of their numbers regardless of the order they were processed in.
+type blockPipeline struct {
+	repo    Repository
+	log     logger.Logger
+	workers int
+	from    uint64
+	to      *uint64
+
+	// trace enables call tracing of transactions in the classify stage
+	trace bool
+
+	// jobs is the queue of block numbers to be fetched
+	jobs chan uint64
+
+	// fetched is the queue of blocks waiting for their transactions to be decoded
+	fetched chan *blockJob
+
+	// decoded is the queue of blocks waiting for their transactions to be classified
+	decoded chan *blockJob
+
+	// results is the queue of classified blocks waiting for the commit
+	results chan *blockJob
+
+	// window limits the number of blocks processed ahead of the commit
+	window chan bool
+}
+
+// newBlockPipeline creates a new block pipeline for the given range of blocks.
+func newBlockPipeline(repo Repository, log logger.Logger, workers int, trace bool, from uint64, to *uint64) *blockPipeline {
+	// we need at least one worker
+	if workers < 1 {
+		workers = 1
+	}
+
+	size := workers * blkPipelineWindowFactor
+	return &blockPipeline{
+		repo:    repo,
+		log:     log,
+		workers: workers,
+		from:    from,
+		to:      to,
+		trace:   trace,
+		jobs:    make(chan uint64, workers),
+		fetched: make(chan *blockJob, size),
+		decoded: make(chan *blockJob, size),
+		results: make(chan *blockJob, size),
+		window:  make(chan bool, size),
+	}
+}
+
+// run executes the pipeline passing classified blocks to the commit function in order.
+// It returns when the end of the range, or the end of the chain is reached, the commit
+// function refuses a block, or the stop signal is received.
+func (bp *blockPipeline) run(sigStop chan bool, commit func(*blockJob) bool) {
+	// start the feeder and the workers of all the stages
+	bp.log.Noticef("block pipeline runs %d workers per stage", bp.workers)
+	done := make(chan bool)
+	var wg sync.WaitGroup
+
+	wg.Add(3*bp.workers + 1)
+	go bp.feed(done, &wg)
+	for i := 0; i < bp.workers; i++ {
+		go bp.work(done, &wg)
+		go bp.stage(bp.fetched, bp.decoded, bp.decode, done, &wg)
+		go bp.stage(bp.decoded, bp.results, bp.classify, done, &wg)
+	}
+
+	// make sure to terminate the loading when the commit is done
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+
+	bp.collect(sigStop, commit)
+}
+
+// feed pushes the block numbers of the range to the workers
+// keeping the number of blocks in progress inside the window.
+func (bp *blockPipeline) feed(done chan bool, wg *sync.WaitGroup) {
+	defer func() {
+		close(bp.jobs)
+		wg.Done()
+	}()
+
+	for num := bp.from; bp.to == nil || num <= *bp.to; num++ {
+		select {
+		case <-done:
+			return
+		case bp.window <- true:
+		}
+
+		select {
+		case <-done:
+			return
+		case bp.jobs <- num:
+		}
+	}
+}
+
+// work fetches the blocks requested by the feeder.
+func (bp *blockPipeline) work(done chan bool, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for {
+		select {
+		case <-done:
+			return
+		case num, ok := <-bp.jobs:
+			if !ok {
+				return
+			}
+
+			// the queue can hold the whole window so this never blocks
+			bp.fetched <- bp.fetch(num)
+		}
+	}
+}
+
+// stage runs a worker of a pipeline stage applying the given step to the blocks
+// received from the input queue and passing them to the output queue.
+func (bp *blockPipeline) stage(in chan *blockJob, out chan *blockJob, step func(*blockJob), done chan bool, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for {
+		select {
+		case <-done:
+			return
+		case job := <-in:
+			// the end of the chain and failed blocks pass through to the commit
+			if job.block != nil && job.err == nil {
+				step(job)
+			}
+
+			// the queue can hold the whole window so this never blocks
+			out <- job
+		}
+	}
+}
+
+// fetch loads the block of the given number.
+func (bp *blockPipeline) fetch(num uint64) *blockJob {
+	job := blockJob{number: num}
+
+	// if the block is not available we assume the end of the chain has been reached
+	blk, err := bp.repo.BlockByNumber((*hexutil.Uint64)(&num))
+	if err != nil || blk == nil {
+		return &job
+	}
+	job.block = blk
+	return &job
+}
+
+// decode loads and decodes all the transactions of the block.
+func (bp *blockPipeline) decode(job *blockJob) {
+	job.trx = make([]*types.Transaction, len(job.block.Txs))
+	for i, hash := range job.block.Txs {
+		bp.log.Debugf("loading trx #%d of %d from block #%d", i, len(job.block.Txs), job.number)
+
+		trx, err := bp.repo.LoadTransaction(hash)
+		if err != nil {
+			job.err = err
+			return
+		}
+
+		// update time stamp using the block data
+		trx.TimeStamp = time.Unix(int64(job.block.TimeStamp), 0)
+		job.trx[i] = trx
+	}
+}
+
+// classify resolves the details of the block transactions the dispatcher needs
+// to route them, which do not depend on the order of the processing; the revert
+// reason of failed transactions and the call trace. Failed lookups are left
+// for the dispatcher to retry.
+func (bp *blockPipeline) classify(job *blockJob) {
+	job.traces = make([]*types.TransactionTrace, len(job.trx))
+	for i, trx := range job.trx {
+		if trx.Status != nil && *trx.Status == 0 {
+			reason, err := bp.repo.TransactionRevertReason(trx)
+			if err != nil {
+				bp.log.Errorf("can not capture revert reason of trx %s; %s", trx.Hash.String(), err.Error())
+			}
+			trx.RevertReason = reason
+		}
+
+		if bp.trace && isTraceable(trx) {
+			trace, err := bp.repo.TraceTransaction(trx)
+			if err != nil {
+				bp.log.Errorf("can not trace trx %s; %s", trx.Hash.String(), err.Error())
+			}
+			job.traces[i] = trace
+		}
+	}
+}
+
+// collect receives classified blocks and commits them in the order of their numbers.
+func (bp *blockPipeline) collect(sigStop chan bool, commit func(*blockJob) bool) {
+	pending := make(map[uint64]*blockJob, cap(bp.window))
+
+	for next := bp.from; bp.to == nil || next <= *bp.to; {
+		// wait for the next block in order
+		job, ok := pending[next]
+		if !ok {
+			select {
+			case <-sigStop:
+				return
+			case job = <-bp.results:
+				pending[job.number] = job
+			}
+			continue
+		}
+
+		// release the block from the window
+		delete(pending, next)
+		<-bp.window
+
+		// end of the chain?
+		if job.block == nil {
+			return
+		}
+
+		// failed to decode?
+		if job.err != nil {
+			bp.log.Criticalf("transaction not available; %s", job.err.Error())
+			return
+		}
+
+		if !commit(job) {
+			return
+		}
+		next++
+	}
+}
@@ -11,9 +11,8 @@ package repository
 import (
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/logger"
-	"fantom-api-graphql/internal/types"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -23,16 +22,27 @@ type blockScanner struct {
 	buffer chan *eventTransaction
 	isDone chan bool
 	cmd    *config.RepoCmd
+
+	// workers is the number of block pipeline workers per stage
+	workers int
+
+	// trace enables call tracing of transactions in the block pipeline
+	trace bool
+
+	// current is the number of the last block committed
+	current uint64
 }
 
 // newBlockScanner creates new blockchain blockScanner service.
-func newBlockScanner(buffer chan *eventTransaction, isDone chan bool, repo Repository, log logger.Logger, wg *sync.WaitGroup, cmd *config.RepoCmd) *blockScanner {
+func newBlockScanner(buffer chan *eventTransaction, isDone chan bool, repo Repository, log logger.Logger, wg *sync.WaitGroup, cmd *config.RepoCmd, workers int, trace bool) *blockScanner {
 	// create new blockScanner instance
 	return &blockScanner{
 		service: newService("block scanner", repo, log, wg),
 		buffer:  buffer,
 		isDone:  isDone,
 		cmd:     cmd,
+		workers: workers,
+		trace:   trace,
 	}
 }
 
@@ -84,7 +94,7 @@ func (bls *blockScanner) scanRange() (uint64, *uint64, error) {
 }
 
 // logProgress will log the progress of the scanner on tics.
-func (bls *blockScanner) logProgress(block *types.Block, txIndex *int, stop chan bool) {
+func (bls *blockScanner) logProgress(stop chan bool) {
 	start := time.Now()
 	tick := time.NewTicker(5 * time.Second)
 
@@ -100,29 +110,25 @@ func (bls *blockScanner) logProgress(block *types.Block, txIndex *int, stop chan
 			return
 		case <-tick.C:
 			// do we have a block to display
-			if block == nil {
+			current := atomic.LoadUint64(&bls.current)
+			if current == 0 {
 				continue
 			}
 
 			// track the progress
 			if bh, err := bls.repo.BlockHeight(); err == nil && bh != nil {
 				pass := time.Now().Sub(start)
-				bls.log.Infof("block #%d of #%d, trx #%d of %d; runs for %s since %s", uint64(block.Number), bh.ToInt().Uint64(), *txIndex, len(block.Txs), pass.String(), start.String())
+				bls.log.Infof("block #%d of #%d; runs for %s since %s", current, bh.ToInt().Uint64(), pass.String(), start.String())
 			}
 		}
 	}
 }
 
 // scan performs the actual blockScanner operation on the missing blocks starting
-// from the identified last known block id/number.
+// from the identified last known block id/number. Blocks are loaded by the block
+// pipeline concurrently and committed to the dispatcher in order.
 func (bls *blockScanner) scan(from uint64, to *uint64) {
-	// what is the current block
-	var (
-		current = hexutil.Uint64(from)
-		block   *types.Block
-		txIndex int
-		stopLog = make(chan bool, 1)
-	)
+	stopLog := make(chan bool, 1)
 
 	// don't forget to sign off after we are done
 	defer func() {
@@ -138,89 +144,25 @@ func (bls *blockScanner) scan(from uint64, to *uint64) {
 	}()
 
 	// inform about block scanner progress sparsely to prevent log flood
-	go bls.logProgress(block, &txIndex, stopLog)
+	go bls.logProgress(stopLog)
 
 	// do the scan
-	for {
-		// capture stop signal
-		select {
-		case <-bls.sigStop:
-			return
-		default:
-		}
-
-		// do we need a new block? if so, get next block to be scanned
-		if block == nil || block.Txs == nil || len(block.Txs) <= txIndex {
-			block = bls.pullBlock(&current, to, &txIndex)
-			if block == nil {
-				return
-			}
-		}
-
-		// process next block transaction
-		ev, err := bls.procNextTransaction(block, &txIndex)
-		if err != nil {
-			return
-		}
-
-		// anything to dispatch?
-		if ev != nil {
-			select {
-			case <-bls.sigStop:
-				return
-			case bls.buffer <- ev:
-			}
-		}
-	}
+	newBlockPipeline(bls.repo, bls.log, bls.workers, bls.trace, from, to).run(bls.sigStop, bls.commit)
 }
 
-// pullBlock pulls the next block to be scanned, if available.
-func (bls *blockScanner) pullBlock(blk *hexutil.Uint64, to *uint64, txIndex *int) *types.Block {
-	// are we over the set range?
-	if to != nil && uint64(*blk) > *to {
-		return nil
-	}
-
-	// try to get the next block
-	// if not available we assume the scanner reached the end of the chain
-	block, err := bls.repo.BlockByNumber(blk)
-	if err != nil {
-		return nil
-	}
-
+// commit pushes transactions of the loaded block to the dispatcher.
+func (bls *blockScanner) commit(job *blockJob) bool {
 	// add the block to the ring cache
-	bls.repo.CacheBlock(block)
-
-	// reset the current block tx index and advance to the next block
-	*txIndex = 0
-	*blk += 1
-	return block
-}
+	bls.repo.CacheBlock(job.block)
 
-// procNextTransaction processes the next block transaction, if any.
-func (bls *blockScanner) procNextTransaction(block *types.Block, txIndex *int) (*eventTransaction, error) {
-	// do we have something to send?
-	if block == nil || len(block.Txs) <= *txIndex {
-		return nil, nil
-	}
-
-	// log action
-	bls.log.Debugf("loading trx #%d of %d from block #%d", *txIndex, len(block.Txs), uint64(block.Number))
-
-	// get transaction
-	trx, err := bls.repo.LoadTransaction(block.Txs[*txIndex])
-	if err != nil {
-		bls.log.Criticalf("transaction not available; %s", err.Error())
-		return nil, err
+	for i, trx := range job.trx {
+		select {
+		case <-bls.sigStop:
+			return false
+		case bls.buffer <- &eventTransaction{block: job.block, trx: trx, trace: job.traces[i], classified: true}:
+		}
 	}
 
-	// update time stamp using the block data
-	trx.TimeStamp = time.Unix(int64(block.TimeStamp), 0)
-
-	// advance trx index
-	*txIndex++
-	return &eventTransaction{
-		block: block,
-		trx:   trx,
-	}, err
+	atomic.StoreUint64(&bls.current, job.number)
+	return true
 }
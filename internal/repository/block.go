@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	eth "github.com/ethereum/go-ethereum/rpc"
+	"sync"
 )
 
 // ErrBlockNotFound represents an error returned if a block can not be found.
@@ -284,3 +285,41 @@ func (p *proxy) RecentBlocks(length int) (*types.BlockList, error) {
 	}
 	return nil, fmt.Errorf("recent blocks list not available")
 }
+
+// BlocksRange pulls blocks of the given inclusive range of numbers in ascending order.
+// The range is clipped to the current height of the chain.
+func (p *proxy) BlocksRange(from uint64, to uint64) ([]*types.Block, error) {
+	// clip the range to the chain head
+	bh, err := p.BlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	if top := bh.ToInt().Uint64(); to > top {
+		to = top
+	}
+	if from > to {
+		return make([]*types.Block, 0), nil
+	}
+
+	// pull the blocks in parallel
+	list := make([]*types.Block, to-from+1)
+	errs := make([]error, len(list))
+	var wg sync.WaitGroup
+	for i := range list {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			num := hexutil.Uint64(from + uint64(i))
+			list[i], errs[i] = p.BlockByNumber(&num)
+		}(i)
+	}
+	wg.Wait()
+
+	// any block missing?
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,63 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// transactionBloom builds the address bloom of the transaction; it covers the sender,
+// the recipient, the deployed contract, contracts emitting logs and addresses in log topics.
+func transactionBloom(trx *types.Transaction) *types.AddressBloom {
+	bl := types.NewAddressBloom(trx.From)
+	if trx.To != nil {
+		bl.Add(*trx.To)
+	}
+	if trx.ContractAddress != nil {
+		bl.Add(*trx.ContractAddress)
+	}
+
+	for _, lg := range trx.Logs {
+		bl.Add(lg.Address)
+		for _, t := range lg.Topics {
+			bl.AddTopic(t)
+		}
+	}
+	return &bl
+}
+
+// StoreTransactionBloom merges the address bloom of the transaction into the bloom of its block.
+func (p *proxy) StoreTransactionBloom(trx *types.Transaction) error {
+	if trx.BlockNumber == nil {
+		return nil
+	}
+	return p.db.AddBlockBloom(uint64(*trx.BlockNumber), transactionBloom(trx))
+}
+
+// AccountHasActivityIn checks if the address may be involved in any transaction
+// of the given inclusive block range. FALSE means the address is definitely not involved,
+// TRUE means it may be involved and a transaction scan is needed to be sure.
+// Blocks not covered by the bloom index are assumed to contain the address.
+func (p *proxy) AccountHasActivityIn(addr *common.Address, from uint64, to uint64) (bool, error) {
+	// where does the index start
+	start, ok, err := p.db.BlockBloomFrom()
+	if err != nil || !ok || from < start {
+		return true, err
+	}
+
+	// is the range covered by the index already
+	last, err := p.db.LastKnownBlock()
+	if err != nil || to > last {
+		return true, err
+	}
+
+	bl := types.NewAddressBloom(*addr)
+	return p.db.BlockBloomMatch(&bl, from, to)
+}
@@ -0,0 +1,95 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// finalityStatsMaxRange represents the max time range of the finality statistics.
+const finalityStatsMaxRange = 24 * time.Hour
+
+// trackFinality records the finality latency of the new head block; the latency
+// is the time between the block Atropos event creation and the block arrival.
+func (p *proxy) trackFinality(blk *types.Block) {
+	arrived := time.Now()
+
+	// the atropos is loaded on the side so the event bus is not blocked
+	go func() {
+		created, err := p.rpc.AtroposCreationTime(&blk.Hash)
+		if err != nil || created == 0 {
+			return
+		}
+
+		// ignore clock skew on the node side
+		ttf := arrived.Sub(time.Unix(0, int64(created)))
+		if ttf < 0 {
+			return
+		}
+
+		if err := p.db.AddBlockFinality(&types.BlockFinality{
+			Block:          uint64(blk.Number),
+			TimeToFinality: ttf.Milliseconds(),
+			TimeStamp:      time.Unix(int64(blk.TimeStamp), 0).UTC(),
+		}); err != nil {
+			p.log.Errorf("can not store finality of block #%d; %s", uint64(blk.Number), err.Error())
+		}
+	}()
+}
+
+// BlockFinality provides the finality latency of the given block, nil if not observed.
+func (p *proxy) BlockFinality(block uint64) (*types.BlockFinality, error) {
+	return p.db.BlockFinality(block)
+}
+
+// FinalityStats calculates the finality latency statistics of blocks
+// observed in the given time range back from now.
+func (p *proxy) FinalityStats(span time.Duration) (*types.FinalityStats, error) {
+	if span <= 0 || span > finalityStatsMaxRange {
+		return nil, fmt.Errorf("invalid range, expected up to %s", finalityStatsMaxRange.String())
+	}
+
+	list, err := p.db.BlockFinalityList(time.Now().Add(-span))
+	if err != nil {
+		return nil, err
+	}
+
+	// nothing observed
+	stats := types.FinalityStats{Blocks: uint64(len(list))}
+	if len(list) == 0 {
+		return &stats, nil
+	}
+
+	// block time is taken from the first and the last block in the range
+	if len(list) > 1 {
+		first, last := list[0], list[len(list)-1]
+		if last.Block > first.Block {
+			stats.AvgBlockTime = last.TimeStamp.Sub(first.TimeStamp).Milliseconds() / int64(last.Block-first.Block)
+		}
+	}
+
+	// collect the latencies
+	ttf := make([]int64, len(list))
+	var sum int64
+	for i, bf := range list {
+		ttf[i] = bf.TimeToFinality
+		sum += bf.TimeToFinality
+	}
+	sort.Slice(ttf, func(i, j int) bool { return ttf[i] < ttf[j] })
+
+	stats.Min = ttf[0]
+	stats.Max = ttf[len(ttf)-1]
+	stats.Avg = sum / int64(len(ttf))
+	stats.Median = ttf[len(ttf)/2]
+	stats.P95 = ttf[(len(ttf)*95)/100]
+	return &stats, nil
+}
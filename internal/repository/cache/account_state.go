@@ -0,0 +1,99 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"encoding/binary"
+	"fantom-api-graphql/internal/types"
+	"github.com/allegro/bigcache"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sync/atomic"
+)
+
+const (
+	// accountBalanceCacheIdPrefix is the prefix used for cache key to store account balance.
+	accountBalanceCacheIdPrefix = "acb_"
+
+	// accountNonceCacheIdPrefix is the prefix used for cache key to store account nonce.
+	accountNonceCacheIdPrefix = "acn_"
+
+	// accountStateMaxAge is the max number of blocks an account state
+	// is served from cache even if the account was not touched. Balance
+	// changes by internal transactions are visible to the event bus only
+	// if the call tracing is enabled, so we re-validate the state often.
+	accountStateMaxAge = 3
+)
+
+// OnNewHead consumes the new head event to keep track of the chain head.
+func (b *MemBridge) OnNewHead(blk *types.Block) {
+	atomic.StoreUint64(b.head, uint64(blk.Number))
+}
+
+// OnAccountTouched consumes the account touched event; the state
+// of the account we keep in the cache is stale and must be evicted.
+func (b *MemBridge) OnAccountTouched(addr *common.Address) {
+	for _, id := range []string{accountId(addr), accountBalanceCacheIdPrefix + addr.String(), accountNonceCacheIdPrefix + addr.String()} {
+		if err := b.cache.Delete(id); err != nil && err != bigcache.ErrEntryNotFound {
+			b.log.Errorf("can not evict state of %s; %s", addr.String(), err.Error())
+		}
+	}
+}
+
+// PullAccountBalance extracts the balance of the given account from the in-memory cache.
+func (b *MemBridge) PullAccountBalance(addr *common.Address) *hexutil.Big {
+	data := b.pullAccountState(accountBalanceCacheIdPrefix + addr.String())
+	if data == nil {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).SetBytes(data))
+}
+
+// PushAccountBalance stores the balance of the given account in the in-memory cache.
+func (b *MemBridge) PushAccountBalance(addr *common.Address, val *hexutil.Big) {
+	b.pushAccountState(accountBalanceCacheIdPrefix+addr.String(), val.ToInt().Bytes())
+}
+
+// PullAccountNonce extracts the nonce of the given account from the in-memory cache.
+func (b *MemBridge) PullAccountNonce(addr *common.Address) *uint64 {
+	data := b.pullAccountState(accountNonceCacheIdPrefix + addr.String())
+	if len(data) != 8 {
+		return nil
+	}
+
+	val := binary.BigEndian.Uint64(data)
+	return &val
+}
+
+// PushAccountNonce stores the nonce of the given account in the in-memory cache.
+func (b *MemBridge) PushAccountNonce(addr *common.Address, val uint64) {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, val)
+	b.pushAccountState(accountNonceCacheIdPrefix+addr.String(), data)
+}
+
+// pullAccountState loads the account state value of the given key
+// if it's not too old to be served.
+func (b *MemBridge) pullAccountState(key string) []byte {
+	data, err := b.get(key)
+	if err != nil || len(data) < 8 {
+		return nil
+	}
+
+	// check the value age; the value is prefixed with the head block number
+	if atomic.LoadUint64(b.head) > binary.BigEndian.Uint64(data[:8])+accountStateMaxAge {
+		return nil
+	}
+	return data[8:]
+}
+
+// pushAccountState stores the account state value of the given key
+// stamped with the current head block number.
+func (b *MemBridge) pushAccountState(key string, val []byte) {
+	data := make([]byte, 8, 8+len(val))
+	binary.BigEndian.PutUint64(data, atomic.LoadUint64(b.head))
+
+	if err := b.set(key, append(data, val...)); err != nil {
+		b.log.Errorf("can not cache account state %s; %s", key, err.Error())
+	}
+}
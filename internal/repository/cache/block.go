@@ -9,7 +9,7 @@ import (
 // PullBlock extracts block information from the in-memory cache if available.
 func (b *MemBridge) PullBlock(key string) *types.Block {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(key)
+	data, err := b.get(key)
 	if err != nil {
 		// cache returns ErrEntryNotFound if the key does not exist
 		return nil
@@ -40,5 +40,5 @@ func (b *MemBridge) PushBlock(key string, blk *types.Block) error {
 	}
 
 	// set the data to cache by block number
-	return b.cache.Set(key, data)
+	return b.set(key, data)
 }
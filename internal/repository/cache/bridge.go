@@ -2,9 +2,12 @@
 package cache
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/logger"
 	"fantom-api-graphql/internal/repository/cache/ring"
+	"fantom-api-graphql/internal/repository/opstats"
+	"fantom-api-graphql/internal/types"
 	"github.com/allegro/bigcache"
 	"time"
 )
@@ -25,6 +28,13 @@ type MemBridge struct {
 	// ring of the most recent blocks and transactions
 	blkRing *ring.Ring
 	trxRing *ring.Ring
+
+	// head is the number of the most recent block seen on the event bus;
+	// it's shared by the copies of the bridge bound to a context
+	head *uint64
+
+	// calls counts the cache calls of the bound operation, nil if not bound
+	calls *opstats.Counter
 }
 
 // New creates a new BigCache bridge.
@@ -47,9 +57,31 @@ func New(cfg *config.Config, log logger.Logger) (*MemBridge, error) {
 		// make rings
 		blkRing: ring.New(BlockRingCacheSize),
 		trxRing: ring.New(TransactionRingCacheSize),
+		head:    new(uint64),
 	}, nil
 }
 
+// WithContext returns a copy of the bridge with the cache calls counted
+// by the calls counter of the given context, if any. The copy shares
+// the cache and the state of the bridge.
+func (b *MemBridge) WithContext(ctx context.Context) *MemBridge {
+	cp := *b
+	cp.calls = opstats.FromContext(ctx)
+	return &cp
+}
+
+// get loads the entry of the given key from the cache.
+func (b *MemBridge) get(key string) ([]byte, error) {
+	b.calls.Cache()
+	return b.cache.Get(key)
+}
+
+// set stores the entry of the given key in the cache.
+func (b *MemBridge) set(key string, data []byte) error {
+	b.calls.Cache()
+	return b.cache.Set(key, data)
+}
+
 // cacheConfig constructs a configuration structure for BigCache initialization.
 func cacheConfig(cfg *config.Config, log logger.Logger) bigcache.Config {
 	// log the info
@@ -97,3 +129,27 @@ func cacheConfig(cfg *config.Config, log logger.Logger) bigcache.Config {
 		Logger: log,
 	}
 }
+
+// Evict removes an entry with the given key from the in-memory cache.
+// It returns TRUE if the entry existed and has been removed.
+func (b *MemBridge) Evict(key string) bool {
+	if err := b.cache.Delete(key); err != nil {
+		if err != bigcache.ErrEntryNotFound {
+			b.log.Errorf("can not evict cache entry %s; %s", key, err.Error())
+		}
+		return false
+	}
+	return true
+}
+
+// Stats provides the current statistics of the in-memory cache.
+func (b *MemBridge) Stats() types.CacheStats {
+	st := b.cache.Stats()
+	return types.CacheStats{
+		Entries:    b.cache.Len(),
+		Capacity:   b.cache.Capacity(),
+		Hits:       st.Hits,
+		Misses:     st.Misses,
+		Collisions: st.Collisions,
+	}
+}
@@ -26,7 +26,7 @@ func contractId(addr *common.Address) string {
 // PullContract extracts smart contract information from the in-memory cache if available.
 func (b *MemBridge) PullContract(addr *common.Address) *types.Contract {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(contractId((*common.Address)(addr)))
+	data, err := b.get(contractId((*common.Address)(addr)))
 	if err != nil {
 		// cache returns ErrEntryNotFound if the key does not exist
 		return nil
@@ -57,7 +57,7 @@ func (b *MemBridge) PushContract(sc *types.Contract) error {
 	}
 
 	// set the data to cache
-	return b.cache.Set(contractId(&sc.Address), data)
+	return b.set(contractId(&sc.Address), data)
 }
 
 // EvictContract makes sure the contract of the given address
@@ -22,7 +22,7 @@ func delegationCacheKey(adr common.Address, valID *hexutil.Big) string {
 // from internal in-memory cache.
 func (b *MemBridge) PullDelegation(adr common.Address, valID *hexutil.Big) *types.Delegation {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(delegationCacheKey(adr, valID))
+	data, err := b.get(delegationCacheKey(adr, valID))
 	if err != nil {
 		return nil
 	}
@@ -51,7 +51,7 @@ func (b *MemBridge) PushDelegation(dlg *types.Delegation) {
 	}
 
 	// set the data to cache by block number
-	if err := b.cache.Set(delegationCacheKey(dlg.Address, dlg.ToStakerId), data); err != nil {
+	if err := b.set(delegationCacheKey(dlg.Address, dlg.ToStakerId), data); err != nil {
 		b.log.Criticalf("can not cache delegation of %s to #%d; %s", dlg.Address.String(), dlg.ToStakerId.ToInt().Uint64(), err.Error())
 	}
 }
@@ -20,7 +20,7 @@ func epochKey(id *hexutil.Uint64) string {
 // PullEpoch extracts information about the given Epoch from the in-memory cache if available.
 func (b *MemBridge) PullEpoch(id *hexutil.Uint64) *types.Epoch {
 	// try to get the Epoch data from the cache
-	data, err := b.cache.Get(epochKey(id))
+	data, err := b.get(epochKey(id))
 	if err != nil {
 		// cache returns ErrEntryNotFound if the key does not exist
 		return nil
@@ -50,7 +50,40 @@ func (b *MemBridge) PushEpoch(ep *types.Epoch) {
 	}
 
 	// set the data to cache by block number
-	if err := b.cache.Set(epochKey(&ep.Id), data); err != nil {
+	if err := b.set(epochKey(&ep.Id), data); err != nil {
 		b.log.Errorf("can not cache epoch #%d; %s", ep.Id, err.Error())
 	}
 }
+
+// epochStatsCacheKey represents the in-memory cache key prefix for Epoch statistics.
+const epochStatsCacheKey = "epoch_stats"
+
+// PullEpochStats extracts statistics of the given Epoch from the in-memory cache if available.
+func (b *MemBridge) PullEpochStats(id hexutil.Uint64) *types.EpochStats {
+	data, err := b.get(epochStatsCacheKey + id.String())
+	if err != nil {
+		return nil
+	}
+
+	// do we have the data?
+	st, err := types.UnmarshalEpochStats(data)
+	if err != nil {
+		b.log.Criticalf("can not decode epoch stats from in-memory cache; %s", err.Error())
+		return nil
+	}
+	return st
+}
+
+// PushEpochStats stores statistics of the given Epoch in the in-memory cache.
+func (b *MemBridge) PushEpochStats(id hexutil.Uint64, st *types.EpochStats) {
+	data, err := st.Marshal()
+	if err != nil {
+		b.log.Criticalf("can not marshal epoch stats to JSON; %s", err.Error())
+		return
+	}
+
+	// set the data to cache by epoch number
+	if err := b.set(epochStatsCacheKey+id.String(), data); err != nil {
+		b.log.Errorf("can not cache epoch #%d stats; %s", id, err.Error())
+	}
+}
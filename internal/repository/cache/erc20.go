@@ -24,7 +24,7 @@ func erc20TokenId(addr *common.Address) string {
 // PullErc20Token extracts ERC20 token information from the in-memory cache if available.
 func (b *MemBridge) PullErc20Token(addr *common.Address) *types.Erc20Token {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(erc20TokenId(addr))
+	data, err := b.get(erc20TokenId(addr))
 	if err != nil {
 		// cache returns ErrEntryNotFound if the key does not exist
 		return nil
@@ -55,5 +55,5 @@ func (b *MemBridge) PushErc20Token(token *types.Erc20Token) error {
 	}
 
 	// set the data to cache
-	return b.cache.Set(erc20TokenId(&token.Address), data)
+	return b.set(erc20TokenId(&token.Address), data)
 }
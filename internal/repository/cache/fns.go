@@ -0,0 +1,51 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"strings"
+)
+
+// fnsNameCacheIdPrefix is the prefix used for cache key to store domain name of an address.
+const fnsNameCacheIdPrefix = "fns_name_"
+
+// fnsAddressCacheIdPrefix is the prefix used for cache key to store address of a domain name.
+const fnsAddressCacheIdPrefix = "fns_addr_"
+
+// PullDomainName extracts the domain name of the given address from the in-memory cache.
+// The returned nil value means the name is not known to the cache; empty name means
+// the address does not have any domain name.
+func (b *MemBridge) PullDomainName(addr *common.Address) *string {
+	data, err := b.get(fnsNameCacheIdPrefix + addr.String())
+	if err != nil {
+		return nil
+	}
+
+	name := string(data)
+	return &name
+}
+
+// PushDomainName stores the domain name of the given address in the in-memory cache.
+func (b *MemBridge) PushDomainName(addr *common.Address, name string) {
+	if err := b.set(fnsNameCacheIdPrefix+addr.String(), []byte(name)); err != nil {
+		b.log.Errorf("can not cache domain name of %s; %s", addr.String(), err.Error())
+	}
+}
+
+// PullDomainAddress extracts the address of the given domain name from the in-memory cache.
+func (b *MemBridge) PullDomainAddress(name string) *common.Address {
+	data, err := b.get(fnsAddressCacheIdPrefix + strings.ToLower(name))
+	if err != nil {
+		return nil
+	}
+
+	adr := common.BytesToAddress(data)
+	return &adr
+}
+
+// PushDomainAddress stores the address of the given domain name in the in-memory cache.
+func (b *MemBridge) PushDomainAddress(name string, addr *common.Address) {
+	if err := b.set(fnsAddressCacheIdPrefix+strings.ToLower(name), addr.Bytes()); err != nil {
+		b.log.Errorf("can not cache address of domain %s; %s", name, err.Error())
+	}
+}
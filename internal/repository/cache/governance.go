@@ -31,7 +31,7 @@ func (b *MemBridge) PullGovernanceTotalWeight(gov *common.Address) *hexutil.Big
 	}
 
 	// try to get the account data from the cache
-	data, err := b.cache.Get(getGovernanceTotalWeightKey(gov))
+	data, err := b.get(getGovernanceTotalWeightKey(gov))
 	if err != nil {
 		// cache returns ErrEntryNotFound if the key does not exist
 		return nil
@@ -63,5 +63,5 @@ func (b *MemBridge) PushGovernanceTotalWeight(gov *common.Address, val *hexutil.
 	}
 
 	// set the data to cache by address
-	return b.cache.Set(getGovernanceTotalWeightKey(gov), data)
+	return b.set(getGovernanceTotalWeightKey(gov), data)
 }
@@ -0,0 +1,59 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/allegro/bigcache"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// labelCacheIdPrefix is the prefix used for cache key to store address labels.
+const labelCacheIdPrefix = "label_"
+
+// PullAddressLabel extracts the label of the given address from the in-memory cache.
+// The second return value signals if the cache knows the address; a known address
+// without a label is represented by nil label.
+func (b *MemBridge) PullAddressLabel(addr *common.Address) (*types.AddressLabel, bool) {
+	data, err := b.get(labelCacheIdPrefix + addr.String())
+	if err != nil {
+		return nil, false
+	}
+
+	// the address does not have any label
+	if len(data) == 0 {
+		return nil, true
+	}
+
+	// decode the label
+	al, err := types.UnmarshalAddressLabel(data)
+	if err != nil {
+		b.log.Criticalf("can not decode address label from in-memory cache; %s", err.Error())
+		return nil, false
+	}
+	return al, true
+}
+
+// PushAddressLabel stores the label of the given address in the in-memory cache.
+// The nil label marks the address as known without any label assigned.
+func (b *MemBridge) PushAddressLabel(addr *common.Address, al *types.AddressLabel) {
+	var data []byte
+	if al != nil {
+		var err error
+		if data, err = al.Marshal(); err != nil {
+			b.log.Criticalf("can not marshal address label to JSON; %s", err.Error())
+			return
+		}
+	}
+
+	// set the data to cache
+	if err := b.set(labelCacheIdPrefix+addr.String(), data); err != nil {
+		b.log.Errorf("can not cache label of %s; %s", addr.String(), err.Error())
+	}
+}
+
+// EvictAddressLabel removes the label of the given address from the in-memory cache.
+func (b *MemBridge) EvictAddressLabel(addr *common.Address) {
+	if err := b.cache.Delete(labelCacheIdPrefix + addr.String()); err != nil && err != bigcache.ErrEntryNotFound {
+		b.log.Errorf("can not evict label of %s; %s", addr.String(), err.Error())
+	}
+}
@@ -13,7 +13,7 @@ const priceCacheKeyPrefix = "price_FTM_2_"
 // PullPrice extracts price information from the in-memory cache if available.
 func (b *MemBridge) PullPrice(symbol string) *types.Price {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(getPriceKeyBySymbol(symbol))
+	data, err := b.get(getPriceKeyBySymbol(symbol))
 	if err != nil {
 		// cache returns ErrEntryNotFound if the key does not exist
 		return nil
@@ -44,7 +44,7 @@ func (b *MemBridge) PushPrice(sym string, pri *types.Price) error {
 	}
 
 	// set the data to cache by block number
-	return b.cache.Set(getPriceKeyBySymbol(sym), data)
+	return b.set(getPriceKeyBySymbol(sym), data)
 }
 
 // getPriceKeyBySymbol build a cache key for the given price symbol.
@@ -0,0 +1,77 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"encoding/binary"
+	"fantom-api-graphql/internal/types"
+	"github.com/allegro/bigcache"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+const (
+	// riskFlagCacheIdPrefix is the prefix used for cache key to store risk flags.
+	riskFlagCacheIdPrefix = "risk_"
+
+	// riskFlagMaxAge is the max time a risk flag is served from cache.
+	// Changes made through another API instance evict only the cache
+	// of that instance, so we need to re-validate the flag from time to time.
+	riskFlagMaxAge = 60 * time.Second
+)
+
+// PullRiskFlag extracts the risk flag of the given address from the in-memory cache.
+// The second return value signals if the cache knows the address; a known address
+// without any flag is represented by nil flag.
+func (b *MemBridge) PullRiskFlag(addr *common.Address) (*types.RiskFlag, bool) {
+	data, err := b.get(riskFlagCacheIdPrefix + addr.String())
+	if err != nil || len(data) < 8 {
+		return nil, false
+	}
+
+	// check the value age; the value is prefixed with the cache time
+	if time.Since(time.Unix(int64(binary.BigEndian.Uint64(data[:8])), 0)) > riskFlagMaxAge {
+		return nil, false
+	}
+	data = data[8:]
+
+	// the address is not flagged
+	if len(data) == 0 {
+		return nil, true
+	}
+
+	// decode the flag
+	rf, err := types.UnmarshalRiskFlag(data)
+	if err != nil {
+		b.log.Criticalf("can not decode risk flag from in-memory cache; %s", err.Error())
+		return nil, false
+	}
+	return rf, true
+}
+
+// PushRiskFlag stores the risk flag of the given address in the in-memory cache.
+// The nil flag marks the address as known without any flag.
+func (b *MemBridge) PushRiskFlag(addr *common.Address, rf *types.RiskFlag) {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, uint64(time.Now().Unix()))
+
+	if rf != nil {
+		flag, err := rf.Marshal()
+		if err != nil {
+			b.log.Criticalf("can not marshal risk flag to JSON; %s", err.Error())
+			return
+		}
+		data = append(data, flag...)
+	}
+
+	// set the data to cache
+	if err := b.set(riskFlagCacheIdPrefix+addr.String(), data); err != nil {
+		b.log.Errorf("can not cache risk flag of %s; %s", addr.String(), err.Error())
+	}
+}
+
+// EvictRiskFlag removes the risk flag of the given address from the in-memory cache.
+func (b *MemBridge) EvictRiskFlag(addr *common.Address) {
+	if err := b.cache.Delete(riskFlagCacheIdPrefix + addr.String()); err != nil && err != bigcache.ErrEntryNotFound {
+		b.log.Errorf("can not evict risk flag of %s; %s", addr.String(), err.Error())
+	}
+}
@@ -2,6 +2,7 @@
 package cache
 
 import (
+	"encoding/binary"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -14,12 +15,13 @@ const (
 	sfcMaxDelegatedRatioKey = "sfc_dlr"
 	sfcConfigurationKey     = "sfc_cfg"
 	sfcValidatorAddress     = "val_adr"
+	sfcValidatorDelegators  = "val_dlc"
 )
 
 // PullSfcMaxDelegatedRatio extract the ratio from cache, if possible.
 func (b *MemBridge) PullSfcMaxDelegatedRatio() *big.Int {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(sfcMaxDelegatedRatioKey)
+	data, err := b.get(sfcMaxDelegatedRatioKey)
 	if err != nil {
 		return nil
 	}
@@ -31,7 +33,7 @@ func (b *MemBridge) PushSfcMaxDelegatedRatio(val *big.Int) {
 	if val == nil {
 		return
 	}
-	if err := b.cache.Set(sfcMaxDelegatedRatioKey, val.Bytes()); err != nil {
+	if err := b.set(sfcMaxDelegatedRatioKey, val.Bytes()); err != nil {
 		b.log.Errorf("can not store SFC delegation ratio value")
 	}
 }
@@ -39,7 +41,7 @@ func (b *MemBridge) PushSfcMaxDelegatedRatio(val *big.Int) {
 // PullSfcConfig extract the SFC configuration from cache, if possible.
 func (b *MemBridge) PullSfcConfig() *types.SfcConfig {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(sfcConfigurationKey)
+	data, err := b.get(sfcConfigurationKey)
 	if err != nil {
 		return nil
 	}
@@ -76,7 +78,7 @@ func (b *MemBridge) PushSfcConfig(val *types.SfcConfig) {
 	}
 
 	// store the data
-	if err := b.cache.Set(sfcConfigurationKey, data); err != nil {
+	if err := b.set(sfcConfigurationKey, data); err != nil {
 		b.log.Errorf("can not store SFC configuration")
 	}
 }
@@ -97,7 +99,7 @@ func (b *MemBridge) PushValidatorAddress(valID *hexutil.Big, adr *common.Address
 	}
 
 	// store the address
-	if err := b.cache.Set(validatorAddressKey(valID), adr.Bytes()); err != nil {
+	if err := b.set(validatorAddressKey(valID), adr.Bytes()); err != nil {
 		b.log.Errorf("can not store address of validator %d", valID.ToInt().Uint64())
 	}
 }
@@ -110,10 +112,37 @@ func (b *MemBridge) PullValidatorAddress(valID *hexutil.Big) *common.Address {
 	}
 
 	// try to get the account data from the cache
-	data, err := b.cache.Get(validatorAddressKey(valID))
+	data, err := b.get(validatorAddressKey(valID))
 	if err != nil {
 		return nil
 	}
 	adr := common.BytesToAddress(data)
 	return &adr
 }
+
+// PushValidatorDelegatorsCount stores the number of delegators of the given validator in the memory cache.
+func (b *MemBridge) PushValidatorDelegatorsCount(valID *hexutil.Big, count uint64) {
+	if nil == valID {
+		return
+	}
+
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, count)
+	if err := b.set(sfcValidatorDelegators+valID.String(), data); err != nil {
+		b.log.Errorf("can not store delegators count of validator %d", valID.ToInt().Uint64())
+	}
+}
+
+// PullValidatorDelegatorsCount tries to pull the number of delegators of the given validator
+// from memory cache. The second value signals if the count was found.
+func (b *MemBridge) PullValidatorDelegatorsCount(valID *hexutil.Big) (uint64, bool) {
+	if nil == valID {
+		return 0, false
+	}
+
+	data, err := b.get(sfcValidatorDelegators + valID.String())
+	if err != nil || len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
@@ -18,7 +18,7 @@ const stiTotalStakedKey = "staked_total"
 // PullStakerInfo extracts staker information from the in-memory cache if available.
 func (b *MemBridge) PullStakerInfo(id *hexutil.Big) *types.StakerInfo {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(getStakerInfoKey(id))
+	data, err := b.get(getStakerInfoKey(id))
 	if err != nil {
 		// cache returns ErrEntryNotFound if the key does not exist
 		return nil
@@ -44,7 +44,7 @@ func (b *MemBridge) PushStakerInfo(id *hexutil.Big, sti *types.StakerInfo) error
 	}
 
 	// set the data to cache by block number
-	return b.cache.Set(getStakerInfoKey(id), data)
+	return b.set(getStakerInfoKey(id), data)
 }
 
 // getPriceKeyBySymbol build a cache key for the given price symbol.
@@ -61,7 +61,7 @@ func getStakerInfoKey(id *hexutil.Big) string {
 // PullTotalStaked extracts total staked amount from the in-memory cache if available.
 func (b *MemBridge) PullTotalStaked() *hexutil.Big {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(stiTotalStakedKey)
+	data, err := b.get(stiTotalStakedKey)
 	if err != nil {
 		return nil
 	}
@@ -80,5 +80,5 @@ func (b *MemBridge) PushTotalStaked(amount *hexutil.Big) error {
 	}
 
 	// encode account
-	return b.cache.Set(stiTotalStakedKey, amount.ToInt().Bytes())
+	return b.set(stiTotalStakedKey, amount.ToInt().Bytes())
 }
@@ -0,0 +1,59 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/allegro/bigcache"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// tokenMetaCacheIdPrefix is the prefix used for cache key to store token metadata.
+const tokenMetaCacheIdPrefix = "tmeta_"
+
+// PullTokenMeta extracts the metadata of the given token from the in-memory cache.
+// The second return value signals if the cache knows the token; a known token
+// without any metadata is represented by nil.
+func (b *MemBridge) PullTokenMeta(addr *common.Address) (*types.TokenMeta, bool) {
+	data, err := b.get(tokenMetaCacheIdPrefix + addr.String())
+	if err != nil {
+		return nil, false
+	}
+
+	// the token has no metadata
+	if len(data) == 0 {
+		return nil, true
+	}
+
+	// decode the metadata
+	tm, err := types.UnmarshalTokenMeta(data)
+	if err != nil {
+		b.log.Criticalf("can not decode token metadata from in-memory cache; %s", err.Error())
+		return nil, false
+	}
+	return tm, true
+}
+
+// PushTokenMeta stores the metadata of the given token in the in-memory cache.
+// The nil metadata marks the token as known without any metadata.
+func (b *MemBridge) PushTokenMeta(addr *common.Address, tm *types.TokenMeta) {
+	var data []byte
+	if tm != nil {
+		var err error
+		if data, err = tm.Marshal(); err != nil {
+			b.log.Criticalf("can not marshal token metadata to JSON; %s", err.Error())
+			return
+		}
+	}
+
+	// set the data to cache
+	if err := b.set(tokenMetaCacheIdPrefix+addr.String(), data); err != nil {
+		b.log.Errorf("can not cache token metadata of %s; %s", addr.String(), err.Error())
+	}
+}
+
+// EvictTokenMeta removes the metadata of the given token from the in-memory cache.
+func (b *MemBridge) EvictTokenMeta(addr *common.Address) {
+	if err := b.cache.Delete(tokenMetaCacheIdPrefix + addr.String()); err != nil && err != bigcache.ErrEntryNotFound {
+		b.log.Errorf("can not evict token metadata of %s; %s", addr.String(), err.Error())
+	}
+}
@@ -10,7 +10,7 @@ import (
 // PullTransaction extracts transaction information from the in-memory cache if available.
 func (b *MemBridge) PullTransaction(hash *common.Hash) *types.Transaction {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(hash.String())
+	data, err := b.get(hash.String())
 	if err != nil {
 		// cache returns ErrEntryNotFound if the key does not exist
 		return nil
@@ -54,7 +54,7 @@ func (b *MemBridge) PushTransaction(trx *types.Transaction) {
 	}()
 
 	// set the data to cache by block number
-	if err := b.cache.Set(trx.Hash.String(), s2.Encode(nil, data)); err != nil {
+	if err := b.set(trx.Hash.String(), s2.Encode(nil, data)); err != nil {
 		b.log.Criticalf("can not cache transaction %s; %s", trx.Hash.String(), err.Error())
 	}
 }
@@ -29,7 +29,7 @@ func (b *MemBridge) PushUniswapPairTokens(pair *common.Address, tl []common.Addr
 	// just stick those addresses together into a single slice of 40 bytes, 20 for each address
 	// we don't need to worry about rewriting tl[0] bytes slice by the append call
 	// since it's strictly 20 bytes long and so a new underlying array will be allocated by append()
-	if err := b.cache.Set(uniswapPairTokensKey(pair), append(tl[0].Bytes(), tl[1].Bytes()...)); err != nil {
+	if err := b.set(uniswapPairTokensKey(pair), append(tl[0].Bytes(), tl[1].Bytes()...)); err != nil {
 		b.log.Errorf("can not store uniswap pair %s tokens; %s", pair.String(), err.Error())
 	}
 }
@@ -42,7 +42,7 @@ func (b *MemBridge) PullUniswapPairTokens(pair *common.Address) []common.Address
 	}
 
 	// try to get the data from cache
-	data, err := b.cache.Get(uniswapPairTokensKey(pair))
+	data, err := b.get(uniswapPairTokensKey(pair))
 	if err != nil {
 		// cache returns ErrEntryNotFound if the key does not exist
 		return nil
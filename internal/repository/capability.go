@@ -0,0 +1,298 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockReader provides read access to blocks of the Opera blockchain.
+type BlockReader interface {
+	// BlockHeight returns the current height of the Opera blockchain in blocks.
+	BlockHeight() (*hexutil.Big, error)
+
+	// LastKnownBlock returns number of the last block known to the repository.
+	LastKnownBlock() (uint64, error)
+
+	// BlockByNumber returns a block at Opera blockchain represented by a number.
+	// Top block is returned if the number is not provided.
+	// If the block is not found, ErrBlockNotFound error is returned.
+	BlockByNumber(*hexutil.Uint64) (*types.Block, error)
+
+	// BlockByHash returns a block at Opera blockchain represented by a hash.
+	// Top block is returned if the hash is not provided.
+	// If the block is not found, ErrBlockNotFound error is returned.
+	BlockByHash(*common.Hash) (*types.Block, error)
+
+	// Blocks pulls list of blocks starting on the specified block number
+	// and going up, or down based on count number.
+	Blocks(*uint64, int32) (*types.BlockList, error)
+
+	// BlocksRange pulls blocks of the given inclusive range of numbers in ascending order.
+	BlocksRange(from uint64, to uint64) ([]*types.Block, error)
+
+	// AccountHasActivityIn checks if the address may be involved in any transaction
+	// of the given inclusive block range using the block bloom index.
+	AccountHasActivityIn(addr *common.Address, from uint64, to uint64) (bool, error)
+
+	// BlockFinality provides the finality latency of the given block, nil if not observed.
+	BlockFinality(block uint64) (*types.BlockFinality, error)
+
+	// FinalityStats calculates the finality latency statistics of blocks
+	// observed in the given time range back from now.
+	FinalityStats(span time.Duration) (*types.FinalityStats, error)
+}
+
+// TxReader provides read access to transactions of the Opera blockchain.
+type TxReader interface {
+	// LoadTransaction returns a transaction at Opera blockchain
+	// by a hash loaded directly from the node.
+	LoadTransaction(hash *common.Hash) (*types.Transaction, error)
+
+	// Transaction returns a transaction at Opera blockchain by a hash, nil if not found.
+	Transaction(*common.Hash) (*types.Transaction, error)
+
+	// Transactions returns list of transaction hashes at Opera blockchain.
+	Transactions(*string, int32) (*types.TransactionList, error)
+
+	// LargeTransfers returns list of transactions transferring at least the given value of native tokens.
+	LargeTransfers(minValue *big.Int, cursor *string, count int32) (*types.TransactionList, error)
+
+	// TransactionsCount returns total number of transactions in the block chain.
+	TransactionsCount() (uint64, error)
+
+	// EstimateTransactionsCount returns an approximate amount of transactions on the network.
+	EstimateTransactionsCount() (hexutil.Uint64, error)
+
+	// TransactionRaw loads the RLP encoded transaction of the given hash from the node.
+	TransactionRaw(*common.Hash) (hexutil.Bytes, error)
+
+	// TransactionReceipt loads the receipt of the transaction of the given hash from the node.
+	// It returns nil if the transaction has not been processed yet.
+	TransactionReceipt(*common.Hash) (*types.TransactionReceipt, error)
+
+	// TransactionRevertReason resolves the reason the given failed transaction reverted.
+	TransactionRevertReason(*types.Transaction) (*string, error)
+}
+
+// StakingReader provides read access to epochs, validators, delegations
+// and rewards of the SFC staking.
+type StakingReader interface {
+	// SfcVersion returns current version of the SFC contract.
+	SfcVersion() (hexutil.Uint64, error)
+
+	// SfcDecimalUnit returns the decimal unit adjustment used by the SFC contract.
+	SfcDecimalUnit() *big.Int
+
+	// CurrentEpoch returns the id of the current epoch.
+	CurrentEpoch() (hexutil.Uint64, error)
+
+	// LastKnownEpoch returns the id of the last known and scanned epoch.
+	LastKnownEpoch() (uint64, error)
+
+	// EpochStats provides aggregated statistics of the given epoch.
+	EpochStats(hexutil.Uint64) (*types.EpochStats, error)
+
+	// Epoch returns the id of the current epoch.
+	Epoch(*hexutil.Uint64) (*types.Epoch, error)
+
+	// CurrentSealedEpoch returns the data of the latest sealed epoch.
+	CurrentSealedEpoch() (*types.Epoch, error)
+
+	// Epochs pulls list of epochs starting at the specified cursor.
+	Epochs(cursor *string, count int32) (*types.EpochList, error)
+
+	// TotalStaked calculates current total staked amount for all stakers.
+	TotalStaked() (*hexutil.Big, error)
+
+	// RewardsAllowed returns the reward lock status from SFC.
+	RewardsAllowed() (bool, error)
+
+	// LockingAllowed indicates if the stake locking has been enabled in SFC.
+	LockingAllowed() (bool, error)
+
+	// LastValidatorId returns the last validator id in Opera blockchain.
+	LastValidatorId() (uint64, error)
+
+	// ValidatorsCount returns the number of stakers in Opera blockchain.
+	ValidatorsCount() (uint64, error)
+
+	// IsValidator returns TRUE if the given address is an SFC staker.
+	IsValidator(*common.Address) (bool, error)
+
+	// ValidatorAddress extract a staker address for the given staker ID.
+	ValidatorAddress(*hexutil.Big) (*common.Address, error)
+
+	// Validator extract a staker information from SFC smart contract.
+	Validator(*hexutil.Big) (*types.Validator, error)
+
+	// ValidatorByAddress extract a staker information by address.
+	ValidatorByAddress(*common.Address) (*types.Validator, error)
+
+	// ValidatorDowntime pulls information about validator downtime from the RPC interface.
+	ValidatorDowntime(*hexutil.Big) (uint64, uint64, error)
+
+	// ValidatorDelegatorsCount provides the number of active delegators of the given validator.
+	ValidatorDelegatorsCount(*hexutil.Big) (uint64, error)
+
+	// StakingStats provides recent aggregated staking statistics of the network.
+	StakingStats() (*types.StakingStats, error)
+
+	// ValidatorAprHistory provides the realized APR of the given validator
+	// in the given range of sealed epochs.
+	ValidatorAprHistory(valID *hexutil.Big, from hexutil.Uint64, to hexutil.Uint64) ([]*types.ValidatorApr, error)
+
+	// ValidatorCommissionHistory provides the commission of the given validator at the start
+	// of the given range of sealed epochs and each change of it within the range.
+	ValidatorCommissionHistory(valID *hexutil.Big, from hexutil.Uint64, to hexutil.Uint64) ([]*types.CommissionChange, error)
+
+	// DailyRewards provides the list of daily aggregations of reward claims.
+	DailyRewards(from *time.Time, to *time.Time) ([]*types.DailyRewards, error)
+
+	// SfcConfiguration provides SFC contract configuration.
+	SfcConfiguration() (*types.SfcConfig, error)
+
+	// SfcMaxDelegatedRatio extracts a ratio between self delegation and received stake.
+	SfcMaxDelegatedRatio() (*big.Int, error)
+
+	// RetrieveStakerInfo gets staker information from in-memory if available.
+	RetrieveStakerInfo(*hexutil.Big) *types.StakerInfo
+
+	// IsDelegating returns if the given address is an SFC delegator.
+	IsDelegating(*common.Address) (bool, error)
+
+	// Delegation returns a detail of delegation for the given address and validator ID.
+	Delegation(*common.Address, *hexutil.Big) (*types.Delegation, error)
+
+	// DelegationAmountStaked returns the current amount of staked tokens
+	// for the given delegation.
+	DelegationAmountStaked(*common.Address, *hexutil.Big) (*big.Int, error)
+
+	// DelegationsByAddress returns a list of all delegations of a given delegator address.
+	DelegationsByAddress(*common.Address, *string, int32) (*types.DelegationList, error)
+
+	// DelegationsByAddressAll returns a list of all delegations of the given address un-paged.
+	DelegationsByAddressAll(addr *common.Address) ([]*types.Delegation, error)
+
+	// DelegationsOfValidator extracts a list of delegations for a validator by its ID.
+	DelegationsOfValidator(*hexutil.Big, *string, int32) (*types.DelegationList, error)
+
+	// DelegationLock returns delegation lock information using SFC contract binding.
+	DelegationLock(*common.Address, *hexutil.Big) (*types.DelegationLock, error)
+
+	// DelegationUnlockPenalty returns the amount of penalty applied on given stake unlock.
+	DelegationUnlockPenalty(addr *common.Address, valID *big.Int, amount *big.Int) (hexutil.Big, error)
+
+	// DelegationAmountUnlocked returns delegation lock information using SFC contract binding.
+	DelegationAmountUnlocked(addr *common.Address, valID *big.Int) (hexutil.Big, error)
+
+	// PendingRewards returns a detail of pending rewards for the given delegation.
+	PendingRewards(*common.Address, *hexutil.Big) (*types.PendingRewards, error)
+
+	// DelegationOutstandingSFTM returns the amount of sFTM tokens for the delegation
+	// identified by the delegator address and the staker id.
+	DelegationOutstandingSFTM(*common.Address, *hexutil.Big) (*hexutil.Big, error)
+
+	// DelegationTokenizerUnlocked returns the status of SFC Tokenizer lock
+	// for a delegation identified by the address and staker id.
+	DelegationTokenizerUnlocked(*common.Address, *hexutil.Big) (bool, error)
+
+	// DelegationFluidStakingActive signals if the delegation is upgraded to Fluid Staking model.
+	DelegationFluidStakingActive(*common.Address, *hexutil.Big) (bool, error)
+
+	// WithdrawRequest extracts details of a withdraw request specified by the delegator, validator and request ID.
+	WithdrawRequest(*common.Address, *hexutil.Big, *hexutil.Big) (*types.WithdrawRequest, error)
+
+	// WithdrawRequests extracts a list of withdraw requests for the given address and validator.
+	WithdrawRequests(*common.Address, *hexutil.Big, *string, int32) (*types.WithdrawRequestList, error)
+
+	// WithdrawRequestsPendingTotal is the total value of all pending withdrawal requests
+	// for the given delegator and target staker ID.
+	WithdrawRequestsPendingTotal(*common.Address, *hexutil.Big) (*big.Int, error)
+
+	// RewardsClaimed returns the sum of all the claimed rewards
+	// for the given delegator address and validator ID.
+	RewardsClaimed(adr *common.Address, valId *big.Int) (*big.Int, error)
+
+	// RewardClaims provides list of reward claims for the given criteria.
+	RewardClaims(*common.Address, *big.Int, *string, int32) (*types.RewardClaimsList, error)
+
+	// DelegationOperations provides list of operations of the given delegation.
+	DelegationOperations(*common.Address, *hexutil.Big, *string, int32) (*types.DelegationOperationList, error)
+}
+
+// TokenReader provides read access to ERC20 tokens and their transactions.
+type TokenReader interface {
+	// Erc20Transactions provides list of ERC20 transactions based on given filters.
+	Erc20Transactions(token *common.Address, acc *common.Address, tt *int32, cursor *string, count int32) (*types.Erc20TransactionList, error)
+
+	// LargeErc20Transfers provides list of transfers of the given ERC20 token of at least the given amount.
+	LargeErc20Transfers(token *common.Address, minValue *big.Int, cursor *string, count int32) (*types.Erc20TransactionList, error)
+
+	// Erc20Approvals provides the latest approval of each ERC20 token
+	// and spender pair granted by the given owner.
+	Erc20Approvals(*common.Address) ([]*types.Erc20Transaction, error)
+
+	// Erc20Token returns an ERC20 token rfor the given address, if available.
+	Erc20Token(*common.Address) (*types.Erc20Token, error)
+
+	// Erc20TokensList returns a list of known ERC20 tokens ordered by their activity.
+	Erc20TokensList(int32) ([]common.Address, error)
+
+	// Erc20BalanceOf load the current available balance of and ERC20 token identified by the token
+	// contract address for an identified owner address.
+	Erc20BalanceOf(*common.Address, *common.Address) (hexutil.Big, error)
+
+	// Erc20BalancesOf loads the current available balances of the given list
+	// of ERC20 tokens for the identified owner address in batches.
+	Erc20BalancesOf([]common.Address, *common.Address) ([]hexutil.Big, error)
+
+	// Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
+	// contract by the token owner.
+	Erc20Allowance(*common.Address, *common.Address, *common.Address) (hexutil.Big, error)
+
+	// Erc20TotalSupply provides information about all available tokens
+	Erc20TotalSupply(*common.Address) (hexutil.Big, error)
+
+	// Erc20Name provides information about the name of the ERC20 token.
+	Erc20Name(*common.Address) (string, error)
+
+	// Erc20Symbol provides information about the symbol of the ERC20 token.
+	Erc20Symbol(*common.Address) (string, error)
+
+	// Erc20Decimals provides information about the decimals of the ERC20 token.
+	Erc20Decimals(*common.Address) (int32, error)
+
+	// Erc20LogoURL provides URL address of a logo of the ERC20 token.
+	Erc20LogoURL(*common.Address) string
+
+	// TokenMeta provides curated metadata of the given token.
+	// It returns nil if the token is not known to the registry.
+	TokenMeta(*common.Address) (*types.TokenMeta, error)
+}
+
+// Cache provides control over the in-memory cache of frequently accessed entities.
+type Cache interface {
+	// CacheBlock puts a block to the internal block ring cache.
+	CacheBlock(blk *types.Block)
+
+	// CacheTransaction puts a transaction to the internal ring cache.
+	CacheTransaction(trx *types.Transaction)
+
+	// CacheStats provides the statistics of the in-memory cache.
+	CacheStats() types.CacheStats
+
+	// PurgeCache removes the entry with the given key from the in-memory cache.
+	PurgeCache(string) bool
+}
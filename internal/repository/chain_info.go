@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// chainNetworks maps known chain identifiers to the names of their networks.
+var chainNetworks = map[uint64]string{
+	250:  "Opera Mainnet",
+	4002: "Opera Testnet",
+}
+
+// ChainInfo provides the identification and capabilities of the chain and the connected node.
+func (p *proxy) ChainInfo() (*types.ChainInfo, error) {
+	id, err := p.rpc.ChainId()
+	if err != nil {
+		return nil, err
+	}
+
+	ci := types.ChainInfo{
+		ChainId:  id,
+		Network:  chainNetworks[id],
+		Features: p.enabledFeatures(),
+	}
+
+	// the node may restrict the informative calls; it's not critical
+	if ci.ClientVersion, err = p.rpc.ClientVersion(); err != nil {
+		p.log.Warningf("node client version not available; %s", err.Error())
+	}
+	if ci.Modules, err = p.rpc.RpcModules(); err != nil {
+		p.log.Warningf("node RPC modules not available; %s", err.Error())
+		ci.Modules = make([]string, 0)
+	}
+	return &ci, nil
+}
+
+// enabledFeatures builds the list of optional API features enabled by the configuration.
+func (p *proxy) enabledFeatures() []string {
+	empty := common.Address{}
+	defi := !p.cfg.Features.IsDisabled(config.FeatureDeFi)
+	gov := !p.cfg.Features.IsDisabled(config.FeatureGovernance)
+	list := make([]string, 0)
+	for _, f := range []struct {
+		name string
+		on   bool
+	}{
+		{"fmint", defi && p.cfg.DeFi.FMint.AddressProvider != empty},
+		{"flend", defi && p.cfg.DeFi.FLend.LendingPool != empty},
+		{"uniswap", defi && p.cfg.DeFi.Uniswap.Core != empty && p.cfg.DeFi.Uniswap.Router != empty},
+		{"governance", gov && len(p.cfg.Governance.Contracts) > 0},
+		{"ballots", gov && len(p.cfg.Governance.Ballots) > 0},
+		{"fns", p.cfg.NameService.Registry != empty},
+		{"multicall", p.cfg.Lachesis.Multicall != empty},
+		{"trace_creations", p.cfg.Repository.TraceCreations},
+		{"token_list", p.cfg.TokenList.Url != ""},
+		{"watch_lists", len(p.cfg.Auth.ClientKeys) > 0},
+		{"playground", p.cfg.Server.Playground.Enabled},
+	} {
+		if f.on {
+			list = append(list, f.name)
+		}
+	}
+	return list
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/compiler"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"strings"
+	"time"
 )
 
 // Contract extract a smart contract information by account address, if available.
@@ -49,6 +50,25 @@ func (p *proxy) Contracts(validatedOnly bool, cursor *string, count int32) (*typ
 	return p.db.Contracts(validatedOnly, cursor, count)
 }
 
+// contractMatchRank ranks the byte code match levels by their quality.
+var contractMatchRank = map[string]int{
+	types.ContractMatchPartial:          1,
+	types.ContractMatchMetadataStripped: 2,
+	types.ContractMatchExact:            3,
+}
+
+// metadataHashKeys represents CBOR encoded keys of the compiler metadata hash
+// we recognize in the byte code metadata section (IPFS and Swarm versions).
+var metadataHashKeys = [][]byte{
+	append([]byte{0x64}, "ipfs"...),
+	append([]byte{0x65}, "bzzr1"...),
+	append([]byte{0x65}, "bzzr0"...),
+}
+
+// libraryPlaceholderLength is the length of a library address placeholder
+// in the hex encoded compiler output of a not linked byte code.
+const libraryPlaceholderLength = 2 * common.AddressLength
+
 // cutCodeMetadata removes the IPFS/Swarm metadata information from the code
 // for partial comparison. The current version of the Solidity compiler usually
 // adds metadata to the end of the deployed byte code.
@@ -56,6 +76,9 @@ func (p *proxy) Contracts(validatedOnly bool, cursor *string, count int32) (*typ
 func cutCodeMetadata(bc []byte) []byte {
 	// last 2 bytes are expected to contain metadata length
 	bcLen := uint64(len(bc))
+	if bcLen < 2 {
+		return bc
+	}
 	cut := uint64(bc[bcLen-2])<<8 | uint64(bc[bcLen-1])
 
 	// are we safely within the byte code size?
@@ -66,12 +89,61 @@ func cutCodeMetadata(bc []byte) []byte {
 	return bc[:bcLen-cut-2]
 }
 
-// compareContractCode compares provided compiled code with the transaction input.
-func compareContractCode(tx *types.Transaction, code string) (bool, error) {
+// codeMetadataHash extracts the compiler metadata hash from the metadata
+// section of the byte code. Nil is returned if the hash is not recognized.
+func codeMetadataHash(bc []byte) hexutil.Bytes {
+	meta := bc[len(cutCodeMetadata(bc)):]
+	for _, key := range metadataHashKeys {
+		// the key is followed by the CBOR byte string header and length
+		ix := bytes.Index(meta, key)
+		if ix < 0 || len(meta) < ix+len(key)+2 || meta[ix+len(key)] != 0x58 {
+			continue
+		}
+
+		from := ix + len(key) + 2
+		to := from + int(meta[from-1])
+		if to <= len(meta) {
+			return common.CopyBytes(meta[from:to])
+		}
+	}
+	return nil
+}
+
+// decodeContractCode decodes the hex encoded compiler output. Placeholders
+// of not linked libraries are zeroed and their byte offsets are returned
+// so the comparison can skip them.
+func decodeContractCode(code string) ([]byte, []int, error) {
+	code = strings.TrimPrefix(code, "0x")
+
+	var str strings.Builder
+	libs := make([]int, 0)
+	for i := 0; i < len(code); {
+		if strings.HasPrefix(code[i:], "__") && len(code) >= i+libraryPlaceholderLength {
+			libs = append(libs, i/2)
+			str.WriteString(strings.Repeat("0", libraryPlaceholderLength))
+			i += libraryPlaceholderLength
+			continue
+		}
+		str.WriteByte(code[i])
+		i++
+	}
+
+	bc, err := hexutil.Decode("0x" + str.String())
+	return bc, libs, err
+}
+
+// compareContractCode compares provided compiled code with the transaction input
+// and returns the match level reached. Empty level is returned if the code does not match.
+func compareContractCode(tx *types.Transaction, code string) (string, error) {
 	// decode the detail into byte array
-	bc, err := hexutil.Decode(code)
+	bc, libs, err := decodeContractCode(code)
 	if err != nil {
-		return false, err
+		return "", err
+	}
+
+	// full byte code including the metadata is the best we can get
+	if len(libs) == 0 && len(tx.InputData) >= len(bc) && bytes.Equal(bc, tx.InputData[:len(bc)]) {
+		return types.ContractMatchExact, nil
 	}
 
 	// remove meta data hash from the byte code so we can compare raw
@@ -84,15 +156,29 @@ func compareContractCode(tx *types.Transaction, code string) (bool, error) {
 	// Is the transaction input shorter than the compiled contract?
 	// If so there is no chance for pass.
 	if len(tx.InputData) < len(bc) {
-		return false, nil
+		return "", nil
 	}
 
 	// compare only up to <bc> length, the rest is metadata
 	// and constructor parameters
-	res := bytes.Compare(bc, tx.InputData[:len(bc)])
+	in := common.CopyBytes(tx.InputData[:len(bc)])
+	if len(libs) == 0 {
+		if bytes.Equal(bc, in) {
+			return types.ContractMatchMetadataStripped, nil
+		}
+		return "", nil
+	}
 
-	// return the comparison result
-	return res == 0, nil
+	// linked library addresses are not known to the compiler, skip them
+	for _, ix := range libs {
+		if ix+common.AddressLength <= len(in) {
+			copy(in[ix:ix+common.AddressLength], make([]byte, common.AddressLength))
+		}
+	}
+	if bytes.Equal(bc, in) {
+		return types.ContractMatchPartial, nil
+	}
+	return "", nil
 }
 
 // updateContractDetails updates local contract details from the provided compiler
@@ -115,6 +201,35 @@ func updateContractDetails(sc *types.Contract, detail *compiler.Contract) {
 	sc.SourceCode = detail.Info.Source
 }
 
+// newContractArtifact builds the compilation artifact of the validated contract
+// from the provided compiler output.
+func newContractArtifact(sc *types.Contract, name string, detail *compiler.Contract) *types.ContractArtifact {
+	ca := types.ContractArtifact{
+		Address:           sc.Address,
+		Name:              strings.TrimPrefix(name, "<stdin>:"),
+		Compiler:          sc.Compiler,
+		CompilerVersion:   detail.Info.CompilerVersion,
+		CompilerOptions:   detail.Info.CompilerOptions,
+		Abi:               sc.Abi,
+		Bytecode:          detail.Code,
+		DeployedBytecode:  detail.RuntimeCode,
+		DeployedSourceMap: detail.Info.SrcMapRuntime,
+		Metadata:          detail.Info.Metadata,
+		Created:           hexutil.Uint64(time.Now().UTC().Unix()),
+	}
+
+	// the source map is not typed by the compiler package
+	if sm, ok := detail.Info.SrcMap.(string); ok {
+		ca.SourceMap = sm
+	}
+	return &ca
+}
+
+// ContractArtifact returns the compilation artifact of a validated contract, if available.
+func (p *proxy) ContractArtifact(addr *common.Address) (*types.ContractArtifact, error) {
+	return p.db.ContractArtifact(addr)
+}
+
 // ValidateContract tries to validate contract byte code using
 // provided source code. If successful, the contract information
 // is updated the the repository.
@@ -122,49 +237,69 @@ func (p *proxy) ValidateContract(sc *types.Contract) error {
 	// get the byte code of the actual contract
 	tx, err := p.Transaction(&sc.TransactionHash)
 	if err != nil {
-		p.log.Errorf("can not get contract deployment transaction; %s", err.Error())
+		p.valLog.Errorf("can not get contract deployment transaction; %s", err.Error())
 		return err
 	}
 
 	// try to compile the source code provided
 	contracts, err := compiler.CompileSolidityString(p.solCompiler, sc.SourceCode)
 	if err != nil {
-		p.log.Errorf("solidity code compilation failed")
+		p.valLog.Errorf("solidity code compilation failed")
 		return err
 	}
 
-	// loop over contracts ad try to validate one of them
+	// loop over contracts and find the best match of them
+	var best, bestName string
+	var bestDetail *compiler.Contract
 	for name, detail := range contracts {
 		// check if the compiled byte code match with the deployed contract
-		match, err := compareContractCode(tx, detail.Code)
+		level, err := compareContractCode(tx, detail.Code)
 		if err != nil {
-			p.log.Errorf("contract byte code comparison failed")
+			p.valLog.Errorf("contract byte code comparison failed")
 			return err
 		}
 
-		// we have the winner
-		if match {
-			// set the contract name if not done already
-			if 0 == len(sc.Name) {
-				sc.Name = strings.TrimPrefix(name, "<stdin>:")
-			}
+		// keep the better match
+		if contractMatchRank[level] > contractMatchRank[best] {
+			best, bestName, bestDetail = level, name, detail
+		}
+	}
 
-			// update the contract data
-			updateContractDetails(sc, detail)
+	// we have the winner
+	if bestDetail != nil {
+		// set the contract name if not done already
+		if 0 == len(sc.Name) {
+			sc.Name = strings.TrimPrefix(bestName, "<stdin>:")
+		}
 
-			// write update to the database
-			if err := p.db.UpdateContract(sc); err != nil {
-				p.log.Errorf("contract validation failed due to db error; %s", err.Error())
-				return err
-			}
+		// update the contract data
+		updateContractDetails(sc, bestDetail)
+		sc.MatchLevel = best
+		now := hexutil.Uint64(time.Now().UTC().Unix())
+		sc.Validated = &now
+		sc.VerifiedFrom = nil
+		sc.MetadataHash = nil
+		if bc, _, err := decodeContractCode(bestDetail.Code); err == nil {
+			sc.MetadataHash = codeMetadataHash(bc)
+		}
 
-			// inform about success
-			p.log.Debugf("contract %s [%s] validated", sc.Address.String(), name)
-			p.cache.EvictContract(&sc.Address)
+		// write update to the database
+		if err := p.db.UpdateContract(sc); err != nil {
+			p.valLog.Errorf("contract validation failed due to db error; %s", err.Error())
+			return err
+		}
 
-			// inform the upper instance we have a winner
-			return nil
+		// keep the full compilation output for the tooling; the validation itself is done
+		if err := p.db.StoreContractArtifact(newContractArtifact(sc, bestName, bestDetail)); err != nil {
+			p.valLog.Errorf("contract %s artifact not stored; %s", sc.Address.String(), err.Error())
 		}
+
+		// inform about success
+		p.valLog.Debugf("contract %s [%s] validated with %s match", sc.Address.String(), bestName, best)
+		p.cache.EvictContract(&sc.Address)
+
+		// inform the upper instance we have a winner
+		return nil
 	}
 
 	// validation fails
@@ -176,6 +311,14 @@ func (p *proxy) StoreContract(con *types.Contract) error {
 	// is the a known contract which will be updated?
 	isUpdate := p.db.IsContractKnown(&con.Address)
 
+	// hash the runtime code so identical deployments can be found; the hashing
+	// is re-tried by the contract classifier if it fails here
+	if con.CodeHash == nil {
+		if hash, err := p.contractCodeHash(&con.Address); err == nil {
+			con.CodeHash = hash
+		}
+	}
+
 	// do the add/update op
 	if err := p.db.AddContract(con); err != nil {
 		p.log.Errorf("contract %s store failed; %s", con.Address.String(), err.Error())
@@ -0,0 +1,265 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"encoding/binary"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"sync"
+)
+
+const (
+
+	// contractClassifierBatch represents the number of contracts classified in one round.
+	contractClassifierBatch = 100
+
+	// opPush4 is the EVM PUSH4 opcode used to push function selectors.
+	opPush4 = 0x63
+)
+
+// ERC-165 interface identifiers of the interfaces we are able to query.
+var (
+	erc165InterfaceId  = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	erc165InvalidId    = [4]byte{0xff, 0xff, 0xff, 0xff}
+	erc721InterfaceId  = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	erc1155InterfaceId = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+)
+
+// contractFingerprints maps detectable interfaces to the function selectors
+// which all must be present in the contract byte code.
+var contractFingerprints = []struct {
+	name      string
+	selectors []uint32
+}{
+	// totalSupply, balanceOf, transfer, transferFrom, approve, allowance
+	{types.AccountTypeERC20Token, []uint32{0x18160ddd, 0x70a08231, 0xa9059cbb, 0x23b872dd, 0x095ea7b3, 0xdd62ed3e}},
+
+	// balanceOf, ownerOf, safeTransferFrom, transferFrom, approve, getApproved
+	{types.AccountTypeERC721Token, []uint32{0x70a08231, 0x6352211e, 0x42842e0e, 0x23b872dd, 0x095ea7b3, 0x081812fc}},
+
+	// balanceOfBatch, safeTransferFrom, safeBatchTransferFrom, setApprovalForAll
+	{types.AccountTypeERC1155Token, []uint32{0x4e1273f4, 0xf242432a, 0x2eb2c2d6, 0xa22cb465}},
+
+	// getOwners, getThreshold, execTransaction
+	{types.AccountTypeGnosisSafe, []uint32{0xa0e67e2b, 0xe75235b8, 0x6a761202}},
+
+	// token0, token1, getReserves, swap
+	{types.AccountTypeUniswapPair, []uint32{0x0dfe1681, 0xd21220a7, 0x0902f1ac, 0x022c0d9f}},
+}
+
+// contractTypePriority is the order in which the detected interfaces decide
+// the contract type. Token types go first so the contracts already known
+// as tokens keep their type.
+var contractTypePriority = []string{
+	types.AccountTypeSFC,
+	types.AccountTypeERC20Token,
+	types.AccountTypeERC721Token,
+	types.AccountTypeERC1155Token,
+	types.AccountTypeGnosisSafe,
+	types.AccountTypeUniswapPair,
+	types.AccountTypeProxy,
+}
+
+// contractClassifier represents a service classifying known contracts
+// by their interfaces.
+type contractClassifier struct {
+	service
+}
+
+// newContractClassifier creates a new contract classifier service.
+func newContractClassifier(repo Repository, log logger.Logger, wg *sync.WaitGroup) *contractClassifier {
+	return &contractClassifier{
+		service: newPeriodicService("contract classifier", repo, log, wg),
+	}
+}
+
+// run starts the contract classifier service
+func (cc *contractClassifier) run() {
+	cc.wg.Add(1)
+	go cc.schedule()
+}
+
+// schedule schedules regular contract classification rounds.
+func (cc *contractClassifier) schedule() {
+	// inform about the service
+	cc.log.Notice("contract classifier is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		cc.log.Notice("contract classifier is closed")
+		cc.wg.Done()
+	}()
+
+	// run on schedule
+	cc.loop(func() {
+		cc.exclusive(func() {
+			cc.classify()
+			cc.hashCode()
+		})
+	})
+}
+
+// classify classifies a batch of contracts not classified yet.
+func (cc *contractClassifier) classify() {
+	list, err := cc.repo.AccountsToClassify(contractClassifierBatch)
+	if err != nil {
+		cc.log.Errorf("can not load contracts to classify; %s", err.Error())
+		return
+	}
+
+	for i := range list {
+		// check for the stop signal between contracts, the classification is slow;
+		// the closed signal channel stops the scheduler loop as well
+		select {
+		case <-cc.sigStop:
+			return
+		default:
+		}
+
+		if err := cc.repo.ClassifyAccount(&list[i]); err != nil {
+			cc.log.Errorf("can not classify contract %s; %s", list[i].String(), err.Error())
+		}
+	}
+}
+
+// hashCode calculates the runtime byte code hash of a batch of contracts not hashed yet.
+func (cc *contractClassifier) hashCode() {
+	if _, err := cc.repo.UpdateContractCodeHashes(contractClassifierBatch); err != nil {
+		cc.log.Errorf("can not hash contracts code; %s", err.Error())
+	}
+}
+
+// AccountsToClassify loads a batch of contract accounts which have not been classified yet.
+func (p *proxy) AccountsToClassify(limit int64) ([]common.Address, error) {
+	return p.db.AccountsToClassify(limit)
+}
+
+// ClassifyAccount detects the interfaces of the contract at the given address
+// and stores the structured classification with the account.
+// The type of the account is updated only if it's a generic contract,
+// types detected on the account creation are kept.
+func (p *proxy) ClassifyAccount(addr *common.Address) error {
+	acc, err := p.Account(addr)
+	if err != nil {
+		return err
+	}
+
+	// detect the interfaces
+	ifs, err := p.contractInterfaces(addr)
+	if err != nil {
+		return err
+	}
+
+	// pick the type
+	cType := acc.Type
+	if cType == types.AccountTypeContract {
+		cType = contractType(ifs)
+	}
+
+	// store and make sure the cache does not serve the old state
+	if err := p.db.UpdateAccountClass(addr, cType, ifs); err != nil {
+		return err
+	}
+	p.cache.EvictAccount(addr)
+	return nil
+}
+
+// contractInterfaces detects the interfaces implemented by the contract at the given address
+// using ERC-165 queries and function selectors present in the byte code.
+func (p *proxy) contractInterfaces(addr *common.Address) ([]string, error) {
+	ifs := make([]string, 0)
+	if p.IsSfcContract(addr) {
+		ifs = append(ifs, types.AccountTypeSFC)
+	}
+
+	code, err := p.rpc.ContractCode(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// proxy contracts are classified by their implementation
+	if impl := p.rpc.ProxyImplementation(addr, code); impl != nil {
+		ifs = append(ifs, types.AccountTypeProxy)
+
+		ic, err := p.rpc.ContractCode(impl)
+		if err != nil {
+			return nil, err
+		}
+		code = append(code, ic...)
+	}
+
+	// ERC-165 query is authoritative for NFT standards
+	erc165 := p.rpc.SupportsInterface(addr, erc165InterfaceId) && !p.rpc.SupportsInterface(addr, erc165InvalidId)
+	if erc165 {
+		ifs = append(ifs, types.ContractInterfaceERC165)
+	}
+
+	// check the selectors
+	sel := codeSelectors(code)
+	for _, fp := range contractFingerprints {
+		if hasAllSelectors(sel, fp.selectors) || (erc165 && p.supportsStandard(addr, fp.name)) {
+			ifs = append(ifs, fp.name)
+		}
+	}
+	return ifs, nil
+}
+
+// supportsStandard checks ERC-165 support of the given standard, if the standard has an interface id.
+func (p *proxy) supportsStandard(addr *common.Address, name string) bool {
+	switch name {
+	case types.AccountTypeERC721Token:
+		return p.rpc.SupportsInterface(addr, erc721InterfaceId)
+	case types.AccountTypeERC1155Token:
+		return p.rpc.SupportsInterface(addr, erc1155InterfaceId)
+	}
+	return false
+}
+
+// contractType decides the contract type from the list of detected interfaces.
+func contractType(ifs []string) string {
+	for _, t := range contractTypePriority {
+		for _, i := range ifs {
+			if i == t {
+				return t
+			}
+		}
+	}
+	return types.AccountTypeContract
+}
+
+// codeSelectors collects the function selectors pushed to stack by the given byte code.
+func codeSelectors(code []byte) map[uint32]bool {
+	sel := make(map[uint32]bool)
+	for i := 0; i < len(code); i++ {
+		op := code[i]
+
+		// collect PUSH4 data
+		if op == opPush4 && i+4 < len(code) {
+			sel[binary.BigEndian.Uint32(code[i+1:i+5])] = true
+		}
+
+		// skip data of PUSH1 to PUSH32
+		if op >= 0x60 && op <= 0x7f {
+			i += int(op - 0x5f)
+		}
+	}
+	return sel
+}
+
+// hasAllSelectors checks if all the given selectors are in the set.
+func hasAllSelectors(set map[uint32]bool, list []uint32) bool {
+	for _, s := range list {
+		if !set[s] {
+			return false
+		}
+	}
+	return true
+}
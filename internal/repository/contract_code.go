@@ -0,0 +1,213 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"sync"
+)
+
+const (
+	// contractsSameCodeMaxCount is the max number of contracts with identical byte code
+	// loaded in a single query, or updated by a single validation propagation.
+	contractsSameCodeMaxCount = 500
+
+	// validationPropagatorBatch represents the number of validated contracts
+	// propagated in one round.
+	validationPropagatorBatch = 50
+)
+
+// validationPropagator represents a service copying source code validation
+// to not validated contracts with identical runtime byte code.
+type validationPropagator struct {
+	service
+}
+
+// newValidationPropagator creates a new validation propagator service.
+func newValidationPropagator(repo Repository, log logger.Logger, wg *sync.WaitGroup) *validationPropagator {
+	return &validationPropagator{
+		service: newPeriodicService("validation propagator", repo, log, wg),
+	}
+}
+
+// run starts the validation propagator service
+func (vp *validationPropagator) run() {
+	vp.wg.Add(1)
+	go vp.schedule()
+}
+
+// schedule schedules regular validation propagation rounds.
+func (vp *validationPropagator) schedule() {
+	// inform about the service
+	vp.log.Notice("validation propagator is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		vp.log.Notice("validation propagator is closed")
+		vp.wg.Done()
+	}()
+
+	// run on schedule
+	vp.loop(func() {
+		vp.exclusive(vp.propagate)
+	})
+}
+
+// propagate copies validation of a batch of validated contracts to their identical deployments.
+func (vp *validationPropagator) propagate() {
+	list, err := vp.repo.ContractsToPropagate(validationPropagatorBatch)
+	if err != nil {
+		vp.log.Errorf("can not load contracts to propagate; %s", err.Error())
+		return
+	}
+
+	for i := range list {
+		if _, err := vp.repo.PropagateContractValidation(&list[i]); err != nil {
+			vp.log.Errorf("can not propagate validation of contract %s; %s", list[i].String(), err.Error())
+		}
+	}
+}
+
+// emptyCodeHash is the hash of an empty byte code, i.e. of a destroyed contract.
+var emptyCodeHash = crypto.Keccak256Hash(nil)
+
+// contractCodeHash calculates the hash of the runtime byte code deployed at the given address.
+func (p *proxy) contractCodeHash(addr *common.Address) (*common.Hash, error) {
+	code, err := p.rpc.ContractCode(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := crypto.Keccak256Hash(code)
+	return &hash, nil
+}
+
+// UpdateContractCodeHashes calculates the runtime byte code hash of a batch of contracts
+// which don't have it yet. It returns the number of contracts updated.
+func (p *proxy) UpdateContractCodeHashes(limit int64) (int, error) {
+	list, err := p.db.ContractsWithoutCodeHash(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range list {
+		hash, err := p.contractCodeHash(&list[i])
+		if err != nil {
+			return i, err
+		}
+
+		if err := p.db.SetContractCodeHash(&list[i], hash); err != nil {
+			return i, err
+		}
+		p.cache.EvictContract(&list[i])
+	}
+	return len(list), nil
+}
+
+// ContractsWithSameBytecode loads contracts with the runtime byte code identical
+// to the contract at the given address.
+func (p *proxy) ContractsWithSameBytecode(addr *common.Address, limit int64) ([]*types.Contract, error) {
+	sc, err := p.Contract(addr)
+	if err != nil {
+		return nil, err
+	}
+	if sc == nil {
+		return nil, fmt.Errorf("contract %s not found", addr.String())
+	}
+
+	// the code is not hashed yet, or the contract is gone
+	if sc.CodeHash == nil || *sc.CodeHash == emptyCodeHash {
+		return []*types.Contract{}, nil
+	}
+	return p.db.ContractsByCodeHash(sc.CodeHash, addr, limit)
+}
+
+// PropagateContractValidation copies the source code validation of the contract
+// at the given address to the not validated contracts with identical runtime byte code.
+// It returns the number of contracts updated.
+func (p *proxy) PropagateContractValidation(addr *common.Address) (int, error) {
+	src, err := p.Contract(addr)
+	if err != nil {
+		return 0, err
+	}
+	if src == nil || src.Validated == nil {
+		return 0, fmt.Errorf("contract %s is not validated", addr.String())
+	}
+
+	// keep the reference to the directly validated contract
+	ref := src.Address
+	if src.VerifiedFrom != nil {
+		ref = *src.VerifiedFrom
+	}
+
+	// nothing to propagate to if the code is not known
+	if src.CodeHash == nil || *src.CodeHash == emptyCodeHash {
+		return 0, nil
+	}
+
+	list, err := p.db.UnvalidatedContractsByCodeHash(src.CodeHash, contractsSameCodeMaxCount)
+	if err != nil {
+		return 0, err
+	}
+
+	// the artifact is shared by identical deployments
+	ca, err := p.db.ContractArtifact(addr)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, sc := range list {
+		copyContractValidation(src, sc)
+		sc.VerifiedFrom = &ref
+		if err := p.db.UpdateContract(sc); err != nil {
+			return count, err
+		}
+		p.cache.EvictContract(&sc.Address)
+
+		if ca != nil {
+			cp := *ca
+			cp.Address = sc.Address
+			if err := p.db.StoreContractArtifact(&cp); err != nil {
+				p.valLog.Errorf("contract %s artifact not stored; %s", sc.Address.String(), err.Error())
+			}
+		}
+		count++
+	}
+
+	p.valLog.Noticef("validation of contract %s propagated to %d identical contracts", addr.String(), count)
+	return count, nil
+}
+
+// ContractsToPropagate finds directly validated contracts which have not validated
+// contracts with identical runtime byte code deployed.
+func (p *proxy) ContractsToPropagate(limit int64) ([]common.Address, error) {
+	return p.db.ContractsToPropagate(&emptyCodeHash, limit)
+}
+
+// copyContractValidation copies the validated source code details between contracts.
+func copyContractValidation(src *types.Contract, sc *types.Contract) {
+	sc.Name = src.Name
+	sc.Version = src.Version
+	sc.SupportContact = src.SupportContact
+	sc.License = src.License
+	sc.Compiler = src.Compiler
+	sc.IsOptimized = src.IsOptimized
+	sc.OptimizeRuns = src.OptimizeRuns
+	sc.SourceCode = src.SourceCode
+	sc.SourceCodeHash = src.SourceCodeHash
+	sc.Abi = src.Abi
+	sc.Validated = src.Validated
+	sc.MatchLevel = src.MatchLevel
+	sc.MetadataHash = src.MetadataHash
+}
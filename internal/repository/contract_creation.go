@@ -0,0 +1,36 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TraceTransaction collects contracts deployed by other contracts and accounts
+// involved in internal value transfers of the given transaction using the node call trace.
+func (p *proxy) TraceTransaction(trx *types.Transaction) (*types.TransactionTrace, error) {
+	return p.rpc.TraceTransaction(trx)
+}
+
+// StoreContractCreation stores the given contract creation record.
+func (p *proxy) StoreContractCreation(cc *types.ContractCreation) error {
+	return p.db.AddContractCreation(cc)
+}
+
+// ContractCreation provides the creation record of the given contract,
+// nil if the contract was not deployed by another contract.
+func (p *proxy) ContractCreation(addr *common.Address) (*types.ContractCreation, error) {
+	return p.db.ContractCreation(addr)
+}
+
+// ContractCreationsBy provides the most recent contracts deployed by the given creator contract.
+func (p *proxy) ContractCreationsBy(creator *common.Address, limit int64) ([]*types.ContractCreation, error) {
+	return p.db.ContractCreationsBy(creator, limit)
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+// StoreContractEvent stores contract event record in the persistent repository.
+func (p *proxy) StoreContractEvent(ev *types.ContractEvent) error {
+	return p.db.AddContractEvent(ev)
+}
+
+// ContractEvents provides a list of events emitted by the given contract.
+func (p *proxy) ContractEvents(addr *common.Address, cursor *string, count int32) (*types.ContractEventList, error) {
+	p.log.Debugf("loading events of contract %s", addr.String())
+	return p.db.ContractEvents(cursor, count, &bson.D{{types.FiContractEventContract, addr.String()}})
+}
+
+// ContractEventStats provides daily counters of events emitted by the given contract
+// for the given number of days, including today.
+func (p *proxy) ContractEventStats(addr *common.Address, days int) ([]*types.ContractEventStat, error) {
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1-days)
+	return p.db.ContractEventStats(addr, since)
+}
+
+// storeContractEvent records the event log in the index of events of the emitting contract.
+func (ld *logsDispatcher) storeContractEvent(log *retypes.Log) {
+	// get the block
+	blk := hexutil.Uint64(log.BlockNumber)
+	block, err := ld.repo.BlockByNumber(&blk)
+	if err != nil {
+		ld.log.Errorf("can not index event of %s; %s", log.Address.String(), err.Error())
+		return
+	}
+
+	// store the event
+	if err := ld.repo.StoreContractEvent(&types.ContractEvent{
+		Contract:    log.Address,
+		Topics:      log.Topics,
+		Data:        log.Data,
+		Trx:         log.TxHash,
+		BlockNumber: log.BlockNumber,
+		LogIndex:    log.Index,
+		TimeStamp:   block.TimeStamp,
+	}); err != nil {
+		ld.log.Errorf("failed to store contract event; %s", err.Error())
+	}
+}
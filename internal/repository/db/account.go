@@ -30,6 +30,9 @@ const (
 	// fiAccountTransactionCounter is the name of the field of the account transaction counter.
 	fiAccountTransactionCounter = "atc"
 
+	// fiAccountInterfaces is the name of the field of the account contract interfaces.
+	fiAccountInterfaces = "ifs"
+
 	// fiScCreationTx is the name of the field of the transaction hash
 	// which created the contract, if the account is a contract.
 	fiScCreationTx = "sc"
@@ -45,12 +48,24 @@ type AccountRow struct {
 	Sc       *string      `bson:"sc"`
 	Activity uint64       `bson:"ats"`
 	Counter  uint64       `bson:"atc"`
+	Ifs      []string     `bson:"ifs"`
 	ScHash   *common.Hash `bson:"-"`
 }
 
 // initAccountsCollection initializes the account collection with
 // indexes and additional parameters needed by the app.
-func (db *MongoDbBridge) initAccountsCollection() {
+func (db *MongoDbBridge) initAccountsCollection(col *mongo.Collection) {
+	// index funder and deployer links for the related addresses lookup
+	ix := []mongo.IndexModel{
+		{Keys: bson.D{{fiAccountFunder, 1}}},
+		{Keys: bson.D{{fiAccountDeployer, 1}}},
+	}
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for accounts collection; %s", err.Error())
+	}
+
 	db.log.Debugf("accounts collection initialized")
 }
 
@@ -61,7 +76,7 @@ func (db *MongoDbBridge) Account(addr *common.Address) (*types.Account, error) {
 	col := db.client.Database(db.dbName).Collection(coAccounts)
 
 	// try to find the account
-	sr := col.FindOne(context.Background(), bson.D{{fiAccountPk, addr.String()}}, options.FindOne())
+	sr := col.FindOne(db.opContext(), bson.D{{fiAccountPk, addr.String()}}, options.FindOne())
 
 	// error on lookup?
 	if sr.Err() != nil {
@@ -94,6 +109,7 @@ func (db *MongoDbBridge) Account(addr *common.Address) (*types.Account, error) {
 		Type:         row.Type,
 		LastActivity: hexutil.Uint64(row.Activity),
 		TrxCounter:   hexutil.Uint64(row.Counter),
+		Interfaces:   row.Ifs,
 	}, nil
 }
 
@@ -115,7 +131,7 @@ func (db *MongoDbBridge) AddAccount(acc *types.Account) error {
 	}
 
 	// do the update based on given PK; we don't need to pull the document updated
-	_, err := col.InsertOne(context.Background(), bson.D{
+	_, err := col.InsertOne(db.opContext(), bson.D{
 		{fiAccountPk, acc.Address.String()},
 		{fiScCreationTx, conTx},
 		{fiAccountType, acc.Type},
@@ -132,7 +148,7 @@ func (db *MongoDbBridge) AddAccount(acc *types.Account) error {
 	// check init state
 	// make sure transactions collection is initialized
 	if db.initAccounts != nil {
-		db.initAccounts.Do(func() { db.initAccountsCollection(); db.initAccounts = nil })
+		db.initAccounts.Do(func() { db.initAccountsCollection(col); db.initAccounts = nil })
 	}
 
 	// log what we have done
@@ -146,7 +162,7 @@ func (db *MongoDbBridge) IsAccountKnown(addr *common.Address) (bool, error) {
 	col := db.client.Database(db.dbName).Collection(coAccounts)
 
 	// try to find the account in the database (it may already exist)
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(db.opContext(), bson.D{
 		{fiAccountPk, addr.String()},
 	}, options.FindOne().SetProjection(bson.D{{fiAccountPk, true}}))
 
@@ -169,28 +185,6 @@ func (db *MongoDbBridge) AccountCount() (uint64, error) {
 	return db.EstimateCount(db.client.Database(db.dbName).Collection(coAccounts))
 }
 
-// AccountTransactions loads list of transaction hashes of an account.
-func (db *MongoDbBridge) AccountTransactions(addr *common.Address, cursor *string, count int32) (*types.TransactionList, error) {
-	// nothing to load?
-	if count == 0 {
-		return nil, fmt.Errorf("nothing to do, zero blocks requested")
-	}
-
-	// no account given?
-	if addr == nil {
-		return nil, fmt.Errorf("can not list transactions of empty account")
-	}
-
-	// log what we do here
-	db.log.Debugf("loading transactions of %s", addr.String())
-
-	// make the filter for [(from = Account) OR (to = Account)]
-	filter := bson.D{{"$or", bson.A{bson.D{{"from", addr.String()}}, bson.D{{"to", addr.String()}}}}}
-
-	// return list of transactions filtered by the account
-	return db.Transactions(cursor, count, &filter)
-}
-
 // AccountMarkActivity marks the latest account activity in the repository.
 func (db *MongoDbBridge) AccountMarkActivity(addr *common.Address, ts uint64) error {
 	// log what we do
@@ -200,7 +194,7 @@ func (db *MongoDbBridge) AccountMarkActivity(addr *common.Address, ts uint64) er
 	col := db.client.Database(db.dbName).Collection(coAccounts)
 
 	// update the contract details
-	if _, err := col.UpdateOne(context.Background(),
+	if _, err := col.UpdateOne(db.opContext(),
 		bson.D{{fiAccountPk, addr.String()}},
 		bson.D{
 			{"$set", bson.D{{fiAccountLastActivity, ts}}},
@@ -235,7 +229,7 @@ func (db *MongoDbBridge) Erc20TokensList(count int32) ([]common.Address, error)
 	}).SetLimit(int64(count))
 
 	// load the data
-	cursor, err := col.Find(context.Background(), filter, opt)
+	cursor, err := col.Find(db.opContext(), filter, opt)
 	if err != nil {
 		db.log.Errorf("error loading ERC20 tokens list; %s", err.Error())
 		return nil, err
@@ -257,7 +251,7 @@ func (db *MongoDbBridge) loadErc20TokensList(cursor *mongo.Cursor) ([]common.Add
 	// loop and load
 	list := make([]common.Address, 0)
 	var row AccountRow
-	for cursor.Next(context.Background()) {
+	for cursor.Next(db.opContext()) {
 		// try to decode the next row
 		if err := cursor.Decode(&row); err != nil {
 			db.log.Errorf("can not decodeERC20 list row; %s", err.Error())
@@ -270,3 +264,63 @@ func (db *MongoDbBridge) loadErc20TokensList(cursor *mongo.Cursor) ([]common.Add
 
 	return list, nil
 }
+
+// AccountsToClassify loads a batch of contract accounts
+// which have not been classified yet.
+func (db *MongoDbBridge) AccountsToClassify(limit int64) ([]common.Address, error) {
+	// get the collection for contracts
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	// contracts without interfaces field
+	cursor, err := col.Find(db.opContext(), bson.D{
+		{fiScCreationTx, bson.D{{"$type", "string"}}},
+		{fiAccountInterfaces, bson.D{{"$exists", false}}},
+	}, options.Find().SetProjection(bson.D{{fiAccountPk, true}}).SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not load accounts to classify; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			db.log.Errorf("error closing accounts cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]common.Address, 0)
+	for cursor.Next(db.opContext()) {
+		var row struct {
+			Address string `bson:"_id"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode account row; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, common.HexToAddress(row.Address))
+	}
+	return list, nil
+}
+
+// UpdateAccountClass updates the contract type and the list of interfaces
+// detected on the given account.
+func (db *MongoDbBridge) UpdateAccountClass(addr *common.Address, cType string, ifs []string) error {
+	// get the collection for contracts
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	// make sure to store an empty list rather than null
+	if ifs == nil {
+		ifs = make([]string, 0)
+	}
+
+	if _, err := col.UpdateOne(db.opContext(),
+		bson.D{{fiAccountPk, addr.String()}},
+		bson.D{{"$set", bson.D{
+			{fiAccountType, cType},
+			{fiAccountInterfaces, ifs},
+		}}}); err != nil {
+		db.log.Errorf("can not update account %s class; %s", addr.String(), err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// fiAccountFunder is the name of the field of the address which sent
+	// the first native tokens to the account; empty if not known.
+	// db.account.createIndex({fnd:1})
+	fiAccountFunder = "fnd"
+
+	// fiAccountDeployer is the name of the field of the address which deployed the contract account.
+	// db.account.createIndex({dpl:1})
+	fiAccountDeployer = "dpl"
+)
+
+// AccountsToLink loads a batch of accounts which have not been linked
+// to their funder and deployer yet.
+func (db *MongoDbBridge) AccountsToLink(limit int64) ([]common.Address, error) {
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	cursor, err := col.Find(db.opContext(), bson.D{
+		{fiAccountFunder, bson.D{{"$exists", false}}},
+	}, options.Find().SetProjection(bson.D{{fiAccountPk, true}}).SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not load accounts to link; %s", err.Error())
+		return nil, err
+	}
+	return db.loadAccountAddresses(cursor)
+}
+
+// FirstFunder finds the sender of the first transaction transferring
+// native tokens to the given address; nil if there is none.
+func (db *MongoDbBridge) FirstFunder(addr *common.Address) (*common.Address, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	sr := col.FindOne(db.opContext(), bson.D{
+		{fiTransactionRecipient, addr.String()},
+		{fiTransactionAmount, bson.D{{"$gt", 0}}},
+	}, options.FindOne().SetSort(bson.D{{fiTransactionOrdinalIndex, 1}}).SetProjection(bson.D{{fiTransactionSender, true}}))
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("can not find funder of %s; %s", addr.String(), sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	var row struct {
+		From string `bson:"from"`
+	}
+	if err := sr.Decode(&row); err != nil {
+		db.log.Errorf("can not decode funding transaction of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	funder := common.HexToAddress(row.From)
+	return &funder, nil
+}
+
+// UpdateAccountLinks stores the funder and the deployer of the given account.
+// Accounts without a known funder are marked so they are not linked again.
+func (db *MongoDbBridge) UpdateAccountLinks(addr *common.Address, funder *common.Address, deployer *common.Address) error {
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	set := bson.D{{fiAccountFunder, ""}}
+	if funder != nil {
+		set[0].Value = funder.String()
+	}
+	if deployer != nil {
+		set = append(set, bson.E{Key: fiAccountDeployer, Value: deployer.String()})
+	}
+
+	if _, err := col.UpdateOne(db.opContext(), bson.D{{fiAccountPk, addr.String()}}, bson.D{{"$set", set}}); err != nil {
+		db.log.Errorf("can not update account %s links; %s", addr.String(), err.Error())
+		return err
+	}
+	return nil
+}
+
+// AccountLinks loads the funder and the deployer of the given account, if known.
+func (db *MongoDbBridge) AccountLinks(addr *common.Address) (funder *common.Address, deployer *common.Address, err error) {
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	sr := col.FindOne(db.opContext(), bson.D{{fiAccountPk, addr.String()}},
+		options.FindOne().SetProjection(bson.D{{fiAccountFunder, true}, {fiAccountDeployer, true}}))
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil, nil
+		}
+		db.log.Errorf("can not load account %s links; %s", addr.String(), sr.Err().Error())
+		return nil, nil, sr.Err()
+	}
+
+	var row struct {
+		Funder   string `bson:"fnd"`
+		Deployer string `bson:"dpl"`
+	}
+	if err := sr.Decode(&row); err != nil {
+		db.log.Errorf("can not decode account %s links; %s", addr.String(), err.Error())
+		return nil, nil, err
+	}
+
+	if row.Funder != "" {
+		fa := common.HexToAddress(row.Funder)
+		funder = &fa
+	}
+	if row.Deployer != "" {
+		da := common.HexToAddress(row.Deployer)
+		deployer = &da
+	}
+	return funder, deployer, nil
+}
+
+// AccountsFundedBy loads accounts which received their first native tokens from the given address.
+func (db *MongoDbBridge) AccountsFundedBy(funder *common.Address, limit int64) ([]common.Address, error) {
+	return db.accountsLinkedBy(fiAccountFunder, funder, limit)
+}
+
+// AccountsDeployedBy loads contract accounts deployed by the given address.
+func (db *MongoDbBridge) AccountsDeployedBy(deployer *common.Address, limit int64) ([]common.Address, error) {
+	return db.accountsLinkedBy(fiAccountDeployer, deployer, limit)
+}
+
+// accountsLinkedBy loads accounts linked to the given address by the given field.
+func (db *MongoDbBridge) accountsLinkedBy(field string, addr *common.Address, limit int64) ([]common.Address, error) {
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	cursor, err := col.Find(db.opContext(), bson.D{{field, addr.String()}},
+		options.Find().SetProjection(bson.D{{fiAccountPk, true}}).SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not load accounts linked to %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	return db.loadAccountAddresses(cursor)
+}
+
+// loadAccountAddresses loads addresses of the accounts from the given cursor.
+func (db *MongoDbBridge) loadAccountAddresses(cursor *mongo.Cursor) ([]common.Address, error) {
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			db.log.Errorf("error closing accounts cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]common.Address, 0)
+	for cursor.Next(db.opContext()) {
+		var row struct {
+			Address string `bson:"_id"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode account row; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, common.HexToAddress(row.Address))
+	}
+	return list, nil
+}
@@ -0,0 +1,390 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// coAccountTransactions is the name of the off-chain database collection
+	// linking accounts with the transactions they participate in.
+	coAccountTransactions = "account_trx"
+
+	// fiAccountTrxPk is the name of the primary key field of the account transaction link.
+	fiAccountTrxPk = "_id"
+
+	// fiAccountTrxAddress is the name of the field of the linked account address.
+	// db.account_trx.createIndex({adr:1,orx:-1})
+	fiAccountTrxAddress = "adr"
+
+	// fiAccountTrxDirection is the name of the field of the direction of the transaction.
+	fiAccountTrxDirection = "dir"
+
+	// fiAccountTrxTransaction is the name of the field of the linked transaction hash.
+	fiAccountTrxTransaction = "trx"
+
+	// fiAccountTrxOrdinalIndex is the name of the field of the linked transaction ordinal index.
+	fiAccountTrxOrdinalIndex = "orx"
+
+	// fiAccountTrxTimeStamp is the name of the field of the linked transaction time stamp.
+	fiAccountTrxTimeStamp = "stamp"
+)
+
+const (
+	// accountTrxSent marks transactions sent by the account.
+	accountTrxSent = 1
+
+	// accountTrxReceived marks transactions received by the account.
+	accountTrxReceived = 2
+)
+
+// accountTrxBackfillTimeout represents the max duration of the backfill
+// of account transaction links from the existing transactions.
+const accountTrxBackfillTimeout = 12 * time.Hour
+
+// accountTrxLink represents a link between an account and a transaction.
+type accountTrxLink struct {
+	Pk        string    `bson:"_id"`
+	Address   string    `bson:"adr"`
+	Direction int32     `bson:"dir"`
+	Trx       string    `bson:"trx"`
+	Ordinal   uint64    `bson:"orx"`
+	Stamp     time.Time `bson:"stamp"`
+}
+
+// accountTrxPk provides the primary key of the link between the account and the transaction.
+func accountTrxPk(addr string, ordinal uint64) string {
+	return fmt.Sprintf("%s:%d", addr, ordinal)
+}
+
+// initAccountTransactionsCollection initializes the account transactions collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initAccountTransactionsCollection(col *mongo.Collection) {
+	// prepare index models; the history of an account is listed from new to old
+	ix := []mongo.IndexModel{{Keys: bson.D{{fiAccountTrxAddress, 1}, {fiAccountTrxOrdinalIndex, -1}}}}
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for account transactions collection; %s", err.Error())
+	}
+
+	// log we done that
+	db.log.Debugf("account transactions collection initialized")
+}
+
+// AccountTransactionsCount returns the number of account transaction links stored in the database.
+func (db *MongoDbBridge) AccountTransactionsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(coAccountTransactions))
+}
+
+// addAccountTransactions links the sender and the recipient of the given transaction
+// with the transaction so the account history can be listed using a single index.
+func (db *MongoDbBridge) addAccountTransactions(trx *types.Transaction) error {
+	// get the collection for account transactions
+	col := db.client.Database(db.dbName).Collection(coAccountTransactions)
+
+	// sender is always there; self transfer is linked only once
+	links := make([]accountTrxLink, 0, 2)
+	dir := int32(accountTrxSent)
+	if trx.To != nil && *trx.To == trx.From {
+		dir |= accountTrxReceived
+	}
+	links = append(links, db.accountTrxLink(trx, trx.From.String(), dir))
+
+	// recipient is missing on contract creation
+	if trx.To != nil && *trx.To != trx.From {
+		links = append(links, db.accountTrxLink(trx, trx.To.String(), accountTrxReceived))
+	}
+
+	// the links are upserted, so the transaction can be re-processed safely
+	for _, link := range links {
+		if _, err := col.ReplaceOne(db.opContext(),
+			bson.D{{fiAccountTrxPk, link.Pk}},
+			link, options.Replace().SetUpsert(true)); err != nil {
+			db.log.Errorf("can not link transaction %s to %s; %s", link.Trx, link.Address, err.Error())
+			return err
+		}
+	}
+
+	// make sure account transactions collection is initialized
+	if db.initAccountTrx != nil {
+		db.initAccountTrx.Do(func() { db.initAccountTransactionsCollection(col); db.initAccountTrx = nil })
+	}
+	return nil
+}
+
+// accountTrxLink creates a link between the given account and the transaction.
+func (db *MongoDbBridge) accountTrxLink(trx *types.Transaction, addr string, dir int32) accountTrxLink {
+	return accountTrxLink{
+		Pk:        accountTrxPk(addr, trx.Uid()),
+		Address:   addr,
+		Direction: dir,
+		Trx:       trx.Hash.String(),
+		Ordinal:   trx.Uid(),
+		Stamp:     trx.TimeStamp,
+	}
+}
+
+// checkAccountTransactionsState checks if the account transaction links are complete
+// and starts the backfill of the links of already known transactions if needed.
+func (db *MongoDbBridge) checkAccountTransactionsState() {
+	state, err := db.configValue(keyConfigAccountTrxReady)
+	if err != nil {
+		db.log.Errorf("can not check account transactions state; %s", err.Error())
+		return
+	}
+
+	// the links are complete already
+	if state != "" {
+		atomic.StoreInt32(db.accountTrxReady, 1)
+		return
+	}
+
+	// no transactions known yet; the links will be built by the indexer
+	if db.initTransactions != nil {
+		db.markAccountTransactionsReady()
+		return
+	}
+	go db.backfillAccountTransactions()
+}
+
+// markAccountTransactionsReady marks the account transaction links complete
+// so the account history is served from them.
+func (db *MongoDbBridge) markAccountTransactionsReady() {
+	if err := db.setConfigValue(keyConfigAccountTrxReady, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		db.log.Errorf("can not store account transactions state; %s", err.Error())
+		return
+	}
+	atomic.StoreInt32(db.accountTrxReady, 1)
+}
+
+// backfillAccountTransactions builds the account transaction links of transactions
+// already stored in the database. The account history is served from the transactions
+// collection directly until the backfill is done.
+func (db *MongoDbBridge) backfillAccountTransactions() {
+	col := db.maint.Database(db.dbName).Collection(coTransactions)
+	db.log.Noticef("building account transaction links")
+
+	// the collection will be created by the pipeline, make sure it's initialized first
+	if db.initAccountTrx != nil {
+		link := db.client.Database(db.dbName).Collection(coAccountTransactions)
+		db.initAccountTrx.Do(func() { db.initAccountTransactionsCollection(link); db.initAccountTrx = nil })
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), accountTrxBackfillTimeout)
+	defer cancel()
+
+	// the pipelines merge the links in; links already created by the indexer are kept
+	merge := bson.D{{"$merge", bson.D{
+		{"into", coAccountTransactions},
+		{"whenMatched", "keepExisting"},
+		{"whenNotMatched", "insert"},
+	}}}
+	for _, pipe := range []bson.A{
+		{accountTrxBackfillProjection(fiTransactionSender, bson.D{{"$cond", bson.A{
+			bson.D{{"$eq", bson.A{"$" + fiTransactionSender, "$" + fiTransactionRecipient}}},
+			accountTrxSent | accountTrxReceived,
+			accountTrxSent,
+		}}}), merge},
+		{bson.D{{"$match", bson.D{
+			{fiTransactionRecipient, bson.D{{"$ne", nil}}},
+			{"$expr", bson.D{{"$ne", bson.A{"$" + fiTransactionSender, "$" + fiTransactionRecipient}}}},
+		}}}, accountTrxBackfillProjection(fiTransactionRecipient, accountTrxReceived), merge},
+	} {
+		cur, err := col.Aggregate(ctx, pipe, options.Aggregate().SetAllowDiskUse(true))
+		if err != nil {
+			db.log.Errorf("can not build account transaction links; %s", err.Error())
+			return
+		}
+		if err := cur.Close(ctx); err != nil {
+			db.log.Errorf("can not close aggregation cursor; %s", err.Error())
+		}
+	}
+
+	db.markAccountTransactionsReady()
+	db.log.Noticef("account transaction links built")
+}
+
+// accountTrxBackfillProjection provides pipeline stage projecting a transaction
+// into the account transaction link of the account in the given field.
+func accountTrxBackfillProjection(field string, dir interface{}) bson.D {
+	return bson.D{{"$project", bson.D{
+		{fiAccountTrxPk, bson.D{{"$concat", bson.A{"$" + field, ":", bson.D{{"$toString", "$" + fiTransactionOrdinalIndex}}}}}},
+		{fiAccountTrxAddress, "$" + field},
+		{fiAccountTrxDirection, dir},
+		{fiAccountTrxTransaction, "$" + fiTransactionPk},
+		{fiAccountTrxOrdinalIndex, "$" + fiTransactionOrdinalIndex},
+		{fiAccountTrxTimeStamp, "$" + fiTransactionTimeStamp},
+	}}}
+}
+
+// AccountTransactions loads list of transactions of an account.
+func (db *MongoDbBridge) AccountTransactions(addr *common.Address, cursor *string, count int32) (*types.TransactionList, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero blocks requested")
+	}
+
+	// no account given?
+	if addr == nil {
+		return nil, fmt.Errorf("can not list transactions of empty account")
+	}
+
+	// log what we do here
+	db.log.Debugf("loading transactions of %s", addr.String())
+
+	// links are not ready yet; make the filter for [(from = Account) OR (to = Account)]
+	if atomic.LoadInt32(db.accountTrxReady) == 0 {
+		filter := bson.D{{"$or", bson.A{bson.D{{fiTransactionSender, addr.String()}}, bson.D{{fiTransactionRecipient, addr.String()}}}}}
+		return db.Transactions(cursor, count, &filter)
+	}
+
+	// the links are not keyed by the transaction hash, legacy cursors must be translated
+	cursor, err := db.accountTrxCursor(cursor)
+	if err != nil {
+		db.log.Errorf("invalid account transactions cursor; %s", err.Error())
+		return nil, err
+	}
+
+	// init the list over the account links
+	col := db.client.Database(db.dbName).Collection(coAccountTransactions)
+	filter := bson.D{{fiAccountTrxAddress, addr.String()}}
+	list, err := db.initTrxList(col, cursor, count, &filter)
+	if err != nil {
+		db.log.Errorf("can not build account transactions list; %s", err.Error())
+		return nil, err
+	}
+
+	// load data if there are any
+	if list.Total > 0 {
+		if err := db.accountTrxListLoad(col, cursor, count, list); err != nil {
+			db.log.Errorf("can not load account transactions list from database; %s", err.Error())
+			return nil, err
+		}
+
+		// reverse on negative so new-er transaction will be on top
+		if count < 0 {
+			list.Reverse()
+		}
+	}
+	return list, nil
+}
+
+// accountTrxCursor translates legacy transaction hash cursor into the opaque list cursor.
+func (db *MongoDbBridge) accountTrxCursor(cursor *string) (*string, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+	if _, ok := types.DecodeListCursor(*cursor); ok {
+		return cursor, nil
+	}
+
+	// find the ordinal index of the referenced transaction
+	ix, err := db.findBorderOrdinalIndex(db.client.Database(db.dbName).Collection(coTransactions),
+		bson.D{{fiTransactionPk, *cursor}},
+		options.FindOne())
+	if err != nil {
+		return nil, err
+	}
+
+	oc := types.ListCursor(ix)
+	return &oc, nil
+}
+
+// accountTrxListLoad loads the initialized list of account transactions from database.
+func (db *MongoDbBridge) accountTrxListLoad(col *mongo.Collection, cursor *string, count int32, list *types.TransactionList) error {
+	// get the context for loader
+	ctx := db.opContext()
+
+	// load the links
+	ld, err := col.Find(ctx, db.txListFilter(cursor, count, list), db.txListOptions(count).SetProjection(bson.D{{fiAccountTrxTransaction, true}}))
+	if err != nil {
+		db.log.Errorf("error loading account transactions list; %s", err.Error())
+		return err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing account transactions list cursor; %s", err.Error())
+		}
+	}()
+
+	// collect the linked transactions
+	hashes := make([]string, 0, list.Total)
+	for ld.Next(ctx) {
+		var row accountTrxLink
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode the list row; %s", err.Error())
+			return err
+		}
+		hashes = append(hashes, row.Trx)
+	}
+
+	// we loaded one extra link to detect the list boundary
+	loaded := int32(len(hashes))
+	list.IsEnd = (cursor == nil && count < 0) || (count > 0 && loaded <= count)
+	list.IsStart = (cursor == nil && count > 0) || (count < 0 && loaded <= -count)
+	if !list.IsStart && !list.IsEnd && loaded > 0 {
+		hashes = hashes[:loaded-1]
+	}
+
+	// load the transactions themselves
+	txs, err := db.transactionsByHash(hashes)
+	if err != nil {
+		return err
+	}
+	list.Collection = append(list.Collection, txs...)
+	return nil
+}
+
+// transactionsByHash loads transactions of the given hashes keeping their order.
+func (db *MongoDbBridge) transactionsByHash(hashes []string) ([]*types.Transaction, error) {
+	if len(hashes) == 0 {
+		return []*types.Transaction{}, nil
+	}
+
+	// get the context for loader
+	ctx := db.opContext()
+	ld, err := db.client.Database(db.dbName).Collection(coTransactions).Find(ctx, bson.D{{fiTransactionPk, bson.D{{"$in", hashes}}}})
+	if err != nil {
+		db.log.Errorf("error loading transactions; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing transactions cursor; %s", err.Error())
+		}
+	}()
+
+	// decode the transactions
+	found := make(map[string]*types.Transaction, len(hashes))
+	for ld.Next(ctx) {
+		var row types.Transaction
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode transaction; %s", err.Error())
+			return nil, err
+		}
+		found[row.Hash.String()] = &row
+	}
+
+	// keep the order of the links
+	txs := make([]*types.Transaction, 0, len(hashes))
+	for _, h := range hashes {
+		if trx, ok := found[h]; ok {
+			txs = append(txs, trx)
+		}
+	}
+	return txs, ld.Err()
+}
@@ -0,0 +1,210 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// colAlertRules represents the name of the alert rules collection in database.
+	colAlertRules = "alert_rules"
+
+	// colAlerts represents the name of the raised alerts collection in database.
+	colAlerts = "alerts"
+)
+
+// initAlertRulesCollection initializes the alert rules collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initAlertRulesCollection(col *mongo.Collection) {
+	if _, err := col.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{types.FiAlertRuleOwner, 1}}}); err != nil {
+		db.log.Panicf("can not create indexes for alert rules collection; %s", err.Error())
+	}
+	db.log.Debugf("alert rules collection initialized")
+}
+
+// initAlertsCollection initializes the alerts collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initAlertsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiAlertOwner, 1}, {types.FiAlertTime, 1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiAlertDelivered, 1}, {types.FiAlertTime, 1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for alerts collection; %s", err.Error())
+	}
+	db.log.Debugf("alerts collection initialized")
+}
+
+// AddAlertRule stores the alert rule in the database.
+func (db *MongoDbBridge) AddAlertRule(ar *types.AlertRule) error {
+	col := db.client.Database(db.dbName).Collection(colAlertRules)
+
+	if _, err := col.InsertOne(db.opContext(), ar); err != nil {
+		db.log.Errorf("can not add alert rule; %s", err.Error())
+		return err
+	}
+
+	// make sure alert rules collection is initialized
+	if db.initAlertRules != nil {
+		db.initAlertRules.Do(func() { db.initAlertRulesCollection(col); db.initAlertRules = nil })
+	}
+	return nil
+}
+
+// RemoveAlertRule removes the alert rule of the given owner from the database.
+func (db *MongoDbBridge) RemoveAlertRule(owner string, id string) (bool, error) {
+	col := db.client.Database(db.dbName).Collection(colAlertRules)
+
+	res, err := col.DeleteOne(db.opContext(), bson.D{{types.FiAlertRulePk, id}, {types.FiAlertRuleOwner, owner}})
+	if err != nil {
+		db.log.Errorf("can not remove alert rule %s; %s", id, err.Error())
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// SetAlertRuleState updates the last evaluated state of the alert rule.
+func (db *MongoDbBridge) SetAlertRuleState(id string, state string, block uint64) error {
+	col := db.client.Database(db.dbName).Collection(colAlertRules)
+
+	if _, err := col.UpdateOne(db.opContext(), bson.D{{types.FiAlertRulePk, id}}, bson.D{{"$set", bson.D{
+		{types.FiAlertRuleState, state},
+		{types.FiAlertRuleBlock, block},
+	}}}); err != nil {
+		db.log.Errorf("can not update alert rule %s; %s", id, err.Error())
+		return err
+	}
+	return nil
+}
+
+// AlertRules loads the alert rules of the given owner; all the rules if the owner is empty.
+func (db *MongoDbBridge) AlertRules(owner string) ([]*types.AlertRule, error) {
+	col := db.client.Database(db.dbName).Collection(colAlertRules)
+
+	filter := bson.D{}
+	if owner != "" {
+		filter = bson.D{{types.FiAlertRuleOwner, owner}}
+	}
+
+	ld, err := col.Find(db.opContext(), filter)
+	if err != nil {
+		db.log.Errorf("can not load alert rules; %s", err.Error())
+		return nil, err
+	}
+	defer func() {
+		if err := ld.Close(context.Background()); err != nil {
+			db.log.Errorf("error closing alert rules cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.AlertRule, 0)
+	for ld.Next(db.opContext()) {
+		var row types.AlertRule
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode alert rule; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// AlertRulesSize calculates the number of alert rules of the given owner.
+func (db *MongoDbBridge) AlertRulesSize(owner string) (int64, error) {
+	return db.client.Database(db.dbName).Collection(colAlertRules).CountDocuments(db.opContext(), bson.D{{types.FiAlertRuleOwner, owner}})
+}
+
+// AlertRulesCount calculates total number of alert rules in the database.
+func (db *MongoDbBridge) AlertRulesCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colAlertRules))
+}
+
+// AddAlert stores the raised alert in the database.
+// Returns FALSE if the same alert has already been raised.
+func (db *MongoDbBridge) AddAlert(al *types.Alert) (bool, error) {
+	col := db.client.Database(db.dbName).Collection(colAlerts)
+
+	if _, err := col.InsertOne(db.opContext(), al); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		db.log.Errorf("can not add alert; %s", err.Error())
+		return false, err
+	}
+
+	// make sure alerts collection is initialized
+	if db.initAlerts != nil {
+		db.initAlerts.Do(func() { db.initAlertsCollection(col); db.initAlerts = nil })
+	}
+	return true, nil
+}
+
+// SetAlertDelivered marks the alert as delivered to the webhook of its rule.
+func (db *MongoDbBridge) SetAlertDelivered(al *types.Alert) error {
+	col := db.client.Database(db.dbName).Collection(colAlerts)
+
+	if _, err := col.UpdateOne(db.opContext(), bson.D{{types.FiAlertPk, al.Pk()}}, bson.D{{"$set", bson.D{
+		{types.FiAlertDelivered, true},
+	}}}); err != nil {
+		db.log.Errorf("can not update alert %s; %s", al.Pk(), err.Error())
+		return err
+	}
+	return nil
+}
+
+// Alerts loads alerts of the given owner raised since the given time, oldest first.
+func (db *MongoDbBridge) Alerts(owner string, since time.Time, limit int64) ([]*types.Alert, error) {
+	return db.loadAlerts(bson.D{
+		{types.FiAlertOwner, owner},
+		{types.FiAlertTime, bson.D{{"$gte", since}}},
+	}, limit)
+}
+
+// UndeliveredAlerts loads alerts with a webhook raised since the given time
+// and not delivered yet, oldest first.
+func (db *MongoDbBridge) UndeliveredAlerts(since time.Time, limit int64) ([]*types.Alert, error) {
+	return db.loadAlerts(bson.D{
+		{types.FiAlertDelivered, false},
+		{types.FiAlertTime, bson.D{{"$gte", since}}},
+		{types.FiAlertWebhook, bson.D{{"$nin", bson.A{nil, ""}}}},
+	}, limit)
+}
+
+// loadAlerts loads alerts matching the given filter, oldest first.
+func (db *MongoDbBridge) loadAlerts(filter bson.D, limit int64) ([]*types.Alert, error) {
+	col := db.client.Database(db.dbName).Collection(colAlerts)
+
+	ld, err := col.Find(db.opContext(), filter, options.Find().SetSort(bson.D{{types.FiAlertTime, 1}}).SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not load alerts; %s", err.Error())
+		return nil, err
+	}
+	defer func() {
+		if err := ld.Close(context.Background()); err != nil {
+			db.log.Errorf("error closing alerts cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.Alert, 0)
+	for ld.Next(db.opContext()) {
+		var row types.Alert
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode alert; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// AlertsCount calculates total number of alerts in the database.
+func (db *MongoDbBridge) AlertsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colAlerts))
+}
@@ -0,0 +1,57 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"fantom-api-graphql/internal/config"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// analyticsRoute decides which collections serve heavy analytical queries
+// with a read preference different from the latency-sensitive lookups.
+type analyticsRoute struct {
+	pref        *readpref.ReadPref
+	collections map[string]bool
+}
+
+// newAnalyticsRoute creates the routing of analytical queries from the database config;
+// nil is returned if the routing is not configured.
+func newAnalyticsRoute(cfg *config.Database) (*analyticsRoute, error) {
+	if cfg.AnalyticsReadPreference == "" || len(cfg.AnalyticsCollections) == 0 {
+		return nil, nil
+	}
+
+	rp, err := readPreference(cfg.AnalyticsReadPreference)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := analyticsRoute{pref: rp, collections: make(map[string]bool, len(cfg.AnalyticsCollections))}
+	for _, name := range cfg.AnalyticsCollections {
+		ar.collections[name] = true
+	}
+	return &ar, nil
+}
+
+// readPreference parses the read preference mode, i.e. primary, primaryPreferred,
+// secondary, secondaryPreferred, or nearest.
+func readPreference(mode string) (*readpref.ReadPref, error) {
+	m, err := readpref.ModeFromString(mode)
+	if err != nil {
+		return nil, err
+	}
+	return readpref.New(m)
+}
+
+// analyticsCollection provides the collection of the given name for heavy analytical queries.
+// Reads of collections configured for analytics follow the analytics read preference,
+// so they can run against secondary replicas; other collections stay on the default.
+// Use it for reads only; aggregations writing with $merge, or $out must stay on the primary.
+func (db *MongoDbBridge) analyticsCollection(name string) *mongo.Collection {
+	if db.analytics != nil && db.analytics.collections[name] {
+		return db.client.Database(db.dbName).Collection(name, options.Collection().SetReadPreference(db.analytics.pref))
+	}
+	return db.client.Database(db.dbName).Collection(name)
+}
@@ -0,0 +1,125 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colBallotVotes represents the name of the ballot votes collection.
+const colBallotVotes = "ballot_votes"
+
+// initBallotVotesCollection initializes the ballot votes collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initBallotVotesCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiBallotVoteBallot, 1}, {types.FiBallotVoteProposal, 1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiBallotVoteVoter, 1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for ballot votes collection; %s", err.Error())
+	}
+
+	// log we done that
+	db.log.Debugf("ballot votes collection initialized")
+}
+
+// AddBallotVote stores the given ballot vote in the database.
+// A previous vote of the same voter on the same ballot is replaced.
+func (db *MongoDbBridge) AddBallotVote(bv *types.BallotVote) error {
+	// get the collection for ballot votes
+	col := db.client.Database(db.dbName).Collection(colBallotVotes)
+
+	// replace the vote, or insert a new one
+	if _, err := col.ReplaceOne(db.opContext(),
+		bson.D{{types.FiBallotVotePk, bv.Pk()}},
+		bv, options.Replace().SetUpsert(true)); err != nil {
+		db.log.Criticalf("can not store vote of %s on ballot %s; %s", bv.Voter.String(), bv.Ballot.String(), err.Error())
+		return err
+	}
+
+	// make sure ballot votes collection is initialized
+	if db.initBallotVotes != nil {
+		db.initBallotVotes.Do(func() { db.initBallotVotesCollection(col); db.initBallotVotes = nil })
+	}
+	return nil
+}
+
+// BallotVote loads the vote of the given voter on the given ballot.
+// It returns nil if the voter did not vote on the ballot.
+func (db *MongoDbBridge) BallotVote(ballot *common.Address, voter *common.Address) (*types.BallotVote, error) {
+	// get the collection for ballot votes
+	col := db.client.Database(db.dbName).Collection(colBallotVotes)
+
+	// try to find the vote
+	bv := types.BallotVote{Ballot: *ballot, Voter: *voter}
+	sr := col.FindOne(db.opContext(), bson.D{{types.FiBallotVotePk, bv.Pk()}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load vote of %s on ballot %s; %s", voter.String(), ballot.String(), sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	// try to decode
+	if err := sr.Decode(&bv); err != nil {
+		db.log.Errorf("can not decode vote of %s on ballot %s; %s", voter.String(), ballot.String(), err.Error())
+		return nil, err
+	}
+	return &bv, nil
+}
+
+// BallotVotersCount calculates the number of voters of each proposal of the given ballot.
+// The resulting map is indexed by the proposal index.
+func (db *MongoDbBridge) BallotVotersCount(ballot *common.Address) (map[uint64]uint64, error) {
+	// get the collection and context
+	col := db.client.Database(db.dbName).Collection(colBallotVotes)
+	ctx := db.opContext()
+
+	// aggregate votes by the proposal
+	cr, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{"$match", bson.D{{types.FiBallotVoteBallot, ballot.String()}}}},
+		{{"$group", bson.D{
+			{"_id", "$" + types.FiBallotVoteProposal},
+			{"count", bson.D{{"$sum", 1}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not count voters of ballot %s; %s", ballot.String(), err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := cr.Close(ctx); err != nil {
+			db.log.Errorf("error closing ballot voters cursor; %s", err.Error())
+		}
+	}()
+
+	res := make(map[uint64]uint64)
+	for cr.Next(ctx) {
+		var row struct {
+			Proposal int64 `bson:"_id"`
+			Count    int64 `bson:"count"`
+		}
+		if err := cr.Decode(&row); err != nil {
+			db.log.Errorf("can not decode ballot voters row; %s", err.Error())
+			return nil, err
+		}
+		res[uint64(row.Proposal)] = uint64(row.Count)
+	}
+	return res, nil
+}
+
+// BallotVotesCount calculates total number of ballot votes in the database.
+func (db *MongoDbBridge) BallotVotesCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colBallotVotes))
+}
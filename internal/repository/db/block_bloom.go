@@ -0,0 +1,111 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// colBlockBloom represents the name of the block address bloom collection in database.
+	colBlockBloom = "block_bloom"
+
+	// fiBlockBloomPk is the name of the primary key field of the block bloom, the block number.
+	fiBlockBloomPk = "_id"
+
+	// keyConfigBlockBloomFrom is the primary key of the first block with the address bloom indexed.
+	keyConfigBlockBloomFrom = "bbf"
+)
+
+// blockBloomWord provides the name of the field of the block bloom word.
+func blockBloomWord(i int) string {
+	return "b" + strconv.Itoa(i)
+}
+
+// AddBlockBloom merges the given address bloom into the bloom of the block.
+// The bloom words are merged by the database so the update is safe
+// for concurrent transactions of the same block.
+func (db *MongoDbBridge) AddBlockBloom(block uint64, bl *types.AddressBloom) error {
+	// remember where the bloom index starts
+	db.blockBloomStart.Do(func() { db.markBlockBloomFrom(block) })
+
+	// merge non-empty words only
+	words := bson.D{}
+	for i, w := range bl {
+		if w != 0 {
+			words = append(words, bson.E{Key: blockBloomWord(i), Value: bson.D{{"or", int64(w)}}})
+		}
+	}
+	if len(words) == 0 {
+		return nil
+	}
+
+	col := db.client.Database(db.dbName).Collection(colBlockBloom)
+	if _, err := col.UpdateOne(db.opContext(),
+		bson.D{{fiBlockBloomPk, int64(block)}},
+		bson.D{{"$bit", words}},
+		options.Update().SetUpsert(true)); err != nil {
+		db.log.Errorf("can not update bloom of block #%d; %s", block, err.Error())
+		return err
+	}
+	return nil
+}
+
+// markBlockBloomFrom stores the first block with the address bloom indexed, if not known yet.
+func (db *MongoDbBridge) markBlockBloomFrom(block uint64) {
+	val, err := db.configValue(keyConfigBlockBloomFrom)
+	if err != nil {
+		db.log.Errorf("can not check block bloom start; %s", err.Error())
+		return
+	}
+	if val != "" {
+		return
+	}
+
+	if err := db.setConfigValue(keyConfigBlockBloomFrom, strconv.FormatUint(block, 10)); err != nil {
+		db.log.Errorf("can not store block bloom start; %s", err.Error())
+	}
+}
+
+// BlockBloomFrom provides the first block with the address bloom indexed.
+// The flag is FALSE if the bloom index was not started yet.
+func (db *MongoDbBridge) BlockBloomFrom() (uint64, bool, error) {
+	val, err := db.configValue(keyConfigBlockBloomFrom)
+	if err != nil || val == "" {
+		return 0, false, err
+	}
+
+	from, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid block bloom start %s; %s", val, err.Error())
+	}
+	return from, true, nil
+}
+
+// BlockBloomMatch checks if any block of the given inclusive range has the bloom
+// matching all the bits of the given address bloom.
+func (db *MongoDbBridge) BlockBloomMatch(bl *types.AddressBloom, from uint64, to uint64) (bool, error) {
+	filter := bson.D{{fiBlockBloomPk, bson.D{{"$gte", int64(from)}, {"$lte", int64(to)}}}}
+	for i, w := range bl {
+		if w != 0 {
+			filter = append(filter, bson.E{Key: blockBloomWord(i), Value: bson.D{{"$bitsAllSet", int64(w)}}})
+		}
+	}
+
+	col := db.client.Database(db.dbName).Collection(colBlockBloom)
+	err := col.FindOne(db.opContext(), filter, options.FindOne().SetProjection(bson.D{{fiBlockBloomPk, true}})).Err()
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return false, nil
+		}
+
+		db.log.Errorf("can not match block bloom; %s", err.Error())
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,114 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// colBlockFinality represents the name of the block finality collection.
+const colBlockFinality = "block_finality"
+
+// initBlockFinalityCollection initializes the block finality collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initBlockFinalityCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := []mongo.IndexModel{{Keys: bson.D{{types.FiBlockFinalityStamp, -1}}}}
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for block finality collection; %s", err.Error())
+	}
+
+	// log we done that
+	db.log.Debugf("block finality collection initialized")
+}
+
+// AddBlockFinality stores the given block finality record in the database.
+func (db *MongoDbBridge) AddBlockFinality(bf *types.BlockFinality) error {
+	// get the collection for block finality
+	col := db.client.Database(db.dbName).Collection(colBlockFinality)
+
+	// replace the record, or insert a new one
+	if _, err := col.ReplaceOne(db.opContext(),
+		bson.D{{types.FiBlockFinalityPk, bf.Block}},
+		bf, options.Replace().SetUpsert(true)); err != nil {
+		db.log.Errorf("can not store finality of block #%d; %s", bf.Block, err.Error())
+		return err
+	}
+
+	// make sure block finality collection is initialized
+	if db.initBlockFinality != nil {
+		db.initBlockFinality.Do(func() { db.initBlockFinalityCollection(col); db.initBlockFinality = nil })
+	}
+	return nil
+}
+
+// BlockFinality loads the finality record of the given block.
+// It returns nil if the finality of the block was not observed.
+func (db *MongoDbBridge) BlockFinality(block uint64) (*types.BlockFinality, error) {
+	// get the collection for block finality
+	col := db.client.Database(db.dbName).Collection(colBlockFinality)
+
+	// try to find the record
+	sr := col.FindOne(db.opContext(), bson.D{{types.FiBlockFinalityPk, block}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load finality of block #%d; %s", block, sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	// try to decode
+	var bf types.BlockFinality
+	if err := sr.Decode(&bf); err != nil {
+		db.log.Errorf("can not decode finality of block #%d; %s", block, err.Error())
+		return nil, err
+	}
+	return &bf, nil
+}
+
+// BlockFinalityList loads the finality records of blocks created since the given time,
+// ordered by the block number.
+func (db *MongoDbBridge) BlockFinalityList(since time.Time) ([]*types.BlockFinality, error) {
+	// get the collection for block finality
+	col := db.client.Database(db.dbName).Collection(colBlockFinality)
+
+	// load the records
+	cursor, err := col.Find(db.opContext(),
+		bson.D{{types.FiBlockFinalityStamp, bson.D{{"$gte", since}}}},
+		options.Find().SetSort(bson.D{{types.FiBlockFinalityPk, 1}}))
+	if err != nil {
+		db.log.Errorf("can not load block finality list; %s", err.Error())
+		return nil, err
+	}
+
+	// make sure to close the cursor
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.BlockFinality, 0)
+	for cursor.Next(db.opContext()) {
+		var bf types.BlockFinality
+		if err := cursor.Decode(&bf); err != nil {
+			db.log.Errorf("can not decode block finality; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &bf)
+	}
+	return list, nil
+}
+
+// BlockFinalityCount calculates total number of block finality records in the database.
+func (db *MongoDbBridge) BlockFinalityCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colBlockFinality))
+}
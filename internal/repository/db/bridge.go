@@ -5,14 +5,20 @@ import (
 	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository/db/migrations"
+	"fantom-api-graphql/internal/repository/latency"
+	"fantom-api-graphql/internal/repository/opstats"
 	"fmt"
 	"math/big"
+	"strconv"
 	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 // MongoDbBridge represents Mongo DB abstraction layer.
@@ -21,16 +27,57 @@ type MongoDbBridge struct {
 	log    logger.Logger
 	dbName string
 
+	// maint is the client of long running maintenance operations; it does not use
+	// the socket timeout, the operations are bound by their own context instead
+	maint *mongo.Client
+
+	// latency of recent database commands
+	lat *latency.Window
+
+	// connection pool statistics and the configured max size of the pool
+	pool    *poolStats
+	maxPool uint64
+
+	// routing of heavy analytical queries, nil if not configured
+	analytics *analyticsRoute
+
 	// init state marks
-	initAccounts     *sync.Once
-	initTransactions *sync.Once
-	initContracts    *sync.Once
-	initSwaps        *sync.Once
-	initDelegations  *sync.Once
-	initWithdrawals  *sync.Once
-	initRewards      *sync.Once
-	initErc20Trx     *sync.Once
-	initEpochs       *sync.Once
+	initAccounts           *sync.Once
+	initTransactions       *sync.Once
+	initContracts          *sync.Once
+	initSwaps              *sync.Once
+	initDelegations        *sync.Once
+	initWithdrawals        *sync.Once
+	initRewards            *sync.Once
+	initErc20Trx           *sync.Once
+	initEpochs             *sync.Once
+	initLabels             *sync.Once
+	initBallotVotes        *sync.Once
+	initBlockFinality      *sync.Once
+	initContractCreations  *sync.Once
+	initAccountTrx         *sync.Once
+	initDelegationOps      *sync.Once
+	initFtmSupply          *sync.Once
+	initWatchList          *sync.Once
+	initWatchDigest        *sync.Once
+	initPriceHistory       *sync.Once
+	initContractEvents     *sync.Once
+	initContractEventStats *sync.Once
+	initAlertRules         *sync.Once
+	initAlerts             *sync.Once
+	initFMintAccounts      *sync.Once
+	initUniswapPairs       *sync.Once
+	initValidatorApr       *sync.Once
+	initUsage              *sync.Once
+
+	// accountTrxReady signals the account transaction links are complete
+	accountTrxReady *int32
+
+	// blockBloomStart makes sure the start of the block bloom index is checked only once
+	blockBloomStart *sync.Once
+
+	// ctx is the context the database operations are bound to, nil if not bound
+	ctx context.Context
 }
 
 // docListCountAggregationTimeout represents a max duration of DB query executed to calculate
@@ -38,6 +85,12 @@ type MongoDbBridge struct {
 // ad we fall back to full collection documents count estimation.
 const docListCountAggregationTimeout = 500 * time.Millisecond
 
+// migrationsTimeout represents the max duration of the database migrations on start.
+const migrationsTimeout = 2 * time.Hour
+
+// maintenancePoolSize represents the max number of connections of the maintenance client.
+const maintenancePoolSize = 4
+
 // intZero represents an empty big value.
 var intZero = new(big.Int)
 
@@ -46,35 +99,114 @@ func New(cfg *config.Config, log logger.Logger) (*MongoDbBridge, error) {
 	// log what we do
 	log.Debugf("connecting database at %s/%s", cfg.Db.Url, cfg.Db.DbName)
 
+	// routing of analytical queries
+	ar, err := newAnalyticsRoute(&cfg.Db)
+	if err != nil {
+		log.Criticalf("invalid analytics read preference; %s", err.Error())
+		return nil, err
+	}
+
 	// open the database connection
-	con, err := connectDb(&cfg.Db)
+	lat := new(latency.Window)
+	pool := new(poolStats)
+	con, err := connectDb(&cfg.Db, lat, pool)
 	if err != nil {
 		log.Criticalf("can not contact the database; %s", err.Error())
 		return nil, err
 	}
 
+	// long running operations need a connection without the socket timeout
+	maint := con
+	if cfg.Db.SocketTimeout > 0 {
+		maint, err = connectMaintenance(&cfg.Db)
+		if err != nil {
+			log.Criticalf("can not contact the database; %s", err.Error())
+			_ = con.Disconnect(context.Background())
+			return nil, err
+		}
+	}
+
 	// log the event
 	log.Notice("database connection established")
 
 	// return the bridge
 	db := &MongoDbBridge{
-		client: con,
-		log:    log,
-		dbName: cfg.Db.DbName,
+		client:          con,
+		maint:           maint,
+		log:             log,
+		dbName:          cfg.Db.DbName,
+		lat:             lat,
+		pool:            pool,
+		maxPool:         cfg.Db.MaxPoolSize,
+		analytics:       ar,
+		accountTrxReady: new(int32),
+		blockBloomStart: new(sync.Once),
 	}
 
 	// check the state
 	db.CheckDatabaseInitState()
+
+	// bring existing data up to date with this version of the API server
+	ctx, cancel := context.WithTimeout(context.Background(), migrationsTimeout)
+	defer cancel()
+
+	if err := migrations.Run(ctx, maint.Database(db.dbName), log); err != nil {
+		db.Close()
+		return nil, err
+	}
 	return db, nil
 }
 
-// connectDb opens Mongo database connection
-func connectDb(cfg *config.Database) (*mongo.Client, error) {
+// connectMaintenance opens a small Mongo client of long running maintenance operations,
+// i.e. migrations, backfills and snapshots. It has the same configuration as the API client
+// except the socket timeout, which would cut off their legitimately long database commands.
+func connectMaintenance(cfg *config.Database) (*mongo.Client, error) {
+	mc := *cfg
+	mc.SocketTimeout = 0
+	mc.MinPoolSize = 0
+	mc.MaxPoolSize = maintenancePoolSize
+
+	opt := options.Client().ApplyURI(cfg.Url)
+	if err := poolOptions(&mc, opt); err != nil {
+		return nil, err
+	}
+
+	client, err := mongo.Connect(context.Background(), opt)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// connectDb opens Mongo database connection; the latency of database commands
+// is collected in the given window, the connection pool events in the pool stats.
+func connectDb(cfg *config.Database, lat *latency.Window, pool *poolStats) (*mongo.Client, error) {
 	// get empty unrestricted context
 	ctx := context.Background()
 
+	// observe the latency of finished commands, count the commands of API operations
+	mon := &event.CommandMonitor{
+		Started: func(ctx context.Context, _ *event.CommandStartedEvent) {
+			opstats.FromContext(ctx).Db()
+		},
+		Succeeded: func(_ context.Context, ev *event.CommandSucceededEvent) {
+			lat.Observe(time.Duration(ev.DurationNanos))
+		},
+		Failed: func(_ context.Context, ev *event.CommandFailedEvent) {
+			lat.Observe(time.Duration(ev.DurationNanos))
+		},
+	}
+
+	// prep the client options
+	opt := options.Client().ApplyURI(cfg.Url).SetMonitor(mon).SetPoolMonitor(pool.monitor())
+
+	// tune the connection pool and consistency
+	if err := poolOptions(cfg, opt); err != nil {
+		return nil, err
+	}
+
 	// create new Mongo client
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.Url))
+	client, err := mongo.Connect(ctx, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +220,71 @@ func connectDb(cfg *config.Database) (*mongo.Client, error) {
 	return client, nil
 }
 
+// poolOptions applies the connection pool and the consistency configuration
+// to the client options. Options not configured keep the driver defaults.
+func poolOptions(cfg *config.Database, opt *options.ClientOptions) error {
+	if cfg.ConnectTimeout > 0 {
+		opt.SetConnectTimeout(cfg.ConnectTimeout)
+	}
+	if cfg.SocketTimeout > 0 {
+		opt.SetSocketTimeout(cfg.SocketTimeout)
+	}
+	if cfg.MaxPoolSize > 0 {
+		opt.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	if cfg.MinPoolSize > 0 {
+		opt.SetMinPoolSize(cfg.MinPoolSize)
+	}
+	if cfg.MaxIdleTime > 0 {
+		opt.SetMaxConnIdleTime(cfg.MaxIdleTime)
+	}
+
+	// read preference
+	if cfg.ReadPreference != "" {
+		rp, err := readPreference(cfg.ReadPreference)
+		if err != nil {
+			return err
+		}
+		opt.SetReadPreference(rp)
+	}
+
+	// write concern; majority, or the number of nodes
+	switch cfg.WriteConcern {
+	case "":
+	case "majority":
+		opt.SetWriteConcern(writeconcern.New(writeconcern.WMajority()))
+	default:
+		w, err := strconv.Atoi(cfg.WriteConcern)
+		if err != nil || w < 0 {
+			return fmt.Errorf("invalid write concern %s", cfg.WriteConcern)
+		}
+		opt.SetWriteConcern(writeconcern.New(writeconcern.W(w)))
+	}
+	return nil
+}
+
+// Latency provides the 95th percentile of the latency of recent database commands.
+func (db *MongoDbBridge) Latency() time.Duration {
+	return db.lat.P95()
+}
+
+// WithContext returns a copy of the bridge with the database operations bound to the given context,
+// so operations of a cancelled API request are cancelled, too. The copy shares the connection
+// and the state of the bridge. Long running maintenance operations are not bound.
+func (db *MongoDbBridge) WithContext(ctx context.Context) *MongoDbBridge {
+	cp := *db
+	cp.ctx = ctx
+	return &cp
+}
+
+// opContext provides the context a database operation should run in.
+func (db *MongoDbBridge) opContext() context.Context {
+	if db.ctx == nil {
+		return context.Background()
+	}
+	return db.ctx
+}
+
 // Close will terminate or finish all operations and close the connection to Mongo database.
 func (db *MongoDbBridge) Close() {
 	// do we have a client?
@@ -101,6 +298,13 @@ func (db *MongoDbBridge) Close() {
 			db.log.Errorf("error on closing database connection; %s", err.Error())
 		}
 
+		// the maintenance client is separate only if the socket timeout is used
+		if db.maint != nil && db.maint != db.client {
+			if err := db.maint.Disconnect(ctx); err != nil {
+				db.log.Errorf("error on closing maintenance database connection; %s", err.Error())
+			}
+		}
+
 		// inform
 		db.log.Info("database connection is closed")
 		cancel()
@@ -110,7 +314,7 @@ func (db *MongoDbBridge) Close() {
 // getAggregateValue extract single aggregate value for a given collection and aggregation pipeline.
 func (db *MongoDbBridge) getAggregateValue(col *mongo.Collection, pipeline *bson.A) (uint64, error) {
 	// work with context
-	ctx := context.Background()
+	ctx := db.opContext()
 
 	// use aggregate pipeline to get the result set, should be just one row
 	res, err := col.Aggregate(ctx, *pipeline)
@@ -172,6 +376,25 @@ func (db *MongoDbBridge) CheckDatabaseInitState() {
 	db.collectionNeedInit("rewards", db.RewardsCount, &db.initRewards)
 	db.collectionNeedInit("erc20 transactions", db.ErcTransactionCount, &db.initErc20Trx)
 	db.collectionNeedInit("epochs", db.EpochsCount, &db.initEpochs)
+	db.collectionNeedInit("labels", db.AddressLabelsCount, &db.initLabels)
+	db.collectionNeedInit("ballot votes", db.BallotVotesCount, &db.initBallotVotes)
+	db.collectionNeedInit("block finality", db.BlockFinalityCount, &db.initBlockFinality)
+	db.collectionNeedInit("contract creations", db.ContractCreationsCount, &db.initContractCreations)
+	db.collectionNeedInit("account transactions", db.AccountTransactionsCount, &db.initAccountTrx)
+	db.collectionNeedInit("delegation operations", db.DelegationOperationsCount, &db.initDelegationOps)
+	db.collectionNeedInit("ftm supply", db.FtmSupplyCount, &db.initFtmSupply)
+	db.collectionNeedInit("watch list", db.WatchListCount, &db.initWatchList)
+	db.collectionNeedInit("watch digest", db.WatchDigestCount, &db.initWatchDigest)
+	db.collectionNeedInit("price history", db.PriceHistoryCount, &db.initPriceHistory)
+	db.collectionNeedInit("contract events", db.ContractEventsCount, &db.initContractEvents)
+	db.collectionNeedInit("contract event stats", db.ContractEventStatsCount, &db.initContractEventStats)
+	db.collectionNeedInit("alert rules", db.AlertRulesCount, &db.initAlertRules)
+	db.collectionNeedInit("alerts", db.AlertsCount, &db.initAlerts)
+	db.collectionNeedInit("fmint accounts", db.FMintAccountsCount, &db.initFMintAccounts)
+	db.collectionNeedInit("uniswap pairs", db.UniswapPairsCount, &db.initUniswapPairs)
+	db.collectionNeedInit("validator apr", db.ValidatorAprCount, &db.initValidatorApr)
+	db.collectionNeedInit("usage", db.UsageCount, &db.initUsage)
+	db.checkAccountTransactionsState()
 }
 
 // checkAccountCollectionState checks the Accounts collection state.
@@ -203,7 +426,7 @@ func (db *MongoDbBridge) CountFiltered(col *mongo.Collection, filter *bson.D) (u
 	}
 
 	// do the counting
-	val, err := col.CountDocuments(context.Background(), *filter)
+	val, err := col.CountDocuments(db.opContext(), *filter)
 	if err != nil {
 		db.log.Errorf("can not count documents in rewards collection; %s", err.Error())
 		return 0, err
@@ -214,7 +437,7 @@ func (db *MongoDbBridge) CountFiltered(col *mongo.Collection, filter *bson.D) (u
 // EstimateCount calculates an estimated number of documents in the given collection.
 func (db *MongoDbBridge) EstimateCount(col *mongo.Collection) (uint64, error) {
 	// do the counting
-	val, err := col.EstimatedDocumentCount(context.Background())
+	val, err := col.EstimatedDocumentCount(db.opContext())
 	if err != nil {
 		db.log.Errorf("can not count documents in rewards collection; %s", err.Error())
 		return 0, err
@@ -226,7 +449,7 @@ func (db *MongoDbBridge) EstimateCount(col *mongo.Collection) (uint64, error) {
 // time, use general estimation to speed up the loader.
 func (db *MongoDbBridge) listDocumentsCount(col *mongo.Collection, filter *bson.D) (int64, error) {
 	// try to count the proper way
-	total, err := col.CountDocuments(context.Background(), filter, options.Count().SetMaxTime(docListCountAggregationTimeout))
+	total, err := col.CountDocuments(db.opContext(), filter, options.Count().SetMaxTime(docListCountAggregationTimeout))
 	if err == nil {
 		return total, nil
 	}
@@ -235,10 +458,20 @@ func (db *MongoDbBridge) listDocumentsCount(col *mongo.Collection, filter *bson.
 	db.log.Errorf("can not count documents properly; %s", err.Error())
 
 	// just estimate the whole collection size
-	total, err = col.EstimatedDocumentCount(context.Background())
+	total, err = col.EstimatedDocumentCount(db.opContext())
 	if err != nil {
 		db.log.Errorf("can not count documents")
 		return 0, err
 	}
 	return total, nil
 }
+
+// Ping checks the database connection and provides the time the database took to respond.
+func (db *MongoDbBridge) Ping() (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(db.opContext(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := db.client.Ping(ctx, nil)
+	return time.Since(start), err
+}
@@ -2,7 +2,6 @@
 package db
 
 import (
-	"context"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"go.mongodb.org/mongo-driver/bson"
@@ -22,6 +21,9 @@ const (
 
 	// keyConfigLastKnownBlock is the primary key for the Last Known Block value.
 	keyConfigLastKnownBlock = "lnb"
+
+	// keyConfigAccountTrxReady is the primary key for the account transaction links state value.
+	keyConfigAccountTrxReady = "atx"
 )
 
 // ConfigRow represents a row in configuration collection.
@@ -41,7 +43,7 @@ func (db *MongoDbBridge) UpdateLastKnownBlock(blockNo *hexutil.Uint64) error {
 	col := db.client.Database(db.dbName).Collection(coConfiguration)
 
 	// insert/update
-	_, err := col.UpdateByID(context.Background(), keyConfigLastKnownBlock, bson.D{{"$set", bson.D{
+	_, err := col.UpdateByID(db.opContext(), keyConfigLastKnownBlock, bson.D{{"$set", bson.D{
 		{fiConfigPk, keyConfigLastKnownBlock},
 		{fiConfigValue, blockNo.String()},
 	}}}, new(options.UpdateOptions).SetUpsert(true))
@@ -57,7 +59,7 @@ func (db *MongoDbBridge) LastKnownBlock() (uint64, error) {
 	col := db.client.Database(db.dbName).Collection(coConfiguration)
 
 	// get the last known block from the config collection
-	res := col.FindOne(context.Background(), bson.D{{fiConfigPk, keyConfigLastKnownBlock}})
+	res := col.FindOne(db.opContext(), bson.D{{fiConfigPk, keyConfigLastKnownBlock}})
 	if res.Err() == nil {
 		// get the data
 		var row ConfigRow
@@ -88,7 +90,7 @@ func (db *MongoDbBridge) lastKnownBlock() (uint64, error) {
 
 	// get the collection for account transactions
 	col := db.client.Database(db.dbName).Collection(coTransactions)
-	res := col.FindOne(context.Background(), bson.D{}, opt)
+	res := col.FindOne(db.opContext(), bson.D{}, opt)
 	if res.Err() != nil {
 		// may be no block at all
 		if res.Err() == mongo.ErrNoDocuments {
@@ -114,3 +116,29 @@ func (db *MongoDbBridge) lastKnownBlock() (uint64, error) {
 	}
 	return tx.Block, nil
 }
+
+// configValue loads the value of the given key from the config collection.
+// It returns an empty string if the key is not set.
+func (db *MongoDbBridge) configValue(key string) (string, error) {
+	col := db.client.Database(db.dbName).Collection(coConfiguration)
+
+	var row ConfigRow
+	if err := col.FindOne(db.opContext(), bson.D{{fiConfigPk, key}}).Decode(&row); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", nil
+		}
+		return "", err
+	}
+	return row.Value, nil
+}
+
+// setConfigValue stores the value of the given key into the config collection.
+func (db *MongoDbBridge) setConfigValue(key string, value string) error {
+	col := db.client.Database(db.dbName).Collection(coConfiguration)
+
+	_, err := col.UpdateByID(db.opContext(), key, bson.D{{"$set", bson.D{
+		{fiConfigPk, key},
+		{fiConfigValue, value},
+	}}}, new(options.UpdateOptions).SetUpsert(true))
+	return err
+}
@@ -9,7 +9,6 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	"strconv"
 )
 
 const (
@@ -43,6 +42,9 @@ func (db *MongoDbBridge) initContractsCollection(col *mongo.Collection) {
 		},
 	})
 
+	// index runtime byte code hash for identical deployments lookup
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{fiContractCodeHash, 1}}})
+
 	// create indexes
 	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
 		db.log.Panicf("can not create indexes for contracts collection; %s", err.Error())
@@ -76,7 +78,7 @@ func (db *MongoDbBridge) AddContract(sc *types.Contract) error {
 	}
 
 	// try to do the insert
-	if _, err = col.InsertOne(context.Background(), sc); err != nil {
+	if _, err = col.InsertOne(db.opContext(), sc); err != nil {
 		db.log.Critical(err)
 		return err
 	}
@@ -103,7 +105,7 @@ func (db *MongoDbBridge) UpdateContract(sc *types.Contract) error {
 	col := db.client.Database(db.dbName).Collection(coContract)
 
 	// update the contract details
-	if _, err := col.UpdateOne(context.Background(),
+	if _, err := col.UpdateOne(db.opContext(),
 		bson.D{{fiContractPk, sc.Address.String()}},
 		bson.D{{"$set", sc}}); err != nil {
 		// log the issue
@@ -128,7 +130,7 @@ func (db *MongoDbBridge) IsContractKnown(addr *common.Address) bool {
 // isContractKnown checks if a smart contract document already exists in the database.
 func (db *MongoDbBridge) isContractKnown(col *mongo.Collection, addr *common.Address) (bool, error) {
 	// try to find the contract in the database (it may already exist)
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(db.opContext(), bson.D{
 		{fiContractPk, addr.String()},
 	}, options.FindOne().SetProjection(bson.D{
 		{fiContractPk, true},
@@ -174,7 +176,7 @@ func (db *MongoDbBridge) Contract(addr *common.Address) (*types.Contract, error)
 	col := db.client.Database(db.dbName).Collection(coContract)
 
 	// try to find the contract in the database (it may already exist)
-	sr := col.FindOne(context.Background(), bson.D{{fiContractPk, addr.String()}})
+	sr := col.FindOne(db.opContext(), bson.D{{fiContractPk, addr.String()}})
 
 	// error on lookup?
 	if sr.Err() != nil {
@@ -215,7 +217,7 @@ func (db *MongoDbBridge) contractListTotal(col *mongo.Collection, validatedOnly
 	}
 
 	// find how many contracts do we have in the database
-	total, err := col.CountDocuments(context.Background(), filter)
+	total, err := col.CountDocuments(db.opContext(), filter)
 	if err != nil {
 		db.log.Errorf("can not count contracts")
 		return err
@@ -235,9 +237,9 @@ func contractListTopFilter(validatedOnly bool, cursor *string) (*bson.D, error)
 		var err error
 
 		// get the ordinal index based on cursor
-		ix, err = strconv.ParseUint(*cursor, 10, 64)
+		ix, err = decimalCursorOrdinal(*cursor)
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor value; %s", err.Error())
+			return nil, err
 		}
 	}
 
@@ -395,7 +397,7 @@ func (db *MongoDbBridge) contractListOptions(count int32) *options.FindOptions {
 // contractListLoad loads the initialized contract list from persistent database.
 func (db *MongoDbBridge) contractListLoad(col *mongo.Collection, validatedOnly bool, cursor *string, count int32, list *types.ContractList) error {
 	// get the context for loader
-	ctx := context.Background()
+	ctx := db.opContext()
 
 	// load the data
 	ld, err := col.Find(ctx, db.contractListFilter(validatedOnly, cursor, count, list), db.contractListOptions(count))
@@ -0,0 +1,58 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// coContractArtifacts represents the name of the contract compilation artifacts collection.
+const coContractArtifacts = "contract_artifacts"
+
+// StoreContractArtifact stores the compilation artifact of a validated contract.
+// An artifact of previous validation of the contract is replaced.
+func (db *MongoDbBridge) StoreContractArtifact(ca *types.ContractArtifact) error {
+	// get the collection for artifacts
+	col := db.client.Database(db.dbName).Collection(coContractArtifacts)
+
+	// try to do the upsert
+	if _, err := col.ReplaceOne(
+		db.opContext(),
+		bson.D{{fiContractPk, ca.Address.String()}},
+		ca,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store contract %s artifact; %s", ca.Address.String(), err.Error())
+		return err
+	}
+	return nil
+}
+
+// ContractArtifact loads the compilation artifact of the given contract.
+// Nil is returned if the contract has no artifact stored.
+func (db *MongoDbBridge) ContractArtifact(addr *common.Address) (*types.ContractArtifact, error) {
+	// get the collection for artifacts
+	col := db.client.Database(db.dbName).Collection(coContractArtifacts)
+
+	// try to find the artifact
+	sr := col.FindOne(db.opContext(), bson.D{{fiContractPk, addr.String()}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not get contract %s artifact; %s", addr.String(), sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	// try to decode the artifact
+	var ca types.ContractArtifact
+	if err := sr.Decode(&ca); err != nil {
+		db.log.Errorf("can not decode contract %s artifact; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	return &ca, nil
+}
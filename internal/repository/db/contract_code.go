@@ -0,0 +1,155 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// fiContractCodeHash is the name of the contract runtime byte code hash field.
+	fiContractCodeHash = "code_h"
+
+	// fiContractVerifiedFrom is the name of the field referencing the contract
+	// the validation was copied from.
+	fiContractVerifiedFrom = "vfrom"
+)
+
+// ContractsWithoutCodeHash loads a batch of contracts which do not have
+// the hash of their runtime byte code known yet.
+func (db *MongoDbBridge) ContractsWithoutCodeHash(limit int64) ([]common.Address, error) {
+	col := db.client.Database(db.dbName).Collection(coContract)
+
+	// load the addresses only
+	ld, err := col.Find(db.opContext(),
+		bson.D{{fiContractCodeHash, nil}},
+		options.Find().SetProjection(bson.D{{fiContractPk, true}}).SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not load contracts without code hash; %s", err.Error())
+		return nil, err
+	}
+	defer func() {
+		if err := ld.Close(context.Background()); err != nil {
+			db.log.Errorf("error closing contracts cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]common.Address, 0, limit)
+	for ld.Next(db.opContext()) {
+		var row struct {
+			Address string `bson:"_id"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode contract address; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, common.HexToAddress(row.Address))
+	}
+	return list, nil
+}
+
+// SetContractCodeHash stores the hash of the runtime byte code of the given contract.
+func (db *MongoDbBridge) SetContractCodeHash(addr *common.Address, hash *common.Hash) error {
+	col := db.client.Database(db.dbName).Collection(coContract)
+	if _, err := col.UpdateOne(db.opContext(),
+		bson.D{{fiContractPk, addr.String()}},
+		bson.D{{"$set", bson.D{{fiContractCodeHash, hash.String()}}}}); err != nil {
+		db.log.Errorf("can not set code hash of contract %s; %s", addr.String(), err.Error())
+		return err
+	}
+	return nil
+}
+
+// ContractsByCodeHash loads contracts with the given runtime byte code hash,
+// except the given contract. The newest contracts go first.
+func (db *MongoDbBridge) ContractsByCodeHash(hash *common.Hash, except *common.Address, limit int64) ([]*types.Contract, error) {
+	return db.contractsByCodeHash(bson.D{{fiContractCodeHash, hash.String()}, {fiContractPk, bson.D{{"$ne", except.String()}}}}, limit)
+}
+
+// UnvalidatedContractsByCodeHash loads not validated contracts with the given
+// runtime byte code hash. The newest contracts go first.
+func (db *MongoDbBridge) UnvalidatedContractsByCodeHash(hash *common.Hash, limit int64) ([]*types.Contract, error) {
+	return db.contractsByCodeHash(bson.D{{fiContractCodeHash, hash.String()}, {fiContractSourceValidated, nil}}, limit)
+}
+
+// contractsByCodeHash loads contracts matching the given filter.
+func (db *MongoDbBridge) contractsByCodeHash(filter bson.D, limit int64) ([]*types.Contract, error) {
+	col := db.client.Database(db.dbName).Collection(coContract)
+
+	ld, err := col.Find(db.opContext(), filter, options.Find().SetSort(bson.D{{fiContractOrdinalIndex, -1}}).SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not load contracts by code hash; %s", err.Error())
+		return nil, err
+	}
+	defer func() {
+		if err := ld.Close(context.Background()); err != nil {
+			db.log.Errorf("error closing contracts cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.Contract, 0)
+	for ld.Next(db.opContext()) {
+		var row types.Contract
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode contract; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// ContractsToPropagate finds directly validated contracts which have not validated
+// contracts with identical runtime byte code deployed. The given code hash is skipped.
+func (db *MongoDbBridge) ContractsToPropagate(skip *common.Hash, limit int64) ([]common.Address, error) {
+	col := db.client.Database(db.dbName).Collection(coContract)
+
+	// group contracts by the code hash and pick the validated source of each group
+	ld, err := col.Aggregate(db.opContext(), mongo.Pipeline{
+		{{"$match", bson.D{{fiContractCodeHash, bson.D{{"$ne", nil}, {"$nin", bson.A{skip.String()}}}}}}},
+		{{"$group", bson.D{
+			{"_id", "$" + fiContractCodeHash},
+			{"src", bson.D{{"$max", bson.D{{"$cond", bson.A{
+				bson.D{{"$and", bson.A{
+					bson.D{{"$gt", bson.A{bson.D{{"$ifNull", bson.A{"$" + fiContractSourceValidated, 0}}}, 0}}},
+					bson.D{{"$eq", bson.A{bson.D{{"$ifNull", bson.A{"$" + fiContractVerifiedFrom, ""}}}, ""}}},
+				}}},
+				"$" + fiContractPk,
+				nil,
+			}}}}}},
+			{"pending", bson.D{{"$sum", bson.D{{"$cond", bson.A{
+				bson.D{{"$gt", bson.A{bson.D{{"$ifNull", bson.A{"$" + fiContractSourceValidated, 0}}}, 0}}},
+				0,
+				1,
+			}}}}}},
+		}}},
+		{{"$match", bson.D{{"src", bson.D{{"$ne", nil}}}, {"pending", bson.D{{"$gt", 0}}}}}},
+		{{"$limit", limit}},
+	})
+	if err != nil {
+		db.log.Errorf("can not find contracts to propagate; %s", err.Error())
+		return nil, err
+	}
+	defer func() {
+		if err := ld.Close(context.Background()); err != nil {
+			db.log.Errorf("error closing contracts cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]common.Address, 0)
+	for ld.Next(db.opContext()) {
+		var row struct {
+			Source string `bson:"src"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode contract to propagate; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, common.HexToAddress(row.Source))
+	}
+	return list, nil
+}
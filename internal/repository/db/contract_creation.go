@@ -0,0 +1,113 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colContractCreations represents the name of the contract creations collection.
+const colContractCreations = "contract_creations"
+
+// initContractCreationsCollection initializes the contract creations collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initContractCreationsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := []mongo.IndexModel{{Keys: bson.D{{types.FiContractCreationCreator, 1}, {types.FiContractCreationStamp, -1}}}}
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for contract creations collection; %s", err.Error())
+	}
+
+	// log we done that
+	db.log.Debugf("contract creations collection initialized")
+}
+
+// AddContractCreation stores the given contract creation in the database.
+func (db *MongoDbBridge) AddContractCreation(cc *types.ContractCreation) error {
+	// get the collection for contract creations
+	col := db.client.Database(db.dbName).Collection(colContractCreations)
+
+	// replace the record, or insert a new one
+	if _, err := col.ReplaceOne(db.opContext(),
+		bson.D{{types.FiContractCreationPk, cc.Address.String()}},
+		cc, options.Replace().SetUpsert(true)); err != nil {
+		db.log.Errorf("can not store creation of contract %s; %s", cc.Address.String(), err.Error())
+		return err
+	}
+
+	// make sure contract creations collection is initialized
+	if db.initContractCreations != nil {
+		db.initContractCreations.Do(func() { db.initContractCreationsCollection(col); db.initContractCreations = nil })
+	}
+	return nil
+}
+
+// ContractCreation loads the creation record of the given contract.
+// It returns nil if the contract was not created by another contract.
+func (db *MongoDbBridge) ContractCreation(addr *common.Address) (*types.ContractCreation, error) {
+	// get the collection for contract creations
+	col := db.client.Database(db.dbName).Collection(colContractCreations)
+
+	// try to find the record
+	sr := col.FindOne(db.opContext(), bson.D{{types.FiContractCreationPk, addr.String()}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load creation of contract %s; %s", addr.String(), sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	// try to decode
+	var cc types.ContractCreation
+	if err := sr.Decode(&cc); err != nil {
+		db.log.Errorf("can not decode creation of contract %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	return &cc, nil
+}
+
+// ContractCreationsBy loads the most recent contracts created by the given creator contract.
+func (db *MongoDbBridge) ContractCreationsBy(creator *common.Address, limit int64) ([]*types.ContractCreation, error) {
+	// get the collection for contract creations
+	col := db.client.Database(db.dbName).Collection(colContractCreations)
+
+	// load the records
+	cursor, err := col.Find(db.opContext(),
+		bson.D{{types.FiContractCreationCreator, creator.String()}},
+		options.Find().SetSort(bson.D{{types.FiContractCreationStamp, -1}}).SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not load contracts created by %s; %s", creator.String(), err.Error())
+		return nil, err
+	}
+
+	// make sure to close the cursor
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.ContractCreation, 0)
+	for cursor.Next(db.opContext()) {
+		var cc types.ContractCreation
+		if err := cursor.Decode(&cc); err != nil {
+			db.log.Errorf("can not decode contract creation; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &cc)
+	}
+	return list, nil
+}
+
+// ContractCreationsCount calculates total number of contract creations in the database.
+func (db *MongoDbBridge) ContractCreationsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colContractCreations))
+}
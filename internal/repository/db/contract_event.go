@@ -0,0 +1,424 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// coContractEvents represents the name of the contract events collection in database.
+	coContractEvents = "contract_events"
+
+	// coContractEventStats represents the name of the daily contract event statistics collection in database.
+	coContractEventStats = "contract_event_stats"
+)
+
+// initContractEventsCollection initializes the contract events collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initContractEventsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+
+	// index contract and the ordinal for the list of events of a contract
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiContractEventContract, 1}, {types.FiContractEventOrdinal, -1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiContractEventOrdinal, -1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for contract events collection; %s", err.Error())
+	}
+
+	// log we done that
+	db.log.Debugf("contract events collection initialized")
+}
+
+// initContractEventStatsCollection initializes the contract event statistics collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initContractEventStatsCollection(col *mongo.Collection) {
+	// index contract, topic and day; there is a single counter for each combination
+	unique := true
+	if _, err := col.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{types.FiContractEventStatContract, 1},
+			{types.FiContractEventStatDay, 1},
+			{types.FiContractEventStatTopic, 1},
+		},
+		Options: &options.IndexOptions{Unique: &unique},
+	}); err != nil {
+		db.log.Panicf("can not create indexes for contract event stats collection; %s", err.Error())
+	}
+
+	// log we done that
+	db.log.Debugf("contract event stats collection initialized")
+}
+
+// AddContractEvent stores a contract event in the database and counts it
+// in the daily statistics of the contract. The event is identified by the transaction
+// and the log index, so re-processing the same event log doesn't count it again.
+func (db *MongoDbBridge) AddContractEvent(ev *types.ContractEvent) error {
+	// get the collection for contract events
+	col := db.client.Database(db.dbName).Collection(coContractEvents)
+
+	// try to do the upsert
+	res, err := col.ReplaceOne(
+		db.opContext(),
+		bson.D{{types.FiContractEventPk, ev.Pk()}},
+		ev,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Critical(err)
+		return err
+	}
+
+	// make sure contract events collection is initialized
+	if db.initContractEvents != nil {
+		db.initContractEvents.Do(func() { db.initContractEventsCollection(col); db.initContractEvents = nil })
+	}
+
+	// count a new event
+	if res.UpsertedCount > 0 {
+		return db.incContractEventStat(ev)
+	}
+	return nil
+}
+
+// incContractEventStat increments the daily counter of the event signature of the given event.
+func (db *MongoDbBridge) incContractEventStat(ev *types.ContractEvent) error {
+	col := db.client.Database(db.dbName).Collection(coContractEventStats)
+	day := time.Unix(int64(ev.TimeStamp), 0).UTC().Truncate(24 * time.Hour)
+
+	if _, err := col.UpdateOne(db.opContext(), bson.D{
+		{types.FiContractEventStatContract, ev.Contract.String()},
+		{types.FiContractEventStatDay, day},
+		{types.FiContractEventStatTopic, ev.Topic().String()},
+	}, bson.D{{"$inc", bson.D{{types.FiContractEventStatCount, 1}}}}, options.Update().SetUpsert(true)); err != nil {
+		db.log.Errorf("can not count event of %s; %s", ev.Contract.String(), err.Error())
+		return err
+	}
+
+	// make sure contract event stats collection is initialized
+	if db.initContractEventStats != nil {
+		db.initContractEventStats.Do(func() { db.initContractEventStatsCollection(col); db.initContractEventStats = nil })
+	}
+	return nil
+}
+
+// ContractEventsCount calculates total number of contract events in the database.
+func (db *MongoDbBridge) ContractEventsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(coContractEvents))
+}
+
+// ContractEventStatsCount calculates total number of daily contract event counters in the database.
+func (db *MongoDbBridge) ContractEventStatsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(coContractEventStats))
+}
+
+// ContractEventStats loads daily counters of events of the given contract since the given day,
+// sorted by the day.
+func (db *MongoDbBridge) ContractEventStats(addr *common.Address, since time.Time) ([]*types.ContractEventStat, error) {
+	col := db.client.Database(db.dbName).Collection(coContractEventStats)
+	ctx := db.opContext()
+
+	cur, err := col.Find(ctx, bson.D{
+		{types.FiContractEventStatContract, addr.String()},
+		{types.FiContractEventStatDay, bson.D{{"$gte", since}}},
+	}, options.Find().SetSort(bson.D{{types.FiContractEventStatDay, 1}}))
+	if err != nil {
+		db.log.Errorf("can not load event stats of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cur.Close(ctx); err != nil {
+			db.log.Errorf("error closing event stats cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.ContractEventStat, 0)
+	for cur.Next(ctx) {
+		var row struct {
+			Topic string    `bson:"topic"`
+			Day   time.Time `bson:"day"`
+			Count int64     `bson:"cnt"`
+		}
+		if err := cur.Decode(&row); err != nil {
+			db.log.Errorf("can not decode event stats row; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &types.ContractEventStat{
+			Topic: common.HexToHash(row.Topic),
+			Day:   row.Day,
+			Count: uint64(row.Count),
+		})
+	}
+	return list, cur.Err()
+}
+
+// cevListInit initializes list of contract events based on provided cursor, count, and filter.
+func (db *MongoDbBridge) cevListInit(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.ContractEventList, error) {
+	// make sure some filter is used
+	if nil == filter {
+		filter = &bson.D{}
+	}
+
+	// find how many events do we have in the database
+	total, err := col.CountDocuments(db.opContext(), *filter)
+	if err != nil {
+		db.log.Errorf("can not count contract events")
+		return nil, err
+	}
+
+	// make the list and notify the size of it
+	db.log.Debugf("found %d filtered contract events", total)
+	list := types.ContractEventList{
+		Collection: make([]*types.ContractEvent, 0),
+		Total:      uint64(total),
+		First:      0,
+		Last:       0,
+		IsStart:    total == 0,
+		IsEnd:      total == 0,
+		Filter:     *filter,
+	}
+
+	// is the list non-empty? return the list with properly calculated range marks
+	if 0 < total {
+		return db.cevListCollectRangeMarks(col, &list, cursor, count)
+	}
+	// this is an empty list
+	db.log.Debug("empty contract events list created")
+	return &list, nil
+}
+
+// cevListCollectRangeMarks returns a list of contract events with proper First/Last marks.
+func (db *MongoDbBridge) cevListCollectRangeMarks(col *mongo.Collection, list *types.ContractEventList, cursor *string, count int32) (*types.ContractEventList, error) {
+	var err error
+
+	// find out the cursor ordinal index
+	if cursor == nil && count > 0 {
+		// get the highest available pk
+		list.First, err = db.cevListBorderPk(col,
+			list.Filter,
+			options.FindOne().SetSort(bson.D{{types.FiContractEventOrdinal, -1}}))
+		list.IsStart = true
+
+	} else if cursor == nil && count < 0 {
+		// get the lowest available pk
+		list.First, err = db.cevListBorderPk(col,
+			list.Filter,
+			options.FindOne().SetSort(bson.D{{types.FiContractEventOrdinal, 1}}))
+		list.IsEnd = true
+
+	} else if cursor != nil {
+		// the cursor itself is the starting point
+		var ok bool
+		if list.First, ok = types.DecodeListCursor(*cursor); !ok {
+			err = fmt.Errorf("invalid contract events cursor %s", *cursor)
+		}
+	}
+
+	// check the error
+	if err != nil {
+		db.log.Errorf("can not find the initial contract event")
+		return nil, err
+	}
+
+	// inform what we are about to do
+	db.log.Debugf("contract event list initialized with ordinal %d", list.First)
+	return list, nil
+}
+
+// cevListBorderPk finds the top PK of the contract events collection based on given filter and options.
+func (db *MongoDbBridge) cevListBorderPk(col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
+	// prep container
+	var row struct {
+		Value uint64 `bson:"orx"`
+	}
+
+	// make sure we pull only what we need
+	opt.SetProjection(bson.D{{types.FiContractEventOrdinal, true}})
+
+	// try to decode
+	sr := col.FindOne(db.opContext(), filter, opt)
+	err := sr.Decode(&row)
+	if err != nil {
+		return 0, err
+	}
+	return row.Value, nil
+}
+
+// cevListFilter creates a filter for contract events list loading.
+func (db *MongoDbBridge) cevListFilter(cursor *string, count int32, list *types.ContractEventList) *bson.D {
+	// build an extended filter for the query; add PK (decoded cursor) to the original filter
+	if cursor == nil {
+		if count > 0 {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiContractEventOrdinal, Value: bson.D{{"$lte", list.First}}})
+		} else {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiContractEventOrdinal, Value: bson.D{{"$gte", list.First}}})
+		}
+	} else {
+		if count > 0 {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiContractEventOrdinal, Value: bson.D{{"$lt", list.First}}})
+		} else {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiContractEventOrdinal, Value: bson.D{{"$gt", list.First}}})
+		}
+	}
+	// return the new filter
+	return &list.Filter
+}
+
+// cevListOptions creates a filter options set for contract events list search.
+func (db *MongoDbBridge) cevListOptions(count int32) *options.FindOptions {
+	// prep options
+	opt := options.Find()
+
+	// how to sort results in the collection
+	// from high (new) to low (old) by default; reversed if loading from bottom
+	sd := -1
+	if count < 0 {
+		sd = 1
+	}
+
+	// sort with the direction we want
+	opt.SetSort(bson.D{{types.FiContractEventOrdinal, sd}})
+
+	// prep the loading limit
+	var limit = int64(count)
+	if limit < 0 {
+		limit = -limit
+	}
+
+	// apply the limit, try to get one more record so we can detect list end
+	opt.SetLimit(limit + 1)
+	return opt
+}
+
+// cevListLoad load the initialized list of contract events from database.
+func (db *MongoDbBridge) cevListLoad(col *mongo.Collection, cursor *string, count int32, list *types.ContractEventList) (err error) {
+	// get the context for loader
+	ctx := db.opContext()
+
+	// load the data
+	ld, err := col.Find(ctx, db.cevListFilter(cursor, count, list), db.cevListOptions(count))
+	if err != nil {
+		db.log.Errorf("error loading contract events list; %s", err.Error())
+		return err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		err = ld.Close(ctx)
+		if err != nil {
+			db.log.Errorf("error closing contract events list cursor; %s", err.Error())
+		}
+	}()
+
+	// loop and load the list; we may not store the last value
+	var cev *types.ContractEvent
+	for ld.Next(ctx) {
+		// append a previous value to the list, if we have one
+		if cev != nil {
+			list.Collection = append(list.Collection, cev)
+		}
+
+		// try to decode the next row
+		var row types.ContractEvent
+		if err = ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode the contract event list row; %s", err.Error())
+			return err
+		}
+
+		// use this row as the next item
+		cev = &row
+	}
+
+	// we should have all the items already; we may just need to check if a boundary was reached
+	list.IsEnd = (cursor == nil && count < 0) || (count > 0 && int32(len(list.Collection)) < count)
+	list.IsStart = (cursor == nil && count > 0) || (count < 0 && int32(len(list.Collection)) < -count)
+
+	// add the last item as well if we hit the boundary
+	if (list.IsStart || list.IsEnd) && cev != nil {
+		list.Collection = append(list.Collection, cev)
+	}
+	return nil
+}
+
+// ContractEvents pulls list of contract events starting at the specified cursor.
+func (db *MongoDbBridge) ContractEvents(cursor *string, count int32, filter *bson.D) (*types.ContractEventList, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero contract events requested")
+	}
+
+	// get the collection and context
+	col := db.client.Database(db.dbName).Collection(coContractEvents)
+
+	// init the list
+	list, err := db.cevListInit(col, cursor, count, filter)
+	if err != nil {
+		db.log.Errorf("can not build contract events list; %s", err.Error())
+		return nil, err
+	}
+
+	// load data if there are any
+	if list.Total > 0 {
+		err = db.cevListLoad(col, cursor, count, list)
+		if err != nil {
+			db.log.Errorf("can not load contract events list from database; %s", err.Error())
+			return nil, err
+		}
+
+		// reverse on negative so new-er events will be on top
+		if count < 0 {
+			list.Reverse()
+		}
+	}
+	return list, nil
+}
+
+// ContractEventsIn loads events of the given signature emitted by the given contract
+// in the given range of blocks, oldest first.
+func (db *MongoDbBridge) ContractEventsIn(addr *common.Address, topic *common.Hash, from uint64, to uint64, limit int64) ([]*types.ContractEvent, error) {
+	col := db.client.Database(db.dbName).Collection(coContractEvents)
+	ctx := db.opContext()
+
+	filter := bson.D{
+		{types.FiContractEventContract, addr.String()},
+		{types.FiContractEventOrdinal, bson.D{
+			{"$gte", (&types.ContractEvent{BlockNumber: from}).OrdinalIndex()},
+			{"$lt", (&types.ContractEvent{BlockNumber: to + 1}).OrdinalIndex()},
+		}},
+	}
+	if topic != nil {
+		filter = append(filter, bson.E{Key: types.FiContractEventTopic, Value: topic.String()})
+	}
+
+	cur, err := col.Find(ctx, filter, options.Find().SetSort(bson.D{{types.FiContractEventOrdinal, 1}}).SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not load events of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	defer func() {
+		if err := cur.Close(ctx); err != nil {
+			db.log.Errorf("error closing contract events cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.ContractEvent, 0)
+	for cur.Next(ctx) {
+		var row types.ContractEvent
+		if err := cur.Decode(&row); err != nil {
+			db.log.Errorf("can not decode contract event; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, cur.Err()
+}
@@ -55,7 +55,7 @@ func (db *MongoDbBridge) Delegation(addr *common.Address, valID *hexutil.Big) (*
 	col := db.client.Database(db.dbName).Collection(colDelegations)
 
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(db.opContext(), bson.D{
 		{types.FiDelegationAddress, addr.String()},
 		{types.FiDelegationToValidator, valID.String()},
 	})
@@ -84,7 +84,7 @@ func (db *MongoDbBridge) AddDelegation(dl *types.Delegation) error {
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), dl); err != nil {
+	if _, err := col.InsertOne(db.opContext(), dl); err != nil {
 		db.log.Critical(err)
 		return err
 	}
@@ -110,7 +110,7 @@ func (db *MongoDbBridge) UpdateDelegation(dl *types.Delegation) error {
 
 	// try to update a delegation by replacing it in the database
 	// we use address and validator ID to identify unique delegation
-	er, err := col.UpdateOne(context.Background(), bson.D{
+	er, err := col.UpdateOne(db.opContext(), bson.D{
 		{types.FiDelegationAddress, dl.Address.String()},
 		{types.FiDelegationToValidator, dl.ToStakerId.String()},
 	}, bson.D{{"$set", bson.D{
@@ -142,7 +142,7 @@ func (db *MongoDbBridge) UpdateDelegationBalance(addr *common.Address, valID *he
 	db.log.Debugf("%s delegation to #%d value changed to %d", addr.String(), valID.ToInt().Uint64(), val)
 
 	// update the transaction details
-	ur, err := col.UpdateOne(context.Background(),
+	ur, err := col.UpdateOne(db.opContext(),
 		bson.D{
 			{types.FiDelegationAddress, addr.String()},
 			{types.FiDelegationToValidator, valID.String()},
@@ -168,7 +168,7 @@ func (db *MongoDbBridge) UpdateDelegationBalance(addr *common.Address, valID *he
 // isDelegationKnown checks if the given delegation exists in the database.
 func (db *MongoDbBridge) isDelegationKnown(col *mongo.Collection, dl *types.Delegation) bool {
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(db.opContext(), bson.D{
 		{types.FiDelegationAddress, dl.Address.String()},
 		{types.FiDelegationToValidator, dl.ToStakerId.String()},
 	}, options.FindOne().SetProjection(bson.D{
@@ -199,6 +199,48 @@ func (db *MongoDbBridge) DelegationsCount() (uint64, error) {
 	return db.EstimateCount(db.client.Database(db.dbName).Collection(colDelegations))
 }
 
+// DelegatorsCountByValidator calculates the number of active delegations of each validator.
+// The resulting map is indexed by the hex encoded validator ID.
+func (db *MongoDbBridge) DelegatorsCountByValidator() (map[string]uint64, error) {
+	// get the collection and context
+	col := db.client.Database(db.dbName).Collection(colDelegations)
+	ctx := db.opContext()
+
+	// aggregate non-empty delegations by the target validator
+	cr, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{"$match", bson.D{{types.FiDelegationAmount, bson.D{{"$ne", "0x0"}}}}}},
+		{{"$group", bson.D{
+			{"_id", "$" + types.FiDelegationToValidator},
+			{"count", bson.D{{"$sum", 1}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not count delegators by validator; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := cr.Close(ctx); err != nil {
+			db.log.Errorf("error closing delegators count cursor; %s", err.Error())
+		}
+	}()
+
+	res := make(map[string]uint64)
+	for cr.Next(ctx) {
+		var row struct {
+			ID    string `bson:"_id"`
+			Count int64  `bson:"count"`
+		}
+		if err := cr.Decode(&row); err != nil {
+			db.log.Errorf("can not decode delegators count row; %s", err.Error())
+			return nil, err
+		}
+		res[row.ID] = uint64(row.Count)
+	}
+	return res, nil
+}
+
 // dlgListInit initializes list of delegations based on provided cursor, count, and filter.
 func (db *MongoDbBridge) dlgListInit(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.DelegationList, error) {
 	// make sure some filter is used
@@ -207,7 +249,7 @@ func (db *MongoDbBridge) dlgListInit(col *mongo.Collection, cursor *string, coun
 	}
 
 	// find how many transactions do we have in the database
-	total, err := col.CountDocuments(context.Background(), *filter)
+	total, err := col.CountDocuments(db.opContext(), *filter)
 	if err != nil {
 		db.log.Errorf("can not count delegations")
 		return nil, err
@@ -256,7 +298,7 @@ func (db *MongoDbBridge) dlgListCollectRangeMarks(col *mongo.Collection, list *t
 
 	} else if cursor != nil {
 		// the cursor itself is the starting point
-		cv, err := hexutil.DecodeUint64(*cursor)
+		cv, err := hexCursorOrdinal(*cursor)
 		if err != nil {
 			return nil, err
 		}
@@ -286,7 +328,7 @@ func (db *MongoDbBridge) dlgListBorderPk(col *mongo.Collection, filter bson.D, o
 
 	// make sure we pull only what we need
 	opt.SetProjection(bson.D{{types.FiDelegationOrdinal, true}})
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(db.opContext(), filter, opt)
 
 	// try to decode
 	err := sr.Decode(&row)
@@ -347,7 +389,7 @@ func (db *MongoDbBridge) dlgListOptions(count int32) *options.FindOptions {
 // dlgListLoad load the initialized list of delegations from database.
 func (db *MongoDbBridge) dlgListLoad(col *mongo.Collection, cursor *string, count int32, list *types.DelegationList) (err error) {
 	// get the context for loader
-	ctx := context.Background()
+	ctx := db.opContext()
 
 	// load the data
 	ld, err := col.Find(ctx, db.dlgListFilter(cursor, count, list), db.dlgListOptions(count))
@@ -433,7 +475,7 @@ func (db *MongoDbBridge) DelegationsAll(filter *bson.D) ([]*types.Delegation, er
 	// get the collection and context
 	col := db.client.Database(db.dbName).Collection(colDelegations)
 	list := make([]*types.Delegation, 0)
-	ctx := context.Background()
+	ctx := db.opContext()
 
 	// load the data
 	ld, err := col.Find(ctx, filter, options.Find().SetSort(bson.D{{types.FiDelegationCreated, -1}}))
@@ -0,0 +1,289 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colDelegationOperations represents the name of the delegation operations collection in database.
+const colDelegationOperations = "delegation_ops"
+
+// initDelegationOperationsCollection initializes the delegation operations collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initDelegationOperationsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+
+	// index delegator and validator pair for the history of a delegation, and the ordinal
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{
+		{types.FiDelegationOperationAddress, 1},
+		{types.FiDelegationOperationToValidator, 1},
+		{types.FiDelegationOperationOrdinal, -1},
+	}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiDelegationOperationToValidator, 1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiDelegationOperationOrdinal, -1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for delegation operations collection; %s", err.Error())
+	}
+
+	// log we done that
+	db.log.Debugf("delegation operations collection initialized")
+}
+
+// AddDelegationOperation stores a delegation operation in the database.
+// The operation is identified by the transaction and the log index, so re-processing
+// the same event log just replaces the existing record.
+func (db *MongoDbBridge) AddDelegationOperation(op *types.DelegationOperation) error {
+	// get the collection for delegation operations
+	col := db.client.Database(db.dbName).Collection(colDelegationOperations)
+
+	// try to do the upsert
+	if _, err := col.ReplaceOne(
+		db.opContext(),
+		bson.D{{types.FiDelegationOperationPk, op.Pk()}},
+		op,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Critical(err)
+		return err
+	}
+
+	// make sure delegation operations collection is initialized
+	if db.initDelegationOps != nil {
+		db.initDelegationOps.Do(func() { db.initDelegationOperationsCollection(col); db.initDelegationOps = nil })
+	}
+	return nil
+}
+
+// DelegationOperationsCount calculates total number of delegation operations in the database.
+func (db *MongoDbBridge) DelegationOperationsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colDelegationOperations))
+}
+
+// dopListInit initializes list of delegation operations based on provided cursor, count, and filter.
+func (db *MongoDbBridge) dopListInit(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.DelegationOperationList, error) {
+	// make sure some filter is used
+	if nil == filter {
+		filter = &bson.D{}
+	}
+
+	// find how many operations do we have in the database
+	total, err := col.CountDocuments(db.opContext(), *filter)
+	if err != nil {
+		db.log.Errorf("can not count delegation operations")
+		return nil, err
+	}
+
+	// make the list and notify the size of it
+	db.log.Debugf("found %d filtered delegation operations", total)
+	list := types.DelegationOperationList{
+		Collection: make([]*types.DelegationOperation, 0),
+		Total:      uint64(total),
+		First:      0,
+		Last:       0,
+		IsStart:    total == 0,
+		IsEnd:      total == 0,
+		Filter:     *filter,
+	}
+
+	// is the list non-empty? return the list with properly calculated range marks
+	if 0 < total {
+		return db.dopListCollectRangeMarks(col, &list, cursor, count)
+	}
+	// this is an empty list
+	db.log.Debug("empty delegation operations list created")
+	return &list, nil
+}
+
+// dopListCollectRangeMarks returns a list of delegation operations with proper First/Last marks.
+func (db *MongoDbBridge) dopListCollectRangeMarks(col *mongo.Collection, list *types.DelegationOperationList, cursor *string, count int32) (*types.DelegationOperationList, error) {
+	var err error
+
+	// find out the cursor ordinal index
+	if cursor == nil && count > 0 {
+		// get the highest available pk
+		list.First, err = db.dopListBorderPk(col,
+			list.Filter,
+			options.FindOne().SetSort(bson.D{{types.FiDelegationOperationOrdinal, -1}}))
+		list.IsStart = true
+
+	} else if cursor == nil && count < 0 {
+		// get the lowest available pk
+		list.First, err = db.dopListBorderPk(col,
+			list.Filter,
+			options.FindOne().SetSort(bson.D{{types.FiDelegationOperationOrdinal, 1}}))
+		list.IsEnd = true
+
+	} else if cursor != nil {
+		// the cursor itself is the starting point
+		var ok bool
+		if list.First, ok = types.DecodeListCursor(*cursor); !ok {
+			err = fmt.Errorf("invalid delegation operations cursor %s", *cursor)
+		}
+	}
+
+	// check the error
+	if err != nil {
+		db.log.Errorf("can not find the initial delegation operation")
+		return nil, err
+	}
+
+	// inform what we are about to do
+	db.log.Debugf("delegation operation list initialized with ordinal %d", list.First)
+	return list, nil
+}
+
+// dopListBorderPk finds the top PK of the delegation operations collection based on given filter and options.
+func (db *MongoDbBridge) dopListBorderPk(col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
+	// prep container
+	var row struct {
+		Value uint64 `bson:"orx"`
+	}
+
+	// make sure we pull only what we need
+	opt.SetProjection(bson.D{{types.FiDelegationOperationOrdinal, true}})
+
+	// try to decode
+	sr := col.FindOne(db.opContext(), filter, opt)
+	err := sr.Decode(&row)
+	if err != nil {
+		return 0, err
+	}
+	return row.Value, nil
+}
+
+// dopListFilter creates a filter for delegation operations list loading.
+func (db *MongoDbBridge) dopListFilter(cursor *string, count int32, list *types.DelegationOperationList) *bson.D {
+	// build an extended filter for the query; add PK (decoded cursor) to the original filter
+	if cursor == nil {
+		if count > 0 {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiDelegationOperationOrdinal, Value: bson.D{{"$lte", list.First}}})
+		} else {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiDelegationOperationOrdinal, Value: bson.D{{"$gte", list.First}}})
+		}
+	} else {
+		if count > 0 {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiDelegationOperationOrdinal, Value: bson.D{{"$lt", list.First}}})
+		} else {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiDelegationOperationOrdinal, Value: bson.D{{"$gt", list.First}}})
+		}
+	}
+	// return the new filter
+	return &list.Filter
+}
+
+// dopListOptions creates a filter options set for delegation operations list search.
+func (db *MongoDbBridge) dopListOptions(count int32) *options.FindOptions {
+	// prep options
+	opt := options.Find()
+
+	// how to sort results in the collection
+	// from high (new) to low (old) by default; reversed if loading from bottom
+	sd := -1
+	if count < 0 {
+		sd = 1
+	}
+
+	// sort with the direction we want
+	opt.SetSort(bson.D{{types.FiDelegationOperationOrdinal, sd}})
+
+	// prep the loading limit
+	var limit = int64(count)
+	if limit < 0 {
+		limit = -limit
+	}
+
+	// apply the limit, try to get one more record so we can detect list end
+	opt.SetLimit(limit + 1)
+	return opt
+}
+
+// dopListLoad load the initialized list of delegation operations from database.
+func (db *MongoDbBridge) dopListLoad(col *mongo.Collection, cursor *string, count int32, list *types.DelegationOperationList) (err error) {
+	// get the context for loader
+	ctx := db.opContext()
+
+	// load the data
+	ld, err := col.Find(ctx, db.dopListFilter(cursor, count, list), db.dopListOptions(count))
+	if err != nil {
+		db.log.Errorf("error loading delegation operations list; %s", err.Error())
+		return err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		err = ld.Close(ctx)
+		if err != nil {
+			db.log.Errorf("error closing delegation operations list cursor; %s", err.Error())
+		}
+	}()
+
+	// loop and load the list; we may not store the last value
+	var dop *types.DelegationOperation
+	for ld.Next(ctx) {
+		// append a previous value to the list, if we have one
+		if dop != nil {
+			list.Collection = append(list.Collection, dop)
+		}
+
+		// try to decode the next row
+		var row types.DelegationOperation
+		if err = ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode the delegation operation list row; %s", err.Error())
+			return err
+		}
+
+		// use this row as the next item
+		dop = &row
+	}
+
+	// we should have all the items already; we may just need to check if a boundary was reached
+	list.IsEnd = (cursor == nil && count < 0) || (count > 0 && int32(len(list.Collection)) < count)
+	list.IsStart = (cursor == nil && count > 0) || (count < 0 && int32(len(list.Collection)) < -count)
+
+	// add the last item as well if we hit the boundary
+	if (list.IsStart || list.IsEnd) && dop != nil {
+		list.Collection = append(list.Collection, dop)
+	}
+	return nil
+}
+
+// DelegationOperations pulls list of delegation operations starting at the specified cursor.
+func (db *MongoDbBridge) DelegationOperations(cursor *string, count int32, filter *bson.D) (*types.DelegationOperationList, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero delegation operations requested")
+	}
+
+	// get the collection and context
+	col := db.client.Database(db.dbName).Collection(colDelegationOperations)
+
+	// init the list
+	list, err := db.dopListInit(col, cursor, count, filter)
+	if err != nil {
+		db.log.Errorf("can not build delegation operations list; %s", err.Error())
+		return nil, err
+	}
+
+	// load data if there are any
+	if list.Total > 0 {
+		err = db.dopListLoad(col, cursor, count, list)
+		if err != nil {
+			db.log.Errorf("can not load delegation operations list from database; %s", err.Error())
+			return nil, err
+		}
+
+		// reverse on negative so new-er operations will be on top
+		if count < 0 {
+			list.Reverse()
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,38 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DenyListMatch checks the given addresses against the denylist stored
+// in the given collection. It returns the record of the first denied address found,
+// nil if none of the addresses is denied. The collection is maintained by the operator.
+func (db *MongoDbBridge) DenyListMatch(colName string, list []common.Address) (*types.ScreeningMatch, error) {
+	col := db.client.Database(db.dbName).Collection(colName)
+
+	addr := make(bson.A, len(list))
+	for i, a := range list {
+		addr[i] = a.String()
+	}
+
+	sr := col.FindOne(db.opContext(), bson.D{{types.FiDenyListPk, bson.D{{"$in", addr}}}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not screen addresses against %s; %s", colName, sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	var sm types.ScreeningMatch
+	if err := sr.Decode(&sm); err != nil {
+		db.log.Errorf("can not decode denied address; %s", err.Error())
+		return nil, err
+	}
+	return &sm, nil
+}
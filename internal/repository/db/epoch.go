@@ -20,6 +20,12 @@ const (
 
 	// fiEpochEndTime is the name of the epoch end field in the collection.
 	fiEpochEndTime = "end"
+
+	// fiEpochStats is the name of the epoch aggregated statistics sub-document.
+	fiEpochStats = "stats"
+
+	// epochStatsBatchSize is the max number of epochs without statistics loaded at once.
+	epochStatsBatchSize = 100
 )
 
 // initEpochsCollection initializes the epochs collection with
@@ -58,7 +64,7 @@ func (db *MongoDbBridge) AddEpoch(e *types.Epoch) error {
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), e); err != nil {
+	if _, err := col.InsertOne(db.opContext(), e); err != nil {
 		db.log.Critical(err)
 		return err
 	}
@@ -76,7 +82,7 @@ func (db *MongoDbBridge) AddEpoch(e *types.Epoch) error {
 // isEpochKnown checks if the given epoch has already been added to the database
 func (db *MongoDbBridge) isEpochKnown(col *mongo.Collection, e *types.Epoch) bool {
 	// try to find the epoch in the database (it may already exist)
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(db.opContext(), bson.D{
 		{fiEpochPk, int64(e.Id)},
 	}, options.FindOne().SetProjection(bson.D{
 		{fiEpochPk, true},
@@ -147,7 +153,7 @@ func (db *MongoDbBridge) epochListCollectRangeMarks(col *mongo.Collection, list
 
 	} else if cursor != nil {
 		// the cursor itself is the starting point
-		list.First, err = hexutil.DecodeUint64(*cursor)
+		list.First, err = hexCursorOrdinal(*cursor)
 	}
 
 	// check the error
@@ -172,7 +178,7 @@ func (db *MongoDbBridge) epochListBorderPk(col *mongo.Collection, opt *options.F
 	opt.SetProjection(bson.D{{fiEpochPk, true}})
 
 	// try to decode
-	sr := col.FindOne(context.Background(), bson.D{}, opt)
+	sr := col.FindOne(db.opContext(), bson.D{}, opt)
 	err := sr.Decode(&row)
 	if err != nil {
 		return 0, err
@@ -226,7 +232,7 @@ func (db *MongoDbBridge) epochListOptions(count int32) *options.FindOptions {
 // epochListLoad loads the initialized list of epochs from database.
 func (db *MongoDbBridge) epochListLoad(col *mongo.Collection, cursor *string, count int32, list *types.EpochList) (err error) {
 	// get the context for loader
-	ctx := context.Background()
+	ctx := db.opContext()
 
 	// load the data
 	ld, err := col.Find(ctx, db.epochListFilter(cursor, count, list), db.epochListOptions(count))
@@ -304,3 +310,102 @@ func (db *MongoDbBridge) Epochs(cursor *string, count int32) (*types.EpochList,
 	}
 	return list, nil
 }
+
+// EpochsWithoutStats loads a batch of the oldest stored epochs
+// not having aggregated statistics calculated yet.
+func (db *MongoDbBridge) EpochsWithoutStats() ([]*types.Epoch, error) {
+	// get the collection and context
+	ctx := db.opContext()
+	col := db.client.Database(db.dbName).Collection(colEpochs)
+
+	// find epochs without the statistics
+	ld, err := col.Find(ctx, bson.D{{fiEpochStats, bson.D{{"$exists", false}}}},
+		options.Find().SetSort(bson.D{{fiEpochPk, 1}}).SetLimit(epochStatsBatchSize))
+	if err != nil {
+		db.log.Errorf("can not load epochs without stats; %s", err.Error())
+		return nil, err
+	}
+
+	return db.loadEpochs(ctx, ld)
+}
+
+// EpochsAfter loads a batch of stored epochs following the given epoch id
+// sorted from the oldest to the newest.
+func (db *MongoDbBridge) EpochsAfter(id uint64, limit int64) ([]*types.Epoch, error) {
+	// get the collection and context
+	ctx := db.opContext()
+	col := db.client.Database(db.dbName).Collection(colEpochs)
+
+	// find epochs following the given one
+	ld, err := col.Find(ctx, bson.D{{fiEpochPk, bson.D{{"$gt", int64(id)}}}},
+		options.Find().SetSort(bson.D{{fiEpochPk, 1}}).SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not load epochs after #%d; %s", id, err.Error())
+		return nil, err
+	}
+	return db.loadEpochs(ctx, ld)
+}
+
+// loadEpochs loads all the epochs from the given cursor and closes it.
+func (db *MongoDbBridge) loadEpochs(ctx context.Context, ld *mongo.Cursor) ([]*types.Epoch, error) {
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing epochs cursor; %s", err.Error())
+		}
+	}()
+
+	// load the list
+	list := make([]*types.Epoch, 0)
+	for ld.Next(ctx) {
+		var row types.Epoch
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode epoch; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// SetEpochStats stores aggregated statistics of the given epoch.
+func (db *MongoDbBridge) SetEpochStats(id hexutil.Uint64, st *types.EpochStats) error {
+	col := db.client.Database(db.dbName).Collection(colEpochs)
+
+	// update the epoch record
+	if _, err := col.UpdateOne(db.opContext(),
+		bson.D{{fiEpochPk, int64(id)}},
+		bson.D{{"$set", bson.D{{fiEpochStats, st}}}}); err != nil {
+		db.log.Errorf("can not store epoch #%d stats; %s", id, err.Error())
+		return err
+	}
+	return nil
+}
+
+// EpochStats loads aggregated statistics of the given epoch.
+// It returns nil if the statistics are not available.
+func (db *MongoDbBridge) EpochStats(id hexutil.Uint64) (*types.EpochStats, error) {
+	col := db.client.Database(db.dbName).Collection(colEpochs)
+
+	// load only the statistics
+	sr := col.FindOne(db.opContext(), bson.D{{fiEpochPk, int64(id)}},
+		options.FindOne().SetProjection(bson.D{{fiEpochStats, true}}))
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load epoch #%d stats; %s", id, sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	// decode the statistics
+	var row struct {
+		Stats *types.EpochStats `bson:"stats"`
+	}
+	if err := sr.Decode(&row); err != nil {
+		db.log.Errorf("can not decode epoch #%d stats; %s", id, err.Error())
+		return nil, err
+	}
+	return row.Stats, nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fantom-api-graphql/internal/types"
 	"fmt"
+	"github.com/ethereum/go-ethereum/common"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -46,7 +47,7 @@ func (db *MongoDbBridge) AddERC20Transaction(trx *types.Erc20Transaction) error
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), trx); err != nil {
+	if _, err := col.InsertOne(db.opContext(), trx); err != nil {
 		db.log.Critical(err)
 		return err
 	}
@@ -61,7 +62,7 @@ func (db *MongoDbBridge) AddERC20Transaction(trx *types.Erc20Transaction) error
 // isErcTransactionKnown checks if the given delegation exists in the database.
 func (db *MongoDbBridge) isErcTransactionKnown(col *mongo.Collection, trx *types.Erc20Transaction) bool {
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(db.opContext(), bson.D{
 		{types.FiErc20TransactionPk, trx.Pk()},
 	}, options.FindOne().SetProjection(bson.D{
 		{types.FiErc20TransactionPk, true},
@@ -99,7 +100,7 @@ func (db *MongoDbBridge) ercTrxListInit(col *mongo.Collection, cursor *string, c
 	}
 
 	// find how many transactions do we have in the database
-	total, err := col.CountDocuments(context.Background(), *filter)
+	total, err := col.CountDocuments(db.opContext(), *filter)
 	if err != nil {
 		db.log.Errorf("can not count ERC20 transactions")
 		return nil, err
@@ -147,9 +148,13 @@ func (db *MongoDbBridge) ercTrxListCollectRangeMarks(col *mongo.Collection, list
 
 	} else if cursor != nil {
 		// the cursor itself is the starting point
-		list.First, err = db.ercTrxListBorderPk(col,
-			bson.D{{types.FiErc20TransactionPk, *cursor}},
-			options.FindOne())
+		var ok bool
+		if list.First, ok = types.DecodeListCursor(*cursor); !ok {
+			// legacy cursor; find the ordinal index of the referenced transaction
+			list.First, err = db.ercTrxListBorderPk(col,
+				bson.D{{types.FiErc20TransactionPk, *cursor}},
+				options.FindOne())
+		}
 	}
 
 	// check the error
@@ -174,7 +179,7 @@ func (db *MongoDbBridge) ercTrxListBorderPk(col *mongo.Collection, filter bson.D
 	opt.SetProjection(bson.D{{types.FiErc20TransactionOrdinal, true}})
 
 	// try to decode
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(db.opContext(), filter, opt)
 	err := sr.Decode(&row)
 	if err != nil {
 		return 0, err
@@ -231,7 +236,7 @@ func (db *MongoDbBridge) ercTrxListOptions(count int32) *options.FindOptions {
 // ercTrxListLoad load the initialized list of ERC20 transactions from database.
 func (db *MongoDbBridge) ercTrxListLoad(col *mongo.Collection, cursor *string, count int32, list *types.Erc20TransactionList) (err error) {
 	// get the context for loader
-	ctx := context.Background()
+	ctx := db.opContext()
 
 	// load the data
 	ld, err := col.Find(ctx, db.ercTrxListFilter(cursor, count, list), db.ercTrxListOptions(count))
@@ -310,3 +315,52 @@ func (db *MongoDbBridge) Erc20Transactions(cursor *string, count int32, filter *
 	}
 	return list, nil
 }
+
+// Erc20Approvals loads the latest approval of each token and spender pair
+// granted by the given owner.
+func (db *MongoDbBridge) Erc20Approvals(owner *common.Address) ([]*types.Erc20Transaction, error) {
+	// create command pipeline
+	pipe := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: types.FiErc20TransactionSender, Value: owner.String()},
+			{Key: types.FiErc20TransactionType, Value: types.ERC20TrxTypeApproval},
+			{Key: types.FiErc20TransactionTokenType, Value: types.AccountTypeERC20Token},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: types.FiErc20TransactionOrdinal, Value: -1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "tok", Value: "$" + types.FiErc20TransactionToken},
+				{Key: "to", Value: "$" + types.FiErc20TransactionRecipient},
+			}},
+			{Key: "doc", Value: bson.D{{Key: "$first", Value: "$$ROOT"}}},
+		}}},
+		{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: "$doc"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: types.FiErc20TransactionOrdinal, Value: -1}}}},
+	}
+
+	// query collection
+	col := db.client.Database(db.dbName).Collection(colErcTransactions)
+	cursor, err := col.Aggregate(db.opContext(), pipe)
+	if err != nil {
+		db.log.Errorf("can not load ERC20 approvals of %s; %s", owner.String(), err.Error())
+		return nil, err
+	}
+
+	// make sure to close the cursor
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.Erc20Transaction, 0)
+	for cursor.Next(db.opContext()) {
+		var trx types.Erc20Transaction
+		if err := cursor.Decode(&trx); err != nil {
+			db.log.Errorf("can not decode ERC20 approval; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &trx)
+	}
+	return list, nil
+}
@@ -0,0 +1,219 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// colFMintAccounts represents the name of the fMint accounts collection in database.
+	colFMintAccounts = "fmint_accounts"
+
+	// colFMintStats represents the name of the fMint stats snapshots collection in database.
+	colFMintStats = "fmint_stats"
+
+	// fiFMintAccountFirstSeen is the name of the field of the time the account was first seen.
+	fiFMintAccountFirstSeen = "first"
+)
+
+// initFMintAccountsCollection initializes the fMint accounts collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initFMintAccountsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiFMintAccountActive, 1}, {types.FiFMintAccountOrdinal, 1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for fmint accounts collection; %s", err.Error())
+	}
+	db.log.Debugf("fmint accounts collection initialized")
+}
+
+// AddFMintAccount stores the address of an fMint account in the database, if it's not known yet.
+func (db *MongoDbBridge) AddFMintAccount(addr *common.Address) error {
+	col := db.client.Database(db.dbName).Collection(colFMintAccounts)
+
+	// insert the account only once; keep the first seen time
+	if _, err := col.UpdateOne(
+		db.opContext(),
+		bson.D{{types.FiFMintAccountPk, addr.String()}},
+		bson.D{{"$setOnInsert", bson.D{{fiFMintAccountFirstSeen, time.Now().UTC()}}}},
+		options.Update().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store fMint account %s; %s", addr.String(), err.Error())
+		return err
+	}
+
+	// make sure the collection is initialized
+	if db.initFMintAccounts != nil {
+		db.initFMintAccounts.Do(func() { db.initFMintAccountsCollection(col); db.initFMintAccounts = nil })
+	}
+	return nil
+}
+
+// FMintAccountsCount calculates total number of indexed fMint accounts in the database.
+func (db *MongoDbBridge) FMintAccountsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colFMintAccounts))
+}
+
+// SetFMintAccountState updates the last known state of an indexed fMint account.
+func (db *MongoDbBridge) SetFMintAccountState(fa *types.FMintAccountState) error {
+	col := db.client.Database(db.dbName).Collection(colFMintAccounts)
+
+	row := fa.BsonState()
+	if _, err := col.UpdateOne(db.opContext(), bson.D{{types.FiFMintAccountPk, row.Address}}, bson.D{{"$set", bson.D{
+		{"col", row.Collateral},
+		{"debt", row.Debt},
+		{"col_val", row.CollateralValue},
+		{"debt_val", row.DebtValue},
+		{types.FiFMintAccountActive, row.Active},
+		{types.FiFMintAccountRatio, row.Ratio4},
+		{types.FiFMintAccountOrdinal, row.Ordinal},
+		{"upd", row.Updated},
+	}}}); err != nil {
+		db.log.Errorf("can not update fMint account %s; %s", row.Address, err.Error())
+		return err
+	}
+	return nil
+}
+
+// FMintAccountsBelowRatio loads a page of the states of fMint accounts with a debt
+// and the collateral ratio below the given ratio, sorted from the lowest ratio.
+// The page starts after the given cursor, if any.
+func (db *MongoDbBridge) FMintAccountsBelowRatio(ratio4 uint64, cursor *string, count int32) (*types.FMintAccountStateList, error) {
+	ctx := db.opContext()
+	col := db.client.Database(db.dbName).Collection(colFMintAccounts)
+
+	filter := bson.D{
+		{types.FiFMintAccountActive, true},
+		{types.FiFMintAccountRatio, bson.D{{"$lt", int64(ratio4)}}},
+	}
+
+	// count all the matching accounts
+	total, err := col.CountDocuments(ctx, filter)
+	if err != nil {
+		db.log.Errorf("can not count fMint accounts at risk; %s", err.Error())
+		return nil, err
+	}
+
+	// continue after the cursor
+	if cursor != nil {
+		ix, ok := types.DecodeListCursor(*cursor)
+		if !ok {
+			return nil, fmt.Errorf("invalid fMint accounts cursor %s", *cursor)
+		}
+		filter = append(filter, bson.E{Key: types.FiFMintAccountOrdinal, Value: bson.D{{"$gt", int64(ix)}}})
+	}
+
+	// load one more to detect the list end
+	ld, err := col.Find(ctx, filter, options.Find().SetSort(bson.D{{types.FiFMintAccountOrdinal, 1}}).SetLimit(int64(count)+1))
+	if err != nil {
+		db.log.Errorf("can not load fMint accounts at risk; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing fMint accounts cursor; %s", err.Error())
+		}
+	}()
+
+	list := types.FMintAccountStateList{Collection: make([]*types.FMintAccountState, 0), Total: uint64(total), IsEnd: true}
+	for ld.Next(ctx) {
+		if len(list.Collection) == int(count) {
+			list.IsEnd = false
+			break
+		}
+
+		var row types.FMintAccountState
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode fMint account state; %s", err.Error())
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+	return &list, nil
+}
+
+// FMintAccounts loads the addresses of all the indexed fMint accounts.
+func (db *MongoDbBridge) FMintAccounts() ([]common.Address, error) {
+	ctx := db.opContext()
+	col := db.client.Database(db.dbName).Collection(colFMintAccounts)
+
+	ld, err := col.Find(ctx, bson.D{}, options.Find().SetProjection(bson.D{{types.FiFMintAccountPk, true}}))
+	if err != nil {
+		db.log.Errorf("can not load fMint accounts; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing fMint accounts cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]common.Address, 0)
+	for ld.Next(ctx) {
+		var row struct {
+			Addr string `bson:"_id"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode fMint account; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, common.HexToAddress(row.Addr))
+	}
+	return list, nil
+}
+
+// AddFMintStats stores a snapshot of the fMint protocol metrics in the database.
+func (db *MongoDbBridge) AddFMintStats(fs *types.FMintStats) error {
+	col := db.client.Database(db.dbName).Collection(colFMintStats)
+
+	if _, err := col.ReplaceOne(
+		db.opContext(),
+		bson.D{{types.FiFMintStatsPk, fs.Time}},
+		fs,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store fMint stats; %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// LastFMintStats loads the latest snapshot of the fMint protocol metrics.
+// It returns nil if no snapshot has been aggregated yet.
+func (db *MongoDbBridge) LastFMintStats() (*types.FMintStats, error) {
+	col := db.client.Database(db.dbName).Collection(colFMintStats)
+
+	// find the newest record
+	sr := col.FindOne(db.opContext(), bson.D{}, options.FindOne().SetSort(bson.D{{types.FiFMintStatsPk, -1}}))
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load the latest fMint stats; %s", sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	// decode the record
+	var row types.FMintStats
+	if err := sr.Decode(&row); err != nil {
+		db.log.Errorf("can not decode the latest fMint stats; %s", err.Error())
+		return nil, err
+	}
+	return &row, nil
+}
@@ -0,0 +1,120 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// colFtmSupply represents the name of the FTM supply history collection in database.
+	colFtmSupply = "ftm_supply"
+
+	// ftmSupplyHistoryLimit is the max number of supply records loaded at once.
+	ftmSupplyHistoryLimit = 1000
+)
+
+// initFtmSupplyCollection initializes the FTM supply collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initFtmSupplyCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiFtmSupplyTimeStamp, -1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for ftm supply collection; %s", err.Error())
+	}
+	db.log.Debugf("ftm supply collection initialized")
+}
+
+// AddFtmSupply stores the FTM supply record of an epoch in the database.
+func (db *MongoDbBridge) AddFtmSupply(fs *types.FtmSupply) error {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colFtmSupply)
+
+	// try to do the upsert
+	if _, err := col.ReplaceOne(
+		db.opContext(),
+		bson.D{{types.FiFtmSupplyPk, int64(fs.Epoch)}},
+		fs,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store supply of epoch #%d; %s", fs.Epoch, err.Error())
+		return err
+	}
+
+	// make sure the collection is initialized
+	if db.initFtmSupply != nil {
+		db.initFtmSupply.Do(func() { db.initFtmSupplyCollection(col); db.initFtmSupply = nil })
+	}
+	return nil
+}
+
+// FtmSupplyCount calculates total number of FTM supply records in the database.
+func (db *MongoDbBridge) FtmSupplyCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colFtmSupply))
+}
+
+// LastFtmSupply loads the FTM supply record of the latest tracked epoch.
+// It returns nil if no supply has been tracked yet.
+func (db *MongoDbBridge) LastFtmSupply() (*types.FtmSupply, error) {
+	col := db.client.Database(db.dbName).Collection(colFtmSupply)
+
+	// find the newest record
+	sr := col.FindOne(db.opContext(), bson.D{}, options.FindOne().SetSort(bson.D{{types.FiFtmSupplyPk, -1}}))
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load the latest supply record; %s", sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	// decode the record
+	var row types.FtmSupply
+	if err := sr.Decode(&row); err != nil {
+		db.log.Errorf("can not decode the latest supply record; %s", err.Error())
+		return nil, err
+	}
+	return &row, nil
+}
+
+// FtmSupplyHistory loads FTM supply records of the given range of epochs
+// sorted from the oldest to the newest.
+func (db *MongoDbBridge) FtmSupplyHistory(from uint64, to uint64) ([]*types.FtmSupply, error) {
+	// get the collection and context
+	ctx := db.opContext()
+	col := db.client.Database(db.dbName).Collection(colFtmSupply)
+
+	// pull the data; make sure there is a limit to the range
+	ld, err := col.Find(ctx, bson.D{{types.FiFtmSupplyPk, bson.D{{"$gte", int64(from)}, {"$lte", int64(to)}}}},
+		options.Find().SetSort(bson.D{{types.FiFtmSupplyPk, 1}}).SetLimit(ftmSupplyHistoryLimit))
+	if err != nil {
+		db.log.Errorf("can not load supply history; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing supply history cursor; %s", err.Error())
+		}
+	}()
+
+	// load the list
+	list := make([]*types.FtmSupply, 0)
+	for ld.Next(ctx) {
+		var row types.FtmSupply
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode supply record; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
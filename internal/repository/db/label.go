@@ -0,0 +1,94 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colLabels represents the name of the address labels collection.
+const colLabels = "labels"
+
+// initLabelsCollection initializes the address labels collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initLabelsCollection(col *mongo.Collection) {
+	// prepare index models; the address is the primary key already
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiLabelCategory, 1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for labels collection; %s", err.Error())
+	}
+
+	// log we done that
+	db.log.Debugf("labels collection initialized")
+}
+
+// AddressLabel loads the label of the given address from the database.
+// It returns nil if the address does not have any label assigned.
+func (db *MongoDbBridge) AddressLabel(addr *common.Address) (*types.AddressLabel, error) {
+	// get the collection for labels
+	col := db.client.Database(db.dbName).Collection(colLabels)
+
+	// try to find the label in the database
+	sr := col.FindOne(db.opContext(), bson.D{{types.FiLabelPk, addr.String()}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load label of %s; %s", addr.String(), sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	// try to decode
+	var al types.AddressLabel
+	if err := sr.Decode(&al); err != nil {
+		db.log.Errorf("can not decode label of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	return &al, nil
+}
+
+// StoreAddressLabel inserts, or replaces the given address label in the database.
+func (db *MongoDbBridge) StoreAddressLabel(al *types.AddressLabel) error {
+	// get the collection for labels
+	col := db.client.Database(db.dbName).Collection(colLabels)
+
+	// replace the label, or insert a new one
+	if _, err := col.ReplaceOne(db.opContext(),
+		bson.D{{types.FiLabelPk, al.Address.String()}},
+		al, options.Replace().SetUpsert(true)); err != nil {
+		db.log.Criticalf("can not store label of %s; %s", al.Address.String(), err.Error())
+		return err
+	}
+
+	// make sure labels collection is initialized
+	if db.initLabels != nil {
+		db.initLabels.Do(func() { db.initLabelsCollection(col); db.initLabels = nil })
+	}
+	return nil
+}
+
+// RemoveAddressLabel removes the label of the given address from the database.
+func (db *MongoDbBridge) RemoveAddressLabel(addr *common.Address) error {
+	// get the collection for labels
+	col := db.client.Database(db.dbName).Collection(colLabels)
+
+	// delete the label
+	if _, err := col.DeleteOne(db.opContext(), bson.D{{types.FiLabelPk, addr.String()}}); err != nil {
+		db.log.Criticalf("can not remove label of %s; %s", addr.String(), err.Error())
+		return err
+	}
+	return nil
+}
+
+// AddressLabelsCount calculates total number of address labels in the database.
+func (db *MongoDbBridge) AddressLabelsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colLabels))
+}
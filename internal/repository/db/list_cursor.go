@@ -0,0 +1,38 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// decimalCursorOrdinal decodes the ordinal index of a list item from the given cursor.
+// Opaque list cursors are expected, legacy decimal cursors are still accepted.
+func decimalCursorOrdinal(cursor string) (uint64, error) {
+	if ix, ok := types.DecodeListCursor(cursor); ok {
+		return ix, nil
+	}
+
+	ix, err := strconv.ParseUint(cursor, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor value; %s", err.Error())
+	}
+	return ix, nil
+}
+
+// hexCursorOrdinal decodes the ordinal index of a list item from the given cursor.
+// Opaque list cursors are expected, legacy hex cursors are still accepted.
+func hexCursorOrdinal(cursor string) (uint64, error) {
+	if ix, ok := types.DecodeListCursor(cursor); ok {
+		return ix, nil
+	}
+
+	ix, err := hexutil.DecodeUint64(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor value; %s", err.Error())
+	}
+	return ix, nil
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// coAccount is the name of the accounts collection.
+	coAccount = "account"
+
+	// fiAccountFunder is the name of the account funder field.
+	fiAccountFunder = "fnd"
+
+	// fiAccountDeployer is the name of the contract account deployer field.
+	fiAccountDeployer = "dpl"
+)
+
+// accountLinkIndex makes sure accounts can be searched by their funder and deployer.
+// New collections get the indexes on init, existing collections created before
+// the related addresses were introduced need them added.
+func accountLinkIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(coAccount).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{fiAccountFunder, 1}}},
+		{Keys: bson.D{{fiAccountDeployer, 1}}},
+	})
+	return err
+}
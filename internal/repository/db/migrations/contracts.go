@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// coContract is the name of the smart contracts collection.
+	coContract = "contract"
+
+	// fiContractSource is the name of the contract source code field.
+	fiContractSource = "src"
+
+	// fiContractCodeHash is the name of the contract runtime byte code hash field.
+	fiContractCodeHash = "code_h"
+
+	// fiContractMatch is the name of the contract verification match level field.
+	fiContractMatch = "match"
+
+	// fiContractVerifiedFrom is the name of the field referencing the contract
+	// the validation was copied from.
+	fiContractVerifiedFrom = "vfrom"
+)
+
+// contractCodeHashIndex makes sure contracts can be searched by the hash of their
+// runtime byte code. New collections get the index on init, existing collections
+// created before the code hash was introduced need it added.
+func contractCodeHashIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(coContract).Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{fiContractCodeHash, 1}}})
+	return err
+}
+
+// contractMatchLevel sets the verification match level of contracts validated
+// before the level was recorded. The validation compared the byte code
+// with the compiler metadata removed, so the level is known.
+func contractMatchLevel(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(coContract).UpdateMany(ctx, bson.D{
+		{fiContractSource, bson.D{{"$nin", bson.A{"", nil}}}},
+		{fiContractMatch, bson.D{{"$in", bson.A{"", nil}}}},
+		{fiContractVerifiedFrom, nil},
+	}, bson.D{{"$set", bson.D{{fiContractMatch, types.ContractMatchMetadataStripped}}}})
+	return err
+}
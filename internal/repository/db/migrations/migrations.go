@@ -0,0 +1,127 @@
+// Package migrations implements versioned transformations of the off-chain database
+// applied when the API server is upgraded. Applied migrations are recorded
+// in a meta collection so each migration runs only once.
+package migrations
+
+import (
+	"context"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// coMigrations is the name of the collection recording applied migrations.
+	coMigrations = "migrations"
+
+	// fiMigrationPk is the name of the primary key field of the migration record.
+	fiMigrationPk = "_id"
+
+	// fiMigrationApplied is the name of the field with the time the migration was applied.
+	fiMigrationApplied = "applied"
+
+	// fiMigrationDuration is the name of the field with the duration of the migration in milliseconds.
+	fiMigrationDuration = "took"
+)
+
+// Migration represents a single versioned transformation of the database.
+// Migrations must be idempotent; API peers sharing the database may start
+// at the same time and a failed migration is re-tried on the next start.
+type Migration struct {
+	// ID identifies the migration; it's prefixed with the sequence number of the migration.
+	ID string
+
+	// Description explains what the migration does.
+	Description string
+
+	// Up applies the migration to the database.
+	Up func(ctx context.Context, db *mongo.Database) error
+}
+
+// list is the ordered list of known migrations.
+// New migrations are always appended to the end of the list.
+var list = []Migration{
+	{
+		ID:          "0001_contract_code_hash_index",
+		Description: "index contracts by the hash of their runtime byte code",
+		Up:          contractCodeHashIndex,
+	},
+	{
+		ID:          "0002_contract_match_level",
+		Description: "backfill verification match level of previously validated contracts",
+		Up:          contractMatchLevel,
+	},
+	{
+		ID:          "0003_account_link_index",
+		Description: "index accounts by their funder and deployer",
+		Up:          accountLinkIndex,
+	},
+}
+
+// Run applies all the migrations not applied to the database yet, in order.
+// It stops on the first failed migration so the following ones don't run
+// against an inconsistent database.
+func Run(ctx context.Context, db *mongo.Database, log logger.Logger) error {
+	col := db.Collection(coMigrations)
+
+	// what migrations do we have already
+	done, err := applied(ctx, col, log)
+	if err != nil {
+		log.Errorf("can not load applied migrations; %s", err.Error())
+		return err
+	}
+
+	for _, m := range list {
+		if done[m.ID] {
+			continue
+		}
+
+		log.Noticef("applying database migration %s; %s", m.ID, m.Description)
+		start := time.Now()
+		if err := m.Up(ctx, db); err != nil {
+			log.Criticalf("database migration %s failed; %s", m.ID, err.Error())
+			return fmt.Errorf("migration %s failed; %s", m.ID, err.Error())
+		}
+
+		// record the migration
+		took := time.Since(start)
+		if _, err := col.UpdateOne(ctx, bson.D{{fiMigrationPk, m.ID}}, bson.D{{"$set", bson.D{
+			{fiMigrationApplied, time.Now().UTC()},
+			{fiMigrationDuration, took.Milliseconds()},
+		}}}, options.Update().SetUpsert(true)); err != nil {
+			log.Errorf("can not record migration %s; %s", m.ID, err.Error())
+			return err
+		}
+		log.Noticef("database migration %s applied in %s", m.ID, took)
+	}
+	return nil
+}
+
+// applied loads the set of migrations already applied to the database.
+func applied(ctx context.Context, col *mongo.Collection, log logger.Logger) (map[string]bool, error) {
+	cur, err := col.Find(ctx, bson.D{}, options.Find().SetProjection(bson.D{{fiMigrationPk, true}}))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cur.Close(ctx); err != nil {
+			log.Errorf("error closing migrations cursor; %s", err.Error())
+		}
+	}()
+
+	done := make(map[string]bool)
+	for cur.Next(ctx) {
+		var row struct {
+			ID string `bson:"_id"`
+		}
+		if err := cur.Decode(&row); err != nil {
+			return nil, err
+		}
+		done[row.ID] = true
+	}
+	return done, cur.Err()
+}
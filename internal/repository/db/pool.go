@@ -0,0 +1,58 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"fantom-api-graphql/internal/types"
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// poolStats collects the statistics of the database connection pool
+// from the driver pool events.
+type poolStats struct {
+	created     int64
+	closed      int64
+	checkedOut  int64
+	checkedIn   int64
+	checkFailed int64
+	cleared     int64
+}
+
+// monitor creates the driver pool monitor updating the statistics.
+func (ps *poolStats) monitor() *event.PoolMonitor {
+	return &event.PoolMonitor{Event: func(ev *event.PoolEvent) {
+		switch ev.Type {
+		case event.ConnectionCreated:
+			atomic.AddInt64(&ps.created, 1)
+		case event.ConnectionClosed:
+			atomic.AddInt64(&ps.closed, 1)
+		case event.GetSucceeded:
+			atomic.AddInt64(&ps.checkedOut, 1)
+		case event.ConnectionReturned:
+			atomic.AddInt64(&ps.checkedIn, 1)
+		case event.GetFailed:
+			atomic.AddInt64(&ps.checkFailed, 1)
+		case event.PoolCleared:
+			atomic.AddInt64(&ps.cleared, 1)
+		}
+	}}
+}
+
+// PoolStats provides the statistics of the database connection pool.
+func (db *MongoDbBridge) PoolStats() types.DbPoolStats {
+	ps := db.pool
+	open := atomic.LoadInt64(&ps.created) - atomic.LoadInt64(&ps.closed)
+	out := atomic.LoadInt64(&ps.checkedOut)
+	inUse := out - atomic.LoadInt64(&ps.checkedIn)
+
+	return types.DbPoolStats{
+		Open:          open,
+		InUse:         inUse,
+		Idle:          open - inUse,
+		MaxSize:       int64(db.maxPool),
+		CheckOuts:     out,
+		CheckOutFails: atomic.LoadInt64(&ps.checkFailed),
+		Cleared:       atomic.LoadInt64(&ps.cleared),
+	}
+}
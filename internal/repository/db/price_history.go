@@ -0,0 +1,92 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"strings"
+	"time"
+)
+
+// colPriceHistory represents the name of the price history collection in database.
+const colPriceHistory = "price_history"
+
+// initPriceHistoryCollection initializes the price history collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initPriceHistoryCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiPriceSampleSymbol, 1}, {types.FiPriceSampleTime, -1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for price history collection; %s", err.Error())
+	}
+	db.log.Debugf("price history collection initialized")
+}
+
+// AddPriceSample stores the price sample in the price history.
+// A sample recorded in the same hour replaces the previous one.
+func (db *MongoDbBridge) AddPriceSample(ps *types.PriceSample) error {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colPriceHistory)
+
+	// try to do the upsert
+	if _, err := col.ReplaceOne(
+		db.opContext(),
+		bson.D{{types.FiPriceSamplePk, ps.Pk()}},
+		ps,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store price sample; %s", err.Error())
+		return err
+	}
+
+	// make sure price history collection is initialized
+	if db.initPriceHistory != nil {
+		db.initPriceHistory.Do(func() { db.initPriceHistoryCollection(col); db.initPriceHistory = nil })
+	}
+	return nil
+}
+
+// PriceHistoryCount calculates total number of price samples in the database.
+func (db *MongoDbBridge) PriceHistoryCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colPriceHistory))
+}
+
+// PriceSampleBefore loads the latest price sample of the target symbol recorded
+// at, or before, the given time. It returns nil if no such sample is known.
+func (db *MongoDbBridge) PriceSampleBefore(sym string, ts time.Time) (*types.PriceSample, error) {
+	return db.priceSample(sym, bson.D{{"$lte", ts}}, -1)
+}
+
+// PriceSampleAfter loads the earliest price sample of the target symbol recorded
+// at, or after, the given time. It returns nil if no such sample is known.
+func (db *MongoDbBridge) PriceSampleAfter(sym string, ts time.Time) (*types.PriceSample, error) {
+	return db.priceSample(sym, bson.D{{"$gte", ts}}, 1)
+}
+
+// priceSample loads the first price sample of the target symbol matching the time condition
+// in the given sort direction of the sample time.
+func (db *MongoDbBridge) priceSample(sym string, cond bson.D, sort int) (*types.PriceSample, error) {
+	col := db.client.Database(db.dbName).Collection(colPriceHistory)
+
+	sr := col.FindOne(db.opContext(), bson.D{
+		{types.FiPriceSampleSymbol, strings.ToUpper(sym)},
+		{types.FiPriceSampleTime, cond},
+	}, options.FindOne().SetSort(bson.D{{types.FiPriceSampleTime, sort}}))
+
+	var ps types.PriceSample
+	if err := sr.Decode(&ps); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load %s price sample; %s", sym, err.Error())
+		return nil, err
+	}
+	return &ps, nil
+}
@@ -46,7 +46,7 @@ func (db *MongoDbBridge) AddRewardClaim(rc *types.RewardClaim) error {
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), rc); err != nil {
+	if _, err := col.InsertOne(db.opContext(), rc); err != nil {
 		db.log.Critical(err)
 		return err
 	}
@@ -61,7 +61,7 @@ func (db *MongoDbBridge) AddRewardClaim(rc *types.RewardClaim) error {
 // isDelegationKnown checks if the given delegation exists in the database.
 func (db *MongoDbBridge) isRewardClaimKnown(col *mongo.Collection, rc *types.RewardClaim) bool {
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(db.opContext(), bson.D{
 		{types.FiRewardClaimPk, rc.Pk()},
 	}, options.FindOne().SetProjection(bson.D{
 		{types.FiRewardClaimPk, true},
@@ -99,7 +99,7 @@ func (db *MongoDbBridge) rewListInit(col *mongo.Collection, cursor *string, coun
 	}
 
 	// find how many transactions do we have in the database
-	total, err := col.CountDocuments(context.Background(), *filter)
+	total, err := col.CountDocuments(db.opContext(), *filter)
 	if err != nil {
 		db.log.Errorf("can not count reward claims")
 		return nil, err
@@ -147,9 +147,13 @@ func (db *MongoDbBridge) rewListCollectRangeMarks(col *mongo.Collection, list *t
 
 	} else if cursor != nil {
 		// the cursor itself is the starting point
-		list.First, err = db.rewListBorderPk(col,
-			bson.D{{types.FiRewardClaimPk, *cursor}},
-			options.FindOne())
+		var ok bool
+		if list.First, ok = types.DecodeListCursor(*cursor); !ok {
+			// legacy cursor; find the ordinal index of the referenced claim
+			list.First, err = db.rewListBorderPk(col,
+				bson.D{{types.FiRewardClaimPk, *cursor}},
+				options.FindOne())
+		}
 	}
 
 	// check the error
@@ -174,7 +178,7 @@ func (db *MongoDbBridge) rewListBorderPk(col *mongo.Collection, filter bson.D, o
 	opt.SetProjection(bson.D{{types.FiRewardClaimOrdinal, true}})
 
 	// try to decode
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(db.opContext(), filter, opt)
 	err := sr.Decode(&row)
 	if err != nil {
 		return 0, err
@@ -231,7 +235,7 @@ func (db *MongoDbBridge) rewListOptions(count int32) *options.FindOptions {
 // rewListLoad load the initialized list of reward claims from database.
 func (db *MongoDbBridge) rewListLoad(col *mongo.Collection, cursor *string, count int32, list *types.RewardClaimsList) (err error) {
 	// get the context for loader
-	ctx := context.Background()
+	ctx := db.opContext()
 
 	// load the data
 	ld, err := col.Find(ctx, db.rewListFilter(cursor, count, list), db.rewListOptions(count))
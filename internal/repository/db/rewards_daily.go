@@ -0,0 +1,101 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// coRewardsDaily represents the name of the daily rewards aggregation collection.
+	coRewardsDaily = "rewards_daily"
+
+	// fiRewardsDailyPk name of the primary key of the daily rewards row.
+	fiRewardsDailyPk = "_id"
+)
+
+// RewardsDailyList loads a range of daily reward claims aggregations from the database.
+func (db *MongoDbBridge) RewardsDailyList(from *time.Time, to *time.Time) ([]*types.DailyRewards, error) {
+	// log what we do
+	db.log.Debugf("loading daily rewards between %s and %s", from.String(), to.String())
+
+	// get the collection and context
+	ctx := db.opContext()
+	col := db.analyticsCollection(coRewardsDaily)
+
+	// pull the data; make sure there is a limit to the range
+	ld, err := col.Find(ctx, trxDailyFlowListFilter(from, to), options.Find().SetSort(bson.D{{fiRewardsDailyPk, 1}}).SetLimit(365))
+	if err != nil {
+		db.log.Errorf("can not load daily rewards; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing daily rewards list cursor; %s", err.Error())
+		}
+	}()
+
+	// load the list
+	list := make([]*types.DailyRewards, 0)
+	for ld.Next(ctx) {
+		var row types.DailyRewards
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode daily rewards row; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// RewardsDailyUpdate performs an update on the daily rewards aggregation
+// for the reward claims made after the given time.
+func (db *MongoDbBridge) RewardsDailyUpdate(from time.Time) error {
+	// log what we do
+	db.log.Noticef("updating daily rewards after %s", from)
+
+	// we aggregate reward claims
+	col := db.client.Database(db.dbName).Collection(colRewards)
+	cr, err := col.Aggregate(db.opContext(), mongo.Pipeline{
+		{{"$match", bson.D{
+			{types.FiRewardClaimedTimeStamp, bson.D{{"$gte", from}}},
+		}}},
+		{{"$group", bson.D{
+			{"_id", bson.D{
+				{"$dateToString", bson.D{
+					{"format", "%Y-%m-%d"},
+					{"date", "$" + types.FiRewardClaimedTimeStamp},
+				}},
+			}},
+			{"value", bson.D{{"$sum", "$" + types.FiRewardClaimedValue}}},
+			{"claims", bson.D{{"$sum", 1}}},
+		}}},
+		{{"$project", bson.D{
+			{"stamp", bson.D{{"$toDate", "$_id"}}},
+			{"value", 1},
+			{"claims", 1},
+		}}},
+		{{"$merge", bson.D{
+			{"into", coRewardsDaily},
+			{"on", "_id"},
+			{"whenMatched", "replace"},
+			{"whenNotMatched", "insert"},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not update daily rewards; %s", err.Error())
+		return err
+	}
+
+	// close the cursor, we don't really need the data
+	if err := cr.Close(context.Background()); err != nil {
+		db.log.Errorf("can not close aggregate cursor; %s", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colRiskFlags represents the name of the moderation risk flags collection.
+const colRiskFlags = "risk_flags"
+
+// RiskFlag loads the risk flag of the given address from the database.
+// It returns nil if the address is not flagged.
+func (db *MongoDbBridge) RiskFlag(addr *common.Address) (*types.RiskFlag, error) {
+	// get the collection for risk flags
+	col := db.client.Database(db.dbName).Collection(colRiskFlags)
+
+	// try to find the flag in the database
+	sr := col.FindOne(db.opContext(), bson.D{{types.FiRiskFlagPk, addr.String()}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load risk flag of %s; %s", addr.String(), sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	// try to decode
+	var rf types.RiskFlag
+	if err := sr.Decode(&rf); err != nil {
+		db.log.Errorf("can not decode risk flag of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	return &rf, nil
+}
+
+// StoreRiskFlag inserts, or replaces the given risk flag in the database.
+func (db *MongoDbBridge) StoreRiskFlag(rf *types.RiskFlag) error {
+	// get the collection for risk flags
+	col := db.client.Database(db.dbName).Collection(colRiskFlags)
+
+	// replace the flag, or insert a new one
+	if _, err := col.ReplaceOne(db.opContext(),
+		bson.D{{types.FiRiskFlagPk, rf.Address.String()}},
+		rf, options.Replace().SetUpsert(true)); err != nil {
+		db.log.Criticalf("can not store risk flag of %s; %s", rf.Address.String(), err.Error())
+		return err
+	}
+	return nil
+}
+
+// RemoveRiskFlag removes the risk flag of the given address from the database.
+func (db *MongoDbBridge) RemoveRiskFlag(addr *common.Address) error {
+	// get the collection for risk flags
+	col := db.client.Database(db.dbName).Collection(colRiskFlags)
+
+	// delete the flag
+	if _, err := col.DeleteOne(db.opContext(), bson.D{{types.FiRiskFlagPk, addr.String()}}); err != nil {
+		db.log.Criticalf("can not remove risk flag of %s; %s", addr.String(), err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// coServiceLocks represents the name of the service locks collection.
+	coServiceLocks = "service_locks"
+
+	// fiServiceLockPk is the name of the primary key field of the service lock,
+	// the name of the locked service.
+	fiServiceLockPk = "_id"
+
+	// fiServiceLockHolder is the name of the field of the lock holder identification.
+	fiServiceLockHolder = "holder"
+
+	// fiServiceLockExpires is the name of the field of the lock expiration time.
+	fiServiceLockExpires = "exp"
+
+	// coServiceResults represents the name of the collection of service results
+	// shared by the leading API instance with its peers.
+	coServiceResults = "service_results"
+
+	// fiServiceResultPk is the name of the primary key field of the service result,
+	// the name of the service.
+	fiServiceResultPk = "_id"
+
+	// fiServiceResultData is the name of the field of the encoded service result.
+	fiServiceResultData = "data"
+
+	// fiServiceResultUpdated is the name of the field of the result update time.
+	fiServiceResultUpdated = "upd"
+)
+
+// AcquireServiceLock tries to acquire, or renew, the lock of the given service for the holder.
+// The lock is granted if it's free, expired, or already held by the same holder.
+// Returns FALSE if a live lock is held by another holder.
+func (db *MongoDbBridge) AcquireServiceLock(name string, holder string, ttl time.Duration) (bool, error) {
+	col := db.client.Database(db.dbName).Collection(coServiceLocks)
+	now := time.Now().UTC()
+
+	// match the lock if we hold it, or if it expired; a live lock of another holder
+	// does not match and the upsert fails on the duplicate primary key
+	_, err := col.UpdateOne(db.opContext(), bson.D{
+		{fiServiceLockPk, name},
+		{"$or", bson.A{
+			bson.D{{fiServiceLockHolder, holder}},
+			bson.D{{fiServiceLockExpires, bson.D{{"$lt", now}}}},
+		}},
+	}, bson.D{{"$set", bson.D{
+		{fiServiceLockHolder, holder},
+		{fiServiceLockExpires, now.Add(ttl)},
+	}}}, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+
+		db.log.Errorf("can not acquire lock of %s; %s", name, err.Error())
+		return false, err
+	}
+	return true, nil
+}
+
+// ReleaseServiceLock releases the lock of the given service if it's held by the holder.
+func (db *MongoDbBridge) ReleaseServiceLock(name string, holder string) error {
+	col := db.client.Database(db.dbName).Collection(coServiceLocks)
+
+	_, err := col.DeleteOne(db.opContext(), bson.D{
+		{fiServiceLockPk, name},
+		{fiServiceLockHolder, holder},
+	})
+	if err != nil {
+		db.log.Errorf("can not release lock of %s; %s", name, err.Error())
+	}
+	return err
+}
+
+// StoreServiceResult stores the latest result of the given service shared with peer API instances.
+func (db *MongoDbBridge) StoreServiceResult(name string, data []byte) error {
+	col := db.client.Database(db.dbName).Collection(coServiceResults)
+
+	_, err := col.UpdateOne(db.opContext(), bson.D{{fiServiceResultPk, name}}, bson.D{{"$set", bson.D{
+		{fiServiceResultData, data},
+		{fiServiceResultUpdated, time.Now().UTC()},
+	}}}, options.Update().SetUpsert(true))
+	if err != nil {
+		db.log.Errorf("can not store result of %s; %s", name, err.Error())
+	}
+	return err
+}
+
+// ServiceResult loads the latest result of the given service shared by the leading API instance.
+// It returns nil if the service did not share any result yet.
+func (db *MongoDbBridge) ServiceResult(name string) ([]byte, error) {
+	col := db.client.Database(db.dbName).Collection(coServiceResults)
+
+	var row struct {
+		Data []byte `bson:"data"`
+	}
+	err := col.FindOne(db.opContext(), bson.D{{fiServiceResultPk, name}}).Decode(&row)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load result of %s; %s", name, err.Error())
+		return nil, err
+	}
+	return row.Data, nil
+}
@@ -0,0 +1,369 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// snapshotManifestFile represents the name of the file describing the snapshot content.
+const snapshotManifestFile = "manifest.json"
+
+// snapshotBatchSize represents the number of documents inserted at once on the snapshot import.
+const snapshotBatchSize = 1000
+
+// snapshotMaxDocumentSize represents the max size of a BSON document accepted on import.
+const snapshotMaxDocumentSize = 16 * 1024 * 1024
+
+const (
+	// SnapshotFormatBSON represents snapshot of raw BSON documents.
+	SnapshotFormatBSON = "bson"
+
+	// SnapshotFormatJSON represents snapshot of extended JSON documents, one per line.
+	SnapshotFormatJSON = "json"
+)
+
+// SnapshotManifest describes the content of an off-chain database snapshot.
+type SnapshotManifest struct {
+	Database    string                `json:"database"`
+	Format      string                `json:"format"`
+	Created     time.Time             `json:"created"`
+	Collections []*SnapshotCollection `json:"collections"`
+}
+
+// SnapshotCollection describes a single collection stored in the snapshot.
+type SnapshotCollection struct {
+	Name      string            `json:"name"`
+	Documents int64             `json:"documents"`
+	Indexes   []json.RawMessage `json:"indexes"`
+}
+
+// fileName provides the name of the collection data file in the snapshot.
+func (sc *SnapshotCollection) fileName(format string) string {
+	return fmt.Sprintf("%s.%s.gz", sc.Name, format)
+}
+
+// ExportSnapshot writes compressed snapshot of the given collections into the target directory.
+// All the collections of the database are exported if no collection is specified.
+// The export runs on the maintenance client bound only by the given context.
+func (db *MongoDbBridge) ExportSnapshot(ctx context.Context, dir string, names []string, format string) (*SnapshotManifest, error) {
+	// validate the format
+	if format != SnapshotFormatBSON && format != SnapshotFormatJSON {
+		return nil, fmt.Errorf("unknown snapshot format %s", format)
+	}
+
+	// export all the collections by default
+	if len(names) == 0 {
+		var err error
+		names, err = db.maint.Database(db.dbName).ListCollectionNames(ctx, bson.D{})
+		if err != nil {
+			db.log.Errorf("can not list collections; %s", err.Error())
+			return nil, err
+		}
+	}
+
+	// make sure the target exists
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	man := SnapshotManifest{
+		Database:    db.dbName,
+		Format:      format,
+		Created:     time.Now().UTC(),
+		Collections: make([]*SnapshotCollection, 0, len(names)),
+	}
+
+	for _, name := range names {
+		sc, err := db.exportCollection(ctx, dir, name, format)
+		if err != nil {
+			db.log.Errorf("can not export collection %s; %s", name, err.Error())
+			return nil, err
+		}
+
+		db.log.Noticef("exported %d documents of %s", sc.Documents, name)
+		man.Collections = append(man.Collections, sc)
+	}
+
+	// write the manifest
+	data, err := json.MarshalIndent(&man, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &man, ioutil.WriteFile(filepath.Join(dir, snapshotManifestFile), data, 0644)
+}
+
+// exportCollection writes all the documents and indexes of the given collection into the snapshot.
+func (db *MongoDbBridge) exportCollection(ctx context.Context, dir string, name string, format string) (sc *SnapshotCollection, err error) {
+	col := db.maint.Database(db.dbName).Collection(name)
+	sc = &SnapshotCollection{Name: name}
+
+	// collect indexes so they can be re-created on import
+	if sc.Indexes, err = db.collectionIndexes(ctx, col); err != nil {
+		return nil, err
+	}
+
+	// open the target file
+	f, err := os.Create(filepath.Join(dir, sc.fileName(format)))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if e := f.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	zw := gzip.NewWriter(f)
+	defer func() {
+		if e := zw.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	// walk the whole collection
+	cur, err := col.Find(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if e := cur.Close(ctx); e != nil {
+			db.log.Errorf("can not close cursor; %s", e.Error())
+		}
+	}()
+
+	for cur.Next(ctx) {
+		if err = writeSnapshotDocument(zw, cur.Current, format); err != nil {
+			return nil, err
+		}
+		sc.Documents++
+	}
+	return sc, cur.Err()
+}
+
+// collectionIndexes provides the specification of secondary indexes of the collection.
+func (db *MongoDbBridge) collectionIndexes(ctx context.Context, col *mongo.Collection) ([]json.RawMessage, error) {
+	cur, err := col.Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if e := cur.Close(ctx); e != nil {
+			db.log.Errorf("can not close cursor; %s", e.Error())
+		}
+	}()
+
+	list := make([]json.RawMessage, 0)
+	for cur.Next(ctx) {
+		// the primary index is always there
+		if name, ok := cur.Current.Lookup("name").StringValueOK(); ok && name == "_id_" {
+			continue
+		}
+
+		ix, err := bson.MarshalExtJSON(cur.Current, true, false)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, ix)
+	}
+	return list, cur.Err()
+}
+
+// ImportSnapshot loads the given collections from the snapshot in the source directory.
+// All the collections of the snapshot are imported if no collection is specified.
+// Existing collections are dropped before the import if requested, importing into
+// a collection which is not empty fails otherwise.
+// The import runs on the maintenance client bound only by the given context.
+func (db *MongoDbBridge) ImportSnapshot(ctx context.Context, dir string, names []string, drop bool) (*SnapshotManifest, error) {
+	// read the manifest
+	data, err := ioutil.ReadFile(filepath.Join(dir, snapshotManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var man SnapshotManifest
+	if err := json.Unmarshal(data, &man); err != nil {
+		return nil, err
+	}
+
+	// find the collections to be imported
+	list := man.Collections
+	if len(names) > 0 {
+		list = make([]*SnapshotCollection, 0, len(names))
+		for _, name := range names {
+			sc := man.collection(name)
+			if sc == nil {
+				return nil, fmt.Errorf("collection %s not found in the snapshot", name)
+			}
+			list = append(list, sc)
+		}
+	}
+
+	for _, sc := range list {
+		if err := db.importCollection(ctx, dir, sc, man.Format, drop); err != nil {
+			db.log.Errorf("can not import collection %s; %s", sc.Name, err.Error())
+			return nil, err
+		}
+		db.log.Noticef("imported %d documents of %s", sc.Documents, sc.Name)
+	}
+
+	man.Collections = list
+	return &man, nil
+}
+
+// collection finds the collection of the given name in the snapshot manifest.
+func (man *SnapshotManifest) collection(name string) *SnapshotCollection {
+	for _, sc := range man.Collections {
+		if sc.Name == name {
+			return sc
+		}
+	}
+	return nil
+}
+
+// importCollection loads the documents and indexes of a single collection from the snapshot.
+func (db *MongoDbBridge) importCollection(ctx context.Context, dir string, sc *SnapshotCollection, format string, drop bool) error {
+	col := db.maint.Database(db.dbName).Collection(sc.Name)
+
+	// make sure we don't mix the snapshot with existing data
+	if drop {
+		if err := col.Drop(ctx); err != nil {
+			return err
+		}
+	} else if n, err := col.EstimatedDocumentCount(ctx); err != nil || n > 0 {
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("collection %s is not empty", sc.Name)
+	}
+
+	// open the source file
+	f, err := os.Open(filepath.Join(dir, sc.fileName(format)))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if e := f.Close(); e != nil {
+			db.log.Errorf("can not close snapshot file; %s", e.Error())
+		}
+	}()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	r := bufio.NewReader(zr)
+
+	// insert the documents in batches
+	batch := make([]interface{}, 0, snapshotBatchSize)
+	for {
+		doc, err := readSnapshotDocument(r, format)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		batch = append(batch, doc)
+		if len(batch) == snapshotBatchSize {
+			if _, err := col.InsertMany(ctx, batch, options.InsertMany().SetOrdered(false)); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if _, err := col.InsertMany(ctx, batch, options.InsertMany().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+	return db.createIndexes(ctx, col, sc.Indexes)
+}
+
+// createIndexes re-creates the exported indexes on the collection.
+func (db *MongoDbBridge) createIndexes(ctx context.Context, col *mongo.Collection, list []json.RawMessage) error {
+	for _, raw := range list {
+		var spec bson.D
+		if err := bson.UnmarshalExtJSON(raw, true, &spec); err != nil {
+			return err
+		}
+
+		// drop the server managed details of the index
+		ix := make(bson.D, 0, len(spec))
+		for _, el := range spec {
+			if el.Key != "v" && el.Key != "ns" {
+				ix = append(ix, el)
+			}
+		}
+
+		cmd := bson.D{{Key: "createIndexes", Value: col.Name()}, {Key: "indexes", Value: bson.A{ix}}}
+		if err := col.Database().RunCommand(ctx, cmd).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSnapshotDocument writes a single document into the snapshot stream.
+func writeSnapshotDocument(w io.Writer, doc bson.Raw, format string) error {
+	if format == SnapshotFormatBSON {
+		_, err := w.Write(doc)
+		return err
+	}
+
+	data, err := bson.MarshalExtJSON(doc, true, false)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// readSnapshotDocument reads the next document from the snapshot stream.
+func readSnapshotDocument(r *bufio.Reader, format string) (bson.Raw, error) {
+	if format == SnapshotFormatJSON {
+		line, err := r.ReadBytes('\n')
+		if err != nil && (err != io.EOF || len(line) == 0) {
+			return nil, err
+		}
+
+		var doc bson.Raw
+		if err := bson.UnmarshalExtJSON(line, true, &doc); err != nil {
+			return nil, err
+		}
+		return doc, nil
+	}
+
+	// the BSON document starts with its length
+	var head [4]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.LittleEndian.Uint32(head[:])
+	if size < 5 || size > snapshotMaxDocumentSize {
+		return nil, fmt.Errorf("invalid document size %d", size)
+	}
+
+	doc := make([]byte, size)
+	copy(doc, head[:])
+	if _, err := io.ReadFull(r, doc[4:]); err != nil {
+		return nil, err
+	}
+	return doc, bson.Raw(doc).Validate()
+}
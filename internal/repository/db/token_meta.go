@@ -0,0 +1,86 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colTokenMeta represents the name of the token metadata collection.
+const colTokenMeta = "token_meta"
+
+// TokenMeta loads the metadata of the given token from the database.
+// It returns nil if the token is not known.
+func (db *MongoDbBridge) TokenMeta(addr *common.Address) (*types.TokenMeta, error) {
+	// get the collection for token metadata
+	col := db.client.Database(db.dbName).Collection(colTokenMeta)
+
+	// try to find the token in the database
+	sr := col.FindOne(db.opContext(), bson.D{{types.FiTokenMetaPk, addr.String()}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load token metadata of %s; %s", addr.String(), sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	// try to decode
+	var tm types.TokenMeta
+	if err := sr.Decode(&tm); err != nil {
+		db.log.Errorf("can not decode token metadata of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	return &tm, nil
+}
+
+// TokenMetaList loads the metadata of all the known tokens from the database.
+func (db *MongoDbBridge) TokenMetaList() ([]*types.TokenMeta, error) {
+	// get the collection for token metadata
+	col := db.client.Database(db.dbName).Collection(colTokenMeta)
+
+	// load all the records
+	cursor, err := col.Find(db.opContext(), bson.D{})
+	if err != nil {
+		db.log.Errorf("can not load token metadata list; %s", err.Error())
+		return nil, err
+	}
+
+	// make sure to close the cursor
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.TokenMeta, 0)
+	for cursor.Next(db.opContext()) {
+		var tm types.TokenMeta
+		if err := cursor.Decode(&tm); err != nil {
+			db.log.Errorf("can not decode token metadata; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &tm)
+	}
+	return list, nil
+}
+
+// StoreTokenMeta inserts, or replaces the given token metadata in the database.
+func (db *MongoDbBridge) StoreTokenMeta(tm *types.TokenMeta) error {
+	// get the collection for token metadata
+	col := db.client.Database(db.dbName).Collection(colTokenMeta)
+
+	// replace the record, or insert a new one
+	if _, err := col.ReplaceOne(db.opContext(),
+		bson.D{{types.FiTokenMetaPk, tm.Address.String()}},
+		tm, options.Replace().SetUpsert(true)); err != nil {
+		db.log.Criticalf("can not store token metadata of %s; %s", tm.Address.String(), err.Error())
+		return err
+	}
+	return nil
+}
@@ -9,6 +9,9 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"math"
+	"math/big"
+	"time"
 )
 
 const (
@@ -37,6 +40,9 @@ const (
 	// fiTransactionValue is the name of the field of the transaction value.
 	fiTransactionValue = "value"
 
+	// fiTransactionAmount is the name of the field of the transaction value in 10^-9 units.
+	fiTransactionAmount = "amo"
+
 	// fiTransactionTimeStamp is the name of the field of the transaction time stamp.
 	fiTransactionTimeStamp = "stamp"
 )
@@ -96,11 +102,14 @@ func (db *MongoDbBridge) AddTransaction(block *types.Block, trx *types.Transacti
 	// if the transaction already exists, we don't need to add it
 	// just make sure the transaction accounts were processed
 	if !db.shouldAddTransaction(col, trx) {
-		return db.UpdateTransaction(col, trx)
+		if err := db.UpdateTransaction(col, trx); err != nil {
+			return err
+		}
+		return db.addAccountTransactions(trx)
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), trx); err != nil {
+	if _, err := col.InsertOne(db.opContext(), trx); err != nil {
 		db.log.Critical(err)
 		return err
 	}
@@ -113,7 +122,8 @@ func (db *MongoDbBridge) AddTransaction(block *types.Block, trx *types.Transacti
 		db.initTransactions.Do(func() { db.initTransactionsCollection(col); db.initTransactions = nil })
 	}
 
-	return nil
+	// link the transaction with its accounts
+	return db.addAccountTransactions(trx)
 }
 
 // UpdateTransaction updates transaction data in the database collection.
@@ -123,7 +133,7 @@ func (db *MongoDbBridge) UpdateTransaction(col *mongo.Collection, trx *types.Tra
 
 	// try to update a delegation by replacing it in the database
 	// we use address and validator ID to identify unique delegation
-	er, err := col.UpdateOne(context.Background(), bson.D{
+	er, err := col.UpdateOne(db.opContext(), bson.D{
 		{fiTransactionPk, trx.Hash.String()},
 	}, bson.D{{"$set", bson.D{
 		{fiTransactionOrdinalIndex, trx.Uid()},
@@ -146,7 +156,7 @@ func (db *MongoDbBridge) UpdateTransaction(col *mongo.Collection, trx *types.Tra
 // IsTransactionKnown checks if a transaction document already exists in the database.
 func (db *MongoDbBridge) IsTransactionKnown(col *mongo.Collection, hash *common.Hash) (bool, error) {
 	// try to find the transaction in the database (it may already exist)
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(db.opContext(), bson.D{
 		{fiTransactionPk, hash.String()},
 	}, options.FindOne().SetProjection(bson.D{
 		{fiTransactionPk, true},
@@ -232,10 +242,14 @@ func (db *MongoDbBridge) trxListWithRangeMarks(
 		list.IsEnd = true
 
 	} else if cursor != nil {
-		// get the highest available ordinal index (top transaction)
-		list.First, err = db.findBorderOrdinalIndex(col,
-			bson.D{{fiTransactionPk, *cursor}},
-			options.FindOne())
+		// opaque cursor carries the ordinal index itself
+		var ok bool
+		if list.First, ok = types.DecodeListCursor(*cursor); !ok {
+			// legacy cursor; find the ordinal index of the referenced transaction
+			list.First, err = db.findBorderOrdinalIndex(col,
+				bson.D{{fiTransactionPk, *cursor}},
+				options.FindOne())
+		}
 	}
 
 	// check the error
@@ -259,7 +273,7 @@ func (db *MongoDbBridge) findBorderOrdinalIndex(col *mongo.Collection, filter bs
 
 	// make sure we pull only what we need
 	opt.SetProjection(bson.D{{"orx", true}})
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(db.opContext(), filter, opt)
 
 	// try to decode
 	err := sr.Decode(&row)
@@ -323,7 +337,7 @@ func (db *MongoDbBridge) txListOptions(count int32) *options.FindOptions {
 // txListLoad load the initialized list from database
 func (db *MongoDbBridge) txListLoad(col *mongo.Collection, cursor *string, count int32, list *types.TransactionList) error {
 	// get the context for loader
-	ctx := context.Background()
+	ctx := db.opContext()
 
 	// load the data
 	ld, err := col.Find(ctx, db.txListFilter(cursor, count, list), db.txListOptions(count))
@@ -408,3 +422,47 @@ func (db *MongoDbBridge) Transactions(cursor *string, count int32, filter *bson.
 
 	return list, nil
 }
+
+// LastBlockBefore returns the number of the last known block containing
+// a transaction stamped at or before the given time.
+func (db *MongoDbBridge) LastBlockBefore(ts time.Time) (uint64, error) {
+	// prep search options
+	opt := options.FindOne()
+	opt.SetSort(bson.D{{fiTransactionBlock, -1}})
+	opt.SetProjection(bson.D{{fiTransactionBlock, true}})
+
+	// get the collection for account transactions
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+	res := col.FindOne(db.opContext(), bson.D{{fiTransactionTimeStamp, bson.D{{"$lte", ts}}}}, opt)
+	if res.Err() != nil {
+		db.log.Errorf("can not get the last block before %s; %s", ts.String(), res.Err().Error())
+		return 0, res.Err()
+	}
+
+	// get the actual value
+	var tx struct {
+		Block uint64 `bson:"blk"`
+	}
+	if err := res.Decode(&tx); err != nil {
+		db.log.Errorf("can not decode the last block before %s; %s", ts.String(), err.Error())
+		return 0, err
+	}
+	return tx.Block, nil
+}
+
+// LargeTransactions pulls list of transactions transferring at least the given value
+// starting on the specified cursor.
+func (db *MongoDbBridge) LargeTransactions(minValue *big.Int, cursor *string, count int32) (*types.TransactionList, error) {
+	fi := bson.D{{fiTransactionAmount, bson.D{{"$gte", correctedAmount(minValue)}}}}
+	return db.Transactions(cursor, count, &fi)
+}
+
+// correctedAmount converts the given value to the 10^-9 units used by the amount
+// fields of stored transactions; values out of the stored range are capped.
+func correctedAmount(val *big.Int) int64 {
+	amo := new(big.Int).Div(val, types.TransactionDecimalsCorrection)
+	if !amo.IsInt64() {
+		return math.MaxInt64
+	}
+	return amo.Int64()
+}
@@ -29,8 +29,8 @@ func (db *MongoDbBridge) TrxDailyFlowList(from *time.Time, to *time.Time) ([]*ty
 	db.log.Debugf("loading trx flow between %s and %s", from.String(), to.String())
 
 	// get the collection and context
-	ctx := context.Background()
-	col := db.client.Database(db.dbName).Collection(coTransactionVolume)
+	ctx := db.opContext()
+	col := db.analyticsCollection(coTransactionVolume)
 
 	// pull the data; make sure there is a limit to the range
 	ld, err := col.Find(ctx, trxDailyFlowListFilter(from, to), options.Find().SetSort(bson.D{{fiTrxVolumePk, 1}}).SetLimit(365))
@@ -60,8 +60,8 @@ func (db *MongoDbBridge) TrxGasSpeed(from *time.Time, to *time.Time) (float64, e
 	}
 
 	// get the collection and context
-	ctx := context.Background()
-	col := db.client.Database(db.dbName).Collection(coTransactions)
+	ctx := db.opContext()
+	col := db.analyticsCollection(coTransactions)
 
 	// aggregate the gas used from the given time range
 	cr, err := col.Aggregate(ctx, mongo.Pipeline{
@@ -88,7 +88,7 @@ func (db *MongoDbBridge) TrxGasSpeed(from *time.Time, to *time.Time) (float64, e
 // trxGasSpeed makes the gas speed calculation from the given aggregation cursor.
 func (db *MongoDbBridge) trxGasSpeed(cr *mongo.Cursor, from *time.Time, to *time.Time) (float64, error) {
 	// get the row
-	if !cr.Next(context.Background()) {
+	if !cr.Next(db.opContext()) {
 		db.log.Errorf("can not navigate gas speed results")
 		return 0.0, fmt.Errorf("gas speed aggregation failure")
 	}
@@ -113,10 +113,10 @@ func (db *MongoDbBridge) TrxRecentTrxSpeed(sec int32) (float64, error) {
 		sec = 60
 	}
 	from := time.Now().UTC().Add(time.Duration(-sec) * time.Second)
-	col := db.client.Database(db.dbName).Collection(coTransactions)
+	col := db.analyticsCollection(coTransactions)
 
 	// find how many transactions do we have in the database
-	total, err := col.CountDocuments(context.Background(), bson.D{
+	total, err := col.CountDocuments(db.opContext(), bson.D{
 		{fiTransactionTimeStamp, bson.D{
 			{"$gte", from},
 		}},
@@ -133,6 +133,51 @@ func (db *MongoDbBridge) TrxRecentTrxSpeed(sec int32) (float64, error) {
 	return float64(total) / float64(sec), nil
 }
 
+// TrxRangeStats calculates the number of transactions and the total amount of gas
+// consumed by transactions with time stamp in the given time range (from, to].
+func (db *MongoDbBridge) TrxRangeStats(from time.Time, to time.Time) (uint64, uint64, error) {
+	// get the collection and context
+	ctx := db.opContext()
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	// aggregate the transactions of the given time range
+	cr, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{"$match", bson.D{{fiTransactionTimeStamp, bson.D{{"$gt", from}, {"$lte", to}}}}}},
+		{{"$group", bson.D{
+			{"_id", nil},
+			{"count", bson.D{{"$sum", 1}}},
+			{"gas", bson.D{{"$sum", "$gas_use"}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not collect trx range stats; %s", err.Error())
+		return 0, 0, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := cr.Close(ctx); err != nil {
+			db.log.Errorf("error closing trx range stats cursor; %s", err.Error())
+		}
+	}()
+
+	// no transactions in the range
+	if !cr.Next(ctx) {
+		return 0, 0, nil
+	}
+
+	// decode the result
+	var row struct {
+		Count int64 `bson:"count"`
+		Gas   int64 `bson:"gas"`
+	}
+	if err := cr.Decode(&row); err != nil {
+		db.log.Errorf("can not decode trx range stats; %s", err.Error())
+		return 0, 0, err
+	}
+	return uint64(row.Count), uint64(row.Gas), nil
+}
+
 // trxDailyFlowListFilter creates a filter for loading trx flow data based on provided
 // range dates.
 func trxDailyFlowListFilter(from *time.Time, to *time.Time) *bson.D {
@@ -182,7 +227,7 @@ func (db *MongoDbBridge) TrxDailyFlowUpdate(from time.Time) error {
 	col := db.client.Database(db.dbName).Collection(coTransactions)
 
 	// get the collection
-	cr, err := col.Aggregate(context.Background(), mongo.Pipeline{
+	cr, err := col.Aggregate(db.opContext(), mongo.Pipeline{
 		{{"$match", bson.D{
 			{"stamp", bson.D{{"$gte", from}}},
 		}}},
@@ -7,7 +7,6 @@ import (
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"math/big"
-	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -132,7 +131,7 @@ func (db *MongoDbBridge) UniswapAdd(swap *types.Swap) error {
 	swapHash := getHash(swap)
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(),
+	if _, err := col.InsertOne(db.opContext(),
 		/*
 			&bson.D{
 				{fiSwapPk, swapHash.String()},
@@ -200,7 +199,7 @@ func swapData(base *bson.D, swap *types.Swap) bson.D {
 // IsSwapKnown checks if swap document already exists in the database.
 func (db *MongoDbBridge) IsSwapKnown(col *mongo.Collection, hash *common.Hash, swap *types.Swap) (bool, error) {
 	// try to find swap in the database (it may already exist)
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(db.opContext(), bson.D{
 		{Key: fiSwapPk, Value: hash.String()}})
 
 	// error on lookup?
@@ -223,7 +222,7 @@ func (db *MongoDbBridge) IsSwapKnown(col *mongo.Collection, hash *common.Hash, s
 	// if swap is sync type, then update reserves
 	if swap.Type == types.SwapSync {
 		db.log.Debugf("Updating reserves for Swap %s", hash.String())
-		_, err := col.UpdateOne(context.Background(),
+		_, err := col.UpdateOne(db.opContext(),
 			bson.M{fiSwapPk: hash.String()},
 			bson.D{
 				{Key: "$set", Value: bson.M{fiSwapReserve0: removeDecimals(swap.Reserve0)}},
@@ -249,7 +248,7 @@ func (db *MongoDbBridge) IsSwapKnown(col *mongo.Collection, hash *common.Hash, s
 		if types.SwapSync == values.Type {
 			// log issue
 			db.log.Debugf("updating reserve for swap: %s, reserve0: %v, reserve1: %v", hash.String(), values.Reserve0, values.Reserve1)
-			if _, err := col.DeleteOne(context.Background(), bson.D{{Key: fiSwapPk, Value: hash.String()}}); err != nil {
+			if _, err := col.DeleteOne(db.opContext(), bson.D{{Key: fiSwapPk, Value: hash.String()}}); err != nil {
 				db.log.Errorf("can not delete swap data; %s", err.Error())
 			}
 
@@ -278,7 +277,7 @@ func (db *MongoDbBridge) LastKnownSwapBlock() (uint64, error) {
 
 	// get the swaps collection
 	col := db.client.Database(db.dbName).Collection(coUniswap)
-	res := col.FindOne(context.Background(), query)
+	res := col.FindOne(db.opContext(), query)
 	if res.Err() != nil {
 		// may be no block at all
 		if res.Err() == mongo.ErrNoDocuments {
@@ -326,7 +325,7 @@ func (db *MongoDbBridge) UniswapUpdateLastKnownSwapBlock(blkNumber uint64) error
 
 	// get the collection for transactions and insert data
 	col := db.client.Database(db.dbName).Collection(coUniswap)
-	if _, err := col.UpdateOne(context.Background(),
+	if _, err := col.UpdateOne(db.opContext(),
 		query, data, options.Update().SetUpsert(true)); err != nil {
 
 		db.log.Critical(err)
@@ -375,7 +374,7 @@ func (db *MongoDbBridge) UniswapVolume(pairAddress *common.Address, fromTime int
 
 	// query collection
 	col := db.client.Database(db.dbName).Collection(coUniswap)
-	cursor, err := col.Aggregate(context.Background(), pipe)
+	cursor, err := col.Aggregate(db.opContext(), pipe)
 	def := types.DefiSwapVolume{
 		PairAddress: pairAddress,
 		Volume:      big.NewInt(0)}
@@ -392,7 +391,7 @@ func (db *MongoDbBridge) UniswapVolume(pairAddress *common.Address, fromTime int
 		}()
 
 		// get result and fill return data
-		for cursor.Next(context.Background()) {
+		for cursor.Next(db.opContext()) {
 			var val Volume
 			err := cursor.Decode(&val)
 			if err != nil {
@@ -441,7 +440,7 @@ func (db *MongoDbBridge) UniswapTimeVolumes(pairAddress *common.Address, resolut
 
 	// execute query
 	col := db.client.Database(db.dbName).Collection(coUniswap)
-	cursor, err := col.Aggregate(context.Background(), pipe)
+	cursor, err := col.Aggregate(db.opContext(), pipe)
 
 	if err != nil {
 		db.log.Errorf(err.Error())
@@ -454,7 +453,7 @@ func (db *MongoDbBridge) UniswapTimeVolumes(pairAddress *common.Address, resolut
 		}()
 
 		// iterate thru results and construct data
-		for cursor.Next(context.Background()) {
+		for cursor.Next(db.opContext()) {
 			var val Volume
 			err := cursor.Decode(&val)
 			if err != nil {
@@ -584,7 +583,7 @@ func (db *MongoDbBridge) UniswapTimePrices(pairAddress *common.Address, resoluti
 
 	// execute query
 	col := db.client.Database(db.dbName).Collection(coUniswap)
-	cursor, err := col.Aggregate(context.Background(), pipe)
+	cursor, err := col.Aggregate(db.opContext(), pipe)
 	if err != nil {
 		db.log.Errorf(err.Error())
 		return list, nil
@@ -596,7 +595,7 @@ func (db *MongoDbBridge) UniswapTimePrices(pairAddress *common.Address, resoluti
 		}()
 
 		// iterate thru results and construct data
-		for cursor.Next(context.Background()) {
+		for cursor.Next(db.opContext()) {
 			var priceVal types.DefiTimePrice
 			err := cursor.Decode(&priceVal)
 			if err != nil {
@@ -649,7 +648,7 @@ func (db *MongoDbBridge) UniswapTimeReserves(pairAddress *common.Address, resolu
 
 	// execute query
 	col := db.client.Database(db.dbName).Collection(coUniswap)
-	cursor, err := col.Aggregate(context.Background(), pipe)
+	cursor, err := col.Aggregate(db.opContext(), pipe)
 	if err != nil {
 		db.log.Errorf(err.Error())
 		return list, nil
@@ -661,7 +660,7 @@ func (db *MongoDbBridge) UniswapTimeReserves(pairAddress *common.Address, resolu
 		}()
 
 		// iterate thru results and construct data
-		for cursor.Next(context.Background()) {
+		for cursor.Next(db.opContext()) {
 			var reserveVal TimeReserve
 			err := cursor.Decode(&reserveVal)
 			if err != nil {
@@ -766,7 +765,7 @@ func (db *MongoDbBridge) uniswapActionListTotal(col *mongo.Collection, pairAddre
 	filter = bson.D{{Key: "$and", Value: bson.A{filterPair, filterType, filterBlk}}}
 
 	// find how many uniswap events do we have in the database
-	total, err := col.CountDocuments(context.Background(), filter)
+	total, err := col.CountDocuments(db.opContext(), filter)
 	if err != nil {
 		db.log.Errorf("Can not count uniswap actions: %v", err.Error())
 		return err
@@ -825,9 +824,9 @@ func uniswapActionListTopFilter(pairAddress *common.Address, cursor *string, act
 		var err error
 
 		// get the ordinal index based on cursor
-		ix, err = strconv.ParseUint(*cursor, 10, 64)
+		ix, err = decimalCursorOrdinal(*cursor)
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor value; %s", err.Error())
+			return nil, err
 		}
 	}
 
@@ -860,7 +859,7 @@ func uniswapActionListTopFilter(pairAddress *common.Address, cursor *string, act
 // uniswapActionListLoad loads the initialized uniswap action list from persistent database.
 func (db *MongoDbBridge) uniswapActionListLoad(col *mongo.Collection, pairAddress *common.Address, actionType int32, cursor *string, count int32, list *types.UniswapActionList) error {
 	// get the context for loader
-	ctx := context.Background()
+	ctx := db.opContext()
 
 	// load the data
 	ld, err := col.Find(ctx, db.uniswapActionListFilter(pairAddress, actionType, cursor, count, list), db.uniswapActionListOptions(count))
@@ -1025,7 +1024,7 @@ func (db *MongoDbBridge) findUniswapActionBorderOrdinalIndex(col *mongo.Collecti
 
 	// make sure we pull only what we need
 	opt.SetProjection(bson.D{{Key: fiSwapOrdIndex, Value: true}})
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(db.opContext(), filter, opt)
 
 	// try to decode
 	err := sr.Decode(&row)
@@ -1035,3 +1034,61 @@ func (db *MongoDbBridge) findUniswapActionBorderOrdinalIndex(col *mongo.Collecti
 
 	return row.Value, nil
 }
+
+// UniswapPositionEntries aggregates liquidity added and removed by the given
+// owner on each Uniswap pair using the stored Mint and Burn events.
+func (db *MongoDbBridge) UniswapPositionEntries(owner *common.Address) ([]*types.UniswapPositionEntry, error) {
+	// create command pipeline
+	pipe := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: fiSwapSender, Value: owner.String()},
+			{Key: fiSwapType, Value: bson.D{{Key: "$in", Value: bson.A{types.SwapMint, types.SwapBurn}}}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + fiSwapPair},
+			{Key: "am0in", Value: bson.D{{Key: "$sum", Value: "$" + fiSwapAmount0in}}},
+			{Key: "am1in", Value: bson.D{{Key: "$sum", Value: "$" + fiSwapAmount1in}}},
+			{Key: "am0out", Value: bson.D{{Key: "$sum", Value: "$" + fiSwapAmount0out}}},
+			{Key: "am1out", Value: bson.D{{Key: "$sum", Value: "$" + fiSwapAmount1out}}},
+		}}},
+	}
+
+	// query collection
+	col := db.client.Database(db.dbName).Collection(coUniswap)
+	cursor, err := col.Aggregate(db.opContext(), pipe)
+	if err != nil {
+		db.log.Errorf("can not aggregate uniswap positions of %s; %s", owner.String(), err.Error())
+		return nil, err
+	}
+
+	// make sure to close the cursor
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	// decode the aggregated rows
+	list := make([]*types.UniswapPositionEntry, 0)
+	for cursor.Next(db.opContext()) {
+		var row struct {
+			Pair       string `bson:"_id"`
+			Amount0In  int64  `bson:"am0in"`
+			Amount1In  int64  `bson:"am1in"`
+			Amount0Out int64  `bson:"am0out"`
+			Amount1Out int64  `bson:"am1out"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode uniswap position; %s", err.Error())
+			return nil, err
+		}
+
+		list = append(list, &types.UniswapPositionEntry{
+			Pair:       common.HexToAddress(row.Pair),
+			Deposited0: returnDecimals(big.NewInt(row.Amount0In)),
+			Deposited1: returnDecimals(big.NewInt(row.Amount1In)),
+			Withdrawn0: returnDecimals(big.NewInt(row.Amount0Out)),
+			Withdrawn1: returnDecimals(big.NewInt(row.Amount1Out)),
+		})
+	}
+	return list, nil
+}
@@ -0,0 +1,90 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colUniswapPairs represents the name of the registered Uniswap pairs collection in database.
+const colUniswapPairs = "uniswap_pairs"
+
+// initUniswapPairsCollection initializes the registered Uniswap pairs collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initUniswapPairsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiUniswapPairCreated, 1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for uniswap pairs collection; %s", err.Error())
+	}
+	db.log.Debugf("uniswap pairs collection initialized")
+}
+
+// AddUniswapPair registers a Uniswap pair created by the factory.
+// It returns FALSE if the pair has already been registered.
+func (db *MongoDbBridge) AddUniswapPair(up *types.UniswapPairCreated) (bool, error) {
+	col := db.client.Database(db.dbName).Collection(colUniswapPairs)
+
+	if _, err := col.InsertOne(db.opContext(), up); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+
+		db.log.Errorf("can not register uniswap pair %s; %s", up.Pair.String(), err.Error())
+		return false, err
+	}
+
+	// make sure uniswap pairs collection is initialized
+	if db.initUniswapPairs != nil {
+		db.initUniswapPairs.Do(func() { db.initUniswapPairsCollection(col); db.initUniswapPairs = nil })
+	}
+	return true, nil
+}
+
+// UniswapPairsCount calculates total number of registered Uniswap pairs in the database.
+func (db *MongoDbBridge) UniswapPairsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colUniswapPairs))
+}
+
+// UniswapRegisteredPairs loads addresses of all the registered Uniswap pairs
+// sorted by the block of their creation.
+func (db *MongoDbBridge) UniswapRegisteredPairs() ([]common.Address, error) {
+	ctx := db.opContext()
+	col := db.client.Database(db.dbName).Collection(colUniswapPairs)
+
+	ld, err := col.Find(ctx, bson.D{}, options.Find().
+		SetSort(bson.D{{types.FiUniswapPairCreated, 1}}).
+		SetProjection(bson.D{{types.FiUniswapPairPk, true}}))
+	if err != nil {
+		db.log.Errorf("can not load registered uniswap pairs; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing uniswap pairs cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]common.Address, 0)
+	for ld.Next(ctx) {
+		var row struct {
+			Pair string `bson:"_id"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode uniswap pair; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, common.HexToAddress(row.Pair))
+	}
+	return list, nil
+}
@@ -0,0 +1,111 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// colUsage represents the name of the API usage collection.
+const colUsage = "usage"
+
+// initUsageCollection initializes the API usage collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initUsageCollection(col *mongo.Collection) {
+	// index the time window for reports
+	ix := []mongo.IndexModel{{Keys: bson.D{{types.FiUsageHour, -1}, {types.FiUsageOrigin, 1}}}}
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for usage collection; %s", err.Error())
+	}
+	db.log.Debugf("usage collection initialized")
+}
+
+// AddUsage adds the given usage records to the hourly usage of their origins.
+// It returns the number of records stored.
+func (db *MongoDbBridge) AddUsage(list []*types.UsageRecord) (int, error) {
+	// get the collection for API usage
+	col := db.client.Database(db.dbName).Collection(colUsage)
+
+	for i, ur := range list {
+		if _, err := col.UpdateOne(db.opContext(),
+			bson.D{{types.FiUsagePk, fmt.Sprintf("%s/%d", ur.Origin, ur.From.Unix())}},
+			bson.D{
+				{"$setOnInsert", bson.D{{types.FiUsageOrigin, ur.Origin}, {types.FiUsageHour, ur.From}}},
+				{"$inc", bson.D{{types.FiUsageRequests, ur.Requests}, {types.FiUsageBytes, ur.Bytes}, {types.FiUsageCost, ur.Cost}}},
+			}, options.Update().SetUpsert(true)); err != nil {
+			db.log.Errorf("can not store usage of %s; %s", ur.Origin, err.Error())
+			return i, err
+		}
+	}
+
+	// check init state
+	// make sure usage collection is initialized
+	if db.initUsage != nil {
+		db.initUsage.Do(func() { db.initUsageCollection(col); db.initUsage = nil })
+	}
+	return len(list), nil
+}
+
+// UsageReport aggregates the API usage of all the origins in the given time range,
+// the heaviest users by the compute cost go first.
+func (db *MongoDbBridge) UsageReport(from time.Time, to time.Time) ([]*types.UsageRecord, error) {
+	// get the collection for API usage
+	col := db.analyticsCollection(colUsage)
+
+	cursor, err := col.Aggregate(db.opContext(), mongo.Pipeline{
+		{{"$match", bson.D{{types.FiUsageHour, bson.D{{"$gte", from}, {"$lt", to}}}}}},
+		{{"$group", bson.D{
+			{"_id", "$" + types.FiUsageOrigin},
+			{types.FiUsageRequests, bson.D{{"$sum", "$" + types.FiUsageRequests}}},
+			{types.FiUsageBytes, bson.D{{"$sum", "$" + types.FiUsageBytes}}},
+			{types.FiUsageCost, bson.D{{"$sum", "$" + types.FiUsageCost}}},
+		}}},
+		{{"$sort", bson.D{{types.FiUsageCost, -1}}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate usage; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			db.log.Errorf("error closing usage cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.UsageRecord, 0)
+	for cursor.Next(db.opContext()) {
+		var row struct {
+			Origin   string `bson:"_id"`
+			Requests int64  `bson:"req"`
+			Bytes    int64  `bson:"bytes"`
+			Cost     int64  `bson:"cost"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode usage; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &types.UsageRecord{
+			Origin:   row.Origin,
+			From:     from,
+			To:       to,
+			Requests: uint64(row.Requests),
+			Bytes:    uint64(row.Bytes),
+			Cost:     uint64(row.Cost),
+		})
+	}
+	return list, nil
+}
+
+// UsageCount calculates total number of usage records in the database.
+func (db *MongoDbBridge) UsageCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colUsage))
+}
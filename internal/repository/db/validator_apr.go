@@ -0,0 +1,91 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colValidatorApr represents the name of the validators realized APR collection in database.
+const colValidatorApr = "validator_apr"
+
+// initValidatorAprCollection initializes the validators APR collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initValidatorAprCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiValidatorAprValidator, 1}, {types.FiValidatorAprEpoch, 1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for validator APR collection; %s", err.Error())
+	}
+	db.log.Debugf("validator APR collection initialized")
+}
+
+// StoreValidatorApr stores the realized APR of validators in a sealed epoch.
+// Records of the same validator and epoch are replaced.
+func (db *MongoDbBridge) StoreValidatorApr(list []*types.ValidatorApr) error {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colValidatorApr)
+
+	for _, va := range list {
+		if _, err := col.ReplaceOne(
+			db.opContext(),
+			bson.D{{types.FiValidatorAprPk, va.Pk()}},
+			va,
+			options.Replace().SetUpsert(true),
+		); err != nil {
+			db.log.Errorf("can not store APR of validator #%d; %s", va.ValidatorId, err.Error())
+			return err
+		}
+	}
+
+	// make sure the collection is initialized
+	if db.initValidatorApr != nil {
+		db.initValidatorApr.Do(func() { db.initValidatorAprCollection(col); db.initValidatorApr = nil })
+	}
+	return nil
+}
+
+// ValidatorAprCount calculates total number of validator APR records in the database.
+func (db *MongoDbBridge) ValidatorAprCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colValidatorApr))
+}
+
+// ValidatorAprHistory loads the realized APR of the given validator
+// in the given range of sealed epochs sorted from the oldest epoch.
+func (db *MongoDbBridge) ValidatorAprHistory(valID uint64, from uint64, to uint64) ([]*types.ValidatorApr, error) {
+	ctx := db.opContext()
+	col := db.client.Database(db.dbName).Collection(colValidatorApr)
+
+	ld, err := col.Find(ctx, bson.D{
+		{types.FiValidatorAprValidator, int64(valID)},
+		{types.FiValidatorAprEpoch, bson.D{{"$gte", int64(from)}, {"$lte", int64(to)}}},
+	}, options.Find().SetSort(bson.D{{types.FiValidatorAprEpoch, 1}}))
+	if err != nil {
+		db.log.Errorf("can not load APR history of validator #%d; %s", valID, err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing validator APR cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.ValidatorApr, 0)
+	for ld.Next(ctx) {
+		var row types.ValidatorApr
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode validator APR; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
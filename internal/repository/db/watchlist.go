@@ -0,0 +1,309 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// colWatchList represents the name of the watched addresses collection in database.
+	colWatchList = "watchlist"
+
+	// colWatchDigest represents the name of the watched addresses activity digest collection in database.
+	colWatchDigest = "watch_digest"
+
+	// keyConfigWatchDigest is the primary key for the end of the last watch digest period.
+	keyConfigWatchDigest = "wdg"
+)
+
+// initWatchListCollection initializes the watch list collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initWatchListCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiWatchedAddressOwner, 1}, {types.FiWatchedAddressAdded, 1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiWatchedAddress, 1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for watch list collection; %s", err.Error())
+	}
+	db.log.Debugf("watch list collection initialized")
+}
+
+// initWatchDigestCollection initializes the watch digest collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initWatchDigestCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{types.FiWatchDigestAddress, 1}, {types.FiWatchDigestFrom, -1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for watch digest collection; %s", err.Error())
+	}
+	db.log.Debugf("watch digest collection initialized")
+}
+
+// AddWatchedAddress stores the address on the watch list of its owner.
+// Adding an address already watched updates its label.
+func (db *MongoDbBridge) AddWatchedAddress(wa *types.WatchedAddress) error {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colWatchList)
+
+	// try to do the upsert
+	if _, err := col.ReplaceOne(
+		db.opContext(),
+		bson.D{{types.FiWatchedAddressPk, wa.Pk()}},
+		wa,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store watched address %s; %s", wa.Address.String(), err.Error())
+		return err
+	}
+
+	// make sure the collection is initialized
+	if db.initWatchList != nil {
+		db.initWatchList.Do(func() { db.initWatchListCollection(col); db.initWatchList = nil })
+	}
+	return nil
+}
+
+// RemoveWatchedAddress removes the address from the watch list of the given owner.
+// It returns false if the address was not on the list.
+func (db *MongoDbBridge) RemoveWatchedAddress(owner string, addr *common.Address) (bool, error) {
+	col := db.client.Database(db.dbName).Collection(colWatchList)
+
+	wa := types.WatchedAddress{Owner: owner, Address: *addr}
+	res, err := col.DeleteOne(db.opContext(), bson.D{{types.FiWatchedAddressPk, wa.Pk()}})
+	if err != nil {
+		db.log.Errorf("can not remove watched address %s; %s", addr.String(), err.Error())
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// WatchList loads the watch list of the given owner, oldest addresses first.
+func (db *MongoDbBridge) WatchList(owner string) ([]*types.WatchedAddress, error) {
+	col := db.client.Database(db.dbName).Collection(colWatchList)
+
+	ld, err := col.Find(db.opContext(),
+		bson.D{{types.FiWatchedAddressOwner, owner}},
+		options.Find().SetSort(bson.D{{types.FiWatchedAddressAdded, 1}}))
+	if err != nil {
+		db.log.Errorf("can not load watch list; %s", err.Error())
+		return nil, err
+	}
+	defer func() {
+		if err := ld.Close(context.Background()); err != nil {
+			db.log.Errorf("error closing watch list cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.WatchedAddress, 0)
+	for ld.Next(db.opContext()) {
+		var row types.WatchedAddress
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode watched address; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// WatchListSize calculates the number of addresses on the watch list of the given owner.
+func (db *MongoDbBridge) WatchListSize(owner string) (int64, error) {
+	return db.client.Database(db.dbName).Collection(colWatchList).CountDocuments(db.opContext(), bson.D{{types.FiWatchedAddressOwner, owner}})
+}
+
+// WatchListCount calculates total number of watched addresses in the database.
+func (db *MongoDbBridge) WatchListCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colWatchList))
+}
+
+// WatchDigestCount calculates total number of watch digest records in the database.
+func (db *MongoDbBridge) WatchDigestCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colWatchDigest))
+}
+
+// WatchedAddresses loads all the addresses watched by any of the clients.
+func (db *MongoDbBridge) WatchedAddresses() ([]common.Address, error) {
+	col := db.client.Database(db.dbName).Collection(colWatchList)
+
+	res, err := col.Distinct(db.opContext(), types.FiWatchedAddress, bson.D{})
+	if err != nil {
+		db.log.Errorf("can not load watched addresses; %s", err.Error())
+		return nil, err
+	}
+
+	list := make([]common.Address, 0, len(res))
+	for _, v := range res {
+		if adr, ok := v.(string); ok {
+			list = append(list, common.HexToAddress(adr))
+		}
+	}
+	return list, nil
+}
+
+// WatchDigestState provides the end of the last watch digest period processed.
+// Zero time is returned if no digest has been made yet.
+func (db *MongoDbBridge) WatchDigestState() (time.Time, error) {
+	val, err := db.configValue(keyConfigWatchDigest)
+	if err != nil || val == "" {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, val)
+}
+
+// SetWatchDigestState stores the end of the last watch digest period processed.
+func (db *MongoDbBridge) SetWatchDigestState(to time.Time) error {
+	return db.setConfigValue(keyConfigWatchDigest, to.UTC().Format(time.RFC3339))
+}
+
+// WatchDigestCompute aggregates the activity of the given address in the given period.
+func (db *MongoDbBridge) WatchDigestCompute(addr *common.Address, from time.Time, to time.Time) (*types.WatchDigest, error) {
+	wd := types.WatchDigest{Address: *addr, From: from, To: to}
+	adr := addr.String()
+
+	// native transactions sent and received
+	var trx struct {
+		Sent     int64 `bson:"sent"`
+		Received int64 `bson:"recv"`
+		ValSent  int64 `bson:"val_out"`
+		ValRecv  int64 `bson:"val_in"`
+	}
+	if err := db.watchDigestAggregate(coTransactions, adr, fiTransactionSender, fiTransactionRecipient, fiTransactionTimeStamp, fiTransactionAmount, from, to, &trx); err != nil {
+		return nil, err
+	}
+	wd.TrxSent, wd.TrxReceived, wd.ValueSent, wd.ValueReceived = trx.Sent, trx.Received, trx.ValSent, trx.ValRecv
+
+	// token transfers sent and received
+	var erc struct {
+		Sent     int64 `bson:"sent"`
+		Received int64 `bson:"recv"`
+	}
+	if err := db.watchDigestAggregate(colErcTransactions, adr, types.FiErc20TransactionSender, types.FiErc20TransactionRecipient, types.FiErc20TransactionStamp, "", from, to, &erc); err != nil {
+		return nil, err
+	}
+	wd.Erc20Sent, wd.Erc20Received = erc.Sent, erc.Received
+	return &wd, nil
+}
+
+// watchDigestAggregate counts documents of the collection sent and received by the address
+// in the given period, summing the given value field if any.
+func (db *MongoDbBridge) watchDigestAggregate(coName string, adr string, fiFrom string, fiTo string, fiStamp string, fiValue string, from time.Time, to time.Time, res interface{}) error {
+	col := db.client.Database(db.dbName).Collection(coName)
+
+	// sum helper; counts documents on the given side of the transfer
+	sum := func(side string, val interface{}) bson.D {
+		return bson.D{{"$sum", bson.D{{"$cond", bson.A{bson.D{{"$eq", bson.A{"$" + side, adr}}}, val, 0}}}}}
+	}
+	group := bson.D{{"_id", nil}, {"sent", sum(fiFrom, 1)}, {"recv", sum(fiTo, 1)}}
+	if fiValue != "" {
+		group = append(group, bson.E{Key: "val_out", Value: sum(fiFrom, "$"+fiValue)}, bson.E{Key: "val_in", Value: sum(fiTo, "$"+fiValue)})
+	}
+
+	ld, err := col.Aggregate(db.opContext(), mongo.Pipeline{
+		{{"$match", bson.D{
+			{"$or", bson.A{bson.D{{fiFrom, adr}}, bson.D{{fiTo, adr}}}},
+			{fiStamp, bson.D{{"$gte", from}, {"$lt", to}}},
+		}}},
+		{{"$group", group}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate %s activity of %s; %s", coName, adr, err.Error())
+		return err
+	}
+	defer func() {
+		if err := ld.Close(context.Background()); err != nil {
+			db.log.Errorf("error closing watch digest cursor; %s", err.Error())
+		}
+	}()
+
+	// no activity at all
+	if !ld.Next(db.opContext()) {
+		return nil
+	}
+	return ld.Decode(res)
+}
+
+// AddWatchDigest stores the activity digest of a watched address.
+func (db *MongoDbBridge) AddWatchDigest(wd *types.WatchDigest) error {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colWatchDigest)
+
+	// try to do the upsert
+	if _, err := col.ReplaceOne(
+		db.opContext(),
+		bson.D{{types.FiWatchDigestPk, wd.Pk()}},
+		wd,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store watch digest of %s; %s", wd.Address.String(), err.Error())
+		return err
+	}
+
+	// make sure the collection is initialized
+	if db.initWatchDigest != nil {
+		db.initWatchDigest.Do(func() { db.initWatchDigestCollection(col); db.initWatchDigest = nil })
+	}
+	return nil
+}
+
+// WatchDigests sums the stored activity digests of the given addresses since the given time.
+// Addresses without any activity in the period are not included.
+func (db *MongoDbBridge) WatchDigests(addrs []common.Address, since time.Time) ([]*types.WatchDigest, error) {
+	col := db.client.Database(db.dbName).Collection(colWatchDigest)
+
+	adr := make(bson.A, len(addrs))
+	for i, a := range addrs {
+		adr[i] = a.String()
+	}
+
+	ld, err := col.Aggregate(db.opContext(), mongo.Pipeline{
+		{{"$match", bson.D{
+			{types.FiWatchDigestAddress, bson.D{{"$in", adr}}},
+			{types.FiWatchDigestFrom, bson.D{{"$gte", since}}},
+		}}},
+		{{"$group", bson.D{
+			{"_id", "$" + types.FiWatchDigestAddress},
+			{"adr", bson.D{{"$first", "$" + types.FiWatchDigestAddress}}},
+			{"from", bson.D{{"$min", "$from"}}},
+			{"to", bson.D{{"$max", "$to"}}},
+			{"trx_out", bson.D{{"$sum", "$trx_out"}}},
+			{"trx_in", bson.D{{"$sum", "$trx_in"}}},
+			{"val_out", bson.D{{"$sum", "$val_out"}}},
+			{"val_in", bson.D{{"$sum", "$val_in"}}},
+			{"erc_out", bson.D{{"$sum", "$erc_out"}}},
+			{"erc_in", bson.D{{"$sum", "$erc_in"}}},
+		}}},
+		{{"$sort", bson.D{{"_id", 1}}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate watch digests; %s", err.Error())
+		return nil, err
+	}
+	defer func() {
+		if err := ld.Close(context.Background()); err != nil {
+			db.log.Errorf("error closing watch digest cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.WatchDigest, 0)
+	for ld.Next(db.opContext()) {
+		var row types.WatchDigest
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode watch digest; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
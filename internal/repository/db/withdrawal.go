@@ -56,7 +56,7 @@ func (db *MongoDbBridge) Withdrawal(addr *common.Address, valID *hexutil.Big, re
 	col := db.client.Database(db.dbName).Collection(colWithdrawals)
 
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(db.opContext(), bson.D{
 		{types.FiWithdrawalAddress, addr.String()},
 		{types.FiWithdrawalToValidator, valID.String()},
 		{types.FiWithdrawalRequestID, reqID.String()},
@@ -88,7 +88,7 @@ func (db *MongoDbBridge) AddWithdrawal(wr *types.WithdrawRequest) error {
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), wr); err != nil {
+	if _, err := col.InsertOne(db.opContext(), wr); err != nil {
 		db.log.Criticalf("failed to store %s to %d, %s, %s; %s",
 			wr.Address.String(),
 			wr.StakerID.ToInt().Uint64(),
@@ -136,7 +136,7 @@ func (db *MongoDbBridge) shiftClosedWithdrawRequest(col *mongo.Collection, wr *t
 	reqID := (*hexutil.Big)(new(big.Int).SetBytes(wr.RequestTrx.Bytes()[:16])).String()
 
 	// try to shift a closed withdraw request to a different reqID by updating it in the database
-	er, err := col.UpdateOne(context.Background(), bson.D{
+	er, err := col.UpdateOne(db.opContext(), bson.D{
 		{types.FiWithdrawalAddress, wr.Address.String()},
 		{types.FiWithdrawalToValidator, wr.StakerID.String()},
 		{types.FiWithdrawalRequestID, wr.WithdrawRequestID.String()},
@@ -187,7 +187,7 @@ func (db *MongoDbBridge) UpdateWithdrawal(wr *types.WithdrawRequest) error {
 
 	// try to update a withdraw request by replacing it in the database
 	// we use request ID identify unique withdrawal
-	er, err := col.UpdateOne(context.Background(), bson.D{
+	er, err := col.UpdateOne(db.opContext(), bson.D{
 		{types.FiWithdrawalAddress, wr.Address.String()},
 		{types.FiWithdrawalToValidator, wr.StakerID.String()},
 		{types.FiWithdrawalRequestID, wr.WithdrawRequestID.String()},
@@ -217,7 +217,7 @@ func (db *MongoDbBridge) UpdateWithdrawal(wr *types.WithdrawRequest) error {
 // isWithdrawalKnown checks if the given delegation exists in the database.
 func (db *MongoDbBridge) isWithdrawalKnown(col *mongo.Collection, wr *types.WithdrawRequest) bool {
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(db.opContext(), bson.D{
 		{types.FiWithdrawalAddress, wr.Address.String()},
 		{types.FiWithdrawalToValidator, wr.StakerID.String()},
 		{types.FiWithdrawalRequestID, wr.WithdrawRequestID.String()},
@@ -257,7 +257,7 @@ func (db *MongoDbBridge) wrListInit(col *mongo.Collection, cursor *string, count
 	}
 
 	// find how many transactions do we have in the database
-	total, err := col.CountDocuments(context.Background(), *filter)
+	total, err := col.CountDocuments(db.opContext(), *filter)
 	if err != nil {
 		db.log.Errorf("can not count withdraw requests")
 		return nil, err
@@ -306,9 +306,13 @@ func (db *MongoDbBridge) wrListCollectRangeMarks(col *mongo.Collection, list *ty
 
 	} else if cursor != nil {
 		// the cursor itself is the starting point
-		list.First, err = db.wrListBorderPk(col,
-			bson.D{{types.FiWithdrawalPk, *cursor}},
-			options.FindOne())
+		var ok bool
+		if list.First, ok = types.DecodeListCursor(*cursor); !ok {
+			// legacy cursor; find the ordinal index of the referenced request
+			list.First, err = db.wrListBorderPk(col,
+				bson.D{{types.FiWithdrawalPk, *cursor}},
+				options.FindOne())
+		}
 	}
 
 	// check the error
@@ -331,7 +335,7 @@ func (db *MongoDbBridge) wrListBorderPk(col *mongo.Collection, filter bson.D, op
 
 	// make sure we pull only what we need
 	opt.SetProjection(bson.D{{types.FiWithdrawalOrdinal, true}})
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(db.opContext(), filter, opt)
 
 	// try to decode
 	if err := sr.Decode(&row); err != nil {
@@ -390,7 +394,7 @@ func (db *MongoDbBridge) wrListOptions(count int32) *options.FindOptions {
 // wrListLoad load the initialized list of withdraw requests from database.
 func (db *MongoDbBridge) wrListLoad(col *mongo.Collection, cursor *string, count int32, list *types.WithdrawRequestList) (err error) {
 	// get the context for loader
-	ctx := context.Background()
+	ctx := db.opContext()
 
 	// load the data
 	ld, err := col.Find(ctx, db.wrListFilter(cursor, count, list), db.wrListOptions(count))
@@ -492,7 +496,7 @@ func (db *MongoDbBridge) sumFieldValue(col *mongo.Collection, field string, filt
 	sb.WriteString(field)
 
 	// get the collection
-	cr, err := col.Aggregate(context.Background(), mongo.Pipeline{
+	cr, err := col.Aggregate(db.opContext(), mongo.Pipeline{
 		{{"$match", filter}},
 		{{"$group", bson.D{
 			{"_id", nil},
@@ -517,7 +521,7 @@ func (db *MongoDbBridge) readAggregatedSumFieldValue(cr *mongo.Cursor, decCorrec
 	}()
 
 	// do we have any data to read?
-	if !cr.Next(context.Background()) {
+	if !cr.Next(db.opContext()) {
 		return new(big.Int), nil
 	}
 
@@ -3,6 +3,7 @@ package repository
 import (
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -24,9 +25,35 @@ func (p *proxy) DefiTokens() ([]types.DefiToken, error) {
 }
 
 // DefiTokenPrice loads the current price of the given token
-// from on-chain price oracle.
+// from on-chain price oracle. The wrapped FTM not known to the oracle
+// is priced as the native FTM.
 func (p *proxy) DefiTokenPrice(token *common.Address) (hexutil.Big, error) {
-	return p.rpc.FMintTokenPrice(token)
+	val, err := p.rpc.FMintTokenPrice(token)
+	if err != nil || val.ToInt().Sign() > 0 || *token != p.cfg.DeFi.Uniswap.WrappedNative {
+		return val, err
+	}
+	return p.nativeTokenPrice(), nil
+}
+
+// nativeTokenPrice provides the price of the native FTM in the oracle denomination,
+// i.e. USD with 18 decimals. Zero is returned if the price is not available.
+func (p *proxy) nativeTokenPrice() hexutil.Big {
+	if !p.isValidPriceSymbol(uniswapPriceStable) {
+		return hexutil.Big{}
+	}
+
+	pri, err := p.Price(uniswapPriceStable)
+	if err != nil || pri.Price <= 0 {
+		return hexutil.Big{}
+	}
+
+	val, _ := new(big.Float).Mul(big.NewFloat(pri.Price), big.NewFloat(1e18)).Int(nil)
+	return hexutil.Big(*val)
+}
+
+// WrappedNativeAddress returns the address of the configured wrapped FTM token contract.
+func (p *proxy) WrappedNativeAddress() common.Address {
+	return p.cfg.DeFi.Uniswap.WrappedNative
 }
 
 // FMintAccount loads details of a DeFi/fMint account identified by the owner address.
@@ -80,6 +107,12 @@ func (p *proxy) FMintCanPushRewards() (bool, error) {
 	return p.rpc.FMintCanPushRewards()
 }
 
+// FMintRewardsSchedule resolves the state of the rewards distribution
+// relevant to the rewards claiming schedule of the given fMint account.
+func (p *proxy) FMintRewardsSchedule(addr *common.Address) (*types.FMintRewardsSchedule, error) {
+	return p.rpc.FMintRewardsSchedule(addr)
+}
+
 // FLendGetLendingPool resolves lending pool contract instace
 // to be able to get calls and informations from this contract
 func (p *proxy) FLendGetLendingPool() (*contracts.ILendingPool, error) {
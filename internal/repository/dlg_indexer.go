@@ -0,0 +1,64 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"sync"
+)
+
+// delegatorsIndexer represents a service keeping the number of delegators of validators indexed.
+type delegatorsIndexer struct {
+	service
+}
+
+// newDelegatorsIndexer creates a new validators delegators indexer service.
+func newDelegatorsIndexer(repo Repository, log logger.Logger, wg *sync.WaitGroup) *delegatorsIndexer {
+	return &delegatorsIndexer{
+		service: newPeriodicService("delegators indexer", repo, log, wg),
+	}
+}
+
+// run starts the delegators indexer service
+func (dci *delegatorsIndexer) run() {
+	dci.wg.Add(1)
+	go dci.schedule()
+}
+
+// schedule schedules regular delegators index updates.
+func (dci *delegatorsIndexer) schedule() {
+	// inform about the service
+	dci.log.Notice("delegators indexer is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		dci.log.Notice("delegators indexer is closed")
+		dci.wg.Done()
+	}()
+
+	// build the index right away
+	dci.update()
+
+	// run on schedule
+	dci.loop(func() {
+		dci.update()
+	})
+}
+
+// update refreshes the delegators count index; the index is built by the leading
+// API server instance, but it's kept in memory of each of them.
+func (dci *delegatorsIndexer) update() {
+	var idx map[string]uint64
+	if dci.shared(&idx, func() (err error) {
+		idx, err = dci.repo.ValidatorsDelegatorsIndex()
+		return err
+	}) {
+		dci.repo.IndexValidatorsDelegators(idx)
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// epochStatsUpdater represents a service calculating aggregated statistics of sealed epochs.
+type epochStatsUpdater struct {
+	service
+}
+
+// newEpochStatsUpdater creates a new epoch statistics updater service.
+func newEpochStatsUpdater(repo Repository, log logger.Logger, wg *sync.WaitGroup) *epochStatsUpdater {
+	return &epochStatsUpdater{
+		service: newPeriodicService("epoch stats updater", repo, log, wg),
+	}
+}
+
+// run starts the epoch statistics updater service
+func (esu *epochStatsUpdater) run() {
+	esu.wg.Add(1)
+	go esu.schedule()
+}
+
+// schedule schedules regular epoch statistics updates.
+func (esu *epochStatsUpdater) schedule() {
+	// inform about the service
+	esu.log.Notice("epoch stats updater is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		esu.log.Notice("epoch stats updater is closed")
+		esu.wg.Done()
+	}()
+
+	// run on schedule
+	esu.loop(func() {
+		esu.exclusive(esu.update)
+	})
+}
+
+// update calculates statistics of a batch of epochs without them.
+func (esu *epochStatsUpdater) update() {
+	list, err := esu.repo.EpochsWithoutStats()
+	if err != nil {
+		esu.log.Errorf("can not get epochs without stats; %s", err.Error())
+		return
+	}
+
+	// process the batch
+	for _, ep := range list {
+		// check for the stop signal between epochs
+		select {
+		case <-esu.sigStop:
+			// pass the signal to the scheduler
+			esu.sigStop <- true
+			return
+		default:
+		}
+
+		if err := esu.repo.UpdateEpochStats(ep); err != nil {
+			esu.log.Errorf("can not update epoch #%d stats; %s", ep.Id, err.Error())
+			return
+		}
+	}
+}
+
+// EpochsWithoutStats provides a batch of stored epochs without aggregated statistics.
+func (p *proxy) EpochsWithoutStats() ([]*types.Epoch, error) {
+	return p.db.EpochsWithoutStats()
+}
+
+// UpdateEpochStats calculates and stores aggregated statistics of the given epoch.
+func (p *proxy) UpdateEpochStats(ep *types.Epoch) error {
+	// get the previous epoch so we know where this one started
+	var prev *types.Epoch
+	if ep.Id > 1 {
+		pid := ep.Id - 1
+		var err error
+		if prev, err = p.Epoch(&pid); err != nil {
+			return err
+		}
+	}
+
+	// calculate the epoch range
+	st := types.EpochStats{}
+	from := time.Unix(int64(ep.EndTime), 0)
+	if prev != nil && prev.EndTime < ep.EndTime {
+		from = time.Unix(int64(prev.EndTime), 0)
+		st.Duration = uint64(ep.EndTime - prev.EndTime)
+	}
+
+	// aggregate transactions
+	var err error
+	st.TxCount, st.GasUsed, err = p.db.TrxRangeStats(from, time.Unix(int64(ep.EndTime), 0))
+	if err != nil {
+		return err
+	}
+
+	// collect validators participation
+	st.Validators, st.OfflineValidators, err = p.rpc.EpochParticipation(ep.Id)
+	if err != nil {
+		return err
+	}
+
+	// realized APR of the validators needs the epoch duration
+	if st.Duration > 0 {
+		if err := p.updateValidatorApr(ep, st.Duration); err != nil {
+			return err
+		}
+	}
+
+	// store the stats
+	if err := p.db.SetEpochStats(ep.Id, &st); err != nil {
+		return err
+	}
+
+	p.cache.PushEpochStats(ep.Id, &st)
+	p.log.Debugf("epoch #%d stats updated", ep.Id)
+	return nil
+}
+
+// EpochStats provides aggregated statistics of the given epoch.
+// It returns nil if the statistics has not been calculated yet.
+func (p *proxy) EpochStats(id hexutil.Uint64) (*types.EpochStats, error) {
+	// try the cache first
+	if st := p.cache.PullEpochStats(id); st != nil {
+		return st, nil
+	}
+
+	// load from the database
+	st, err := p.db.EpochStats(id)
+	if err != nil || st == nil {
+		return nil, err
+	}
+
+	// keep it in cache for future use
+	p.cache.PushEpochStats(id, st)
+	return st, nil
+}
+
+// updateValidatorApr calculates and stores the realized APR of validators
+// participating on the given sealed epoch of the given duration in seconds.
+func (p *proxy) updateValidatorApr(ep *types.Epoch, dur uint64) error {
+	rew, err := p.rpc.EpochRewardPerToken(ep.Id)
+	if err != nil || len(rew) == 0 {
+		return err
+	}
+
+	// the commission is shared by all the validators; the APR is stored without it if not available
+	commission, err := p.epochCommission(ep)
+	if err != nil {
+		p.log.Warningf("validator commission of epoch #%d not available; %s", ep.Id, err.Error())
+	}
+
+	// APR is the reward per token extrapolated to the whole year
+	div := new(big.Int).Mul(new(big.Int).SetUint64(dur), p.SfcDecimalUnit())
+	list := make([]*types.ValidatorApr, 0, len(rew))
+	var total float64
+	for id, val := range rew {
+		apr, _ := new(big.Rat).SetFrac(new(big.Int).Mul(val, big.NewInt(secondsPerYear)), div).Float64()
+		list = append(list, &types.ValidatorApr{
+			ValidatorId: id,
+			Epoch:       uint64(ep.Id),
+			Time:        time.Unix(int64(ep.EndTime), 0).UTC(),
+			Apr:         apr,
+			Commission:  commission,
+		})
+		total += apr
+	}
+
+	// the network average is shared by all the records of the epoch
+	for _, va := range list {
+		va.NetworkApr = total / float64(len(list))
+	}
+	return p.db.StoreValidatorApr(list)
+}
+
+// epochCommission provides the validator commission in effect at the end of the given sealed epoch.
+func (p *proxy) epochCommission(ep *types.Epoch) (*float64, error) {
+	bn, err := p.db.LastBlockBefore(time.Unix(int64(ep.EndTime), 0))
+	if err != nil {
+		return nil, err
+	}
+
+	com, err := p.rpc.SfcValidatorCommission(new(big.Int).SetUint64(bn))
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := p.sfcRatio(com)
+	return &ratio, nil
+}
+
+// ValidatorAprHistory provides the realized APR of the given validator
+// in the given range of sealed epochs.
+func (p *proxy) ValidatorAprHistory(valID *hexutil.Big, from hexutil.Uint64, to hexutil.Uint64) ([]*types.ValidatorApr, error) {
+	return p.db.ValidatorAprHistory(valID.ToInt().Uint64(), uint64(from), uint64(to))
+}
+
+// ValidatorCommissionHistory provides the commission of the given validator at the start
+// of the given range of sealed epochs and each change of it within the range.
+// Epochs without the commission recorded are skipped; a zero commission is a valid value.
+func (p *proxy) ValidatorCommissionHistory(valID *hexutil.Big, from hexutil.Uint64, to hexutil.Uint64) ([]*types.CommissionChange, error) {
+	list, err := p.db.ValidatorAprHistory(valID.ToInt().Uint64(), uint64(from), uint64(to))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.CommissionChange, 0)
+	for _, va := range list {
+		if va.Commission == nil || (len(res) > 0 && res[len(res)-1].Commission == *va.Commission) {
+			continue
+		}
+		res = append(res, &types.CommissionChange{Epoch: va.Epoch, Time: va.Time, Commission: *va.Commission})
+	}
+	return res, nil
+}
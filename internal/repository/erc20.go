@@ -84,6 +84,12 @@ func (p *proxy) Erc20BalanceOf(token *common.Address, owner *common.Address) (he
 	return p.rpc.Erc20BalanceOf(token, owner)
 }
 
+// Erc20BalancesOf loads the current available balances of the given list
+// of ERC20 tokens for the identified owner address in batches.
+func (p *proxy) Erc20BalancesOf(tokens []common.Address, owner *common.Address) ([]hexutil.Big, error) {
+	return p.rpc.Erc20BalancesOf(tokens, owner)
+}
+
 // Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
 // contract by the token owner.
 func (p *proxy) Erc20Allowance(token *common.Address, owner *common.Address, spender *common.Address) (hexutil.Big, error) {
@@ -101,7 +107,13 @@ func (p *proxy) Erc20TokensList(count int32) ([]common.Address, error) {
 }
 
 // Erc20LogoURL provides URL address of a logo of the ERC20 token.
+// The token metadata registry takes precedence over the configured logo map.
 func (p *proxy) Erc20LogoURL(addr *common.Address) string {
+	// does the registry know the logo?
+	if tm, err := p.TokenMeta(addr); err == nil && tm != nil && tm.LogoURL != "" {
+		return tm.LogoURL
+	}
+
 	// do we know the token?
 	logo, ok := p.cfg.TokenLogo[*addr]
 	if !ok {
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	retypes "github.com/ethereum/go-ethereum/core/types"
 	"go.mongodb.org/mongo-driver/bson"
+	"math"
 	"math/big"
 )
 
@@ -61,6 +62,29 @@ func (p *proxy) Erc20Transactions(token *common.Address, acc *common.Address, tt
 	return p.db.Erc20Transactions(cursor, count, &fi)
 }
 
+// LargeErc20Transfers provides list of transfers of the given ERC20 token
+// of at least the given amount.
+func (p *proxy) LargeErc20Transfers(token *common.Address, minValue *big.Int, cursor *string, count int32) (*types.Erc20TransactionList, error) {
+	// the stored value is in 10^-9 units of the amount
+	min := new(big.Int).Div(minValue, types.TransactionDecimalsCorrection)
+	if !min.IsInt64() {
+		min.SetInt64(math.MaxInt64)
+	}
+
+	fi := bson.D{
+		{Key: types.FiErc20TransactionToken, Value: token.String()},
+		{Key: types.FiErc20TransactionType, Value: types.ERC20TrxTypeTransfer},
+		{Key: types.FiErc20TransactionValue, Value: bson.D{{Key: "$gte", Value: min.Int64()}}},
+	}
+	return p.db.Erc20Transactions(cursor, count, &fi)
+}
+
+// Erc20Approvals provides the latest approval of each ERC20 token
+// and spender pair granted by the given owner.
+func (p *proxy) Erc20Approvals(owner *common.Address) ([]*types.Erc20Transaction, error) {
+	return p.db.Erc20Approvals(owner)
+}
+
 // handleErc20Approval handles Approval event on an ERC20 token.
 // event Approval(address indexed owner, address indexed spender, uint256 value)
 func handleErc20Approval(log *retypes.Log, ld *logsDispatcher) {
@@ -0,0 +1,30 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"errors"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+)
+
+// ErrRateLimited represents an error returned if an external service
+// refused to process the request due to the rate limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// ComplianceError represents an error returned if the compliance screening
+// denied an address involved in the call.
+type ComplianceError struct {
+	Match types.ScreeningMatch
+}
+
+// Error returns the text of the error.
+func (e *ComplianceError) Error() string {
+	return fmt.Sprintf("address %s is denied by compliance list %s", e.Match.Address.String(), e.Match.List)
+}
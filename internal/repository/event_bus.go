@@ -0,0 +1,80 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"sync"
+)
+
+// eventBus implements internal bus of repository events consumed
+// by the interested parties, namely the cache layer.
+type eventBus struct {
+	mu      sync.RWMutex
+	onHead  []func(*types.Block)
+	onTouch []func(*common.Address)
+}
+
+// newEventBus creates a new event bus with the in-memory cache handlers
+// and the finality tracker registered.
+func newEventBus(p *proxy) *eventBus {
+	eb := new(eventBus)
+	eb.subscribeNewHead(p.cache.OnNewHead)
+	eb.subscribeAccountTouched(p.cache.OnAccountTouched)
+
+	// keep track of the finality latency of new blocks
+	eb.subscribeNewHead(p.trackFinality)
+	return eb
+}
+
+// subscribeNewHead registers a handler of the new head events.
+func (eb *eventBus) subscribeNewHead(fn func(*types.Block)) {
+	eb.mu.Lock()
+	eb.onHead = append(eb.onHead, fn)
+	eb.mu.Unlock()
+}
+
+// subscribeAccountTouched registers a handler of the account touched events.
+func (eb *eventBus) subscribeAccountTouched(fn func(*common.Address)) {
+	eb.mu.Lock()
+	eb.onTouch = append(eb.onTouch, fn)
+	eb.mu.Unlock()
+}
+
+// publishNewHead delivers the new head event to all the subscribers.
+func (eb *eventBus) publishNewHead(blk *types.Block) {
+	eb.mu.RLock()
+	defer eb.mu.RUnlock()
+
+	for _, fn := range eb.onHead {
+		fn(blk)
+	}
+}
+
+// publishAccountTouched delivers the account touched event to all the subscribers.
+func (eb *eventBus) publishAccountTouched(addr *common.Address) {
+	eb.mu.RLock()
+	defer eb.mu.RUnlock()
+
+	for _, fn := range eb.onTouch {
+		fn(addr)
+	}
+}
+
+// NotifyNewHead publishes the new head block event.
+func (p *proxy) NotifyNewHead(blk *types.Block) {
+	p.bus.publishNewHead(blk)
+}
+
+// NotifyAccountTouched publishes the event of an account being touched
+// by a transaction, so the state of the account changed.
+func (p *proxy) NotifyAccountTouched(addr *common.Address) {
+	p.bus.publishAccountTouched(addr)
+}
@@ -0,0 +1,228 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// fMintStatsMaxAge is the max age of the fMint metrics; they are refreshed
+// sooner if the price of any of the DeFi tokens changes.
+const fMintStatsMaxAge = 10 * time.Minute
+
+// fMintRatioDecimals is the correction of collateral ratios represented in 4 digits.
+var fMintRatioDecimals = big.NewInt(10000)
+
+// fMintAggregator represents a service aggregating protocol level fMint metrics
+// over the indexed fMint accounts.
+type fMintAggregator struct {
+	service
+	prices  map[common.Address]string
+	updated time.Time
+}
+
+// newFMintAggregator creates a new fMint stats aggregator service.
+func newFMintAggregator(repo Repository, log logger.Logger, wg *sync.WaitGroup) *fMintAggregator {
+	return &fMintAggregator{
+		service: newPeriodicService("fmint aggregator", repo, log, wg),
+	}
+}
+
+// run starts the fMint aggregator service
+func (fa *fMintAggregator) run() {
+	fa.wg.Add(1)
+	go fa.schedule()
+}
+
+// schedule schedules regular aggregation of the fMint metrics.
+func (fa *fMintAggregator) schedule() {
+	// inform about the service
+	fa.log.Notice("fmint aggregator is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		fa.log.Notice("fmint aggregator is closed")
+		fa.wg.Done()
+	}()
+
+	// run on schedule
+	fa.loop(func() {
+		fa.exclusive(func() {
+			if !fa.pricesChanged() && time.Since(fa.updated) < fMintStatsMaxAge {
+				return
+			}
+
+			if err := fa.repo.AggregateFMintStats(); err != nil {
+				fa.log.Errorf("can not aggregate fMint stats; %s", err.Error())
+				return
+			}
+			fa.updated = time.Now()
+		})
+	})
+}
+
+// pricesChanged checks if the on-chain price of any of the DeFi tokens changed
+// since the previous check.
+func (fa *fMintAggregator) pricesChanged() bool {
+	tokens, err := fa.repo.DefiTokens()
+	if err != nil {
+		fa.log.Errorf("can not check DeFi token prices; %s", err.Error())
+		return false
+	}
+
+	changed := false
+	prices := make(map[common.Address]string, len(tokens))
+	for _, tk := range tokens {
+		pri, err := fa.repo.DefiTokenPrice(&tk.Address)
+		if err != nil {
+			return false
+		}
+
+		prices[tk.Address] = pri.String()
+		if prices[tk.Address] != fa.prices[tk.Address] {
+			changed = true
+		}
+	}
+
+	fa.prices = prices
+	return changed
+}
+
+// handleFMintDeposited indexes the account depositing a collateral to the fMint protocol.
+// event Deposited(address indexed token, address indexed user, uint256 amount)
+func handleFMintDeposited(log *retypes.Log, ld *logsDispatcher) {
+	// the same event signature may be used by other contracts
+	if log.Address != ld.repo.FMintMinterAddress() {
+		return
+	}
+
+	// sanity check for data
+	if len(log.Topics) != 3 {
+		ld.log.Errorf("%s log invalid for fMint deposit; expected 3 topics, %d given", log.TxHash.String(), len(log.Topics))
+		return
+	}
+
+	addr := common.BytesToAddress(log.Topics[2].Bytes())
+	if err := ld.repo.AddFMintAccount(&addr); err != nil {
+		ld.log.Errorf("can not index fMint account %s; %s", addr.String(), err.Error())
+	}
+}
+
+// FMintMinterAddress returns the address of the fMint minter contract.
+func (p *proxy) FMintMinterAddress() common.Address {
+	return p.rpc.FMintMinterAddress()
+}
+
+// AddFMintAccount adds the address to the indexed fMint accounts.
+func (p *proxy) AddFMintAccount(addr *common.Address) error {
+	return p.db.AddFMintAccount(addr)
+}
+
+// FMintStats loads the latest snapshot of the protocol level fMint metrics.
+// It returns nil if the metrics have not been aggregated yet.
+func (p *proxy) FMintStats() (*types.FMintStats, error) {
+	return p.db.LastFMintStats()
+}
+
+// AggregateFMintStats aggregates the protocol level fMint metrics
+// over the indexed fMint accounts and stores the snapshot.
+func (p *proxy) AggregateFMintStats() (err error) {
+	fs := types.FMintStats{
+		Time:          time.Now().UTC().Truncate(time.Second),
+		WarningRatio4: p.cfg.DeFi.FMint.WarningRatio4,
+	}
+
+	// pool totals
+	fs.Collateral, fs.CollateralValue, err = p.rpc.FMintPoolTotals(types.DefiTokenTypeCollateral)
+	if err != nil {
+		return err
+	}
+	fs.Debt, fs.DebtValue, err = p.rpc.FMintPoolTotals(types.DefiTokenTypeDebt)
+	if err != nil {
+		return err
+	}
+
+	// check the indexed accounts
+	accounts, err := p.db.FMintAccounts()
+	if err != nil {
+		return err
+	}
+
+	for i := range accounts {
+		fa, err := p.refreshFMintAccount(&accounts[i])
+		if err != nil {
+			return err
+		}
+
+		// accounts without any debt are not at risk
+		if fa.DebtValue.ToInt().Sign() == 0 {
+			continue
+		}
+		fs.ActiveAccounts++
+
+		if fa.Ratio4 < fs.WarningRatio4 {
+			fs.AccountsAtRisk++
+		}
+	}
+	fs.Accounts = int32(len(accounts))
+
+	p.log.Infof("fMint stats aggregated over %d accounts, %d at risk", fs.Accounts, fs.AccountsAtRisk)
+	return p.db.AddFMintStats(&fs)
+}
+
+// refreshFMintAccount loads the current state of the fMint account with its positions
+// and stores it so the accounts can be searched by the collateral ratio.
+func (p *proxy) refreshFMintAccount(addr *common.Address) (*types.FMintAccountState, error) {
+	da, err := p.rpc.FMintAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	fa := types.FMintAccountState{
+		Address:         *addr,
+		CollateralValue: da.CollateralValue,
+		DebtValue:       da.DebtValue,
+		Updated:         time.Now().UTC(),
+	}
+
+	// positions are loaded only for accounts with a debt; others can not be liquidated
+	if fa.DebtValue.ToInt().Sign() > 0 {
+		ratio := new(big.Int).Mul(fa.CollateralValue.ToInt(), fMintRatioDecimals)
+		fa.Ratio4 = ratio.Div(ratio, fa.DebtValue.ToInt()).Uint64()
+
+		if fa.Collateral, err = p.rpc.FMintAccountPositions(addr, types.DefiTokenTypeCollateral); err != nil {
+			return nil, err
+		}
+		if fa.Debt, err = p.rpc.FMintAccountPositions(addr, types.DefiTokenTypeDebt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.db.SetFMintAccountState(&fa); err != nil {
+		return nil, err
+	}
+	return &fa, nil
+}
+
+// FMintAccountsAtRisk loads a page of fMint accounts with a debt and the collateral ratio
+// below the given ratio in 4 digits, sorted from the lowest ratio. The configured
+// warning ratio is used if the ratio is not given.
+func (p *proxy) FMintAccountsAtRisk(ratio4 *uint64, cursor *string, count int32) (*types.FMintAccountStateList, error) {
+	if ratio4 == nil {
+		ratio4 = &p.cfg.DeFi.FMint.WarningRatio4
+	}
+	return p.db.FMintAccountsBelowRatio(*ratio4, cursor, count)
+}
@@ -0,0 +1,66 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ResolveName resolves the address assigned to the given FNS domain name.
+// It returns nil if the name service is not configured, or the name is not known.
+func (p *proxy) ResolveName(name string) (*common.Address, error) {
+	// is the name service available?
+	if !p.rpc.FnsEnabled() || name == "" {
+		return nil, nil
+	}
+
+	// try the cache first
+	if adr := p.cache.PullDomainAddress(name); adr != nil {
+		return adr, nil
+	}
+
+	// resolve the name using the registry
+	adr, err := p.rpc.FnsResolveName(name)
+	if err != nil || adr == nil {
+		return nil, err
+	}
+
+	// keep it in cache for future use
+	p.cache.PushDomainAddress(name, adr)
+	return adr, nil
+}
+
+// DomainName resolves the primary FNS domain name of the given address.
+// It returns nil if the name service is not configured, or no name is assigned.
+func (p *proxy) DomainName(addr *common.Address) (*string, error) {
+	// is the name service available?
+	if !p.rpc.FnsEnabled() {
+		return nil, nil
+	}
+
+	// try the cache first; an empty name is cached as well
+	name := p.cache.PullDomainName(addr)
+	if name == nil {
+		// resolve the name using the registry
+		val, err := p.rpc.FnsReverseName(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		// keep the name in cache
+		p.cache.PushDomainName(addr, val)
+		name = &val
+	}
+
+	// no name assigned?
+	if *name == "" {
+		return nil, nil
+	}
+	return name, nil
+}
@@ -0,0 +1,147 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sync"
+)
+
+const (
+	// supplyTrackerBatchSize is the max number of epochs processed by a single update.
+	supplyTrackerBatchSize = 500
+)
+
+// supplyTracker represents a service tracking the native FTM supply on sealed epochs.
+type supplyTracker struct {
+	service
+}
+
+// newSupplyTracker creates a new FTM supply tracker service.
+func newSupplyTracker(repo Repository, log logger.Logger, wg *sync.WaitGroup) *supplyTracker {
+	return &supplyTracker{
+		service: newPeriodicService("supply tracker", repo, log, wg),
+	}
+}
+
+// run starts the supply tracker service
+func (sut *supplyTracker) run() {
+	sut.wg.Add(1)
+	go sut.schedule()
+}
+
+// schedule schedules regular supply history updates.
+func (sut *supplyTracker) schedule() {
+	// inform about the service
+	sut.log.Notice("supply tracker is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		sut.log.Notice("supply tracker is closed")
+		sut.wg.Done()
+	}()
+
+	// run on schedule
+	sut.loop(func() {
+		sut.exclusive(func() {
+			if err := sut.repo.UpdateFtmSupplyHistory(); err != nil {
+				sut.log.Errorf("can not update supply history; %s", err.Error())
+			}
+		})
+	})
+}
+
+// UpdateFtmSupplyHistory adds a batch of sealed epochs following the latest tracked one
+// into the FTM supply history.
+func (p *proxy) UpdateFtmSupplyHistory() error {
+	prev, err := p.db.LastFtmSupply()
+	if err != nil {
+		return err
+	}
+
+	// find epochs not tracked yet
+	var last uint64
+	if prev != nil {
+		last = uint64(prev.Epoch)
+	}
+	list, err := p.db.EpochsAfter(last, supplyTrackerBatchSize)
+	if err != nil {
+		return err
+	}
+
+	// process the batch in order, each record builds on the previous one
+	for _, ep := range list {
+		fs := ftmSupplyOfEpoch(ep, prev)
+		if err := p.db.AddFtmSupply(fs); err != nil {
+			return err
+		}
+		prev = fs
+	}
+
+	if len(list) > 0 {
+		p.log.Debugf("supply history updated up to epoch #%d", prev.Epoch)
+	}
+	return nil
+}
+
+// ftmSupplyOfEpoch calculates the supply record of the given epoch.
+// All the epoch fees are removed from the circulation when the epoch is sealed,
+// the minted amount is derived from the supply change of consecutive epochs.
+func ftmSupplyOfEpoch(ep *types.Epoch, prev *types.FtmSupply) *types.FtmSupply {
+	fs := types.FtmSupply{
+		Epoch:       ep.Id,
+		EndTime:     ep.EndTime,
+		TotalSupply: ep.TotalSupply,
+		Staked:      ep.StakeTotalAmount,
+		Burned:      ep.EpochFee,
+		TotalBurned: ep.EpochFee,
+	}
+
+	// we can not calculate changes without the previous epoch
+	if prev == nil {
+		return &fs
+	}
+	fs.TotalBurned = hexutil.Big(*new(big.Int).Add(prev.TotalBurned.ToInt(), ep.EpochFee.ToInt()))
+
+	// minted = supply change + burned fees
+	if prev.Epoch+1 == ep.Id {
+		mint := new(big.Int).Sub(ep.TotalSupply.ToInt(), prev.TotalSupply.ToInt())
+		mint.Add(mint, ep.EpochFee.ToInt())
+		if mint.Sign() > 0 {
+			fs.Minted = hexutil.Big(*mint)
+		}
+	}
+	return &fs
+}
+
+// FtmSupply provides the latest tracked state of the native FTM supply.
+func (p *proxy) FtmSupply() (*types.FtmSupply, error) {
+	fs, err := p.db.LastFtmSupply()
+	if err != nil {
+		return nil, err
+	}
+
+	// nothing tracked yet, use the latest sealed epoch
+	if fs == nil {
+		ep, err := p.CurrentSealedEpoch()
+		if err != nil {
+			return nil, err
+		}
+		fs = ftmSupplyOfEpoch(ep, nil)
+	}
+	return fs, nil
+}
+
+// FtmSupplyHistory provides the FTM supply history of the given range of epochs.
+func (p *proxy) FtmSupplyHistory(from uint64, to uint64) ([]*types.FtmSupply, error) {
+	return p.db.FtmSupplyHistory(from, to)
+}
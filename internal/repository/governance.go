@@ -14,6 +14,8 @@ import (
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
 )
 
 // GovernanceProposalsCount provides the total number of proposals
@@ -70,6 +72,12 @@ func (p *proxy) GovernanceProposalFee(gov *common.Address) (hexutil.Big, error)
 	return p.rpc.GovernanceProposalFee(gov)
 }
 
+// GovernanceMaxExecutionPeriod returns the max period in seconds after the voting end
+// in which an accepted proposal has to be executed.
+func (p *proxy) GovernanceMaxExecutionPeriod(gov *common.Address) (hexutil.Uint64, error) {
+	return p.rpc.GovernanceMaxExecutionPeriod(gov)
+}
+
 // GovernanceTotalWeight provides the total weight of all available votes
 // in the governance contract identified by the address.
 func (p *proxy) GovernanceTotalWeight(gov *common.Address) (hexutil.Big, error) {
@@ -98,3 +106,56 @@ func (p *proxy) GovernanceTotalWeight(gov *common.Address) (hexutil.Big, error)
 
 	return *we, nil
 }
+
+// GovernanceVotingPower provides the voting weight of the given address
+// in the governance contract context at a past point. The point is given
+// either by a block number, or by an epoch, in which case the state
+// at the last block of the epoch is used. If neither is given,
+// the current voting weight is returned.
+func (p *proxy) GovernanceVotingPower(gov *common.Address, addr *common.Address, block *hexutil.Uint64, epoch *hexutil.Uint64) (hexutil.Big, error) {
+	// get the governance config
+	cfg, ok := p.govContracts[gov.String()]
+	if !ok {
+		return hexutil.Big{}, fmt.Errorf("unknown governance %s", gov.String())
+	}
+
+	// resolve the snapshot block
+	var blk *big.Int
+	if block != nil {
+		blk = new(big.Int).SetUint64(uint64(*block))
+	} else if epoch != nil {
+		ep, err := p.Epoch(epoch)
+		if err != nil {
+			return hexutil.Big{}, err
+		}
+
+		bn, err := p.db.LastBlockBefore(time.Unix(int64(ep.EndTime), 0))
+		if err != nil {
+			return hexutil.Big{}, err
+		}
+		blk = new(big.Int).SetUint64(bn)
+	}
+
+	// collect delegation targets of the address
+	dl, err := p.DelegationsByAddressAll(addr)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+
+	to := make([]common.Address, 0, len(dl))
+	known := make(map[common.Address]bool, len(dl))
+	for _, d := range dl {
+		if !known[d.ToStakerAddress] {
+			known[d.ToStakerAddress] = true
+			to = append(to, d.ToStakerAddress)
+		}
+	}
+
+	// pull the weight at the snapshot
+	w, err := p.rpc.GovernanceVotingPower(&cfg.Governable, *addr, to, blk)
+	if err != nil {
+		p.log.Errorf("can not pull voting power of %s on %s; %s", addr.String(), gov.String(), err.Error())
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*w), nil
+}
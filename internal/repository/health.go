@@ -0,0 +1,89 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"time"
+)
+
+// DatabaseHealth checks the health of the off-chain database.
+func (p *proxy) DatabaseHealth() types.BackendHealth {
+	lat, err := p.db.Ping()
+	return backendHealth(lat, err)
+}
+
+// NodeHealth checks the health of the block chain node.
+func (p *proxy) NodeHealth() types.NodeHealth {
+	start := time.Now()
+	h, err := p.rpc.BlockHeight()
+	nh := types.NodeHealth{BackendHealth: backendHealth(time.Since(start), err)}
+	if err != nil {
+		return nh
+	}
+	nh.Height = h.ToInt().Uint64()
+
+	// peers are not critical for the node health
+	if nh.Peers, err = p.rpc.PeerCount(); err != nil {
+		p.log.Warningf("node peers not available; %s", err.Error())
+	}
+	return nh
+}
+
+// NodeLatency provides the 95th percentile of the latency of recent block chain node calls.
+func (p *proxy) NodeLatency() time.Duration {
+	return p.rpc.Latency()
+}
+
+// DatabaseLatency provides the 95th percentile of the latency of recent database commands.
+func (p *proxy) DatabaseLatency() time.Duration {
+	return p.db.Latency()
+}
+
+// DatabasePoolStats provides the statistics of the database connection pool.
+func (p *proxy) DatabasePoolStats() types.DbPoolStats {
+	return p.db.PoolStats()
+}
+
+// StorageInfo describes the off-chain storage driver and the features it does not provide.
+func (p *proxy) StorageInfo() types.StorageInfo {
+	return p.db.StorageInfo()
+}
+
+// IndexerLag provides the number of blocks the off-chain database is behind the chain head.
+func (p *proxy) IndexerLag() (uint64, error) {
+	h, err := p.rpc.BlockHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	lnb, err := p.db.LastKnownBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	// the node may be behind the database while it re-syncs
+	if head := h.ToInt().Uint64(); head > lnb {
+		return head - lnb, nil
+	}
+	return 0, nil
+}
+
+// CacheStats provides the statistics of the in-memory cache.
+func (p *proxy) CacheStats() types.CacheStats {
+	return p.cache.Stats()
+}
+
+// backendHealth builds the health of a backend from the result of its check.
+func backendHealth(lat time.Duration, err error) types.BackendHealth {
+	if err != nil {
+		return types.BackendHealth{Latency: lat, Error: err.Error()}
+	}
+	return types.BackendHealth{Healthy: true, Latency: lat}
+}
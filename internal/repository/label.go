@@ -0,0 +1,61 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// AddressLabel provides the public label assigned to the given address.
+// It returns nil if the address does not have any label.
+func (p *proxy) AddressLabel(addr *common.Address) (*types.AddressLabel, error) {
+	// try the cache first; addresses without label are cached as well
+	if al, ok := p.cache.PullAddressLabel(addr); ok {
+		return al, nil
+	}
+
+	// load the label from database
+	al, err := p.db.AddressLabel(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep it in cache for future use
+	p.cache.PushAddressLabel(addr, al)
+	return al, nil
+}
+
+// StoreAddressLabel stores the given address label, replacing any previous one.
+func (p *proxy) StoreAddressLabel(al *types.AddressLabel) error {
+	// mark the update time
+	al.Updated = time.Now().UTC()
+
+	// store the label
+	if err := p.db.StoreAddressLabel(al); err != nil {
+		return err
+	}
+
+	// update the cached value
+	p.cache.PushAddressLabel(&al.Address, al)
+	return nil
+}
+
+// RemoveAddressLabel removes the public label of the given address.
+func (p *proxy) RemoveAddressLabel(addr *common.Address) error {
+	// remove the label
+	if err := p.db.RemoveAddressLabel(addr); err != nil {
+		return err
+	}
+
+	// drop the cached value
+	p.cache.EvictAddressLabel(addr)
+	return nil
+}
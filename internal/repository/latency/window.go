@@ -0,0 +1,57 @@
+// Package latency implements tracking of the recent latency of backend calls.
+package latency
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// windowSize is the max number of the recent samples kept by the window.
+const windowSize = 512
+
+// windowAge is the max age of samples considered by the window.
+const windowAge = time.Minute
+
+// sample represents a single observed call latency.
+type sample struct {
+	at  time.Time
+	dur time.Duration
+}
+
+// Window collects the latency of recent calls in a fixed size ring.
+type Window struct {
+	mu   sync.Mutex
+	ring [windowSize]sample
+	next int
+}
+
+// Observe records the latency of a finished call.
+func (w *Window) Observe(dur time.Duration) {
+	w.mu.Lock()
+	w.ring[w.next] = sample{at: time.Now(), dur: dur}
+	w.next = (w.next + 1) % windowSize
+	w.mu.Unlock()
+}
+
+// P95 calculates the 95th percentile of the latency of calls
+// observed in the last minute; zero if there were no calls.
+func (w *Window) P95() time.Duration {
+	since := time.Now().Add(-windowAge)
+	list := make([]time.Duration, 0, windowSize)
+
+	w.mu.Lock()
+	for _, s := range w.ring {
+		if s.at.After(since) {
+			list = append(list, s.dur)
+		}
+	}
+	w.mu.Unlock()
+
+	if len(list) == 0 {
+		return 0
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list[(len(list)*95)/100]
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	retypes "github.com/ethereum/go-ethereum/core/types"
 	"sync"
@@ -22,6 +23,9 @@ type logsDispatcher struct {
 	service
 	buffer      chan *eventTrxLog
 	knownTopics map[common.Hash]func(*retypes.Log, *logsDispatcher)
+
+	// onUniswapPair represents the channel receiving newly created Uniswap pairs
+	onUniswapPair chan *types.UniswapPairCreated
 }
 
 // newLogsDispatcher creates a new transaction logs dispatcher instance.
@@ -91,6 +95,15 @@ func newLogsDispatcher(buffer chan *eventTrxLog, repo Repository, log logger.Log
 			/* SFC3::RestakedRewards(address indexed delegator, uint256 indexed toValidatorID, uint256 lockupExtraReward, uint256 lockupBaseReward, uint256 unlockedReward) */
 			common.HexToHash("0x4119153d17a36f9597d40e3ab4148d03261a439dddbec4e91799ab7159608e26"): handleSfcRestakeRewards,
 
+			/* Ballot::Voted(address indexed voter, uint256 indexed proposal) */
+			common.HexToHash("0x4d99b957a2bc29a30ebd96a7be8e68fe50a3c701db28a91436490b7d53870ca4"): handleBallotVoted,
+
+			/* fMint::Deposited(address indexed token, address indexed user, uint256 amount) */
+			common.HexToHash("0x8752a472e571a816aea92eec8dae9baf628e840f4929fbcc2d155e6233ff68a7"): handleFMintDeposited,
+
+			/* UniswapFactory::PairCreated(address indexed token0, address indexed token1, address pair, uint) */
+			common.HexToHash("0x0d3648bd0f6ba80134a33ba9275ac585d9d315f0ad8355cddefde31afa28d0e9"): handleUniswapPairCreated,
+
 			/* ERC20::Approval(address indexed owner, address indexed spender, uint256 value) */
 			common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"): handleErc20Approval,
 
@@ -123,13 +136,18 @@ func (ld *logsDispatcher) dispatch() {
 		// try to read next transaction
 		select {
 		case log := <-ld.buffer:
-			// try to find the topic handler
-			handler, ok := ld.knownTopics[log.Topics[0]]
-			if ok {
-				ld.log.Debugf("known topic %s found, processing", log.Topics[0].String())
-				handler(&log.Log, ld)
+			// try to find the topic handler; anonymous events don't have any
+			if len(log.Topics) > 0 {
+				handler, ok := ld.knownTopics[log.Topics[0]]
+				if ok {
+					ld.log.Debugf("known topic %s found, processing", log.Topics[0].String())
+					handler(&log.Log, ld)
+				}
 			}
 
+			// index the event of the contract
+			ld.storeContractEvent(&log.Log)
+
 			// mark the processing as finished
 			log.wg.Done()
 
@@ -0,0 +1,249 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// defaultERC20ListLength is the number of ERC20 tokens pulled by default on negative count.
+const defaultERC20ListLength = 25
+
+// account represents a stored account record.
+type account struct {
+	seq      uint64
+	sc       *common.Hash
+	typ      string
+	activity uint64
+	counter  uint64
+	ifs      []string
+
+	// links to the funder and the deployer; linked marks the links were resolved
+	linked   bool
+	funder   *common.Address
+	deployer *common.Address
+}
+
+// Account tries to load an account identified by the address given.
+// It returns nil if the account is not known.
+func (mb *MemDbBridge) Account(addr *common.Address) (*types.Account, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	acc, ok := mb.accounts[*addr]
+	if !ok {
+		return nil, nil
+	}
+
+	return &types.Account{
+		Address:      *addr,
+		ContractTx:   acc.sc,
+		Type:         acc.typ,
+		LastActivity: hexutil.Uint64(acc.activity),
+		TrxCounter:   hexutil.Uint64(acc.counter),
+		Interfaces:   acc.ifs,
+	}, nil
+}
+
+// AddAccount stores an account if it does not exist.
+func (mb *MemDbBridge) AddAccount(acc *types.Account) error {
+	if acc == nil {
+		return fmt.Errorf("can not add empty account")
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if _, ok := mb.accounts[acc.Address]; ok {
+		return fmt.Errorf("account %s already exists", acc.Address.String())
+	}
+
+	mb.accounts[acc.Address] = &account{
+		seq:      uint64(len(mb.accounts)),
+		sc:       acc.ContractTx,
+		typ:      acc.Type,
+		activity: uint64(acc.LastActivity),
+		counter:  uint64(acc.TrxCounter),
+	}
+	return nil
+}
+
+// IsAccountKnown checks if an account is already stored.
+func (mb *MemDbBridge) IsAccountKnown(addr *common.Address) (bool, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	_, ok := mb.accounts[*addr]
+	return ok, nil
+}
+
+// AccountCount calculates total number of accounts stored.
+func (mb *MemDbBridge) AccountCount() (uint64, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return uint64(len(mb.accounts)), nil
+}
+
+// AccountMarkActivity marks the latest account activity.
+func (mb *MemDbBridge) AccountMarkActivity(addr *common.Address, ts uint64) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if acc, ok := mb.accounts[*addr]; ok {
+		acc.activity = ts
+		acc.counter++
+	}
+	return nil
+}
+
+// Erc20TokensList returns a list of known ERC20 tokens ordered by their activity.
+func (mb *MemDbBridge) Erc20TokensList(count int32) ([]common.Address, error) {
+	if count <= 0 {
+		count = defaultERC20ListLength
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := mb.accountsBy(func(acc *account) bool { return acc.typ == types.AccountTypeERC20Token })
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := mb.accounts[list[i]], mb.accounts[list[j]]
+		if a.counter == b.counter {
+			return a.activity > b.activity
+		}
+		return a.counter > b.counter
+	})
+	return limitAddresses(list, int64(count)), nil
+}
+
+// AccountsToClassify loads a batch of contract accounts
+// which have not been classified yet.
+func (mb *MemDbBridge) AccountsToClassify(limit int64) ([]common.Address, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := mb.accountsBy(func(acc *account) bool { return acc.sc != nil && acc.ifs == nil })
+	return limitAddresses(list, limit), nil
+}
+
+// UpdateAccountClass updates the contract type and the list of interfaces
+// detected on the given account.
+func (mb *MemDbBridge) UpdateAccountClass(addr *common.Address, cType string, ifs []string) error {
+	if ifs == nil {
+		ifs = make([]string, 0)
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if acc, ok := mb.accounts[*addr]; ok {
+		acc.typ = cType
+		acc.ifs = ifs
+	}
+	return nil
+}
+
+// AccountLinks loads the funder and the deployer of the given account, if known.
+func (mb *MemDbBridge) AccountLinks(addr *common.Address) (funder *common.Address, deployer *common.Address, err error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	acc, ok := mb.accounts[*addr]
+	if !ok {
+		return nil, nil, nil
+	}
+	return acc.funder, acc.deployer, nil
+}
+
+// AccountsDeployedBy loads contract accounts deployed by the given address.
+func (mb *MemDbBridge) AccountsDeployedBy(deployer *common.Address, limit int64) ([]common.Address, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := mb.accountsBy(func(acc *account) bool { return acc.deployer != nil && *acc.deployer == *deployer })
+	return limitAddresses(list, limit), nil
+}
+
+// AccountsFundedBy loads accounts which received their first native tokens from the given address.
+func (mb *MemDbBridge) AccountsFundedBy(funder *common.Address, limit int64) ([]common.Address, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := mb.accountsBy(func(acc *account) bool { return acc.funder != nil && *acc.funder == *funder })
+	return limitAddresses(list, limit), nil
+}
+
+// AccountsToLink loads a batch of accounts which have not been linked
+// to their funder and deployer yet.
+func (mb *MemDbBridge) AccountsToLink(limit int64) ([]common.Address, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := mb.accountsBy(func(acc *account) bool { return !acc.linked })
+	return limitAddresses(list, limit), nil
+}
+
+// FirstFunder finds the sender of the first transaction transferring
+// native tokens to the given address; nil if there is none.
+func (mb *MemDbBridge) FirstFunder(addr *common.Address) (*common.Address, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	var first *document
+	for _, d := range mb.trx.docs {
+		if d.doc["to"] == addr.String() && intValue(d.doc["amo"]) > 0 && (first == nil || d.ord < first.ord) {
+			first = d
+		}
+	}
+
+	if first == nil {
+		return nil, nil
+	}
+	from, _ := first.doc["from"].(string)
+	adr := common.HexToAddress(from)
+	return &adr, nil
+}
+
+// UpdateAccountLinks stores the funder and the deployer of the given account.
+// Accounts without a known funder are marked so they are not linked again.
+func (mb *MemDbBridge) UpdateAccountLinks(addr *common.Address, funder *common.Address, deployer *common.Address) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	acc, ok := mb.accounts[*addr]
+	if !ok {
+		return nil
+	}
+
+	acc.linked = true
+	acc.funder = funder
+	if deployer != nil {
+		acc.deployer = deployer
+	}
+	return nil
+}
+
+// accountsBy collects addresses of accounts matching the given condition
+// in the order the accounts were added.
+func (mb *MemDbBridge) accountsBy(match func(*account) bool) []common.Address {
+	list := make([]common.Address, 0)
+	for adr, acc := range mb.accounts {
+		if match(acc) {
+			list = append(list, adr)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return mb.accounts[list[i]].seq < mb.accounts[list[j]].seq })
+	return list
+}
+
+// limitAddresses cuts the list of addresses to the given limit; non-positive limit keeps all.
+func limitAddresses(list []common.Address, limit int64) []common.Address {
+	if limit > 0 && int64(len(list)) > limit {
+		return list[:limit]
+	}
+	return list
+}
@@ -0,0 +1,146 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AddAlertRule stores the alert rule.
+func (mb *MemDbBridge) AddAlertRule(ar *types.AlertRule) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if mb.rules.has(ar.Id) {
+		return fmt.Errorf("alert rule %s already exists", ar.Id)
+	}
+	return mb.rules.put(ar.Id, uint64(ar.Created.UnixNano()), ar)
+}
+
+// RemoveAlertRule removes the alert rule of the given owner.
+func (mb *MemDbBridge) RemoveAlertRule(owner string, id string) (bool, error) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	d, ok := mb.rules.docs[id]
+	if !ok || d.doc[types.FiAlertRuleOwner] != owner {
+		return false, nil
+	}
+	return mb.rules.remove(id), nil
+}
+
+// SetAlertRuleState updates the last evaluated state of the alert rule.
+func (mb *MemDbBridge) SetAlertRuleState(id string, state string, block uint64) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	_, err := mb.rules.set(id, bson.M{types.FiAlertRuleState: state, types.FiAlertRuleBlock: block})
+	return err
+}
+
+// AlertRules loads the alert rules of the given owner; all the rules if the owner is empty.
+func (mb *MemDbBridge) AlertRules(owner string) ([]*types.AlertRule, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	filter := bson.D{}
+	if owner != "" {
+		filter = bson.D{{types.FiAlertRuleOwner, owner}}
+	}
+
+	docs, err := mb.rules.find(&filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.AlertRule, 0, len(docs))
+	for _, d := range ascending(docs) {
+		var row types.AlertRule
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// AlertRulesSize calculates the number of alert rules of the given owner.
+func (mb *MemDbBridge) AlertRulesSize(owner string) (int64, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.rules.find(&bson.D{{types.FiAlertRuleOwner, owner}})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(docs)), nil
+}
+
+// AddAlert stores the raised alert.
+// Returns FALSE if the same alert has already been raised.
+func (mb *MemDbBridge) AddAlert(al *types.Alert) (bool, error) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if mb.alerts.has(al.Pk()) {
+		return false, nil
+	}
+	if err := mb.alerts.put(al.Pk(), uint64(al.Time.UnixNano()), al); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetAlertDelivered marks the alert as delivered to the webhook of its rule.
+func (mb *MemDbBridge) SetAlertDelivered(al *types.Alert) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	_, err := mb.alerts.set(al.Pk(), bson.M{types.FiAlertDelivered: true})
+	return err
+}
+
+// Alerts loads alerts of the given owner raised since the given time, oldest first.
+func (mb *MemDbBridge) Alerts(owner string, since time.Time, limit int64) ([]*types.Alert, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	return mb.loadAlerts(&bson.D{
+		{types.FiAlertOwner, owner},
+		{types.FiAlertTime, bson.D{{"$gte", since}}},
+	}, limit)
+}
+
+// UndeliveredAlerts loads alerts with a webhook raised since the given time
+// and not delivered yet, oldest first.
+func (mb *MemDbBridge) UndeliveredAlerts(since time.Time, limit int64) ([]*types.Alert, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	return mb.loadAlerts(&bson.D{
+		{types.FiAlertDelivered, false},
+		{types.FiAlertTime, bson.D{{"$gte", since}}},
+		{types.FiAlertWebhook, bson.D{{"$nin", bson.A{nil, ""}}}},
+	}, limit)
+}
+
+// loadAlerts loads alerts matching the given filter, oldest first.
+// The caller is expected to hold the lock.
+func (mb *MemDbBridge) loadAlerts(filter *bson.D, limit int64) ([]*types.Alert, error) {
+	docs, err := mb.alerts.find(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.Alert, 0, len(docs))
+	for _, d := range top(ascending(docs), limit) {
+		var row types.Alert
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
@@ -0,0 +1,45 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AddBallotVote stores a vote of a ballot, the previous vote of the voter is replaced.
+func (mb *MemDbBridge) AddBallotVote(bv *types.BallotVote) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.ballotVotes.put(bv.Pk(), 0, bv)
+}
+
+// BallotVote loads the vote of the voter in the given ballot, or nil if the voter did not vote.
+func (mb *MemDbBridge) BallotVote(ballot *common.Address, voter *common.Address) (*types.BallotVote, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	bv := types.BallotVote{Ballot: *ballot, Voter: *voter}
+	ok, err := mb.ballotVotes.get(bv.Pk(), &bv)
+	if !ok || err != nil {
+		return nil, err
+	}
+	return &bv, nil
+}
+
+// BallotVotersCount counts the voters of the given ballot per proposal.
+func (mb *MemDbBridge) BallotVotersCount(ballot *common.Address) (map[uint64]uint64, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.ballotVotes.find(&bson.D{{types.FiBallotVoteBallot, ballot.String()}})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[uint64]uint64)
+	for _, d := range docs {
+		res[uint64(intValue(d.doc[types.FiBallotVoteProposal]))]++
+	}
+	return res, nil
+}
@@ -0,0 +1,138 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// LastKnownBlock returns the last known block from the storage.
+// The newest block of stored transactions is used if the block has not been marked yet.
+func (mb *MemDbBridge) LastKnownBlock() (uint64, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	if mb.lastBlock != nil {
+		return *mb.lastBlock, nil
+	}
+
+	var top uint64
+	for _, d := range mb.trx.docs {
+		if blk, ok := toInt(d.doc["blk"]); ok && uint64(blk) > top {
+			top = uint64(blk)
+		}
+	}
+	return top, nil
+}
+
+// UpdateLastKnownBlock stores the last known block.
+func (mb *MemDbBridge) UpdateLastKnownBlock(blockNo *hexutil.Uint64) error {
+	if blockNo == nil {
+		return fmt.Errorf("can not add empty block")
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	blk := uint64(*blockNo)
+	mb.lastBlock = &blk
+	return nil
+}
+
+// AddBlockBloom merges the given address bloom into the bloom of the block.
+func (mb *MemDbBridge) AddBlockBloom(block uint64, bl *types.AddressBloom) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	// the first block indexed starts the bloom index
+	if mb.bloomFrom == nil {
+		from := block
+		mb.bloomFrom = &from
+	}
+
+	cur := mb.blooms[block]
+	for i, w := range bl {
+		cur[i] |= w
+	}
+	mb.blooms[block] = cur
+	return nil
+}
+
+// BlockBloomFrom provides the first block with the address bloom indexed.
+func (mb *MemDbBridge) BlockBloomFrom() (uint64, bool, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	if mb.bloomFrom == nil {
+		return 0, false, nil
+	}
+	return *mb.bloomFrom, true, nil
+}
+
+// BlockBloomMatch checks if any block of the given inclusive range has the bloom
+// matching all the bits of the given address bloom.
+func (mb *MemDbBridge) BlockBloomMatch(bl *types.AddressBloom, from uint64, to uint64) (bool, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	for blk, cur := range mb.blooms {
+		if blk < from || blk > to {
+			continue
+		}
+
+		match := true
+		for i, w := range bl {
+			if cur[i]&w != w {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AddBlockFinality stores the given block finality record.
+func (mb *MemDbBridge) AddBlockFinality(bf *types.BlockFinality) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	mb.finality[bf.Block] = *bf
+	return nil
+}
+
+// BlockFinality loads the finality record of the given block.
+// It returns nil if the finality of the block was not observed.
+func (mb *MemDbBridge) BlockFinality(block uint64) (*types.BlockFinality, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	bf, ok := mb.finality[block]
+	if !ok {
+		return nil, nil
+	}
+	return &bf, nil
+}
+
+// BlockFinalityList loads the finality records of blocks created since the given time,
+// ordered by the block number.
+func (mb *MemDbBridge) BlockFinalityList(since time.Time) ([]*types.BlockFinality, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := make([]*types.BlockFinality, 0)
+	for _, bf := range mb.finality {
+		if !bf.TimeStamp.Before(since) {
+			row := bf
+			list = append(list, &row)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Block < list[j].Block })
+	return list, nil
+}
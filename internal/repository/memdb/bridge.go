@@ -0,0 +1,216 @@
+// Package memdb implements bridge to volatile in-memory storage used in place of Mongo database
+// to run the API server for development without a database.
+package memdb
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// storageLimits lists the limits of the in-memory storage compared to Mongo database.
+var storageLimits = []string{
+	"data is lost on restart, the chain is scanned again from the configured start",
+	"memory use grows with the indexed data without any bound",
+	"denylist screening is not available, no denylist collections can be loaded",
+	"a single API server instance only, service locks and results are not shared",
+	"list filters support only the query operators used by the API server",
+}
+
+// MemDbBridge represents the in-memory storage abstraction layer.
+// It keeps all the off-chain data in maps guarded by a single lock.
+type MemDbBridge struct {
+	log logger.Logger
+	mu  sync.RWMutex
+
+	// config values and scanner states
+	lastBlock   *uint64
+	bloomFrom   *uint64
+	swapBlock   uint64
+	digestState time.Time
+
+	// blocks
+	blooms   map[uint64]types.AddressBloom
+	finality map[uint64]types.BlockFinality
+
+	// transactions and their aggregations
+	trx       *collection
+	erc20     *collection
+	trxVolume map[string]types.DailyTrxVolume
+
+	// accounts and their relations
+	accounts map[common.Address]*account
+	labels   *collection
+	watched  *collection
+	digests  *collection
+	rules    *collection
+	alerts   *collection
+
+	// contracts and events
+	contracts  *collection
+	artifacts  *collection
+	creations  *collection
+	events     *collection
+	eventStats map[eventStatKey]uint64
+
+	// staking
+	delegations  *collection
+	delegationOp *collection
+	epochs       map[uint64]types.Epoch
+	epochStats   map[uint64]types.EpochStats
+	rewards      *collection
+	rewardsDaily map[string]types.DailyRewards
+	validatorApr map[string]types.ValidatorApr
+	withdrawals  *collection
+	ftmSupply    *collection
+
+	// DeFi, tokens and governance
+	fMintAccounts map[common.Address]*fMintAccount
+	fMintStats    *collection
+	swaps         *collection
+	pairs         *collection
+	prices        *collection
+	tokenMeta     *collection
+	ballotVotes   *collection
+
+	// moderation and service state
+	risks   *collection
+	locks   map[string]serviceLock
+	results map[string][]byte
+	usage   map[string]types.UsageRecord
+}
+
+// New creates a new in-memory storage bridge.
+func New(log logger.Logger) *MemDbBridge {
+	log.Notice("in-memory storage used, data will not survive restart")
+	return &MemDbBridge{
+		log:           log,
+		blooms:        make(map[uint64]types.AddressBloom),
+		finality:      make(map[uint64]types.BlockFinality),
+		trx:           newCollection(),
+		erc20:         newCollection(),
+		trxVolume:     make(map[string]types.DailyTrxVolume),
+		accounts:      make(map[common.Address]*account),
+		labels:        newCollection(),
+		watched:       newCollection(),
+		digests:       newCollection(),
+		rules:         newCollection(),
+		alerts:        newCollection(),
+		contracts:     newCollection(),
+		artifacts:     newCollection(),
+		creations:     newCollection(),
+		events:        newCollection(),
+		eventStats:    make(map[eventStatKey]uint64),
+		delegations:   newCollection(),
+		delegationOp:  newCollection(),
+		epochs:        make(map[uint64]types.Epoch),
+		epochStats:    make(map[uint64]types.EpochStats),
+		rewards:       newCollection(),
+		rewardsDaily:  make(map[string]types.DailyRewards),
+		validatorApr:  make(map[string]types.ValidatorApr),
+		withdrawals:   newCollection(),
+		ftmSupply:     newCollection(),
+		fMintAccounts: make(map[common.Address]*fMintAccount),
+		fMintStats:    newCollection(),
+		swaps:         newCollection(),
+		pairs:         newCollection(),
+		prices:        newCollection(),
+		tokenMeta:     newCollection(),
+		ballotVotes:   newCollection(),
+		risks:         newCollection(),
+		locks:         make(map[string]serviceLock),
+		results:       make(map[string][]byte),
+		usage:         make(map[string]types.UsageRecord),
+	}
+}
+
+// Close will terminate the storage; the in-memory data are dropped.
+func (mb *MemDbBridge) Close() {
+	mb.log.Info("in-memory storage closed")
+}
+
+// Latency provides the 95th percentile of the latency of recent storage operations;
+// the in-memory storage responds immediately.
+func (mb *MemDbBridge) Latency() time.Duration {
+	return 0
+}
+
+// Ping checks the storage and provides the time it took to respond.
+func (mb *MemDbBridge) Ping() (time.Duration, error) {
+	start := time.Now()
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return time.Since(start), nil
+}
+
+// PoolStats provides the statistics of the storage connection pool;
+// the in-memory storage does not use any connections.
+func (mb *MemDbBridge) PoolStats() types.DbPoolStats {
+	return types.DbPoolStats{}
+}
+
+// StorageInfo describes the in-memory storage and its limits.
+func (mb *MemDbBridge) StorageInfo() types.StorageInfo {
+	return types.StorageInfo{
+		Driver:     config.DbDriverMemory,
+		Persistent: false,
+		Limits:     storageLimits,
+	}
+}
+
+// listOrdinal decodes the ordinal index of a list item from the given cursor.
+// The cursor is an opaque list cursor, the id of an item of the given collection,
+// or a legacy number in the given base; zero base does not accept numbers.
+func listOrdinal(col *collection, cursor string, base int) (uint64, error) {
+	if ix, ok := types.DecodeListCursor(cursor); ok {
+		return ix, nil
+	}
+
+	if col != nil {
+		if d, ok := col.docs[cursor]; ok {
+			return d.ord, nil
+		}
+	}
+
+	var ix uint64
+	var err error
+	switch base {
+	case 0:
+		return 0, fmt.Errorf("invalid cursor value %s", cursor)
+	case 16:
+		ix, err = hexutil.DecodeUint64(cursor)
+	default:
+		ix, err = strconv.ParseUint(cursor, base, 64)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor value; %s", err.Error())
+	}
+	return ix, nil
+}
+
+// list loads a page of the documents of the given collection matching the filter.
+// The cursor is decoded by the given function, if any.
+func (mb *MemDbBridge) list(col *collection, cursor *string, count int32, filter *bson.D, decode func(string) (uint64, error)) (*listPage, error) {
+	docs, err := col.find(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var ix *uint64
+	if cursor != nil {
+		val, err := decode(*cursor)
+		if err != nil {
+			return nil, err
+		}
+		ix = &val
+	}
+	return page(docs, ix, count), nil
+}
@@ -0,0 +1,236 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// newTestBridge creates an empty in-memory storage.
+func newTestBridge() *MemDbBridge {
+	return New(logger.New(&config.Config{AppName: "test", Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}))
+}
+
+// ftm provides the given amount of FTM in WEI.
+func ftm(amount int64) *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).Mul(big.NewInt(amount), big.NewInt(1e18)))
+}
+
+func TestErc20TransactionsFilterAndCursor(t *testing.T) {
+	mb := newTestBridge()
+	token, other := common.HexToAddress("0xe0"), common.HexToAddress("0xe1")
+	alice, bob, carol := common.HexToAddress("0xa"), common.HexToAddress("0xb"), common.HexToAddress("0xc")
+
+	transfers := []struct {
+		token    common.Address
+		from, to common.Address
+		typ      int32
+		amount   int64
+	}{
+		{token, alice, bob, types.ERC20TrxTypeTransfer, 1},
+		{token, bob, carol, types.ERC20TrxTypeTransfer, 20},
+		{token, carol, alice, types.ERC20TrxTypeTransfer, 300},
+		{token, alice, carol, types.ERC20TrxTypeApproval, 4000},
+		{other, alice, bob, types.ERC20TrxTypeTransfer, 50000},
+		{token, bob, carol, types.ERC20TrxTypeTransfer, 600000},
+	}
+	for i, tr := range transfers {
+		err := mb.AddERC20Transaction(&types.Erc20Transaction{
+			Transaction:  common.BigToHash(big.NewInt(int64(i + 1))),
+			TokenAddress: tr.token,
+			TokenType:    types.AccountTypeERC20Token,
+			Type:         tr.typ,
+			Sender:       tr.from,
+			Recipient:    tr.to,
+			Amount:       *ftm(tr.amount),
+			TimeStamp:    hexutil.Uint64(1600000000 + i),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// token transfers of alice, as filtered by the proxy
+	typ := int32(types.ERC20TrxTypeTransfer)
+	fi := bson.D{
+		{Key: types.FiErc20TransactionToken, Value: token.String()},
+		{Key: "$or", Value: bson.A{bson.D{{Key: types.FiErc20TransactionSender, Value: alice.String()}}, bson.D{{Key: types.FiErc20TransactionRecipient, Value: alice.String()}}}},
+		{Key: types.FiErc20TransactionType, Value: typ},
+	}
+	list, err := mb.Erc20Transactions(nil, 10, &fi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != 2 || len(list.Collection) != 2 || list.Collection[0].Amount.ToInt().Cmp(ftm(300).ToInt()) != 0 {
+		t.Fatalf("unexpected transfers of alice; total %d, loaded %d", list.Total, len(list.Collection))
+	}
+
+	// large transfers use the value in 10^-9 units
+	min := new(big.Int).Div(ftm(20).ToInt(), types.TransactionDecimalsCorrection)
+	fi = bson.D{
+		{Key: types.FiErc20TransactionToken, Value: token.String()},
+		{Key: types.FiErc20TransactionType, Value: types.ERC20TrxTypeTransfer},
+		{Key: types.FiErc20TransactionValue, Value: bson.D{{Key: "$gte", Value: min.Int64()}}},
+	}
+	list, err = mb.Erc20Transactions(nil, 10, &fi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != 3 {
+		t.Fatalf("expected 3 large transfers, got %d", list.Total)
+	}
+
+	// walk all the transactions of the token page by page
+	fi = bson.D{{Key: types.FiErc20TransactionToken, Value: token.String()}}
+	seen := make(map[string]bool)
+	var cursor *string
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatalf("paging does not end")
+		}
+		list, err = mb.Erc20Transactions(cursor, 2, &fi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, trx := range list.Collection {
+			if seen[trx.Pk()] {
+				t.Fatalf("transaction %s loaded twice", trx.Pk())
+			}
+			seen[trx.Pk()] = true
+		}
+		if list.IsEnd {
+			break
+		}
+		last := list.Collection[len(list.Collection)-1].Pk()
+		cursor = &last
+	}
+	if len(seen) != 5 {
+		t.Errorf("expected 5 transactions of the token, loaded %d", len(seen))
+	}
+
+	if _, err := mb.Erc20Transactions(nil, 10, &bson.D{{Key: types.FiErc20TransactionValue, Value: bson.D{{Key: "$mod", Value: bson.A{2, 0}}}}}); err == nil {
+		t.Errorf("unsupported operator accepted")
+	}
+}
+
+func TestDelegationsCountFiltered(t *testing.T) {
+	mb := newTestBridge()
+	for i, amo := range []int64{0, 10, 20} {
+		err := mb.AddDelegation(&types.Delegation{
+			Transaction:     common.BigToHash(big.NewInt(int64(i + 1))),
+			Address:         common.BigToAddress(big.NewInt(int64(i + 1))),
+			ToStakerId:      (*hexutil.Big)(big.NewInt(int64(i%2 + 1))),
+			CreatedTime:     hexutil.Uint64(1600000000 + i),
+			AmountStaked:    ftm(amo),
+			AmountDelegated: ftm(amo),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	active, err := mb.DelegationsCountFiltered(&bson.D{{types.FiDelegationValue, bson.D{{"$gt", 0}}}})
+	if err != nil || active != 2 {
+		t.Errorf("expected 2 active delegations, got %d; %v", active, err)
+	}
+
+	toFirst, err := mb.DelegationsCountFiltered(&bson.D{
+		{types.FiDelegationToValidator, (*hexutil.Big)(big.NewInt(1)).String()},
+		{types.FiDelegationAmount, bson.D{{"$ne", "0x0"}}},
+	})
+	if err != nil || toFirst != 1 {
+		t.Errorf("expected 1 delegation to #1, got %d; %v", toFirst, err)
+	}
+
+	byVal, err := mb.DelegatorsCountByValidator()
+	if err != nil || byVal["0x1"] != 1 || byVal["0x2"] != 1 {
+		t.Errorf("unexpected delegators by validator %v; %v", byVal, err)
+	}
+}
+
+func TestWithdrawalsSumValue(t *testing.T) {
+	mb := newTestBridge()
+	addr := common.HexToAddress("0xa")
+	fin := common.HexToHash("0xf1")
+	finTime := hexutil.Uint64(1600001000)
+
+	for i, wr := range []struct {
+		val       int64
+		amount    int64
+		finalized bool
+	}{{1, 5, false}, {1, 7, true}, {2, 11, false}} {
+		req := types.WithdrawRequest{
+			RequestTrx:        common.BigToHash(big.NewInt(int64(i + 1))),
+			WithdrawRequestID: (*hexutil.Big)(big.NewInt(int64(i + 1))),
+			Address:           addr,
+			StakerID:          (*hexutil.Big)(big.NewInt(wr.val)),
+			CreatedTime:       hexutil.Uint64(1600000000 + i),
+			Amount:            ftm(wr.amount),
+		}
+		if wr.finalized {
+			req.WithdrawTrx = &fin
+			req.WithdrawTime = &finTime
+		}
+		if err := mb.AddWithdrawal(&req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// pending withdrawals, as summed by the proxy
+	total, err := mb.WithdrawalsSumValue(&bson.D{
+		{types.FiWithdrawalAddress, addr.String()},
+		{types.FiWithdrawalFinTrx, bson.D{{"$type", 10}}},
+	})
+	if err != nil || total.Cmp(ftm(16).ToInt()) != 0 {
+		t.Errorf("expected 16 FTM pending, got %v; %v", total, err)
+	}
+
+	total, err = mb.WithdrawalsSumValue(&bson.D{
+		{types.FiWithdrawalAddress, addr.String()},
+		{types.FiWithdrawalToValidator, (*hexutil.Big)(big.NewInt(1)).String()},
+		{types.FiWithdrawalFinTrx, bson.D{{"$type", 10}}},
+	})
+	if err != nil || total.Cmp(ftm(5).ToInt()) != 0 {
+		t.Errorf("expected 5 FTM pending to #1, got %v; %v", total, err)
+	}
+}
+
+func TestRewardsSumValue(t *testing.T) {
+	mb := newTestBridge()
+	alice, bob := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+
+	for i, rc := range []struct {
+		addr   common.Address
+		amount int64
+	}{{alice, 3}, {bob, 4}, {alice, 5}} {
+		err := mb.AddRewardClaim(&types.RewardClaim{
+			Delegator:     rc.addr,
+			ToValidatorId: hexutil.Big(*big.NewInt(1)),
+			Claimed:       hexutil.Uint64(1600000000 + i),
+			ClaimTrx:      common.BigToHash(big.NewInt(int64(i + 1))),
+			Amount:        *ftm(rc.amount),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	total, err := mb.RewardsSumValue(&bson.D{})
+	if err != nil || total.Cmp(ftm(12).ToInt()) != 0 {
+		t.Errorf("expected 12 FTM claimed, got %v; %v", total, err)
+	}
+
+	total, err = mb.RewardsSumValue(&bson.D{
+		{Key: types.FiRewardClaimAddress, Value: alice.String()},
+		{Key: types.FiRewardClaimToValidator, Value: (*hexutil.Big)(big.NewInt(1)).String()},
+	})
+	if err != nil || total.Cmp(ftm(8).ToInt()) != 0 {
+		t.Errorf("expected 8 FTM claimed by alice, got %v; %v", total, err)
+	}
+}
@@ -0,0 +1,284 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AddContract stores a smart contract reference; a known contract is replaced.
+func (mb *MemDbBridge) AddContract(sc *types.Contract) error {
+	if sc == nil {
+		return fmt.Errorf("can not add empty contract")
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.contracts.put(sc.Address.String(), sc.Uid(), sc)
+}
+
+// UpdateContract updates smart contract information to reflect
+// new validation or similar changes passed from repository.
+func (mb *MemDbBridge) UpdateContract(sc *types.Contract) error {
+	if sc == nil {
+		return fmt.Errorf("no contract given to update")
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if !mb.contracts.has(sc.Address.String()) {
+		return nil
+	}
+	return mb.contracts.put(sc.Address.String(), sc.Uid(), sc)
+}
+
+// IsContractKnown checks if a smart contract is already stored.
+func (mb *MemDbBridge) IsContractKnown(addr *common.Address) bool {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return mb.contracts.has(addr.String())
+}
+
+// Contract returns details of a smart contract, or nil if contract does not exist.
+func (mb *MemDbBridge) Contract(addr *common.Address) (*types.Contract, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	var sc types.Contract
+	found, err := mb.contracts.get(addr.String(), &sc)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+// ContractTransaction returns contract creation transaction hash if available.
+func (mb *MemDbBridge) ContractTransaction(addr *common.Address) (*common.Hash, error) {
+	sc, err := mb.Contract(addr)
+	if err != nil || sc == nil {
+		return nil, err
+	}
+	return &sc.TransactionHash, nil
+}
+
+// Contracts provides list of smart contracts.
+func (mb *MemDbBridge) Contracts(validatedOnly bool, cursor *string, count int32) (*types.ContractList, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero contracts requested")
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	filter := bson.D{}
+	if validatedOnly {
+		filter = bson.D{{"val", bson.D{{"$ne", nil}}}}
+	}
+
+	pg, err := mb.list(mb.contracts, cursor, count, &filter, func(c string) (uint64, error) {
+		return listOrdinal(nil, c, 10)
+	})
+	if err != nil {
+		mb.log.Errorf("can not load contracts list; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.ContractList{
+		Collection: make([]*types.Contract, 0, len(pg.Docs)),
+		Total:      pg.Total,
+		First:      pg.First,
+		IsStart:    pg.IsStart,
+		IsEnd:      pg.IsEnd,
+	}
+	for _, d := range pg.Docs {
+		var row types.Contract
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &row)
+		list.Last = row.Uid()
+	}
+
+	// shift the first item on cursor
+	if cursor != nil && len(list.Collection) > 0 {
+		list.First = list.Collection[0].Uid()
+	}
+
+	// reverse on negative so new-er contracts will be on top
+	if count < 0 {
+		list.Reverse()
+	}
+	return &list, nil
+}
+
+// ContractsWithoutCodeHash loads a batch of contracts which do not have
+// the hash of their runtime byte code known yet.
+func (mb *MemDbBridge) ContractsWithoutCodeHash(limit int64) ([]common.Address, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.contracts.find(&bson.D{{"code_h", nil}})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]common.Address, 0)
+	for _, d := range top(ascending(docs), limit) {
+		list = append(list, common.HexToAddress(d.id))
+	}
+	return list, nil
+}
+
+// SetContractCodeHash stores the hash of the runtime byte code of the given contract.
+func (mb *MemDbBridge) SetContractCodeHash(addr *common.Address, hash *common.Hash) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	_, err := mb.contracts.set(addr.String(), bson.M{"code_h": hash.String()})
+	return err
+}
+
+// ContractsByCodeHash loads contracts with the given runtime byte code hash,
+// except the given contract. The newest contracts go first.
+func (mb *MemDbBridge) ContractsByCodeHash(hash *common.Hash, except *common.Address, limit int64) ([]*types.Contract, error) {
+	return mb.contractsBy(&bson.D{{"code_h", hash.String()}, {"_id", bson.D{{"$ne", except.String()}}}}, limit)
+}
+
+// UnvalidatedContractsByCodeHash loads not validated contracts with the given
+// runtime byte code hash. The newest contracts go first.
+func (mb *MemDbBridge) UnvalidatedContractsByCodeHash(hash *common.Hash, limit int64) ([]*types.Contract, error) {
+	return mb.contractsBy(&bson.D{{"code_h", hash.String()}, {"val", nil}}, limit)
+}
+
+// contractsBy loads contracts matching the given filter, the newest contracts first.
+func (mb *MemDbBridge) contractsBy(filter *bson.D, limit int64) ([]*types.Contract, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.contracts.find(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.Contract, 0)
+	for _, d := range top(docs, limit) {
+		var row types.Contract
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// ContractsToPropagate finds directly validated contracts which have not validated
+// contracts with identical runtime byte code deployed. The given code hash is skipped.
+func (mb *MemDbBridge) ContractsToPropagate(skip *common.Hash, limit int64) ([]common.Address, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	type codeGroup struct {
+		src     string
+		pending int
+	}
+
+	groups := make(map[string]*codeGroup)
+	order := make([]string, 0)
+	for _, d := range ascending(mb.contracts.all()) {
+		hash, ok := d.doc["code_h"].(string)
+		if !ok || hash == skip.String() {
+			continue
+		}
+
+		grp, ok := groups[hash]
+		if !ok {
+			grp = new(codeGroup)
+			groups[hash] = grp
+			order = append(order, hash)
+		}
+
+		// directly validated contracts are the source of the validation
+		if intValue(d.doc["val"]) > 0 {
+			if from, _ := d.doc["vfrom"].(string); from == "" && d.id > grp.src {
+				grp.src = d.id
+			}
+			continue
+		}
+		grp.pending++
+	}
+
+	list := make([]common.Address, 0)
+	for _, hash := range order {
+		if grp := groups[hash]; grp.src != "" && grp.pending > 0 {
+			list = append(list, common.HexToAddress(grp.src))
+		}
+	}
+	return limitAddresses(list, limit), nil
+}
+
+// StoreContractArtifact stores the compilation artifact of a validated contract.
+// An artifact of previous validation of the contract is replaced.
+func (mb *MemDbBridge) StoreContractArtifact(ca *types.ContractArtifact) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.artifacts.put(ca.Address.String(), 0, ca)
+}
+
+// ContractArtifact loads the compilation artifact of the given contract.
+// Nil is returned if the contract has no artifact stored.
+func (mb *MemDbBridge) ContractArtifact(addr *common.Address) (*types.ContractArtifact, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	var ca types.ContractArtifact
+	found, err := mb.artifacts.get(addr.String(), &ca)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &ca, nil
+}
+
+// AddContractCreation stores the given contract creation.
+func (mb *MemDbBridge) AddContractCreation(cc *types.ContractCreation) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.creations.put(cc.Address.String(), uint64(cc.TimeStamp.UnixNano()), cc)
+}
+
+// ContractCreation loads the creation record of the given contract.
+// It returns nil if the contract was not created by another contract.
+func (mb *MemDbBridge) ContractCreation(addr *common.Address) (*types.ContractCreation, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	var cc types.ContractCreation
+	found, err := mb.creations.get(addr.String(), &cc)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &cc, nil
+}
+
+// ContractCreationsBy loads the most recent contracts created by the given creator contract.
+func (mb *MemDbBridge) ContractCreationsBy(creator *common.Address, limit int64) ([]*types.ContractCreation, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.creations.find(&bson.D{{types.FiContractCreationCreator, creator.String()}})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.ContractCreation, 0)
+	for _, d := range top(docs, limit) {
+		var row types.ContractCreation
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
@@ -0,0 +1,140 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// eventStatKey identifies the daily counter of events of a contract.
+type eventStatKey struct {
+	contract common.Address
+	topic    common.Hash
+	day      time.Time
+}
+
+// AddContractEvent stores a contract event and counts it in the daily statistics
+// of the contract. Re-processing the same event log doesn't count it again.
+func (mb *MemDbBridge) AddContractEvent(ev *types.ContractEvent) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	known := mb.events.has(ev.Pk())
+	if err := mb.events.put(ev.Pk(), ev.OrdinalIndex(), ev); err != nil {
+		mb.log.Errorf("can not store contract event %s; %s", ev.Pk(), err.Error())
+		return err
+	}
+
+	if !known {
+		mb.eventStats[eventStatKey{
+			contract: ev.Contract,
+			topic:    ev.Topic(),
+			day:      time.Unix(int64(ev.TimeStamp), 0).UTC().Truncate(24 * time.Hour),
+		}]++
+	}
+	return nil
+}
+
+// ContractEventStats loads daily counters of events of the given contract since the given day,
+// sorted by the day.
+func (mb *MemDbBridge) ContractEventStats(addr *common.Address, since time.Time) ([]*types.ContractEventStat, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := make([]*types.ContractEventStat, 0)
+	for key, cnt := range mb.eventStats {
+		if key.contract == *addr && !key.day.Before(since) {
+			list = append(list, &types.ContractEventStat{Topic: key.topic, Day: key.day, Count: cnt})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Day.Equal(list[j].Day) {
+			return list[i].Topic.String() < list[j].Topic.String()
+		}
+		return list[i].Day.Before(list[j].Day)
+	})
+	return list, nil
+}
+
+// ContractEvents pulls list of contract events starting at the specified cursor.
+func (mb *MemDbBridge) ContractEvents(cursor *string, count int32, filter *bson.D) (*types.ContractEventList, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero contract events requested")
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	pg, err := mb.list(mb.events, cursor, count, filter, func(c string) (uint64, error) {
+		if ix, ok := types.DecodeListCursor(c); ok {
+			return ix, nil
+		}
+		return 0, fmt.Errorf("invalid contract events cursor %s", c)
+	})
+	if err != nil {
+		mb.log.Errorf("can not load contract events list; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.ContractEventList{
+		Collection: make([]*types.ContractEvent, 0, len(pg.Docs)),
+		Total:      pg.Total,
+		First:      pg.First,
+		IsStart:    pg.IsStart,
+		IsEnd:      pg.IsEnd,
+	}
+	if filter != nil {
+		list.Filter = *filter
+	}
+
+	for _, d := range pg.Docs {
+		var row types.ContractEvent
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+
+	// reverse on negative so new-er events will be on top
+	if count < 0 {
+		list.Reverse()
+	}
+	return &list, nil
+}
+
+// ContractEventsIn loads events of the given signature emitted by the given contract
+// in the given range of blocks, oldest first.
+func (mb *MemDbBridge) ContractEventsIn(addr *common.Address, topic *common.Hash, from uint64, to uint64, limit int64) ([]*types.ContractEvent, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	lo := types.ContractEvent{BlockNumber: from}
+	hi := types.ContractEvent{BlockNumber: to + 1}
+	filter := bson.D{
+		{types.FiContractEventContract, addr.String()},
+		{types.FiContractEventOrdinal, bson.D{{"$gte", lo.OrdinalIndex()}, {"$lt", hi.OrdinalIndex()}}},
+	}
+	if topic != nil {
+		filter = append(filter, bson.E{Key: types.FiContractEventTopic, Value: topic.String()})
+	}
+
+	docs, err := mb.events.find(&filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.ContractEvent, 0)
+	for _, d := range top(ascending(docs), limit) {
+		var row types.ContractEvent
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
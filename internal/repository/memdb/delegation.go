@@ -0,0 +1,243 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/repository/db"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// delegationDoc finds the stored document of the delegation from the address to the validator.
+func (mb *MemDbBridge) delegationDoc(addr *common.Address, valID *hexutil.Big) (*document, error) {
+	docs, err := mb.delegations.find(&bson.D{
+		{types.FiDelegationAddress, addr.String()},
+		{types.FiDelegationToValidator, valID.String()},
+	})
+	if err != nil || len(docs) == 0 {
+		return nil, err
+	}
+	return docs[0], nil
+}
+
+// Delegation returns details of a delegation from an address to a validator ID.
+func (mb *MemDbBridge) Delegation(addr *common.Address, valID *hexutil.Big) (*types.Delegation, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	d, err := mb.delegationDoc(addr, valID)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	var dlg types.Delegation
+	if err := d.decode(&dlg); err != nil {
+		return nil, err
+	}
+	return &dlg, nil
+}
+
+// AddDelegation stores a delegation if it doesn't exist; a known delegation is updated.
+func (mb *MemDbBridge) AddDelegation(dl *types.Delegation) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	d, err := mb.delegationDoc(&dl.Address, dl.ToStakerId)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return mb.delegations.put(dl.Transaction.String(), dl.OrdinalIndex(), dl)
+	}
+
+	// the delegation is identified by the address and the validator, not by the document id
+	_, err = mb.delegations.set(d.id, bson.M{
+		types.FiDelegationOrdinal:            dl.OrdinalIndex(),
+		types.FiDelegationTransaction:        dl.Transaction.String(),
+		types.FiDelegationToValidatorAddress: dl.ToStakerAddress.String(),
+		types.FiDelegationAmountActive:       dl.AmountDelegated.String(),
+		types.FiDelegationValue:              new(big.Int).Div(dl.AmountDelegated.ToInt(), types.DelegationDecimalsCorrection).Uint64(),
+	})
+	d.ord = dl.OrdinalIndex()
+	return err
+}
+
+// UpdateDelegationBalance updates the given delegation active balance to the given amount.
+func (mb *MemDbBridge) UpdateDelegationBalance(addr *common.Address, valID *hexutil.Big, amo *hexutil.Big) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	d, err := mb.delegationDoc(addr, valID)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return db.ErrUnknownDelegation
+	}
+
+	_, err = mb.delegations.set(d.id, bson.M{
+		types.FiDelegationAmountActive: amo.String(),
+		types.FiDelegationValue:        new(big.Int).Div(amo.ToInt(), types.DelegationDecimalsCorrection).Uint64(),
+	})
+	return err
+}
+
+// DelegationsCountFiltered calculates total number of delegations for the given filter.
+func (mb *MemDbBridge) DelegationsCountFiltered(filter *bson.D) (uint64, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.delegations.find(filter)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(docs)), nil
+}
+
+// DelegatorsCountByValidator calculates the number of active delegations of each validator.
+// The resulting map is indexed by the hex encoded validator ID.
+func (mb *MemDbBridge) DelegatorsCountByValidator() (map[string]uint64, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.delegations.find(&bson.D{{types.FiDelegationAmount, bson.D{{"$ne", "0x0"}}}})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]uint64)
+	for _, d := range docs {
+		to, _ := d.doc[types.FiDelegationToValidator].(string)
+		res[to]++
+	}
+	return res, nil
+}
+
+// Delegations pulls list of delegations starting at the specified cursor.
+func (mb *MemDbBridge) Delegations(cursor *string, count int32, filter *bson.D) (*types.DelegationList, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero delegations requested")
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	pg, err := mb.list(mb.delegations, cursor, count, filter, func(c string) (uint64, error) {
+		return listOrdinal(nil, c, 16)
+	})
+	if err != nil {
+		mb.log.Errorf("can not load delegation list; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.DelegationList{
+		Collection: make([]*types.Delegation, 0, len(pg.Docs)),
+		Total:      pg.Total,
+		First:      pg.First,
+		IsStart:    pg.IsStart,
+		IsEnd:      pg.IsEnd,
+	}
+	if filter != nil {
+		list.Filter = *filter
+	}
+
+	for _, d := range pg.Docs {
+		var row types.Delegation
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+
+	// reverse on negative so new-er delegations will be on top
+	if count < 0 {
+		list.Reverse()
+	}
+	return &list, nil
+}
+
+// DelegationsAll pulls list of delegations for the given filter un-paged.
+func (mb *MemDbBridge) DelegationsAll(filter *bson.D) ([]*types.Delegation, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.delegations.find(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.Delegation, 0, len(docs))
+	for _, d := range docs {
+		var row types.Delegation
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+
+	// the newest delegations go first
+	sort.SliceStable(list, func(i, j int) bool { return list[i].CreatedTime > list[j].CreatedTime })
+	return list, nil
+}
+
+// AddDelegationOperation stores a delegation operation; re-processing
+// the same event log just replaces the existing record.
+func (mb *MemDbBridge) AddDelegationOperation(op *types.DelegationOperation) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.delegationOp.put(op.Pk(), op.OrdinalIndex(), op)
+}
+
+// DelegationOperations pulls list of delegation operations starting at the specified cursor.
+func (mb *MemDbBridge) DelegationOperations(cursor *string, count int32, filter *bson.D) (*types.DelegationOperationList, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero delegation operations requested")
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	pg, err := mb.list(mb.delegationOp, cursor, count, filter, func(c string) (uint64, error) {
+		if ix, ok := types.DecodeListCursor(c); ok {
+			return ix, nil
+		}
+		return 0, fmt.Errorf("invalid delegation operations cursor %s", c)
+	})
+	if err != nil {
+		mb.log.Errorf("can not load delegation operations list; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.DelegationOperationList{
+		Collection: make([]*types.DelegationOperation, 0, len(pg.Docs)),
+		Total:      pg.Total,
+		First:      pg.First,
+		IsStart:    pg.IsStart,
+		IsEnd:      pg.IsEnd,
+	}
+	if filter != nil {
+		list.Filter = *filter
+	}
+
+	for _, d := range pg.Docs {
+		var row types.DelegationOperation
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+
+	// reverse on negative so new-er operations will be on top
+	if count < 0 {
+		list.Reverse()
+	}
+	return &list, nil
+}
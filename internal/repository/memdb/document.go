@@ -0,0 +1,562 @@
+package memdb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// document represents a single stored document of a collection.
+// The ordinal index sorts the document in the lists.
+type document struct {
+	id  string
+	ord uint64
+	doc bson.M
+}
+
+// collection represents a set of documents searchable by the same filters
+// the database bridge uses. The documents are kept in their BSON form, so the filters
+// built for the database apply to them as they are.
+type collection struct {
+	docs map[string]*document
+}
+
+// newCollection creates a new empty collection.
+func newCollection() *collection {
+	return &collection{docs: make(map[string]*document)}
+}
+
+// put inserts, or replaces the document with the given id.
+func (c *collection) put(id string, ord uint64, v interface{}) error {
+	raw, err := bson.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+
+	c.docs[id] = &document{id: id, ord: ord, doc: doc}
+	return nil
+}
+
+// get decodes the document with the given id into the given value.
+// It returns FALSE if the document does not exist.
+func (c *collection) get(id string, v interface{}) (bool, error) {
+	d, ok := c.docs[id]
+	if !ok {
+		return false, nil
+	}
+	return true, d.decode(v)
+}
+
+// set updates the given fields of the document with the given id.
+// It returns FALSE if the document does not exist.
+func (c *collection) set(id string, fields bson.M) (bool, error) {
+	d, ok := c.docs[id]
+	if !ok {
+		return false, nil
+	}
+
+	raw, err := bson.Marshal(fields)
+	if err != nil {
+		return false, err
+	}
+
+	var upd bson.M
+	if err := bson.Unmarshal(raw, &upd); err != nil {
+		return false, err
+	}
+
+	for k, v := range upd {
+		d.doc[k] = v
+	}
+	return true, nil
+}
+
+// remove deletes the document with the given id.
+// It returns FALSE if the document does not exist.
+func (c *collection) remove(id string) bool {
+	_, ok := c.docs[id]
+	delete(c.docs, id)
+	return ok
+}
+
+// has checks if the document with the given id exists.
+func (c *collection) has(id string) bool {
+	_, ok := c.docs[id]
+	return ok
+}
+
+// find collects the documents matching the given filter ordered
+// by the ordinal index from the highest to the lowest.
+func (c *collection) find(filter *bson.D) ([]*document, error) {
+	fi, err := normalize(filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFilter(fi); err != nil {
+		return nil, err
+	}
+
+	list := make([]*document, 0)
+	for _, d := range c.docs {
+		if matchDoc(d.doc, fi) {
+			list = append(list, d)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ord == list[j].ord {
+			return list[i].id > list[j].id
+		}
+		return list[i].ord > list[j].ord
+	})
+	return list, nil
+}
+
+// ascending reverses the list of documents found so the lowest ordinal index goes first.
+func ascending(list []*document) []*document {
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return list
+}
+
+// top cuts the list of documents to the given size; non-positive limit keeps all.
+func top(list []*document, size int64) []*document {
+	if size > 0 && int64(len(list)) > size {
+		return list[:size]
+	}
+	return list
+}
+
+// all provides all the documents of the collection ordered
+// by the ordinal index from the highest to the lowest.
+func (c *collection) all() []*document {
+	list, _ := c.find(nil)
+	return list
+}
+
+// decode decodes the document into the given value.
+func (d *document) decode(v interface{}) error {
+	raw, err := bson.Marshal(d.doc)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(raw, v)
+}
+
+// listPage represents a page of a list of documents loaded from a cursor.
+type listPage struct {
+	Docs    []*document
+	Total   uint64
+	First   uint64
+	IsStart bool
+	IsEnd   bool
+}
+
+// page selects a page of the documents ordered by their ordinal index from the highest
+// to the lowest; it follows the paging of the database lists. Positive count pages down
+// from the cursor, negative count pages up, the cursor itself is excluded.
+// The documents of a page loaded upwards are ordered from the lowest index.
+func page(docs []*document, cursor *uint64, count int32) *listPage {
+	pg := listPage{Total: uint64(len(docs)), IsStart: len(docs) == 0, IsEnd: len(docs) == 0}
+	if len(docs) == 0 {
+		return &pg
+	}
+
+	// the starting point of the page
+	switch {
+	case cursor != nil:
+		pg.First = *cursor
+	case count > 0:
+		pg.First = docs[0].ord
+	default:
+		pg.First = docs[len(docs)-1].ord
+	}
+
+	// collect candidates in the direction of the page
+	cand := make([]*document, 0)
+	if count > 0 {
+		for _, d := range docs {
+			if d.ord < pg.First || (cursor == nil && d.ord == pg.First) {
+				cand = append(cand, d)
+			}
+		}
+	} else {
+		for i := len(docs) - 1; i >= 0; i-- {
+			if docs[i].ord > pg.First || (cursor == nil && docs[i].ord == pg.First) {
+				cand = append(cand, docs[i])
+			}
+		}
+	}
+
+	// load one more document than requested to detect the list boundary
+	limit := int(count)
+	if limit < 0 {
+		limit = -limit
+	}
+	if len(cand) > limit+1 {
+		cand = cand[:limit+1]
+	}
+
+	// the last document is kept only if the boundary was reached
+	var last *document
+	if len(cand) > 0 {
+		last = cand[len(cand)-1]
+		pg.Docs = cand[:len(cand)-1]
+	}
+
+	pg.IsEnd = (cursor == nil && count < 0) || (count > 0 && len(pg.Docs) < limit)
+	pg.IsStart = (cursor == nil && count > 0) || (count < 0 && len(pg.Docs) < limit)
+	if (pg.IsStart || pg.IsEnd) && last != nil {
+		pg.Docs = append(pg.Docs, last)
+	}
+	return &pg
+}
+
+// normalize converts the filter into the BSON primitives stored documents consist of.
+func normalize(filter *bson.D) (bson.D, error) {
+	if filter == nil || len(*filter) == 0 {
+		return bson.D{}, nil
+	}
+
+	raw, err := bson.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var fi bson.D
+	if err := bson.Unmarshal(raw, &fi); err != nil {
+		return nil, err
+	}
+	return fi, nil
+}
+
+// checkFilter verifies the filter uses only the operators the in-memory storage
+// can evaluate, so an unsupported filter fails instead of silently matching nothing.
+func checkFilter(filter bson.D) error {
+	for _, e := range filter {
+		switch {
+		case e.Key == "$or" || e.Key == "$and":
+			arr, ok := e.Value.(primitive.A)
+			if !ok {
+				return fmt.Errorf("operator %s expects an array", e.Key)
+			}
+			for _, v := range arr {
+				sub, ok := v.(primitive.D)
+				if !ok {
+					return fmt.Errorf("operator %s expects an array of documents", e.Key)
+				}
+				if err := checkFilter(bson.D(sub)); err != nil {
+					return err
+				}
+			}
+		case strings.HasPrefix(e.Key, "$"):
+			return fmt.Errorf("operator %s not supported by memory storage", e.Key)
+		default:
+			if err := checkCondition(e.Key, e.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkCondition verifies the operators of the condition of a single field.
+func checkCondition(field string, cond interface{}) error {
+	ops, ok := cond.(primitive.D)
+	if !ok || len(ops) == 0 || !strings.HasPrefix(ops[0].Key, "$") {
+		return nil
+	}
+
+	for _, op := range ops {
+		switch op.Key {
+		case "$exists", "$eq", "$ne", "$gt", "$gte", "$lt", "$lte":
+		case "$in", "$nin":
+			if _, ok := op.Value.(primitive.A); !ok {
+				return fmt.Errorf("operator %s of %s expects an array", op.Key, field)
+			}
+		case "$type":
+			if _, err := isType(nil, op.Value); err != nil {
+				return fmt.Errorf("%s of %s; %s", op.Key, field, err.Error())
+			}
+		default:
+			return fmt.Errorf("operator %s of %s not supported by memory storage", op.Key, field)
+		}
+	}
+	return nil
+}
+
+// matchDoc checks if the document matches all the conditions of the filter.
+func matchDoc(doc bson.M, filter bson.D) bool {
+	for _, e := range filter {
+		switch e.Key {
+		case "$or":
+			if !matchAny(doc, e.Value) {
+				return false
+			}
+		case "$and":
+			for _, sub := range subFilters(e.Value) {
+				if !matchDoc(doc, sub) {
+					return false
+				}
+			}
+		default:
+			val, exists := lookup(doc, e.Key)
+			if !matchValue(val, exists, e.Value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchAny checks if the document matches any of the given filters.
+func matchAny(doc bson.M, list interface{}) bool {
+	for _, sub := range subFilters(list) {
+		if matchDoc(doc, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// subFilters extracts the list of filters of a logical operator.
+func subFilters(list interface{}) []bson.D {
+	arr, ok := list.(primitive.A)
+	if !ok {
+		return nil
+	}
+
+	out := make([]bson.D, 0, len(arr))
+	for _, v := range arr {
+		if d, ok := v.(primitive.D); ok {
+			out = append(out, bson.D(d))
+		}
+	}
+	return out
+}
+
+// lookup finds the value of the given, possibly dotted, field path in the document.
+func lookup(doc bson.M, path string) (interface{}, bool) {
+	var cur interface{} = doc
+	for _, key := range strings.Split(path, ".") {
+		switch node := cur.(type) {
+		case bson.M:
+			v, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		case primitive.D:
+			v, ok := node.Map()[key]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// matchValue checks if the value of a document field matches the condition.
+// The condition is either a value to be equal to, or a document of operators.
+// Missing fields are equal to null, as they are in the database.
+func matchValue(val interface{}, exists bool, cond interface{}) bool {
+	ops, ok := cond.(primitive.D)
+	if !ok || len(ops) == 0 || !strings.HasPrefix(ops[0].Key, "$") {
+		return equals(val, cond)
+	}
+
+	for _, op := range ops {
+		if !matchOperator(val, exists, op.Key, op.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchOperator checks the value of a document field against a single query operator.
+func matchOperator(val interface{}, exists bool, op string, arg interface{}) bool {
+	switch op {
+	case "$exists":
+		want, _ := arg.(bool)
+		return exists == want
+	case "$eq":
+		return equals(val, arg)
+	case "$ne":
+		return !equals(val, arg)
+	case "$in":
+		return inList(val, arg)
+	case "$nin":
+		return !inList(val, arg)
+	case "$type":
+		ok, _ := isType(val, arg)
+		return exists && ok
+	case "$gt", "$gte", "$lt", "$lte":
+		if !exists {
+			return false
+		}
+		cmp, ok := compare(val, arg)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "$gt":
+			return cmp > 0
+		case "$gte":
+			return cmp >= 0
+		case "$lt":
+			return cmp < 0
+		default:
+			return cmp <= 0
+		}
+	}
+
+	// unreachable; the filter has been checked before matching
+	return false
+}
+
+// inList checks if the value equals to any of the items of the given array.
+func inList(val interface{}, list interface{}) bool {
+	arr, ok := list.(primitive.A)
+	if !ok {
+		return false
+	}
+	for _, item := range arr {
+		if equals(val, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// isType checks the BSON type of the value; only the types used by the API filters are recognized.
+func isType(val interface{}, t interface{}) (bool, error) {
+	switch t {
+	case "string", int32(2), int64(2):
+		_, ok := val.(string)
+		return ok, nil
+	case "null", int32(10), int64(10):
+		return val == nil, nil
+	}
+	return false, fmt.Errorf("type %v not supported by memory storage", t)
+}
+
+// equals checks if the document value equals to the given value.
+// Array values match if any of the items equals to the value.
+func equals(val interface{}, want interface{}) bool {
+	if arr, ok := val.(primitive.A); ok {
+		if _, isArr := want.(primitive.A); !isArr {
+			for _, item := range arr {
+				if equals(item, want) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	if val == nil || want == nil {
+		return val == nil && want == nil
+	}
+
+	cmp, ok := compare(val, want)
+	if ok {
+		return cmp == 0
+	}
+	return fmt.Sprint(val) == fmt.Sprint(want)
+}
+
+// compare compares two BSON values of the same kind.
+// The second value is FALSE if the values can not be compared.
+func compare(a interface{}, b interface{}) (int, bool) {
+	// integers are compared exactly, other numbers as floats
+	if x, ok := toInt(a); ok {
+		if y, ok := toInt(b); ok {
+			return cmpInt(x, y), true
+		}
+	}
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			return cmpFloat(x, y), true
+		}
+	}
+
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	case bool:
+		if y, ok := b.(bool); ok && x == y {
+			return 0, true
+		}
+	case primitive.DateTime:
+		if y, ok := b.(primitive.DateTime); ok {
+			return cmpInt(int64(x), int64(y)), true
+		}
+	}
+	return 0, false
+}
+
+// toInt converts an integer BSON value.
+func toInt(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	}
+	return 0, false
+}
+
+// toFloat converts a numeric BSON value.
+func toFloat(v interface{}) (float64, bool) {
+	if n, ok := toInt(v); ok {
+		return float64(n), true
+	}
+	if f, ok := v.(float64); ok {
+		return f, true
+	}
+	return 0, false
+}
+
+// cmpInt compares two integers.
+func cmpInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// cmpFloat compares two floats.
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// dateTime converts the given time into the BSON date time stored in documents.
+func dateTime(t time.Time) primitive.DateTime {
+	return primitive.NewDateTimeFromTime(t)
+}
@@ -0,0 +1,169 @@
+package memdb
+
+import (
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// testCollection creates a collection of simple documents with the ordinal index
+// given by the position in the list.
+func testCollection(t *testing.T) *collection {
+	t.Helper()
+	c := newCollection()
+	docs := []bson.M{
+		{"_id": "a", "adr": "0x1", "to": "0x1", "val": int64(0), "amo": "0x0", "fin": nil},
+		{"_id": "b", "adr": "0x1", "to": "0x2", "val": int64(5), "amo": "0x5"},
+		{"_id": "c", "adr": "0x2", "to": "0x1", "val": int64(10), "amo": "0xa", "fin": "0xff"},
+		{"_id": "d", "adr": "0x3", "to": "0x2", "val": int64(15), "amo": "0xf", "tags": bson.A{"x", "y"}, "sub": bson.M{"k": "v"}},
+	}
+	for i, d := range docs {
+		if err := c.put(d["_id"].(string), uint64(i+1), d); err != nil {
+			t.Fatalf("can not put document; %s", err.Error())
+		}
+	}
+	return c
+}
+
+// ids provides the identifiers of the documents.
+func ids(list []*document) string {
+	out := make([]string, len(list))
+	for i, d := range list {
+		out[i] = d.id
+	}
+	return strings.Join(out, "")
+}
+
+func TestFindFilters(t *testing.T) {
+	c := testCollection(t)
+	tests := []struct {
+		name   string
+		filter *bson.D
+		want   string
+	}{
+		{"all", nil, "dcba"},
+		{"empty", &bson.D{}, "dcba"},
+		{"equal", &bson.D{{"adr", "0x1"}}, "ba"},
+		{"and", &bson.D{{"adr", "0x1"}, {"to", "0x2"}}, "b"},
+		{"or", &bson.D{{"$or", bson.A{bson.D{{"adr", "0x2"}}, bson.D{{"to", "0x2"}}}}}, "dcb"},
+		{"and operator", &bson.D{{"$and", bson.A{bson.D{{"adr", "0x1"}}, bson.D{{"val", bson.D{{"$gt", 0}}}}}}}, "b"},
+		{"gt", &bson.D{{"val", bson.D{{"$gt", 0}}}}, "dcb"},
+		{"gte lt", &bson.D{{"val", bson.D{{"$gte", 5}, {"$lt", int64(15)}}}}, "cb"},
+		{"lte", &bson.D{{"val", bson.D{{"$lte", uint64(5)}}}}, "ba"},
+		{"ne", &bson.D{{"amo", bson.D{{"$ne", "0x0"}}}}, "dcb"},
+		{"eq", &bson.D{{"amo", bson.D{{"$eq", "0xa"}}}}, "c"},
+		{"in", &bson.D{{"adr", bson.D{{"$in", bson.A{"0x2", "0x3"}}}}}, "dc"},
+		{"nin", &bson.D{{"adr", bson.D{{"$nin", bson.A{"0x2", "0x3"}}}}}, "ba"},
+		{"type null", &bson.D{{"fin", bson.D{{"$type", 10}}}}, "a"},
+		{"type string", &bson.D{{"fin", bson.D{{"$type", "string"}}}}, "c"},
+		{"null equal missing", &bson.D{{"fin", nil}}, "dba"},
+		{"exists", &bson.D{{"fin", bson.D{{"$exists", true}}}}, "ca"},
+		{"not exists", &bson.D{{"fin", bson.D{{"$exists", false}}}}, "db"},
+		{"array item", &bson.D{{"tags", "y"}}, "d"},
+		{"dotted", &bson.D{{"sub.k", "v"}}, "d"},
+	}
+	for _, tt := range tests {
+		docs, err := c.find(tt.filter)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err.Error())
+			continue
+		}
+		if got := ids(docs); got != tt.want {
+			t.Errorf("%s: found %s, expected %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindUnsupportedOperator(t *testing.T) {
+	c := testCollection(t)
+	filters := []*bson.D{
+		{{"val", bson.D{{"$mod", bson.A{2, 0}}}}},
+		{{"adr", bson.D{{"$regex", "^0x"}}}},
+		{{"$nor", bson.A{bson.D{{"adr", "0x1"}}}}},
+		{{"$or", bson.A{bson.D{{"val", bson.D{{"$size", 2}}}}}}},
+		{{"fin", bson.D{{"$type", "object"}}}},
+		{{"adr", bson.D{{"$in", "0x1"}}}},
+	}
+	for _, fi := range filters {
+		if _, err := c.find(fi); err == nil {
+			t.Errorf("filter %v accepted", *fi)
+		}
+		if _, err := newCollection().find(fi); err == nil {
+			t.Errorf("filter %v accepted on empty collection", *fi)
+		}
+	}
+}
+
+func TestFindSorting(t *testing.T) {
+	c := newCollection()
+	for _, d := range []struct {
+		id  string
+		ord uint64
+	}{{"a", 2}, {"b", 1}, {"c", 2}, {"d", 3}} {
+		if err := c.put(d.id, d.ord, bson.M{"_id": d.id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	docs, err := c.find(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(docs); got != "dcab" {
+		t.Errorf("descending order %s", got)
+	}
+	if got := ids(ascending(docs)); got != "bacd" {
+		t.Errorf("ascending order %s", got)
+	}
+	if got := ids(top(docs, 2)); got != "ba" {
+		t.Errorf("top %s", got)
+	}
+	if got := ids(top(docs, 0)); got != "bacd" {
+		t.Errorf("unlimited top %s", got)
+	}
+}
+
+func TestPageCursors(t *testing.T) {
+	c := newCollection()
+	for i, id := range []string{"a", "b", "c", "d", "e"} {
+		if err := c.put(id, uint64(10*(i+1)), bson.M{"_id": id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	docs, _ := c.find(nil)
+	cur := func(v uint64) *uint64 { return &v }
+
+	tests := []struct {
+		name     string
+		cursor   *uint64
+		count    int32
+		want     string
+		first    uint64
+		start    bool
+		end      bool
+		total    uint64
+		emptyCol bool
+	}{
+		// as in the database lists, the extra document is kept on the list boundary
+		{"top down", nil, 2, "edc", 50, true, false, 5, false},
+		{"down from cursor", cur(40), 2, "cb", 40, false, false, 5, false},
+		{"down to end", cur(30), 2, "ba", 30, false, true, 5, false},
+		{"down past end", cur(30), 5, "ba", 30, false, true, 5, false},
+		{"bottom up", nil, -2, "abc", 10, false, true, 5, false},
+		{"up from cursor", cur(20), -2, "cd", 20, false, false, 5, false},
+		{"up to start", cur(30), -2, "de", 30, true, false, 5, false},
+		{"all", nil, 10, "edcba", 50, true, true, 5, false},
+		{"empty", nil, 2, "", 0, true, true, 0, true},
+	}
+	for _, tt := range tests {
+		list := docs
+		if tt.emptyCol {
+			list = nil
+		}
+		pg := page(list, tt.cursor, tt.count)
+		if got := ids(pg.Docs); got != tt.want || pg.First != tt.first || pg.IsStart != tt.start || pg.IsEnd != tt.end || pg.Total != tt.total {
+			t.Errorf("%s: page %s, first %d, start %t, end %t, total %d", tt.name, got, pg.First, pg.IsStart, pg.IsEnd, pg.Total)
+		}
+	}
+}
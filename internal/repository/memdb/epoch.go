@@ -0,0 +1,191 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// epochStatsBatchSize is the max number of epochs without statistics loaded at once.
+const epochStatsBatchSize = 100
+
+// AddEpoch stores an epoch reference in the storage.
+func (mb *MemDbBridge) AddEpoch(e *types.Epoch) error {
+	// do we have all needed data? we reject epochs without any stake
+	if e == nil || e.EndTime == 0 || e.StakeTotalAmount.ToInt().Cmp(new(big.Int)) <= 0 {
+		return fmt.Errorf("empty epoch received")
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if _, ok := mb.epochs[uint64(e.Id)]; ok {
+		return nil
+	}
+
+	mb.epochs[uint64(e.Id)] = *e
+	mb.log.Debugf("epoch #%d added to storage", e.Id)
+	return nil
+}
+
+// LastKnownEpoch provides the number of the newest epoch stored.
+func (mb *MemDbBridge) LastKnownEpoch() (uint64, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := mb.epochList()
+	if len(list) == 0 {
+		return 0, fmt.Errorf("no epoch known")
+	}
+	return uint64(list[len(list)-1].Id), nil
+}
+
+// epochList provides all the known epochs sorted from the oldest to the newest.
+func (mb *MemDbBridge) epochList() []*types.Epoch {
+	list := make([]*types.Epoch, 0, len(mb.epochs))
+	for _, e := range mb.epochs {
+		row := e
+		list = append(list, &row)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].EndTime < list[j].EndTime })
+	return list
+}
+
+// Epochs pulls list of epochs starting at the specified cursor.
+func (mb *MemDbBridge) Epochs(cursor *string, count int32) (*types.EpochList, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero epochs requested")
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	// epochs are listed from the newest down
+	all := mb.epochList()
+	for i, j := 0, len(all)-1; i < j; i, j = i+1, j-1 {
+		all[i], all[j] = all[j], all[i]
+	}
+
+	list := types.EpochList{
+		Collection: make([]*types.Epoch, 0),
+		Total:      uint64(len(all)),
+		IsStart:    len(all) == 0,
+		IsEnd:      len(all) == 0,
+	}
+	if len(all) == 0 {
+		return &list, nil
+	}
+
+	// find the starting position
+	size := int(count)
+	if size < 0 {
+		size = -size
+	}
+
+	var from, to int
+	switch {
+	case cursor == nil && count > 0:
+		from, to = 0, size
+		list.IsStart = true
+	case cursor == nil && count < 0:
+		from, to = len(all)-size, len(all)
+		list.IsEnd = true
+	default:
+		id, err := listOrdinal(nil, *cursor, 16)
+		if err != nil {
+			return nil, err
+		}
+
+		pos := sort.Search(len(all), func(i int) bool { return uint64(all[i].Id) <= id })
+		if count > 0 {
+			if pos < len(all) && uint64(all[pos].Id) == id {
+				pos++
+			}
+			from, to = pos, pos+size
+		} else {
+			from, to = pos-size, pos
+		}
+	}
+
+	if from <= 0 {
+		from = 0
+		list.IsStart = true
+	}
+	if to >= len(all) {
+		to = len(all)
+		list.IsEnd = true
+	}
+	if from < to {
+		list.Collection = all[from:to]
+		list.First = uint64(list.Collection[0].Id)
+		list.Last = uint64(list.Collection[len(list.Collection)-1].Id)
+	}
+	return &list, nil
+}
+
+// EpochsWithoutStats loads a batch of the oldest stored epochs
+// not having aggregated statistics calculated yet.
+func (mb *MemDbBridge) EpochsWithoutStats() ([]*types.Epoch, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := make([]*types.Epoch, 0)
+	for _, e := range mb.epochList() {
+		if _, ok := mb.epochStats[uint64(e.Id)]; ok {
+			continue
+		}
+
+		list = append(list, e)
+		if len(list) >= epochStatsBatchSize {
+			break
+		}
+	}
+	return list, nil
+}
+
+// EpochsAfter loads a batch of stored epochs following the given epoch id
+// sorted from the oldest to the newest.
+func (mb *MemDbBridge) EpochsAfter(id uint64, limit int64) ([]*types.Epoch, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := make([]*types.Epoch, 0)
+	for _, e := range mb.epochList() {
+		if uint64(e.Id) > id {
+			list = append(list, e)
+		}
+	}
+
+	if limit > 0 && int64(len(list)) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+// SetEpochStats stores aggregated statistics of the given epoch.
+func (mb *MemDbBridge) SetEpochStats(id hexutil.Uint64, st *types.EpochStats) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if _, ok := mb.epochs[uint64(id)]; ok && st != nil {
+		mb.epochStats[uint64(id)] = *st
+	}
+	return nil
+}
+
+// EpochStats loads aggregated statistics of the given epoch.
+// It returns nil if the statistics are not available.
+func (mb *MemDbBridge) EpochStats(id hexutil.Uint64) (*types.EpochStats, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	st, ok := mb.epochStats[uint64(id)]
+	if !ok {
+		return nil, nil
+	}
+	return &st, nil
+}
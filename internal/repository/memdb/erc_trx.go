@@ -0,0 +1,97 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AddERC20Transaction stores an ERC20 transaction if it doesn't exist.
+func (mb *MemDbBridge) AddERC20Transaction(trx *types.Erc20Transaction) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if mb.erc20.has(trx.Pk()) {
+		return nil
+	}
+	return mb.erc20.put(trx.Pk(), trx.OrdinalIndex(), trx)
+}
+
+// Erc20Transactions pulls list of ERC20 transactions starting at the specified cursor.
+func (mb *MemDbBridge) Erc20Transactions(cursor *string, count int32, filter *bson.D) (*types.Erc20TransactionList, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero erc transactions requested")
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	pg, err := mb.list(mb.erc20, cursor, count, filter, func(c string) (uint64, error) {
+		return listOrdinal(mb.erc20, c, 0)
+	})
+	if err != nil {
+		mb.log.Errorf("can not load erc transactions list; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.Erc20TransactionList{
+		Collection: make([]*types.Erc20Transaction, 0, len(pg.Docs)),
+		Total:      pg.Total,
+		First:      pg.First,
+		IsStart:    pg.IsStart,
+		IsEnd:      pg.IsEnd,
+	}
+	if filter != nil {
+		list.Filter = *filter
+	}
+
+	for _, d := range pg.Docs {
+		var row types.Erc20Transaction
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+
+	// reverse on negative so new-er trx will be on top
+	if count < 0 {
+		list.Reverse()
+	}
+	return &list, nil
+}
+
+// Erc20Approvals loads the latest approval of each token and spender pair
+// granted by the given owner.
+func (mb *MemDbBridge) Erc20Approvals(owner *common.Address) ([]*types.Erc20Transaction, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.erc20.find(&bson.D{
+		{types.FiErc20TransactionSender, owner.String()},
+		{types.FiErc20TransactionType, types.ERC20TrxTypeApproval},
+		{types.FiErc20TransactionTokenType, types.AccountTypeERC20Token},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the documents go from the newest, so the first of each pair is the latest one
+	seen := make(map[string]bool)
+	list := make([]*types.Erc20Transaction, 0)
+	for _, d := range docs {
+		key := fmt.Sprint(d.doc[types.FiErc20TransactionToken], d.doc[types.FiErc20TransactionRecipient])
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		var row types.Erc20Transaction
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
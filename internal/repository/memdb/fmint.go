@@ -0,0 +1,132 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// fMintAccount represents a known fMint account and its latest state, if any.
+type fMintAccount struct {
+	first time.Time
+	state *types.BsonFMintAccountState
+}
+
+// AddFMintAccount registers an fMint account, if not known already.
+func (mb *MemDbBridge) AddFMintAccount(addr *common.Address) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if _, ok := mb.fMintAccounts[*addr]; !ok {
+		mb.fMintAccounts[*addr] = &fMintAccount{first: time.Now().UTC()}
+	}
+	return nil
+}
+
+// SetFMintAccountState updates the state of a known fMint account.
+func (mb *MemDbBridge) SetFMintAccountState(fa *types.FMintAccountState) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	// unknown accounts are not updated, same as the database does
+	acc, ok := mb.fMintAccounts[fa.Address]
+	if !ok {
+		return nil
+	}
+
+	row := fa.BsonState()
+	acc.state = &row
+	return nil
+}
+
+// FMintAccounts provides the addresses of all the known fMint accounts.
+func (mb *MemDbBridge) FMintAccounts() ([]common.Address, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := make([]common.Address, 0, len(mb.fMintAccounts))
+	for addr := range mb.fMintAccounts {
+		list = append(list, addr)
+	}
+	return list, nil
+}
+
+// FMintAccountsBelowRatio loads the active fMint accounts with the collateral to debt ratio
+// below the given value sorted by the account ordinal.
+func (mb *MemDbBridge) FMintAccountsBelowRatio(ratio4 uint64, cursor *string, count int32) (*types.FMintAccountStateList, error) {
+	var from *uint64
+	if cursor != nil {
+		ix, ok := types.DecodeListCursor(*cursor)
+		if !ok {
+			return nil, fmt.Errorf("invalid fMint accounts cursor %s", *cursor)
+		}
+		from = &ix
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	// collect the accounts at risk
+	rows := make([]*types.BsonFMintAccountState, 0)
+	for _, acc := range mb.fMintAccounts {
+		if acc.state != nil && acc.state.Active && acc.state.Ratio4 < int64(ratio4) {
+			rows = append(rows, acc.state)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Ordinal < rows[j].Ordinal })
+
+	list := types.FMintAccountStateList{Collection: make([]*types.FMintAccountState, 0), Total: uint64(len(rows)), IsEnd: true}
+	for _, row := range rows {
+		if from != nil && row.Ordinal <= int64(*from) {
+			continue
+		}
+		if len(list.Collection) == int(count) {
+			list.IsEnd = false
+			break
+		}
+
+		data, err := bson.Marshal(row)
+		if err != nil {
+			return nil, err
+		}
+
+		var fa types.FMintAccountState
+		if err := bson.Unmarshal(data, &fa); err != nil {
+			mb.log.Errorf("can not decode fMint account state; %s", err.Error())
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &fa)
+	}
+	return &list, nil
+}
+
+// AddFMintStats stores a snapshot of the fMint protocol statistics.
+func (mb *MemDbBridge) AddFMintStats(fs *types.FMintStats) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	ts := uint64(fs.Time.UnixNano())
+	return mb.fMintStats.put(strconv.FormatUint(ts, 10), ts, fs)
+}
+
+// LastFMintStats loads the latest snapshot of the fMint protocol statistics, or nil if none.
+func (mb *MemDbBridge) LastFMintStats() (*types.FMintStats, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs := mb.fMintStats.all()
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	var row types.FMintStats
+	if err := docs[0].decode(&row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
@@ -0,0 +1,57 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"strconv"
+)
+
+// ftmSupplyHistoryLimit is the max number of supply records loaded at once.
+const ftmSupplyHistoryLimit = 1000
+
+// AddFtmSupply stores the FTM supply of a sealed epoch.
+func (mb *MemDbBridge) AddFtmSupply(fs *types.FtmSupply) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.ftmSupply.put(strconv.FormatUint(uint64(fs.Epoch), 10), uint64(fs.Epoch), fs)
+}
+
+// LastFtmSupply loads the supply of the most recent epoch, or nil if not known.
+func (mb *MemDbBridge) LastFtmSupply() (*types.FtmSupply, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs := mb.ftmSupply.all()
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	var row types.FtmSupply
+	if err := docs[0].decode(&row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// FtmSupplyHistory loads the supply of the given range of epochs sorted from the oldest.
+func (mb *MemDbBridge) FtmSupplyHistory(from uint64, to uint64) ([]*types.FtmSupply, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := make([]*types.FtmSupply, 0)
+	for _, d := range ascending(mb.ftmSupply.all()) {
+		if d.ord < from || d.ord > to {
+			continue
+		}
+
+		var row types.FtmSupply
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+
+		list = append(list, &row)
+		if len(list) >= ftmSupplyHistoryLimit {
+			break
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,37 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AddressLabel loads the label of the given address.
+// It returns nil if the address does not have any label assigned.
+func (mb *MemDbBridge) AddressLabel(addr *common.Address) (*types.AddressLabel, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	var al types.AddressLabel
+	found, err := mb.labels.get(addr.String(), &al)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &al, nil
+}
+
+// StoreAddressLabel inserts, or replaces the given address label.
+func (mb *MemDbBridge) StoreAddressLabel(al *types.AddressLabel) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.labels.put(al.Address.String(), 0, al)
+}
+
+// RemoveAddressLabel removes the label of the given address.
+func (mb *MemDbBridge) RemoveAddressLabel(addr *common.Address) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	mb.labels.remove(addr.String())
+	return nil
+}
@@ -0,0 +1,52 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AddPriceSample stores a price sample, the sample of the same symbol and time is replaced.
+func (mb *MemDbBridge) AddPriceSample(ps *types.PriceSample) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.prices.put(ps.Pk(), uint64(ps.Time.UnixNano()), ps)
+}
+
+// PriceSampleBefore loads the latest price sample of the symbol recorded not after the given time.
+func (mb *MemDbBridge) PriceSampleBefore(sym string, ts time.Time) (*types.PriceSample, error) {
+	return mb.priceSample(sym, bson.D{{"$lte", ts}}, false)
+}
+
+// PriceSampleAfter loads the earliest price sample of the symbol recorded not before the given time.
+func (mb *MemDbBridge) PriceSampleAfter(sym string, ts time.Time) (*types.PriceSample, error) {
+	return mb.priceSample(sym, bson.D{{"$gte", ts}}, true)
+}
+
+// priceSample loads the price sample of the symbol nearest to the time condition, or nil if none.
+func (mb *MemDbBridge) priceSample(sym string, cond bson.D, earliest bool) (*types.PriceSample, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.prices.find(&bson.D{
+		{types.FiPriceSampleSymbol, strings.ToUpper(sym)},
+		{types.FiPriceSampleTime, cond},
+	})
+	if err != nil || len(docs) == 0 {
+		return nil, err
+	}
+
+	// samples are sorted from the latest
+	d := docs[0]
+	if earliest {
+		d = docs[len(docs)-1]
+	}
+
+	var ps types.PriceSample
+	if err := d.decode(&ps); err != nil {
+		return nil, err
+	}
+	return &ps, nil
+}
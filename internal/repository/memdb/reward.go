@@ -0,0 +1,120 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AddRewardClaim stores a reward claim if it doesn't exist.
+func (mb *MemDbBridge) AddRewardClaim(rc *types.RewardClaim) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if mb.rewards.has(rc.Pk()) {
+		return nil
+	}
+	return mb.rewards.put(rc.Pk(), rc.OrdinalIndex(), rc)
+}
+
+// RewardClaims pulls list of reward claims starting at the specified cursor.
+func (mb *MemDbBridge) RewardClaims(cursor *string, count int32, filter *bson.D) (*types.RewardClaimsList, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero reward claims requested")
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	pg, err := mb.list(mb.rewards, cursor, count, filter, func(c string) (uint64, error) {
+		return listOrdinal(mb.rewards, c, 0)
+	})
+	if err != nil {
+		mb.log.Errorf("can not load reward claims list; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.RewardClaimsList{
+		Collection: make([]*types.RewardClaim, 0, len(pg.Docs)),
+		Total:      pg.Total,
+		First:      pg.First,
+		IsStart:    pg.IsStart,
+		IsEnd:      pg.IsEnd,
+	}
+	if filter != nil {
+		list.Filter = *filter
+	}
+
+	for _, d := range pg.Docs {
+		var row types.RewardClaim
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+
+	// reverse on negative so new-er claims will be on top
+	if count < 0 {
+		list.Reverse()
+	}
+	return &list, nil
+}
+
+// RewardsSumValue calculates sum of values for all the reward claims by a filter.
+func (mb *MemDbBridge) RewardsSumValue(filter *bson.D) (*big.Int, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return sumFieldValue(mb.rewards, types.FiRewardClaimedValue, filter, types.RewardDecimalsCorrection)
+}
+
+// RewardsDailyList loads a range of daily reward claims aggregations.
+func (mb *MemDbBridge) RewardsDailyList(from *time.Time, to *time.Time) ([]*types.DailyRewards, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := make([]*types.DailyRewards, 0)
+	for _, v := range mb.rewardsDaily {
+		if inRange(v.Stamp, from, to) {
+			row := v
+			list = append(list, &row)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Day < list[j].Day })
+	if len(list) > 365 {
+		list = list[:365]
+	}
+	return list, nil
+}
+
+// RewardsDailyUpdate performs an update on the daily rewards aggregation
+// for the reward claims made after the given time.
+func (mb *MemDbBridge) RewardsDailyUpdate(from time.Time) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	days := make(map[string]types.DailyRewards)
+	for _, d := range mb.rewards.docs {
+		ts := stampValue(d.doc[types.FiRewardClaimedTimeStamp])
+		if ts.Before(from) {
+			continue
+		}
+
+		day := ts.Format("2006-01-02")
+		v := days[day]
+		v.Day = day
+		v.Stamp = ts.Truncate(24 * time.Hour)
+		v.Claims++
+		v.AmountAdjusted += intValue(d.doc[types.FiRewardClaimedValue])
+		days[day] = v
+	}
+
+	for day, v := range days {
+		mb.rewardsDaily[day] = v
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RiskFlag loads the risk flag of the given address, or nil if the address is not flagged.
+func (mb *MemDbBridge) RiskFlag(addr *common.Address) (*types.RiskFlag, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	var rf types.RiskFlag
+	ok, err := mb.risks.get(addr.String(), &rf)
+	if !ok || err != nil {
+		return nil, err
+	}
+	return &rf, nil
+}
+
+// StoreRiskFlag stores the risk flag of an address.
+func (mb *MemDbBridge) StoreRiskFlag(rf *types.RiskFlag) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.risks.put(rf.Address.String(), 0, rf)
+}
+
+// RemoveRiskFlag removes the risk flag of the given address.
+func (mb *MemDbBridge) RemoveRiskFlag(addr *common.Address) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	mb.risks.remove(addr.String())
+	return nil
+}
+
+// DenyListMatch checks the given addresses against a denylist.
+// The denylists are maintained by the operator in the database and can not be loaded
+// into the in-memory storage, so no address is ever denied.
+func (mb *MemDbBridge) DenyListMatch(_ string, _ []common.Address) (*types.ScreeningMatch, error) {
+	return nil, nil
+}
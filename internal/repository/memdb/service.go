@@ -0,0 +1,110 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"sort"
+	"time"
+)
+
+// serviceLock represents the lock of a service held by a holder until it expires.
+type serviceLock struct {
+	holder string
+	exp    time.Time
+}
+
+// AcquireServiceLock tries to acquire, or renew, the lock of the given service for the holder.
+// The lock is granted if it's free, expired, or already held by the same holder.
+func (mb *MemDbBridge) AcquireServiceLock(name string, holder string, ttl time.Duration) (bool, error) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	now := time.Now().UTC()
+	if lock, ok := mb.locks[name]; ok && lock.holder != holder && !lock.exp.Before(now) {
+		return false, nil
+	}
+
+	mb.locks[name] = serviceLock{holder: holder, exp: now.Add(ttl)}
+	return true, nil
+}
+
+// ReleaseServiceLock releases the lock of the given service if it's held by the holder.
+func (mb *MemDbBridge) ReleaseServiceLock(name string, holder string) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if lock, ok := mb.locks[name]; ok && lock.holder == holder {
+		delete(mb.locks, name)
+	}
+	return nil
+}
+
+// StoreServiceResult stores the latest result of the given service.
+func (mb *MemDbBridge) StoreServiceResult(name string, data []byte) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	mb.results[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// ServiceResult loads the latest result of the given service.
+// It returns nil if the service did not store any result yet.
+func (mb *MemDbBridge) ServiceResult(name string) ([]byte, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	data, ok := mb.results[name]
+	if !ok {
+		return nil, nil
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// AddUsage adds the given usage records to the hourly usage of their origins.
+// It returns the number of records stored.
+func (mb *MemDbBridge) AddUsage(list []*types.UsageRecord) (int, error) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	for _, ur := range list {
+		key := ur.Origin + "/" + ur.From.UTC().Format(time.RFC3339)
+		u, ok := mb.usage[key]
+		if !ok {
+			u = types.UsageRecord{Origin: ur.Origin, From: ur.From}
+		}
+
+		u.Requests += ur.Requests
+		u.Bytes += ur.Bytes
+		u.Cost += ur.Cost
+		mb.usage[key] = u
+	}
+	return len(list), nil
+}
+
+// UsageReport aggregates the API usage of all the origins in the given time range,
+// the heaviest users by the compute cost go first.
+func (mb *MemDbBridge) UsageReport(from time.Time, to time.Time) ([]*types.UsageRecord, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	origins := make(map[string]*types.UsageRecord)
+	list := make([]*types.UsageRecord, 0)
+	for _, u := range mb.usage {
+		if u.From.Before(from) || !u.From.Before(to) {
+			continue
+		}
+
+		row, ok := origins[u.Origin]
+		if !ok {
+			row = &types.UsageRecord{Origin: u.Origin, From: from, To: to}
+			origins[u.Origin] = row
+			list = append(list, row)
+		}
+		row.Requests += u.Requests
+		row.Bytes += u.Bytes
+		row.Cost += u.Cost
+	}
+
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Cost > list[j].Cost })
+	return list, nil
+}
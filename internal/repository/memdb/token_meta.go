@@ -0,0 +1,44 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TokenMeta loads the metadata of the given token, or nil if not known.
+func (mb *MemDbBridge) TokenMeta(addr *common.Address) (*types.TokenMeta, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	var tm types.TokenMeta
+	ok, err := mb.tokenMeta.get(addr.String(), &tm)
+	if !ok || err != nil {
+		return nil, err
+	}
+	return &tm, nil
+}
+
+// TokenMetaList loads the metadata of all the known tokens.
+func (mb *MemDbBridge) TokenMetaList() ([]*types.TokenMeta, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs := mb.tokenMeta.all()
+	list := make([]*types.TokenMeta, 0, len(docs))
+	for _, d := range docs {
+		var tm types.TokenMeta
+		if err := d.decode(&tm); err != nil {
+			return nil, err
+		}
+		list = append(list, &tm)
+	}
+	return list, nil
+}
+
+// StoreTokenMeta stores the metadata of a token.
+func (mb *MemDbBridge) StoreTokenMeta(tm *types.TokenMeta) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.tokenMeta.put(tm.Address.String(), 0, tm)
+}
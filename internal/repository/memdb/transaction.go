@@ -0,0 +1,272 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AddTransaction stores a transaction reference in the storage.
+// A known transaction is replaced by the given one.
+func (mb *MemDbBridge) AddTransaction(block *types.Block, trx *types.Transaction) error {
+	if block == nil || trx == nil {
+		return fmt.Errorf("can not add empty transaction")
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if err := mb.trx.put(trx.Hash.String(), trx.Uid(), trx); err != nil {
+		mb.log.Errorf("can not store transaction %s; %s", trx.Hash.String(), err.Error())
+		return err
+	}
+	return nil
+}
+
+// TransactionsCount returns the number of transactions stored.
+func (mb *MemDbBridge) TransactionsCount() (uint64, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return uint64(len(mb.trx.docs)), nil
+}
+
+// Transactions pulls list of transaction hashes starting on the specified cursor.
+func (mb *MemDbBridge) Transactions(cursor *string, count int32, filter *bson.D) (*types.TransactionList, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero transactions requested")
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return mb.trxList(cursor, count, filter)
+}
+
+// AccountTransactions loads list of transactions of an account.
+func (mb *MemDbBridge) AccountTransactions(addr *common.Address, cursor *string, count int32) (*types.TransactionList, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero blocks requested")
+	}
+	if addr == nil {
+		return nil, fmt.Errorf("can not list transactions of empty account")
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	filter := bson.D{{"$or", bson.A{bson.D{{"from", addr.String()}}, bson.D{{"to", addr.String()}}}}}
+	return mb.trxList(cursor, count, &filter)
+}
+
+// LargeTransactions pulls list of transactions transferring at least the given value
+// starting on the specified cursor.
+func (mb *MemDbBridge) LargeTransactions(minValue *big.Int, cursor *string, count int32) (*types.TransactionList, error) {
+	amo := new(big.Int).Div(minValue, types.TransactionDecimalsCorrection)
+	min := int64(math.MaxInt64)
+	if amo.IsInt64() {
+		min = amo.Int64()
+	}
+
+	filter := bson.D{{"amo", bson.D{{"$gte", min}}}}
+	return mb.Transactions(cursor, count, &filter)
+}
+
+// trxList loads a page of the transactions matching the given filter.
+func (mb *MemDbBridge) trxList(cursor *string, count int32, filter *bson.D) (*types.TransactionList, error) {
+	pg, err := mb.list(mb.trx, cursor, count, filter, func(c string) (uint64, error) {
+		return listOrdinal(mb.trx, c, 0)
+	})
+	if err != nil {
+		mb.log.Errorf("can not load transactions list; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.TransactionList{
+		Collection: make([]*types.Transaction, 0, len(pg.Docs)),
+		Total:      pg.Total,
+		First:      pg.First,
+		IsStart:    pg.IsStart,
+		IsEnd:      pg.IsEnd,
+	}
+	if filter != nil {
+		list.Filter = *filter
+	}
+
+	for _, d := range pg.Docs {
+		var row types.Transaction
+		if err := d.decode(&row); err != nil {
+			mb.log.Errorf("can not decode the list row; %s", err.Error())
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+
+	// reverse on negative so new-er transaction will be on top
+	if count < 0 {
+		list.Reverse()
+	}
+	return &list, nil
+}
+
+// LastBlockBefore returns the number of the last known block containing
+// a transaction stamped at or before the given time.
+func (mb *MemDbBridge) LastBlockBefore(ts time.Time) (uint64, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	var top int64
+	found := false
+	for _, d := range mb.trx.docs {
+		blk, ok := toInt(d.doc["blk"])
+		if ok && !trxStamp(d).After(ts) && (!found || blk > top) {
+			top, found = blk, true
+		}
+	}
+
+	if !found {
+		return 0, fmt.Errorf("no block known before %s", ts.String())
+	}
+	return uint64(top), nil
+}
+
+// TrxDailyFlowList loads a range of daily trx volumes.
+func (mb *MemDbBridge) TrxDailyFlowList(from *time.Time, to *time.Time) ([]*types.DailyTrxVolume, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := make([]*types.DailyTrxVolume, 0)
+	for _, v := range mb.trxVolume {
+		if inRange(v.Stamp, from, to) {
+			row := v
+			list = append(list, &row)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Day < list[j].Day })
+	if len(list) > 365 {
+		list = list[:365]
+	}
+	return list, nil
+}
+
+// TrxDailyFlowUpdate performs an update on the daily trx flow data
+// for the given date range directly.
+func (mb *MemDbBridge) TrxDailyFlowUpdate(from time.Time) error {
+	mb.log.Noticef("updating trx flow after %s", from)
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	days := make(map[string]types.DailyTrxVolume)
+	for _, d := range mb.trx.docs {
+		ts := trxStamp(d)
+		if ts.Before(from) {
+			continue
+		}
+
+		day := ts.UTC().Format("2006-01-02")
+		v := days[day]
+		v.Day = day
+		v.Stamp = ts.UTC().Truncate(24 * time.Hour)
+		v.Counter++
+		v.AmountAdjusted += intValue(d.doc["amo"])
+		v.Gas += intValue(d.doc["gas_use"])
+		days[day] = v
+	}
+
+	for day, v := range days {
+		mb.trxVolume[day] = v
+	}
+	return nil
+}
+
+// TrxGasSpeed provides amount of gas consumed by transaction per second
+// in the given time range.
+func (mb *MemDbBridge) TrxGasSpeed(from *time.Time, to *time.Time) (float64, error) {
+	if !from.Before(*to) {
+		return 0.0, fmt.Errorf("invalid time range requested")
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	var gas int64
+	found := false
+	for _, d := range mb.trx.docs {
+		if inRange(trxStamp(d), from, to) {
+			gas += intValue(d.doc["gas_use"])
+			found = true
+		}
+	}
+
+	if !found {
+		return 0.0, fmt.Errorf("gas speed aggregation failure")
+	}
+	return float64(gas) / to.Sub(*from).Seconds(), nil
+}
+
+// TrxRecentTrxSpeed provides the number of transaction per second on the defined range in seconds.
+func (mb *MemDbBridge) TrxRecentTrxSpeed(sec int32) (float64, error) {
+	if sec < 60 {
+		sec = 60
+	}
+	from := time.Now().UTC().Add(time.Duration(-sec) * time.Second)
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	var total int
+	for _, d := range mb.trx.docs {
+		if !trxStamp(d).Before(from) {
+			total++
+		}
+	}
+	return float64(total) / float64(sec), nil
+}
+
+// TrxRangeStats calculates the number of transactions and the total amount of gas
+// consumed by transactions with time stamp in the given time range (from, to].
+func (mb *MemDbBridge) TrxRangeStats(from time.Time, to time.Time) (uint64, uint64, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	var count, gas uint64
+	for _, d := range mb.trx.docs {
+		ts := trxStamp(d)
+		if ts.After(from) && !ts.After(to) {
+			count++
+			gas += uint64(intValue(d.doc["gas_use"]))
+		}
+	}
+	return count, gas, nil
+}
+
+// trxStamp provides the time stamp of the stored transaction document.
+func trxStamp(d *document) time.Time {
+	return stampValue(d.doc["stamp"])
+}
+
+// stampValue converts a stored date time value.
+func stampValue(v interface{}) time.Time {
+	if dt, ok := v.(primitive.DateTime); ok {
+		return dt.Time().UTC()
+	}
+	return time.Time{}
+}
+
+// intValue converts a stored integer value; missing values are zero.
+func intValue(v interface{}) int64 {
+	n, _ := toInt(v)
+	return n
+}
+
+// inRange checks if the time is inside the given inclusive range; missing bounds are open.
+func inRange(ts time.Time, from *time.Time, to *time.Time) bool {
+	return (from == nil || !ts.Before(*from)) && (to == nil || !ts.After(*to))
+}
@@ -0,0 +1,434 @@
+package memdb
+
+import (
+	"crypto/sha256"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// decChange is the decimal correction applied to the stored swap amounts.
+var decChange = new(big.Int).SetUint64(1000000000)
+
+// swapRecord represents a stored swap with the amounts reduced by the decimal correction.
+type swapRecord struct {
+	ID         string    `bson:"_id"`
+	OrdIndex   uint64    `bson:"orx"`
+	Block      uint64    `bson:"blk"`
+	Type       int       `bson:"type"`
+	Date       time.Time `bson:"date"`
+	TxHash     string    `bson:"tx"`
+	Pair       string    `bson:"pair"`
+	Sender     string    `bson:"sender"`
+	Amount0In  uint64    `bson:"am0in"`
+	Amount0Out uint64    `bson:"am0out"`
+	Amount1In  uint64    `bson:"am1in"`
+	Amount1Out uint64    `bson:"am1out"`
+	Reserve0   uint64    `bson:"reserve0"`
+	Reserve1   uint64    `bson:"reserve1"`
+}
+
+// swapKey provides the unique key of the swap in the given pair.
+func swapKey(swap *types.Swap) string {
+	sum := sha256.Sum256(append(swap.Hash.Big().Bytes(), swap.Pair.Bytes()...))
+	return common.BytesToHash(sum[:]).String()
+}
+
+// removeDecimals reduces the given amount by the decimal correction.
+func removeDecimals(val *big.Int) uint64 {
+	if val == nil {
+		return 0
+	}
+	return new(big.Int).Div(val, decChange).Uint64()
+}
+
+// returnDecimals restores the decimal correction of the given stored amount.
+func returnDecimals(val uint64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(val), decChange)
+}
+
+// isZeroSwap checks if the swap amounts are zero after removing decimals.
+// Sync swaps carry only reserves and are never considered zero.
+func isZeroSwap(swap *types.Swap) bool {
+	if swap.Type == types.SwapSync {
+		return false
+	}
+	return removeDecimals(new(big.Int).Add(swap.Amount0In, swap.Amount0Out)) == 0 ||
+		removeDecimals(new(big.Int).Add(swap.Amount1In, swap.Amount1Out)) == 0
+}
+
+// UniswapAdd stores a swap of a uniswap pair. A sync swap of a known swap updates its reserves,
+// a swap following a stored sync swap replaces it and takes over its reserves.
+func (mb *MemDbBridge) UniswapAdd(swap *types.Swap) error {
+	if swap == nil {
+		return fmt.Errorf("can not add empty swap")
+	}
+	if isZeroSwap(swap) {
+		mb.log.Debugf("swap from block %d skipped, zero amount after removing decimals", uint64(*swap.BlockNumber))
+		return nil
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	key := swapKey(swap)
+	row := swapRecord{
+		ID:         key,
+		OrdIndex:   swap.OrdIndex,
+		Block:      uint64(*swap.BlockNumber),
+		Type:       swap.Type,
+		Date:       time.Unix(int64(*swap.TimeStamp), 0).UTC(),
+		TxHash:     swap.Hash.String(),
+		Pair:       swap.Pair.String(),
+		Sender:     swap.Sender.String(),
+		Amount0In:  removeDecimals(swap.Amount0In),
+		Amount0Out: removeDecimals(swap.Amount0Out),
+		Amount1In:  removeDecimals(swap.Amount1In),
+		Amount1Out: removeDecimals(swap.Amount1Out),
+		Reserve0:   removeDecimals(swap.Reserve0),
+		Reserve1:   removeDecimals(swap.Reserve1),
+	}
+
+	var known swapRecord
+	ok, err := mb.swaps.get(key, &known)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if swap.Type == types.SwapSync {
+			_, err := mb.swaps.set(key, bson.M{"reserve0": row.Reserve0, "reserve1": row.Reserve1})
+			return err
+		}
+		if known.Type != types.SwapSync {
+			return nil
+		}
+
+		row.Reserve0, row.Reserve1 = known.Reserve0, known.Reserve1
+	}
+	return mb.swaps.put(key, row.OrdIndex, &row)
+}
+
+// LastKnownSwapBlock provides the number of the last block with swaps processed.
+func (mb *MemDbBridge) LastKnownSwapBlock() (uint64, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return mb.swapBlock, nil
+}
+
+// UniswapUpdateLastKnownSwapBlock stores the number of the last block with swaps processed.
+func (mb *MemDbBridge) UniswapUpdateLastKnownSwapBlock(blkNumber uint64) error {
+	if blkNumber == 0 {
+		return fmt.Errorf("no need to store zero value, will start from 0 next time")
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.swapBlock = blkNumber
+	return nil
+}
+
+// pairSwaps provides the stored swaps of the given pair in the given time range
+// sorted by the swap time; zero to time means an open range.
+func (mb *MemDbBridge) pairSwaps(pair *common.Address, fromTime int64, toTime int64) ([]swapRecord, error) {
+	from := time.Unix(fromTime, 0)
+	to := time.Unix(toTime, 0)
+
+	list := make([]swapRecord, 0)
+	for _, d := range mb.swaps.docs {
+		var row swapRecord
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		if row.Pair != pair.String() || row.Date.Before(from) || (toTime != 0 && row.Date.After(to)) {
+			continue
+		}
+		list = append(list, row)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Date.Before(list[j].Date) })
+	return list, nil
+}
+
+// swapTimeSlot provides the label of the time slot of the given resolution the swap falls into.
+func swapTimeSlot(date time.Time, resolution string) string {
+	mul := int64(60 * 1000)
+	switch resolution {
+	case "month":
+		mul *= 30 * 24 * 60
+	case "4h":
+		mul *= 4 * 60
+	case "1h":
+		mul *= 60
+	case "30m":
+		mul *= 30
+	case "15m":
+		mul *= 15
+	case "5m":
+		mul *= 5
+	case "1m":
+	default:
+		mul *= 24 * 60
+	}
+
+	ms := date.UnixNano() / int64(time.Millisecond)
+	ms -= ms % mul
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+// UniswapVolume calculates the swap volume of the first token of the pair in the given time range.
+func (mb *MemDbBridge) UniswapVolume(pairAddress *common.Address, fromTime int64, toTime int64) (types.DefiSwapVolume, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	vol := types.DefiSwapVolume{PairAddress: pairAddress, Volume: big.NewInt(0)}
+	list, err := mb.pairSwaps(pairAddress, fromTime, toTime)
+	if err != nil {
+		return vol, err
+	}
+
+	var total uint64
+	for _, s := range list {
+		total += s.Amount0In + s.Amount0Out
+	}
+	vol.Volume = returnDecimals(total)
+	return vol, nil
+}
+
+// UniswapTimeVolumes calculates the swap volumes of the first token of the pair
+// in time slots of the given resolution.
+func (mb *MemDbBridge) UniswapTimeVolumes(pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiSwapVolume, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	swaps, err := mb.pairSwaps(pairAddress, fromTime, toTime)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := make([]string, 0)
+	totals := make(map[string]uint64)
+	for _, s := range swaps {
+		slot := swapTimeSlot(s.Date, resolution)
+		if _, ok := totals[slot]; !ok {
+			slots = append(slots, slot)
+		}
+		totals[slot] += s.Amount0In + s.Amount0Out
+	}
+
+	list := make([]types.DefiSwapVolume, 0, len(slots))
+	for _, slot := range slots {
+		list = append(list, types.DefiSwapVolume{
+			PairAddress: pairAddress,
+			Volume:      returnDecimals(totals[slot]),
+			DateString:  slot,
+		})
+	}
+	return list, nil
+}
+
+// UniswapTimePrices calculates the prices of the pair tokens in time slots of the given resolution.
+// Zero direction provides price of the second token in the first one.
+func (mb *MemDbBridge) UniswapTimePrices(pairAddress *common.Address, resolution string, fromTime int64, toTime int64, direction int32) ([]types.DefiTimePrice, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	swaps, err := mb.pairSwaps(pairAddress, fromTime, toTime)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]types.DefiTimePrice, 0)
+	var counts []int
+	for _, s := range swaps {
+		if s.Type == types.SwapSync {
+			continue
+		}
+
+		a, b := float64(s.Amount0In+s.Amount0Out), float64(s.Amount1In+s.Amount1Out)
+		if direction != 0 {
+			a, b = b, a
+		}
+		price := a / b
+
+		slot := swapTimeSlot(s.Date, resolution)
+		if len(list) == 0 || list[len(list)-1].Time != slot {
+			list = append(list, types.DefiTimePrice{PairAddress: *pairAddress, Time: slot, Open: price, Low: price, High: price})
+			counts = append(counts, 0)
+		}
+
+		tp := &list[len(list)-1]
+		tp.Close = price
+		if price < tp.Low {
+			tp.Low = price
+		}
+		if price > tp.High {
+			tp.High = price
+		}
+
+		counts[len(counts)-1]++
+		tp.Average += (price - tp.Average) / float64(counts[len(counts)-1])
+	}
+	return list, nil
+}
+
+// UniswapTimeReserves provides the closing reserves of the pair in time slots of the given resolution.
+func (mb *MemDbBridge) UniswapTimeReserves(pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiTimeReserve, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	swaps, err := mb.pairSwaps(pairAddress, fromTime, toTime)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]types.DefiTimeReserve, 0)
+	for _, s := range swaps {
+		res := []hexutil.Big{hexutil.Big(*returnDecimals(s.Reserve0)), hexutil.Big(*returnDecimals(s.Reserve1))}
+
+		slot := swapTimeSlot(s.Date, resolution)
+		if len(list) == 0 || list[len(list)-1].Time != slot {
+			list = append(list, types.DefiTimeReserve{Time: slot})
+		}
+		list[len(list)-1].ReserveClose = res
+	}
+	return list, nil
+}
+
+// UniswapActions provides a list of uniswap actions of the given pair and type;
+// nil pair means all the pairs, negative type means all the types.
+func (mb *MemDbBridge) UniswapActions(pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero uniswap actions requested")
+	}
+
+	filter := bson.D{}
+	if pairAddress != nil {
+		filter = append(filter, bson.E{Key: "pair", Value: pairAddress.String()})
+	}
+	if actionType >= 0 {
+		filter = append(filter, bson.E{Key: "type", Value: actionType})
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	pg, err := mb.list(mb.swaps, cursor, count, &filter, func(c string) (uint64, error) {
+		return listOrdinal(nil, c, 10)
+	})
+	if err != nil {
+		mb.log.Errorf("can not load uniswap action list; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.UniswapActionList{
+		Collection: make([]*types.UniswapAction, 0, len(pg.Docs)),
+		Total:      pg.Total,
+		First:      pg.First,
+		IsStart:    pg.IsStart,
+		IsEnd:      pg.IsEnd,
+	}
+
+	for _, d := range pg.Docs {
+		var row swapRecord
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+
+		list.Collection = append(list.Collection, &types.UniswapAction{
+			ID:              common.HexToHash(row.ID),
+			OrdIndex:        row.OrdIndex,
+			BlockNr:         hexutil.Uint64(row.Block),
+			Type:            int32(row.Type),
+			PairAddress:     common.HexToAddress(row.Pair),
+			Sender:          common.HexToAddress(row.Sender),
+			TransactionHash: common.HexToHash(row.TxHash),
+			Time:            hexutil.Uint64(row.Date.UTC().Unix()),
+			Amount0in:       hexutil.Big(*returnDecimals(row.Amount0In)),
+			Amount0out:      hexutil.Big(*returnDecimals(row.Amount0Out)),
+			Amount1in:       hexutil.Big(*returnDecimals(row.Amount1In)),
+			Amount1out:      hexutil.Big(*returnDecimals(row.Amount1Out)),
+		})
+		list.Last = row.OrdIndex
+	}
+
+	if cursor != nil && len(list.Collection) > 0 {
+		list.First = list.Collection[0].OrdIndex
+	}
+	return &list, nil
+}
+
+// UniswapPositionEntries aggregates the liquidity added and removed by the given owner per pair.
+func (mb *MemDbBridge) UniswapPositionEntries(owner *common.Address) ([]*types.UniswapPositionEntry, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.swaps.find(&bson.D{
+		{Key: "sender", Value: owner.String()},
+		{Key: "type", Value: bson.D{{Key: "$in", Value: bson.A{types.SwapMint, types.SwapBurn}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]string, 0)
+	sums := make(map[string]*swapRecord)
+	for _, d := range docs {
+		var row swapRecord
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+
+		sum, ok := sums[row.Pair]
+		if !ok {
+			sum = &swapRecord{}
+			sums[row.Pair] = sum
+			pairs = append(pairs, row.Pair)
+		}
+		sum.Amount0In += row.Amount0In
+		sum.Amount1In += row.Amount1In
+		sum.Amount0Out += row.Amount0Out
+		sum.Amount1Out += row.Amount1Out
+	}
+
+	list := make([]*types.UniswapPositionEntry, 0, len(pairs))
+	for _, p := range pairs {
+		list = append(list, &types.UniswapPositionEntry{
+			Pair:       common.HexToAddress(p),
+			Deposited0: returnDecimals(sums[p].Amount0In),
+			Deposited1: returnDecimals(sums[p].Amount1In),
+			Withdrawn0: returnDecimals(sums[p].Amount0Out),
+			Withdrawn1: returnDecimals(sums[p].Amount1Out),
+		})
+	}
+	return list, nil
+}
+
+// AddUniswapPair registers a uniswap pair; it returns FALSE if the pair is already known.
+func (mb *MemDbBridge) AddUniswapPair(up *types.UniswapPairCreated) (bool, error) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if mb.pairs.has(up.Pair.String()) {
+		return false, nil
+	}
+	return true, mb.pairs.put(up.Pair.String(), uint64(up.Block), up)
+}
+
+// UniswapRegisteredPairs provides the registered uniswap pairs sorted by the block of creation.
+func (mb *MemDbBridge) UniswapRegisteredPairs() ([]common.Address, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs := ascending(mb.pairs.all())
+	list := make([]common.Address, 0, len(docs))
+	for _, d := range docs {
+		list = append(list, common.HexToAddress(d.id))
+	}
+	return list, nil
+}
@@ -0,0 +1,35 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"sort"
+)
+
+// StoreValidatorApr stores the realized APR of validators in sealed epochs.
+func (mb *MemDbBridge) StoreValidatorApr(list []*types.ValidatorApr) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	for _, va := range list {
+		mb.validatorApr[va.Pk()] = *va
+	}
+	return nil
+}
+
+// ValidatorAprHistory loads the realized APR of the given validator
+// in the given range of epochs sorted from the oldest.
+func (mb *MemDbBridge) ValidatorAprHistory(valID uint64, from uint64, to uint64) ([]*types.ValidatorApr, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	list := make([]*types.ValidatorApr, 0)
+	for _, va := range mb.validatorApr {
+		if va.ValidatorId == valID && va.Epoch >= from && va.Epoch <= to {
+			row := va
+			list = append(list, &row)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Epoch < list[j].Epoch })
+	return list, nil
+}
@@ -0,0 +1,188 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AddWatchedAddress stores the address on the watch list of its owner.
+// Adding an address already watched updates its label.
+func (mb *MemDbBridge) AddWatchedAddress(wa *types.WatchedAddress) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.watched.put(wa.Pk(), uint64(wa.Added.UnixNano()), wa)
+}
+
+// RemoveWatchedAddress removes the address from the watch list of the given owner.
+// It returns false if the address was not on the list.
+func (mb *MemDbBridge) RemoveWatchedAddress(owner string, addr *common.Address) (bool, error) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	wa := types.WatchedAddress{Owner: owner, Address: *addr}
+	return mb.watched.remove(wa.Pk()), nil
+}
+
+// WatchList loads the watch list of the given owner, oldest addresses first.
+func (mb *MemDbBridge) WatchList(owner string) ([]*types.WatchedAddress, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.watched.find(&bson.D{{types.FiWatchedAddressOwner, owner}})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.WatchedAddress, 0, len(docs))
+	for _, d := range ascending(docs) {
+		var row types.WatchedAddress
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// WatchListSize calculates the number of addresses on the watch list of the given owner.
+func (mb *MemDbBridge) WatchListSize(owner string) (int64, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	docs, err := mb.watched.find(&bson.D{{types.FiWatchedAddressOwner, owner}})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(docs)), nil
+}
+
+// WatchedAddresses loads all the addresses watched by any of the clients.
+func (mb *MemDbBridge) WatchedAddresses() ([]common.Address, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	list := make([]common.Address, 0)
+	for _, d := range mb.watched.docs {
+		adr, _ := d.doc[types.FiWatchedAddress].(string)
+		if !seen[adr] {
+			seen[adr] = true
+			list = append(list, common.HexToAddress(adr))
+		}
+	}
+	return list, nil
+}
+
+// WatchDigestState provides the end of the last watch digest period processed.
+// Zero time is returned if no digest has been made yet.
+func (mb *MemDbBridge) WatchDigestState() (time.Time, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return mb.digestState, nil
+}
+
+// SetWatchDigestState stores the end of the last watch digest period processed.
+func (mb *MemDbBridge) SetWatchDigestState(to time.Time) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	mb.digestState = to.UTC().Truncate(time.Second)
+	return nil
+}
+
+// WatchDigestCompute aggregates the activity of the given address in the given period.
+func (mb *MemDbBridge) WatchDigestCompute(addr *common.Address, from time.Time, to time.Time) (*types.WatchDigest, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	wd := types.WatchDigest{Address: *addr, From: from, To: to}
+	adr := addr.String()
+
+	// native transactions sent and received
+	for _, d := range mb.trx.docs {
+		if ts := trxStamp(d); ts.Before(from) || !ts.Before(to) {
+			continue
+		}
+		if d.doc["from"] == adr {
+			wd.TrxSent++
+			wd.ValueSent += intValue(d.doc["amo"])
+		}
+		if d.doc["to"] == adr {
+			wd.TrxReceived++
+			wd.ValueReceived += intValue(d.doc["amo"])
+		}
+	}
+
+	// token transfers sent and received
+	for _, d := range mb.erc20.docs {
+		if ts := stampValue(d.doc[types.FiErc20TransactionStamp]); ts.Before(from) || !ts.Before(to) {
+			continue
+		}
+		if d.doc[types.FiErc20TransactionSender] == adr {
+			wd.Erc20Sent++
+		}
+		if d.doc[types.FiErc20TransactionRecipient] == adr {
+			wd.Erc20Received++
+		}
+	}
+	return &wd, nil
+}
+
+// AddWatchDigest stores the activity digest of a watched address.
+func (mb *MemDbBridge) AddWatchDigest(wd *types.WatchDigest) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.digests.put(wd.Pk(), uint64(wd.From.Unix()), wd)
+}
+
+// WatchDigests sums the stored activity digests of the given addresses since the given time.
+// Addresses without any activity in the period are not included.
+func (mb *MemDbBridge) WatchDigests(addrs []common.Address, since time.Time) ([]*types.WatchDigest, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	want := make(map[common.Address]bool, len(addrs))
+	for _, adr := range addrs {
+		want[adr] = true
+	}
+
+	sums := make(map[common.Address]*types.WatchDigest)
+	for _, d := range mb.digests.docs {
+		var row types.WatchDigest
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		if !want[row.Address] || row.From.Before(since) {
+			continue
+		}
+
+		sum, ok := sums[row.Address]
+		if !ok {
+			sum = &types.WatchDigest{Address: row.Address, From: row.From, To: row.To}
+			sums[row.Address] = sum
+		}
+		if row.From.Before(sum.From) {
+			sum.From = row.From
+		}
+		if row.To.After(sum.To) {
+			sum.To = row.To
+		}
+		sum.TrxSent += row.TrxSent
+		sum.TrxReceived += row.TrxReceived
+		sum.ValueSent += row.ValueSent
+		sum.ValueReceived += row.ValueReceived
+		sum.Erc20Sent += row.Erc20Sent
+		sum.Erc20Received += row.Erc20Received
+	}
+
+	list := make([]*types.WatchDigest, 0, len(sums))
+	for _, sum := range sums {
+		list = append(list, sum)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Address.String() < list[j].Address.String() })
+	return list, nil
+}
@@ -0,0 +1,179 @@
+package memdb
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// withdrawalDoc finds the stored document of the withdraw request.
+func (mb *MemDbBridge) withdrawalDoc(addr *common.Address, valID *hexutil.Big, reqID string) (*document, error) {
+	docs, err := mb.withdrawals.find(&bson.D{
+		{types.FiWithdrawalAddress, addr.String()},
+		{types.FiWithdrawalToValidator, valID.String()},
+		{types.FiWithdrawalRequestID, reqID},
+	})
+	if err != nil || len(docs) == 0 {
+		return nil, err
+	}
+	return docs[0], nil
+}
+
+// Withdrawal returns details of a withdraw request specified by the request ID.
+func (mb *MemDbBridge) Withdrawal(addr *common.Address, valID *hexutil.Big, reqID *hexutil.Big) (*types.WithdrawRequest, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	d, err := mb.withdrawalDoc(addr, valID, reqID.String())
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	var wr types.WithdrawRequest
+	if err := d.decode(&wr); err != nil {
+		return nil, err
+	}
+	return &wr, nil
+}
+
+// AddWithdrawal stores a withdraw request if it doesn't exist.
+// A finished request with the same ID is shifted aside, since the request IDs can be re-used;
+// an open request with the same ID is updated.
+func (mb *MemDbBridge) AddWithdrawal(wr *types.WithdrawRequest) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	d, err := mb.withdrawalDoc(&wr.Address, wr.StakerID, wr.WithdrawRequestID.String())
+	if err != nil {
+		return err
+	}
+
+	if d != nil {
+		if d.doc[types.FiWithdrawalFinTime] == nil {
+			return mb.updateWithdrawal(d, wr)
+		}
+
+		reqID := (*hexutil.Big)(new(big.Int).SetBytes(wr.RequestTrx.Bytes()[:16])).String()
+		if _, err := mb.withdrawals.set(d.id, bson.M{types.FiWithdrawalRequestID: reqID}); err != nil {
+			return err
+		}
+	}
+	return mb.withdrawals.put(wr.RequestTrx.String(), wr.OrdinalIndex(), wr)
+}
+
+// UpdateWithdrawal updates the given withdraw request.
+func (mb *MemDbBridge) UpdateWithdrawal(wr *types.WithdrawRequest) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	d, err := mb.withdrawalDoc(&wr.Address, wr.StakerID, wr.WithdrawRequestID.String())
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return fmt.Errorf("can not update, the withdraw request not found in database")
+	}
+	return mb.updateWithdrawal(d, wr)
+}
+
+// updateWithdrawal updates the stored document of the withdraw request.
+func (mb *MemDbBridge) updateWithdrawal(d *document, wr *types.WithdrawRequest) error {
+	var trx *string
+	if wr.WithdrawTrx != nil {
+		t := wr.WithdrawTrx.String()
+		trx = &t
+	}
+
+	var pen *string
+	if wr.Penalty != nil {
+		p := wr.Penalty.String()
+		pen = &p
+	}
+
+	_, err := mb.withdrawals.set(d.id, bson.M{
+		types.FiWithdrawalType:       wr.Type,
+		types.FiWithdrawalOrdinal:    wr.OrdinalIndex(),
+		types.FiWithdrawalCreated:    uint64(wr.CreatedTime),
+		types.FiWithdrawalStamp:      time.Unix(int64(wr.CreatedTime), 0),
+		types.FiWithdrawalValue:      new(big.Int).Div(wr.Amount.ToInt(), types.WithdrawDecimalsCorrection).Uint64(),
+		types.FiWithdrawalSlash:      pen,
+		types.FiWithdrawalRequestTrx: wr.RequestTrx.String(),
+		types.FiWithdrawalFinTrx:     trx,
+		types.FiWithdrawalFinTime:    (*uint64)(wr.WithdrawTime),
+	})
+	d.ord = wr.OrdinalIndex()
+	return err
+}
+
+// Withdrawals pulls list of withdraw requests starting at the specified cursor.
+func (mb *MemDbBridge) Withdrawals(cursor *string, count int32, filter *bson.D) (*types.WithdrawRequestList, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero withdrawals requested")
+	}
+
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	pg, err := mb.list(mb.withdrawals, cursor, count, filter, func(c string) (uint64, error) {
+		return listOrdinal(mb.withdrawals, c, 0)
+	})
+	if err != nil {
+		mb.log.Errorf("can not load withdraw requests list; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.WithdrawRequestList{
+		Collection: make([]*types.WithdrawRequest, 0, len(pg.Docs)),
+		Total:      pg.Total,
+		First:      pg.First,
+		IsStart:    pg.IsStart,
+		IsEnd:      pg.IsEnd,
+	}
+	if filter != nil {
+		list.Filter = *filter
+	}
+
+	for _, d := range pg.Docs {
+		var row types.WithdrawRequest
+		if err := d.decode(&row); err != nil {
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+
+	// reverse on negative so new-er withdrawals will be on top
+	if count < 0 {
+		list.Reverse()
+	}
+	return &list, nil
+}
+
+// WithdrawalsSumValue calculates sum of values for all the withdrawals by a filter.
+func (mb *MemDbBridge) WithdrawalsSumValue(filter *bson.D) (*big.Int, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	return sumFieldValue(mb.withdrawals, types.FiWithdrawalValue, filter, types.WithdrawDecimalsCorrection)
+}
+
+// sumFieldValue calculates sum of values of the given field of documents matching the filter.
+func sumFieldValue(col *collection, field string, filter *bson.D, decCorrection *big.Int) (*big.Int, error) {
+	docs, err := col.find(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var total uint64
+	for _, d := range docs {
+		total += uint64(intValue(d.doc[field]))
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(total), decCorrection), nil
+}
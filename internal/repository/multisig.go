@@ -0,0 +1,91 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// multisigTxServiceTimeout represents the timeout of the Safe transaction service requests.
+const multisigTxServiceTimeout = 5 * time.Second
+
+// Multisig loads the configuration of a Safe-style multisig wallet at the given address.
+func (p *proxy) Multisig(addr *common.Address) (*types.Multisig, error) {
+	return p.rpc.Multisig(addr)
+}
+
+// MultisigPendingTxCount provides the number of transactions proposed to the given
+// multisig wallet, but not executed yet. The value comes from the configured Safe
+// transaction service since the proposals are not stored on chain; nil is returned
+// if the service is not configured.
+func (p *proxy) MultisigPendingTxCount(addr *common.Address, nonce uint64) (*uint64, error) {
+	// is there any service at all?
+	if p.cfg.Multisig.TxServiceUrl == "" {
+		return nil, nil
+	}
+
+	// prep the request
+	url := fmt.Sprintf("%s/api/v1/safes/%s/multisig-transactions/?executed=false&nonce__gte=%d&limit=1",
+		strings.TrimRight(p.cfg.Multisig.TxServiceUrl, "/"), addr.String(), nonce)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can not create HTTP request for multisig transactions; %s", err.Error())
+	}
+
+	// be honest, set agent
+	req.Header.Set("User-Agent", "Fantom GraphQL API Server")
+
+	// do the request
+	client := &http.Client{Timeout: multisigTxServiceTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("can not load multisig transactions; %s", err.Error())
+	}
+
+	// don't forget to close
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			p.log.Errorf("error closing multisig transactions request; %s", err.Error())
+		}
+	}()
+
+	// unknown wallets are not an error
+	if resp.StatusCode == http.StatusNotFound {
+		var zero uint64
+		return &zero, nil
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("multisig transactions not available; %w", ErrRateLimited)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("multisig transactions not available; status %d", resp.StatusCode)
+	}
+
+	// read the data
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("can not read multisig transactions; %s", err.Error())
+	}
+
+	// decode the count
+	var list struct {
+		Count uint64 `json:"count"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("can not decode multisig transactions; %s", err.Error())
+	}
+	return &list.Count, nil
+}
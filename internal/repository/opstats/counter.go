@@ -0,0 +1,63 @@
+// Package opstats implements counting of backend calls made on behalf of a single API operation.
+package opstats
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// counterKey is the context key of the backend calls counter.
+type counterKey struct{}
+
+// Counter counts the database, node and cache calls of a single API operation.
+// The counter is safe for concurrent use; a nil counter ignores the calls.
+type Counter struct {
+	db    int64
+	rpc   int64
+	cache int64
+}
+
+// WithCounter attaches a new backend calls counter to the context.
+func WithCounter(ctx context.Context) (context.Context, *Counter) {
+	c := new(Counter)
+	return context.WithValue(ctx, counterKey{}, c), c
+}
+
+// FromContext provides the backend calls counter of the context, nil if there is none.
+func FromContext(ctx context.Context) *Counter {
+	if ctx == nil {
+		return nil
+	}
+	c, _ := ctx.Value(counterKey{}).(*Counter)
+	return c
+}
+
+// Db counts a database command.
+func (c *Counter) Db() {
+	if c != nil {
+		atomic.AddInt64(&c.db, 1)
+	}
+}
+
+// Rpc counts a node call.
+func (c *Counter) Rpc() {
+	if c != nil {
+		atomic.AddInt64(&c.rpc, 1)
+	}
+}
+
+// Cache counts a cache lookup, or store.
+func (c *Counter) Cache() {
+	if c != nil {
+		atomic.AddInt64(&c.cache, 1)
+	}
+}
+
+// Report provides the number of the database, node and cache calls counted so far.
+func (c *Counter) Report() map[string]int64 {
+	return map[string]int64{
+		"db":    atomic.LoadInt64(&c.db),
+		"rpc":   atomic.LoadInt64(&c.rpc),
+		"cache": atomic.LoadInt64(&c.cache),
+	}
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"strings"
+)
+
+// OracleFeeds loads the state of the on-chain price feeds of all the active fMint DeFi tokens.
+func (p *proxy) OracleFeeds() ([]*types.OracleFeed, error) {
+	tokens, err := p.rpc.DefiTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.OracleFeed, 0, len(tokens))
+	for i := range tokens {
+		feed, err := p.oracleFeed(&tokens[i])
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, feed)
+	}
+	return list, nil
+}
+
+// OraclePrice loads the state of the on-chain price feed of the fMint DeFi token
+// with the given symbol. It returns nil if there is no such token.
+func (p *proxy) OraclePrice(sym string) (*types.OracleFeed, error) {
+	tokens, err := p.rpc.DefiTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tokens {
+		if strings.EqualFold(tokens[i].Symbol, sym) {
+			return p.oracleFeed(&tokens[i])
+		}
+	}
+	return nil, nil
+}
+
+// oracleFeed loads the on-chain price feed of the token and adds the off-chain reference price.
+func (p *proxy) oracleFeed(tk *types.DefiToken) (*types.OracleFeed, error) {
+	feed, err := p.rpc.FMintOracleFeed(tk)
+	if err != nil {
+		p.log.Errorf("price feed of %s not available; %s", tk.Symbol, err.Error())
+		return nil, err
+	}
+	feed.Reference = p.oracleReferencePrice(tk)
+	return feed, nil
+}
+
+// oracleReferencePrice provides the USD price of the token from an off-chain source.
+// The wrapped FTM uses the price API, other tokens are priced by the Uniswap routes.
+func (p *proxy) oracleReferencePrice(tk *types.DefiToken) *float64 {
+	if tk.Address == p.cfg.DeFi.Uniswap.WrappedNative && p.isValidPriceSymbol(uniswapPriceStable) {
+		pri, err := p.Price(uniswapPriceStable)
+		if err == nil && pri.Price > 0 {
+			return &pri.Price
+		}
+	}
+
+	tp, err := p.Erc20TokenPrice(&tk.Address, uniswapPriceStable)
+	if err != nil || tp == nil || tp.Price <= 0 {
+		return nil
+	}
+	return &tp.Price
+}
@@ -9,9 +9,12 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"crypto/rand"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/logger"
 	"fantom-api-graphql/internal/types"
+	"fmt"
+	"os"
 	"sync"
 	"time"
 )
@@ -22,6 +25,10 @@ const swapDispatchQueueCapacity = 20000
 // orScannersCount is the number of scanner services running concurrently.
 const orScannersCount = 2
 
+// orDefaultServicePeriod is the scheduling period of a periodic service
+// missing in the scheduler configuration.
+const orDefaultServicePeriod = time.Minute
+
 // Orchestrator implements repository synchronization and monitoring control
 type orchestrator struct {
 	service
@@ -60,6 +67,21 @@ type orchestrator struct {
 	blm *blockMonitor
 	stm *stiMonitor
 	txf *txFlowUpdater
+	esu *epochStatsUpdater
+	dci *delegatorsIndexer
+	sss *stakersSnapshot
+	ssu *stakingStatsUpdater
+	sut *supplyTracker
+	upr *uniswapPriceRouter
+	tmr *tokenMetaRegistry
+	ccl *contractClassifier
+	adl *addressLinker
+	usr *usageRecorder
+	vlp *validationPropagator
+	wdb *watchDigestBuilder
+	prr *priceRecorder
+	alm *alertMonitor
+	fma *fMintAggregator
 }
 
 // NewOrchestrator creates a new instance of repository orchestrator.
@@ -90,18 +112,21 @@ func newOrchestrator(repo Repository, log logger.Logger, cfg *config.Config) *or
 // init initiates the orchestrator work.
 func (or *orchestrator) init(cfg *config.Config) {
 	// make all the dispatchers first so they can receive and process objects
-	or.txd = newTrxDispatcher(or.trxDispatcherQueue, or.repo, or.log, or.wg)
+	or.txd = newTrxDispatcher(or.trxDispatcherQueue, cfg.Repository.TraceCreations, or.repo, or.log, or.wg)
 	or.acd = newAccountDispatcher(or.accountQueue, or.repo, or.log, or.wg)
 	or.uwd = newSwapDispatcher(or.swapDispatcherQueue, or.repo, or.log, or.wg)
 	or.lod = newLogsDispatcher(or.logsQueue, or.repo, or.log, or.wg)
 
 	// create sync blockScanner; it starts scanning immediately
 	or.blkScanDone = make(chan bool, 1)
-	or.bls = newBlockScanner(or.trxDispatcherQueue, or.blkScanDone, or.repo, or.log, or.wg, &cfg.RepoCommand)
+	or.bls = newBlockScanner(or.trxDispatcherQueue, or.blkScanDone, or.repo, or.log, or.wg, &cfg.RepoCommand, cfg.Repository.ScanWorkers, cfg.Repository.TraceCreations)
 
-	// create swap blockScanner, which loads uniswap to local db immediately
+	// create swap blockScanner, which loads uniswap to local db immediately;
+	// light deployments may run without the DeFi contracts
 	or.swapScanDone = make(chan bool, 1)
-	or.uws = newUniswapScanner(or.swapDispatcherQueue, or.swapScanDone, or.repo, or.log, or.wg)
+	if !cfg.Features.IsDisabled(config.FeatureDeFi) {
+		or.uws = newUniswapScanner(or.swapDispatcherQueue, or.swapScanDone, or.repo, or.log, or.wg)
+	}
 
 	// SFC scanner
 	or.sfs = newSFCScanner(or.repo, or.log, or.wg)
@@ -111,7 +136,9 @@ func (or *orchestrator) init(cfg *config.Config) {
 	or.blm = NewBlockMonitor(or.repo.FtmConnection(), or.trxDispatcherQueue, or.reScan, or.repo, or.log, or.wg)
 
 	// create the Uniswap monitor
-	or.uwm = NewUniswapMonitor(or.repo.FtmConnection(), or.swapDispatcherQueue, or.repo, or.log, or.wg)
+	if !cfg.Features.IsDisabled(config.FeatureDeFi) {
+		or.uwm = NewUniswapMonitor(or.repo.FtmConnection(), or.swapDispatcherQueue, or.repo, or.log, or.wg)
+	}
 
 	// create staker information monitor; it starts right away on slow peace
 	if cfg.Repository.MonitorStakers {
@@ -120,6 +147,126 @@ func (or *orchestrator) init(cfg *config.Config) {
 
 	// create trx flow updater
 	or.txf = NewTxFlowUpdater(or.repo, or.log, or.wg)
+
+	// create epoch statistics updater
+	or.esu = newEpochStatsUpdater(or.repo, or.log, or.wg)
+
+	// create validators delegators indexer
+	or.dci = newDelegatorsIndexer(or.repo, or.log, or.wg)
+
+	// create stakers snapshot service
+	or.sss = newStakersSnapshot(or.repo, or.log, or.wg)
+
+	// create staking statistics updater
+	or.ssu = newStakingStatsUpdater(or.repo, or.log, or.wg)
+
+	// create FTM supply tracker
+	or.sut = newSupplyTracker(or.repo, or.log, or.wg)
+
+	// create uniswap price router
+	or.upr = newUniswapPriceRouter(or.repo, or.log, or.wg)
+
+	// create token metadata registry
+	or.tmr = newTokenMetaRegistry(or.repo, or.log, or.wg)
+
+	// create contract classifier
+	or.ccl = newContractClassifier(or.repo, or.log, or.wg)
+	or.adl = newAddressLinker(or.repo, or.log, or.wg)
+	or.usr = newUsageRecorder(or.repo, or.log, or.wg)
+
+	// create contract validation propagator
+	or.vlp = newValidationPropagator(or.repo, or.log, or.wg)
+
+	// create watch list digest builder
+	or.wdb = newWatchDigestBuilder(or.repo, or.log, or.wg)
+
+	// create price history recorder
+	or.prr = newPriceRecorder(or.repo, or.log, or.wg)
+
+	// create alert rules monitor
+	or.alm = newAlertMonitor(or.repo, or.log, or.wg)
+
+	// create fMint stats aggregator
+	if !cfg.Features.IsDisabled(config.FeatureDeFi) {
+		or.fma = newFMintAggregator(or.repo, or.log, or.wg)
+	}
+
+	// apply the configured schedule to periodic services
+	or.schedule(&cfg.Repository.Scheduler)
+}
+
+// schedule sets the scheduling period and jitter of the periodic services.
+func (or *orchestrator) schedule(cfg *config.Scheduler) {
+	holder := instanceId()
+	or.log.Noticef("services lock holder is %s", holder)
+
+	for _, se := range or.periodic() {
+		se.holder = holder
+
+		period, ok := cfg.Periods[se.configKey()]
+		if !ok || period <= 0 {
+			or.log.Errorf("invalid period of %s; using %s", se.name, orDefaultServicePeriod)
+			period = orDefaultServicePeriod
+		}
+
+		se.setSchedule(period, cfg.Jitter)
+		or.log.Debugf("%s scheduled every %s", se.name, period)
+	}
+}
+
+// instanceId provides a unique identification of this API server instance
+// used to hold locks of exclusive jobs shared with other API peers.
+func instanceId() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	rnd := make([]byte, 4)
+	if _, err := rand.Read(rnd); err != nil {
+		return fmt.Sprintf("%s:%d:%d", host, os.Getpid(), time.Now().UnixNano())
+	}
+	return fmt.Sprintf("%s:%d:%x", host, os.Getpid(), rnd)
+}
+
+// periodic provides the list of periodic services managed by the orchestrator.
+func (or *orchestrator) periodic() []*service {
+	list := []*service{
+		&or.txf.service,
+		&or.esu.service,
+		&or.dci.service,
+		&or.sss.service,
+		&or.ssu.service,
+		&or.sut.service,
+		&or.upr.service,
+		&or.tmr.service,
+		&or.ccl.service,
+		&or.adl.service,
+		&or.usr.service,
+		&or.vlp.service,
+		&or.wdb.service,
+		&or.prr.service,
+		&or.alm.service,
+	}
+
+	// stakers info monitor and DeFi services may not be run at all
+	if or.stm != nil {
+		list = append(list, &or.stm.service)
+	}
+	if or.fma != nil {
+		list = append(list, &or.fma.service)
+	}
+	return list
+}
+
+// periodicService finds a periodic service by its name, or its configuration key.
+func (or *orchestrator) periodicService(name string) (*service, error) {
+	for _, se := range or.periodic() {
+		if se.name == name || se.configKey() == name {
+			return se, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown periodic service %s", name)
 }
 
 // run starts the orchestrator work
@@ -132,18 +279,37 @@ func (or *orchestrator) run() {
 
 	// now the scanners so we sync the off-chain database
 	or.bls.run()
-	or.uws.run()
 	or.sfs.run()
 
 	// finally monitors
-	or.uwm.run()
 	or.txf.run()
+	or.esu.run()
+	or.dci.run()
+	or.sss.run()
+	or.ssu.run()
+	or.sut.run()
+	or.upr.run()
+	or.tmr.run()
+	or.ccl.run()
+	or.adl.run()
+	or.usr.run()
+	or.vlp.run()
+	or.wdb.run()
+	or.prr.run()
+	or.alm.run()
 
 	// stakers info monitor may not be run at all
 	if or.stm != nil {
 		or.stm.run()
 	}
 
+	// DeFi services may not be run at all
+	if or.uws != nil {
+		or.uws.run()
+		or.uwm.run()
+		or.fma.run()
+	}
+
 	// start orchestrating
 	or.wg.Add(1)
 	go or.orchestrate()
@@ -180,12 +346,24 @@ func (or *orchestrator) closeServices() {
 
 	// signal monitors to close
 	or.blm.close()
-	or.uwm.close()
 	or.txf.close()
+	or.esu.close()
+	or.dci.close()
+	or.sss.close()
+	or.ssu.close()
+	or.sut.close()
+	or.upr.close()
+	or.tmr.close()
+	or.ccl.close()
+	or.adl.close()
+	or.usr.close()
+	or.vlp.close()
+	or.wdb.close()
+	or.prr.close()
+	or.alm.close()
 
 	// signal scanners to close
 	or.bls.close()
-	or.uws.close()
 	or.sfs.close()
 
 	// signal closing to sti monitor as well, if it exists
@@ -193,6 +371,13 @@ func (or *orchestrator) closeServices() {
 		or.stm.close()
 	}
 
+	// signal closing to DeFi services, if they exist
+	if or.uws != nil {
+		or.uws.close()
+		or.uwm.close()
+		or.fma.close()
+	}
+
 	// signal dispatchers to close
 	or.uwd.close()
 	or.txd.close()
@@ -200,6 +385,45 @@ func (or *orchestrator) closeServices() {
 	or.lod.close()
 }
 
+// states provides the current state of all the orchestrated services.
+func (or *orchestrator) states() []types.ServiceState {
+	// dispatchers report their queues
+	list := []types.ServiceState{
+		or.service.state(),
+		queueState(&or.txd.service, len(or.trxDispatcherQueue), cap(or.trxDispatcherQueue)),
+		queueState(&or.acd.service, len(or.accountQueue), cap(or.accountQueue)),
+		queueState(&or.uwd.service, len(or.swapDispatcherQueue), cap(or.swapDispatcherQueue)),
+		queueState(&or.lod.service, len(or.logsQueue), cap(or.logsQueue)),
+		or.bls.state(),
+		or.sfs.state(),
+		or.blm.state(),
+		or.txf.state(),
+		or.esu.state(),
+		or.dci.state(),
+		or.sss.state(),
+		or.ssu.state(),
+		or.sut.state(),
+		or.upr.state(),
+		or.tmr.state(),
+		or.ccl.state(),
+		or.adl.state(),
+		or.usr.state(),
+		or.vlp.state(),
+		or.wdb.state(),
+		or.prr.state(),
+		or.alm.state(),
+	}
+
+	// stakers info monitor and DeFi services may not be run at all
+	if or.stm != nil {
+		list = append(list, or.stm.state())
+	}
+	if or.uws != nil {
+		list = append(list, or.uws.state(), or.uwm.state(), or.fma.state())
+	}
+	return list
+}
+
 // setBlockChannel registers a channel for notifying new block events.
 func (or *orchestrator) setBlockChannel(ch chan *types.Block) {
 	or.blm.onBlock = ch
@@ -210,6 +434,16 @@ func (or *orchestrator) setTrxChannel(ch chan *types.Transaction) {
 	or.blm.onTransaction = ch
 }
 
+// setEpochChannel registers a channel for notifying sealed epoch events.
+func (or *orchestrator) setEpochChannel(ch chan *types.Epoch) {
+	or.sfs.onEpoch = ch
+}
+
+// setUniswapPairChannel registers a channel for notifying new Uniswap pair events.
+func (or *orchestrator) setUniswapPairChannel(ch chan *types.UniswapPairCreated) {
+	or.lod.onUniswapPair = ch
+}
+
 // orchestrate starts the service orchestration.
 func (or *orchestrator) orchestrate() {
 	// log action
@@ -279,7 +513,9 @@ func (or *orchestrator) scheduleRescan() {
 			return
 		case <-time.After(dur):
 			or.bls.run()
-			or.uws.run()
+			if or.uws != nil {
+				or.uws.run()
+			}
 			return
 		}
 	}
@@ -0,0 +1,123 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// priceHistoryMaxAge represents the max age of the latest price sample
+// used for the price at a time after the sample.
+const priceHistoryMaxAge = 2 * time.Hour
+
+// priceRecorder represents a service recording the price of FTM
+// in the supported target symbols into the price history.
+type priceRecorder struct {
+	service
+}
+
+// newPriceRecorder creates a new price recorder service.
+func newPriceRecorder(repo Repository, log logger.Logger, wg *sync.WaitGroup) *priceRecorder {
+	return &priceRecorder{
+		service: newPeriodicService("price recorder", repo, log, wg),
+	}
+}
+
+// run starts the price recorder service
+func (pr *priceRecorder) run() {
+	pr.wg.Add(1)
+	go pr.schedule()
+}
+
+// schedule schedules regular price recording.
+func (pr *priceRecorder) schedule() {
+	// inform about the service
+	pr.log.Notice("price recorder is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		pr.log.Notice("price recorder is closed")
+		pr.wg.Done()
+	}()
+
+	// run on schedule
+	pr.loop(func() {
+		pr.exclusive(func() {
+			if err := pr.repo.RecordPrices(); err != nil {
+				pr.log.Errorf("can not record prices; %s", err.Error())
+			}
+		})
+	})
+}
+
+// RecordPrices stores the current price of each supported target symbol
+// in the price history.
+func (p *proxy) RecordPrices() error {
+	for _, sym := range p.cfg.DeFi.PriceSymbols {
+		pri, err := p.Price(sym)
+		if err != nil {
+			return err
+		}
+
+		// use the time of the price, if known
+		ts := time.Now().UTC()
+		if pri.LastUpdate > 0 {
+			ts = time.Unix(int64(pri.LastUpdate), 0).UTC()
+		}
+
+		if err := p.db.AddPriceSample(&types.PriceSample{Symbol: sym, Time: ts, Price: pri.Price}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PriceAt provides the price of the given target symbol at the given time.
+// The price is interpolated linearly between the closest recorded samples.
+// It returns nil if the price history does not reach that far.
+func (p *proxy) PriceAt(sym string, ts time.Time) (*types.PriceSample, error) {
+	if !p.isValidPriceSymbol(sym) {
+		return nil, fmt.Errorf("unknown price symbol requested")
+	}
+
+	// find the closest sample before the time
+	before, err := p.db.PriceSampleBefore(sym, ts)
+	if err != nil || before == nil {
+		return nil, err
+	}
+	if before.Time.Equal(ts) {
+		return before, nil
+	}
+
+	// find the closest sample after the time
+	after, err := p.db.PriceSampleAfter(sym, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	// no later sample; the latest one must be recent enough
+	if after == nil {
+		if ts.Sub(before.Time) > priceHistoryMaxAge {
+			return nil, nil
+		}
+		return &types.PriceSample{Symbol: before.Symbol, Time: ts, Price: before.Price}, nil
+	}
+
+	// interpolate between the samples
+	ratio := float64(ts.Sub(before.Time)) / float64(after.Time.Sub(before.Time))
+	return &types.PriceSample{
+		Symbol: before.Symbol,
+		Time:   ts,
+		Price:  before.Price + (after.Price-before.Price)*ratio,
+	}, nil
+}
@@ -9,13 +9,16 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/logger"
 	"fantom-api-graphql/internal/repository/cache"
 	"fantom-api-graphql/internal/repository/db"
+	"fantom-api-graphql/internal/repository/memdb"
 	"fantom-api-graphql/internal/repository/rpc"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
+	"fmt"
 	retypes "github.com/ethereum/go-ethereum/core/types"
 	"golang.org/x/sync/singleflight"
 	"math/big"
@@ -35,6 +38,18 @@ type Repository interface {
 	// FtmConnection returns open connection to Opera/Lachesis full node.
 	FtmConnection() *ftm.Client
 
+	// WithContext provides the repository with the database operations and the node calls
+	// bound to the given context, so they are cancelled together with the API request.
+	WithContext(ctx context.Context) Repository
+
+	// capabilities of the repository, usable separately by consumers
+	// which need only a part of it
+	BlockReader
+	TxReader
+	StakingReader
+	TokenReader
+	Cache
+
 	// Account returns account at Opera blockchain for an address, nil if not found.
 	Account(*common.Address) (*types.Account, error)
 
@@ -44,6 +59,14 @@ type Repository interface {
 	// AccountNonce returns the current number of sent transactions of an account at Opera blockchain.
 	AccountNonce(*common.Address) (*hexutil.Uint64, error)
 
+	// AccountPendingNonce returns the number of sent transactions of an account
+	// including transactions waiting in the node transaction pool.
+	AccountPendingNonce(*common.Address) (*hexutil.Uint64, error)
+
+	// AccountPendingTransactions returns the list of transactions of an account
+	// waiting in the node transaction pool.
+	AccountPendingTransactions(*common.Address) ([]*types.Transaction, error)
+
 	// AccountTransactions returns list of transaction hashes for account at Opera blockchain.
 	//
 	// String cursor represents cursor based on which the list is loaded. If null,
@@ -75,31 +98,121 @@ type Repository interface {
 	// QueueAccount puts the given account into the account processing queue.
 	QueueAccount(*types.Block, *types.Transaction, *common.Address, *common.Hash, *sync.WaitGroup)
 
-	// BlockHeight returns the current height of the Opera blockchain in blocks.
-	BlockHeight() (*hexutil.Big, error)
+	// ResolveName resolves the address assigned to the given FNS domain name.
+	ResolveName(string) (*common.Address, error)
+
+	// DomainName resolves the primary FNS domain name of the given address.
+	DomainName(*common.Address) (*string, error)
+
+	// AddressLabel provides the public label assigned to the given address, if any.
+	AddressLabel(*common.Address) (*types.AddressLabel, error)
 
-	// LastKnownBlock returns number of the last block known to the repository.
-	LastKnownBlock() (uint64, error)
+	// StoreAddressLabel stores the given address label, replacing any previous one.
+	StoreAddressLabel(*types.AddressLabel) error
+
+	// RemoveAddressLabel removes the public label of the given address.
+	RemoveAddressLabel(*common.Address) error
+
+	// RiskLevel provides the moderation risk level of the given contract, or token.
+	RiskLevel(*common.Address) (string, error)
+
+	// StoreRiskFlag stores the given risk flag; flags with no risk level are removed.
+	StoreRiskFlag(*types.RiskFlag) error
 
 	// UpdateLastKnownBlock update record about last known block.
 	UpdateLastKnownBlock(blockNo *hexutil.Uint64) error
 
-	// BlockByNumber returns a block at Opera blockchain represented by a number.
-	// Top block is returned if the number is not provided.
-	// If the block is not found, ErrBlockNotFound error is returned.
-	BlockByNumber(*hexutil.Uint64) (*types.Block, error)
+	// StoreTransactionBloom merges the address bloom of the transaction into the bloom of its block.
+	StoreTransactionBloom(*types.Transaction) error
+
+	// AccountsToClassify loads a batch of contract accounts which have not been classified yet.
+	AccountsToClassify(limit int64) ([]common.Address, error)
+
+	// ClassifyAccount detects the interfaces of the contract at the given address
+	// and stores the structured classification with the account.
+	ClassifyAccount(addr *common.Address) error
+
+	// AccountsToLink loads a batch of accounts which have not been linked
+	// to their funder and deployer yet.
+	AccountsToLink(limit int64) ([]common.Address, error)
+
+	// LinkAccount finds the funder of the account at the given address and the deployer,
+	// if the account is a contract, and stores them with the account.
+	LinkAccount(addr *common.Address) error
+
+	// RelatedAddresses provides addresses likely controlled by the same entity as the account
+	// at the given address, ordered by the confidence of the relation.
+	RelatedAddresses(addr *common.Address, limit int) ([]*types.RelatedAddress, error)
+
+	// ScreenAddresses checks the given addresses by the compliance screening.
+	// It returns ComplianceError if any of the addresses is denied.
+	ScreenAddresses(list ...common.Address) error
+
+	// ScreenQuery checks the address of an account data query by the compliance screening,
+	// if the screening of queries is enabled.
+	ScreenQuery(addr *common.Address) error
+
+	// RecordUsage adds the given API usage to the usage of the client origin.
+	RecordUsage(origin string, requests int, bytes int, cost time.Duration)
+
+	// FlushUsage stores the API usage accumulated since the last flush.
+	FlushUsage() error
+
+	// UsageReport provides the API usage of all the client origins in the given time range,
+	// the heaviest users by the compute cost go first.
+	UsageReport(from time.Time, to time.Time) ([]*types.UsageRecord, error)
+
+	// Multisig loads the configuration of a Safe-style multisig wallet at the given address.
+	Multisig(addr *common.Address) (*types.Multisig, error)
+
+	// MultisigPendingTxCount provides the number of transactions proposed to the given
+	// multisig wallet, but not executed yet; nil if not available.
+	MultisigPendingTxCount(addr *common.Address, nonce uint64) (*uint64, error)
+
+	// TraceTransaction collects contracts deployed by other contracts and accounts
+	// involved in internal value transfers of the given transaction using the node call trace.
+	TraceTransaction(*types.Transaction) (*types.TransactionTrace, error)
 
-	// BlockByHash returns a block at Opera blockchain represented by a hash.
-	// Top block is returned if the hash is not provided.
-	// If the block is not found, ErrBlockNotFound error is returned.
-	BlockByHash(*common.Hash) (*types.Block, error)
+	// StoreContractCreation stores the given contract creation record.
+	StoreContractCreation(*types.ContractCreation) error
 
-	// Blocks pulls list of blocks starting on the specified block number
-	// and going up, or down based on count number.
-	Blocks(*uint64, int32) (*types.BlockList, error)
+	// ContractCreation provides the creation record of the given contract,
+	// nil if the contract was not deployed by another contract.
+	ContractCreation(*common.Address) (*types.ContractCreation, error)
 
-	// CacheBlock puts a block to the internal block ring cache.
-	CacheBlock(blk *types.Block)
+	// ContractCreationsBy provides the most recent contracts deployed by the given creator contract.
+	ContractCreationsBy(*common.Address, int64) ([]*types.ContractCreation, error)
+
+	// DatabaseHealth checks the health of the off-chain database.
+	DatabaseHealth() types.BackendHealth
+
+	// NodeHealth checks the health of the block chain node.
+	NodeHealth() types.NodeHealth
+
+	// NodeLatency provides the 95th percentile of the latency of recent block chain node calls.
+	NodeLatency() time.Duration
+
+	// DatabaseLatency provides the 95th percentile of the latency of recent database commands.
+	DatabaseLatency() time.Duration
+
+	// DatabasePoolStats provides the statistics of the database connection pool.
+	DatabasePoolStats() types.DbPoolStats
+
+	// StorageInfo describes the off-chain storage driver and the features it does not provide.
+	StorageInfo() types.StorageInfo
+
+	// ChainInfo provides the identification and capabilities of the chain and the connected node.
+	ChainInfo() (*types.ChainInfo, error)
+
+	// IndexerLag provides the number of blocks the off-chain database is behind the chain head.
+	IndexerLag() (uint64, error)
+
+	// NotifyNewHead publishes the new head block event.
+	NotifyNewHead(blk *types.Block)
+
+	// NotifyAccountTouched publishes the event of an account being touched
+	// by a transaction, so the state of the account changed.
+	NotifyAccountTouched(addr *common.Address)
 
 	// Contract extract a smart contract information by address if available.
 	Contract(*common.Address) (*types.Contract, error)
@@ -112,41 +225,36 @@ type Repository interface {
 	// is updated the the repository.
 	ValidateContract(*types.Contract) error
 
-	// StoreContract updates the contract in repository.
-	StoreContract(*types.Contract) error
+	// UpdateContractCodeHashes calculates the runtime byte code hash of a batch of contracts
+	// which don't have it yet. It returns the number of contracts updated.
+	UpdateContractCodeHashes(limit int64) (int, error)
 
-	// SfcVersion returns current version of the SFC contract.
-	SfcVersion() (hexutil.Uint64, error)
+	// ContractsWithSameBytecode loads contracts with the runtime byte code identical
+	// to the contract at the given address.
+	ContractsWithSameBytecode(addr *common.Address, limit int64) ([]*types.Contract, error)
 
-	// SfcDecimalUnit returns the decimal unit adjustment used by the SFC contract.
-	SfcDecimalUnit() *big.Int
+	// PropagateContractValidation copies the source code validation of the contract
+	// to the not validated contracts with identical runtime byte code.
+	PropagateContractValidation(addr *common.Address) (int, error)
 
-	// CurrentEpoch returns the id of the current epoch.
-	CurrentEpoch() (hexutil.Uint64, error)
+	// ContractsToPropagate finds directly validated contracts which have not validated
+	// contracts with identical runtime byte code deployed.
+	ContractsToPropagate(limit int64) ([]common.Address, error)
 
-	// LastKnownEpoch returns the id of the last known and scanned epoch.
-	LastKnownEpoch() (uint64, error)
+	// ContractArtifact returns the compilation artifact of a validated contract, if available.
+	ContractArtifact(*common.Address) (*types.ContractArtifact, error)
+
+	// StoreContract updates the contract in repository.
+	StoreContract(*types.Contract) error
 
 	// AddEpoch stores an epoch reference in connected persistent storage.
 	AddEpoch(e *types.Epoch) error
 
-	// Epoch returns the id of the current epoch.
-	Epoch(*hexutil.Uint64) (*types.Epoch, error)
-
-	// CurrentSealedEpoch returns the data of the latest sealed epoch.
-	CurrentSealedEpoch() (*types.Epoch, error)
+	// EpochsWithoutStats provides a batch of stored epochs without aggregated statistics.
+	EpochsWithoutStats() ([]*types.Epoch, error)
 
-	// Epochs pulls list of epochs starting at the specified cursor.
-	Epochs(cursor *string, count int32) (*types.EpochList, error)
-
-	// TotalStaked calculates current total staked amount for all stakers.
-	TotalStaked() (*hexutil.Big, error)
-
-	// RewardsAllowed returns the reward lock status from SFC.
-	RewardsAllowed() (bool, error)
-
-	// LockingAllowed indicates if the stake locking has been enabled in SFC.
-	LockingAllowed() (bool, error)
+	// UpdateEpochStats calculates and stores aggregated statistics of the given epoch.
+	UpdateEpochStats(*types.Epoch) error
 
 	// IsSfcContract returns true if the given address points to the SFC contract.
 	IsSfcContract(*common.Address) bool
@@ -157,63 +265,49 @@ type Repository interface {
 	// StoreTransaction adds a new incoming transaction from blockchain to the repository.
 	StoreTransaction(*types.Block, *types.Transaction) error
 
-	// LoadTransaction returns a transaction at Opera blockchain
-	// by a hash loaded directly from the node.
-	LoadTransaction(hash *common.Hash) (*types.Transaction, error)
-
-	// Transaction returns a transaction at Opera blockchain by a hash, nil if not found.
-	Transaction(*common.Hash) (*types.Transaction, error)
-
-	// Transactions returns list of transaction hashes at Opera blockchain.
-	Transactions(*string, int32) (*types.TransactionList, error)
-
-	// TransactionsCount returns total number of transactions in the block chain.
-	TransactionsCount() (uint64, error)
-
-	// EstimateTransactionsCount returns an approximate amount of transactions on the network.
-	EstimateTransactionsCount() (hexutil.Uint64, error)
-
 	// IncTrxCountEstimate bumps the value of transaction counter estimator.
 	IncTrxCountEstimate(diff uint64)
 
 	// UpdateTrxCountEstimate updates the value of transaction counter estimator.
 	UpdateTrxCountEstimate(val uint64)
 
-	// CacheTransaction puts a transaction to the internal ring cache.
-	CacheTransaction(trx *types.Transaction)
-
 	// SendTransaction sends raw signed and RLP encoded transaction to the block chain.
 	SendTransaction(hexutil.Bytes) (*types.Transaction, error)
 
+	// SendTransactionBatch sends a list of raw signed and RLP encoded transactions
+	// to the block chain in order, with the nonce sequence of each sender pre-checked.
+	SendTransactionBatch([]hexutil.Bytes) []*types.TransactionSubmission
+
 	// QueueTrxLog pushes a transaction log record into the log processing queue.
 	QueueTrxLog(log *retypes.Log, wg *sync.WaitGroup)
 
-	// LastValidatorId returns the last validator id in Opera blockchain.
-	LastValidatorId() (uint64, error)
+	// ValidatorsDelegatorsIndex builds the index of delegators count of all the validators
+	// keyed by the validator id; validators without delegations are included.
+	ValidatorsDelegatorsIndex() (map[string]uint64, error)
 
-	// ValidatorsCount returns the number of stakers in Opera blockchain.
-	ValidatorsCount() (uint64, error)
+	// IndexValidatorsDelegators updates the in-memory index of delegators count of all the validators.
+	IndexValidatorsDelegators(map[string]uint64)
 
-	// IsValidator returns TRUE if the given address is an SFC staker.
-	IsValidator(*common.Address) (bool, error)
+	// ValidatorsSnapshot provides a recent snapshot of all validators with their performance.
+	ValidatorsSnapshot() ([]*types.ValidatorSnapshot, error)
 
-	// ValidatorAddress extract a staker address for the given staker ID.
-	ValidatorAddress(*hexutil.Big) (*common.Address, error)
+	// LoadValidatorsSnapshot loads a fresh snapshot of all validators with their performance.
+	LoadValidatorsSnapshot() ([]*types.ValidatorSnapshot, error)
 
-	// Validator extract a staker information from SFC smart contract.
-	Validator(*hexutil.Big) (*types.Validator, error)
+	// LoadStakingStats calculates fresh aggregated staking statistics of the network.
+	LoadStakingStats() (*types.StakingStats, error)
 
-	// ValidatorByAddress extract a staker information by address.
-	ValidatorByAddress(*common.Address) (*types.Validator, error)
+	// RewardsDailyUpdate executes the daily rewards aggregation update in the database.
+	RewardsDailyUpdate(full bool)
 
-	// ValidatorDowntime pulls information about validator downtime from the RPC interface.
-	ValidatorDowntime(*hexutil.Big) (uint64, uint64, error)
+	// FtmSupply provides the latest tracked state of the native FTM supply.
+	FtmSupply() (*types.FtmSupply, error)
 
-	// SfcConfiguration provides SFC contract configuration.
-	SfcConfiguration() (*types.SfcConfig, error)
+	// FtmSupplyHistory provides the FTM supply history of the given range of epochs.
+	FtmSupplyHistory(from uint64, to uint64) ([]*types.FtmSupply, error)
 
-	// SfcMaxDelegatedRatio extracts a ratio between self delegation and received stake.
-	SfcMaxDelegatedRatio() (*big.Int, error)
+	// UpdateFtmSupplyHistory adds newly sealed epochs into the FTM supply history.
+	UpdateFtmSupplyHistory() error
 
 	// PullStakerInfo extracts an extended staker information from smart contact.
 	PullStakerInfo(*hexutil.Big) (*types.StakerInfo, error)
@@ -221,86 +315,48 @@ type Repository interface {
 	// StoreStakerInfo stores staker information to in-memory cache for future use.
 	StoreStakerInfo(*hexutil.Big, *types.StakerInfo) error
 
-	// RetrieveStakerInfo gets staker information from in-memory if available.
-	RetrieveStakerInfo(*hexutil.Big) *types.StakerInfo
-
-	// IsDelegating returns if the given address is an SFC delegator.
-	IsDelegating(*common.Address) (bool, error)
-
 	// StoreDelegation stores a delegation in the persistent repository.
 	StoreDelegation(*types.Delegation) error
 
 	// UpdateDelegationBalance updates active balance of the given delegation.
 	UpdateDelegationBalance(*common.Address, *hexutil.Big, func(*big.Int) error) error
 
-	// Delegation returns a detail of delegation for the given address and validator ID.
-	Delegation(*common.Address, *hexutil.Big) (*types.Delegation, error)
-
-	// DelegationAmountStaked returns the current amount of staked tokens
-	// for the given delegation.
-	DelegationAmountStaked(*common.Address, *hexutil.Big) (*big.Int, error)
-
-	// DelegationsByAddress returns a list of all delegations of a given delegator address.
-	DelegationsByAddress(*common.Address, *string, int32) (*types.DelegationList, error)
-
-	// DelegationsByAddressAll returns a list of all delegations of the given address un-paged.
-	DelegationsByAddressAll(addr *common.Address) ([]*types.Delegation, error)
-
-	// DelegationsOfValidator extracts a list of delegations for a validator by its ID.
-	DelegationsOfValidator(*hexutil.Big, *string, int32) (*types.DelegationList, error)
-
-	// DelegationLock returns delegation lock information using SFC contract binding.
-	DelegationLock(*common.Address, *hexutil.Big) (*types.DelegationLock, error)
-
-	// DelegationUnlockPenalty returns the amount of penalty applied on given stake unlock.
-	DelegationUnlockPenalty(addr *common.Address, valID *big.Int, amount *big.Int) (hexutil.Big, error)
-
-	// DelegationAmountUnlocked returns delegation lock information using SFC contract binding.
-	DelegationAmountUnlocked(addr *common.Address, valID *big.Int) (hexutil.Big, error)
-
-	// PendingRewards returns a detail of pending rewards for the given delegation.
-	PendingRewards(*common.Address, *hexutil.Big) (*types.PendingRewards, error)
-
-	// DelegationOutstandingSFTM returns the amount of sFTM tokens for the delegation
-	// identified by the delegator address and the staker id.
-	DelegationOutstandingSFTM(*common.Address, *hexutil.Big) (*hexutil.Big, error)
-
-	// DelegationTokenizerUnlocked returns the status of SFC Tokenizer lock
-	// for a delegation identified by the address and staker id.
-	DelegationTokenizerUnlocked(*common.Address, *hexutil.Big) (bool, error)
-
-	// DelegationFluidStakingActive signals if the delegation is upgraded to Fluid Staking model.
-	DelegationFluidStakingActive(*common.Address, *hexutil.Big) (bool, error)
-
 	// StoreWithdrawRequest stores the given withdraw request in persistent storage.
 	StoreWithdrawRequest(*types.WithdrawRequest) error
 
 	// UpdateWithdrawRequest stores the updated withdraw request in persistent storage.
 	UpdateWithdrawRequest(*types.WithdrawRequest) error
 
-	// WithdrawRequest extracts details of a withdraw request specified by the delegator, validator and request ID.
-	WithdrawRequest(*common.Address, *hexutil.Big, *hexutil.Big) (*types.WithdrawRequest, error)
-
-	// WithdrawRequests extracts a list of withdraw requests for the given address and validator.
-	WithdrawRequests(*common.Address, *hexutil.Big, *string, int32) (*types.WithdrawRequestList, error)
-
-	// WithdrawRequestsPendingTotal is the total value of all pending withdrawal requests
-	// for the given delegator and target staker ID.
-	WithdrawRequestsPendingTotal(*common.Address, *hexutil.Big) (*big.Int, error)
-
 	// StoreRewardClaim stores reward claim record in the persistent repository.
 	StoreRewardClaim(*types.RewardClaim) error
 
-	// RewardsClaimed returns the sum of all the claimed rewards
-	// for the given delegator address and validator ID.
-	RewardsClaimed(adr *common.Address, valId *big.Int) (*big.Int, error)
+	// StoreDelegationOperation stores delegation operation record in the persistent repository.
+	StoreDelegationOperation(*types.DelegationOperation) error
+
+	// StoreContractEvent stores contract event record in the persistent repository.
+	StoreContractEvent(*types.ContractEvent) error
+
+	// ContractEvents provides list of events emitted by the given contract.
+	ContractEvents(*common.Address, *string, int32) (*types.ContractEventList, error)
 
-	// RewardClaims provides list of reward claims for the given criteria.
-	RewardClaims(*common.Address, *big.Int, *string, int32) (*types.RewardClaimsList, error)
+	// ContractEventStats provides daily counters of events emitted by the given contract
+	// for the given number of days.
+	ContractEventStats(*common.Address, int) ([]*types.ContractEventStat, error)
 
 	// Price returns a price information for the given target symbol.
 	Price(sym string) (types.Price, error)
 
+	// RecordPrices stores the current price of each supported target symbol
+	// in the price history.
+	RecordPrices() error
+
+	// PriceAt provides the price of the given target symbol at the given time
+	// interpolated from the price history. It returns nil if the price history does not reach that far.
+	PriceAt(sym string, ts time.Time) (*types.PriceSample, error)
+
+	// VerifySignature validates the signature of the given message for the expected signer address.
+	VerifySignature(*common.Address, string, []byte, bool) (*types.SignatureVerification, error)
+
 	// GasPrice resolves the current amount of WEI for single Gas.
 	GasPrice() (hexutil.Uint64, error)
 
@@ -322,6 +378,12 @@ type Repository interface {
 	// SetTrxChannel registers a channel for notifying new transaction events.
 	SetTrxChannel(chan *types.Transaction)
 
+	// SetEpochChannel registers a channel for notifying sealed epoch events.
+	SetEpochChannel(chan *types.Epoch)
+
+	// SetUniswapPairChannel registers a channel for notifying new Uniswap pair events.
+	SetUniswapPairChannel(chan *types.UniswapPairCreated)
+
 	// DefiConfiguration loads the current DeFi contract settings.
 	DefiConfiguration() (*types.DefiSettings, error)
 
@@ -332,12 +394,39 @@ type Repository interface {
 	DefiToken(*common.Address) (*types.DefiToken, error)
 
 	// DefiTokenPrice loads the current price of the given token
-	// from on-chain price oracle.
+	// from on-chain price oracle. The wrapped FTM not known to the oracle
+	// is priced as the native FTM.
 	DefiTokenPrice(*common.Address) (hexutil.Big, error)
 
+	// OracleFeeds loads the state of the on-chain price feeds of all the active fMint DeFi tokens.
+	OracleFeeds() ([]*types.OracleFeed, error)
+
+	// OraclePrice loads the state of the on-chain price feed of the fMint DeFi token
+	// with the given symbol. It returns nil if there is no such token.
+	OraclePrice(string) (*types.OracleFeed, error)
+
 	// FMintAccount loads details of a DeFi/fMint account identified by the owner address.
 	FMintAccount(common.Address) (*types.FMintAccount, error)
 
+	// FMintMinterAddress returns the address of the fMint minter contract.
+	FMintMinterAddress() common.Address
+
+	// AddFMintAccount adds the address to the indexed fMint accounts.
+	AddFMintAccount(*common.Address) error
+
+	// AggregateFMintStats aggregates the protocol level fMint metrics
+	// over the indexed fMint accounts and stores the snapshot.
+	AggregateFMintStats() error
+
+	// FMintStats loads the latest snapshot of the protocol level fMint metrics.
+	// It returns nil if the metrics have not been aggregated yet.
+	FMintStats() (*types.FMintStats, error)
+
+	// FMintAccountsAtRisk loads a page of fMint accounts with a debt and the collateral ratio
+	// below the given ratio in 4 digits, sorted from the lowest ratio. The configured
+	// warning ratio is used if the ratio is not given.
+	FMintAccountsAtRisk(*uint64, *string, int32) (*types.FMintAccountStateList, error)
+
 	// FMintTokenBalance loads balance of a single DeFi token by it's address.
 	FMintTokenBalance(*common.Address, *common.Address, types.DefiTokenType) (hexutil.Big, error)
 
@@ -368,9 +457,24 @@ type Repository interface {
 	// on the rewards distribution contract and can be pushed to accounts.
 	FMintCanPushRewards() (bool, error)
 
+	// FMintRewardsSchedule resolves the state of the rewards distribution
+	// relevant to the rewards claiming schedule of the given fMint account.
+	FMintRewardsSchedule(*common.Address) (*types.FMintRewardsSchedule, error)
+
 	// UniswapPairs returns list of all token pairs managed by Uniswap core.
 	UniswapPairs() ([]common.Address, error)
 
+	// UniswapBestRoute finds the swap route over the known Uniswap pairs providing
+	// the highest output amount for the given input amount.
+	UniswapBestRoute(common.Address, common.Address, hexutil.Big, int) (*types.UniswapRoute, error)
+
+	// UniswapFactoryAddress returns the address of the Uniswap factory (core) contract.
+	UniswapFactoryAddress() common.Address
+
+	// AddUniswapPair registers a Uniswap pair created by the factory.
+	// It returns FALSE if the pair has already been registered.
+	AddUniswapPair(*types.UniswapPairCreated) (bool, error)
+
 	// UniswapPair returns an address of an Uniswap pair for the given tokens.
 	UniswapPair(*common.Address, *common.Address) (*common.Address, error)
 
@@ -431,43 +535,47 @@ type Repository interface {
 	// UniswapActions provides list of uniswap actions stored in the persistent db.
 	UniswapActions(*common.Address, *string, int32, int32) (*types.UniswapActionList, error)
 
-	// NativeTokenAddress returns address of the native token wrapper, if available.
-	NativeTokenAddress() (*common.Address, error)
+	// UniswapPairsReserves loads the current reserves of all the indexed Uniswap pairs.
+	UniswapPairsReserves() ([]*types.UniswapPairReserves, error)
 
-	// Erc20Transactions provides list of ERC20 transactions based on given filters.
-	Erc20Transactions(token *common.Address, acc *common.Address, tt *int32, cursor *string, count int32) (*types.Erc20TransactionList, error)
+	// Erc20TokenPrice calculates the price of the given ERC20 token in the target symbol
+	// by routing through the indexed Uniswap pairs.
+	Erc20TokenPrice(*common.Address, string) (*types.TokenPrice, error)
 
-	// Erc20Token returns an ERC20 token rfor the given address, if available.
-	Erc20Token(*common.Address) (*types.Erc20Token, error)
+	// UniswapPositionEntries provides the aggregated liquidity added and removed
+	// by the given owner on each Uniswap pair.
+	UniswapPositionEntries(*common.Address) ([]*types.UniswapPositionEntry, error)
 
-	// Erc20TokensList returns a list of known ERC20 tokens ordered by their activity.
-	Erc20TokensList(int32) ([]common.Address, error)
+	// WrappedNativeAddress returns the address of the configured wrapped FTM token contract.
+	WrappedNativeAddress() common.Address
 
-	// Erc20BalanceOf load the current available balance of and ERC20 token identified by the token
-	// contract address for an identified owner address.
-	Erc20BalanceOf(*common.Address, *common.Address) (hexutil.Big, error)
+	// NativeTokenAddress returns address of the native token wrapper, if available.
+	NativeTokenAddress() (*common.Address, error)
 
-	// Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
-	// contract by the token owner.
-	Erc20Allowance(*common.Address, *common.Address, *common.Address) (hexutil.Big, error)
+	// RefreshTokenMeta merges the configured curated token list into the token
+	// metadata registry and refreshes on-chain details of all the known tokens.
+	RefreshTokenMeta() error
 
-	// Erc20TotalSupply provides information about all available tokens
-	Erc20TotalSupply(*common.Address) (hexutil.Big, error)
+	// StoreErc20Transaction stores ERC20 transaction into the repository.
+	StoreErc20Transaction(*types.Erc20Transaction) error
 
-	// Erc20Name provides information about the name of the ERC20 token.
-	Erc20Name(*common.Address) (string, error)
+	// Ballots provides the list of configured community ballots.
+	Ballots() ([]*types.Ballot, error)
 
-	// Erc20Symbol provides information about the symbol of the ERC20 token.
-	Erc20Symbol(*common.Address) (string, error)
+	// Ballot provides details of the configured community ballot on the given address.
+	Ballot(*common.Address) (*types.Ballot, error)
 
-	// Erc20Decimals provides information about the decimals of the ERC20 token.
-	Erc20Decimals(*common.Address) (int32, error)
+	// IsBallot checks if the given address is a configured community ballot.
+	IsBallot(*common.Address) bool
 
-	// Erc20LogoURL provides URL address of a logo of the ERC20 token.
-	Erc20LogoURL(*common.Address) string
+	// BallotVote provides the indexed vote of the given voter on the given ballot.
+	BallotVote(*common.Address, *common.Address) (*types.BallotVote, error)
 
-	// StoreErc20Transaction stores ERC20 transaction into the repository.
-	StoreErc20Transaction(*types.Erc20Transaction) error
+	// BallotVotersCount provides the number of voters of each proposal of the given ballot.
+	BallotVotersCount(*common.Address) (map[uint64]uint64, error)
+
+	// StoreBallotVote stores the given ballot vote in the persistent storage.
+	StoreBallotVote(*types.BallotVote) error
 
 	// GovernanceContractBy provides governance contract details by its address.
 	GovernanceContractBy(*common.Address) (*config.GovernanceContract, error)
@@ -496,6 +604,10 @@ type Repository interface {
 	// GovernanceProposals loads list of proposals from given set of Governance contracts.
 	GovernanceProposals([]*common.Address, *string, int32, bool) (*types.GovernanceProposalList, error)
 
+	// GovernanceMaxExecutionPeriod returns the max period in seconds after the voting end
+	// in which an accepted proposal has to be executed.
+	GovernanceMaxExecutionPeriod(*common.Address) (hexutil.Uint64, error)
+
 	// GovernanceProposalFee returns the fee payable for a new proposal
 	// in given Governance contract context.
 	GovernanceProposalFee(*common.Address) (hexutil.Big, error)
@@ -504,6 +616,10 @@ type Repository interface {
 	// in the governance contract identified by the address.
 	GovernanceTotalWeight(*common.Address) (hexutil.Big, error)
 
+	// GovernanceVotingPower provides the voting weight of an address in the governance
+	// contract context at the given block, or at the end of the given epoch.
+	GovernanceVotingPower(*common.Address, *common.Address, *hexutil.Uint64, *hexutil.Uint64) (hexutil.Big, error)
+
 	// FLendGetLendingPool resolves lending pool contract instance
 	// to be able to get calls and information from this contract
 	FLendGetLendingPool() (*contracts.ILendingPool, error)
@@ -535,8 +651,76 @@ type Repository interface {
 	// TrxFlowSpeed provides speed of transaction per second for the last <sec> seconds.
 	TrxFlowSpeed(sec int32) (float64, error)
 
+	// ServiceStates provides the current state of internal services of the repository.
+	ServiceStates() []types.ServiceState
+
+	// PauseService pauses, or resumes scheduled runs of a periodic service.
+	PauseService(name string, paused bool) error
+
+	// TriggerService runs a periodic service right away.
+	TriggerService(name string) error
+
+	// AcquireServiceLock tries to acquire, or renew, the lock of the given service for the holder.
+	AcquireServiceLock(name string, holder string, ttl time.Duration) (bool, error)
+
+	// ReleaseServiceLock releases the lock of the given service if it's held by the holder.
+	ReleaseServiceLock(name string, holder string) error
+
+	// StoreServiceResult stores the latest result of the given service shared with peer API instances.
+	StoreServiceResult(name string, data []byte) error
+
+	// ServiceResult loads the latest result of the given service shared by the leading API instance.
+	// It returns nil if the service did not share any result yet.
+	ServiceResult(name string) ([]byte, error)
+
 	// Close and cleanup the repository.
 	Close()
+
+	// UpdateWatchDigests aggregates the activity of watched addresses
+	// in the digest periods finished since the last update.
+	UpdateWatchDigests() error
+
+	// AddWatchedAddress adds the address to the watch list of its owner.
+	AddWatchedAddress(*types.WatchedAddress) error
+
+	// RemoveWatchedAddress removes the address from the watch list of the given owner.
+	RemoveWatchedAddress(owner string, addr *common.Address) (bool, error)
+
+	// WatchList loads the watch list of the given owner.
+	WatchList(owner string) ([]*types.WatchedAddress, error)
+
+	// WatchListSize provides the number of addresses on the watch list of the given owner.
+	WatchListSize(owner string) (int64, error)
+
+	// WatchListDigest provides the aggregated activity of addresses on the watch list
+	// of the given owner since the given time.
+	WatchListDigest(owner string, since time.Time) ([]*types.WatchDigest, error)
+
+	// EvaluateAlertRules evaluates all the registered alert rules on the blocks
+	// processed since the previous evaluation and raises alerts of the rules met.
+	EvaluateAlertRules() error
+
+	// AddAlertRule registers the alert rule of its owner.
+	AddAlertRule(*types.AlertRule) error
+
+	// RemoveAlertRule removes the alert rule of the given owner.
+	RemoveAlertRule(owner string, id string) (bool, error)
+
+	// AlertRules loads the alert rules of the given owner.
+	AlertRules(owner string) ([]*types.AlertRule, error)
+
+	// AlertRulesSize provides the number of alert rules of the given owner.
+	AlertRulesSize(owner string) (int64, error)
+
+	// Alerts loads up to the given number of alerts of the given owner raised since the given time.
+	Alerts(owner string, since time.Time, limit int64) ([]*types.Alert, error)
+
+	// UndeliveredAlerts loads up to the given number of alerts with a webhook raised
+	// since the given time and not delivered yet.
+	UndeliveredAlerts(since time.Time, limit int64) ([]*types.Alert, error)
+
+	// DeliverAlert posts the alert to its webhook and marks it delivered.
+	DeliverAlert(al *types.Alert) error
 }
 
 // repo represents an instance of the Repository manager.
@@ -565,6 +749,17 @@ func SetLogger(l logger.Logger) {
 	log = l
 }
 
+// backends represents the backends provided to the repository
+// instead of the ones connected using the configuration.
+var backends Backends
+
+// SetBackends sets the backends the repository should be built on,
+// e.g. an alternative persistent storage. Backends not provided are connected
+// using the configuration. It has to be called before the first call to R().
+func SetBackends(b Backends) {
+	backends = b
+}
+
 // R provides access to the singleton instance of the Repository.
 func R() Repository {
 	// make sure to instantiate the Repository only once
@@ -574,20 +769,31 @@ func R() Repository {
 	return repo
 }
 
+// Use replaces the Repository instance provided by R() with the given implementation,
+// e.g. a mock for tests. Use SetBackends to replace individual backends instead.
+// It has to be called before the first call to R().
+func Use(r Repository) {
+	onceRepo.Do(func() {})
+	repo = r
+}
+
 // Proxy represents Repository interface implementation and controls access to data
 // trough several low level bridges.
 type proxy struct {
-	cache *cache.MemBridge
-	db    *db.MongoDbBridge
-	rpc   *rpc.FtmBridge
+	cache ObjectCache
+	db    Store
+	rpc   Node
 	log   logger.Logger
 	cfg   *config.Config
 
+	// contract validation logger
+	valLog logger.Logger
+
 	// transaction estimator counter
-	txCount uint64
+	txCount *uint64
 
 	// we need a Group to use single flight to control price pulls
-	apiRequestGroup singleflight.Group
+	apiRequestGroup *singleflight.Group
 
 	// governance contracts reference
 	govContracts map[string]*config.GovernanceContract
@@ -595,40 +801,107 @@ type proxy struct {
 	// smart contract compilers
 	solCompiler string
 
+	// internal events bus
+	bus *eventBus
+
+	// transactions submitted, but not indexed yet
+	pendingTrx *pendingTrxOverlay
+
+	// compliance screening, if enabled
+	screener Screener
+
+	// API usage not stored yet
+	usage *usageMeter
+
 	// service orchestrator reference
 	orc *orchestrator
 }
 
 // newRepository creates new instance of Repository implementation, namely proxy structure.
 func newRepository() Repository {
-	// create new in-memory cache bridge
-	caBridge, dbBridge, rpcBridge, err := connect(cfg, log)
+	p, err := newProxy(cfg, log, backends)
 	if err != nil {
-		log.Fatal("repository init failed")
+		log.Fatalf("repository init failed; %s", err.Error())
 		return nil
 	}
 
+	// start the service orchestrator
+	p.orc.run()
+	return p
+}
+
+// New creates a new Repository instance on the given backends with the background
+// services not running, so the chain is not scanned and no periodic jobs are executed.
+// It serves tools and tests which feed the backends themselves; the API server
+// uses the shared instance provided by R(). Backends not provided are connected
+// using the configuration.
+func New(c *config.Config, l logger.Logger, b Backends) (Repository, error) {
+	return newProxy(c, l, b)
+}
+
+// newProxy creates the proxy on the given backends together with its service orchestrator;
+// the services are not started.
+func newProxy(cfg *config.Config, log logger.Logger, backends Backends) (*proxy, error) {
+	// connect the backends not provided
+	b, err := connect(cfg, log, backends)
+	if err != nil {
+		return nil, err
+	}
+
 	// construct the proxy instance
 	p := proxy{
-		cache: caBridge,
-		db:    dbBridge,
-		rpc:   rpcBridge,
-		log:   log,
-		cfg:   cfg,
+		cache:  b.Cache,
+		db:     b.Store,
+		rpc:    b.Node,
+		log:    log,
+		cfg:    cfg,
+		valLog: log.ModuleLogger(logger.ModuleValidator),
+
+		// shared by the copies of the proxy bound to a context
+		txCount:         new(uint64),
+		apiRequestGroup: new(singleflight.Group),
 
 		// get the map of governance contracts
 		govContracts: governanceContractsMap(&cfg.Governance),
 
 		// keep reference to the SOL compiler
 		solCompiler: cfg.Compiler.DefaultSolCompilerPath,
+
+		// make the pending transactions overlay
+		pendingTrx: newPendingTrxOverlay(),
+
+		// make the API usage meter
+		usage: newUsageMeter(),
+	}
+
+	// make the compliance screening
+	if p.screener, err = p.newScreener(); err != nil {
+		return nil, fmt.Errorf("compliance screening init failed; %s", err.Error())
 	}
 
-	// make the service orchestrator and start it's job
+	// make the events bus
+	p.bus = newEventBus(&p)
+
+	// make the service orchestrator
 	p.orc = newOrchestrator(&p, log, cfg)
-	p.orc.run()
+	return &p, nil
+}
+
+// WithContext provides a copy of the repository with the database operations and the node calls
+// bound to the given context; the backend calls are counted by the calls counter of the context, if any.
+// The copy shares the cache, the services and the state of the repository.
+func (p *proxy) WithContext(ctx context.Context) Repository {
+	cp := *p
+	cp.cache = p.cache.WithContext(ctx)
+	cp.db = p.db.WithContext(ctx)
+	cp.rpc = p.rpc.WithContext(ctx)
+	return &cp
+}
 
-	// return the proxy
-	return &p
+// WithoutNode marks the context so the node calls of the repository bound to it fail
+// right away; the data are served from the cache and the database only.
+func WithoutNode(ctx context.Context) context.Context {
+	return rpc.WithoutNode(ctx)
 }
 
 // governanceContractsMap creates map of governance contracts keyed
@@ -644,30 +917,96 @@ func governanceContractsMap(cfg *config.Governance) map[string]*config.Governanc
 	return res
 }
 
-// connect opens connections to the external sources we need.
-func connect(cfg *config.Config, log logger.Logger) (*cache.MemBridge, *db.MongoDbBridge, *rpc.FtmBridge, error) {
+// connect opens connections to the external sources we need;
+// the backends already provided are used as they are.
+func connect(cfg *config.Config, log logger.Logger, b Backends) (*Backends, error) {
 	// create new in-memory cache bridge
-	caBridge, err := cache.New(cfg, log)
-	if err != nil {
-		log.Criticalf("can not create in-memory cache bridge, %s", err.Error())
-		return nil, nil, nil, err
+	if b.Cache == nil {
+		mem, err := cache.New(cfg, log)
+		if err != nil {
+			log.Criticalf("can not create in-memory cache bridge, %s", err.Error())
+			return nil, err
+		}
+		b.Cache = memCache{mem}
+	}
+
+	// use the in-memory storage if configured
+	if b.Store == nil && cfg.Db.Driver == config.DbDriverMemory {
+		b.Store = memoryStore{memdb.New(log.ModuleLogger(logger.ModuleDb))}
 	}
 
 	// create new database connection bridge
-	dbBridge, err := db.New(cfg, log)
-	if err != nil {
-		log.Criticalf("can not connect backend persistent storage, %s", err.Error())
-		return nil, nil, nil, err
+	if b.Store == nil {
+		dbBridge, err := db.New(cfg, log.ModuleLogger(logger.ModuleDb))
+		if err != nil {
+			log.Criticalf("can not connect backend persistent storage, %s", err.Error())
+			return nil, err
+		}
+		b.Store = mongoStore{dbBridge}
 	}
 
 	// create new Lachesis RPC bridge
-	rpcBridge, err := rpc.New(cfg, log)
+	if b.Node == nil {
+		rpcBridge, err := rpc.New(cfg, log.ModuleLogger(logger.ModuleRpc))
+		if err != nil {
+			log.Criticalf("can not connect Lachesis RPC interface, %s", err.Error())
+			return nil, err
+		}
+		b.Node = ftmNode{rpcBridge}
+	}
+	return &b, nil
+}
+
+// PurgeCache removes the entry with the given key from the in-memory cache.
+func (p *proxy) PurgeCache(key string) bool {
+	return p.cache.Evict(key)
+}
+
+// ServiceStates provides the current state of internal services of the repository.
+func (p *proxy) ServiceStates() []types.ServiceState {
+	return p.orc.states()
+}
+
+// PauseService pauses, or resumes scheduled runs of a periodic service.
+func (p *proxy) PauseService(name string, paused bool) error {
+	se, err := p.orc.periodicService(name)
+	if err != nil {
+		return err
+	}
+
+	se.pause(paused)
+	return nil
+}
+
+// TriggerService runs a periodic service right away.
+func (p *proxy) TriggerService(name string) error {
+	se, err := p.orc.periodicService(name)
 	if err != nil {
-		log.Criticalf("can not connect Lachesis RPC interface, %s", err.Error())
-		return nil, nil, nil, err
+		return err
 	}
 
-	return caBridge, dbBridge, rpcBridge, nil
+	se.trigger()
+	return nil
+}
+
+// AcquireServiceLock tries to acquire, or renew, the lock of the given service for the holder.
+func (p *proxy) AcquireServiceLock(name string, holder string, ttl time.Duration) (bool, error) {
+	return p.db.AcquireServiceLock(name, holder, ttl)
+}
+
+// ReleaseServiceLock releases the lock of the given service if it's held by the holder.
+func (p *proxy) ReleaseServiceLock(name string, holder string) error {
+	return p.db.ReleaseServiceLock(name, holder)
+}
+
+// StoreServiceResult stores the latest result of the given service shared with peer API instances.
+func (p *proxy) StoreServiceResult(name string, data []byte) error {
+	return p.db.StoreServiceResult(name, data)
+}
+
+// ServiceResult loads the latest result of the given service shared by the leading API instance.
+func (p *proxy) ServiceResult(name string) ([]byte, error) {
+	return p.db.ServiceResult(name)
 }
 
 // Close with close all connections and clean up the pending work for graceful termination.
@@ -705,3 +1044,8 @@ func (p *proxy) SetBlockChannel(ch chan *types.Block) {
 func (p *proxy) SetTrxChannel(ch chan *types.Transaction) {
 	p.orc.setTrxChannel(ch)
 }
+
+// SetEpochChannel registers a channel for notifying sealed epoch events.
+func (p *proxy) SetEpochChannel(ch chan *types.Epoch) {
+	p.orc.setEpochChannel(ch)
+}
@@ -0,0 +1,62 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// RiskLevel provides the moderation risk level of the given contract, or token.
+// Flags imported from the configuration take precedence over flags stored
+// in the database.
+func (p *proxy) RiskLevel(addr *common.Address) (string, error) {
+	// check the configured flags first
+	for _, rf := range p.cfg.Moderation.Flagged {
+		if rf.Address == *addr {
+			return rf.Level, nil
+		}
+	}
+
+	// try the cache; addresses without flag are cached as well
+	rf, ok := p.cache.PullRiskFlag(addr)
+	if !ok {
+		var err error
+		if rf, err = p.db.RiskFlag(addr); err != nil {
+			return types.RiskLevelNone, err
+		}
+
+		// keep it in cache for future use
+		p.cache.PushRiskFlag(addr, rf)
+	}
+
+	// no flag means no known risk
+	if rf == nil {
+		return types.RiskLevelNone, nil
+	}
+	return rf.Level, nil
+}
+
+// StoreRiskFlag stores the given risk flag; flags with no risk level are removed.
+// Only the local cache is evicted, other API instances sharing the database
+// pick up the change when their cached value expires (see cache.riskFlagMaxAge).
+func (p *proxy) StoreRiskFlag(rf *types.RiskFlag) error {
+	// drop the cached value in any case
+	defer p.cache.EvictRiskFlag(&rf.Address)
+
+	// is this a removal?
+	if rf.Level == types.RiskLevelNone {
+		return p.db.RemoveRiskFlag(&rf.Address)
+	}
+
+	// mark the update time and store the flag
+	rf.Updated = time.Now().UTC()
+	return p.db.StoreRiskFlag(rf)
+}
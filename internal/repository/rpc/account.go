@@ -14,15 +14,17 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sort"
 )
 
 // AccountBalance reads balance of account from Lachesis node.
 func (ftm *FtmBridge) AccountBalance(addr *common.Address) (*hexutil.Big, error) {
 	// use RPC to make the call
 	var balance string
-	err := ftm.rpc.Call(&balance, "ftm_getBalance", addr.Hex(), "latest")
+	err := ftm.call(&balance, "ftm_getBalance", addr.Hex(), "latest")
 	if err != nil {
 		ftm.log.Errorf("can not get balance of account [%s]", addr.Hex())
 		return nil, err
@@ -40,9 +42,21 @@ func (ftm *FtmBridge) AccountBalance(addr *common.Address) (*hexutil.Big, error)
 
 // AccountNonce returns the total number of transaction of account from Lachesis node.
 func (ftm *FtmBridge) AccountNonce(addr *common.Address) (uint64, error) {
+	return ftm.accountNonce(addr, BlockTypeLatest)
+}
+
+// AccountPendingNonce returns the total number of transaction of account from Lachesis node
+// including transactions waiting in the node transaction pool.
+func (ftm *FtmBridge) AccountPendingNonce(addr *common.Address) (uint64, error) {
+	return ftm.accountNonce(addr, BlockTypePending)
+}
+
+// accountNonce returns the total number of transaction of account from Lachesis node
+// on the given block tag.
+func (ftm *FtmBridge) accountNonce(addr *common.Address, tag string) (uint64, error) {
 	// use RPC to make the call
 	var nonce string
-	err := ftm.rpc.Call(&nonce, "ftm_getTransactionCount", addr.Hex(), "latest")
+	err := ftm.call(&nonce, "ftm_getTransactionCount", addr.Hex(), tag)
 	if err != nil {
 		ftm.log.Errorf("can not get number of transaction of account [%s]", addr.Hex())
 		return 0, err
@@ -57,3 +71,35 @@ func (ftm *FtmBridge) AccountNonce(addr *common.Address) (uint64, error) {
 
 	return val, nil
 }
+
+// AccountPendingTransactions returns the list of transactions sent by the account
+// waiting in the node transaction pool to be processed.
+func (ftm *FtmBridge) AccountPendingTransactions(addr *common.Address) ([]*types.Transaction, error) {
+	// the pool content is split into pending and queued transactions, both keyed by nonce
+	var content struct {
+		Pending map[string]*types.Transaction `json:"pending"`
+		Queued  map[string]*types.Transaction `json:"queued"`
+	}
+
+	// use RPC to make the call
+	err := ftm.call(&content, "txpool_contentFrom", addr.Hex())
+	if err != nil {
+		ftm.log.Errorf("can not get pending transactions of account [%s]; %s", addr.Hex(), err.Error())
+		return nil, err
+	}
+
+	// collect both pending and queued transactions
+	list := make([]*types.Transaction, 0, len(content.Pending)+len(content.Queued))
+	for _, trx := range content.Pending {
+		list = append(list, trx)
+	}
+	for _, trx := range content.Queued {
+		list = append(list, trx)
+	}
+
+	// sort the list by nonce so the client gets the expected processing order
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Nonce < list[j].Nonce
+	})
+	return list, nil
+}
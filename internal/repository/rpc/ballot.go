@@ -0,0 +1,96 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"bytes"
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+//go:generate tools/abigen.sh --abi ./contracts/abi/ballot.abi --pkg contracts --type BallotContract --out ./contracts/ballot.go
+
+// Ballot loads details of the ballot contract deployed on the given address.
+func (ftm *FtmBridge) Ballot(addr *common.Address) (*types.Ballot, error) {
+	// connect the contract
+	con, err := contracts.NewBallotContract(*addr, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("can not access ballot %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	// load the basic details
+	bt := types.Ballot{Address: *addr}
+	if bt.Name, err = con.Name(ftm.callOpts()); err != nil {
+		ftm.log.Errorf("can not get name of ballot %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	if bt.Url, err = con.Url(ftm.callOpts()); err != nil {
+		ftm.log.Errorf("can not get url of ballot %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	if bt.Finalized, err = con.Finalized(ftm.callOpts()); err != nil {
+		ftm.log.Errorf("can not get state of ballot %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	// load the time frame and the winner
+	for _, v := range []struct {
+		call func(*bind.CallOpts) (*big.Int, error)
+		val  *hexutil.Uint64
+	}{
+		{con.Start, &bt.Start},
+		{con.End, &bt.End},
+		{con.Winner, &bt.Winner},
+	} {
+		val, err := v.call(ftm.callOpts())
+		if err != nil {
+			ftm.log.Errorf("can not get details of ballot %s; %s", addr.String(), err.Error())
+			return nil, err
+		}
+		*v.val = hexutil.Uint64(val.Uint64())
+	}
+
+	// load the proposals
+	if bt.Proposals, err = ftm.ballotProposals(con); err != nil {
+		ftm.log.Errorf("can not get proposals of ballot %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	return &bt, nil
+}
+
+// ballotProposals loads the list of proposals of the given ballot contract.
+func (ftm *FtmBridge) ballotProposals(con *contracts.BallotContract) ([]types.BallotProposal, error) {
+	count, err := con.ProposalsCount(ftm.callOpts())
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]types.BallotProposal, count.Uint64())
+	for i := range list {
+		prop, err := con.Proposals(ftm.callOpts(), big.NewInt(int64(i)))
+		if err != nil {
+			return nil, err
+		}
+		list[i] = types.BallotProposal{
+			Name:  string(bytes.TrimRight(prop.Name[:], "\x00")),
+			Votes: hexutil.Big(*prop.Votes),
+		}
+	}
+	return list, nil
+}
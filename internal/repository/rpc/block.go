@@ -25,13 +25,14 @@ import (
 const (
 	BlockTypeLatest   = "latest"
 	BlockTypeEarliest = "earliest"
+	BlockTypePending  = "pending"
 )
 
 // MustBlockHeight returns the current block height
 // of the block chain. It returns nil if the block height can not be pulled.
 func (ftm *FtmBridge) MustBlockHeight() *big.Int {
 	var val hexutil.Big
-	if err := ftm.rpc.Call(&val, "ftm_blockNumber"); err != nil {
+	if err := ftm.call(&val, "ftm_blockNumber"); err != nil {
 		ftm.log.Errorf("failed block height check; %s", err.Error())
 		return nil
 	}
@@ -45,7 +46,7 @@ func (ftm *FtmBridge) BlockHeight() (*hexutil.Big, error) {
 
 	// call for data
 	var height hexutil.Big
-	err := ftm.rpc.Call(&height, "ftm_blockNumber")
+	err := ftm.call(&height, "ftm_blockNumber")
 	if err != nil {
 		ftm.log.Error("block height could not be obtained")
 		return nil, err
@@ -64,7 +65,7 @@ func (ftm *FtmBridge) Block(numTag *string) (*types.Block, error) {
 
 	// call for data
 	var block types.Block
-	err := ftm.rpc.Call(&block, "ftm_getBlockByNumber", numTag, false)
+	err := ftm.call(&block, "ftm_getBlockByNumber", numTag, false)
 	if err != nil {
 		ftm.log.Error("block could not be extracted")
 		return nil, err
@@ -89,7 +90,7 @@ func (ftm *FtmBridge) BlockByHash(hash *string) (*types.Block, error) {
 
 	// call for data
 	var block types.Block
-	err := ftm.rpc.Call(&block, "ftm_getBlockByHash", hash, false)
+	err := ftm.call(&block, "ftm_getBlockByHash", hash, false)
 	if err != nil {
 		ftm.log.Error("block could not be extracted")
 		return nil, err
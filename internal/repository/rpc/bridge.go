@@ -17,19 +17,28 @@ import (
 	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository/opstats"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fmt"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	eth "github.com/ethereum/go-ethereum/ethclient"
 	ftm "github.com/ethereum/go-ethereum/rpc"
 	"golang.org/x/sync/singleflight"
+	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // FtmBridge represents Lachesis RPC abstraction layer.
 type FtmBridge struct {
 	rpc *ftm.Client
-	eth *eth.Client
+	eth *nodeBackend
+	pol *callPolicy
+	fix *fixtureTransport
 	log logger.Logger
 	cg  *singleflight.Group
 
@@ -37,14 +46,23 @@ type FtmBridge struct {
 	sigConfig     *config.ServerSignature
 	sfcConfig     *config.Staking
 	uniswapConfig *config.DeFiUniswap
+	fnsConfig     *config.NameService
 
 	// extended minter config
-	fMintCfg fMintConfig
+	fMintCfg *fMintConfig
 	fLendCfg fLendConfig
 
 	// common contracts
 	sfcAbi      *abi.ABI
 	sfcContract *contracts.SfcContract
+	sfcAdapter  *atomic.Value
+
+	// multicallAddress is the address of the multicall contract used
+	// to batch read calls; empty address disables batching
+	multicallAddress common.Address
+
+	// ctx is the context the node calls are bound to, nil if not bound
+	ctx context.Context
 }
 
 // New creates new Lachesis RPC connection bridge.
@@ -53,40 +71,56 @@ func New(cfg *config.Config, log logger.Logger) (*FtmBridge, error) {
 	log.Debugf("connecting block chain node at %s", cfg.Lachesis.Url)
 
 	// try to establish a connection
-	client, err := ftm.Dial(cfg.Lachesis.Url)
+	client, con, fix, err := dial(&cfg.Lachesis, log)
 	if err != nil {
 		log.Critical(err)
 		return nil, err
 	}
 
-	// log
-	log.Notice("block chain node online")
+	// prep the node call policy
+	pol := newCallPolicy(&cfg.Lachesis)
 
-	// try to establish a for smart contract interaction
-	con, err := eth.Dial(cfg.Lachesis.Url)
+	// the common contracts are shared by all the copies of the bridge
+	sfcAbi, err := abi.JSON(strings.NewReader(contracts.SfcContractABI))
 	if err != nil {
-		log.Critical(err)
+		log.Criticalf("failed to parse SFC contract ABI; %s", err.Error())
 		return nil, err
 	}
 
-	// log
-	log.Notice("smart contact connection open")
+	nb := &nodeBackend{Client: con, pol: pol}
+	sfcContract, err := contracts.NewSfcContract(cfg.Staking.SFCContract, nb)
+	if err != nil {
+		log.Criticalf("failed to instantiate SFC contract; %s", err.Error())
+		return nil, err
+	}
 
 	// return the Bridge
 	br := &FtmBridge{
 		rpc: client,
-		eth: con,
+		eth: nb,
+		pol: pol,
+		fix: fix,
 		log: log,
 		cg:  new(singleflight.Group),
 
+		sfcAbi:      &sfcAbi,
+		sfcContract: sfcContract,
+
+		// the SFC adapter is detected on the first use
+		sfcAdapter: new(atomic.Value),
+
 		// special configuration options below this line
 		sigConfig:     &cfg.MySignature,
 		sfcConfig:     &cfg.Staking,
 		uniswapConfig: &cfg.DeFi.Uniswap,
-		fMintCfg: fMintConfig{
+		fnsConfig:     &cfg.NameService,
+		fMintCfg: &fMintConfig{
 			addressProvider: cfg.DeFi.FMint.AddressProvider,
+			contracts:       new(sync.Map),
+			requestGroup:    new(singleflight.Group),
 		},
-		fLendCfg: fLendConfig{lendigPoolAddress: cfg.DeFi.FLend.LendingPool},
+		fLendCfg:         fLendConfig{lendigPoolAddress: cfg.DeFi.FLend.LendingPool},
+		multicallAddress: cfg.Lachesis.Multicall,
 	}
 
 	// inform about the local address of the API node
@@ -97,6 +131,52 @@ func New(cfg *config.Config, log logger.Logger) (*FtmBridge, error) {
 	return br, nil
 }
 
+// dial opens the node connection for RPC calls and the connection for smart contract interaction.
+// Node responses are recorded into, or replayed from the fixtures file, if configured.
+func dial(cfg *config.Lachesis, log logger.Logger) (*ftm.Client, *eth.Client, *fixtureTransport, error) {
+	if cfg.Fixtures.Mode == "" {
+		client, err := ftm.Dial(cfg.Url)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		log.Notice("block chain node online")
+
+		con, err := eth.Dial(cfg.Url)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		log.Notice("smart contact connection open")
+		return client, con, nil, nil
+	}
+
+	// the fixtures are exchanged over HTTP, subscriptions are not available
+	fix, err := newFixtureTransport(&cfg.Fixtures, log)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if fix.mode == fixturesRecord && !strings.HasPrefix(cfg.Url, "http") {
+		return nil, nil, nil, fmt.Errorf("node fixtures can be recorded only over HTTP, %s given", cfg.Url)
+	}
+	log.Noticef("node fixtures %s mode with %s", cfg.Fixtures.Mode, cfg.Fixtures.Path)
+
+	hc := &http.Client{Transport: fix}
+	client, err := ftm.DialHTTPWithClient(cfg.Url, hc)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	con, err := ftm.DialHTTPWithClient(cfg.Url, hc)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return client, eth.NewClient(con), fix, nil
+}
+
+// Latency provides the 95th percentile of the latency of recent node calls.
+func (ftm *FtmBridge) Latency() time.Duration {
+	return ftm.pol.lat.P95()
+}
+
 // Close will finish all pending operations and terminate the Lachesis RPC connection
 func (ftm *FtmBridge) Close() {
 	// do we have a connection?
@@ -105,6 +185,13 @@ func (ftm *FtmBridge) Close() {
 		ftm.eth.Close()
 		ftm.log.Info("blockchain connections are closed")
 	}
+
+	// keep the recorded node responses
+	if ftm.fix != nil {
+		if err := ftm.fix.save(); err != nil {
+			ftm.log.Errorf("can not save node fixtures; %s", err.Error())
+		}
+	}
 }
 
 // Connection returns open Opera/Lachesis connection.
@@ -120,36 +207,49 @@ func (ftm *FtmBridge) DefaultCallOpts() *bind.CallOpts {
 			Pending:     false,
 			From:        ftm.sigConfig.Address,
 			BlockNumber: ftm.MustBlockHeight(),
-			Context:     context.Background(),
 		}, nil
 	})
-	return co.(*bind.CallOpts)
+
+	// the options are shared by parallel callers, bind a copy to our context
+	opts := *co.(*bind.CallOpts)
+	opts.Context = ftm.callContext()
+	return &opts
+}
+
+// callOpts creates call options of a read-only contract call on the latest block.
+func (ftm *FtmBridge) callOpts() *bind.CallOpts {
+	return &bind.CallOpts{Context: ftm.callContext()}
+}
+
+// WithContext returns a copy of the bridge with the node calls bound to the given context,
+// so calls of a cancelled API request are not retried. The copy shares the connection
+// and the state of the bridge; the fMint configuration is bound to the copy.
+func (ftm *FtmBridge) WithContext(ctx context.Context) *FtmBridge {
+	cp := *ftm
+	cp.ctx = ctx
+
+	fmc := *ftm.fMintCfg
+	fmc.bridge = &cp
+	cp.fMintCfg = &fmc
+	return &cp
+}
+
+// callContext provides the context a node call should run in.
+// The call is counted by the calls counter of the bound operation, if any.
+func (ftm *FtmBridge) callContext() context.Context {
+	if ftm.ctx == nil {
+		return context.Background()
+	}
+	opstats.FromContext(ftm.ctx).Rpc()
+	return ftm.ctx
 }
 
 // SfcContract returns instance of SFC contract for interaction.
 func (ftm *FtmBridge) SfcContract() *contracts.SfcContract {
-	// lazy create SFC contract instance
-	if nil == ftm.sfcContract {
-		// instantiate the contract and display its name
-		var err error
-		ftm.sfcContract, err = contracts.NewSfcContract(ftm.sfcConfig.SFCContract, ftm.eth)
-		if err != nil {
-			ftm.log.Criticalf("failed to instantiate SFC contract; %s", err.Error())
-			panic(err)
-		}
-	}
 	return ftm.sfcContract
 }
 
 // SfcAbi returns a parse ABI of the AFC contract.
 func (ftm *FtmBridge) SfcAbi() *abi.ABI {
-	if nil == ftm.sfcAbi {
-		ab, err := abi.JSON(strings.NewReader(contracts.SfcContractABI))
-		if err != nil {
-			ftm.log.Criticalf("failed to parse SFC contract ABI; %s", err.Error())
-			panic(err)
-		}
-		ftm.sfcAbi = &ab
-	}
 	return ftm.sfcAbi
 }
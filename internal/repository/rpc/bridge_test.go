@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/singleflight"
+)
+
+// TestBridgeWithContext verifies the fMint configuration of a bridge copy
+// is bound to the copy and shares the resolved contract addresses.
+func TestBridgeWithContext(t *testing.T) {
+	br := &FtmBridge{fMintCfg: &fMintConfig{contracts: new(sync.Map), requestGroup: new(singleflight.Group)}}
+	br.fMintCfg.bridge = br
+
+	ctx := WithoutNode(context.Background())
+	cp := br.WithContext(ctx)
+	if cp.fMintCfg.bridge != cp || cp.fMintCfg.bridge.callContext() != ctx {
+		t.Errorf("fMint configuration not bound to the bridge copy")
+	}
+	if br.fMintCfg.bridge != br {
+		t.Errorf("fMint configuration of the bridge rebound")
+	}
+
+	adr := common.HexToAddress("0xBB634cafEf389cDD03bB276c82738726079FcF2E")
+	cp.fMintCfg.contracts.Store(fMintAddressMinter, adr)
+	if got := br.fMintCfg.mustContractAddress(fMintAddressMinter); got != adr {
+		t.Errorf("contract address not shared, got %s", got.String())
+	}
+}
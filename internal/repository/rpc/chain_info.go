@@ -0,0 +1,43 @@
+package rpc
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ChainId provides the identifier of the chain used to sign transactions.
+func (ftm *FtmBridge) ChainId() (uint64, error) {
+	var id hexutil.Uint64
+	if err := ftm.call(&id, "eth_chainId"); err != nil {
+		ftm.log.Errorf("can not get chain id; %s", err.Error())
+		return 0, err
+	}
+	return uint64(id), nil
+}
+
+// ClientVersion provides the version of the node client software.
+func (ftm *FtmBridge) ClientVersion() (string, error) {
+	var ver string
+	if err := ftm.call(&ver, "web3_clientVersion"); err != nil {
+		ftm.log.Errorf("can not get node client version; %s", err.Error())
+		return "", err
+	}
+	return ver, nil
+}
+
+// RpcModules provides the sorted list of RPC API modules enabled on the node.
+func (ftm *FtmBridge) RpcModules() ([]string, error) {
+	var mods map[string]string
+	if err := ftm.call(&mods, "rpc_modules"); err != nil {
+		ftm.log.Errorf("can not get node RPC modules; %s", err.Error())
+		return nil, err
+	}
+
+	list := make([]string, 0, len(mods))
+	for name := range mods {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list, nil
+}
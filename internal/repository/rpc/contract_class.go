@@ -0,0 +1,124 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"bytes"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+var (
+	// erc165SupportsInterface is the selector of ERC-165 supportsInterface(bytes4) call.
+	erc165SupportsInterface = []byte{0x01, 0xff, 0xc9, 0xa7}
+
+	// ownableOwner is the selector of the Ownable owner() call.
+	ownableOwner = []byte{0x8d, 0xa5, 0xcb, 0x5b}
+
+	// eip1167Prefix is the byte code prefix of EIP-1167 minimal proxy;
+	// the implementation address follows.
+	eip1167Prefix = common.FromHex("0x363d3d373d3d3d363d73")
+
+	// proxyImplementationSlots is the list of storage slots known to keep
+	// the implementation address of a proxy contract, namely EIP-1967 and EIP-1822.
+	proxyImplementationSlots = []common.Hash{
+		common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"),
+		common.HexToHash("0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7"),
+	}
+)
+
+// opDelegateCall is the EVM DELEGATECALL opcode.
+const opDelegateCall = 0xf4
+
+// smallProxyCodeLength is the max length of a proxy byte code we check
+// for implementation address stored in the first storage slot (Gnosis Safe proxy).
+const smallProxyCodeLength = 256
+
+// ContractCode loads the byte code deployed at the given address.
+func (ftm *FtmBridge) ContractCode(addr *common.Address) ([]byte, error) {
+	code, err := ftm.eth.CodeAt(ftm.callContext(), *addr, nil)
+	if err != nil {
+		ftm.log.Errorf("can not get code of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	return code, nil
+}
+
+// SupportsInterface checks if the contract at the given address
+// responds positively to ERC-165 query for the given interface id.
+func (ftm *FtmBridge) SupportsInterface(addr *common.Address, id [4]byte) bool {
+	data := make([]byte, 36)
+	copy(data, erc165SupportsInterface)
+	copy(data[4:], id[:])
+
+	// failed call means the contract does not implement ERC-165
+	res, err := ftm.eth.CallContract(ftm.callContext(), ethereum.CallMsg{To: addr, Data: data, Gas: 30000}, nil)
+	if err != nil || len(res) < 32 {
+		return false
+	}
+	return new(big.Int).SetBytes(res[:32]).Sign() > 0
+}
+
+// ProxyImplementation tries to find the implementation address
+// of a proxy contract with the given byte code. It returns nil
+// if the contract is not recognized as a proxy.
+func (ftm *FtmBridge) ProxyImplementation(addr *common.Address, code []byte) *common.Address {
+	// minimal proxy keeps the address in the code
+	if bytes.HasPrefix(code, eip1167Prefix) && len(code) >= len(eip1167Prefix)+common.AddressLength {
+		impl := common.BytesToAddress(code[len(eip1167Prefix) : len(eip1167Prefix)+common.AddressLength])
+		return &impl
+	}
+
+	// no delegate call, no proxy
+	if bytes.IndexByte(code, opDelegateCall) < 0 {
+		return nil
+	}
+
+	// check the well known slots; small proxies may use the first slot
+	slots := proxyImplementationSlots
+	if len(code) <= smallProxyCodeLength {
+		slots = append([]common.Hash{{}}, slots...)
+	}
+
+	for _, slot := range slots {
+		val, err := ftm.eth.StorageAt(ftm.callContext(), *addr, slot, nil)
+		if err != nil {
+			ftm.log.Errorf("can not read storage of %s; %s", addr.String(), err.Error())
+			return nil
+		}
+
+		// we need a contract address there
+		impl := common.BytesToAddress(val)
+		if impl == (common.Address{}) {
+			continue
+		}
+		if ok, err := ftm.IsContract(&impl); err == nil && ok {
+			return &impl
+		}
+	}
+	return nil
+}
+
+// ContractOwner loads the owner of the contract at the given address using
+// the Ownable owner() call. It returns nil if the contract doesn't respond to the call.
+func (ftm *FtmBridge) ContractOwner(addr *common.Address) *common.Address {
+	res, err := ftm.eth.CallContract(ftm.callContext(), ethereum.CallMsg{To: addr, Data: ownableOwner, Gas: 30000}, nil)
+	if err != nil || len(res) < 32 {
+		return nil
+	}
+
+	owner := common.BytesToAddress(res[12:32])
+	return &owner
+}
@@ -0,0 +1,653 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// BallotContractABI is the input ABI used to generate the binding from.
+const BallotContractABI = "[{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"url\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"start\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"end\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"finalized\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"winner\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"proposalsCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"proposals\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"name\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"votes\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"voters\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"voted\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"vote\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"proposal\",\"type\":\"uint256\"}],\"name\":\"vote\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"proposal\",\"type\":\"uint256\"}],\"name\":\"Voted\",\"type\":\"event\"}]"
+
+// BallotContract is an auto generated Go binding around an Ethereum contract.
+type BallotContract struct {
+	BallotContractCaller     // Read-only binding to the contract
+	BallotContractTransactor // Write-only binding to the contract
+	BallotContractFilterer   // Log filterer for contract events
+}
+
+// BallotContractCaller is an auto generated read-only Go binding around an Ethereum contract.
+type BallotContractCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BallotContractTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type BallotContractTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BallotContractFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type BallotContractFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BallotContractSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type BallotContractSession struct {
+	Contract     *BallotContract   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BallotContractCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type BallotContractCallerSession struct {
+	Contract *BallotContractCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// BallotContractTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type BallotContractTransactorSession struct {
+	Contract     *BallotContractTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// BallotContractRaw is an auto generated low-level Go binding around an Ethereum contract.
+type BallotContractRaw struct {
+	Contract *BallotContract // Generic contract binding to access the raw methods on
+}
+
+// BallotContractCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type BallotContractCallerRaw struct {
+	Contract *BallotContractCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// BallotContractTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type BallotContractTransactorRaw struct {
+	Contract *BallotContractTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBallotContract creates a new instance of BallotContract, bound to a specific deployed contract.
+func NewBallotContract(address common.Address, backend bind.ContractBackend) (*BallotContract, error) {
+	contract, err := bindBallotContract(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &BallotContract{BallotContractCaller: BallotContractCaller{contract: contract}, BallotContractTransactor: BallotContractTransactor{contract: contract}, BallotContractFilterer: BallotContractFilterer{contract: contract}}, nil
+}
+
+// NewBallotContractCaller creates a new read-only instance of BallotContract, bound to a specific deployed contract.
+func NewBallotContractCaller(address common.Address, caller bind.ContractCaller) (*BallotContractCaller, error) {
+	contract, err := bindBallotContract(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BallotContractCaller{contract: contract}, nil
+}
+
+// NewBallotContractTransactor creates a new write-only instance of BallotContract, bound to a specific deployed contract.
+func NewBallotContractTransactor(address common.Address, transactor bind.ContractTransactor) (*BallotContractTransactor, error) {
+	contract, err := bindBallotContract(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BallotContractTransactor{contract: contract}, nil
+}
+
+// NewBallotContractFilterer creates a new log filterer instance of BallotContract, bound to a specific deployed contract.
+func NewBallotContractFilterer(address common.Address, filterer bind.ContractFilterer) (*BallotContractFilterer, error) {
+	contract, err := bindBallotContract(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &BallotContractFilterer{contract: contract}, nil
+}
+
+// bindBallotContract binds a generic wrapper to an already deployed contract.
+func bindBallotContract(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(BallotContractABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_BallotContract *BallotContractRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _BallotContract.Contract.BallotContractCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_BallotContract *BallotContractRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BallotContract.Contract.BallotContractTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_BallotContract *BallotContractRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _BallotContract.Contract.BallotContractTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_BallotContract *BallotContractCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _BallotContract.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_BallotContract *BallotContractTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BallotContract.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_BallotContract *BallotContractTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _BallotContract.Contract.contract.Transact(opts, method, params...)
+}
+
+// End is a free data retrieval call binding the contract method 0xefbe1c1c.
+//
+// Solidity: function end() view returns(uint256)
+func (_BallotContract *BallotContractCaller) End(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _BallotContract.contract.Call(opts, &out, "end")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// End is a free data retrieval call binding the contract method 0xefbe1c1c.
+//
+// Solidity: function end() view returns(uint256)
+func (_BallotContract *BallotContractSession) End() (*big.Int, error) {
+	return _BallotContract.Contract.End(&_BallotContract.CallOpts)
+}
+
+// End is a free data retrieval call binding the contract method 0xefbe1c1c.
+//
+// Solidity: function end() view returns(uint256)
+func (_BallotContract *BallotContractCallerSession) End() (*big.Int, error) {
+	return _BallotContract.Contract.End(&_BallotContract.CallOpts)
+}
+
+// Finalized is a free data retrieval call binding the contract method 0xb3f05b97.
+//
+// Solidity: function finalized() view returns(bool)
+func (_BallotContract *BallotContractCaller) Finalized(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _BallotContract.contract.Call(opts, &out, "finalized")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Finalized is a free data retrieval call binding the contract method 0xb3f05b97.
+//
+// Solidity: function finalized() view returns(bool)
+func (_BallotContract *BallotContractSession) Finalized() (bool, error) {
+	return _BallotContract.Contract.Finalized(&_BallotContract.CallOpts)
+}
+
+// Finalized is a free data retrieval call binding the contract method 0xb3f05b97.
+//
+// Solidity: function finalized() view returns(bool)
+func (_BallotContract *BallotContractCallerSession) Finalized() (bool, error) {
+	return _BallotContract.Contract.Finalized(&_BallotContract.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_BallotContract *BallotContractCaller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _BallotContract.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_BallotContract *BallotContractSession) Name() (string, error) {
+	return _BallotContract.Contract.Name(&_BallotContract.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_BallotContract *BallotContractCallerSession) Name() (string, error) {
+	return _BallotContract.Contract.Name(&_BallotContract.CallOpts)
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x013cf08b.
+//
+// Solidity: function proposals(uint256 ) view returns(bytes32 name, uint256 votes)
+func (_BallotContract *BallotContractCaller) Proposals(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Name  [32]byte
+	Votes *big.Int
+}, error) {
+	var out []interface{}
+	err := _BallotContract.contract.Call(opts, &out, "proposals", arg0)
+
+	outstruct := new(struct {
+		Name  [32]byte
+		Votes *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Name = *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+	outstruct.Votes = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x013cf08b.
+//
+// Solidity: function proposals(uint256 ) view returns(bytes32 name, uint256 votes)
+func (_BallotContract *BallotContractSession) Proposals(arg0 *big.Int) (struct {
+	Name  [32]byte
+	Votes *big.Int
+}, error) {
+	return _BallotContract.Contract.Proposals(&_BallotContract.CallOpts, arg0)
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x013cf08b.
+//
+// Solidity: function proposals(uint256 ) view returns(bytes32 name, uint256 votes)
+func (_BallotContract *BallotContractCallerSession) Proposals(arg0 *big.Int) (struct {
+	Name  [32]byte
+	Votes *big.Int
+}, error) {
+	return _BallotContract.Contract.Proposals(&_BallotContract.CallOpts, arg0)
+}
+
+// ProposalsCount is a free data retrieval call binding the contract method 0x0a9f46ad.
+//
+// Solidity: function proposalsCount() view returns(uint256)
+func (_BallotContract *BallotContractCaller) ProposalsCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _BallotContract.contract.Call(opts, &out, "proposalsCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ProposalsCount is a free data retrieval call binding the contract method 0x0a9f46ad.
+//
+// Solidity: function proposalsCount() view returns(uint256)
+func (_BallotContract *BallotContractSession) ProposalsCount() (*big.Int, error) {
+	return _BallotContract.Contract.ProposalsCount(&_BallotContract.CallOpts)
+}
+
+// ProposalsCount is a free data retrieval call binding the contract method 0x0a9f46ad.
+//
+// Solidity: function proposalsCount() view returns(uint256)
+func (_BallotContract *BallotContractCallerSession) ProposalsCount() (*big.Int, error) {
+	return _BallotContract.Contract.ProposalsCount(&_BallotContract.CallOpts)
+}
+
+// Start is a free data retrieval call binding the contract method 0xbe9a6555.
+//
+// Solidity: function start() view returns(uint256)
+func (_BallotContract *BallotContractCaller) Start(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _BallotContract.contract.Call(opts, &out, "start")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Start is a free data retrieval call binding the contract method 0xbe9a6555.
+//
+// Solidity: function start() view returns(uint256)
+func (_BallotContract *BallotContractSession) Start() (*big.Int, error) {
+	return _BallotContract.Contract.Start(&_BallotContract.CallOpts)
+}
+
+// Start is a free data retrieval call binding the contract method 0xbe9a6555.
+//
+// Solidity: function start() view returns(uint256)
+func (_BallotContract *BallotContractCallerSession) Start() (*big.Int, error) {
+	return _BallotContract.Contract.Start(&_BallotContract.CallOpts)
+}
+
+// Url is a free data retrieval call binding the contract method 0x5600f04f.
+//
+// Solidity: function url() view returns(string)
+func (_BallotContract *BallotContractCaller) Url(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _BallotContract.contract.Call(opts, &out, "url")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Url is a free data retrieval call binding the contract method 0x5600f04f.
+//
+// Solidity: function url() view returns(string)
+func (_BallotContract *BallotContractSession) Url() (string, error) {
+	return _BallotContract.Contract.Url(&_BallotContract.CallOpts)
+}
+
+// Url is a free data retrieval call binding the contract method 0x5600f04f.
+//
+// Solidity: function url() view returns(string)
+func (_BallotContract *BallotContractCallerSession) Url() (string, error) {
+	return _BallotContract.Contract.Url(&_BallotContract.CallOpts)
+}
+
+// Voters is a free data retrieval call binding the contract method 0xa3ec138d.
+//
+// Solidity: function voters(address ) view returns(bool voted, uint256 vote)
+func (_BallotContract *BallotContractCaller) Voters(opts *bind.CallOpts, arg0 common.Address) (struct {
+	Voted bool
+	Vote  *big.Int
+}, error) {
+	var out []interface{}
+	err := _BallotContract.contract.Call(opts, &out, "voters", arg0)
+
+	outstruct := new(struct {
+		Voted bool
+		Vote  *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Voted = *abi.ConvertType(out[0], new(bool)).(*bool)
+	outstruct.Vote = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Voters is a free data retrieval call binding the contract method 0xa3ec138d.
+//
+// Solidity: function voters(address ) view returns(bool voted, uint256 vote)
+func (_BallotContract *BallotContractSession) Voters(arg0 common.Address) (struct {
+	Voted bool
+	Vote  *big.Int
+}, error) {
+	return _BallotContract.Contract.Voters(&_BallotContract.CallOpts, arg0)
+}
+
+// Voters is a free data retrieval call binding the contract method 0xa3ec138d.
+//
+// Solidity: function voters(address ) view returns(bool voted, uint256 vote)
+func (_BallotContract *BallotContractCallerSession) Voters(arg0 common.Address) (struct {
+	Voted bool
+	Vote  *big.Int
+}, error) {
+	return _BallotContract.Contract.Voters(&_BallotContract.CallOpts, arg0)
+}
+
+// Winner is a free data retrieval call binding the contract method 0xdfbf53ae.
+//
+// Solidity: function winner() view returns(uint256)
+func (_BallotContract *BallotContractCaller) Winner(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _BallotContract.contract.Call(opts, &out, "winner")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Winner is a free data retrieval call binding the contract method 0xdfbf53ae.
+//
+// Solidity: function winner() view returns(uint256)
+func (_BallotContract *BallotContractSession) Winner() (*big.Int, error) {
+	return _BallotContract.Contract.Winner(&_BallotContract.CallOpts)
+}
+
+// Winner is a free data retrieval call binding the contract method 0xdfbf53ae.
+//
+// Solidity: function winner() view returns(uint256)
+func (_BallotContract *BallotContractCallerSession) Winner() (*big.Int, error) {
+	return _BallotContract.Contract.Winner(&_BallotContract.CallOpts)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0x0121b93f.
+//
+// Solidity: function vote(uint256 proposal) returns()
+func (_BallotContract *BallotContractTransactor) Vote(opts *bind.TransactOpts, proposal *big.Int) (*types.Transaction, error) {
+	return _BallotContract.contract.Transact(opts, "vote", proposal)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0x0121b93f.
+//
+// Solidity: function vote(uint256 proposal) returns()
+func (_BallotContract *BallotContractSession) Vote(proposal *big.Int) (*types.Transaction, error) {
+	return _BallotContract.Contract.Vote(&_BallotContract.TransactOpts, proposal)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0x0121b93f.
+//
+// Solidity: function vote(uint256 proposal) returns()
+func (_BallotContract *BallotContractTransactorSession) Vote(proposal *big.Int) (*types.Transaction, error) {
+	return _BallotContract.Contract.Vote(&_BallotContract.TransactOpts, proposal)
+}
+
+// BallotContractVotedIterator is returned from FilterVoted and is used to iterate over the raw logs and unpacked data for Voted events raised by the BallotContract contract.
+type BallotContractVotedIterator struct {
+	Event *BallotContractVoted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BallotContractVotedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BallotContractVoted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BallotContractVoted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BallotContractVotedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BallotContractVotedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BallotContractVoted represents a Voted event raised by the BallotContract contract.
+type BallotContractVoted struct {
+	Voter    common.Address
+	Proposal *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterVoted is a free log retrieval operation binding the contract event 0x4d99b957a2bc29a30ebd96a7be8e68fe50a3c701db28a91436490b7d53870ca4.
+//
+// Solidity: event Voted(address indexed voter, uint256 indexed proposal)
+func (_BallotContract *BallotContractFilterer) FilterVoted(opts *bind.FilterOpts, voter []common.Address, proposal []*big.Int) (*BallotContractVotedIterator, error) {
+
+	var voterRule []interface{}
+	for _, voterItem := range voter {
+		voterRule = append(voterRule, voterItem)
+	}
+	var proposalRule []interface{}
+	for _, proposalItem := range proposal {
+		proposalRule = append(proposalRule, proposalItem)
+	}
+
+	logs, sub, err := _BallotContract.contract.FilterLogs(opts, "Voted", voterRule, proposalRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BallotContractVotedIterator{contract: _BallotContract.contract, event: "Voted", logs: logs, sub: sub}, nil
+}
+
+// WatchVoted is a free log subscription operation binding the contract event 0x4d99b957a2bc29a30ebd96a7be8e68fe50a3c701db28a91436490b7d53870ca4.
+//
+// Solidity: event Voted(address indexed voter, uint256 indexed proposal)
+func (_BallotContract *BallotContractFilterer) WatchVoted(opts *bind.WatchOpts, sink chan<- *BallotContractVoted, voter []common.Address, proposal []*big.Int) (event.Subscription, error) {
+
+	var voterRule []interface{}
+	for _, voterItem := range voter {
+		voterRule = append(voterRule, voterItem)
+	}
+	var proposalRule []interface{}
+	for _, proposalItem := range proposal {
+		proposalRule = append(proposalRule, proposalItem)
+	}
+
+	logs, sub, err := _BallotContract.contract.WatchLogs(opts, "Voted", voterRule, proposalRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BallotContractVoted)
+				if err := _BallotContract.contract.UnpackLog(event, "Voted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseVoted is a log parse operation binding the contract event 0x4d99b957a2bc29a30ebd96a7be8e68fe50a3c701db28a91436490b7d53870ca4.
+//
+// Solidity: event Voted(address indexed voter, uint256 indexed proposal)
+func (_BallotContract *BallotContractFilterer) ParseVoted(log types.Log) (*BallotContractVoted, error) {
+	event := new(BallotContractVoted)
+	if err := _BallotContract.contract.UnpackLog(event, "Voted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,203 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ErcSignatureValidatorABI is the input ABI used to generate the binding from.
+const ErcSignatureValidatorABI = "[{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_hash\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"_signature\",\"type\":\"bytes\"}],\"name\":\"isValidSignature\",\"outputs\":[{\"internalType\":\"bytes4\",\"name\":\"magicValue\",\"type\":\"bytes4\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// ErcSignatureValidator is an auto generated Go binding around an Ethereum contract.
+type ErcSignatureValidator struct {
+	ErcSignatureValidatorCaller     // Read-only binding to the contract
+	ErcSignatureValidatorTransactor // Write-only binding to the contract
+	ErcSignatureValidatorFilterer   // Log filterer for contract events
+}
+
+// ErcSignatureValidatorCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ErcSignatureValidatorCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ErcSignatureValidatorTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ErcSignatureValidatorTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ErcSignatureValidatorFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ErcSignatureValidatorFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ErcSignatureValidatorSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ErcSignatureValidatorSession struct {
+	Contract     *ErcSignatureValidator // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts          // Call options to use throughout this session
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// ErcSignatureValidatorCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ErcSignatureValidatorCallerSession struct {
+	Contract *ErcSignatureValidatorCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                // Call options to use throughout this session
+}
+
+// ErcSignatureValidatorTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ErcSignatureValidatorTransactorSession struct {
+	Contract     *ErcSignatureValidatorTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                // Transaction auth options to use throughout this session
+}
+
+// ErcSignatureValidatorRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ErcSignatureValidatorRaw struct {
+	Contract *ErcSignatureValidator // Generic contract binding to access the raw methods on
+}
+
+// ErcSignatureValidatorCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ErcSignatureValidatorCallerRaw struct {
+	Contract *ErcSignatureValidatorCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ErcSignatureValidatorTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ErcSignatureValidatorTransactorRaw struct {
+	Contract *ErcSignatureValidatorTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewErcSignatureValidator creates a new instance of ErcSignatureValidator, bound to a specific deployed contract.
+func NewErcSignatureValidator(address common.Address, backend bind.ContractBackend) (*ErcSignatureValidator, error) {
+	contract, err := bindErcSignatureValidator(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ErcSignatureValidator{ErcSignatureValidatorCaller: ErcSignatureValidatorCaller{contract: contract}, ErcSignatureValidatorTransactor: ErcSignatureValidatorTransactor{contract: contract}, ErcSignatureValidatorFilterer: ErcSignatureValidatorFilterer{contract: contract}}, nil
+}
+
+// NewErcSignatureValidatorCaller creates a new read-only instance of ErcSignatureValidator, bound to a specific deployed contract.
+func NewErcSignatureValidatorCaller(address common.Address, caller bind.ContractCaller) (*ErcSignatureValidatorCaller, error) {
+	contract, err := bindErcSignatureValidator(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ErcSignatureValidatorCaller{contract: contract}, nil
+}
+
+// NewErcSignatureValidatorTransactor creates a new write-only instance of ErcSignatureValidator, bound to a specific deployed contract.
+func NewErcSignatureValidatorTransactor(address common.Address, transactor bind.ContractTransactor) (*ErcSignatureValidatorTransactor, error) {
+	contract, err := bindErcSignatureValidator(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ErcSignatureValidatorTransactor{contract: contract}, nil
+}
+
+// NewErcSignatureValidatorFilterer creates a new log filterer instance of ErcSignatureValidator, bound to a specific deployed contract.
+func NewErcSignatureValidatorFilterer(address common.Address, filterer bind.ContractFilterer) (*ErcSignatureValidatorFilterer, error) {
+	contract, err := bindErcSignatureValidator(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ErcSignatureValidatorFilterer{contract: contract}, nil
+}
+
+// bindErcSignatureValidator binds a generic wrapper to an already deployed contract.
+func bindErcSignatureValidator(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ErcSignatureValidatorABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ErcSignatureValidator *ErcSignatureValidatorRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ErcSignatureValidator.Contract.ErcSignatureValidatorCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ErcSignatureValidator *ErcSignatureValidatorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ErcSignatureValidator.Contract.ErcSignatureValidatorTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ErcSignatureValidator *ErcSignatureValidatorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ErcSignatureValidator.Contract.ErcSignatureValidatorTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ErcSignatureValidator *ErcSignatureValidatorCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ErcSignatureValidator.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ErcSignatureValidator *ErcSignatureValidatorTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ErcSignatureValidator.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ErcSignatureValidator *ErcSignatureValidatorTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ErcSignatureValidator.Contract.contract.Transact(opts, method, params...)
+}
+
+// IsValidSignature is a free data retrieval call binding the contract method 0x1626ba7e.
+//
+// Solidity: function isValidSignature(bytes32 _hash, bytes _signature) view returns(bytes4 magicValue)
+func (_ErcSignatureValidator *ErcSignatureValidatorCaller) IsValidSignature(opts *bind.CallOpts, _hash [32]byte, _signature []byte) ([4]byte, error) {
+	var out []interface{}
+	err := _ErcSignatureValidator.contract.Call(opts, &out, "isValidSignature", _hash, _signature)
+
+	if err != nil {
+		return *new([4]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([4]byte)).(*[4]byte)
+
+	return out0, err
+
+}
+
+// IsValidSignature is a free data retrieval call binding the contract method 0x1626ba7e.
+//
+// Solidity: function isValidSignature(bytes32 _hash, bytes _signature) view returns(bytes4 magicValue)
+func (_ErcSignatureValidator *ErcSignatureValidatorSession) IsValidSignature(_hash [32]byte, _signature []byte) ([4]byte, error) {
+	return _ErcSignatureValidator.Contract.IsValidSignature(&_ErcSignatureValidator.CallOpts, _hash, _signature)
+}
+
+// IsValidSignature is a free data retrieval call binding the contract method 0x1626ba7e.
+//
+// Solidity: function isValidSignature(bytes32 _hash, bytes _signature) view returns(bytes4 magicValue)
+func (_ErcSignatureValidator *ErcSignatureValidatorCallerSession) IsValidSignature(_hash [32]byte, _signature []byte) ([4]byte, error) {
+	return _ErcSignatureValidator.Contract.IsValidSignature(&_ErcSignatureValidator.CallOpts, _hash, _signature)
+}
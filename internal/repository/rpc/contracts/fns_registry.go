@@ -0,0 +1,296 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// FnsRegistryABI is the input ABI used to generate the binding from.
+const FnsRegistryABI = "[{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"}],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"}],\"name\":\"resolver\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"}],\"name\":\"ttl\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"}],\"name\":\"recordExists\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// FnsRegistry is an auto generated Go binding around an Ethereum contract.
+type FnsRegistry struct {
+	FnsRegistryCaller     // Read-only binding to the contract
+	FnsRegistryTransactor // Write-only binding to the contract
+	FnsRegistryFilterer   // Log filterer for contract events
+}
+
+// FnsRegistryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type FnsRegistryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FnsRegistryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type FnsRegistryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FnsRegistryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type FnsRegistryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FnsRegistrySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type FnsRegistrySession struct {
+	Contract     *FnsRegistry      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// FnsRegistryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type FnsRegistryCallerSession struct {
+	Contract *FnsRegistryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// FnsRegistryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type FnsRegistryTransactorSession struct {
+	Contract     *FnsRegistryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// FnsRegistryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type FnsRegistryRaw struct {
+	Contract *FnsRegistry // Generic contract binding to access the raw methods on
+}
+
+// FnsRegistryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type FnsRegistryCallerRaw struct {
+	Contract *FnsRegistryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// FnsRegistryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type FnsRegistryTransactorRaw struct {
+	Contract *FnsRegistryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewFnsRegistry creates a new instance of FnsRegistry, bound to a specific deployed contract.
+func NewFnsRegistry(address common.Address, backend bind.ContractBackend) (*FnsRegistry, error) {
+	contract, err := bindFnsRegistry(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &FnsRegistry{FnsRegistryCaller: FnsRegistryCaller{contract: contract}, FnsRegistryTransactor: FnsRegistryTransactor{contract: contract}, FnsRegistryFilterer: FnsRegistryFilterer{contract: contract}}, nil
+}
+
+// NewFnsRegistryCaller creates a new read-only instance of FnsRegistry, bound to a specific deployed contract.
+func NewFnsRegistryCaller(address common.Address, caller bind.ContractCaller) (*FnsRegistryCaller, error) {
+	contract, err := bindFnsRegistry(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FnsRegistryCaller{contract: contract}, nil
+}
+
+// NewFnsRegistryTransactor creates a new write-only instance of FnsRegistry, bound to a specific deployed contract.
+func NewFnsRegistryTransactor(address common.Address, transactor bind.ContractTransactor) (*FnsRegistryTransactor, error) {
+	contract, err := bindFnsRegistry(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FnsRegistryTransactor{contract: contract}, nil
+}
+
+// NewFnsRegistryFilterer creates a new log filterer instance of FnsRegistry, bound to a specific deployed contract.
+func NewFnsRegistryFilterer(address common.Address, filterer bind.ContractFilterer) (*FnsRegistryFilterer, error) {
+	contract, err := bindFnsRegistry(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &FnsRegistryFilterer{contract: contract}, nil
+}
+
+// bindFnsRegistry binds a generic wrapper to an already deployed contract.
+func bindFnsRegistry(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(FnsRegistryABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FnsRegistry *FnsRegistryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FnsRegistry.Contract.FnsRegistryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FnsRegistry *FnsRegistryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FnsRegistry.Contract.FnsRegistryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FnsRegistry *FnsRegistryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FnsRegistry.Contract.FnsRegistryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FnsRegistry *FnsRegistryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FnsRegistry.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FnsRegistry *FnsRegistryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FnsRegistry.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FnsRegistry *FnsRegistryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FnsRegistry.Contract.contract.Transact(opts, method, params...)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x02571be3.
+//
+// Solidity: function owner(bytes32 node) view returns(address)
+func (_FnsRegistry *FnsRegistryCaller) Owner(opts *bind.CallOpts, node [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _FnsRegistry.contract.Call(opts, &out, "owner", node)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x02571be3.
+//
+// Solidity: function owner(bytes32 node) view returns(address)
+func (_FnsRegistry *FnsRegistrySession) Owner(node [32]byte) (common.Address, error) {
+	return _FnsRegistry.Contract.Owner(&_FnsRegistry.CallOpts, node)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x02571be3.
+//
+// Solidity: function owner(bytes32 node) view returns(address)
+func (_FnsRegistry *FnsRegistryCallerSession) Owner(node [32]byte) (common.Address, error) {
+	return _FnsRegistry.Contract.Owner(&_FnsRegistry.CallOpts, node)
+}
+
+// RecordExists is a free data retrieval call binding the contract method 0xf79fe538.
+//
+// Solidity: function recordExists(bytes32 node) view returns(bool)
+func (_FnsRegistry *FnsRegistryCaller) RecordExists(opts *bind.CallOpts, node [32]byte) (bool, error) {
+	var out []interface{}
+	err := _FnsRegistry.contract.Call(opts, &out, "recordExists", node)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// RecordExists is a free data retrieval call binding the contract method 0xf79fe538.
+//
+// Solidity: function recordExists(bytes32 node) view returns(bool)
+func (_FnsRegistry *FnsRegistrySession) RecordExists(node [32]byte) (bool, error) {
+	return _FnsRegistry.Contract.RecordExists(&_FnsRegistry.CallOpts, node)
+}
+
+// RecordExists is a free data retrieval call binding the contract method 0xf79fe538.
+//
+// Solidity: function recordExists(bytes32 node) view returns(bool)
+func (_FnsRegistry *FnsRegistryCallerSession) RecordExists(node [32]byte) (bool, error) {
+	return _FnsRegistry.Contract.RecordExists(&_FnsRegistry.CallOpts, node)
+}
+
+// Resolver is a free data retrieval call binding the contract method 0x0178b8bf.
+//
+// Solidity: function resolver(bytes32 node) view returns(address)
+func (_FnsRegistry *FnsRegistryCaller) Resolver(opts *bind.CallOpts, node [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _FnsRegistry.contract.Call(opts, &out, "resolver", node)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Resolver is a free data retrieval call binding the contract method 0x0178b8bf.
+//
+// Solidity: function resolver(bytes32 node) view returns(address)
+func (_FnsRegistry *FnsRegistrySession) Resolver(node [32]byte) (common.Address, error) {
+	return _FnsRegistry.Contract.Resolver(&_FnsRegistry.CallOpts, node)
+}
+
+// Resolver is a free data retrieval call binding the contract method 0x0178b8bf.
+//
+// Solidity: function resolver(bytes32 node) view returns(address)
+func (_FnsRegistry *FnsRegistryCallerSession) Resolver(node [32]byte) (common.Address, error) {
+	return _FnsRegistry.Contract.Resolver(&_FnsRegistry.CallOpts, node)
+}
+
+// Ttl is a free data retrieval call binding the contract method 0x16a25cbd.
+//
+// Solidity: function ttl(bytes32 node) view returns(uint64)
+func (_FnsRegistry *FnsRegistryCaller) Ttl(opts *bind.CallOpts, node [32]byte) (uint64, error) {
+	var out []interface{}
+	err := _FnsRegistry.contract.Call(opts, &out, "ttl", node)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// Ttl is a free data retrieval call binding the contract method 0x16a25cbd.
+//
+// Solidity: function ttl(bytes32 node) view returns(uint64)
+func (_FnsRegistry *FnsRegistrySession) Ttl(node [32]byte) (uint64, error) {
+	return _FnsRegistry.Contract.Ttl(&_FnsRegistry.CallOpts, node)
+}
+
+// Ttl is a free data retrieval call binding the contract method 0x16a25cbd.
+//
+// Solidity: function ttl(bytes32 node) view returns(uint64)
+func (_FnsRegistry *FnsRegistryCallerSession) Ttl(node [32]byte) (uint64, error) {
+	return _FnsRegistry.Contract.Ttl(&_FnsRegistry.CallOpts, node)
+}
@@ -0,0 +1,265 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// FnsResolverABI is the input ABI used to generate the binding from.
+const FnsResolverABI = "[{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"}],\"name\":\"addr\",\"outputs\":[{\"internalType\":\"addresspayable\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"}],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"interfaceID\",\"type\":\"bytes4\"}],\"name\":\"supportsInterface\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// FnsResolver is an auto generated Go binding around an Ethereum contract.
+type FnsResolver struct {
+	FnsResolverCaller     // Read-only binding to the contract
+	FnsResolverTransactor // Write-only binding to the contract
+	FnsResolverFilterer   // Log filterer for contract events
+}
+
+// FnsResolverCaller is an auto generated read-only Go binding around an Ethereum contract.
+type FnsResolverCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FnsResolverTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type FnsResolverTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FnsResolverFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type FnsResolverFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FnsResolverSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type FnsResolverSession struct {
+	Contract     *FnsResolver      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// FnsResolverCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type FnsResolverCallerSession struct {
+	Contract *FnsResolverCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// FnsResolverTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type FnsResolverTransactorSession struct {
+	Contract     *FnsResolverTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// FnsResolverRaw is an auto generated low-level Go binding around an Ethereum contract.
+type FnsResolverRaw struct {
+	Contract *FnsResolver // Generic contract binding to access the raw methods on
+}
+
+// FnsResolverCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type FnsResolverCallerRaw struct {
+	Contract *FnsResolverCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// FnsResolverTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type FnsResolverTransactorRaw struct {
+	Contract *FnsResolverTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewFnsResolver creates a new instance of FnsResolver, bound to a specific deployed contract.
+func NewFnsResolver(address common.Address, backend bind.ContractBackend) (*FnsResolver, error) {
+	contract, err := bindFnsResolver(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &FnsResolver{FnsResolverCaller: FnsResolverCaller{contract: contract}, FnsResolverTransactor: FnsResolverTransactor{contract: contract}, FnsResolverFilterer: FnsResolverFilterer{contract: contract}}, nil
+}
+
+// NewFnsResolverCaller creates a new read-only instance of FnsResolver, bound to a specific deployed contract.
+func NewFnsResolverCaller(address common.Address, caller bind.ContractCaller) (*FnsResolverCaller, error) {
+	contract, err := bindFnsResolver(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FnsResolverCaller{contract: contract}, nil
+}
+
+// NewFnsResolverTransactor creates a new write-only instance of FnsResolver, bound to a specific deployed contract.
+func NewFnsResolverTransactor(address common.Address, transactor bind.ContractTransactor) (*FnsResolverTransactor, error) {
+	contract, err := bindFnsResolver(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FnsResolverTransactor{contract: contract}, nil
+}
+
+// NewFnsResolverFilterer creates a new log filterer instance of FnsResolver, bound to a specific deployed contract.
+func NewFnsResolverFilterer(address common.Address, filterer bind.ContractFilterer) (*FnsResolverFilterer, error) {
+	contract, err := bindFnsResolver(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &FnsResolverFilterer{contract: contract}, nil
+}
+
+// bindFnsResolver binds a generic wrapper to an already deployed contract.
+func bindFnsResolver(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(FnsResolverABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FnsResolver *FnsResolverRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FnsResolver.Contract.FnsResolverCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FnsResolver *FnsResolverRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FnsResolver.Contract.FnsResolverTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FnsResolver *FnsResolverRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FnsResolver.Contract.FnsResolverTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FnsResolver *FnsResolverCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FnsResolver.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FnsResolver *FnsResolverTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FnsResolver.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FnsResolver *FnsResolverTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FnsResolver.Contract.contract.Transact(opts, method, params...)
+}
+
+// Addr is a free data retrieval call binding the contract method 0x3b3b57de.
+//
+// Solidity: function addr(bytes32 node) view returns(address)
+func (_FnsResolver *FnsResolverCaller) Addr(opts *bind.CallOpts, node [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _FnsResolver.contract.Call(opts, &out, "addr", node)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Addr is a free data retrieval call binding the contract method 0x3b3b57de.
+//
+// Solidity: function addr(bytes32 node) view returns(address)
+func (_FnsResolver *FnsResolverSession) Addr(node [32]byte) (common.Address, error) {
+	return _FnsResolver.Contract.Addr(&_FnsResolver.CallOpts, node)
+}
+
+// Addr is a free data retrieval call binding the contract method 0x3b3b57de.
+//
+// Solidity: function addr(bytes32 node) view returns(address)
+func (_FnsResolver *FnsResolverCallerSession) Addr(node [32]byte) (common.Address, error) {
+	return _FnsResolver.Contract.Addr(&_FnsResolver.CallOpts, node)
+}
+
+// Name is a free data retrieval call binding the contract method 0x691f3431.
+//
+// Solidity: function name(bytes32 node) view returns(string)
+func (_FnsResolver *FnsResolverCaller) Name(opts *bind.CallOpts, node [32]byte) (string, error) {
+	var out []interface{}
+	err := _FnsResolver.contract.Call(opts, &out, "name", node)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x691f3431.
+//
+// Solidity: function name(bytes32 node) view returns(string)
+func (_FnsResolver *FnsResolverSession) Name(node [32]byte) (string, error) {
+	return _FnsResolver.Contract.Name(&_FnsResolver.CallOpts, node)
+}
+
+// Name is a free data retrieval call binding the contract method 0x691f3431.
+//
+// Solidity: function name(bytes32 node) view returns(string)
+func (_FnsResolver *FnsResolverCallerSession) Name(node [32]byte) (string, error) {
+	return _FnsResolver.Contract.Name(&_FnsResolver.CallOpts, node)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceID) view returns(bool)
+func (_FnsResolver *FnsResolverCaller) SupportsInterface(opts *bind.CallOpts, interfaceID [4]byte) (bool, error) {
+	var out []interface{}
+	err := _FnsResolver.contract.Call(opts, &out, "supportsInterface", interfaceID)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceID) view returns(bool)
+func (_FnsResolver *FnsResolverSession) SupportsInterface(interfaceID [4]byte) (bool, error) {
+	return _FnsResolver.Contract.SupportsInterface(&_FnsResolver.CallOpts, interfaceID)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceID) view returns(bool)
+func (_FnsResolver *FnsResolverCallerSession) SupportsInterface(interfaceID [4]byte) (bool, error) {
+	return _FnsResolver.Contract.SupportsInterface(&_FnsResolver.CallOpts, interfaceID)
+}
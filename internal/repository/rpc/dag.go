@@ -0,0 +1,34 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AtroposCreationTime loads the creation time of the Atropos event of the block
+// with the given hash in nanoseconds; the block hash is the Atropos event id.
+func (ftm *FtmBridge) AtroposCreationTime(block *common.Hash) (uint64, error) {
+	var evt struct {
+		CreationTime hexutil.Uint64 `json:"creationTime"`
+	}
+
+	// call for data
+	if err := ftm.call(&evt, "dag_getEvent", block.Hex()); err != nil {
+		ftm.log.Debugf("atropos of block %s not available; %s", block.String(), err.Error())
+		return 0, err
+	}
+	return uint64(evt.CreationTime), nil
+}
@@ -18,7 +18,6 @@ package rpc
 //go:generate tools/abigen.sh --abi ./contracts/abi/defi-fmint-reward-distribution.abi --pkg contracts --type FMintRewardsDistribution --out ./contracts/fmint_rewards.go
 
 import (
-	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -62,7 +61,7 @@ func (ftm *FtmBridge) FMintAccount(owner *common.Address) (*types.FMintAccount,
 // FMintPoolBalance loads balance of an fMint token from the given pool contract.
 func (ftm *FtmBridge) FMintPoolBalance(pool *contracts.DeFiTokenStorage, owner *common.Address, token *common.Address) (hexutil.Big, error) {
 	// get the collateral token balance
-	val, err := pool.BalanceOf(nil, *owner, *token)
+	val, err := pool.BalanceOf(ftm.callOpts(), *owner, *token)
 	if err != nil {
 		ftm.log.Debugf("pool balance failed on token %s, account %s; %s", token.String(), owner.String(), err.Error())
 		return hexutil.Big{}, err
@@ -131,7 +130,7 @@ func (ftm *FtmBridge) FMintTokenTotalBalance(token *common.Address, tp types.Def
 	}
 
 	// get the collateral token balance
-	val, err := pool.TotalBalance(nil, *token)
+	val, err := pool.TotalBalance(ftm.callOpts(), *token)
 	if err != nil {
 		ftm.log.Debugf("pool total balance failed on token %s", token.String(), err.Error())
 		return hexutil.Big{}, err
@@ -176,7 +175,7 @@ func (ftm *FtmBridge) FMintTokenPrice(token *common.Address) (hexutil.Big, error
 	}
 
 	// get the price for the given token from oracle
-	val, err := oracle.GetPrice(nil, *token)
+	val, err := oracle.GetPrice(ftm.callOpts(), *token)
 	if err != nil {
 		ftm.log.Errorf("price not available for token %s; %s", token.String(), err.Error())
 		return hexutil.Big{}, nil
@@ -200,14 +199,14 @@ func (ftm *FtmBridge) fMintAccountValue(owner common.Address) (hexutil.Big, hexu
 	}
 
 	// get joined collateral value
-	cValue, err := contract.CollateralValueOf(nil, owner, common.Address{}, new(big.Int))
+	cValue, err := contract.CollateralValueOf(ftm.callOpts(), owner, common.Address{}, new(big.Int))
 	if err != nil {
 		ftm.log.Errorf("joined collateral value loader failed")
 		return hexutil.Big{}, hexutil.Big{}, err
 	}
 
 	// get joined debt value
-	dValue, err := contract.DebtValueOf(nil, owner, common.Address{}, new(big.Int))
+	dValue, err := contract.DebtValueOf(ftm.callOpts(), owner, common.Address{}, new(big.Int))
 	if err != nil {
 		ftm.log.Errorf("joined debt value loader failed")
 		return hexutil.Big{}, hexutil.Big{}, err
@@ -238,7 +237,7 @@ func (ftm *FtmBridge) FMintRewardsEarned(addr *common.Address) (hexutil.Big, err
 		Pending:     false,
 		From:        *addr,
 		BlockNumber: bl.ToInt(),
-		Context:     context.Background(),
+		Context:     ftm.callContext(),
 	}
 
 	// get the rewards
@@ -261,7 +260,7 @@ func (ftm *FtmBridge) FMintRewardsStashed(addr *common.Address) (hexutil.Big, er
 	}
 
 	// get the rewards
-	rw, err := contract.RewardStash(nil, *addr)
+	rw, err := contract.RewardStash(ftm.callOpts(), *addr)
 	if err != nil {
 		ftm.log.Errorf("can not calculate stashed rewards; %s", err.Error())
 		return hexutil.Big{}, err
@@ -281,7 +280,7 @@ func (ftm *FtmBridge) FMintCanClaimRewards(addr *common.Address) (bool, error) {
 	}
 
 	// ask if the claim is possible
-	flag, err := contract.RewardCanClaim(nil, *addr)
+	flag, err := contract.RewardCanClaim(ftm.callOpts(), *addr)
 	if err != nil {
 		ftm.log.Errorf("can not check rewards claim flag; %s", err.Error())
 		return false, err
@@ -302,7 +301,7 @@ func (ftm *FtmBridge) FMintCanReceiveRewards(addr *common.Address) (bool, error)
 	}
 
 	// ask if the claim is possible
-	flag, err := contract.RewardIsEligible(nil, *addr)
+	flag, err := contract.RewardIsEligible(ftm.callOpts(), *addr)
 	if err != nil {
 		ftm.log.Errorf("can not check rewards eligibility flag; %s", err.Error())
 		return false, err
@@ -321,7 +320,7 @@ func (ftm *FtmBridge) FMintCanPushRewards() (bool, error) {
 	}
 
 	// get the last time rewards were pushed
-	lastPush, err := contract.LastRewardPush(nil)
+	lastPush, err := contract.LastRewardPush(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("can not check rewards last push; %s", err.Error())
 		return false, err
@@ -336,3 +335,47 @@ func (ftm *FtmBridge) FMintCanPushRewards() (bool, error) {
 	// @todo Check the amount of rewards available so we know that it will push.
 	return true, nil
 }
+
+// FMintRewardsSchedule resolves the state of the rewards distribution
+// relevant to the rewards claiming schedule of the given fMint account.
+func (ftm *FtmBridge) FMintRewardsSchedule(addr *common.Address) (*types.FMintRewardsSchedule, error) {
+	// connect the contract
+	contract, err := ftm.fMintCfg.fMintRewardsDistribution()
+	if err != nil {
+		return nil, err
+	}
+
+	// prep the container
+	rs := types.FMintRewardsSchedule{Address: *addr}
+
+	// prep to load distribution values
+	loaders := tConfigItemsLoaders{
+		&rs.EpochLength:     contract.RewardEpochLength,
+		&rs.EpochEnds:       contract.RewardEpochEnds,
+		&rs.LastPush:        contract.LastRewardPush,
+		&rs.MinPushInterval: contract.MinRewardPushInterval,
+		&rs.RewardRate:      contract.RewardRate,
+		&rs.Updated:         contract.RewardUpdated,
+		&rs.PrincipalTotal:  contract.PrincipalBalance,
+		&rs.Principal: func(opts *bind.CallOpts) (*big.Int, error) {
+			return contract.PrincipalBalanceOf(opts, *addr)
+		},
+	}
+
+	// load all the values
+	if err := ftm.pullSetOfDefiConfigValues(loaders); err != nil {
+		ftm.log.Errorf("can not pull rewards schedule of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	// load the earned rewards
+	if rs.Earned, err = ftm.FMintRewardsEarned(addr); err != nil {
+		return nil, err
+	}
+
+	// can the rewards be claimed?
+	if rs.CanClaim, err = ftm.FMintCanClaimRewards(addr); err != nil {
+		return nil, err
+	}
+	return &rs, nil
+}
@@ -41,11 +41,12 @@ type fMintConfig struct {
 	// of the fMint Address Provider contract
 	addressProvider common.Address
 
-	// contracts represents lazy-loaded addresses
-	contracts sync.Map
+	// contracts represents lazy-loaded addresses;
+	// shared by the copies of the configuration bound to bridge copies
+	contracts *sync.Map
 
 	// use request group to handle contracts address resolution
-	requestGroup singleflight.Group
+	requestGroup *singleflight.Group
 }
 
 // tokenRegistryContract returns an instance of the fMint TokenRegistry
@@ -215,7 +216,7 @@ func (fmc *fMintConfig) loadAddress(name string) (*common.Address, error) {
 	copy(id[:], name)
 
 	// try to get the address
-	addr, err := ap.GetAddress(nil, id)
+	addr, err := ap.GetAddress(fmc.bridge.callOpts(), id)
 	if err != nil {
 		fmc.bridge.log.Errorf("[%s] can not get address of %s; %s", fmc.addressProvider.String(), name, err.Error())
 		return nil, err
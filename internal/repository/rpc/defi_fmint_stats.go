@@ -0,0 +1,116 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// FMintMinterAddress returns the address of the fMint minter contract,
+// or an empty address if the address is not available.
+func (ftm *FtmBridge) FMintMinterAddress() common.Address {
+	return ftm.fMintCfg.mustContractAddress(fMintAddressMinter)
+}
+
+// FMintPoolTotals loads the total amounts of the tokens locked in the fMint pool
+// of the given type along with their value and the total value of the pool in fUSD.
+func (ftm *FtmBridge) FMintPoolTotals(tp types.DefiTokenType) ([]types.FMintTokenTotal, hexutil.Big, error) {
+	pool, err := ftm.fMintPool(tp)
+	if err != nil {
+		return nil, hexutil.Big{}, err
+	}
+
+	// collect the totals of the tokens
+	list, err := ftm.fMintPoolTokens(pool, func(tok common.Address) (*big.Int, error) {
+		return pool.TotalBalance(ftm.callOpts(), tok)
+	})
+	if err != nil {
+		return nil, hexutil.Big{}, err
+	}
+
+	// the total value of the pool
+	total, err := pool.Total(ftm.callOpts())
+	if err != nil {
+		ftm.log.Errorf("can not load pool total value; %s", err.Error())
+		return nil, hexutil.Big{}, err
+	}
+	if total == nil {
+		total = new(big.Int)
+	}
+	return list, hexutil.Big(*total), nil
+}
+
+// FMintAccountPositions loads the amounts of the tokens of the given account
+// locked in the fMint pool of the given type along with their value in fUSD.
+func (ftm *FtmBridge) FMintAccountPositions(owner *common.Address, tp types.DefiTokenType) ([]types.FMintTokenTotal, error) {
+	pool, err := ftm.fMintPool(tp)
+	if err != nil {
+		return nil, err
+	}
+
+	return ftm.fMintPoolTokens(pool, func(tok common.Address) (*big.Int, error) {
+		return pool.BalanceOf(ftm.callOpts(), *owner, tok)
+	})
+}
+
+// fMintPool provides the fMint token storage pool of the given type.
+func (ftm *FtmBridge) fMintPool(tp types.DefiTokenType) (*contracts.DeFiTokenStorage, error) {
+	switch tp {
+	case types.DefiTokenTypeCollateral:
+		return ftm.fMintCfg.fMintCollateralPool()
+	case types.DefiTokenTypeDebt:
+		return ftm.fMintCfg.fMintDebtPool()
+	}
+	return nil, fmt.Errorf("unknown token pool type %s", tp)
+}
+
+// fMintPoolTokens collects the non-zero amounts of the tokens known to the pool
+// using the given amount loader, along with their value in fUSD.
+func (ftm *FtmBridge) fMintPoolTokens(pool *contracts.DeFiTokenStorage, amountOf func(common.Address) (*big.Int, error)) ([]types.FMintTokenTotal, error) {
+	// get the list of tokens known to the pool
+	tokens, err := ftm.defiTokenAddressList(pool.TokensCount, pool.Tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]types.FMintTokenTotal, 0, len(tokens))
+	for _, tok := range tokens {
+		amount, err := amountOf(tok)
+		if err != nil {
+			ftm.log.Errorf("can not load pool amount of token %s; %s", tok.String(), err.Error())
+			return nil, err
+		}
+
+		// skip tokens not present
+		if amount == nil || amount.Sign() == 0 {
+			continue
+		}
+
+		value, err := pool.TokenValue(ftm.callOpts(), tok, amount)
+		if err != nil {
+			ftm.log.Errorf("can not load pool value of token %s; %s", tok.String(), err.Error())
+			return nil, err
+		}
+		if value == nil {
+			value = new(big.Int)
+		}
+		list = append(list, types.FMintTokenTotal{Token: tok, Amount: hexutil.Big(*amount), Value: hexutil.Big(*value)})
+	}
+	return list, nil
+}
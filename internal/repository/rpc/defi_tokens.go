@@ -92,7 +92,7 @@ func (ftm *FtmBridge) defiTokenAddressList(
 // defiTokenDetail loads details of a token specified by the token address.
 func (ftm *FtmBridge) defiTokenDetail(contract *contracts.DefiFMintTokenRegistry, token *common.Address) (*types.DefiToken, error) {
 	// get the token details
-	tk, err := contract.Tokens(nil, *token)
+	tk, err := contract.Tokens(ftm.callOpts(), *token)
 	if err != nil {
 		ftm.log.Errorf("token %s not found; %s", token.String(), err.Error())
 		return nil, err
@@ -166,6 +166,7 @@ func decodeToken(addr *common.Address, tk struct {
 		LogoUrl:       tk.Logo,
 		Decimals:      int32(tk.Decimals),
 		PriceDecimals: int32(tk.PriceDecimals),
+		Oracle:        tk.Oracle,
 		IsActive:      tk.IsActive,
 		CanDeposit:    tk.CanDeposit,
 		CanMint:       tk.CanMint,
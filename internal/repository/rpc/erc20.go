@@ -38,7 +38,7 @@ func (ftm *FtmBridge) Erc20Name(token *common.Address) (string, error) {
 	}
 
 	// get the token name
-	name, err := contract.Name(nil)
+	name, err := contract.Name(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("ERC20 token %s name not available; %s", token.String(), err.Error())
 		return "", err
@@ -57,7 +57,7 @@ func (ftm *FtmBridge) Erc20Symbol(token *common.Address) (string, error) {
 	}
 
 	// get the token name
-	symbol, err := contract.Symbol(nil)
+	symbol, err := contract.Symbol(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("ERC20 token %s symbol not available; %s", token.String(), err.Error())
 		return "", err
@@ -76,7 +76,7 @@ func (ftm *FtmBridge) Erc20Decimals(token *common.Address) (int32, error) {
 	}
 
 	// get the token name
-	deci, err := contract.Decimals(nil)
+	deci, err := contract.Decimals(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("ERC20 token %s decimals not available; %s", token.String(), err.Error())
 		return 0, nil
@@ -96,7 +96,7 @@ func (ftm *FtmBridge) Erc20BalanceOf(token *common.Address, owner *common.Addres
 	}
 
 	// get the balance
-	val, err := contract.BalanceOf(nil, *owner)
+	val, err := contract.BalanceOf(ftm.callOpts(), *owner)
 	if err != nil {
 		ftm.log.Errorf("can not ERC20 %s balance for %s; %s", token.String(), owner.String(), err.Error())
 		return hexutil.Big{}, err
@@ -114,6 +114,49 @@ func (ftm *FtmBridge) Erc20BalanceOf(token *common.Address, owner *common.Addres
 	return hexutil.Big(*val), nil
 }
 
+// Erc20BalancesOf loads the current available balances of the given list of ERC20 tokens
+// for the identified owner address. Balances are read in batches using the multicall
+// contract, if available; tokens failing to provide the balance report zero.
+func (ftm *FtmBridge) Erc20BalancesOf(tokens []common.Address, owner *common.Address) ([]hexutil.Big, error) {
+	// prep the calls
+	calls := make([]mcCall, len(tokens))
+	for i, token := range tokens {
+		calls[i] = mcCall{Target: token, Abi: contracts.ERCTwentyABI, Method: "balanceOf", Args: []interface{}{*owner}}
+	}
+
+	// batch the calls; fall back to sequential calls without multicall
+	res, err := ftm.multicall(calls, nil)
+	if err == ErrMulticallNotAvailable {
+		return ftm.erc20BalancesOfSeq(tokens, owner), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// collect the balances
+	list := make([]hexutil.Big, len(tokens))
+	for i, r := range res {
+		if r.Err != nil || len(r.Out) == 0 {
+			continue
+		}
+		if val, ok := r.Out[0].(*big.Int); ok && val != nil {
+			list[i] = hexutil.Big(*val)
+		}
+	}
+	return list, nil
+}
+
+// erc20BalancesOfSeq loads the balances of the given list of ERC20 tokens one by one.
+func (ftm *FtmBridge) erc20BalancesOfSeq(tokens []common.Address, owner *common.Address) []hexutil.Big {
+	list := make([]hexutil.Big, len(tokens))
+	for i := range tokens {
+		if val, err := ftm.Erc20BalanceOf(&tokens[i], owner); err == nil {
+			list[i] = val
+		}
+	}
+	return list
+}
+
 // Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
 // contract by the token owner.
 func (ftm *FtmBridge) Erc20Allowance(token *common.Address, owner *common.Address, spender *common.Address) (hexutil.Big, error) {
@@ -131,7 +174,7 @@ func (ftm *FtmBridge) Erc20Allowance(token *common.Address, owner *common.Addres
 	}
 
 	// get the amount of tokens allowed for DeFi
-	val, err := contract.Allowance(nil, *owner, *spender)
+	val, err := contract.Allowance(ftm.callOpts(), *owner, *spender)
 	if err != nil {
 		ftm.log.Errorf("can not get defi ERC20 %s allowance for %s; %s", token.String(), owner.String(), err.Error())
 		return hexutil.Big{}, err
@@ -159,7 +202,7 @@ func (ftm *FtmBridge) Erc20TotalSupply(token *common.Address) (hexutil.Big, erro
 	}
 
 	// get the amount of tokens allowed for DeFi
-	val, err := contract.TotalSupply(nil)
+	val, err := contract.TotalSupply(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("can not get defi ERC20 %s total supply; %s", token.String(), err.Error())
 		return hexutil.Big{}, err
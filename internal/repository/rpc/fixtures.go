@@ -0,0 +1,227 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	// fixturesRecord is the fixtures mode recording node responses.
+	fixturesRecord = "record"
+
+	// fixturesReplay is the fixtures mode replaying recorded node responses.
+	fixturesReplay = "replay"
+)
+
+// fixtureMessage represents a JSON-RPC message exchanged with the node.
+type fixtureMessage struct {
+	Version string          `json:"jsonrpc,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   json.RawMessage `json:"error,omitempty"`
+}
+
+// fixture represents a recorded node response to a call.
+type fixture struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+// fixtureTransport implements HTTP transport of the node calls recording
+// node responses into a fixtures file, or replaying them from the file.
+// Calls are matched by the method and the parameters, the latest recorded
+// response is used if the same call was made several times.
+type fixtureTransport struct {
+	mode string
+	path string
+	next http.RoundTripper
+	log  logger.Logger
+
+	mu   sync.Mutex
+	data map[string]fixture
+}
+
+// newFixtureTransport creates a new fixtures transport for the given configuration.
+func newFixtureTransport(cfg *config.NodeFixtures, log logger.Logger) (*fixtureTransport, error) {
+	if cfg.Mode != fixturesRecord && cfg.Mode != fixturesReplay {
+		return nil, fmt.Errorf("unknown node fixtures mode %s", cfg.Mode)
+	}
+
+	ft := fixtureTransport{
+		mode: cfg.Mode,
+		path: cfg.Path,
+		next: http.DefaultTransport,
+		log:  log,
+		data: make(map[string]fixture),
+	}
+
+	// load existing fixtures; recording extends them
+	data, err := ioutil.ReadFile(cfg.Path)
+	if err != nil {
+		if os.IsNotExist(err) && cfg.Mode == fixturesRecord {
+			return &ft, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &ft.data); err != nil {
+		return nil, fmt.Errorf("invalid node fixtures file %s; %s", cfg.Path, err.Error())
+	}
+	return &ft, nil
+}
+
+// fixtureKey builds the key of the node call by the method and the parameters.
+func fixtureKey(msg *fixtureMessage) string {
+	var params bytes.Buffer
+	if err := json.Compact(&params, msg.Params); err != nil {
+		return msg.Method + string(msg.Params)
+	}
+	return msg.Method + params.String()
+}
+
+// decodeMessages decodes a single, or a batch of JSON-RPC messages.
+func decodeMessages(data []byte) ([]*fixtureMessage, bool, error) {
+	var list []*fixtureMessage
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		err := json.Unmarshal(data, &list)
+		return list, true, err
+	}
+
+	var msg fixtureMessage
+	err := json.Unmarshal(data, &msg)
+	return []*fixtureMessage{&msg}, false, err
+}
+
+// RoundTrip executes a single HTTP exchange with the node, or with the fixtures.
+func (ft *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Body.Close(); err != nil {
+		return nil, err
+	}
+
+	calls, batch, err := decodeMessages(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if ft.mode == fixturesReplay {
+		return ft.replay(req, calls, batch)
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return ft.record(req, calls)
+}
+
+// record forwards the node call to the node and records the node responses.
+func (ft *fixtureTransport) record(req *http.Request, calls []*fixtureMessage) (*http.Response, error) {
+	res, err := ft.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := res.Body.Close(); err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	// do not record failed exchanges
+	if res.StatusCode != http.StatusOK {
+		return res, nil
+	}
+
+	resp, _, err := decodeMessages(body)
+	if err != nil {
+		ft.log.Errorf("can not decode node response; %s", err.Error())
+		return res, nil
+	}
+
+	// pair responses with calls by the message ID
+	ids := make(map[string]*fixtureMessage, len(resp))
+	for _, r := range resp {
+		ids[string(r.ID)] = r
+	}
+
+	ft.mu.Lock()
+	for _, c := range calls {
+		if r, ok := ids[string(c.ID)]; ok {
+			ft.data[fixtureKey(c)] = fixture{Result: r.Result, Error: r.Error}
+		}
+	}
+	ft.mu.Unlock()
+	return res, nil
+}
+
+// replay builds the response of the node call from the recorded fixtures.
+func (ft *fixtureTransport) replay(req *http.Request, calls []*fixtureMessage, batch bool) (*http.Response, error) {
+	resp := make([]*fixtureMessage, len(calls))
+
+	ft.mu.Lock()
+	for i, c := range calls {
+		resp[i] = &fixtureMessage{Version: "2.0", ID: c.ID}
+
+		fix, ok := ft.data[fixtureKey(c)]
+		if !ok {
+			ft.log.Warningf("no node fixture for %s", fixtureKey(c))
+			resp[i].Error = json.RawMessage(fmt.Sprintf(`{"code":-32000,"message":%q}`, "no fixture recorded for "+c.Method))
+			continue
+		}
+		resp[i].Result, resp[i].Error = fix.Result, fix.Error
+	}
+	ft.mu.Unlock()
+
+	var body []byte
+	var err error
+	if batch {
+		body, err = json.Marshal(resp)
+	} else {
+		body, err = json.Marshal(resp[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// save writes recorded fixtures into the fixtures file.
+// The keys are sorted so repeated recordings produce stable files.
+func (ft *fixtureTransport) save() error {
+	if ft.mode != fixturesRecord {
+		return nil
+	}
+
+	ft.mu.Lock()
+	data, err := json.MarshalIndent(ft.data, "", "  ")
+	ft.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(ft.path, data, 0644)
+}
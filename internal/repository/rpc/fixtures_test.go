@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestFixtures verifies node responses recorded into fixtures are replayed without the node.
+func TestFixtures(t *testing.T) {
+	log := logger.New(&config.Config{AppName: "test", Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+
+	// fake node responding with a fixed block height
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req fixtureMessage
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&fixtureMessage{Version: "2.0", ID: req.ID, Result: json.RawMessage(`"0x2a"`)})
+	}))
+
+	dir, err := ioutil.TempDir("", "fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, "node.json")
+
+	// record the call
+	height := func(cfg *config.Lachesis) (hexutil.Uint64, error) {
+		client, _, fix, err := dial(cfg, log)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			client.Close()
+			if err := fix.save(); err != nil {
+				t.Fatal(err)
+			}
+		}()
+
+		var val hexutil.Uint64
+		err = client.Call(&val, "eth_blockNumber")
+		return val, err
+	}
+
+	val, err := height(&config.Lachesis{Url: node.URL, Fixtures: config.NodeFixtures{Mode: fixturesRecord, Path: path}})
+	if err != nil || val != 42 {
+		t.Fatalf("unexpected recorded height %d; %v", val, err)
+	}
+	node.Close()
+
+	// replay the call without the node
+	val, err = height(&config.Lachesis{Url: node.URL, Fixtures: config.NodeFixtures{Mode: fixturesReplay, Path: path}})
+	if err != nil || val != 42 {
+		t.Fatalf("unexpected replayed height %d; %v", val, err)
+	}
+
+	// calls not recorded fail
+	client, _, _, err := dial(&config.Lachesis{Url: node.URL, Fixtures: config.NodeFixtures{Mode: fixturesReplay, Path: path}}, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var id hexutil.Big
+	if err := client.Call(&id, "eth_chainId"); err == nil {
+		t.Errorf("call without fixture succeeded")
+	}
+}
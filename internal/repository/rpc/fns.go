@@ -0,0 +1,135 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"strings"
+)
+
+//go:generate tools/abigen.sh --abi ./contracts/abi/fns-registry.abi --pkg contracts --type FnsRegistry --out ./contracts/fns_registry.go
+//go:generate tools/abigen.sh --abi ./contracts/abi/fns-resolver.abi --pkg contracts --type FnsResolver --out ./contracts/fns_resolver.go
+
+// fnsReverseDomain is the domain used for reverse address to name records.
+const fnsReverseDomain = "addr.reverse"
+
+// FnsEnabled signals if the name service registry is configured.
+func (ftm *FtmBridge) FnsEnabled() bool {
+	return ftm.fnsConfig.Registry != common.HexToAddress(config.EmptyAddress)
+}
+
+// FnsResolveName resolves the address assigned to the given domain name.
+// It returns nil if the name is not registered, or no address is assigned to it.
+func (ftm *FtmBridge) FnsResolveName(name string) (*common.Address, error) {
+	// get the resolver of the name
+	node := fnsNameHash(name)
+	res, err := ftm.fnsResolver(node)
+	if err != nil || res == nil {
+		return nil, err
+	}
+
+	// resolve the address
+	adr, err := res.Addr(ftm.callOpts(), node)
+	if err != nil {
+		ftm.log.Errorf("can not resolve FNS name %s; %s", name, err.Error())
+		return nil, err
+	}
+
+	// empty address means the name is not assigned
+	if adr == common.HexToAddress(config.EmptyAddress) {
+		return nil, nil
+	}
+	return &adr, nil
+}
+
+// FnsReverseName resolves the primary domain name of the given address.
+// The name is verified by the forward resolution to make sure the name
+// really belongs to the address. It returns empty string if no name is available.
+func (ftm *FtmBridge) FnsReverseName(addr *common.Address) (string, error) {
+	// get the resolver of the reverse record
+	node := fnsNameHash(strings.ToLower(addr.Hex()[2:]) + "." + fnsReverseDomain)
+	res, err := ftm.fnsResolver(node)
+	if err != nil || res == nil {
+		return "", err
+	}
+
+	// resolve the name
+	name, err := res.Name(ftm.callOpts(), node)
+	if err != nil {
+		ftm.log.Errorf("can not resolve FNS reverse record of %s; %s", addr.String(), err.Error())
+		return "", err
+	}
+
+	// no name at all?
+	if name == "" {
+		return "", nil
+	}
+
+	// make sure the name resolves back to the address
+	fwd, err := ftm.FnsResolveName(name)
+	if err != nil || fwd == nil || *fwd != *addr {
+		ftm.log.Debugf("FNS name %s does not resolve back to %s", name, addr.String())
+		return "", err
+	}
+	return name, nil
+}
+
+// fnsResolver provides the resolver contract responsible for the given name node.
+// It returns nil if no resolver is assigned to the node.
+func (ftm *FtmBridge) fnsResolver(node common.Hash) (*contracts.FnsResolver, error) {
+	// connect the registry
+	reg, err := contracts.NewFnsRegistry(ftm.fnsConfig.Registry, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("can not contact FNS registry; %s", err.Error())
+		return nil, err
+	}
+
+	// get the resolver address
+	adr, err := reg.Resolver(ftm.callOpts(), node)
+	if err != nil {
+		ftm.log.Errorf("can not get FNS resolver of %s; %s", node.String(), err.Error())
+		return nil, err
+	}
+
+	// no resolver assigned
+	if adr == common.HexToAddress(config.EmptyAddress) {
+		return nil, nil
+	}
+
+	// connect the resolver
+	res, err := contracts.NewFnsResolver(adr, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("can not contact FNS resolver %s; %s", adr.String(), err.Error())
+		return nil, err
+	}
+	return res, nil
+}
+
+// fnsNameHash calculates the ENS compatible name hash of the given domain name.
+func fnsNameHash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+
+	// hash labels from the top level domain down
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = crypto.Keccak256Hash(node.Bytes(), crypto.Keccak256([]byte(labels[i])))
+	}
+	return node
+}
@@ -16,6 +16,7 @@ package rpc
 import (
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
@@ -44,7 +45,7 @@ func (ftm *FtmBridge) GovernanceProposalsCount(gov *common.Address) (hexutil.Big
 	}
 
 	// get the last proposal id
-	id, err := gc.LastProposalID(nil)
+	id, err := gc.LastProposalID(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("can not count governance %s proposals; %s", gov.String(), err.Error())
 		return hexutil.Big{}, err
@@ -77,7 +78,7 @@ func (ftm *FtmBridge) GovernanceProposal(gov *common.Address, id *hexutil.Big) (
 // specified by its id.
 func (ftm *FtmBridge) governanceProposalDetail(gc *contracts.Governance, govId *common.Address, id *big.Int) (*types.GovernanceProposal, error) {
 	// try to get proposal params
-	data, err := gc.ProposalParams(nil, id)
+	data, err := gc.ProposalParams(ftm.callOpts(), id)
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +119,7 @@ func (ftm *FtmBridge) GovernanceProposalState(gov *common.Address, id *hexutil.B
 	}
 
 	// get the state
-	st, err := gc.ProposalState(nil, id.ToInt())
+	st, err := gc.ProposalState(ftm.callOpts(), id.ToInt())
 	if err != nil {
 		ftm.log.Errorf("can not access governance %s proposal %d state; %s", gov.String(), id.ToInt().Int64(), err.Error())
 		return nil, err
@@ -179,14 +180,14 @@ func (ftm *FtmBridge) GovernanceProposalDetails(prop *common.Address) (*govPropo
 	ge := govProposalExtended{}
 
 	// load the name
-	ge.Name, err = pp.Name(nil)
+	ge.Name, err = pp.Name(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("governance proposal %s name not available; %s", prop.String(), err.Error())
 		return nil, err
 	}
 
 	// load the description
-	ge.Desc, err = pp.Description(nil)
+	ge.Desc, err = pp.Description(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("governance proposal %s description not available; %s", prop.String(), err.Error())
 		return nil, err
@@ -225,7 +226,7 @@ func (ftm *FtmBridge) GovernanceOptionStates(gov *common.Address, propId *hexuti
 	}
 
 	// get the number of options
-	max, err := gc.MaxOptions(nil)
+	max, err := gc.MaxOptions(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("unknown options on governance %s; %s", gov.String(), err.Error())
 		return nil, err
@@ -256,7 +257,7 @@ func (ftm *FtmBridge) GovernanceOptionStates(gov *common.Address, propId *hexuti
 // GovernanceOptionState returns a state of the given option of a proposal.
 func (ftm *FtmBridge) GovernanceOptionStateById(gc *contracts.Governance, propId *hexutil.Big, optId *hexutil.Big) (*types.GovernanceOptionState, error) {
 	// get the state
-	data, err := gc.ProposalOptionState(nil, propId.ToInt(), optId.ToInt())
+	data, err := gc.ProposalOptionState(ftm.callOpts(), propId.ToInt(), optId.ToInt())
 	if err != nil {
 		return nil, err
 	}
@@ -289,7 +290,7 @@ func (ftm *FtmBridge) GovernanceVote(
 	}
 
 	// get the vote details
-	vote, err := gc.GetVote(nil, *from, *delegatedTo, propId.ToInt())
+	vote, err := gc.GetVote(ftm.callOpts(), *from, *delegatedTo, propId.ToInt())
 	if err != nil {
 		ftm.log.Errorf("can not access vote of %s on governance %s; %s", from.String(), gov.String(), err.Error())
 		return nil, err
@@ -315,7 +316,7 @@ func (ftm *FtmBridge) GovernanceProposalsBy(gov *common.Address) ([]*types.Gover
 	}
 
 	// get the max number of proposals
-	maxProposalId, err := gc.LastProposalID(nil)
+	maxProposalId, err := gc.LastProposalID(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("can not count governance %s proposals; %s", gov.String(), err.Error())
 		return nil, err
@@ -357,7 +358,7 @@ func (ftm *FtmBridge) GovernanceProposalFee(gov *common.Address) (hexutil.Big, e
 	}
 
 	// get the fee
-	fee, err := gc.ProposalFee(nil)
+	fee, err := gc.ProposalFee(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("governance %s fee not available; %s", gov.String(), err.Error())
 		return hexutil.Big{}, err
@@ -366,6 +367,25 @@ func (ftm *FtmBridge) GovernanceProposalFee(gov *common.Address) (hexutil.Big, e
 	return hexutil.Big(*fee), nil
 }
 
+// GovernanceMaxExecutionPeriod returns the max period in seconds after the voting end
+// in which an accepted proposal has to be executed in given Governance contract context.
+func (ftm *FtmBridge) GovernanceMaxExecutionPeriod(gov *common.Address) (hexutil.Uint64, error) {
+	// get the contract
+	gc, err := contracts.NewGovernance(*gov, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("can not access governance %s; %s", gov.String(), err.Error())
+		return 0, err
+	}
+
+	// get the period
+	period, err := gc.MaxExecutionPeriod(ftm.callOpts())
+	if err != nil {
+		ftm.log.Errorf("governance %s max execution period not available; %s", gov.String(), err.Error())
+		return 0, err
+	}
+	return hexutil.Uint64(period.Uint64()), nil
+}
+
 // GovernanceTotalWeight returns the total available voting weight for all proposals
 // of a governance contract. The address given must be the Governable contract linked
 // to the core Governance.
@@ -386,3 +406,45 @@ func (ftm *FtmBridge) GovernanceTotalWeight(ge *common.Address) (*hexutil.Big, e
 
 	return (*hexutil.Big)(w), nil
 }
+
+// GovernanceVotingPower returns the voting weight of the given address
+// on the Governable adapter at the given block. The weight is composed
+// of the weight received by the address and the weight it holds
+// on the listed delegation targets. Nil block means the latest state.
+func (ftm *FtmBridge) GovernanceVotingPower(ge *common.Address, addr common.Address, to []common.Address, block *big.Int) (*big.Int, error) {
+	// get the contract
+	goe, err := contracts.NewGovernable(*ge, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("can not access governable adapter %s; %s", ge.String(), err.Error())
+		return nil, err
+	}
+
+	// call the adapter at the requested block
+	opts := &bind.CallOpts{
+		From:        ftm.sigConfig.Address,
+		BlockNumber: block,
+		Context:     ftm.callContext(),
+	}
+
+	// the weight received by the address itself
+	total, err := goe.GetReceivedWeight(opts, addr)
+	if err != nil {
+		ftm.log.Errorf("received weight of %s not available on adapter %s; %s", addr.String(), ge.String(), err.Error())
+		return nil, err
+	}
+
+	// add the weight held on delegation targets
+	for _, dst := range to {
+		if dst == addr {
+			continue
+		}
+
+		w, err := goe.GetWeight(opts, addr, dst)
+		if err != nil {
+			ftm.log.Errorf("weight of %s to %s not available on adapter %s; %s", addr.String(), dst.String(), ge.String(), err.Error())
+			return nil, err
+		}
+		total = new(big.Int).Add(total, w)
+	}
+	return total, nil
+}
@@ -0,0 +1,141 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+//go:generate tools/abigen.sh --abi ./contracts/abi/multicall2.abi --pkg contracts --type Multicall --out ./contracts/multicall.go
+
+import (
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"strings"
+	"sync"
+)
+
+// multicallBatchSize represents the max number of calls aggregated into a single multicall.
+const multicallBatchSize = 200
+
+// ErrMulticallNotAvailable signals the multicall contract is not configured.
+var ErrMulticallNotAvailable = fmt.Errorf("multicall contract not available")
+
+// multicallAbis keeps parsed ABIs used to pack and unpack batched calls.
+var multicallAbis sync.Map
+
+// mcCall represents a single read-only contract call batched into a multicall.
+type mcCall struct {
+	Target common.Address
+	Abi    string
+	Method string
+	Args   []interface{}
+}
+
+// mcResult represents the unpacked output of a single batched call.
+// Failed calls have the error set.
+type mcResult struct {
+	Out []interface{}
+	Err error
+}
+
+// parsedAbi provides the parsed form of the given ABI definition.
+func parsedAbi(def string) (*abi.ABI, error) {
+	if ab, ok := multicallAbis.Load(def); ok {
+		return ab.(*abi.ABI), nil
+	}
+
+	ab, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		return nil, err
+	}
+	multicallAbis.Store(def, &ab)
+	return &ab, nil
+}
+
+// multicall executes the given read-only calls in batches using the configured
+// multicall contract and unpacks their results. A failure of a single call
+// does not break the batch; it's reported on the call result.
+func (ftm *FtmBridge) multicall(calls []mcCall, block *big.Int) ([]mcResult, error) {
+	// do we have the multicall at all?
+	if ftm.multicallAddress == (common.Address{}) {
+		return nil, ErrMulticallNotAvailable
+	}
+
+	// pack the calls
+	packed := make([]contracts.Multicall2Call, len(calls))
+	abis := make([]*abi.ABI, len(calls))
+	for i, c := range calls {
+		ab, err := parsedAbi(c.Abi)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := ab.Pack(c.Method, c.Args...)
+		if err != nil {
+			return nil, err
+		}
+
+		abis[i] = ab
+		packed[i] = contracts.Multicall2Call{Target: c.Target, CallData: data}
+	}
+
+	// run the batches
+	res := make([]mcResult, len(calls))
+	for from := 0; from < len(packed); from += multicallBatchSize {
+		to := from + multicallBatchSize
+		if to > len(packed) {
+			to = len(packed)
+		}
+
+		raw, err := ftm.multicallBatch(packed[from:to], block)
+		if err != nil {
+			return nil, err
+		}
+
+		// unpack the batch results
+		for i, r := range raw {
+			c := &calls[from+i]
+			if !r.Success {
+				res[from+i].Err = fmt.Errorf("call %s on %s failed", c.Method, c.Target.String())
+				continue
+			}
+			res[from+i].Out, res[from+i].Err = abis[from+i].Unpack(c.Method, r.ReturnData)
+		}
+	}
+	return res, nil
+}
+
+// multicallBatch executes a single batch of packed calls on the multicall contract.
+func (ftm *FtmBridge) multicallBatch(calls []contracts.Multicall2Call, block *big.Int) ([]contracts.Multicall2Result, error) {
+	mc, err := contracts.NewMulticallCaller(ftm.multicallAddress, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("can not access multicall contract; %s", err.Error())
+		return nil, err
+	}
+
+	// the aggregation is not a view call, but we execute it as a read only call
+	var out []interface{}
+	raw := contracts.MulticallCallerRaw{Contract: mc}
+	if err := raw.Call(&bind.CallOpts{BlockNumber: block, Context: ftm.callContext()}, &out, "tryAggregate", false, calls); err != nil {
+		ftm.log.Errorf("multicall of %d calls failed; %s", len(calls), err.Error())
+		return nil, err
+	}
+
+	res := *abi.ConvertType(out[0], new([]contracts.Multicall2Result)).(*[]contracts.Multicall2Result)
+	if len(res) != len(calls) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(res), len(calls))
+	}
+	return res, nil
+}
@@ -0,0 +1,69 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+//go:generate tools/abigen.sh --abi ./contracts/abi/gnosis_safe.abi --pkg contracts --type GnosisSafe --out ./contracts/gnosis_safe.go
+
+// Multisig loads the configuration of a Safe-style multisig wallet at the given address.
+// The call fails if the contract does not implement the multisig interface.
+func (ftm *FtmBridge) Multisig(addr *common.Address) (*types.Multisig, error) {
+	// connect the contract
+	contract, err := contracts.NewGnosisSafe(*addr, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("can not contact multisig contract %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	// load the owners
+	owners, err := contract.GetOwners(ftm.callOpts())
+	if err != nil {
+		ftm.log.Debugf("multisig owners of %s not available; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	// load the threshold
+	threshold, err := contract.GetThreshold(ftm.callOpts())
+	if err != nil {
+		ftm.log.Debugf("multisig threshold of %s not available; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	// load the nonce
+	nonce, err := contract.Nonce(ftm.callOpts())
+	if err != nil {
+		ftm.log.Debugf("multisig nonce of %s not available; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	// the version is optional, older wallets may not have it
+	ver, err := contract.VERSION(ftm.callOpts())
+	if err != nil {
+		ver = ""
+	}
+
+	return &types.Multisig{
+		Address:   *addr,
+		Owners:    owners,
+		Threshold: hexutil.Uint64(threshold.Uint64()),
+		Nonce:     hexutil.Uint64(nonce.Uint64()),
+		Version:   ver,
+	}, nil
+}
@@ -0,0 +1,132 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// chainlinkAggregatorAbi is the part of the Chainlink AggregatorV3Interface we use.
+	chainlinkAggregatorAbi = `[{"inputs":[],"name":"latestRoundData","outputs":[{"name":"roundId","type":"uint80"},{"name":"answer","type":"int256"},{"name":"startedAt","type":"uint256"},{"name":"updatedAt","type":"uint256"},{"name":"answeredInRound","type":"uint80"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"description","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"}]`
+
+	// bandReferenceAbi is the part of the Band protocol StdReference interface we use.
+	// The reference data struct is static, so it's encoded the same way as its fields.
+	bandReferenceAbi = `[{"inputs":[{"name":"_base","type":"string"},{"name":"_quote","type":"string"}],"name":"getReferenceData","outputs":[{"name":"rate","type":"uint256"},{"name":"lastUpdatedBase","type":"uint256"},{"name":"lastUpdatedQuote","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+	// bandQuoteSymbol is the quote symbol of Band reference data requests.
+	bandQuoteSymbol = "USD"
+)
+
+// FMintOracleFeed loads the state of the on-chain price feed of the given DeFi token.
+// The price is the one the fMint protocol uses, i.e. provided by the price oracle proxy;
+// the feed adapter is detected to provide the time of the last update.
+func (ftm *FtmBridge) FMintOracleFeed(tk *types.DefiToken) (*types.OracleFeed, error) {
+	price, err := ftm.FMintTokenPrice(&tk.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := types.OracleFeed{
+		Token:         tk.Address,
+		Symbol:        tk.Symbol,
+		Oracle:        tk.Oracle,
+		Source:        types.OracleSourceUnknown,
+		Price:         price,
+		PriceDecimals: tk.PriceDecimals,
+	}
+
+	// try the Chainlink aggregator first, the Band reference next
+	if !ftm.chainlinkFeed(&feed) {
+		ftm.bandFeed(&feed)
+	}
+	return &feed, nil
+}
+
+// chainlinkFeed loads the latest round of the feed, if it's a Chainlink aggregator.
+func (ftm *FtmBridge) chainlinkFeed(feed *types.OracleFeed) bool {
+	out, err := ftm.callAbi(&feed.Oracle, chainlinkAggregatorAbi, "latestRoundData")
+	if err != nil || len(out) != 5 {
+		return false
+	}
+
+	round, ok := out[0].(*big.Int)
+	updated, ok2 := out[3].(*big.Int)
+	if !ok || !ok2 {
+		return false
+	}
+
+	feed.Source = types.OracleSourceChainlink
+	feed.Round = round.Uint64()
+	feed.Updated = time.Unix(updated.Int64(), 0).UTC()
+
+	// the description is optional
+	if out, err := ftm.callAbi(&feed.Oracle, chainlinkAggregatorAbi, "description"); err == nil && len(out) == 1 {
+		feed.Description, _ = out[0].(string)
+	}
+	return true
+}
+
+// bandFeed loads the reference data of the feed, if it's a Band protocol reference.
+func (ftm *FtmBridge) bandFeed(feed *types.OracleFeed) bool {
+	out, err := ftm.callAbi(&feed.Oracle, bandReferenceAbi, "getReferenceData", bandSymbol(feed.Symbol), bandQuoteSymbol)
+	if err != nil || len(out) != 3 {
+		return false
+	}
+
+	updated, ok := out[1].(*big.Int)
+	if !ok {
+		return false
+	}
+
+	feed.Source = types.OracleSourceBand
+	feed.Description = bandSymbol(feed.Symbol) + " / " + bandQuoteSymbol
+	feed.Updated = time.Unix(updated.Int64(), 0).UTC()
+	return true
+}
+
+// bandSymbol provides the Band base symbol of the DeFi token symbol;
+// the wrapped and synthetic token prefixes are removed, i.e. wFTM is priced as FTM.
+func bandSymbol(sym string) string {
+	if len(sym) > 1 && (sym[0] == 'w' || sym[0] == 'f') && strings.ToUpper(sym[1:]) == sym[1:] {
+		return sym[1:]
+	}
+	return sym
+}
+
+// callAbi executes a read-only call of the given method on the contract
+// and unpacks the result using the given ABI definition.
+func (ftm *FtmBridge) callAbi(addr *common.Address, def string, method string, args ...interface{}) ([]interface{}, error) {
+	ab, err := parsedAbi(def)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ab.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ftm.eth.CallContract(ftm.callContext(), ethereum.CallMsg{To: addr, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ab.Unpack(method, res)
+}
@@ -0,0 +1,26 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import "github.com/ethereum/go-ethereum/common/hexutil"
+
+// PeerCount provides the number of peers the node is connected to.
+func (ftm *FtmBridge) PeerCount() (uint64, error) {
+	var peers hexutil.Uint64
+	if err := ftm.call(&peers, "net_peerCount"); err != nil {
+		ftm.log.Errorf("can not get node peers count; %s", err.Error())
+		return 0, err
+	}
+	return uint64(peers), nil
+}
@@ -0,0 +1,284 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/repository/latency"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/ethclient"
+	ftm "github.com/ethereum/go-ethereum/rpc"
+)
+
+// NodeDegradedError signals the block chain node is not responding
+// and the call could not be finished.
+type NodeDegradedError struct {
+	Reason string
+	Err    error
+}
+
+// Error returns the text of the error.
+func (e *NodeDegradedError) Error() string {
+	if e.Err == nil {
+		return "block chain node degraded; " + e.Reason
+	}
+	return "block chain node degraded; " + e.Reason + "; " + e.Err.Error()
+}
+
+// Unwrap provides the underlying error, if any.
+func (e *NodeDegradedError) Unwrap() error {
+	return e.Err
+}
+
+// Extensions provides additional error details for the GraphQL response.
+func (e *NodeDegradedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":   "NODE_UNAVAILABLE",
+		"reason": e.Reason,
+	}
+}
+
+// nonIdempotentCalls lists node calls which must not be repeated after a failure.
+// The node may have executed the call even if the response never arrived,
+// e.g. a transaction accepted right before the timeout; a retry would fail
+// on the transaction being known already and report the submission as failed.
+var nonIdempotentCalls = map[string]bool{
+	"eth_sendRawTransaction": true,
+	"ftm_sendRawTransaction": true,
+}
+
+// nodeOfflineKey is the context key marking node calls which must not reach the node.
+type nodeOfflineKey struct{}
+
+// WithoutNode marks the context so the node calls bound to it fail right away with
+// the node degraded error instead of reaching the node, e.g. while the node resyncs.
+func WithoutNode(ctx context.Context) context.Context {
+	return context.WithValue(ctx, nodeOfflineKey{}, true)
+}
+
+// callPolicy implements timeout, retry and circuit breaker policy of node calls.
+type callPolicy struct {
+	timeout    time.Duration
+	retries    int
+	retryDelay time.Duration
+
+	// latency of recent node calls
+	lat latency.Window
+
+	// circuit breaker state
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+}
+
+// newCallPolicy creates a new node call policy from the node configuration.
+func newCallPolicy(cfg *config.Lachesis) *callPolicy {
+	return &callPolicy{
+		timeout:    cfg.Timeout,
+		retries:    cfg.Retries,
+		retryDelay: cfg.RetryDelay,
+		threshold:  cfg.BreakerThreshold,
+		cooldown:   cfg.BreakerCooldown,
+	}
+}
+
+// do executes the given call under the policy. Calls failing on the node not responding
+// are retried with jittered backoff; calls answered by the node with an error
+// are returned right away since a retry would not change the result.
+func (cp *callPolicy) do(ctx context.Context, call func(context.Context) error) error {
+	return cp.run(ctx, cp.retries, call)
+}
+
+// once executes the given non-idempotent call under the policy with a single attempt.
+func (cp *callPolicy) once(ctx context.Context, call func(context.Context) error) error {
+	err := cp.run(ctx, 0, call)
+
+	// the outcome of the call is not known if the node did not respond
+	var nde *NodeDegradedError
+	if errors.As(err, &nde) && nde.Err != nil {
+		nde.Reason += ", the call may have been executed"
+	}
+	return err
+}
+
+// run executes the given call under the policy with the given max number of retries.
+func (cp *callPolicy) run(ctx context.Context, retries int, call func(context.Context) error) error {
+	// the caller must not reach the node
+	if ctx.Value(nodeOfflineKey{}) != nil {
+		return &NodeDegradedError{Reason: "maintenance"}
+	}
+
+	// is the circuit open?
+	if !cp.allow() {
+		return &NodeDegradedError{Reason: "circuit open"}
+	}
+
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		// wait before the next attempt
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(cp.backoff(attempt)):
+			}
+		}
+
+		if err = cp.attempt(ctx, call); !isNodeFailure(err) {
+			cp.success()
+			return err
+		}
+
+		// the caller gave up on the call
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	// the node did not respond
+	cp.failure()
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &NodeDegradedError{Reason: "timeout", Err: err}
+	}
+	return &NodeDegradedError{Reason: "not responding", Err: err}
+}
+
+// attempt executes a single attempt of the call with the configured timeout.
+func (cp *callPolicy) attempt(ctx context.Context, call func(context.Context) error) error {
+	start := time.Now()
+	defer func() { cp.lat.Observe(time.Since(start)) }()
+
+	if cp.timeout <= 0 {
+		return call(ctx)
+	}
+
+	cc, cancel := context.WithTimeout(ctx, cp.timeout)
+	defer cancel()
+	return call(cc)
+}
+
+// backoff calculates the jittered delay before the given attempt.
+func (cp *callPolicy) backoff(attempt int) time.Duration {
+	if cp.retryDelay <= 0 {
+		return 0
+	}
+	delay := cp.retryDelay << uint(attempt-1)
+	return delay + time.Duration(rand.Int63n(int64(cp.retryDelay)))
+}
+
+// allow checks if the circuit breaker allows a new call.
+func (cp *callPolicy) allow() bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.threshold <= 0 || time.Now().After(cp.openUntil)
+}
+
+// success resets the circuit breaker failures counter.
+func (cp *callPolicy) success() {
+	cp.mu.Lock()
+	cp.failures = 0
+	cp.mu.Unlock()
+}
+
+// failure registers a failed call and opens the circuit if the threshold is reached.
+func (cp *callPolicy) failure() {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.failures++
+	if cp.threshold > 0 && cp.failures >= cp.threshold {
+		cp.openUntil = time.Now().Add(cp.cooldown)
+		cp.failures = 0
+	}
+}
+
+// isNodeFailure checks if the error means the node did not respond to the call.
+// Errors responded by the node itself, like reverted contract calls, are not failures.
+func isNodeFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var re ftm.Error
+	return !errors.As(err, &re)
+}
+
+// nodeBackend implements contract backend applying the call policy
+// on read-only contract calls.
+type nodeBackend struct {
+	*eth.Client
+	pol *callPolicy
+}
+
+// CallContract executes a read-only contract call under the call policy.
+func (nb *nodeBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	var out []byte
+	err := nb.pol.do(ctx, func(cc context.Context) (err error) {
+		out, err = nb.Client.CallContract(cc, msg, block)
+		return err
+	})
+	return out, err
+}
+
+// PendingCallContract executes a read-only contract call on the pending state under the call policy.
+func (nb *nodeBackend) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	var out []byte
+	err := nb.pol.do(ctx, func(cc context.Context) (err error) {
+		out, err = nb.Client.PendingCallContract(cc, msg)
+		return err
+	})
+	return out, err
+}
+
+// CodeAt loads the code of the given contract under the call policy.
+func (nb *nodeBackend) CodeAt(ctx context.Context, contract common.Address, block *big.Int) ([]byte, error) {
+	var out []byte
+	err := nb.pol.do(ctx, func(cc context.Context) (err error) {
+		out, err = nb.Client.CodeAt(cc, contract, block)
+		return err
+	})
+	return out, err
+}
+
+// StorageAt loads the value of the given contract storage slot under the call policy.
+func (nb *nodeBackend) StorageAt(ctx context.Context, contract common.Address, key common.Hash, block *big.Int) ([]byte, error) {
+	var out []byte
+	err := nb.pol.do(ctx, func(cc context.Context) (err error) {
+		out, err = nb.Client.StorageAt(cc, contract, key, block)
+		return err
+	})
+	return out, err
+}
+
+// call executes a node RPC call under the call policy.
+// Non-idempotent calls are never retried.
+func (ftm *FtmBridge) call(result interface{}, method string, args ...interface{}) error {
+	call := func(ctx context.Context) error {
+		return ftm.rpc.CallContext(ctx, result, method, args...)
+	}
+
+	if nonIdempotentCalls[method] {
+		return ftm.pol.once(ftm.callContext(), call)
+	}
+	return ftm.pol.do(ftm.callContext(), call)
+}
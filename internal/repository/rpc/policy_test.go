@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestCallPolicyRetries verifies failed idempotent calls are retried
+// and non-idempotent calls are attempted only once.
+func TestCallPolicyRetries(t *testing.T) {
+	pol := &callPolicy{retries: 2}
+	fail := errors.New("connection reset")
+
+	var calls int
+	call := func(context.Context) error {
+		calls++
+		return fail
+	}
+
+	if err := pol.do(context.Background(), call); !errors.Is(err, fail) {
+		t.Fatalf("expected node failure, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts of idempotent call, got %d", calls)
+	}
+
+	calls = 0
+	err := pol.once(context.Background(), call)
+	if calls != 1 {
+		t.Errorf("expected 1 attempt of non-idempotent call, got %d", calls)
+	}
+
+	var nde *NodeDegradedError
+	if !errors.As(err, &nde) || nde.Reason != "not responding, the call may have been executed" {
+		t.Errorf("unexpected error of non-idempotent call; %v", err)
+	}
+}
+
+// TestCallPolicyWithoutNode verifies calls bound to a context marked
+// to stay off the node fail right away without reaching the node.
+func TestCallPolicyWithoutNode(t *testing.T) {
+	pol := &callPolicy{retries: 2}
+
+	var calls int
+	call := func(context.Context) error {
+		calls++
+		return nil
+	}
+
+	ctx := WithoutNode(context.Background())
+	for _, run := range []func(context.Context, func(context.Context) error) error{pol.do, pol.once} {
+		var nde *NodeDegradedError
+		if err := run(ctx, call); !errors.As(err, &nde) || nde.Reason != "maintenance" {
+			t.Errorf("expected maintenance error, got %v", err)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("expected no call to reach the node, got %d", calls)
+	}
+
+	if err := pol.do(context.Background(), call); err != nil || calls != 1 {
+		t.Errorf("expected unmarked call to reach the node; %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ftm "github.com/ethereum/go-ethereum/rpc"
+)
+
+// panicSelector is the selector of the Solidity Panic(uint256) error.
+var panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+
+// TransactionRevertReason replays the call of the given failed transaction on the state
+// of the previous block and extracts the revert reason from the error responded by the node.
+// Returns empty string if the replayed call did not revert, e.g. the transaction
+// depended on a state changed by an earlier transaction of the same block.
+func (ftm *FtmBridge) TransactionRevertReason(trx *types.Transaction) (string, error) {
+	if trx.BlockNumber == nil || *trx.BlockNumber == 0 {
+		return "", fmt.Errorf("transaction %s not processed yet", trx.Hash.String())
+	}
+
+	// replay the call
+	var out hexutil.Bytes
+	err := ftm.call(&out, "eth_call", struct {
+		From     string         `json:"from"`
+		To       string         `json:"to,omitempty"`
+		Gas      hexutil.Uint64 `json:"gas"`
+		GasPrice *hexutil.Big   `json:"gasPrice"`
+		Value    *hexutil.Big   `json:"value"`
+		Data     hexutil.Bytes  `json:"data"`
+	}{
+		From:     trx.From.String(),
+		To:       toAddress(trx),
+		Gas:      trx.Gas,
+		GasPrice: &trx.GasPrice,
+		Value:    &trx.Value,
+		Data:     trx.InputData,
+	}, hexutil.EncodeUint64(uint64(*trx.BlockNumber)-1))
+	if err == nil {
+		return "", nil
+	}
+
+	// the node responds reverted calls with an error carrying the revert data
+	reason, ok := revertReason(err)
+	if !ok {
+		ftm.log.Errorf("can not replay transaction %s; %s", trx.Hash.String(), err.Error())
+		return "", err
+	}
+	return reason, nil
+}
+
+// revertReason decodes the revert reason from the error of a replayed call.
+// Returns FALSE if the error is not a response of the node, e.g. the call failed.
+func revertReason(err error) (string, bool) {
+	var de ftm.DataError
+	if !errors.As(err, &de) {
+		var re ftm.Error
+		return err.Error(), errors.As(err, &re)
+	}
+
+	str, ok := de.ErrorData().(string)
+	if !ok {
+		return de.Error(), true
+	}
+
+	data, err := hexutil.Decode(str)
+	if err != nil || len(data) < 4 {
+		return de.Error(), true
+	}
+
+	// Error(string)
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason, true
+	}
+
+	// Panic(uint256)
+	if len(data) == 36 && bytes.Equal(data[:4], panicSelector) {
+		return fmt.Sprintf("panic 0x%x", new(big.Int).SetBytes(data[4:])), true
+	}
+	return de.Error(), true
+}
+
+// toAddress returns the recipient address of the transaction, or empty string on contract creation.
+func toAddress(trx *types.Transaction) string {
+	if trx.To == nil {
+		return ""
+	}
+	return trx.To.String()
+}
@@ -0,0 +1,344 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+// sfcV2MaxRewardEpochs is the max number of epochs scanned for pending rewards on SFC v2.
+const sfcV2MaxRewardEpochs = 1000
+
+// ErrSfcNotSupported represents an error returned by SFC adapters
+// for calls not available on the deployed SFC contract version.
+var ErrSfcNotSupported = fmt.Errorf("call not supported by the SFC contract version")
+
+// sfcEpochSnapshot represents the epoch snapshot structure of the SFC contract.
+type sfcEpochSnapshot = struct {
+	EndTime               *big.Int
+	EpochFee              *big.Int
+	TotalBaseRewardWeight *big.Int
+	TotalTxRewardWeight   *big.Int
+	BaseRewardPerSecond   *big.Int
+	TotalStake            *big.Int
+	TotalSupply           *big.Int
+}
+
+// sfcValidator represents the validator structure of the SFC contract.
+type sfcValidator = struct {
+	Status           *big.Int
+	DeactivatedTime  *big.Int
+	DeactivatedEpoch *big.Int
+	ReceivedStake    *big.Int
+	CreatedEpoch     *big.Int
+	CreatedTime      *big.Int
+	Auth             common.Address
+}
+
+// sfcLockupInfo represents the delegation lockup structure of the SFC contract.
+type sfcLockupInfo = struct {
+	LockedStake *big.Int
+	FromEpoch   *big.Int
+	EndTime     *big.Int
+	Duration    *big.Int
+}
+
+// sfcAdapter defines the interface of the SFC contract used by the bridge.
+// The interface follows the current SFC ABI; adapters of older versions
+// translate the calls to their own ABI and semantics.
+type sfcAdapter interface {
+	Version(opts *bind.CallOpts) ([3]byte, error)
+	CurrentEpoch(opts *bind.CallOpts) (*big.Int, error)
+	CurrentSealedEpoch(opts *bind.CallOpts) (*big.Int, error)
+	GetEpochSnapshot(opts *bind.CallOpts, epoch *big.Int) (sfcEpochSnapshot, error)
+	GetEpochValidatorIDs(opts *bind.CallOpts, epoch *big.Int) ([]*big.Int, error)
+	GetEpochOfflineBlocks(opts *bind.CallOpts, epoch *big.Int, validatorID *big.Int) (*big.Int, error)
+	GetEpochAccumulatedUptime(opts *bind.CallOpts, epoch *big.Int, validatorID *big.Int) (*big.Int, error)
+	GetEpochAccumulatedRewardPerToken(opts *bind.CallOpts, epoch *big.Int, validatorID *big.Int) (*big.Int, error)
+	TotalStake(opts *bind.CallOpts) (*big.Int, error)
+	MinSelfStake(opts *bind.CallOpts) (*big.Int, error)
+	MaxDelegatedRatio(opts *bind.CallOpts) (*big.Int, error)
+	MinLockupDuration(opts *bind.CallOpts) (*big.Int, error)
+	MaxLockupDuration(opts *bind.CallOpts) (*big.Int, error)
+	WithdrawalPeriodEpochs(opts *bind.CallOpts) (*big.Int, error)
+	WithdrawalPeriodTime(opts *bind.CallOpts) (*big.Int, error)
+	ValidatorCommission(opts *bind.CallOpts) (*big.Int, error)
+	LastValidatorID(opts *bind.CallOpts) (*big.Int, error)
+	GetValidator(opts *bind.CallOpts, validatorID *big.Int) (sfcValidator, error)
+	GetValidatorID(opts *bind.CallOpts, addr common.Address) (*big.Int, error)
+	GetStake(opts *bind.CallOpts, delegator common.Address, toValidatorID *big.Int) (*big.Int, error)
+	GetLockedStake(opts *bind.CallOpts, delegator common.Address, toValidatorID *big.Int) (*big.Int, error)
+	GetUnlockedStake(opts *bind.CallOpts, delegator common.Address, toValidatorID *big.Int) (*big.Int, error)
+	GetLockupInfo(opts *bind.CallOpts, delegator common.Address, toValidatorID *big.Int) (sfcLockupInfo, error)
+	PendingRewards(opts *bind.CallOpts, delegator common.Address, toValidatorID *big.Int) (*big.Int, error)
+}
+
+// the current SFC contract binding is the reference adapter
+var _ sfcAdapter = (*contracts.SfcContract)(nil)
+
+// sfc returns the SFC adapter matching the version of the deployed SFC contract.
+// Only a successful detection is kept; a failed one is retried on the next call.
+func (ftm *FtmBridge) sfc() (sfcAdapter, error) {
+	if ad, ok := ftm.sfcAdapter.Load().(sfcAdapter); ok {
+		return ad, nil
+	}
+
+	// detect the adapter; concurrent callers share the detection
+	ad, err, _ := ftm.cg.Do("sfc-adapter", func() (interface{}, error) {
+		ad, err := ftm.detectSfcAdapter()
+		if err != nil {
+			return nil, err
+		}
+		ftm.sfcAdapter.Store(ad)
+		return ad, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ad.(sfcAdapter), nil
+}
+
+// detectSfcAdapter creates the SFC adapter by the version of the deployed SFC contract.
+// The version call has the same signature on all the SFC versions.
+func (ftm *FtmBridge) detectSfcAdapter() (sfcAdapter, error) {
+	ver, err := ftm.SfcContract().Version(ftm.callOpts())
+	if err != nil {
+		ftm.log.Errorf("can not detect SFC version; %s", err.Error())
+		return nil, err
+	}
+
+	// the version may be encoded as ASCII digits
+	major := ver[0]
+	if major >= '0' {
+		major -= '0'
+	}
+
+	// older versions need to be adapted
+	if major < 3 {
+		con, err := contracts.NewSfcV2Contract(ftm.sfcConfig.SFCContract, ftm.eth)
+		if err != nil {
+			ftm.log.Criticalf("failed to instantiate SFC v2 contract; %s", err.Error())
+			return nil, err
+		}
+
+		ftm.log.Noticef("using SFC v2 adapter for SFC version %d", major)
+		return &sfcV2Adapter{con: con}, nil
+	}
+
+	ftm.log.Noticef("using SFC adapter for SFC version %d", major)
+	return ftm.SfcContract(), nil
+}
+
+// sfcV2Adapter implements SFC adapter over the SFC v2 contract.
+type sfcV2Adapter struct {
+	con *contracts.SfcV2Contract
+}
+
+// Version returns the version of the SFC contract.
+func (sa *sfcV2Adapter) Version(opts *bind.CallOpts) ([3]byte, error) {
+	return sa.con.Version(opts)
+}
+
+// CurrentEpoch returns the current epoch id.
+func (sa *sfcV2Adapter) CurrentEpoch(opts *bind.CallOpts) (*big.Int, error) {
+	return sa.con.CurrentEpoch(opts)
+}
+
+// CurrentSealedEpoch returns the last sealed epoch id.
+func (sa *sfcV2Adapter) CurrentSealedEpoch(opts *bind.CallOpts) (*big.Int, error) {
+	return sa.con.CurrentSealedEpoch(opts)
+}
+
+// GetEpochSnapshot returns the snapshot of the given epoch.
+func (sa *sfcV2Adapter) GetEpochSnapshot(opts *bind.CallOpts, epoch *big.Int) (sfcEpochSnapshot, error) {
+	es, err := sa.con.EpochSnapshots(opts, epoch)
+	if err != nil {
+		return sfcEpochSnapshot{}, err
+	}
+	return sfcEpochSnapshot{
+		EndTime:               es.EndTime,
+		EpochFee:              es.EpochFee,
+		TotalBaseRewardWeight: es.TotalBaseRewardWeight,
+		TotalTxRewardWeight:   es.TotalTxRewardWeight,
+		BaseRewardPerSecond:   es.BaseRewardPerSecond,
+		TotalStake:            new(big.Int).Add(es.StakeTotalAmount, es.DelegationsTotalAmount),
+		TotalSupply:           es.TotalSupply,
+	}, nil
+}
+
+// GetEpochValidatorIDs is not available on SFC v2.
+func (sa *sfcV2Adapter) GetEpochValidatorIDs(_ *bind.CallOpts, _ *big.Int) ([]*big.Int, error) {
+	return nil, ErrSfcNotSupported
+}
+
+// GetEpochOfflineBlocks is not available on SFC v2.
+func (sa *sfcV2Adapter) GetEpochOfflineBlocks(_ *bind.CallOpts, _ *big.Int, _ *big.Int) (*big.Int, error) {
+	return nil, ErrSfcNotSupported
+}
+
+// GetEpochAccumulatedUptime is not available on SFC v2.
+func (sa *sfcV2Adapter) GetEpochAccumulatedUptime(_ *bind.CallOpts, _ *big.Int, _ *big.Int) (*big.Int, error) {
+	return nil, ErrSfcNotSupported
+}
+
+// GetEpochAccumulatedRewardPerToken is not available on SFC v2.
+func (sa *sfcV2Adapter) GetEpochAccumulatedRewardPerToken(_ *bind.CallOpts, _ *big.Int, _ *big.Int) (*big.Int, error) {
+	return nil, ErrSfcNotSupported
+}
+
+// TotalStake returns the total amount staked including delegations.
+func (sa *sfcV2Adapter) TotalStake(opts *bind.CallOpts) (*big.Int, error) {
+	st, err := sa.con.StakeTotalAmount(opts)
+	if err != nil {
+		return nil, err
+	}
+	dl, err := sa.con.DelegationsTotalAmount(opts)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Add(st, dl), nil
+}
+
+// MinSelfStake returns the minimal amount of validator self stake.
+func (sa *sfcV2Adapter) MinSelfStake(opts *bind.CallOpts) (*big.Int, error) {
+	return sa.con.MinStake(opts)
+}
+
+// MaxDelegatedRatio returns the max ratio of delegations to validator self stake.
+func (sa *sfcV2Adapter) MaxDelegatedRatio(opts *bind.CallOpts) (*big.Int, error) {
+	return sa.con.MaxDelegatedRatio(opts)
+}
+
+// MinLockupDuration returns the minimal lock duration.
+func (sa *sfcV2Adapter) MinLockupDuration(opts *bind.CallOpts) (*big.Int, error) {
+	return sa.con.MinLockupDuration(opts)
+}
+
+// MaxLockupDuration returns the maximal lock duration.
+func (sa *sfcV2Adapter) MaxLockupDuration(opts *bind.CallOpts) (*big.Int, error) {
+	return sa.con.MaxLockupDuration(opts)
+}
+
+// WithdrawalPeriodEpochs returns the number of epochs a withdrawal has to wait.
+func (sa *sfcV2Adapter) WithdrawalPeriodEpochs(opts *bind.CallOpts) (*big.Int, error) {
+	return sa.con.DelegationLockPeriodEpochs(opts)
+}
+
+// WithdrawalPeriodTime returns the number of seconds a withdrawal has to wait.
+func (sa *sfcV2Adapter) WithdrawalPeriodTime(opts *bind.CallOpts) (*big.Int, error) {
+	return sa.con.DelegationLockPeriodTime(opts)
+}
+
+// ValidatorCommission returns the ratio of rewards taken by validators.
+func (sa *sfcV2Adapter) ValidatorCommission(opts *bind.CallOpts) (*big.Int, error) {
+	return sa.con.ValidatorCommission(opts)
+}
+
+// LastValidatorID returns the last validator id.
+func (sa *sfcV2Adapter) LastValidatorID(opts *bind.CallOpts) (*big.Int, error) {
+	return sa.con.StakersLastID(opts)
+}
+
+// GetValidator returns the validator of the given id.
+func (sa *sfcV2Adapter) GetValidator(opts *bind.CallOpts, validatorID *big.Int) (sfcValidator, error) {
+	st, err := sa.con.Stakers(opts, validatorID)
+	if err != nil {
+		return sfcValidator{}, err
+	}
+	return sfcValidator{
+		Status:           st.Status,
+		DeactivatedTime:  st.DeactivatedTime,
+		DeactivatedEpoch: st.DeactivatedEpoch,
+		ReceivedStake:    new(big.Int).Add(st.StakeAmount, st.DelegatedMe),
+		CreatedEpoch:     st.CreatedEpoch,
+		CreatedTime:      st.CreatedTime,
+		Auth:             st.SfcAddress,
+	}, nil
+}
+
+// GetValidatorID returns the id of the validator with the given address.
+func (sa *sfcV2Adapter) GetValidatorID(opts *bind.CallOpts, addr common.Address) (*big.Int, error) {
+	return sa.con.GetStakerID(opts, addr)
+}
+
+// GetStake returns the amount staked by the given address to the given validator.
+// Validators stake on their own on SFC v2, it's not a delegation.
+func (sa *sfcV2Adapter) GetStake(opts *bind.CallOpts, delegator common.Address, toValidatorID *big.Int) (*big.Int, error) {
+	st, err := sa.con.Stakers(opts, toValidatorID)
+	if err != nil {
+		return nil, err
+	}
+	if st.SfcAddress == delegator {
+		return st.StakeAmount, nil
+	}
+
+	dl, err := sa.con.Delegations(opts, delegator, toValidatorID)
+	if err != nil {
+		return nil, err
+	}
+	return dl.Amount, nil
+}
+
+// GetLockupInfo returns the lock of the given delegation.
+func (sa *sfcV2Adapter) GetLockupInfo(opts *bind.CallOpts, delegator common.Address, toValidatorID *big.Int) (sfcLockupInfo, error) {
+	lock, err := sa.con.LockedDelegations(opts, delegator, toValidatorID)
+	if err != nil {
+		return sfcLockupInfo{}, err
+	}
+
+	// the whole delegation is locked on SFC v2
+	amount := new(big.Int)
+	if lock.EndTime.Sign() > 0 {
+		if amount, err = sa.GetStake(opts, delegator, toValidatorID); err != nil {
+			return sfcLockupInfo{}, err
+		}
+	}
+	return sfcLockupInfo{
+		LockedStake: amount,
+		FromEpoch:   lock.FromEpoch,
+		EndTime:     lock.EndTime,
+		Duration:    lock.Duration,
+	}, nil
+}
+
+// GetLockedStake returns the locked amount of the given delegation.
+func (sa *sfcV2Adapter) GetLockedStake(opts *bind.CallOpts, delegator common.Address, toValidatorID *big.Int) (*big.Int, error) {
+	lock, err := sa.GetLockupInfo(opts, delegator, toValidatorID)
+	if err != nil {
+		return nil, err
+	}
+	return lock.LockedStake, nil
+}
+
+// GetUnlockedStake returns the unlocked amount of the given delegation.
+func (sa *sfcV2Adapter) GetUnlockedStake(opts *bind.CallOpts, delegator common.Address, toValidatorID *big.Int) (*big.Int, error) {
+	lock, err := sa.GetLockedStake(opts, delegator, toValidatorID)
+	if err != nil {
+		return nil, err
+	}
+	if lock.Sign() > 0 {
+		return new(big.Int), nil
+	}
+	return sa.GetStake(opts, delegator, toValidatorID)
+}
+
+// PendingRewards returns the amount of pending rewards of the given delegation.
+func (sa *sfcV2Adapter) PendingRewards(opts *bind.CallOpts, delegator common.Address, toValidatorID *big.Int) (*big.Int, error) {
+	rw, _, _, err := sa.con.CalcDelegationRewards(opts, delegator, toValidatorID, new(big.Int), big.NewInt(sfcV2MaxRewardEpochs))
+	return rw, err
+}
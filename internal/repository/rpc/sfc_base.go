@@ -29,10 +29,13 @@ const sfcFirstLockEpoch uint64 = 1600
 
 // SfcVersion returns current version of the SFC contract as a single number.
 func (ftm *FtmBridge) SfcVersion() (hexutil.Uint64, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return 0, err
+	}
+
 	// get the version information from the contract
-	var ver [3]byte
-	var err error
-	ver, err = ftm.SfcContract().Version(nil)
+	ver, err := sfc.Version(ftm.callOpts())
 	if err != nil {
 		ftm.log.Criticalf("failed to get the SFC version; %s", err.Error())
 		return 0, err
@@ -42,8 +45,13 @@ func (ftm *FtmBridge) SfcVersion() (hexutil.Uint64, error) {
 
 // CurrentEpoch extract the current epoch id from SFC smart contract.
 func (ftm *FtmBridge) CurrentEpoch() (hexutil.Uint64, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return 0, err
+	}
+
 	// get the value from the contract
-	epoch, err := ftm.SfcContract().CurrentEpoch(ftm.DefaultCallOpts())
+	epoch, err := sfc.CurrentEpoch(ftm.DefaultCallOpts())
 	if err != nil {
 		ftm.log.Errorf("failed to get the current sealed epoch: %s", err.Error())
 		return 0, err
@@ -53,8 +61,13 @@ func (ftm *FtmBridge) CurrentEpoch() (hexutil.Uint64, error) {
 
 // CurrentSealedEpoch extract the current sealed epoch id from SFC smart contract.
 func (ftm *FtmBridge) CurrentSealedEpoch() (hexutil.Uint64, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return 0, err
+	}
+
 	// get the value from the contract
-	epoch, err := ftm.SfcContract().CurrentSealedEpoch(ftm.DefaultCallOpts())
+	epoch, err := sfc.CurrentSealedEpoch(ftm.DefaultCallOpts())
 	if err != nil {
 		ftm.log.Errorf("failed to get the current sealed epoch: %s", err.Error())
 		return 0, err
@@ -64,8 +77,13 @@ func (ftm *FtmBridge) CurrentSealedEpoch() (hexutil.Uint64, error) {
 
 // Epoch extract information about an epoch from SFC smart contract.
 func (ftm *FtmBridge) Epoch(id hexutil.Uint64) (*types.Epoch, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+
 	// extract epoch snapshot
-	epo, err := ftm.SfcContract().GetEpochSnapshot(nil, big.NewInt(int64(id)))
+	epo, err := sfc.GetEpochSnapshot(ftm.callOpts(), big.NewInt(int64(id)))
 	if err != nil {
 		ftm.log.Errorf("failed to extract epoch information: %s", err.Error())
 		return nil, err
@@ -91,8 +109,13 @@ func (ftm *FtmBridge) RewardsAllowed() (bool, error) {
 
 // LockingAllowed indicates if the stake locking has been enabled in SFC.
 func (ftm *FtmBridge) LockingAllowed() (bool, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return false, err
+	}
+
 	// get the current sealed epoch value from the contract
-	epoch, err := ftm.SfcContract().CurrentSealedEpoch(nil)
+	epoch, err := sfc.CurrentSealedEpoch(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("failed to get the current sealed epoch: %s", err.Error())
 		return false, err
@@ -103,35 +126,131 @@ func (ftm *FtmBridge) LockingAllowed() (bool, error) {
 
 // TotalStaked returns the total amount of staked tokens.
 func (ftm *FtmBridge) TotalStaked() (*big.Int, error) {
-	return ftm.SfcContract().TotalStake(ftm.DefaultCallOpts())
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+	return sfc.TotalStake(ftm.DefaultCallOpts())
 }
 
 // SfcMinValidatorStake extracts a value of minimal validator self stake.
 func (ftm *FtmBridge) SfcMinValidatorStake() (*big.Int, error) {
-	return ftm.SfcContract().MinSelfStake(ftm.DefaultCallOpts())
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+	return sfc.MinSelfStake(ftm.DefaultCallOpts())
 }
 
 // SfcMaxDelegatedRatio extracts a ratio between self delegation and received stake.
 func (ftm *FtmBridge) SfcMaxDelegatedRatio() (*big.Int, error) {
-	return ftm.SfcContract().MaxDelegatedRatio(ftm.DefaultCallOpts())
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+	return sfc.MaxDelegatedRatio(ftm.DefaultCallOpts())
 }
 
 // SfcMinLockupDuration extracts a minimal lockup duration.
 func (ftm *FtmBridge) SfcMinLockupDuration() (*big.Int, error) {
-	return ftm.SfcContract().MinLockupDuration(ftm.DefaultCallOpts())
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+	return sfc.MinLockupDuration(ftm.DefaultCallOpts())
 }
 
 // SfcMaxLockupDuration extracts a maximal lockup duration.
 func (ftm *FtmBridge) SfcMaxLockupDuration() (*big.Int, error) {
-	return ftm.SfcContract().MaxLockupDuration(ftm.DefaultCallOpts())
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+	return sfc.MaxLockupDuration(ftm.DefaultCallOpts())
 }
 
 // SfcWithdrawalPeriodEpochs extracts a minimal number of epochs between un-delegate and withdraw.
 func (ftm *FtmBridge) SfcWithdrawalPeriodEpochs() (*big.Int, error) {
-	return ftm.SfcContract().WithdrawalPeriodEpochs(ftm.DefaultCallOpts())
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+	return sfc.WithdrawalPeriodEpochs(ftm.DefaultCallOpts())
 }
 
 // SfcWithdrawalPeriodTime extracts a minimal number of seconds between un-delegate and withdraw.
 func (ftm *FtmBridge) SfcWithdrawalPeriodTime() (*big.Int, error) {
-	return ftm.SfcContract().WithdrawalPeriodTime(ftm.DefaultCallOpts())
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+	return sfc.WithdrawalPeriodTime(ftm.DefaultCallOpts())
+}
+
+// EpochParticipation provides the number of validators participating on the given
+// sealed epoch and the number of them being offline during the epoch.
+func (ftm *FtmBridge) EpochParticipation(id hexutil.Uint64) (uint64, uint64, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// get the list of validators of the epoch
+	ep := new(big.Int).SetUint64(uint64(id))
+	ids, err := sfc.GetEpochValidatorIDs(ftm.DefaultCallOpts(), ep)
+	if err != nil {
+		ftm.log.Errorf("can not get validators of epoch #%d; %s", id, err.Error())
+		return 0, 0, err
+	}
+
+	// count offline validators
+	var off uint64
+	for _, vid := range ids {
+		blocks, err := sfc.GetEpochOfflineBlocks(ftm.DefaultCallOpts(), ep, vid)
+		if err != nil {
+			ftm.log.Errorf("can not get validator #%d offline blocks; %s", vid.Uint64(), err.Error())
+			return 0, 0, err
+		}
+		if blocks.Sign() > 0 {
+			off++
+		}
+	}
+	return uint64(len(ids)), off, nil
+}
+
+// EpochRewardPerToken provides the reward per token earned by the validators participating
+// on the given sealed epoch, i.e. the increase of their accumulated reward per token
+// since the previous epoch; the map is keyed by the validator ID.
+func (ftm *FtmBridge) EpochRewardPerToken(id hexutil.Uint64) (map[uint64]*big.Int, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+
+	// get the list of validators of the epoch
+	ep := new(big.Int).SetUint64(uint64(id))
+	ids, err := sfc.GetEpochValidatorIDs(ftm.DefaultCallOpts(), ep)
+	if err != nil {
+		ftm.log.Errorf("can not get validators of epoch #%d; %s", id, err.Error())
+		return nil, err
+	}
+
+	prev := new(big.Int).Sub(ep, big.NewInt(1))
+	res := make(map[uint64]*big.Int, len(ids))
+	for _, vid := range ids {
+		cur, err := sfc.GetEpochAccumulatedRewardPerToken(ftm.DefaultCallOpts(), ep, vid)
+		if err != nil {
+			ftm.log.Errorf("can not get accumulated reward of validator #%d; %s", vid.Uint64(), err.Error())
+			return nil, err
+		}
+
+		// validators joining on this epoch have nothing accumulated before
+		last, err := sfc.GetEpochAccumulatedRewardPerToken(ftm.DefaultCallOpts(), prev, vid)
+		if err != nil {
+			ftm.log.Errorf("can not get accumulated reward of validator #%d; %s", vid.Uint64(), err.Error())
+			return nil, err
+		}
+		res[vid.Uint64()] = new(big.Int).Sub(cur, last)
+	}
+	return res, nil
 }
@@ -14,7 +14,6 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
-	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -26,19 +25,32 @@ import (
 
 // AmountStaked returns the current amount at stake for the given staker address and target validator
 func (ftm *FtmBridge) AmountStaked(addr *common.Address, valID *big.Int) (*big.Int, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+
 	// keep track of the operation
 	ftm.log.Debugf("verifying amount staked by %s to %d", addr.String(), valID.Uint64())
-	return ftm.SfcContract().GetStake(ftm.DefaultCallOpts(), *addr, valID)
+	return sfc.GetStake(ftm.DefaultCallOpts(), *addr, valID)
 }
 
 // AmountStakeLocked returns the current locked amount at stake for the given staker address and target validator.
 func (ftm *FtmBridge) AmountStakeLocked(addr *common.Address, valID *big.Int) (*big.Int, error) {
-	return ftm.SfcContract().GetLockedStake(ftm.DefaultCallOpts(), *addr, valID)
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+	return sfc.GetLockedStake(ftm.DefaultCallOpts(), *addr, valID)
 }
 
 // AmountStakeUnlocked returns the current unlocked amount at stake for the given staker address and target validator.
 func (ftm *FtmBridge) AmountStakeUnlocked(addr *common.Address, valID *big.Int) (*big.Int, error) {
-	return ftm.SfcContract().GetUnlockedStake(ftm.DefaultCallOpts(), *addr, valID)
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+	return sfc.GetUnlockedStake(ftm.DefaultCallOpts(), *addr, valID)
 }
 
 // StakeUnlockPenalty returns the expected penalty of a premature stake unlock.
@@ -51,7 +63,7 @@ func (ftm *FtmBridge) StakeUnlockPenalty(addr *common.Address, valID *big.Int, a
 	}
 
 	// make the UnlockStake call as a view call to get the penalty value
-	data, err := ftm.eth.CallContract(context.Background(), ethereum.CallMsg{
+	data, err := ftm.eth.CallContract(ftm.callContext(), ethereum.CallMsg{
 		From: *addr,
 		To:   &ftm.sfcConfig.SFCContract,
 		Data: cd,
@@ -73,6 +85,11 @@ func (ftm *FtmBridge) StakeUnlockPenalty(addr *common.Address, valID *big.Int, a
 
 // PendingRewards returns a detail of delegation rewards waiting to be claimed for the given delegation.
 func (ftm *FtmBridge) PendingRewards(addr *common.Address, valID *big.Int) (*types.PendingRewards, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+
 	// prep the empty value
 	pr := types.PendingRewards{
 		Address: *addr,
@@ -81,7 +98,7 @@ func (ftm *FtmBridge) PendingRewards(addr *common.Address, valID *big.Int) (*typ
 	}
 
 	// get the pending rewards amount
-	amo, err := ftm.SfcContract().PendingRewards(ftm.DefaultCallOpts(), *addr, valID)
+	amo, err := sfc.PendingRewards(ftm.DefaultCallOpts(), *addr, valID)
 	if err != nil {
 		ftm.log.Criticalf("can not calculate pending rewards of %s to %d; %s", addr.String(), valID.Uint64(), err.Error())
 		return &pr, nil
@@ -94,6 +111,11 @@ func (ftm *FtmBridge) PendingRewards(addr *common.Address, valID *big.Int) (*typ
 
 // DelegationLock returns delegation lock information using SFC contract binding.
 func (ftm *FtmBridge) DelegationLock(addr *common.Address, valID *hexutil.Big) (dll *types.DelegationLock, err error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+
 	// recover from panic here
 	defer func() {
 		if r := recover(); r != nil {
@@ -103,7 +125,7 @@ func (ftm *FtmBridge) DelegationLock(addr *common.Address, valID *hexutil.Big) (
 	}()
 
 	// get staker locking detail
-	lock, err := ftm.SfcContract().GetLockupInfo(ftm.DefaultCallOpts(), *addr, valID.ToInt())
+	lock, err := sfc.GetLockupInfo(ftm.DefaultCallOpts(), *addr, valID.ToInt())
 	if err != nil {
 		ftm.log.Errorf("delegation lock query failed; %v", err)
 		return nil, err
@@ -16,6 +16,7 @@ package rpc
 import (
 	"fantom-api-graphql/internal/types"
 	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
@@ -28,7 +29,7 @@ func (ftm *FtmBridge) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, err
 		Blocks hexutil.Uint64 `json:"offlineBlocks"`
 		Time   hexutil.Uint64 `json:"offlineTime"`
 	}
-	if err := ftm.rpc.Call(&dt, "abft_getDowntime", valID); err != nil {
+	if err := ftm.call(&dt, "abft_getDowntime", valID); err != nil {
 		ftm.log.Errorf("failed to get downtime of validator #%d; %s", valID.ToInt().Uint64(), err.Error())
 		return 0, 0, err
 	}
@@ -40,7 +41,7 @@ func (ftm *FtmBridge) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, err
 func (ftm *FtmBridge) ValidatorEpochUptime(valID *hexutil.Big) (uint64, error) {
 	// use rather the public API, it should be faster since it does not involve contract call
 	var ut hexutil.Uint64
-	if err := ftm.rpc.Call(&ut, "abft_getEpochUptime", valID); err != nil {
+	if err := ftm.call(&ut, "abft_getEpochUptime", valID); err != nil {
 		ftm.log.Errorf("failed to get epoch uptime of validator #%d; %s", valID.ToInt().Uint64(), err.Error())
 		return 0, err
 	}
@@ -50,8 +51,13 @@ func (ftm *FtmBridge) ValidatorEpochUptime(valID *hexutil.Big) (uint64, error) {
 
 // LastValidatorId returns the last staker id in Opera blockchain.
 func (ftm *FtmBridge) LastValidatorId() (uint64, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return 0, err
+	}
+
 	// get the value from the contract
-	sl, err := ftm.SfcContract().LastValidatorID(nil)
+	sl, err := sfc.LastValidatorID(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("failed to get the last staker ID: %s", err.Error())
 		return 0, err
@@ -61,15 +67,20 @@ func (ftm *FtmBridge) LastValidatorId() (uint64, error) {
 
 // ValidatorsCount returns the number of validators in Opera blockchain.
 func (ftm *FtmBridge) ValidatorsCount() (uint64, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return 0, err
+	}
+
 	// get the value from the contract
-	epoch, err := ftm.SfcContract().CurrentEpoch(ftm.DefaultCallOpts())
+	epoch, err := sfc.CurrentEpoch(ftm.DefaultCallOpts())
 	if err != nil {
 		ftm.log.Errorf("failed to get the current sealed epoch: %s", err.Error())
 		return 0, err
 	}
 
 	// get the value from the contract
-	val, err := ftm.SfcContract().GetEpochValidatorIDs(nil, epoch)
+	val, err := sfc.GetEpochValidatorIDs(ftm.callOpts(), epoch)
 	if err != nil {
 		ftm.log.Errorf("failed to get the list of validators; %s", err.Error())
 		return 0, err
@@ -93,8 +104,13 @@ func (ftm *FtmBridge) Validator(valID *big.Int) (*types.Validator, error) {
 
 // validatorById loads details of a validator with the specified ID.
 func (ftm *FtmBridge) validatorById(valID *big.Int) (*types.Validator, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+
 	// call for data
-	val, err := ftm.SfcContract().GetValidator(nil, valID)
+	val, err := sfc.GetValidator(ftm.callOpts(), valID)
 	if err != nil {
 		ftm.log.Criticalf("failed to load validator #%d from SFC; %s", valID.Uint64(), err.Error())
 		return nil, err
@@ -134,8 +150,13 @@ func (ftm *FtmBridge) validatorById(valID *big.Int) (*types.Validator, error) {
 
 // ValidatorAddress extract a staker address for the given staker ID.
 func (ftm *FtmBridge) ValidatorAddress(valID *big.Int) (*common.Address, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+
 	// do we have an address call?
-	val, err := ftm.SfcContract().GetValidator(nil, valID)
+	val, err := sfc.GetValidator(ftm.callOpts(), valID)
 	if err != nil {
 		ftm.log.Error("validator information could not be extracted")
 		return nil, err
@@ -153,11 +174,16 @@ func (ftm *FtmBridge) ValidatorAddress(valID *big.Int) (*common.Address, error)
 
 // IsValidator returns if the given address is an SFC validator.
 func (ftm *FtmBridge) IsValidator(addr *common.Address) (bool, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return false, err
+	}
+
 	// keep track of the operation
 	ftm.log.Debugf("verifying validator address %s", addr.String())
 
 	// try to get the id
-	id, err := ftm.SfcContract().GetValidatorID(nil, *addr)
+	id, err := sfc.GetValidatorID(ftm.callOpts(), *addr)
 	if err != nil {
 		ftm.log.Criticalf("can not check validator at %s; %s", addr.String(), err.Error())
 		return false, err
@@ -168,6 +194,11 @@ func (ftm *FtmBridge) IsValidator(addr *common.Address) (bool, error) {
 
 // ValidatorByAddress extracts a validator information by address.
 func (ftm *FtmBridge) ValidatorByAddress(addr *common.Address) (*types.Validator, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+
 	// no validator id?
 	if addr == nil {
 		return nil, fmt.Errorf("validator address not provided")
@@ -177,7 +208,7 @@ func (ftm *FtmBridge) ValidatorByAddress(addr *common.Address) (*types.Validator
 	ftm.log.Debugf("loading validator with address %s", addr.String())
 
 	// try to get the staker id
-	id, err := ftm.SfcContract().GetValidatorID(ftm.DefaultCallOpts(), *addr)
+	id, err := sfc.GetValidatorID(ftm.DefaultCallOpts(), *addr)
 	if err != nil {
 		ftm.log.Criticalf("can not check validator at %s; %s", addr.String(), err.Error())
 		return nil, err
@@ -190,3 +221,48 @@ func (ftm *FtmBridge) ValidatorByAddress(addr *common.Address) (*types.Validator
 	}
 	return ftm.validatorById(id)
 }
+
+// ValidatorEpochAccumulated pulls the accumulated uptime and reward per token
+// of the given validator at the given sealed epoch.
+func (ftm *FtmBridge) ValidatorEpochAccumulated(valID *hexutil.Big, epoch hexutil.Uint64) (*big.Int, *big.Int, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ep := new(big.Int).SetUint64(uint64(epoch))
+
+	// get the accumulated uptime
+	up, err := sfc.GetEpochAccumulatedUptime(ftm.DefaultCallOpts(), ep, valID.ToInt())
+	if err != nil {
+		ftm.log.Errorf("can not get accumulated uptime of validator #%d; %s", valID.ToInt().Uint64(), err.Error())
+		return nil, nil, err
+	}
+
+	// get the accumulated reward per token
+	rew, err := sfc.GetEpochAccumulatedRewardPerToken(ftm.DefaultCallOpts(), ep, valID.ToInt())
+	if err != nil {
+		ftm.log.Errorf("can not get accumulated reward of validator #%d; %s", valID.ToInt().Uint64(), err.Error())
+		return nil, nil, err
+	}
+	return up, rew, nil
+}
+
+// SfcValidatorCommission provides the ratio of rewards taken by validators
+// as their commission from delegations at the given block.
+// Nil block means the latest state.
+func (ftm *FtmBridge) SfcValidatorCommission(block *big.Int) (*big.Int, error) {
+	sfc, err := ftm.sfc()
+	if err != nil {
+		return nil, err
+	}
+
+	if block == nil {
+		return sfc.ValidatorCommission(ftm.DefaultCallOpts())
+	}
+	return sfc.ValidatorCommission(&bind.CallOpts{
+		From:        ftm.sigConfig.Address,
+		BlockNumber: block,
+		Context:     ftm.callContext(),
+	})
+}
@@ -0,0 +1,56 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"bytes"
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:generate tools/abigen.sh --abi ./contracts/abi/erc1271.abi --pkg contracts --type ErcSignatureValidator --out ./contracts/erc1271_validator.go
+
+// erc1271MagicValue is the value returned by EIP-1271 compliant contracts
+// on a valid signature, e.g. bytes4(keccak256("isValidSignature(bytes32,bytes)").
+var erc1271MagicValue = []byte{0x16, 0x26, 0xba, 0x7e}
+
+// IsContract checks if the given address holds a deployed contract code.
+func (ftm *FtmBridge) IsContract(addr *common.Address) (bool, error) {
+	// get the code at the address
+	code, err := ftm.eth.CodeAt(ftm.callContext(), *addr, nil)
+	if err != nil {
+		ftm.log.Errorf("can not get code of %s; %s", addr.String(), err.Error())
+		return false, err
+	}
+	return len(code) > 0, nil
+}
+
+// Erc1271IsValidSignature validates the given signature of the message hash
+// against an EIP-1271 compliant contract wallet.
+func (ftm *FtmBridge) Erc1271IsValidSignature(wallet *common.Address, hash common.Hash, sig []byte) (bool, error) {
+	// connect the contract
+	contract, err := contracts.NewErcSignatureValidator(*wallet, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("can not contact EIP-1271 contract; %s", err.Error())
+		return false, err
+	}
+
+	// call the validation
+	val, err := contract.IsValidSignature(ftm.callOpts(), hash, sig)
+	if err != nil {
+		ftm.log.Debugf("EIP-1271 signature check failed on %s; %s", wallet.String(), err.Error())
+		return false, err
+	}
+	return bytes.Equal(val[:], erc1271MagicValue), nil
+}
@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -52,12 +53,17 @@ func (ftm *FtmBridge) StakerInfo(id *hexutil.Big) (*types.StakerInfo, error) {
 	}
 
 	// call for data
-	stUrl, err := contract.GetInfo(nil, (*big.Int)(id))
+	stUrl, err := contract.GetInfo(ftm.callOpts(), (*big.Int)(id))
 	if err != nil {
 		ftm.log.Errorf("failed to get the staker information: %v", err)
 		return nil, err
 	}
 
+	// use the registry for stakers not registered with the contract
+	if len(stUrl) == 0 && ftm.sfcConfig.InfoUrl != "" {
+		stUrl = strings.Replace(ftm.sfcConfig.InfoUrl, "{id}", id.ToInt().String(), -1)
+	}
+
 	// var url string
 	if len(stUrl) == 0 {
 		ftm.log.Debugf("no information for staker #%d", id.ToInt().Uint64())
@@ -93,6 +99,18 @@ func (ftm *FtmBridge) downloadStakerInfo(stUrl string) (*types.StakerInfo, error
 		return nil, err
 	}
 
+	// don't forget to close
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			ftm.log.Errorf("error closing staker info request; %s", err.Error())
+		}
+	}()
+
+	// registry responds with not found for unknown stakers
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("staker info not available, status %d", res.StatusCode)
+	}
+
 	// read the response
 	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
@@ -111,7 +129,7 @@ func (ftm *FtmBridge) downloadStakerInfo(stUrl string) (*types.StakerInfo, error
 	// do we have anything?
 	if !ftm.isValidStakerInfo(&info) {
 		ftm.log.Errorf("invalid response for staker info [%s]", stUrl)
-		return nil, err
+		return nil, fmt.Errorf("invalid staker info at %s", stUrl)
 	}
 
 	ftm.log.Debugf("found staker [%s]", *info.Name)
@@ -0,0 +1,128 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"strings"
+)
+
+// callTrace represents a single call frame of a transaction trace.
+type callTrace struct {
+	Type   string `json:"type"`
+	Action struct {
+		From           common.Address  `json:"from"`
+		To             *common.Address `json:"to"`
+		Value          *hexutil.Big    `json:"value"`
+		Input          hexutil.Bytes   `json:"input"`
+		Init           hexutil.Bytes   `json:"init"`
+		CreationMethod string          `json:"creationMethod"`
+	} `json:"action"`
+	Result *struct {
+		Address *common.Address `json:"address"`
+	} `json:"result"`
+	Error        string `json:"error"`
+	TraceAddress []int  `json:"traceAddress"`
+}
+
+// TraceTransaction loads the call trace of the given transaction and collects
+// contracts deployed by other contracts and accounts involved in internal value
+// transfers during the transaction processing.
+func (ftm *FtmBridge) TraceTransaction(trx *types.Transaction) (*types.TransactionTrace, error) {
+	var traces []callTrace
+	if err := ftm.call(&traces, "trace_transaction", trx.Hash); err != nil {
+		ftm.log.Errorf("can not trace transaction %s; %s", trx.Hash.String(), err.Error())
+		return nil, err
+	}
+
+	return &types.TransactionTrace{
+		Creations: contractCreations(trx, traces),
+		Touched:   internalTransfers(traces),
+	}, nil
+}
+
+// internalTransfers collects accounts on both sides of successful internal calls
+// transferring value; the top level transfer is known already.
+func internalTransfers(traces []callTrace) []common.Address {
+	list := make([]common.Address, 0)
+	for _, ct := range traces {
+		if ct.Type != "call" || len(ct.TraceAddress) == 0 || ct.Error != "" || ct.Action.To == nil || ct.Action.Value == nil || ct.Action.Value.ToInt().Sign() == 0 {
+			continue
+		}
+		list = append(list, ct.Action.From, *ct.Action.To)
+	}
+	return list
+}
+
+// contractCreations collects contracts deployed by other contracts during the transaction processing.
+func contractCreations(trx *types.Transaction, traces []callTrace) []*types.ContractCreation {
+	list := make([]*types.ContractCreation, 0)
+	for _, ct := range traces {
+		// we need successful internal contract creations only; the top level one is known already
+		if ct.Type != "create" || len(ct.TraceAddress) == 0 || ct.Error != "" || ct.Result == nil || ct.Result.Address == nil {
+			continue
+		}
+
+		cc := types.ContractCreation{
+			Address:      *ct.Result.Address,
+			Creator:      ct.Action.From,
+			Transaction:  trx.Hash,
+			Method:       strings.ToUpper(ct.Action.CreationMethod),
+			InitCodeHash: crypto.Keccak256Hash(ct.Action.Init),
+			TimeStamp:    trx.TimeStamp,
+		}
+
+		// try to recover the salt from the calls made into the factory
+		cc.Salt = create2Salt(&cc, trx.InputData, traces)
+		if cc.Salt != nil {
+			cc.Method = types.ContractCreationMethodCreate2
+		}
+		if cc.Method == "" {
+			cc.Method = types.ContractCreationMethodCreate
+		}
+
+		list = append(list, &cc)
+	}
+	return list
+}
+
+// create2Salt tries to find the CREATE2 salt of the given creation between the call arguments
+// passed to the factory. The salt is not part of the trace, but factories usually receive
+// it as an argument of the deployment call.
+func create2Salt(cc *types.ContractCreation, input []byte, traces []callTrace) *common.Hash {
+	// collect the candidate call inputs
+	inputs := [][]byte{input}
+	for _, ct := range traces {
+		if ct.Type == "call" && ct.Action.To != nil && *ct.Action.To == cc.Creator {
+			inputs = append(inputs, ct.Action.Input)
+		}
+	}
+
+	// check all the 32 bytes words of the call arguments
+	for _, in := range inputs {
+		for i := 4; i+common.HashLength <= len(in); i += common.HashLength {
+			var salt [32]byte
+			copy(salt[:], in[i:i+common.HashLength])
+
+			if crypto.CreateAddress2(cc.Creator, salt, cc.InitCodeHash.Bytes()) == cc.Address {
+				h := common.Hash(salt)
+				return &h
+			}
+		}
+	}
+	return nil
+}
@@ -27,7 +27,7 @@ func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error)
 
 	// call for data
 	var trx types.Transaction
-	err := ftm.rpc.Call(&trx, "ftm_getTransactionByHash", hash)
+	err := ftm.call(&trx, "ftm_getTransactionByHash", hash)
 	if err != nil {
 		ftm.log.Error("transaction could not be extracted")
 		return nil, err
@@ -46,7 +46,7 @@ func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error)
 		}
 
 		// call for the transaction receipt data
-		err := ftm.rpc.Call(&rec, "ftm_getTransactionReceipt", hash)
+		err := ftm.call(&rec, "ftm_getTransactionReceipt", hash)
 		if err != nil {
 			ftm.log.Errorf("can not get receipt for transaction %s", hash)
 			return nil, err
@@ -66,13 +66,36 @@ func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error)
 	return &trx, nil
 }
 
+// TransactionRaw loads the RLP encoded transaction of the given hash.
+func (ftm *FtmBridge) TransactionRaw(hash *common.Hash) (hexutil.Bytes, error) {
+	var raw hexutil.Bytes
+	err := ftm.call(&raw, "ftm_getRawTransactionByHash", hash)
+	if err != nil {
+		ftm.log.Errorf("can not get raw transaction %s; %s", hash.String(), err.Error())
+		return nil, err
+	}
+	return raw, nil
+}
+
+// TransactionReceipt loads the receipt of the transaction of the given hash.
+// It returns nil if the transaction has not been processed yet.
+func (ftm *FtmBridge) TransactionReceipt(hash *common.Hash) (*types.TransactionReceipt, error) {
+	var rec *types.TransactionReceipt
+	err := ftm.call(&rec, "ftm_getTransactionReceipt", hash)
+	if err != nil {
+		ftm.log.Errorf("can not get receipt for transaction %s; %s", hash.String(), err.Error())
+		return nil, err
+	}
+	return rec, nil
+}
+
 // SendTransaction sends raw signed and RLP encoded transaction to the block chain.
 func (ftm *FtmBridge) SendTransaction(tx hexutil.Bytes) (*common.Hash, error) {
 	// keep track of the operation
 	ftm.log.Debug("sending new transaction to block chain")
 
 	var hash common.Hash
-	err := ftm.rpc.Call(&hash, "eth_sendRawTransaction", tx)
+	err := ftm.call(&hash, "eth_sendRawTransaction", tx)
 	if err != nil {
 		ftm.log.Error("transaction could not be sent")
 		return nil, err
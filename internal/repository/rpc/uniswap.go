@@ -37,7 +37,7 @@ func (ftm *FtmBridge) NativeTokenAddress() (*common.Address, error) {
 	}
 
 	// get the native token address
-	adr, err := contract.WETH(nil)
+	adr, err := contract.WETH(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("Unative token address not available; %s", err.Error())
 		return nil, err
@@ -56,7 +56,7 @@ func (ftm *FtmBridge) UniswapPair(tokenA *common.Address, tokenB *common.Address
 	}
 
 	// try to get the pair
-	pair, err := contract.GetPair(nil, *tokenA, *tokenB)
+	pair, err := contract.GetPair(ftm.callOpts(), *tokenA, *tokenB)
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair not found for tokens %s and %s; %s", tokenA.String(), tokenB.String(), err.Error())
 		return nil, err
@@ -75,7 +75,7 @@ func (ftm *FtmBridge) UniswapPairs() ([]common.Address, error) {
 	}
 
 	// get the number of pairs
-	length, err := contract.AllPairsLength(nil)
+	length, err := contract.AllPairsLength(ftm.callOpts())
 	if err != nil || length == nil {
 		ftm.log.Error("Uniswap pairs array length not available")
 		return nil, err
@@ -88,7 +88,7 @@ func (ftm *FtmBridge) UniswapPairs() ([]common.Address, error) {
 	index := new(big.Int)
 	for i := uint64(0); i < length.Uint64(); i++ {
 		// get the pair address
-		adr, err := contract.AllPairs(nil, index.SetUint64(i))
+		adr, err := contract.AllPairs(ftm.callOpts(), index.SetUint64(i))
 		if err != nil {
 			ftm.log.Errorf("error loading Uniswap pair; %s", err.Error())
 			continue
@@ -136,7 +136,7 @@ func (ftm *FtmBridge) UniswapQuoteInput(
 	}
 
 	// get the quote amount
-	amount, err := contract.Quote(nil, amountA.ToInt(), reserveA.ToInt(), reserveB.ToInt())
+	amount, err := contract.Quote(ftm.callOpts(), amountA.ToInt(), reserveA.ToInt(), reserveB.ToInt())
 	if err != nil {
 		ftm.log.Errorf("can not calculate Uniswap quote; %s", err.Error())
 		return hexutil.Big{}, err
@@ -207,14 +207,14 @@ func (ftm *FtmBridge) UniswapTokens(pair *common.Address) ([]common.Address, err
 	tokens := make([]common.Address, 2)
 
 	// get the first token
-	tokens[0], err = contract.Token0(nil)
+	tokens[0], err = contract.Token0(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair %s token A not found; %s", pair.String(), err.Error())
 		return nil, err
 	}
 
 	// get the second token
-	tokens[1], err = contract.Token1(nil)
+	tokens[1], err = contract.Token1(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair %s token B not found; %s", pair.String(), err.Error())
 		return nil, err
@@ -266,7 +266,7 @@ func (ftm *FtmBridge) UniswapCumulativePrices(pair *common.Address) ([]hexutil.B
 	var price *big.Int
 
 	// get the first token price
-	price, err = contract.Price0CumulativeLast(nil)
+	price, err = contract.Price0CumulativeLast(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair %s cumulative price A not available; %s", pair.String(), err.Error())
 		return nil, err
@@ -276,7 +276,7 @@ func (ftm *FtmBridge) UniswapCumulativePrices(pair *common.Address) ([]hexutil.B
 	prices[0] = hexutil.Big(*price)
 
 	// get the second token price
-	price, err = contract.Price1CumulativeLast(nil)
+	price, err = contract.Price1CumulativeLast(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair %s cumulative price B not available; %s", pair.String(), err.Error())
 		return nil, err
@@ -301,7 +301,7 @@ func (ftm *FtmBridge) uniswapReservesRecord(pair *common.Address) (*struct {
 	}
 
 	// try to get the reserves
-	rs, err := contract.GetReserves(nil)
+	rs, err := contract.GetReserves(ftm.callOpts())
 	return &rs, err
 }
 
@@ -315,7 +315,7 @@ func (ftm *FtmBridge) UniswapLastKValue(pair *common.Address) (hexutil.Big, erro
 	}
 
 	// try to get the reserves
-	k, err := contract.KLast(nil)
+	k, err := contract.KLast(ftm.callOpts())
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair %s coefficient K not available; %s", pair.String(), err.Error())
 		return hexutil.Big{}, err
@@ -26,7 +26,7 @@ func (ftm *FtmBridge) GasPrice() (hexutil.Uint64, error) {
 
 	// call for data
 	var price hexutil.Big
-	err := ftm.rpc.Call(&price, "ftm_gasPrice")
+	err := ftm.call(&price, "ftm_gasPrice")
 	if err != nil {
 		ftm.log.Error("current gas price could not be obtained")
 		return hexutil.Uint64(0), err
@@ -55,7 +55,7 @@ func (ftm *FtmBridge) GasEstimate(trx *struct {
 	ftm.log.Debugf("calling for gas amount estimation")
 
 	var val hexutil.Uint64
-	err := ftm.rpc.Call(&val, "ftm_estimateGas", trx)
+	err := ftm.call(&val, "ftm_estimateGas", trx)
 	if err != nil {
 		// missing required argument? incompatibility between old and new RPC API
 		if strings.Contains(err.Error(), "missing value") {
@@ -84,7 +84,7 @@ func (ftm *FtmBridge) GasEstimateWithBlock(trx *struct {
 	ftm.log.Debugf("calling for gas amount estimation with block details")
 
 	var val hexutil.Uint64
-	err := ftm.rpc.Call(&val, "ftm_estimateGas", trx, BlockTypeLatest)
+	err := ftm.call(&val, "ftm_estimateGas", trx, BlockTypeLatest)
 	if err != nil {
 		// return error
 		ftm.log.Errorf("can not estimate gas; %s", err.Error())
@@ -22,6 +22,7 @@ type sfcScanner struct {
 	service
 	epochQueue   chan *types.Epoch
 	sigTermQueue chan bool
+	onEpoch      chan *types.Epoch
 }
 
 // newSFCScanner creates new instance of the SFC scanner service.
@@ -83,6 +84,7 @@ func (sfs *sfcScanner) scan(from uint64) {
 				sfs.log.Criticalf("can not get sealed epoch; %s", err.Error())
 				return
 			}
+			sfs.markRun()
 			if current <= uint64(top.Id) {
 				sfs.log.Infof("epoch scanner at #%d, top sealed epoch #%d", current, top.Id)
 			}
@@ -131,10 +133,28 @@ func (sfs *sfcScanner) updateTop(top **types.Epoch) (err error) {
 
 	// a new epoch found
 	sfs.log.Noticef("current sealed epoch is #%d", ep.Id)
+	if *top != nil {
+		sfs.notify(ep)
+	}
 	*top = ep
 	return nil
 }
 
+// notify sends the newly sealed epoch to the registered events channel, if any.
+// The scanner must not be blocked by a slow consumer, so the event is dropped
+// if the channel is full.
+func (sfs *sfcScanner) notify(ep *types.Epoch) {
+	if sfs.onEpoch == nil {
+		return
+	}
+
+	select {
+	case sfs.onEpoch <- ep:
+	default:
+		sfs.log.Errorf("epoch #%d event dropped, channel full", ep.Id)
+	}
+}
+
 // monitor collects epochs for processing and sends tem to the repository
 // for storing in the off-chain database.
 func (sfs *sfcScanner) monitor() {
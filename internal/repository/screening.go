@@ -0,0 +1,117 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// denyListScreenerName is the name of the built-in list based screener.
+const denyListScreenerName = "denylist"
+
+// Screener represents a compliance screening implementation checking addresses
+// involved in the API calls against regulatory lists.
+type Screener interface {
+	// Screen checks the given addresses and provides the match of the first denied one;
+	// nil if all the addresses pass the screening.
+	Screen(list []common.Address) (*types.ScreeningMatch, error)
+}
+
+// ScreenerFactory creates a compliance screening implementation for the given configuration.
+type ScreenerFactory func(cfg *config.Compliance, log logger.Logger) (Screener, error)
+
+// screenerFactories holds the custom screening implementations by their name.
+var screenerFactories = make(map[string]ScreenerFactory)
+
+// RegisterScreener makes a custom screening implementation available to the compliance
+// configuration under the given name. It has to be called before the first call to R().
+func RegisterScreener(name string, factory ScreenerFactory) {
+	screenerFactories[name] = factory
+}
+
+// denyListScreener implements the built-in screener checking addresses
+// against the denylist collection maintained by the operator.
+type denyListScreener struct {
+	db  ModerationStore
+	col string
+}
+
+// Screen checks the given addresses against the denylist collection.
+func (dl *denyListScreener) Screen(list []common.Address) (*types.ScreeningMatch, error) {
+	return dl.db.DenyListMatch(dl.col, list)
+}
+
+// newScreener creates the configured screening implementation; nil if the screening is disabled.
+func (p *proxy) newScreener() (Screener, error) {
+	switch name := p.cfg.Compliance.Screener; name {
+	case "":
+		return nil, nil
+	case denyListScreenerName:
+		return &denyListScreener{db: p.db, col: p.cfg.Compliance.Collection}, nil
+	default:
+		factory, ok := screenerFactories[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown compliance screener %s", name)
+		}
+		return factory(&p.cfg.Compliance, p.log)
+	}
+}
+
+// ScreenAddresses checks the given addresses by the compliance screening.
+// It returns ComplianceError if any of the addresses is denied.
+// Failed screening rejects the call as well, the screening fails closed.
+func (p *proxy) ScreenAddresses(list ...common.Address) error {
+	if p.screener == nil {
+		return nil
+	}
+
+	sm, err := p.screener.Screen(list)
+	if err != nil {
+		p.log.Errorf("compliance screening failed; %s", err.Error())
+		return fmt.Errorf("compliance screening not available")
+	}
+	if sm != nil {
+		p.log.Noticef("address %s denied by compliance list %s", sm.Address.String(), sm.List)
+		return &ComplianceError{Match: *sm}
+	}
+	return nil
+}
+
+// ScreenQuery checks the address of an account data query by the compliance screening,
+// if the screening of queries is enabled.
+func (p *proxy) ScreenQuery(addr *common.Address) error {
+	if !p.cfg.Compliance.ScreenQueries {
+		return nil
+	}
+	return p.ScreenAddresses(*addr)
+}
+
+// screenTransaction checks the sender and the recipient of the given raw transaction
+// by the compliance screening.
+func (p *proxy) screenTransaction(raw hexutil.Bytes) error {
+	if p.screener == nil {
+		return nil
+	}
+
+	trx, err := decodePendingTransaction(raw)
+	if err != nil {
+		return fmt.Errorf("invalid transaction; %s", err.Error())
+	}
+
+	list := []common.Address{trx.From}
+	if trx.To != nil {
+		list = append(list, *trx.To)
+	}
+	return p.ScreenAddresses(list...)
+}
@@ -239,14 +239,13 @@ func handleNewDelegation(blk hexutil.Uint64, trx *common.Hash, stakerID *big.Int
 // (SFCv1, SFCv2) event CreatedDelegation(address indexed delegator, uint256 indexed toStakerID, uint256 amount)
 // (SFCv3) event Delegated(address indexed delegator, uint256 indexed toValidatorID, uint256 amount)
 func handleSfcCreatedDelegation(log *retypes.Log, ld *logsDispatcher) {
-	handleNewDelegation(
-		hexutil.Uint64(log.BlockNumber),
-		&log.TxHash,
-		new(big.Int).SetBytes(log.Topics[2].Bytes()),
-		common.BytesToAddress(log.Topics[1].Bytes()),
-		new(big.Int).SetBytes(log.Data),
-		ld,
-	)
+	valID := new(big.Int).SetBytes(log.Topics[2].Bytes())
+	addr := common.BytesToAddress(log.Topics[1].Bytes())
+	amo := new(big.Int).SetBytes(log.Data)
+
+	// keep the operation in the delegation history
+	ld.storeDelegationOperation(log, types.DelegationOperationDelegate, addr, valID, amo)
+	handleNewDelegation(hexutil.Uint64(log.BlockNumber), &log.TxHash, valID, addr, amo, ld)
 }
 
 // handleSfc1IncreasedDelegation handles delegation amount increase event in SFC v1 and SFC v2.
@@ -257,6 +256,11 @@ func handleSfc1IncreasedDelegation(log *retypes.Log, ld *logsDispatcher) {
 	addr := common.BytesToAddress(log.Topics[1].Bytes())
 	valID := new(big.Int).SetBytes(log.Topics[2].Bytes())
 
+	// keep the operation in the delegation history; the diff is the amount added
+	if len(log.Data) == 64 {
+		ld.storeDelegationOperation(log, types.DelegationOperationIncrease, addr, valID, new(big.Int).SetBytes(log.Data[32:]))
+	}
+
 	// update the balance
 	if err := ld.repo.UpdateDelegationBalance(&addr, (*hexutil.Big)(valID), func(amo *big.Int) error {
 		return ld.makeAdHocDelegation(log, &addr, (*hexutil.Big)(valID), amo)
@@ -276,13 +280,21 @@ func handleSfcUndelegated(log *retypes.Log, ld *logsDispatcher) {
 		return
 	}
 
+	// extract the basic info about the request
+	addr := common.BytesToAddress(log.Topics[1].Bytes())
+	valID := new(big.Int).SetBytes(log.Topics[2].Bytes())
+	amo := new(big.Int).SetBytes(log.Data[:])
+
+	// keep the operation in the delegation history
+	ld.storeDelegationOperation(log, types.DelegationOperationUndelegate, addr, valID, amo)
+
 	// create withdraw request
 	handleNewWithdrawRequest(
 		types.WithdrawTypeUndelegated,
-		common.BytesToAddress(log.Topics[1].Bytes()),
-		new(big.Int).SetBytes(log.Topics[2].Bytes()),
+		addr,
+		valID,
 		new(big.Int).SetBytes(log.Topics[3].Bytes()),
-		new(big.Int).SetBytes(log.Data[:]),
+		amo,
 		log,
 		ld,
 	)
@@ -453,10 +465,16 @@ func handleSfc1UpdatedDelegation(log *retypes.Log, ld *logsDispatcher) {
 // handleSfcWithdrawn handles a withdrawal request finalization event.
 // event Withdrawn(address indexed delegator, uint256 indexed toValidatorID, uint256 indexed wrID, uint256 amount)
 func handleSfcWithdrawn(log *retypes.Log, ld *logsDispatcher) {
+	addr := common.BytesToAddress(log.Topics[1].Bytes())
+	valID := new(big.Int).SetBytes(log.Topics[2].Bytes())
+
+	// keep the operation in the delegation history
+	ld.storeDelegationOperation(log, types.DelegationOperationWithdraw, addr, valID, new(big.Int).SetBytes(log.Data))
+
 	// finish the request
 	handleFinishedWithdrawRequest(
-		common.BytesToAddress(log.Topics[1].Bytes()),
-		new(big.Int).SetBytes(log.Topics[2].Bytes()),
+		addr,
+		valID,
 		new(big.Int).SetBytes(log.Topics[3].Bytes()),
 		new(big.Int),
 		log,
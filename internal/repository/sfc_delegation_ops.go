@@ -0,0 +1,57 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"math/big"
+)
+
+// StoreDelegationOperation stores delegation operation record in the persistent repository.
+func (p *proxy) StoreDelegationOperation(op *types.DelegationOperation) error {
+	return p.db.AddDelegationOperation(op)
+}
+
+// DelegationOperations provides a list of operations of the given delegation.
+func (p *proxy) DelegationOperations(adr *common.Address, valID *hexutil.Big, cursor *string, count int32) (*types.DelegationOperationList, error) {
+	p.log.Debugf("loading operations of delegation %s to #%d", adr.String(), valID.ToInt().Uint64())
+	return p.db.DelegationOperations(cursor, count, &bson.D{
+		{types.FiDelegationOperationAddress, adr.String()},
+		{types.FiDelegationOperationToValidator, valID.String()},
+	})
+}
+
+// storeDelegationOperation records an operation on a delegation from the given SFC event log.
+func (ld *logsDispatcher) storeDelegationOperation(log *retypes.Log, opType string, addr common.Address, valID *big.Int, amo *big.Int) {
+	// get the block
+	blk := hexutil.Uint64(log.BlockNumber)
+	block, err := ld.repo.BlockByNumber(&blk)
+	if err != nil {
+		ld.log.Errorf("can not decode delegation operation log record; %s", err.Error())
+		return
+	}
+
+	// store the operation
+	if err := ld.repo.StoreDelegationOperation(&types.DelegationOperation{
+		Type:          opType,
+		Delegator:     addr,
+		ToValidatorId: hexutil.Big(*valID),
+		Amount:        hexutil.Big(*amo),
+		Trx:           log.TxHash,
+		BlockNumber:   log.BlockNumber,
+		LogIndex:      log.Index,
+		TimeStamp:     block.TimeStamp,
+	}); err != nil {
+		ld.log.Errorf("failed to store delegation operation; %s", err.Error())
+	}
+}
@@ -111,6 +111,13 @@ func handleSfcRewardClaim(log *retypes.Log, ld *logsDispatcher, isRestake bool)
 		return
 	}
 
+	// keep the operation in the delegation history
+	opType := types.DelegationOperationClaim
+	if isRestake {
+		opType = types.DelegationOperationRestake
+	}
+	ld.storeDelegationOperation(log, opType, addr, valID.ToInt(), amo)
+
 	// check active amount on the delegation
 	if err := ld.repo.UpdateDelegationBalance(&addr, valID, func(amo *big.Int) error {
 		return ld.makeAdHocDelegation(log, &addr, valID, amo)
@@ -169,6 +176,9 @@ func handleSfc1ClaimedDelegationReward(log *retypes.Log, ld *logsDispatcher) {
 		return
 	}
 
+	// keep the operation in the delegation history
+	ld.storeDelegationOperation(log, types.DelegationOperationClaim, addr, valID.ToInt(), amo)
+
 	// check active amount on the delegation
 	if err := ld.repo.UpdateDelegationBalance(&addr, valID, func(amo *big.Int) error {
 		return ld.makeAdHocDelegation(log, &addr, valID, amo)
@@ -12,6 +12,7 @@ import (
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
 	"math/big"
 )
 
@@ -82,3 +83,58 @@ func (p *proxy) SfcMaxDelegatedRatio() (*big.Int, error) {
 func (p *proxy) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, error) {
 	return p.rpc.ValidatorDowntime(valID)
 }
+
+// ValidatorDelegatorsCount provides the number of active delegators of the given validator.
+func (p *proxy) ValidatorDelegatorsCount(valID *hexutil.Big) (uint64, error) {
+	// try the index in cache first
+	if val, ok := p.cache.PullValidatorDelegatorsCount(valID); ok {
+		return val, nil
+	}
+
+	// count delegations in the database
+	val, err := p.db.DelegationsCountFiltered(&bson.D{
+		{types.FiDelegationToValidator, valID.String()},
+		{types.FiDelegationAmount, bson.D{{"$ne", "0x0"}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	p.cache.PushValidatorDelegatorsCount(valID, val)
+	return val, nil
+}
+
+// ValidatorsDelegatorsIndex builds the index of delegators count of all the validators
+// keyed by the validator id; validators without delegations are included.
+func (p *proxy) ValidatorsDelegatorsIndex() (map[string]uint64, error) {
+	idx, err := p.db.DelegatorsCountByValidator()
+	if err != nil {
+		return nil, err
+	}
+
+	// get the validators range
+	top, err := p.rpc.LastValidatorId()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := uint64(1); i <= top; i++ {
+		id := (*hexutil.Big)(new(big.Int).SetUint64(i)).String()
+		if _, ok := idx[id]; !ok {
+			idx[id] = 0
+		}
+	}
+	return idx, nil
+}
+
+// IndexValidatorsDelegators updates the in-memory index of delegators count of all the validators.
+func (p *proxy) IndexValidatorsDelegators(idx map[string]uint64) {
+	for id, val := range idx {
+		valID, err := hexutil.DecodeBig(id)
+		if err != nil {
+			p.log.Errorf("invalid validator id %s in delegators index; %s", id, err.Error())
+			continue
+		}
+		p.cache.PushValidatorDelegatorsCount((*hexutil.Big)(valID), val)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+// VerifySignature validates the signature of the given message for the expected signer address.
+// The message is either a plain text signed by EIP-191 personal_sign, or a JSON encoded
+// EIP-712 typed data structure. Contract wallets are validated using EIP-1271 call.
+func (p *proxy) VerifySignature(addr *common.Address, msg string, sig []byte, typed bool) (*types.SignatureVerification, error) {
+	// prep the result
+	res := types.SignatureVerification{Address: *addr, Type: types.SignatureTypePersonal}
+
+	// calculate the hash of the message
+	var err error
+	if typed {
+		res.Type = types.SignatureTypeTypedData
+		res.MessageHash, err = typedDataHash(msg)
+		if err != nil {
+			p.log.Debugf("invalid typed data message; %s", err.Error())
+			return nil, err
+		}
+	} else {
+		res.MessageHash = common.BytesToHash(accounts.TextHash([]byte(msg)))
+	}
+
+	// try to recover the signer from the signature
+	res.Signer = recoverSigner(res.MessageHash, sig)
+	if res.Signer != nil && *res.Signer == *addr {
+		res.IsValid = true
+		return &res, nil
+	}
+
+	// the address may be a contract wallet
+	isContract, err := p.rpc.IsContract(addr)
+	if err != nil || !isContract {
+		return &res, err
+	}
+
+	// try the EIP-1271 validation; failed call means the contract does not support it
+	res.IsValid, err = p.rpc.Erc1271IsValidSignature(addr, res.MessageHash, sig)
+	if err == nil && res.IsValid {
+		res.Type = types.SignatureTypeContract
+	}
+	return &res, nil
+}
+
+// recoverSigner recovers the signer address from the signature of the given hash.
+// It returns nil if the signer can not be recovered.
+func recoverSigner(hash common.Hash, sig []byte) *common.Address {
+	// make sure the signature is well formed
+	if len(sig) != crypto.SignatureLength {
+		return nil
+	}
+
+	// adjust the recovery id if the signature uses legacy V values (27/28)
+	rs := make([]byte, crypto.SignatureLength)
+	copy(rs, sig)
+	if rs[crypto.RecoveryIDOffset] >= 27 {
+		rs[crypto.RecoveryIDOffset] -= 27
+	}
+
+	// recover the public key
+	pub, err := crypto.SigToPub(hash.Bytes(), rs)
+	if err != nil {
+		return nil
+	}
+
+	adr := crypto.PubkeyToAddress(*pub)
+	return &adr
+}
+
+// typedDataHash calculates the EIP-712 hash of the given JSON encoded typed data structure.
+func typedDataHash(msg string) (common.Hash, error) {
+	// decode the structure
+	var td core.TypedData
+	if err := json.Unmarshal([]byte(msg), &td); err != nil {
+		return common.Hash{}, fmt.Errorf("invalid typed data; %s", err.Error())
+	}
+
+	// hash the domain separator
+	domain, err := td.HashStruct("EIP712Domain", td.Domain.Map())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid typed data domain; %s", err.Error())
+	}
+
+	// hash the message itself
+	data, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid typed data message; %s", err.Error())
+	}
+
+	// combine it all together
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domain, data), nil
+}
@@ -0,0 +1,206 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"math/big"
+	"sync"
+	"time"
+)
+
+const (
+
+	// rewardsDailyUpdateRange represents the range for which we do the daily rewards update.
+	rewardsDailyUpdateRange = -2 * 24 * time.Hour
+)
+
+// stakingStatsUpdater represents a service keeping periodically refreshed
+// aggregated staking statistics of the network.
+type stakingStatsUpdater struct {
+	service
+	mu    sync.RWMutex
+	stats *types.StakingStats
+}
+
+// newStakingStatsUpdater creates a new staking statistics updater service.
+func newStakingStatsUpdater(repo Repository, log logger.Logger, wg *sync.WaitGroup) *stakingStatsUpdater {
+	return &stakingStatsUpdater{
+		service: newPeriodicService("staking stats updater", repo, log, wg),
+	}
+}
+
+// run starts the staking stats updater service
+func (ssu *stakingStatsUpdater) run() {
+	ssu.wg.Add(1)
+	go ssu.schedule()
+}
+
+// schedule schedules regular staking statistics updates.
+func (ssu *stakingStatsUpdater) schedule() {
+	// inform about the service
+	ssu.log.Notice("staking stats updater is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		ssu.log.Notice("staking stats updater is closed")
+		ssu.wg.Done()
+	}()
+
+	// aggregate all the past reward claims on start
+	ssu.repo.RewardsDailyUpdate(true)
+	ssu.update()
+
+	// run on schedule
+	ssu.loop(func() {
+		ssu.exclusive(func() {
+			ssu.repo.RewardsDailyUpdate(false)
+		})
+
+		// the stats are kept in memory of each API server
+		ssu.update()
+	})
+}
+
+// update refreshes the staking statistics.
+func (ssu *stakingStatsUpdater) update() {
+	st, err := ssu.repo.LoadStakingStats()
+	if err != nil {
+		ssu.log.Errorf("can not refresh staking stats; %s", err.Error())
+		return
+	}
+
+	ssu.mu.Lock()
+	ssu.stats = st
+	ssu.mu.Unlock()
+	ssu.log.Debugf("staking stats refreshed")
+}
+
+// current provides the current staking statistics, if available.
+func (ssu *stakingStatsUpdater) current() *types.StakingStats {
+	ssu.mu.RLock()
+	defer ssu.mu.RUnlock()
+	return ssu.stats
+}
+
+// StakingStats provides recent aggregated staking statistics of the network.
+func (p *proxy) StakingStats() (*types.StakingStats, error) {
+	if st := p.orc.ssu.current(); st != nil {
+		return st, nil
+	}
+	return p.LoadStakingStats()
+}
+
+// LoadStakingStats calculates fresh aggregated staking statistics of the network.
+func (p *proxy) LoadStakingStats() (*types.StakingStats, error) {
+	st := types.StakingStats{Updated: hexutil.Uint64(time.Now().UTC().Unix())}
+
+	// get the total staked amount
+	total, err := p.TotalStaked()
+	if err != nil {
+		return nil, err
+	}
+	st.TotalStaked = *total
+
+	// collect validators stake and weighted APR
+	if err := p.stakingStatsValidators(&st); err != nil {
+		return nil, err
+	}
+
+	// participation rate is based on the supply of the latest sealed epoch
+	ep, err := p.CurrentSealedEpoch()
+	if err != nil {
+		return nil, err
+	}
+	st.TotalSupply = ep.TotalSupply
+	if ep.TotalSupply.ToInt().Sign() > 0 {
+		st.ParticipationRate, _ = new(big.Rat).SetFrac(total.ToInt(), ep.TotalSupply.ToInt()).Float64()
+	}
+
+	// the claimed rewards come from the reward claims collection
+	claimed, err := p.db.RewardsSumValue(&bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	st.TotalRewardsClaimed = hexutil.Big(*claimed)
+
+	// count active delegations
+	st.ActiveDelegations, err = p.db.DelegationsCountFiltered(&bson.D{{types.FiDelegationValue, bson.D{{"$gt", 0}}}})
+	if err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// stakingStatsValidators collects self-stake and weighted APR of active validators.
+func (p *proxy) stakingStatsValidators(st *types.StakingStats) error {
+	list, err := p.ValidatorsSnapshot()
+	if err != nil {
+		return err
+	}
+
+	self := new(big.Int)
+	stakeSum := new(big.Float)
+	aprSum := new(big.Float)
+	for _, val := range list {
+		// only active validators earn rewards
+		if val.Status != 0 || val.TotalStake == nil {
+			continue
+		}
+		st.ActiveValidators++
+
+		// self-stake is the delegation of the validator to itself
+		amo, err := p.DelegationAmountStaked(&val.StakerAddress, &val.Id)
+		if err != nil {
+			return err
+		}
+		self.Add(self, amo)
+
+		stake := new(big.Float).SetInt(val.TotalStake.ToInt())
+		stakeSum.Add(stakeSum, stake)
+		aprSum.Add(aprSum, new(big.Float).Mul(stake, big.NewFloat(val.Apr)))
+	}
+
+	// the rest of the stake is delegated
+	st.TotalSelfStaked = hexutil.Big(*self)
+	st.TotalDelegated = hexutil.Big(*new(big.Int).Sub(st.TotalStaked.ToInt(), self))
+	if st.TotalDelegated.ToInt().Sign() < 0 {
+		st.TotalDelegated = hexutil.Big{}
+	}
+	if stakeSum.Sign() > 0 {
+		st.AverageApr, _ = new(big.Float).Quo(aprSum, stakeSum).Float64()
+	}
+	return nil
+}
+
+// DailyRewards provides the list of daily aggregations of reward claims.
+func (p *proxy) DailyRewards(from *time.Time, to *time.Time) ([]*types.DailyRewards, error) {
+	return p.db.RewardsDailyList(from, to)
+}
+
+// RewardsDailyUpdate executes the daily rewards aggregation update in the database.
+// The full update aggregates all the reward claims, otherwise only the recent days are updated.
+func (p *proxy) RewardsDailyUpdate(full bool) {
+	var from time.Time
+	if !full {
+		// calculate previous midnight
+		now := time.Now().UTC()
+		from = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).Add(rewardsDailyUpdateRange)
+	}
+
+	// do the update
+	if err := p.db.RewardsDailyUpdate(from); err != nil {
+		p.log.Criticalf("can not update daily rewards; %s", err.Error())
+		return
+	}
+	p.log.Debugf("daily rewards updated")
+}
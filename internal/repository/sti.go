@@ -16,12 +16,8 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
 	"sync"
-	"time"
 )
 
-// stiPullDelay represents the delay between validators info pulls
-const stiPullDelay = 5 * time.Second
-
 // stiMonitor implements staker information monitoring service.
 type stiMonitor struct {
 	service
@@ -33,7 +29,7 @@ type stiMonitor struct {
 func newStiMonitor(repo Repository, log logger.Logger, wg *sync.WaitGroup) *stiMonitor {
 	// create new blockScanner instance
 	return &stiMonitor{
-		service: newService("stm monitor", repo, log, wg),
+		service: newPeriodicService("stm monitor", repo, log, wg),
 	}
 }
 
@@ -49,30 +45,17 @@ func (sti *stiMonitor) run() {
 
 // monitor runs the staker information monitoring task.
 func (sti *stiMonitor) monitor() {
-	// make the ticker
-	stiTicker := time.NewTicker(stiPullDelay)
-
 	// don't forget to sign off after we are done
 	defer func() {
 		// finish and log
-		stiTicker.Stop()
 		sti.log.Notice("staker information monitor done")
 
 		// signal to wait group we are done
 		sti.wg.Done()
 	}()
 
-	// loop before terminated
-	for {
-		// wait for stop or timeout
-		select {
-		case <-sti.sigStop:
-			// stop signal received?
-			return
-		case <-stiTicker.C:
-			sti.next()
-		}
-	}
+	// pull validators info on schedule
+	sti.loop(sti.next)
 }
 
 // next tries to download and store next staker information.
@@ -0,0 +1,201 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sync"
+)
+
+const (
+
+	// stakersPerformanceEpochs is the number of recent sealed epochs
+	// used to calculate validators performance.
+	stakersPerformanceEpochs = 50
+
+	// secondsPerYear is the number of seconds in a year used for annual rates.
+	secondsPerYear = 365 * 24 * 60 * 60
+)
+
+// stakersSnapshot represents a service keeping a periodically refreshed
+// snapshot of validators with their recent performance.
+type stakersSnapshot struct {
+	service
+	mu   sync.RWMutex
+	list []*types.ValidatorSnapshot
+}
+
+// newStakersSnapshot creates a new stakers snapshot service.
+func newStakersSnapshot(repo Repository, log logger.Logger, wg *sync.WaitGroup) *stakersSnapshot {
+	return &stakersSnapshot{
+		service: newPeriodicService("stakers snapshot", repo, log, wg),
+	}
+}
+
+// run starts the stakers snapshot service
+func (sss *stakersSnapshot) run() {
+	sss.wg.Add(1)
+	go sss.schedule()
+}
+
+// schedule schedules regular stakers snapshot updates.
+func (sss *stakersSnapshot) schedule() {
+	// inform about the service
+	sss.log.Notice("stakers snapshot is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		sss.log.Notice("stakers snapshot is closed")
+		sss.wg.Done()
+	}()
+
+	// build the snapshot right away
+	sss.update()
+
+	// run on schedule
+	sss.loop(func() {
+		sss.update()
+	})
+}
+
+// update refreshes the stakers snapshot; the snapshot is built by the leading
+// API server instance, but it's kept in memory of each of them.
+func (sss *stakersSnapshot) update() {
+	var list []*types.ValidatorSnapshot
+	if !sss.shared(&list, func() (err error) {
+		list, err = sss.repo.LoadValidatorsSnapshot()
+		return err
+	}) {
+		return
+	}
+
+	sss.mu.Lock()
+	sss.list = list
+	sss.mu.Unlock()
+	sss.log.Debugf("stakers snapshot refreshed with %d stakers", len(list))
+}
+
+// snapshot provides the current stakers snapshot, if available.
+func (sss *stakersSnapshot) snapshot() []*types.ValidatorSnapshot {
+	sss.mu.RLock()
+	defer sss.mu.RUnlock()
+	return sss.list
+}
+
+// ValidatorsSnapshot provides a recent snapshot of all validators with their performance.
+// The list must not be modified by the caller.
+func (p *proxy) ValidatorsSnapshot() ([]*types.ValidatorSnapshot, error) {
+	if list := p.orc.sss.snapshot(); list != nil {
+		return list, nil
+	}
+	return p.LoadValidatorsSnapshot()
+}
+
+// LoadValidatorsSnapshot loads a fresh snapshot of all validators with their performance.
+func (p *proxy) LoadValidatorsSnapshot() ([]*types.ValidatorSnapshot, error) {
+	// get the number
+	num, err := p.rpc.LastValidatorId()
+	if err != nil {
+		return nil, err
+	}
+
+	// get the performance window
+	from, to, err := p.stakersPerformanceWindow()
+	if err != nil {
+		return nil, err
+	}
+
+	// the commission is shared by all the validators
+	com, err := p.rpc.SfcValidatorCommission(nil)
+	if err != nil {
+		return nil, err
+	}
+	commission := p.sfcRatio(com)
+
+	// make the list
+	list := make([]*types.ValidatorSnapshot, 0, num)
+	for i := uint64(1); i <= num; i++ {
+		val, err := p.Validator((*hexutil.Big)(new(big.Int).SetUint64(i)))
+		if err != nil {
+			p.log.Errorf("can not extract staker #%d information; %s", i, err.Error())
+			continue
+		}
+
+		// staker not valid?
+		if val.Id.ToInt().Uint64() == 0 {
+			continue
+		}
+
+		snap := types.ValidatorSnapshot{Validator: *val, Commission: commission}
+		if err := p.validatorPerformance(&snap, from, to); err != nil {
+			p.log.Errorf("can not calculate staker #%d performance; %s", i, err.Error())
+		}
+		list = append(list, &snap)
+	}
+	return list, nil
+}
+
+// stakersPerformanceWindow provides the range of sealed epochs used to calculate validators performance.
+func (p *proxy) stakersPerformanceWindow() (*types.Epoch, *types.Epoch, error) {
+	to, err := p.CurrentSealedEpoch()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// find the window start
+	fid := hexutil.Uint64(1)
+	if to.Id > stakersPerformanceEpochs {
+		fid = to.Id - stakersPerformanceEpochs
+	}
+	from, err := p.Epoch(&fid)
+	if err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+// validatorPerformance calculates uptime and APR of the validator on the given epochs range.
+func (p *proxy) validatorPerformance(snap *types.ValidatorSnapshot, from *types.Epoch, to *types.Epoch) error {
+	// no time passed?
+	if to.EndTime <= from.EndTime {
+		return nil
+	}
+	dur := new(big.Int).SetUint64(uint64(to.EndTime - from.EndTime))
+
+	// get the accumulated values on the window edges
+	fUp, fRew, err := p.rpc.ValidatorEpochAccumulated(&snap.Id, from.Id)
+	if err != nil {
+		return err
+	}
+	tUp, tRew, err := p.rpc.ValidatorEpochAccumulated(&snap.Id, to.Id)
+	if err != nil {
+		return err
+	}
+
+	// uptime is the ratio of online time to the window duration
+	up, _ := new(big.Rat).SetFrac(new(big.Int).Sub(tUp, fUp), dur).Float64()
+	if up > 1 {
+		up = 1
+	}
+	snap.Uptime = up
+
+	// APR is the reward per token extrapolated to the whole year
+	rew := new(big.Int).Mul(new(big.Int).Sub(tRew, fRew), big.NewInt(secondsPerYear))
+	snap.Apr, _ = new(big.Rat).SetFrac(rew, new(big.Int).Mul(dur, p.SfcDecimalUnit())).Float64()
+	return nil
+}
+
+// sfcRatio converts the given SFC decimal ratio value into a float.
+func (p *proxy) sfcRatio(val *big.Int) float64 {
+	r, _ := new(big.Rat).SetFrac(val, p.SfcDecimalUnit()).Float64()
+	return r
+}
@@ -9,10 +9,20 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"encoding/json"
 	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"math/rand"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// serviceLockGrace is the extra time the lock of exclusive jobs is held
+// on top of the scheduling period to cover the duration of the job.
+const serviceLockGrace = time.Minute
+
 // service represents a typical service in repository.
 type service struct {
 	name    string
@@ -20,6 +30,31 @@ type service struct {
 	log     logger.Logger
 	wg      *sync.WaitGroup
 	sigStop chan bool
+
+	// closed is set to non-zero value once the service has been signaled to stop
+	closed int32
+
+	// lastRun is the UNIX time in nanoseconds of the last service run
+	lastRun int64
+
+	// period is the scheduling period of a periodic service; zero for other services
+	period time.Duration
+
+	// jitter is the max relative deviation of the period applied to each run
+	// so periodic jobs of API peers sharing the database don't run in sync
+	jitter float64
+
+	// paused is set to non-zero value if the periodic runs are paused
+	paused int32
+
+	// sigTrigger signals a periodic service to run right away
+	sigTrigger chan bool
+
+	// holder identifies this API server instance in locks of exclusive jobs
+	holder string
+
+	// leading is set to non-zero value if the service holds the lock of its exclusive jobs
+	leading int32
 }
 
 // newService creates a new service instance
@@ -33,12 +68,199 @@ func newService(name string, repo Repository, log logger.Logger, wg *sync.WaitGr
 	}
 }
 
+// newPeriodicService creates a new instance of a service running a job periodically.
+// The scheduling period is configured by the orchestrator.
+func newPeriodicService(name string, repo Repository, log logger.Logger, wg *sync.WaitGroup) service {
+	se := newService(name, repo, log, wg)
+	se.sigTrigger = make(chan bool, 1)
+	return se
+}
+
+// configKey provides the key of the service in the scheduler configuration.
+func (se *service) configKey() string {
+	return strings.ReplaceAll(se.name, " ", "_")
+}
+
+// isPeriodic checks if the service runs a job periodically.
+func (se *service) isPeriodic() bool {
+	return se.sigTrigger != nil
+}
+
+// setSchedule sets the scheduling period and jitter of a periodic service.
+func (se *service) setSchedule(period time.Duration, jitter float64) {
+	se.period = period
+	se.jitter = jitter
+}
+
+// lockTTL provides the time the lock of exclusive jobs is held without renewal.
+// The lock is renewed on each run so it outlives the longest jittered period.
+func (se *service) lockTTL() time.Duration {
+	return 2*se.period + serviceLockGrace
+}
+
+// exclusive runs the job only if this API server instance holds the lock of the service,
+// so API peers sharing the database don't run the same heavy job redundantly.
+// The lock expires if the leader dies and another peer takes over on its next run.
+func (se *service) exclusive(job func()) {
+	ok, err := se.repo.AcquireServiceLock(se.configKey(), se.holder, se.lockTTL())
+	if err != nil {
+		se.log.Errorf("%s lock not available; %s", se.name, err.Error())
+		ok = false
+	}
+
+	// log the change of the leadership
+	var val int32
+	if ok {
+		val = 1
+	}
+	if atomic.SwapInt32(&se.leading, val) != val {
+		se.log.Noticef("%s leading: %t", se.name, ok)
+	}
+
+	if ok {
+		job()
+	}
+}
+
+// shared runs the job producing the result into the target only if this API server instance
+// holds the lock of the service and shares the result with peer instances. Peers load the result
+// shared by the leading instance into the target instead. Returns TRUE if the target was updated.
+func (se *service) shared(target interface{}, job func() error) bool {
+	var done bool
+	se.exclusive(func() {
+		if err := job(); err != nil {
+			se.log.Errorf("%s failed; %s", se.name, err.Error())
+			return
+		}
+		done = true
+
+		data, err := json.Marshal(target)
+		if err == nil {
+			err = se.repo.StoreServiceResult(se.configKey(), data)
+		}
+		if err != nil {
+			se.log.Errorf("%s result not shared; %s", se.name, err.Error())
+		}
+	})
+
+	// the leader is done, successfully or not
+	if atomic.LoadInt32(&se.leading) != 0 {
+		return done
+	}
+
+	// load the result of the leader
+	data, err := se.repo.ServiceResult(se.configKey())
+	if err != nil || data == nil {
+		return false
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		se.log.Errorf("%s result not loaded; %s", se.name, err.Error())
+		return false
+	}
+	return true
+}
+
+// release releases the lock of exclusive jobs, if held, so another peer can take over immediately.
+func (se *service) release() {
+	if atomic.SwapInt32(&se.leading, 0) == 0 {
+		return
+	}
+	if err := se.repo.ReleaseServiceLock(se.configKey(), se.holder); err != nil {
+		se.log.Errorf("%s lock not released; %s", se.name, err.Error())
+	}
+}
+
+// nextRun provides the delay of the next periodic run with the jitter applied.
+func (se *service) nextRun() time.Duration {
+	if se.jitter <= 0 {
+		return se.period
+	}
+	return time.Duration(float64(se.period) * (1 + se.jitter*(2*rand.Float64()-1)))
+}
+
+// pause pauses, or resumes the periodic runs of the service.
+func (se *service) pause(paused bool) {
+	var val int32
+	if paused {
+		val = 1
+	}
+	atomic.StoreInt32(&se.paused, val)
+}
+
+// trigger signals the periodic service to run right away, even if paused.
+func (se *service) trigger() {
+	select {
+	case se.sigTrigger <- true:
+	default:
+		// the run is already pending
+	}
+}
+
+// loop runs the job of a periodic service on schedule until the service is closed.
+// Scheduled runs are skipped while the service is paused, triggered runs are not.
+func (se *service) loop(job func()) {
+	timer := time.NewTimer(se.nextRun())
+	defer func() {
+		timer.Stop()
+		se.release()
+	}()
+
+	for {
+		select {
+		case <-se.sigStop:
+			return
+		case <-se.sigTrigger:
+			job()
+			se.markRun()
+		case <-timer.C:
+			if atomic.LoadInt32(&se.paused) == 0 {
+				job()
+				se.markRun()
+			}
+			timer.Reset(se.nextRun())
+		}
+	}
+}
+
 // Close signals the service to stop.
 func (se *service) close() {
 	// log action
 	se.log.Noticef("%s is closing", se.name)
 
+	// mark the state
+	atomic.StoreInt32(&se.closed, 1)
+
 	// send the signal and close the channel
 	se.sigStop <- true
 	close(se.sigStop)
 }
+
+// markRun records the time of the service run.
+func (se *service) markRun() {
+	atomic.StoreInt64(&se.lastRun, time.Now().UnixNano())
+}
+
+// state provides the current state of the service.
+func (se *service) state() types.ServiceState {
+	st := types.ServiceState{
+		Name:    se.name,
+		Running: atomic.LoadInt32(&se.closed) == 0,
+		Period:  se.period,
+		Paused:  atomic.LoadInt32(&se.paused) != 0,
+		Leader:  atomic.LoadInt32(&se.leading) != 0,
+	}
+
+	// did the service run already?
+	if lr := atomic.LoadInt64(&se.lastRun); lr > 0 {
+		st.LastRun = time.Unix(0, lr)
+	}
+	return st
+}
+
+// queueState provides the current state of a service processing the given queue.
+func queueState(se *service, length int, capacity int) types.ServiceState {
+	st := se.state()
+	st.QueueLength = length
+	st.QueueCapacity = capacity
+	return st
+}
@@ -0,0 +1,206 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+
+	// tokenListRequestTimeout represents the timeout of the curated token list download.
+	tokenListRequestTimeout = 10 * time.Second
+)
+
+// tokenListEntry represents a single token of a curated token list
+// in the common token list JSON format.
+type tokenListEntry struct {
+	Address    common.Address `json:"address"`
+	LogoURI    string         `json:"logoURI"`
+	Extensions struct {
+		Description string `json:"description"`
+		Website     string `json:"website"`
+	} `json:"extensions"`
+}
+
+// tokenMetaRegistry represents a service keeping the token metadata
+// registry in sync with the curated token list and the chain.
+type tokenMetaRegistry struct {
+	service
+}
+
+// newTokenMetaRegistry creates a new token metadata registry service.
+func newTokenMetaRegistry(repo Repository, log logger.Logger, wg *sync.WaitGroup) *tokenMetaRegistry {
+	return &tokenMetaRegistry{
+		service: newPeriodicService("token meta registry", repo, log, wg),
+	}
+}
+
+// run starts the token metadata registry service
+func (tmr *tokenMetaRegistry) run() {
+	tmr.wg.Add(1)
+	go tmr.schedule()
+}
+
+// schedule schedules regular token metadata refresh.
+func (tmr *tokenMetaRegistry) schedule() {
+	// inform about the service
+	tmr.log.Notice("token meta registry is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		tmr.log.Notice("token meta registry is closed")
+		tmr.wg.Done()
+	}()
+
+	// refresh the registry right away
+	tmr.exclusive(tmr.refresh)
+
+	// run on schedule
+	tmr.loop(func() {
+		tmr.exclusive(tmr.refresh)
+	})
+}
+
+// refresh updates the token metadata registry.
+func (tmr *tokenMetaRegistry) refresh() {
+	if err := tmr.repo.RefreshTokenMeta(); err != nil {
+		tmr.log.Errorf("can not refresh token metadata; %s", err.Error())
+	}
+}
+
+// TokenMeta provides curated metadata of the given token.
+// It returns nil if the token is not known to the registry.
+func (p *proxy) TokenMeta(addr *common.Address) (*types.TokenMeta, error) {
+	// try the cache; tokens without metadata are cached as well
+	tm, ok := p.cache.PullTokenMeta(addr)
+	if ok {
+		return tm, nil
+	}
+
+	// load the slow way
+	tm, err := p.db.TokenMeta(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep it in cache for future use
+	p.cache.PushTokenMeta(addr, tm)
+	return tm, nil
+}
+
+// RefreshTokenMeta merges the configured curated token list into the token
+// metadata registry and refreshes on-chain details of all the known tokens.
+// Tokens on the curated list are marked as whitelisted; other tokens
+// stored in the registry keep their whitelisting state.
+func (p *proxy) RefreshTokenMeta() error {
+	// load the curated list, if any
+	curated, err := p.loadTokenList()
+	if err != nil {
+		p.log.Errorf("can not load curated token list; %s", err.Error())
+	}
+
+	// get all the known tokens
+	list, err := p.db.TokenMetaList()
+	if err != nil {
+		return err
+	}
+
+	// merge the curated list into the known tokens
+	known := make(map[common.Address]*types.TokenMeta, len(list)+len(curated))
+	for _, tm := range list {
+		known[tm.Address] = tm
+	}
+	for _, te := range curated {
+		tm, ok := known[te.Address]
+		if !ok {
+			tm = &types.TokenMeta{Address: te.Address}
+			known[te.Address] = tm
+		}
+
+		tm.LogoURL = te.LogoURI
+		tm.Description = te.Extensions.Description
+		tm.Website = te.Extensions.Website
+		tm.Whitelisted = true
+	}
+
+	// refresh on-chain details and store
+	for addr, tm := range known {
+		token, err := p.loadErc20TokenDetails(&types.Erc20Token{Address: addr})
+		if err != nil {
+			continue
+		}
+
+		tm.Name = token.Name
+		tm.Symbol = token.Symbol
+		tm.Decimals = token.Decimals
+		tm.Updated = time.Now().UTC()
+		if err := p.db.StoreTokenMeta(tm); err != nil {
+			return err
+		}
+		p.cache.EvictTokenMeta(&tm.Address)
+	}
+
+	p.log.Debugf("token metadata refreshed for %d tokens", len(known))
+	return nil
+}
+
+// loadTokenList downloads the curated token list from the configured URL.
+func (p *proxy) loadTokenList() ([]tokenListEntry, error) {
+	// is there any list at all?
+	if p.cfg.TokenList.Url == "" {
+		return nil, nil
+	}
+
+	// prep the request
+	req, err := http.NewRequest(http.MethodGet, p.cfg.TokenList.Url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can not create HTTP request for token list; %s", err.Error())
+	}
+
+	// be honest, set agent
+	req.Header.Set("User-Agent", "Fantom GraphQL API Server")
+
+	// do the request
+	client := &http.Client{Timeout: tokenListRequestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("can not download token list; %s", err.Error())
+	}
+
+	// don't forget to close
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			p.log.Errorf("error closing token list request; %s", err.Error())
+		}
+	}()
+
+	// read the data
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("can not read token list; %s", err.Error())
+	}
+
+	// decode the list
+	var tl struct {
+		Tokens []tokenListEntry `json:"tokens"`
+	}
+	if err := json.Unmarshal(body, &tl); err != nil {
+		return nil, fmt.Errorf("can not decode token list; %s", err.Error())
+	}
+	return tl.Tokens, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	eth "github.com/ethereum/go-ethereum/rpc"
+	"math/big"
 )
 
 // ErrTransactionNotFound represents an error returned if a transaction can not be found.
@@ -22,6 +23,8 @@ var ErrTransactionNotFound = errors.New("requested transaction can not be found
 
 // StoreTransaction notifies a new incoming transaction from blockchain to the repository.
 func (p *proxy) StoreTransaction(block *types.Block, trx *types.Transaction) error {
+	// the transaction is indexed, no need to keep it in the pending overlay
+	p.pendingTrx.remove(&trx.Hash)
 	return p.db.AddTransaction(block, trx)
 }
 
@@ -43,8 +46,14 @@ func (p *proxy) Transaction(hash *common.Hash) (*types.Transaction, error) {
 		return trx, nil
 	}
 
-	// return the value
+	// return the value; transactions submitted by us may not be known to the node yet
 	trx, err := p.LoadTransaction(hash)
+	if err != nil || trx.Hash != *hash {
+		if pt := p.pendingTrx.get(hash); pt != nil {
+			p.log.Debugf("transaction %s loaded from pending overlay", hash.String())
+			return pt, nil
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -68,11 +77,27 @@ func (p *proxy) LoadTransaction(hash *common.Hash) (*types.Transaction, error) {
 	return p.rpc.Transaction(hash)
 }
 
+// TransactionRaw loads the RLP encoded transaction of the given hash from the node.
+func (p *proxy) TransactionRaw(hash *common.Hash) (hexutil.Bytes, error) {
+	return p.rpc.TransactionRaw(hash)
+}
+
+// TransactionReceipt loads the receipt of the transaction of the given hash from the node.
+// It returns nil if the transaction has not been processed yet.
+func (p *proxy) TransactionReceipt(hash *common.Hash) (*types.TransactionReceipt, error) {
+	return p.rpc.TransactionReceipt(hash)
+}
+
 // SendTransaction sends raw signed and RLP encoded transaction to the block chain.
 func (p *proxy) SendTransaction(tx hexutil.Bytes) (*types.Transaction, error) {
 	// log
 	p.log.Debugf("requested transaction submit for %s", tx.String())
 
+	// screen the parties of the transaction before it's submitted
+	if err := p.screenTransaction(tx); err != nil {
+		return nil, err
+	}
+
 	// try to send it and get the tx hash
 	hash, err := p.rpc.SendTransaction(tx)
 	if err != nil {
@@ -80,9 +105,21 @@ func (p *proxy) SendTransaction(tx hexutil.Bytes) (*types.Transaction, error) {
 		return nil, err
 	}
 
+	// keep the transaction in the pending overlay until it's indexed
+	pt, err := decodePendingTransaction(tx)
+	if err != nil {
+		p.log.Errorf("can not decode submitted transaction %s; %s", hash.String(), err.Error())
+	} else {
+		p.pendingTrx.add(pt)
+	}
+
 	// we do have the hash so we can use it to get the transaction details
 	// we always need to go to RPC and we will not try to store the transaction in cache yet
 	trx, err := p.rpc.Transaction(hash)
+	if pt != nil && (err != nil || trx.Hash != *hash) {
+		p.log.Debugf("transaction %s not known to the node yet", hash.String())
+		return pt, nil
+	}
 	if err != nil {
 		// transaction simply not found?
 		if err == eth.ErrNoResult {
@@ -130,3 +167,9 @@ func (p *proxy) Transactions(cursor *string, count int32) (*types.TransactionLis
 	// use slow trx list pulling
 	return p.db.Transactions(cursor, count, nil)
 }
+
+// LargeTransfers pulls list of transactions transferring at least the given value
+// of native tokens starting on the specified cursor.
+func (p *proxy) LargeTransfers(minValue *big.Int, cursor *string, count int32) (*types.TransactionList, error) {
+	return p.db.LargeTransactions(minValue, cursor, count)
+}
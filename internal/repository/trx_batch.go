@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SendTransactionBatch sends a list of raw signed and RLP encoded transactions
+// to the block chain in the given order. Transactions of each sender are checked
+// to form a continuous sequence of nonces following the sender's pending nonce;
+// a transaction breaking the sequence, and all the following transactions
+// of the same sender, are rejected without being submitted, since the node
+// would not be able to process them anyway. A failed submission rejects
+// the following transactions of the same sender the same way.
+func (p *proxy) SendTransactionBatch(txs []hexutil.Bytes) []*types.TransactionSubmission {
+	p.log.Debugf("requested submit of %d transactions", len(txs))
+
+	res := make([]*types.TransactionSubmission, len(txs))
+	decoded := make([]*types.Transaction, len(txs))
+
+	// decode all the transactions first so we can check nonces of each sender
+	next := make(map[common.Address]uint64)
+	broken := make(map[common.Address]bool)
+	for i, raw := range txs {
+		res[i] = &types.TransactionSubmission{Index: i}
+
+		trx, err := decodePendingTransaction(raw)
+		if err != nil {
+			res[i].Error = fmt.Errorf("invalid transaction; %s", err.Error())
+			continue
+		}
+		res[i].Hash = &trx.Hash
+		decoded[i] = trx
+
+		// an earlier transaction of the sender already broke the sequence
+		if broken[trx.From] {
+			res[i].Error = fmt.Errorf("previous transaction of %s rejected", trx.From.String())
+			continue
+		}
+
+		// the first transaction of the sender must follow the pending nonce
+		nonce, ok := next[trx.From]
+		if !ok {
+			pn, err := p.AccountPendingNonce(&trx.From)
+			if err != nil {
+				res[i].Error = fmt.Errorf("pending nonce of %s not available; %s", trx.From.String(), err.Error())
+				broken[trx.From] = true
+				continue
+			}
+			nonce = uint64(*pn)
+		}
+
+		if uint64(trx.Nonce) != nonce {
+			res[i].Error = fmt.Errorf("nonce gap, expected nonce %d, got %d", nonce, uint64(trx.Nonce))
+			broken[trx.From] = true
+			continue
+		}
+		next[trx.From] = nonce + 1
+	}
+
+	// submit the transactions passing the checks in order
+	failed := make(map[common.Address]bool)
+	for i, trx := range decoded {
+		if trx == nil || res[i].Error != nil {
+			continue
+		}
+
+		// a previous submission of the sender failed
+		if failed[trx.From] {
+			res[i].Error = fmt.Errorf("previous transaction of %s rejected", trx.From.String())
+			continue
+		}
+
+		sent, err := p.SendTransaction(txs[i])
+		if err != nil {
+			res[i].Error = err
+			failed[trx.From] = true
+			continue
+		}
+		res[i].Transaction = sent
+	}
+	return res
+}
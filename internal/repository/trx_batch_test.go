@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"crypto/ecdsa"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	eth "github.com/ethereum/go-ethereum/rpc"
+)
+
+// batchTestNode is a node accepting submitted transactions, except the ones
+// of the listed nonces of each sender.
+type batchTestNode struct {
+	Node
+	nonces map[common.Address]uint64
+	fail   map[common.Address]uint64
+	sent   []string
+}
+
+// AccountPendingNonce provides the configured pending nonce of the sender.
+func (n *batchTestNode) AccountPendingNonce(addr *common.Address) (uint64, error) {
+	return n.nonces[*addr], nil
+}
+
+// SendTransaction accepts the transaction unless its nonce is set to fail.
+func (n *batchTestNode) SendTransaction(tx hexutil.Bytes) (*common.Hash, error) {
+	trx, err := decodePendingTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if nonce, ok := n.fail[trx.From]; ok && nonce == uint64(trx.Nonce) {
+		return nil, fmt.Errorf("transaction underpriced")
+	}
+
+	n.sent = append(n.sent, batchTestLabel(trx.From, uint64(trx.Nonce)))
+	return &trx.Hash, nil
+}
+
+// Transaction never finds the transaction, it stays pending.
+func (n *batchTestNode) Transaction(*common.Hash) (*types.Transaction, error) {
+	return nil, eth.ErrNoResult
+}
+
+// batchTestLabel provides the label of a transaction of the test sender.
+func batchTestLabel(from common.Address, nonce uint64) string {
+	return fmt.Sprintf("%s/%d", from.String()[:6], nonce)
+}
+
+// batchTestTrx creates a raw signed transaction of the given sender and nonce.
+func batchTestTrx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64) hexutil.Bytes {
+	t.Helper()
+
+	to := common.HexToAddress("0x3Dc8A0C5B1cf2a98aaC49D5CB0F9E2dd9E3bC0aa")
+	tx, err := retypes.SignTx(retypes.NewTransaction(nonce, to, big.NewInt(1), 21000, big.NewInt(1e9), nil), retypes.NewEIP155Signer(big.NewInt(250)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+// TestSendTransactionBatch verifies transactions of each sender are submitted
+// only as a continuous sequence of nonces following the pending nonce.
+func TestSendTransactionBatch(t *testing.T) {
+	log := logger.New(&config.Config{AppName: "test", Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+
+	alice, err := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := crypto.PubkeyToAddress(alice.PublicKey), crypto.PubkeyToAddress(bob.PublicKey)
+
+	type trx struct {
+		key   *ecdsa.PrivateKey
+		nonce uint64
+		raw   hexutil.Bytes
+	}
+	tests := []struct {
+		name string
+		list []trx
+		fail map[common.Address]uint64
+		sent []string
+		errs []string
+	}{
+		{
+			name: "interleaved senders",
+			list: []trx{{key: alice, nonce: 5}, {key: bob, nonce: 2}, {key: alice, nonce: 6}, {key: bob, nonce: 3}},
+			sent: []string{batchTestLabel(a, 5), batchTestLabel(b, 2), batchTestLabel(a, 6), batchTestLabel(b, 3)},
+			errs: []string{"", "", "", ""},
+		},
+		{
+			name: "gap in the middle of a sequence",
+			list: []trx{{key: alice, nonce: 5}, {key: alice, nonce: 7}, {key: bob, nonce: 2}, {key: alice, nonce: 8}},
+			sent: []string{batchTestLabel(a, 5), batchTestLabel(b, 2)},
+			errs: []string{"", "nonce gap, expected nonce 6, got 7", "", "previous transaction"},
+		},
+		{
+			name: "undecodable transaction",
+			list: []trx{{key: alice, nonce: 5}, {raw: hexutil.Bytes{0xf8, 0x01, 0x02}}, {key: alice, nonce: 6}},
+			sent: []string{batchTestLabel(a, 5), batchTestLabel(a, 6)},
+			errs: []string{"", "invalid transaction", ""},
+		},
+		{
+			name: "failed submission rejects later transactions of the sender",
+			list: []trx{{key: alice, nonce: 5}, {key: bob, nonce: 2}, {key: alice, nonce: 6}, {key: bob, nonce: 3}},
+			fail: map[common.Address]uint64{a: 5},
+			sent: []string{batchTestLabel(b, 2), batchTestLabel(b, 3)},
+			errs: []string{"transaction underpriced", "", "previous transaction", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		node := &batchTestNode{nonces: map[common.Address]uint64{a: 5, b: 2}, fail: tt.fail}
+		p := &proxy{rpc: node, log: log, pendingTrx: newPendingTrxOverlay()}
+
+		txs := make([]hexutil.Bytes, len(tt.list))
+		for i, tx := range tt.list {
+			txs[i] = tx.raw
+			if tx.key != nil {
+				txs[i] = batchTestTrx(t, tx.key, tx.nonce)
+			}
+		}
+
+		res := p.SendTransactionBatch(txs)
+		if len(res) != len(txs) {
+			t.Fatalf("%s: expected %d results, got %d", tt.name, len(txs), len(res))
+		}
+
+		for i, ts := range res {
+			if ts.Index != i {
+				t.Errorf("%s: expected index %d, got %d", tt.name, i, ts.Index)
+			}
+			switch {
+			case tt.errs[i] == "" && ts.Error != nil:
+				t.Errorf("%s: transaction #%d failed; %s", tt.name, i, ts.Error.Error())
+			case tt.errs[i] == "" && ts.Transaction == nil:
+				t.Errorf("%s: transaction #%d not submitted", tt.name, i)
+			case tt.errs[i] != "" && (ts.Error == nil || !strings.Contains(ts.Error.Error(), tt.errs[i])):
+				t.Errorf("%s: transaction #%d expected to fail with %q, got %v", tt.name, i, tt.errs[i], ts.Error)
+			}
+		}
+
+		if strings.Join(node.sent, ",") != strings.Join(tt.sent, ",") {
+			t.Errorf("%s: expected submissions %v, got %v", tt.name, tt.sent, node.sent)
+		}
+	}
+}
@@ -17,7 +17,7 @@ import (
 func (p *proxy) EstimateTransactionsCount() (hexutil.Uint64, error) {
 	// ask for the value
 	val, err, _ := p.apiRequestGroup.Do("tx_count", func() (interface{}, error) {
-		return p.txCount, nil
+		return atomic.LoadUint64(p.txCount), nil
 	})
 	if err != nil {
 		return 0, err
@@ -36,12 +36,12 @@ func (p *proxy) MustEstimateTransactionsCount() hexutil.Uint64 {
 
 // IncTrxCountEstimate bumps the value of transaction counter estimator.
 func (p *proxy) IncTrxCountEstimate(diff uint64) {
-	atomic.AddUint64(&p.txCount, diff)
+	atomic.AddUint64(p.txCount, diff)
 }
 
 // UpdateTrxCountEstimate updates the value of transaction counter estimator.
 func (p *proxy) UpdateTrxCountEstimate(val uint64) {
-	atomic.StoreUint64(&p.txCount, val)
+	atomic.StoreUint64(p.txCount, val)
 	p.log.Infof("trx count estimator updated to %d", val)
 }
 
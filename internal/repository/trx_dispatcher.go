@@ -22,24 +22,48 @@ const trxDispatchQueueCapacity = 50000
 // trxDispatchBlockUpdateTicker represents the period of block registry updater.
 const trxDispatchBlockUpdateTicker = 15 * time.Second
 
+// trxTraceMinGas represents the minimal amount of gas used by a transaction
+// transferring value internally; the base transaction cost and the cheapest value
+// transferring CALL. Internal contract deployments cost even more.
+const trxTraceMinGas = 21000 + 9000
+
+// trxCreationStoreAttempts represents the number of attempts to store a contract creation record
+// before it's given up; trxCreationStoreDelay is the delay between the attempts.
+const (
+	trxCreationStoreAttempts = 5
+	trxCreationStoreDelay    = 2 * time.Second
+)
+
 // trxDispatcher implements dispatcher of new transactions in the blockchain.
 type trxDispatcher struct {
 	service
 	buffer chan *eventTransaction
+
+	// traceCreations enables call tracing of transactions to detect
+	// contracts deployed by other contracts and internal value transfers
+	traceCreations bool
 }
 
 // eventTransaction represents a single incoming transaction event to be processed.
 type eventTransaction struct {
 	block *types.Block
 	trx   *types.Transaction
+
+	// trace is the call trace of the transaction, if already loaded
+	trace *types.TransactionTrace
+
+	// classified signals the revert reason of the transaction
+	// has been captured already by the block pipeline
+	classified bool
 }
 
 // NewTrxDispatcher creates a new transaction dispatcher instance.
-func newTrxDispatcher(buffer chan *eventTransaction, repo Repository, log logger.Logger, wg *sync.WaitGroup) *trxDispatcher {
+func newTrxDispatcher(buffer chan *eventTransaction, traceCreations bool, repo Repository, log logger.Logger, wg *sync.WaitGroup) *trxDispatcher {
 	// create new dispatcher
 	return &trxDispatcher{
-		service: newService("trx dispatcher", repo, log, wg),
-		buffer:  buffer,
+		service:        newService("trx dispatcher", repo, log, wg),
+		buffer:         buffer,
+		traceCreations: traceCreations,
 	}
 }
 
@@ -116,6 +140,22 @@ func (td *trxDispatcher) process(evt *eventTransaction) {
 	// process transaction into the accounts
 	td.propagateTrxToAccounts(evt, &wg)
 
+	// look for contracts deployed by other contracts and internal transfers
+	if td.traceCreations && isTraceable(evt.trx) {
+		wg.Add(1)
+		go td.propagateTrace(evt, &wg)
+	}
+
+	// index addresses involved in the transaction
+	wg.Add(1)
+	go td.storeBloom(evt.trx, &wg)
+
+	// capture the reason of a failed transaction
+	if !evt.classified && evt.trx.Status != nil && *evt.trx.Status == 0 {
+		wg.Add(1)
+		go td.revertReason(evt.trx, &wg)
+	}
+
 	// process transaction logs
 	for _, lg := range evt.trx.Logs {
 		wg.Add(1)
@@ -127,6 +167,33 @@ func (td *trxDispatcher) process(evt *eventTransaction) {
 	go td.waitAndStore(evt.block, evt.trx, &wg)
 }
 
+// isTraceable checks if the transaction may deploy contracts, or transfer value internally,
+// so it's worth to be traced.
+func isTraceable(trx *types.Transaction) bool {
+	return trx.To != nil && trx.GasUsed != nil && uint64(*trx.GasUsed) >= trxTraceMinGas
+}
+
+// storeBloom adds addresses involved in the transaction into the bloom index of its block.
+func (td *trxDispatcher) storeBloom(trx *types.Transaction, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	if err := td.repo.StoreTransactionBloom(trx); err != nil {
+		td.log.Errorf("can not index bloom of trx %s; %s", trx.Hash.String(), err.Error())
+	}
+}
+
+// revertReason captures the revert reason of the failed transaction so it's stored along with it.
+func (td *trxDispatcher) revertReason(trx *types.Transaction, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	reason, err := td.repo.TransactionRevertReason(trx)
+	if err != nil {
+		td.log.Errorf("can not capture revert reason of trx %s; %s", trx.Hash.String(), err.Error())
+		return
+	}
+	trx.RevertReason = reason
+}
+
 // waitAndStore waits for the transaction processing to finish and stores the transaction into db.
 func (td *trxDispatcher) waitAndStore(blk *types.Block, trx *types.Transaction, wg *sync.WaitGroup) {
 	// wait until the trx is processed
@@ -153,11 +220,13 @@ func (td *trxDispatcher) propagateTrxToAccounts(evt *eventTransaction, wg *sync.
 	// the sender is always present
 	wg.Add(1)
 	td.repo.QueueAccount(evt.block, evt.trx, &evt.trx.From, nil, wg)
+	td.repo.NotifyAccountTouched(&evt.trx.From)
 
 	// do we have a recipient?
 	if evt.trx.To != nil {
 		wg.Add(1)
 		td.repo.QueueAccount(evt.block, evt.trx, evt.trx.To, nil, wg)
+		td.repo.NotifyAccountTouched(evt.trx.To)
 	}
 
 	// no contract creation? we are done
@@ -169,5 +238,58 @@ func (td *trxDispatcher) propagateTrxToAccounts(evt *eventTransaction, wg *sync.
 	td.log.Debugf("contract %s found at trx %s", evt.trx.ContractAddress.String(), evt.trx.Hash.String())
 	wg.Add(1)
 	td.repo.QueueAccount(evt.block, evt.trx, evt.trx.ContractAddress, &evt.trx.Hash, wg)
+	td.repo.NotifyAccountTouched(evt.trx.ContractAddress)
 	return
 }
+
+// propagateTrace traces the transaction for contracts deployed by other contracts
+// and pushes them to the accounts processing. Accounts involved in internal
+// value transfers are marked as touched so their cached state is dropped.
+func (td *trxDispatcher) propagateTrace(evt *eventTransaction, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// the trace may have been loaded already
+	trace := evt.trace
+	if trace == nil {
+		var err error
+		if trace, err = td.repo.TraceTransaction(evt.trx); err != nil {
+			td.log.Errorf("can not trace trx %s; %s", evt.trx.Hash.String(), err.Error())
+			return
+		}
+	}
+
+	for i := range trace.Touched {
+		td.repo.NotifyAccountTouched(&trace.Touched[i])
+	}
+
+	for _, cc := range trace.Creations {
+		td.log.Debugf("contract %s deployed by %s at trx %s", cc.Address.String(), cc.Creator.String(), evt.trx.Hash.String())
+		if err := td.storeContractCreation(cc); err != nil {
+			td.log.Criticalf("contract creation %s at trx %s lost, re-scan block #%d; %s",
+				cc.Address.String(), evt.trx.Hash.String(), uint64(evt.block.Number), err.Error())
+		}
+
+		// the contract account is created by the transaction
+		wg.Add(1)
+		td.repo.QueueAccount(evt.block, evt.trx, &cc.Address, &evt.trx.Hash, wg)
+	}
+}
+
+// storeContractCreation stores the contract creation record; failed writes are retried
+// so a temporary database failure does not drop the record.
+func (td *trxDispatcher) storeContractCreation(cc *types.ContractCreation) (err error) {
+	for attempt := 1; attempt <= trxCreationStoreAttempts; attempt++ {
+		if err = td.repo.StoreContractCreation(cc); err == nil {
+			return nil
+		}
+		td.log.Errorf("can not store contract creation %s, attempt %d; %s", cc.Address.String(), attempt, err.Error())
+
+		// wait before the next attempt, unless we are done
+		select {
+		case <-td.sigStop:
+			return err
+		case <-time.After(trxCreationStoreDelay):
+		}
+	}
+	return err
+}
@@ -16,13 +16,6 @@ import (
 )
 
 const (
-	// trxFlowUpdaterPeriod represents the period in which we do trx flow updates.
-	trxFlowUpdaterPeriod = 7 * time.Minute
-
-	// trxCountUpdaterPeriod represents the period in which the trx count estimation
-	// is updated from the underlying database.
-	trxCountUpdaterPeriod = 30 * time.Minute
-
 	// trxFlowUpdateRange represents the range for which we do the trx flow update.
 	trxFlowUpdateRange = -2 * 24 * time.Hour
 )
@@ -35,7 +28,7 @@ type txFlowUpdater struct {
 // NewTxFlowUpdater creates a new TX Flow updater service.
 func NewTxFlowUpdater(repo Repository, log logger.Logger, wg *sync.WaitGroup) *txFlowUpdater {
 	return &txFlowUpdater{
-		service: newService("trx flow updater", repo, log, wg),
+		service: newPeriodicService("trx flow updater", repo, log, wg),
 	}
 }
 
@@ -51,17 +44,8 @@ func (tfu *txFlowUpdater) schedule() {
 	// inform about the monitor
 	tfu.log.Notice("trx flow updater is running")
 
-	// make tickers
-	flowTicker := time.NewTicker(trxFlowUpdaterPeriod)
-	trxCountTicker := time.NewTicker(trxCountUpdaterPeriod)
-
 	// don't forget to sign off after we are done
 	defer func() {
-		// stop the ticker
-		flowTicker.Stop()
-		trxCountTicker.Stop()
-
-		// log finish and signal end
 		tfu.log.Notice("trx flow updater is closed")
 		tfu.wg.Done()
 	}()
@@ -69,19 +53,16 @@ func (tfu *txFlowUpdater) schedule() {
 	// do initial update
 	go tfu.updateTrxCountEstimate()
 
-	// loop here
-	for {
-		select {
-		case <-tfu.sigStop:
-			return
-		case <-flowTicker.C:
+	// run on schedule; the trx count estimation is cheap so we refresh it with the flow
+	tfu.loop(func() {
+		tfu.exclusive(func() {
 			tfu.log.Infof("calling for trx flow update")
 			tfu.repo.TrxFlowUpdate()
-		case <-trxCountTicker.C:
-			tfu.log.Infof("calling for trx count update")
-			go tfu.updateTrxCountEstimate()
-		}
-	}
+		})
+
+		// the estimation is kept in memory of each API server
+		go tfu.updateTrxCountEstimate()
+	})
 }
 
 // updateTrxCountEstimate updates trx counter estimation.
@@ -0,0 +1,140 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"sort"
+	"sync"
+	"time"
+)
+
+// pendingTrxOverlayTTL represents the max time a submitted transaction
+// is kept in the pending overlay if it never gets indexed.
+const pendingTrxOverlayTTL = 30 * time.Minute
+
+// pendingTrxOverlay keeps transactions submitted through the API
+// until they are indexed, so the clients can see their own transactions right away.
+type pendingTrxOverlay struct {
+	mu   sync.RWMutex
+	list map[common.Hash]*pendingTrx
+}
+
+// pendingTrx represents a single transaction of the pending overlay.
+type pendingTrx struct {
+	trx   *types.Transaction
+	added time.Time
+}
+
+// newPendingTrxOverlay creates a new empty pending transactions overlay.
+func newPendingTrxOverlay() *pendingTrxOverlay {
+	return &pendingTrxOverlay{list: make(map[common.Hash]*pendingTrx)}
+}
+
+// add adds the given transaction to the overlay.
+func (po *pendingTrxOverlay) add(trx *types.Transaction) {
+	po.mu.Lock()
+	defer po.mu.Unlock()
+	po.list[trx.Hash] = &pendingTrx{trx: trx, added: time.Now()}
+}
+
+// remove drops the transaction of the given hash from the overlay.
+func (po *pendingTrxOverlay) remove(hash *common.Hash) {
+	po.mu.Lock()
+	defer po.mu.Unlock()
+	delete(po.list, *hash)
+}
+
+// get provides the transaction of the given hash, if known.
+func (po *pendingTrxOverlay) get(hash *common.Hash) *types.Transaction {
+	po.mu.RLock()
+	defer po.mu.RUnlock()
+
+	pt, ok := po.list[*hash]
+	if !ok || time.Since(pt.added) > pendingTrxOverlayTTL {
+		return nil
+	}
+	return pt.trx
+}
+
+// account provides the list of transactions of the given account; expired transactions
+// are dropped from the overlay on the way.
+func (po *pendingTrxOverlay) account(addr *common.Address) []*types.Transaction {
+	po.mu.Lock()
+	defer po.mu.Unlock()
+
+	list := make([]*types.Transaction, 0)
+	for hash, pt := range po.list {
+		if time.Since(pt.added) > pendingTrxOverlayTTL {
+			delete(po.list, hash)
+			continue
+		}
+		if pt.trx.From == *addr || (pt.trx.To != nil && *pt.trx.To == *addr) {
+			list = append(list, pt.trx)
+		}
+	}
+	return list
+}
+
+// decodePendingTransaction builds the pending transaction representation
+// from the raw signed and RLP encoded transaction.
+func decodePendingTransaction(raw hexutil.Bytes) (*types.Transaction, error) {
+	tx := new(retypes.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, err
+	}
+
+	// recover the sender
+	from, err := retypes.Sender(retypes.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Transaction{
+		TimeStamp: time.Now().UTC(),
+		From:      from,
+		Gas:       hexutil.Uint64(tx.Gas()),
+		GasPrice:  hexutil.Big(*tx.GasPrice()),
+		Hash:      tx.Hash(),
+		Nonce:     hexutil.Uint64(tx.Nonce()),
+		To:        tx.To(),
+		Value:     hexutil.Big(*tx.Value()),
+		InputData: tx.Data(),
+	}, nil
+}
+
+// pendingAccountTransactions provides the list of pending transactions of the given account
+// known to the pending overlay, or to the node transaction pool, sorted by nonce.
+func (p *proxy) pendingAccountTransactions(addr *common.Address) []*types.Transaction {
+	list := p.pendingTrx.account(addr)
+
+	// add transactions from the node pool; the overlay may not know them
+	pool, err := p.rpc.AccountPendingTransactions(addr)
+	if err != nil {
+		p.log.Errorf("can not load pool transactions of %s; %s", addr.String(), err.Error())
+	}
+
+	for _, trx := range pool {
+		if p.pendingTrx.get(&trx.Hash) == nil {
+			list = append(list, trx)
+		}
+	}
+
+	// the newest transactions go first
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].From == list[j].From {
+			return list[i].Nonce > list[j].Nonce
+		}
+		return list[i].TimeStamp.After(list[j].TimeStamp)
+	})
+	return list
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+)
+
+// trxRevertOutOfGas is the revert reason of failed transactions which consumed all the gas provided.
+const trxRevertOutOfGas = "out of gas"
+
+// TransactionRevertReason resolves the reason the given failed transaction reverted.
+// Returns nil for successful transactions and for failed transactions
+// with the reason not recoverable by replaying the call.
+func (p *proxy) TransactionRevertReason(trx *types.Transaction) (*string, error) {
+	// only failed transactions have a revert reason
+	if trx.Status == nil || *trx.Status != 0 || trx.BlockNumber == nil {
+		return nil, nil
+	}
+
+	// all the gas has been consumed
+	if trx.GasUsed != nil && *trx.GasUsed >= trx.Gas {
+		reason := trxRevertOutOfGas
+		return &reason, nil
+	}
+
+	reason, err := p.rpc.TransactionRevertReason(trx)
+	if err != nil || reason == "" {
+		return nil, err
+	}
+	return &reason, nil
+}
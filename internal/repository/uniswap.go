@@ -14,8 +14,31 @@ func (p *proxy) NativeTokenAddress() (*common.Address, error) {
 }
 
 // UniswapPairs returns list of all token pairs managed by Uniswap core.
+// The list contains the white listed pairs and the pairs registered
+// from the factory pair creation events.
 func (p *proxy) UniswapPairs() ([]common.Address, error) {
-	return p.rpc.UniswapPairs()
+	list, err := p.rpc.UniswapPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	// add registered pairs not on the white list
+	reg, err := p.db.UniswapRegisteredPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[common.Address]bool, len(list))
+	for _, adr := range list {
+		known[adr] = true
+	}
+	for _, adr := range reg {
+		if !known[adr] {
+			known[adr] = true
+			list = append(list, adr)
+		}
+	}
+	return list, nil
 }
 
 // UniswapPair returns an address of an Uniswap pair for the given tokens.
@@ -134,3 +157,9 @@ func (p *proxy) UniswapTimeReserves(pairAddress *common.Address, resolution stri
 func (p *proxy) UniswapActions(pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
 	return p.db.UniswapActions(pairAddress, cursor, count, actionType)
 }
+
+// UniswapPositionEntries provides the aggregated liquidity added and removed
+// by the given owner on each Uniswap pair.
+func (p *proxy) UniswapPositionEntries(owner *common.Address) ([]*types.UniswapPositionEntry, error) {
+	return p.db.UniswapPositionEntries(owner)
+}
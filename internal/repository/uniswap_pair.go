@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// handleUniswapPairCreated registers a new pair created by the Uniswap factory.
+// event PairCreated(address indexed token0, address indexed token1, address pair, uint)
+func handleUniswapPairCreated(log *retypes.Log, ld *logsDispatcher) {
+	// the same event signature may be used by other factories
+	if log.Address != ld.repo.UniswapFactoryAddress() {
+		return
+	}
+
+	// sanity check for data
+	if len(log.Topics) != 3 || len(log.Data) != 64 {
+		ld.log.Errorf("%s log invalid for uniswap pair creation; expected 3 topics and 64 bytes of data, %d topics and %d bytes given",
+			log.TxHash.String(), len(log.Topics), len(log.Data))
+		return
+	}
+
+	up := types.UniswapPairCreated{
+		Pair:      common.BytesToAddress(log.Data[12:32]),
+		Token0:    common.BytesToAddress(log.Topics[1].Bytes()),
+		Token1:    common.BytesToAddress(log.Topics[2].Bytes()),
+		Block:     hexutil.Uint64(log.BlockNumber),
+		Trx:       log.TxHash,
+		TimeStamp: time.Now().UTC(),
+	}
+
+	// register the pair; logs of known blocks may be processed again
+	isNew, err := ld.repo.AddUniswapPair(&up)
+	if err != nil {
+		ld.log.Errorf("can not register uniswap pair %s; %s", up.Pair.String(), err.Error())
+		return
+	}
+	if isNew {
+		ld.log.Noticef("new uniswap pair %s of %s and %s registered", up.Pair.String(), up.Token0.String(), up.Token1.String())
+		ld.notifyUniswapPair(&up)
+	}
+}
+
+// notifyUniswapPair sends the newly created Uniswap pair to the registered events channel, if any.
+// The dispatcher must not be blocked by a slow consumer, so the event is dropped
+// if the channel is full.
+func (ld *logsDispatcher) notifyUniswapPair(up *types.UniswapPairCreated) {
+	if ld.onUniswapPair == nil {
+		return
+	}
+
+	select {
+	case ld.onUniswapPair <- up:
+	default:
+		ld.log.Errorf("uniswap pair %s event dropped, channel full", up.Pair.String())
+	}
+}
+
+// UniswapFactoryAddress returns the address of the Uniswap factory (core) contract.
+func (p *proxy) UniswapFactoryAddress() common.Address {
+	return p.cfg.DeFi.Uniswap.Core
+}
+
+// AddUniswapPair registers a Uniswap pair created by the factory.
+// It returns FALSE if the pair has already been registered.
+func (p *proxy) AddUniswapPair(up *types.UniswapPairCreated) (bool, error) {
+	return p.db.AddUniswapPair(up)
+}
+
+// SetUniswapPairChannel registers a channel for notifying new Uniswap pair events.
+func (p *proxy) SetUniswapPairChannel(ch chan *types.UniswapPairCreated) {
+	p.orc.setUniswapPairChannel(ch)
+}
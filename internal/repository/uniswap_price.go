@@ -0,0 +1,294 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+)
+
+const (
+
+	// uniswapPriceMaxHops is the max number of pairs a token price can be routed through.
+	uniswapPriceMaxHops = 3
+
+	// uniswapPriceNative is the symbol of the native token price.
+	uniswapPriceNative = "FTM"
+
+	// uniswapPriceStable is the symbol the stable tokens are pegged to.
+	uniswapPriceStable = "USD"
+)
+
+// uniswapPriceRouter represents a service keeping periodically refreshed
+// reserves of Uniswap pairs used to route ERC20 token prices.
+type uniswapPriceRouter struct {
+	service
+	mu    sync.RWMutex
+	pairs []*types.UniswapPairReserves
+}
+
+// uniswapPriceHop represents a single swap step of a price route.
+type uniswapPriceHop struct {
+	from, to      common.Address
+	resIn, resOut *big.Float
+	decIn, decOut int32
+	pair          common.Address
+}
+
+// newUniswapPriceRouter creates a new Uniswap price router service.
+func newUniswapPriceRouter(repo Repository, log logger.Logger, wg *sync.WaitGroup) *uniswapPriceRouter {
+	return &uniswapPriceRouter{
+		service: newPeriodicService("uniswap price router", repo, log, wg),
+	}
+}
+
+// run starts the uniswap price router service
+func (upr *uniswapPriceRouter) run() {
+	upr.wg.Add(1)
+	go upr.schedule()
+}
+
+// schedule schedules regular pairs reserves updates.
+func (upr *uniswapPriceRouter) schedule() {
+	// inform about the service
+	upr.log.Notice("uniswap price router is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		upr.log.Notice("uniswap price router is closed")
+		upr.wg.Done()
+	}()
+
+	// load the reserves right away
+	upr.update()
+
+	// run on schedule
+	upr.loop(func() {
+		upr.update()
+	})
+}
+
+// update refreshes the pairs reserves; the reserves are loaded by the leading
+// API server instance, but they are kept in memory of each of them.
+func (upr *uniswapPriceRouter) update() {
+	var list []*types.UniswapPairReserves
+	if !upr.shared(&list, func() (err error) {
+		list, err = upr.repo.UniswapPairsReserves()
+		return err
+	}) {
+		return
+	}
+
+	upr.mu.Lock()
+	upr.pairs = list
+	upr.mu.Unlock()
+	upr.log.Debugf("uniswap price router refreshed with %d pairs", len(list))
+}
+
+// snapshot provides the current pairs reserves, if available.
+func (upr *uniswapPriceRouter) snapshot() []*types.UniswapPairReserves {
+	upr.mu.RLock()
+	defer upr.mu.RUnlock()
+	return upr.pairs
+}
+
+// UniswapPairsReserves loads the current reserves of all the indexed Uniswap pairs.
+func (p *proxy) UniswapPairsReserves() ([]*types.UniswapPairReserves, error) {
+	pairs, err := p.UniswapPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.UniswapPairReserves, 0, len(pairs))
+	for i := range pairs {
+		pr, err := p.uniswapPairReserves(&pairs[i])
+		if err != nil {
+			p.log.Errorf("can not load reserves of pair %s; %s", pairs[i].String(), err.Error())
+			continue
+		}
+		list = append(list, pr)
+	}
+	return list, nil
+}
+
+// uniswapPairReserves loads the current state of the given Uniswap pair.
+func (p *proxy) uniswapPairReserves(pair *common.Address) (*types.UniswapPairReserves, error) {
+	tokens, err := p.UniswapTokens(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.UniswapReserves(pair)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) != 2 || len(res) < 2 {
+		return nil, fmt.Errorf("invalid pair structure")
+	}
+
+	pr := types.UniswapPairReserves{Pair: *pair}
+	for i := 0; i < 2; i++ {
+		token, err := p.Erc20Token(&tokens[i])
+		if err != nil {
+			return nil, err
+		}
+		pr.Tokens[i] = tokens[i]
+		pr.Decimals[i] = token.Decimals
+		pr.Reserves[i] = res[i]
+	}
+	return &pr, nil
+}
+
+// Erc20TokenPrice calculates the price of the given ERC20 token in the target symbol
+// by routing through the indexed Uniswap pairs to the wrapped FTM, or to a stable token.
+// The route with the highest liquidity is used. It returns nil if there is no route.
+func (p *proxy) Erc20TokenPrice(token *common.Address, sym string) (*types.TokenPrice, error) {
+	sym = strings.ToUpper(sym)
+	targets, err := p.uniswapPriceTargets(sym)
+	if err != nil {
+		return nil, err
+	}
+
+	// the token may be the target itself; there is no route to measure liquidity of
+	if mul, ok := targets[*token]; ok {
+		return &types.TokenPrice{Symbol: sym, Price: mul, Route: []common.Address{*token}}, nil
+	}
+
+	// get the pairs graph
+	pairs := p.orc.upr.snapshot()
+	if pairs == nil {
+		if pairs, err = p.UniswapPairsReserves(); err != nil {
+			return nil, err
+		}
+	}
+
+	// find the best route
+	var best *types.TokenPrice
+	walkUniswapRoutes(uniswapPriceGraph(pairs), *token, targets, func(route []*uniswapPriceHop, mul float64) {
+		tp := uniswapRoutePrice(route, mul)
+		if best == nil || tp.Liquidity > best.Liquidity {
+			tp.Symbol = sym
+			best = tp
+		}
+	})
+	return best, nil
+}
+
+// uniswapPriceTargets provides the tokens a price can be routed to
+// with the price of the target token in the given symbol.
+func (p *proxy) uniswapPriceTargets(sym string) (map[common.Address]float64, error) {
+	targets := make(map[common.Address]float64)
+
+	// stable tokens represent USD directly
+	if sym == uniswapPriceStable {
+		for _, st := range p.cfg.DeFi.Uniswap.StableTokens {
+			targets[st] = 1
+		}
+	}
+
+	// the wrapped FTM is priced by the FTM price
+	if sym == uniswapPriceNative {
+		targets[p.cfg.DeFi.Uniswap.WrappedNative] = 1
+		return targets, nil
+	}
+	pri, err := p.Price(sym)
+	if err != nil {
+		// we may still be able to use the stable tokens
+		if len(targets) > 0 {
+			return targets, nil
+		}
+		return nil, err
+	}
+	targets[p.cfg.DeFi.Uniswap.WrappedNative] = pri.Price
+	return targets, nil
+}
+
+// uniswapPriceGraph builds the map of swap steps available for each token.
+func uniswapPriceGraph(pairs []*types.UniswapPairReserves) map[common.Address][]*uniswapPriceHop {
+	graph := make(map[common.Address][]*uniswapPriceHop)
+	for _, pr := range pairs {
+		// skip pairs without liquidity
+		if pr.Reserves[0].ToInt().Sign() <= 0 || pr.Reserves[1].ToInt().Sign() <= 0 {
+			continue
+		}
+
+		for i := 0; i < 2; i++ {
+			j := 1 - i
+			graph[pr.Tokens[i]] = append(graph[pr.Tokens[i]], &uniswapPriceHop{
+				from:   pr.Tokens[i],
+				to:     pr.Tokens[j],
+				resIn:  new(big.Float).SetInt(pr.Reserves[i].ToInt()),
+				resOut: new(big.Float).SetInt(pr.Reserves[j].ToInt()),
+				decIn:  pr.Decimals[i],
+				decOut: pr.Decimals[j],
+				pair:   pr.Pair,
+			})
+		}
+	}
+	return graph
+}
+
+// walkUniswapRoutes calls the visitor for each route from the given token to one of the targets.
+func walkUniswapRoutes(graph map[common.Address][]*uniswapPriceHop, token common.Address, targets map[common.Address]float64, visit func([]*uniswapPriceHop, float64)) {
+	visited := map[common.Address]bool{token: true}
+	route := make([]*uniswapPriceHop, 0, uniswapPriceMaxHops)
+
+	var walk func(common.Address)
+	walk = func(from common.Address) {
+		for _, hop := range graph[from] {
+			if visited[hop.to] {
+				continue
+			}
+
+			route = append(route, hop)
+			if mul, ok := targets[hop.to]; ok {
+				visit(route, mul)
+			} else if len(route) < uniswapPriceMaxHops {
+				visited[hop.to] = true
+				walk(hop.to)
+				visited[hop.to] = false
+			}
+			route = route[:len(route)-1]
+		}
+	}
+	walk(token)
+}
+
+// uniswapRoutePrice calculates the price and liquidity of the given route.
+// The liquidity is the lowest value of the output reserves along the route.
+func uniswapRoutePrice(route []*uniswapPriceHop, mul float64) *types.TokenPrice {
+	tp := types.TokenPrice{Route: make([]common.Address, len(route)+1), Liquidity: math.Inf(1)}
+	tp.Route[0] = route[0].from
+
+	// walk the route backwards so we know the price of each output token
+	price := mul
+	for i := len(route) - 1; i >= 0; i-- {
+		hop := route[i]
+		tp.Route[i+1] = hop.to
+
+		out, _ := new(big.Float).Quo(hop.resOut, decimalsUnit(hop.decOut)).Float64()
+		in, _ := new(big.Float).Quo(hop.resIn, decimalsUnit(hop.decIn)).Float64()
+		tp.Liquidity = math.Min(tp.Liquidity, out*price)
+
+		price = price * out / in
+	}
+	tp.Price = price
+	return &tp
+}
+
+// decimalsUnit provides the value of a whole token with the given decimals.
+func decimalsUnit(dec int32) *big.Float {
+	return new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(dec)), nil))
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// uniswapRouteEdge represents a step of a swap route over a Uniswap pair.
+type uniswapRouteEdge struct {
+	pair  common.Address
+	token common.Address
+}
+
+// uniswapRouteSearch represents the state of the best swap route search.
+type uniswapRouteSearch struct {
+	p        *proxy
+	edges    map[common.Address][]uniswapRouteEdge
+	reserves map[common.Address][]hexutil.Big
+	target   common.Address
+	maxHops  int
+
+	// the current path and the best path found so far
+	tokens  []common.Address
+	pairs   []common.Address
+	best    *types.UniswapRoute
+	bestOut *big.Int
+}
+
+// UniswapBestRoute finds the swap route over the known Uniswap pairs providing
+// the highest output amount for the given input amount. The route is limited
+// to the given number of hops. It returns nil if the tokens are not connected.
+func (p *proxy) UniswapBestRoute(tokenIn common.Address, tokenOut common.Address, amountIn hexutil.Big, maxHops int) (*types.UniswapRoute, error) {
+	edges, err := p.uniswapRouteEdges()
+	if err != nil {
+		return nil, err
+	}
+
+	rs := uniswapRouteSearch{
+		p:        p,
+		edges:    edges,
+		reserves: make(map[common.Address][]hexutil.Big),
+		target:   tokenOut,
+		maxHops:  maxHops,
+		tokens:   []common.Address{tokenIn},
+		pairs:    make([]common.Address, 0),
+	}
+	rs.walk(tokenIn, amountIn.ToInt())
+	if rs.best == nil {
+		return nil, nil
+	}
+
+	// the router provides the exact amounts of the best route
+	rs.best.Amounts, err = p.rpc.UniswapAmountsOut(amountIn, rs.best.Tokens)
+	if err != nil {
+		return nil, err
+	}
+	return rs.best, nil
+}
+
+// uniswapRouteEdges builds the map of tokens to the pairs they can be swapped on.
+func (p *proxy) uniswapRouteEdges() (map[common.Address][]uniswapRouteEdge, error) {
+	pairs, err := p.UniswapPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make(map[common.Address][]uniswapRouteEdge)
+	for _, pair := range pairs {
+		tokens, err := p.UniswapTokens(&pair)
+		if err != nil || len(tokens) != 2 {
+			p.log.Errorf("tokens of uniswap pair %s not available", pair.String())
+			continue
+		}
+
+		edges[tokens[0]] = append(edges[tokens[0]], uniswapRouteEdge{pair: pair, token: tokens[1]})
+		edges[tokens[1]] = append(edges[tokens[1]], uniswapRouteEdge{pair: pair, token: tokens[0]})
+	}
+	return edges, nil
+}
+
+// walk explores the swap routes from the given token holding the given amount.
+func (rs *uniswapRouteSearch) walk(token common.Address, amount *big.Int) {
+	// we reached the target token; is it the best route so far?
+	if token == rs.target {
+		if rs.bestOut == nil || amount.Cmp(rs.bestOut) > 0 {
+			rs.bestOut = amount
+			rs.best = &types.UniswapRoute{
+				Tokens: append(make([]common.Address, 0, len(rs.tokens)), rs.tokens...),
+				Pairs:  append(make([]common.Address, 0, len(rs.pairs)), rs.pairs...),
+			}
+		}
+		return
+	}
+
+	// can we make another step?
+	if len(rs.pairs) >= rs.maxHops {
+		return
+	}
+
+	for _, e := range rs.edges[token] {
+		// do not visit the same token twice on the path
+		if rs.onPath(e.token) {
+			continue
+		}
+
+		out := rs.amountOut(e.pair, token, amount)
+		if out == nil || out.Sign() <= 0 {
+			continue
+		}
+
+		rs.tokens = append(rs.tokens, e.token)
+		rs.pairs = append(rs.pairs, e.pair)
+		rs.walk(e.token, out)
+		rs.tokens = rs.tokens[:len(rs.tokens)-1]
+		rs.pairs = rs.pairs[:len(rs.pairs)-1]
+	}
+}
+
+// onPath checks if the given token is already on the current path.
+func (rs *uniswapRouteSearch) onPath(token common.Address) bool {
+	for _, t := range rs.tokens {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
+
+// amountOut calculates the output amount of a swap on the given pair
+// using the constant product formula with the 0.3% LP fee, the same way the router does.
+func (rs *uniswapRouteSearch) amountOut(pair common.Address, tokenIn common.Address, amountIn *big.Int) *big.Int {
+	res, ok := rs.reserves[pair]
+	if !ok {
+		var err error
+		res, err = rs.p.UniswapReserves(&pair)
+		if err != nil || len(res) != 2 {
+			rs.p.log.Errorf("reserves of uniswap pair %s not available", pair.String())
+			res = nil
+		}
+		rs.reserves[pair] = res
+	}
+	if res == nil {
+		return nil
+	}
+
+	tokens, err := rs.p.UniswapTokens(&pair)
+	if err != nil {
+		return nil
+	}
+
+	// reserves are in the order of the pair tokens
+	rIn, rOut := res[0].ToInt(), res[1].ToInt()
+	if tokens[0] != tokenIn {
+		rIn, rOut = rOut, rIn
+	}
+	if rIn.Sign() == 0 || rOut.Sign() == 0 {
+		return nil
+	}
+
+	// out = in * 997 * rOut / (rIn * 1000 + in * 997)
+	inFee := new(big.Int).Mul(amountIn, big.NewInt(997))
+	num := new(big.Int).Mul(inFee, rOut)
+	den := new(big.Int).Add(new(big.Int).Mul(rIn, big.NewInt(1000)), inFee)
+	return num.Quo(num, den)
+}
@@ -0,0 +1,153 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"sync"
+	"time"
+)
+
+// usageMeter accumulates the API usage of client origins in hourly windows
+// until it's flushed into the database.
+type usageMeter struct {
+	mu   sync.Mutex
+	data map[usageKey]*types.UsageRecord
+}
+
+// usageKey identifies the usage of an origin in an hourly window.
+type usageKey struct {
+	origin string
+	hour   int64
+}
+
+// newUsageMeter creates a new empty usage meter.
+func newUsageMeter() *usageMeter {
+	return &usageMeter{data: make(map[usageKey]*types.UsageRecord)}
+}
+
+// add adds the given usage to the current hourly window of the origin.
+func (um *usageMeter) add(origin string, requests int, bytes int, cost time.Duration) {
+	hour := time.Now().UTC().Truncate(time.Hour)
+	key := usageKey{origin: origin, hour: hour.Unix()}
+
+	um.mu.Lock()
+	defer um.mu.Unlock()
+
+	ur, ok := um.data[key]
+	if !ok {
+		ur = &types.UsageRecord{Origin: origin, From: hour, To: hour.Add(time.Hour)}
+		um.data[key] = ur
+	}
+	ur.Requests += uint64(requests)
+	ur.Bytes += uint64(bytes)
+	ur.Cost += uint64(cost.Milliseconds())
+}
+
+// take provides the accumulated usage and resets the meter.
+func (um *usageMeter) take() []*types.UsageRecord {
+	um.mu.Lock()
+	data := um.data
+	um.data = make(map[usageKey]*types.UsageRecord)
+	um.mu.Unlock()
+
+	list := make([]*types.UsageRecord, 0, len(data))
+	for _, ur := range data {
+		list = append(list, ur)
+	}
+	return list
+}
+
+// restore returns the given usage not stored yet into the meter.
+func (um *usageMeter) restore(list []*types.UsageRecord) {
+	um.mu.Lock()
+	defer um.mu.Unlock()
+
+	for _, ur := range list {
+		key := usageKey{origin: ur.Origin, hour: ur.From.Unix()}
+		if cur, ok := um.data[key]; ok {
+			cur.Requests += ur.Requests
+			cur.Bytes += ur.Bytes
+			cur.Cost += ur.Cost
+			continue
+		}
+		um.data[key] = ur
+	}
+}
+
+// usageRecorder represents a service storing the accumulated API usage
+// into the database on regular basis.
+type usageRecorder struct {
+	service
+}
+
+// newUsageRecorder creates a new usage recorder service.
+func newUsageRecorder(repo Repository, log logger.Logger, wg *sync.WaitGroup) *usageRecorder {
+	return &usageRecorder{
+		service: newPeriodicService("usage recorder", repo, log, wg),
+	}
+}
+
+// run starts the usage recorder service
+func (ur *usageRecorder) run() {
+	ur.wg.Add(1)
+	go ur.schedule()
+}
+
+// schedule schedules regular usage storing rounds.
+func (ur *usageRecorder) schedule() {
+	// inform about the service
+	ur.log.Notice("usage recorder is running")
+
+	// don't forget to sign off after we are done; the usage
+	// accumulated since the last round is stored on the way out
+	defer func() {
+		ur.flush()
+		ur.log.Notice("usage recorder is closed")
+		ur.wg.Done()
+	}()
+
+	// run on schedule; each API peer stores its own usage
+	ur.loop(ur.flush)
+}
+
+// flush stores the accumulated usage.
+func (ur *usageRecorder) flush() {
+	if err := ur.repo.FlushUsage(); err != nil {
+		ur.log.Errorf("can not store API usage; %s", err.Error())
+	}
+}
+
+// RecordUsage adds the given API usage to the usage of the client origin.
+// The usage is kept in memory and stored into the database on regular basis.
+func (p *proxy) RecordUsage(origin string, requests int, bytes int, cost time.Duration) {
+	p.usage.add(origin, requests, bytes, cost)
+}
+
+// FlushUsage stores the API usage accumulated since the last flush.
+// Usage not stored is returned to the meter for the next round.
+func (p *proxy) FlushUsage() error {
+	list := p.usage.take()
+	if len(list) == 0 {
+		return nil
+	}
+
+	if done, err := p.db.AddUsage(list); err != nil {
+		p.usage.restore(list[done:])
+		return err
+	}
+	return nil
+}
+
+// UsageReport provides the API usage of all the client origins in the given time range,
+// the heaviest users by the compute cost go first.
+func (p *proxy) UsageReport(from time.Time, to time.Time) ([]*types.UsageRecord, error) {
+	return p.db.UsageReport(from, to)
+}
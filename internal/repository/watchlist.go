@@ -0,0 +1,151 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"sync"
+	"time"
+)
+
+const (
+	// watchDigestPeriod represents the length of a single watch digest period.
+	watchDigestPeriod = time.Hour
+
+	// watchDigestMaxCatchUp represents the max number of past digest periods
+	// processed in a single round, i.e. after the API server was down.
+	watchDigestMaxCatchUp = 24
+)
+
+// watchDigestBuilder represents a service aggregating the activity
+// of watched addresses into periodic digests.
+type watchDigestBuilder struct {
+	service
+}
+
+// newWatchDigestBuilder creates a new watch digest builder service.
+func newWatchDigestBuilder(repo Repository, log logger.Logger, wg *sync.WaitGroup) *watchDigestBuilder {
+	return &watchDigestBuilder{
+		service: newPeriodicService("watch digest builder", repo, log, wg),
+	}
+}
+
+// run starts the watch digest builder service
+func (wdb *watchDigestBuilder) run() {
+	wdb.wg.Add(1)
+	go wdb.schedule()
+}
+
+// schedule schedules regular digest updates.
+func (wdb *watchDigestBuilder) schedule() {
+	// inform about the service
+	wdb.log.Notice("watch digest builder is running")
+
+	// don't forget to sign off after we are done
+	defer func() {
+		wdb.log.Notice("watch digest builder is closed")
+		wdb.wg.Done()
+	}()
+
+	// run on schedule
+	wdb.loop(func() {
+		wdb.exclusive(func() {
+			if err := wdb.repo.UpdateWatchDigests(); err != nil {
+				wdb.log.Errorf("can not update watch digests; %s", err.Error())
+			}
+		})
+	})
+}
+
+// UpdateWatchDigests aggregates the activity of watched addresses
+// in the digest periods finished since the last update.
+func (p *proxy) UpdateWatchDigests() error {
+	last, err := p.db.WatchDigestState()
+	if err != nil {
+		return err
+	}
+
+	// we don't go back too far
+	now := time.Now().UTC().Truncate(watchDigestPeriod)
+	if from := now.Add(-watchDigestMaxCatchUp * watchDigestPeriod); last.Before(from) {
+		last = from
+	}
+	if !last.Before(now) {
+		return nil
+	}
+
+	list, err := p.db.WatchedAddresses()
+	if err != nil {
+		return err
+	}
+
+	for ; last.Before(now); last = last.Add(watchDigestPeriod) {
+		to := last.Add(watchDigestPeriod)
+		for i := range list {
+			wd, err := p.db.WatchDigestCompute(&list[i], last, to)
+			if err != nil {
+				return err
+			}
+
+			// don't store empty periods
+			if wd.IsEmpty() {
+				continue
+			}
+			if err := p.db.AddWatchDigest(wd); err != nil {
+				return err
+			}
+		}
+
+		// the period is done
+		if err := p.db.SetWatchDigestState(to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddWatchedAddress adds the address to the watch list of its owner.
+func (p *proxy) AddWatchedAddress(wa *types.WatchedAddress) error {
+	return p.db.AddWatchedAddress(wa)
+}
+
+// RemoveWatchedAddress removes the address from the watch list of the given owner.
+func (p *proxy) RemoveWatchedAddress(owner string, addr *common.Address) (bool, error) {
+	return p.db.RemoveWatchedAddress(owner, addr)
+}
+
+// WatchList loads the watch list of the given owner.
+func (p *proxy) WatchList(owner string) ([]*types.WatchedAddress, error) {
+	return p.db.WatchList(owner)
+}
+
+// WatchListSize provides the number of addresses on the watch list of the given owner.
+func (p *proxy) WatchListSize(owner string) (int64, error) {
+	return p.db.WatchListSize(owner)
+}
+
+// WatchListDigest provides the aggregated activity of addresses on the watch list
+// of the given owner since the given time.
+func (p *proxy) WatchListDigest(owner string, since time.Time) ([]*types.WatchDigest, error) {
+	wl, err := p.db.WatchList(owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(wl) == 0 {
+		return []*types.WatchDigest{}, nil
+	}
+
+	addrs := make([]common.Address, len(wl))
+	for i, wa := range wl {
+		addrs[i] = wa.Address
+	}
+	return p.db.WatchDigests(addrs, since)
+}
@@ -0,0 +1,122 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// webhookIdleConnTimeout is the time an idle connection to a webhook is kept open.
+const webhookIdleConnTimeout = 90 * time.Second
+
+// nonPublicNetworks lists the address ranges webhooks are not allowed to reach;
+// loopback, private, link-local (incl. the cloud metadata endpoint), shared, reserved
+// and multicast ranges of both IPv4 and IPv6.
+var nonPublicNetworks = parseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+// webhookClient is the HTTP client delivering alerts to the webhooks of API clients.
+// It connects to public addresses only and does not follow redirects, so a webhook
+// can not be used to reach the internal network of the API server.
+var webhookClient = &http.Client{
+	Timeout: alertWebhookTimeout,
+	Transport: &http.Transport{
+		DialContext:         publicDialContext,
+		TLSHandshakeTimeout: alertWebhookTimeout,
+		MaxIdleConns:        10,
+		IdleConnTimeout:     webhookIdleConnTimeout,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// parseNetworks parses the list of CIDR notated networks.
+func parseNetworks(cidr ...string) []*net.IPNet {
+	list := make([]*net.IPNet, len(cidr))
+	for i, c := range cidr {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		list[i] = n
+	}
+	return list
+}
+
+// IsPublicIP checks if the given IP address is a public unicast address
+// a webhook is allowed to connect to.
+func IsPublicIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	for _, n := range nonPublicNetworks {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// publicDialContext resolves the address and connects to it only if all the resolved
+// IP addresses are public. The verified IP is dialed directly so the name
+// can not be re-resolved to a different address in between.
+func publicDialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		if !IsPublicIP(ip.IP) {
+			return nil, fmt.Errorf("webhook host %s resolves to non-public address %s", host, ip.IP.String())
+		}
+	}
+
+	dialer := net.Dialer{Timeout: alertWebhookTimeout}
+	for _, ip := range ips {
+		var conn net.Conn
+		conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip.IP.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("webhook host %s not resolved", host)
+	}
+	return nil, err
+}
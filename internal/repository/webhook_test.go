@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip     string
+		public bool
+	}{
+		{"1.1.1.1", true},
+		{"104.16.0.1", true},
+		{"2606:4700::1111", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.20.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+		{"::1", false},
+		{"::", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+	}
+	for _, tt := range tests {
+		if got := IsPublicIP(net.ParseIP(tt.ip)); got != tt.public {
+			t.Errorf("IsPublicIP(%s) = %v, want %v", tt.ip, got, tt.public)
+		}
+	}
+}
+
+func TestPublicDialContextRefusesLocal(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1:443", "[::1]:443", "169.254.169.254:80", "localhost:443"} {
+		conn, err := publicDialContext(context.Background(), "tcp", addr)
+		if err == nil {
+			_ = conn.Close()
+			t.Fatalf("dial to %s allowed", addr)
+		}
+		if !strings.Contains(err.Error(), "non-public") {
+			t.Errorf("dial to %s failed with unexpected error %s", addr, err.Error())
+		}
+	}
+}
+
+func TestWebhookClientDoesNotFollowRedirects(t *testing.T) {
+	if err := webhookClient.CheckRedirect(&http.Request{}, nil); err != http.ErrUseLastResponse {
+		t.Errorf("redirect not refused; %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+// Package harness implements an integration test harness of the API server.
+// It runs the GraphQL resolvers against the API repository backed by a simulated
+// chain node and the in-memory store, so resolver changes can be verified without
+// access to an Opera node, or a Mongo database.
+package harness
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// chainGasLimit is the gas limit of blocks of the simulated chain.
+const chainGasLimit = 8000000
+
+// transferGas is the amount of gas of a plain value transfer.
+const transferGas = 21000
+
+// Chain represents a simulated chain with a set of funded accounts.
+type Chain struct {
+	backend *backends.SimulatedBackend
+	signer  retypes.Signer
+	keys    []*ecdsa.PrivateKey
+}
+
+// NewChain creates a simulated chain with the given number of accounts,
+// each funded with the given balance in the genesis block.
+func NewChain(accounts int, balance *big.Int) (*Chain, error) {
+	alloc := make(core.GenesisAlloc)
+	keys := make([]*ecdsa.PrivateKey, accounts)
+
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		keys[i] = key
+		alloc[crypto.PubkeyToAddress(key.PublicKey)] = core.GenesisAccount{Balance: new(big.Int).Set(balance)}
+	}
+
+	return &Chain{
+		backend: backends.NewSimulatedBackend(alloc, chainGasLimit),
+		signer:  retypes.NewEIP155Signer(params.AllEthashProtocolChanges.ChainID),
+		keys:    keys,
+	}, nil
+}
+
+// Address provides the address of the funded account of the given index.
+func (ch *Chain) Address(i int) common.Address {
+	return crypto.PubkeyToAddress(ch.keys[i].PublicKey)
+}
+
+// Transfer sends the amount of native tokens from the funded account
+// of the given index to the recipient and seals the transaction in a new block.
+func (ch *Chain) Transfer(from int, to common.Address, amount *big.Int) (common.Hash, error) {
+	ctx := context.Background()
+	sender := ch.Address(from)
+
+	nonce, err := ch.backend.PendingNonceAt(ctx, sender)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	gp, err := ch.backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	tx, err := retypes.SignTx(retypes.NewTransaction(nonce, to, amount, transferGas, gp, nil), ch.signer, ch.keys[from])
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if err := ch.backend.SendTransaction(ctx, tx); err != nil {
+		return common.Hash{}, fmt.Errorf("transfer from %s failed; %s", sender.String(), err.Error())
+	}
+
+	ch.backend.Commit()
+	return tx.Hash(), nil
+}
+
+// Seal seals a new empty block.
+func (ch *Chain) Seal() {
+	ch.backend.Commit()
+}
+
+// Close terminates the simulated chain.
+func (ch *Chain) Close() error {
+	return ch.backend.Close()
+}
@@ -0,0 +1,53 @@
+package harness
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	gqlSchema "fantom-api-graphql/internal/graphql/schema"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Contracts represents the addresses of the contracts the API server reads from the node.
+type Contracts struct {
+	SFC                  common.Address
+	FMintAddressProvider common.Address
+}
+
+// NewOnFixtures creates a new harness with the repository talking to the node
+// through the RPC bridge; the node responses are recorded into, or replayed from
+// the given fixtures file. Replayed harness does not need the node at the URL.
+// The harness has no simulated chain.
+func NewOnFixtures(url string, fix config.NodeFixtures, con Contracts) (*Harness, error) {
+	cfg := &config.Config{
+		AppName:  "harness",
+		Log:      config.Log{Level: "WARNING", Format: "%{level:.4s} %{shortfunc}: %{message}"},
+		Lachesis: config.Lachesis{Url: url, Timeout: 5 * time.Second, Fixtures: fix},
+		Db:       config.Database{Driver: config.DbDriverMemory},
+		Cache:    config.Cache{Eviction: time.Minute, MaxSize: harnessCacheSize},
+		Staking:  config.Staking{SFCContract: con.SFC},
+		DeFi:     config.DeFi{FMint: config.DeFiFMint{AddressProvider: con.FMintAddressProvider}},
+	}
+	log := logger.New(cfg)
+
+	repo, err := repository.New(cfg, log, repository.Backends{})
+	if err != nil {
+		return nil, err
+	}
+	repository.Use(repo)
+
+	rs := resolvers.New(cfg, log)
+	schema, err := graphql.ParseSchema(gqlSchema.Schema(), rs, graphql.UseFieldResolvers())
+	if err != nil {
+		rs.Close()
+		repo.Close()
+		return nil, err
+	}
+	rs.SetSchema(schema)
+
+	return &Harness{Repo: repo, rs: rs, schema: schema}, nil
+}
@@ -0,0 +1,268 @@
+package harness
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fantom-api-graphql/internal/types"
+	"flag"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// record makes the fixtures tests record new fixtures from the scripted node
+// instead of replaying the fixtures committed in the testdata.
+var record = flag.Bool("record", false, "record node fixtures of the harness tests")
+
+// fixturesHeight is the block height of the node the fixtures are recorded on.
+const fixturesHeight = 1000
+
+var (
+	// contracts of the fixtures
+	fixturesSfc           = common.HexToAddress("0xFC00FACE00000000000000000000000000000000")
+	fixturesFMintProvider = common.HexToAddress("0xcb20a1A22976764b882C2f03f0C8523F3df54b10")
+	fixturesFMintMinter   = common.HexToAddress("0xBB634cafEf389cDD03bB276c82738726079FcF2E")
+	fixturesFMintRegistry = common.HexToAddress("0x5AC50e414bB625Ce7dC17AD165A604bf3cA8FD23")
+	fixturesFMintRewards  = common.HexToAddress("0xA7D5F3D8A7D1E5ED2A5A7B3A07fb5E9D08C1E7A1")
+	fixturesCollateral    = common.HexToAddress("0xC25012dEd7b5Cc4EC1b1e04B15b08F6D8aeB5Ae5")
+	fixturesDebt          = common.HexToAddress("0x1B6A2A4D5C0E0C3a2F7a5D1b4F3E2C1D0A9B8C7D")
+	fixturesOracle        = common.HexToAddress("0x3a5e5DcE4dd0f6fA1F35aE84aD6D4a0a2D3aD5fD")
+
+	// accounts of the fixtures
+	fixturesValidator = common.HexToAddress("0x541E408443A592C38e01Bac0C2Ec4aD71c9d35d6")
+	fixturesDelegator = common.HexToAddress("0x3Dc8A0C5B1cf2a98aaC49D5CB0F9E2dd9E3bC0aa")
+	fixturesTokens    = []common.Address{
+		common.HexToAddress("0x21be370D5312f44cB42ce377BC9b8a0cEF1A4C83"),
+		common.HexToAddress("0xAd84341756Bf337f5a0164515b1f6F993D194E1f"),
+	}
+)
+
+// newFixturesHarness creates a harness replaying the node fixtures of the given name.
+// With the record flag, the fixtures are recorded from the scripted node instead;
+// they are written when the returned close function is called.
+func newFixturesHarness(t *testing.T, name string) (*Harness, func()) {
+	path := filepath.Join("testdata", name+".json")
+	con := Contracts{SFC: fixturesSfc, FMintAddressProvider: fixturesFMintProvider}
+
+	if !*record {
+		h, err := NewOnFixtures("http://fixtures.invalid", config.NodeFixtures{Mode: "replay", Path: path}, con)
+		if err != nil {
+			t.Fatalf("can not create harness; %s", err.Error())
+		}
+		return h, func() { closeHarness(t, h) }
+	}
+
+	srv := fixturesNode(t).serve()
+	h, err := NewOnFixtures(srv.URL, config.NodeFixtures{Mode: "record", Path: path}, con)
+	if err != nil {
+		srv.Close()
+		t.Fatalf("can not create harness; %s", err.Error())
+	}
+	return h, func() {
+		closeHarness(t, h)
+		srv.Close()
+	}
+}
+
+// fixturesNode creates the scripted node the fixtures are recorded from.
+func fixturesNode(t *testing.T) *scriptedNode {
+	sn := newScriptedNode(t, fixturesHeight)
+
+	// SFC v3 with a single validator and a locked delegation
+	sn.contract(fixturesSfc, contracts.SfcContractABI, map[string]scriptedMethod{
+		"version": func([]interface{}) []interface{} {
+			return []interface{}{[3]byte{'3', '0', '3'}}
+		},
+		"getValidator": func(args []interface{}) []interface{} {
+			if args[0].(*big.Int).Uint64() != 1 {
+				return []interface{}{new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), common.Address{}}
+			}
+			return []interface{}{new(big.Int), new(big.Int), new(big.Int), amount(700), big.NewInt(12), big.NewInt(1609459200), fixturesValidator}
+		},
+		"getStake": func(args []interface{}) []interface{} {
+			if args[0].(common.Address) == fixturesValidator {
+				return []interface{}{amount(500)}
+			}
+			return []interface{}{amount(200)}
+		},
+		"pendingRewards": func([]interface{}) []interface{} {
+			return []interface{}{new(big.Int).Div(amount(7), big.NewInt(2))}
+		},
+		"getLockupInfo": func([]interface{}) []interface{} {
+			return []interface{}{amount(150), big.NewInt(40), big.NewInt(4102444800), big.NewInt(365 * 86400)}
+		},
+		"isLockedUp": func([]interface{}) []interface{} {
+			return []interface{}{true}
+		},
+	})
+
+	// fMint protocol with two tokens
+	sn.contract(fixturesFMintProvider, contracts.DefiFMintAddressProviderABI, map[string]scriptedMethod{
+		"getAddress": func(args []interface{}) []interface{} {
+			id := args[0].([32]byte)
+			return []interface{}{map[string]common.Address{
+				"fantom_mint":         fixturesFMintMinter,
+				"token_registry":      fixturesFMintRegistry,
+				"reward_distribution": fixturesFMintRewards,
+				"collateral_pool":     fixturesCollateral,
+				"debt_pool":           fixturesDebt,
+				"price_oracle_proxy":  fixturesOracle,
+			}[string(common.TrimRightZeroes(id[:]))]}
+		},
+	})
+	sn.contract(fixturesFMintRegistry, contracts.DefiFMintTokenRegistryABI, map[string]scriptedMethod{
+		"tokensCount": func([]interface{}) []interface{} {
+			return []interface{}{big.NewInt(int64(len(fixturesTokens)))}
+		},
+		"tokensList": func(args []interface{}) []interface{} {
+			return []interface{}{fixturesTokens[args[0].(*big.Int).Uint64()]}
+		},
+	})
+	sn.contract(fixturesFMintMinter, contracts.DefiFMintMinterABI, map[string]scriptedMethod{
+		"collateralValueOf": func([]interface{}) []interface{} {
+			return []interface{}{amount(1250)}
+		},
+		"debtValueOf": func([]interface{}) []interface{} {
+			return []interface{}{amount(400)}
+		},
+		"getFMintFee4dec": func([]interface{}) []interface{} {
+			return []interface{}{big.NewInt(50)}
+		},
+		"getCollateralLowestDebtRatio4dec": func([]interface{}) []interface{} {
+			return []interface{}{big.NewInt(30000)}
+		},
+		"getRewardEligibilityRatio4dec": func([]interface{}) []interface{} {
+			return []interface{}{big.NewInt(50000)}
+		},
+		"fMintFeeDigitsCorrection": func([]interface{}) []interface{} {
+			return []interface{}{big.NewInt(10000)}
+		},
+	})
+	return sn
+}
+
+// TestFixturesStaking verifies the validator and its delegation with pending rewards
+// and the lock are resolved from the node responses recorded in fixtures.
+func TestFixturesStaking(t *testing.T) {
+	h, done := newFixturesHarness(t, "staking")
+	defer done()
+
+	// the delegation is indexed by the block scanner
+	if err := h.Repo.StoreDelegation(&types.Delegation{
+		Transaction:     common.HexToHash("0x5d1f9a1f0bcb2c2d1de1a1e3c3c1d1e1f1a1b1c1d1e1f1a1b1c1d1e1f1a1b1c1"),
+		Address:         fixturesDelegator,
+		ToStakerId:      (*hexutil.Big)(big.NewInt(1)),
+		ToStakerAddress: fixturesValidator,
+		AmountDelegated: (*hexutil.Big)(amount(200)),
+		AmountStaked:    (*hexutil.Big)(amount(200)),
+		CreatedTime:     hexutil.Uint64(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC).Unix()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var res struct {
+		Staker struct {
+			StakerAddress common.Address
+			TotalStake    hexutil.Big
+			Stake         hexutil.Big
+			DelegatedMe   hexutil.Big
+			IsActive      bool
+			CreatedEpoch  hexutil.Uint64
+			CreatedTime   hexutil.Uint64
+		}
+		Delegation struct {
+			AmountDelegated hexutil.Big
+			PendingRewards  struct {
+				Amount hexutil.Big
+				Staker hexutil.Big
+			}
+			IsDelegationLocked bool
+			LockedAmount       hexutil.Big
+			LockedFromEpoch    hexutil.Uint64
+			LockedUntil        hexutil.Uint64
+		}
+	}
+	if err := h.Query(`query ($dlg: Address!) {
+		staker(id: "0x1") { stakerAddress totalStake stake delegatedMe isActive createdEpoch createdTime }
+		delegation(address: $dlg, staker: "0x1") {
+			amountDelegated
+			pendingRewards { amount staker }
+			isDelegationLocked lockedAmount lockedFromEpoch lockedUntil
+		}
+	}`, map[string]interface{}{"dlg": fixturesDelegator.String()}, &res); err != nil {
+		t.Fatal(err)
+	}
+
+	st := res.Staker
+	if st.StakerAddress != fixturesValidator || !st.IsActive || st.CreatedEpoch != 12 || st.CreatedTime != 1609459200 {
+		t.Errorf("unexpected staker %+v", st)
+	}
+	if st.TotalStake.ToInt().Cmp(amount(700)) != 0 || st.Stake.ToInt().Cmp(amount(500)) != 0 || st.DelegatedMe.ToInt().Cmp(amount(200)) != 0 {
+		t.Errorf("unexpected staker amounts %+v", st)
+	}
+
+	dl := res.Delegation
+	if dl.AmountDelegated.ToInt().Cmp(amount(200)) != 0 {
+		t.Errorf("unexpected delegation %+v", dl)
+	}
+	if dl.PendingRewards.Amount.ToInt().Cmp(new(big.Int).Div(amount(7), big.NewInt(2))) != 0 || dl.PendingRewards.Staker.ToInt().Uint64() != 1 {
+		t.Errorf("unexpected pending rewards %+v", dl.PendingRewards)
+	}
+	if !dl.IsDelegationLocked || dl.LockedAmount.ToInt().Cmp(amount(150)) != 0 || dl.LockedFromEpoch != 40 || dl.LockedUntil != 4102444800 {
+		t.Errorf("unexpected delegation lock %+v", dl)
+	}
+}
+
+// TestFixturesFMint verifies the fMint account and the DeFi configuration
+// are resolved from the node responses recorded in fixtures.
+func TestFixturesFMint(t *testing.T) {
+	h, done := newFixturesHarness(t, "fmint")
+	defer done()
+
+	var res struct {
+		FMintAccount struct {
+			CollateralList  []common.Address
+			DebtList        []common.Address
+			CollateralValue hexutil.Big
+			DebtValue       hexutil.Big
+		}
+		DefiConfiguration struct {
+			MintFee4               hexutil.Big
+			MinCollateralRatio4    hexutil.Big
+			RewardCollateralRatio4 hexutil.Big
+			Decimals               int32
+			FMintContract          common.Address
+			FMintCollateralPool    common.Address
+			FMintDebtPool          common.Address
+		}
+	}
+	if err := h.Query(`query ($owner: Address!) {
+		fMintAccount(owner: $owner) { collateralList debtList collateralValue debtValue }
+		defiConfiguration {
+			mintFee4 minCollateralRatio4 rewardCollateralRatio4 decimals
+			fMintContract fMintCollateralPool fMintDebtPool
+		}
+	}`, map[string]interface{}{"owner": fixturesDelegator.String()}, &res); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := res.FMintAccount
+	if len(acc.CollateralList) != 2 || acc.CollateralList[0] != fixturesTokens[0] || acc.CollateralList[1] != fixturesTokens[1] || len(acc.DebtList) != 2 {
+		t.Errorf("unexpected fMint tokens %+v", acc)
+	}
+	if acc.CollateralValue.ToInt().Cmp(amount(1250)) != 0 || acc.DebtValue.ToInt().Cmp(amount(400)) != 0 {
+		t.Errorf("unexpected fMint account value %+v", acc)
+	}
+
+	cfg := res.DefiConfiguration
+	if cfg.MintFee4.ToInt().Int64() != 50 || cfg.MinCollateralRatio4.ToInt().Int64() != 30000 || cfg.RewardCollateralRatio4.ToInt().Int64() != 50000 || cfg.Decimals != 4 {
+		t.Errorf("unexpected DeFi configuration %+v", cfg)
+	}
+	if cfg.FMintContract != fixturesFMintMinter || cfg.FMintCollateralPool != fixturesCollateral || cfg.FMintDebtPool != fixturesDebt {
+		t.Errorf("unexpected fMint contracts %+v", cfg)
+	}
+}
@@ -0,0 +1,135 @@
+package harness
+
+import (
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	gqlSchema "fantom-api-graphql/internal/graphql/schema"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// harnessCacheSize is the max size of the repository cache of the harness in MB.
+const harnessCacheSize = 32
+
+// Harness represents the API server GraphQL schema wired to the repository
+// running on the simulated chain and the in-memory store.
+type Harness struct {
+	Chain *Chain
+	Node  *Node
+	Repo  repository.Repository
+
+	rs     resolvers.ApiResolver
+	schema *graphql.Schema
+}
+
+// New creates a new harness with the given number of funded accounts on the simulated chain.
+// The repository replaces the repository of the API server; only one harness
+// should be used at a time. The background services of the repository are not running.
+func New(accounts int, balance *big.Int) (*Harness, error) {
+	cfg := &config.Config{
+		AppName: "harness",
+		Log:     config.Log{Level: "WARNING", Format: "%{level:.4s} %{shortfunc}: %{message}"},
+		Db:      config.Database{Driver: config.DbDriverMemory},
+		Cache:   config.Cache{Eviction: time.Minute, MaxSize: harnessCacheSize},
+	}
+	log := logger.New(cfg)
+
+	ch, err := NewChain(accounts, balance)
+	if err != nil {
+		return nil, err
+	}
+
+	node := NewNode(ch)
+	repo, err := repository.New(cfg, log, repository.Backends{Node: node})
+	if err != nil {
+		_ = ch.Close()
+		return nil, err
+	}
+	repository.Use(repo)
+
+	rs := resolvers.New(cfg, log)
+	schema, err := graphql.ParseSchema(gqlSchema.Schema(), rs, graphql.UseFieldResolvers())
+	if err != nil {
+		rs.Close()
+		repo.Close()
+		_ = ch.Close()
+		return nil, err
+	}
+	rs.SetSchema(schema)
+
+	return &Harness{Chain: ch, Node: node, Repo: repo, rs: rs, schema: schema}, nil
+}
+
+// Query executes the GraphQL query with the given variables and decodes
+// the response data into the target structure.
+func (h *Harness) Query(query string, vars map[string]interface{}, target interface{}) error {
+	res := h.schema.Exec(context.Background(), query, "", vars)
+	if len(res.Errors) > 0 {
+		return fmt.Errorf("query failed; %s", res.Errors[0].Error())
+	}
+	return json.Unmarshal(res.Data, target)
+}
+
+// AddValidator registers a new active validator with the given self stake.
+func (h *Harness) AddValidator(id uint64, addr common.Address, stake *big.Int) error {
+	h.Node.addValidator(id, addr)
+
+	// the self stake is a delegation of the validator to itself
+	return h.Delegate(addr, id, stake)
+}
+
+// Delegate adds the amount to the delegation of the address to the validator
+// and stores the delegation the way the block scanner does on a new delegation event.
+func (h *Harness) Delegate(addr common.Address, id uint64, amount *big.Int) error {
+	staked, err := h.Node.stake(addr, id, amount)
+	if err != nil {
+		return err
+	}
+
+	valID := (*hexutil.Big)(new(big.Int).SetUint64(id))
+	val, err := h.Repo.ValidatorAddress(valID)
+	if err != nil {
+		return err
+	}
+
+	return h.Repo.StoreDelegation(&types.Delegation{
+		Transaction:     crypto.Keccak256Hash(addr.Bytes(), valID.ToInt().Bytes()),
+		Address:         addr,
+		ToStakerId:      valID,
+		ToStakerAddress: *val,
+		AmountDelegated: (*hexutil.Big)(staked),
+		AmountStaked:    (*hexutil.Big)(new(big.Int).Set(amount)),
+		CreatedTime:     hexutil.Uint64(time.Now().Unix()),
+	})
+}
+
+// AddToken registers a new ERC20 token with the whole supply owned by the given owner.
+func (h *Harness) AddToken(adr common.Address, name string, symbol string, decimals int32, owner common.Address, supply *big.Int) {
+	h.Node.addToken(adr, name, symbol, decimals, owner, supply)
+}
+
+// TransferToken moves the amount of ERC20 tokens between the two owners.
+func (h *Harness) TransferToken(adr common.Address, from common.Address, to common.Address, amount *big.Int) error {
+	return h.Node.transferToken(adr, from, to, amount)
+}
+
+// Close terminates the resolvers, the repository and the simulated chain, if any.
+func (h *Harness) Close() error {
+	h.rs.Close()
+	h.Repo.Close()
+	if h.Chain == nil {
+		return nil
+	}
+	return h.Chain.Close()
+}
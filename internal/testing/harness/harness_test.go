@@ -0,0 +1,242 @@
+package harness
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ftm is the amount of WEI in one FTM.
+var ftm = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// amount provides the given number of FTM in WEI.
+func amount(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), ftm)
+}
+
+// newHarness creates a harness with funded accounts.
+func newHarness(t *testing.T) *Harness {
+	h, err := New(3, amount(1000))
+	if err != nil {
+		t.Fatalf("can not create harness; %s", err.Error())
+	}
+	return h
+}
+
+// closeHarness terminates the harness at the end of the test.
+func closeHarness(t *testing.T, h *Harness) {
+	if err := h.Close(); err != nil {
+		t.Errorf("can not close harness; %s", err.Error())
+	}
+}
+
+// TestBlocks verifies a transfer is sealed in a block and resolved with the block.
+func TestBlocks(t *testing.T) {
+	h := newHarness(t)
+	defer closeHarness(t, h)
+
+	hash, err := h.Chain.Transfer(0, h.Chain.Address(1), amount(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var res struct {
+		Block struct {
+			Number           hexutil.Uint64
+			TransactionCount int32
+			TxHashList       []common.Hash
+		}
+		Transaction struct {
+			From        common.Address
+			To          common.Address
+			Value       hexutil.Big
+			Status      hexutil.Uint64
+			BlockNumber hexutil.Uint64
+		}
+	}
+	if err := h.Query(`query ($hash: Bytes32!) {
+		block { number transactionCount txHashList }
+		transaction(hash: $hash) { from to value status blockNumber }
+	}`, map[string]interface{}{"hash": hash.String()}, &res); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Block.Number != 1 || res.Block.TransactionCount != 1 || len(res.Block.TxHashList) != 1 || res.Block.TxHashList[0] != hash {
+		t.Errorf("unexpected top block %+v", res.Block)
+	}
+	if res.Transaction.From != h.Chain.Address(0) || res.Transaction.To != h.Chain.Address(1) {
+		t.Errorf("unexpected transaction parties %+v", res.Transaction)
+	}
+	if res.Transaction.Value.ToInt().Cmp(amount(5)) != 0 || res.Transaction.Status != 1 || res.Transaction.BlockNumber != res.Block.Number {
+		t.Errorf("unexpected transaction %+v", res.Transaction)
+	}
+}
+
+// TestAccounts verifies account balances and counters follow transfers.
+func TestAccounts(t *testing.T) {
+	h := newHarness(t)
+	defer closeHarness(t, h)
+
+	for i := 0; i < 2; i++ {
+		if _, err := h.Chain.Transfer(0, h.Chain.Address(2), amount(10)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var res struct {
+		Sender struct {
+			Balance hexutil.Big
+			TxCount hexutil.Uint64
+		}
+		Recipient struct {
+			Type    string
+			Balance hexutil.Big
+			TxCount hexutil.Uint64
+		}
+	}
+	if err := h.Query(`query ($from: Address!, $to: Address!) {
+		sender: account(address: $from) { balance txCount }
+		recipient: account(address: $to) { type balance txCount }
+	}`, map[string]interface{}{
+		"from": h.Chain.Address(0).String(),
+		"to":   h.Chain.Address(2).String(),
+	}, &res); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Sender.TxCount != 2 || res.Sender.Balance.ToInt().Cmp(amount(980)) >= 0 {
+		t.Errorf("unexpected sender %+v", res.Sender)
+	}
+	if res.Recipient.TxCount != 0 || res.Recipient.Balance.ToInt().Cmp(amount(1020)) != 0 || res.Recipient.Type != "wallet" {
+		t.Errorf("unexpected recipient %+v", res.Recipient)
+	}
+}
+
+// TestStaking verifies delegations are reflected on the validator.
+func TestStaking(t *testing.T) {
+	h := newHarness(t)
+	defer closeHarness(t, h)
+
+	if err := h.AddValidator(1, h.Chain.Address(0), amount(500)); err != nil {
+		t.Fatalf("can not add validator; %s", err)
+	}
+	if err := h.Delegate(h.Chain.Address(1), 1, amount(200)); err != nil {
+		t.Fatal(err)
+	}
+
+	var res struct {
+		Staker struct {
+			StakerAddress common.Address
+			TotalStake    hexutil.Big
+			Stake         hexutil.Big
+			DelegatedMe   hexutil.Big
+			IsActive      bool
+		}
+		Delegation struct {
+			ToStakerId      hexutil.Big
+			AmountDelegated hexutil.Big
+		}
+	}
+	if err := h.Query(`query ($dlg: Address!) {
+		staker(id: "0x1") { stakerAddress totalStake stake delegatedMe isActive }
+		delegation(address: $dlg, staker: "0x1") { toStakerId amountDelegated }
+	}`, map[string]interface{}{"dlg": h.Chain.Address(1).String()}, &res); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Staker.StakerAddress != h.Chain.Address(0) || !res.Staker.IsActive {
+		t.Errorf("unexpected staker %+v", res.Staker)
+	}
+	if res.Staker.TotalStake.ToInt().Cmp(amount(700)) != 0 || res.Staker.Stake.ToInt().Cmp(amount(500)) != 0 || res.Staker.DelegatedMe.ToInt().Cmp(amount(200)) != 0 {
+		t.Errorf("unexpected staker amounts %+v", res.Staker)
+	}
+	if res.Delegation.ToStakerId.ToInt().Uint64() != 1 || res.Delegation.AmountDelegated.ToInt().Cmp(amount(200)) != 0 {
+		t.Errorf("unexpected delegation %+v", res.Delegation)
+	}
+}
+
+// TestTokens verifies ERC20 token details and balances follow token transfers.
+func TestTokens(t *testing.T) {
+	h := newHarness(t)
+	defer closeHarness(t, h)
+
+	tok := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	h.AddToken(tok, "Test Token", "TST", 18, h.Chain.Address(0), amount(1000000))
+	if err := h.TransferToken(tok, h.Chain.Address(0), h.Chain.Address(1), amount(250)); err != nil {
+		t.Fatal(err)
+	}
+
+	var res struct {
+		Erc20Token struct {
+			Symbol      string
+			Decimals    int32
+			TotalSupply hexutil.Big
+			BalanceOf   hexutil.Big
+		}
+		ErcTokenBalance hexutil.Big
+	}
+	if err := h.Query(`query ($token: Address!, $owner: Address!, $holder: Address!) {
+		erc20Token(token: $token) { symbol decimals totalSupply balanceOf(owner: $owner) }
+		ercTokenBalance(token: $token, owner: $holder)
+	}`, map[string]interface{}{
+		"token":  tok.String(),
+		"owner":  h.Chain.Address(0).String(),
+		"holder": h.Chain.Address(1).String(),
+	}, &res); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Erc20Token.Symbol != "TST" || res.Erc20Token.Decimals != 18 || res.Erc20Token.TotalSupply.ToInt().Cmp(amount(1000000)) != 0 {
+		t.Errorf("unexpected token %+v", res.Erc20Token)
+	}
+	if res.Erc20Token.BalanceOf.ToInt().Cmp(amount(999750)) != 0 || res.ErcTokenBalance.ToInt().Cmp(amount(250)) != 0 {
+		t.Errorf("unexpected token balances %+v, %s", res.Erc20Token, res.ErcTokenBalance.String())
+	}
+}
+
+// TestFederation verifies federated entities not found resolve to null
+// without failing the other entities of the list.
+func TestFederation(t *testing.T) {
+	h := newHarness(t)
+	defer closeHarness(t, h)
+
+	hash, err := h.Chain.Transfer(0, h.Chain.Address(1), amount(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var res struct {
+		Entities []*struct {
+			Hash   *common.Hash
+			Number *hexutil.Uint64
+		} `json:"_entities"`
+	}
+	if err := h.Query(`query ($reps: [_Any!]!) {
+		_entities(representations: $reps) {
+			... on Transaction { hash }
+			... on Block { number }
+		}
+	}`, map[string]interface{}{"reps": []interface{}{
+		map[string]interface{}{"__typename": "Transaction", "hash": hash.String()},
+		map[string]interface{}{"__typename": "Transaction", "hash": common.HexToHash("0x01").String()},
+		map[string]interface{}{"__typename": "Block", "number": "0x3e8"},
+		map[string]interface{}{"__typename": "Block", "number": "0x1"},
+	}}, &res); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Entities) != 4 {
+		t.Fatalf("expected 4 entities, got %d", len(res.Entities))
+	}
+	if res.Entities[0] == nil || res.Entities[0].Hash == nil || *res.Entities[0].Hash != hash {
+		t.Errorf("unexpected transaction entity %+v", res.Entities[0])
+	}
+	if res.Entities[1] != nil || res.Entities[2] != nil {
+		t.Errorf("expected unknown entities to be null, got %+v, %+v", res.Entities[1], res.Entities[2])
+	}
+	if res.Entities[3] == nil || res.Entities[3].Number == nil || *res.Entities[3].Number != 1 {
+		t.Errorf("unexpected block entity %+v", res.Entities[3])
+	}
+}
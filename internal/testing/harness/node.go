@@ -0,0 +1,463 @@
+package harness
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	eth "github.com/ethereum/go-ethereum/rpc"
+)
+
+// Node represents the blockchain node backend of the repository on top of the simulated chain.
+// Blocks, transactions and account balances come from the simulated chain; the SFC staking
+// and ERC20 token contracts are not deployed there, so their state is kept by the node
+// and updated by the test flows.
+//
+// Only the calls needed by the harness tests are implemented; calling any other
+// node function panics on the embedded nil interface.
+type Node struct {
+	repository.Node
+
+	chain *Chain
+	mu    sync.RWMutex
+
+	validators map[uint64]*types.Validator
+	stakes     map[string]*big.Int
+	tokens     map[common.Address]*token
+}
+
+// token represents an ERC20 token contract state.
+type token struct {
+	name     string
+	symbol   string
+	decimals int32
+	supply   *big.Int
+	balances map[common.Address]*big.Int
+}
+
+// NewNode creates a new node backend on top of the given simulated chain.
+func NewNode(ch *Chain) *Node {
+	return &Node{
+		chain:      ch,
+		validators: make(map[uint64]*types.Validator),
+		stakes:     make(map[string]*big.Int),
+		tokens:     make(map[common.Address]*token),
+	}
+}
+
+// stakeKey builds the key of a stake of the given address in the validator.
+func stakeKey(addr common.Address, id uint64) string {
+	return fmt.Sprintf("%s/%d", addr.String(), id)
+}
+
+// addValidator registers a new active validator.
+func (n *Node) addValidator(id uint64, addr common.Address) *types.Validator {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	val := &types.Validator{
+		Id:            hexutil.Big(*new(big.Int).SetUint64(id)),
+		StakerAddress: addr,
+		TotalStake:    (*hexutil.Big)(new(big.Int)),
+		CreatedTime:   hexutil.Uint64(time.Now().Unix()),
+	}
+	n.validators[id] = val
+	return val
+}
+
+// stake adds the amount to the stake of the address in the validator
+// and provides the new amount staked.
+func (n *Node) stake(addr common.Address, id uint64, amount *big.Int) (*big.Int, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	val, ok := n.validators[id]
+	if !ok {
+		return nil, fmt.Errorf("validator #%d not found", id)
+	}
+
+	key := stakeKey(addr, id)
+	if _, ok := n.stakes[key]; !ok {
+		n.stakes[key] = new(big.Int)
+	}
+	n.stakes[key].Add(n.stakes[key], amount)
+	val.TotalStake = (*hexutil.Big)(new(big.Int).Add(val.TotalStake.ToInt(), amount))
+	return new(big.Int).Set(n.stakes[key]), nil
+}
+
+// addToken registers a new ERC20 token with the whole supply owned by the given owner.
+func (n *Node) addToken(adr common.Address, name string, symbol string, decimals int32, owner common.Address, supply *big.Int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.tokens[adr] = &token{
+		name:     name,
+		symbol:   symbol,
+		decimals: decimals,
+		supply:   new(big.Int).Set(supply),
+		balances: map[common.Address]*big.Int{owner: new(big.Int).Set(supply)},
+	}
+}
+
+// transferToken moves the amount of ERC20 tokens between the two owners.
+func (n *Node) transferToken(adr common.Address, from common.Address, to common.Address, amount *big.Int) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	tok, ok := n.tokens[adr]
+	if !ok {
+		return fmt.Errorf("token %s not found", adr.String())
+	}
+
+	bal, ok := tok.balances[from]
+	if !ok || bal.Cmp(amount) < 0 {
+		return fmt.Errorf("insufficient balance of %s", from.String())
+	}
+
+	if _, ok := tok.balances[to]; !ok {
+		tok.balances[to] = new(big.Int)
+	}
+	bal.Sub(bal, amount)
+	tok.balances[to].Add(tok.balances[to], amount)
+	return nil
+}
+
+// token provides the ERC20 token of the given address.
+// The caller is expected to hold the lock.
+func (n *Node) token(adr *common.Address) (*token, error) {
+	tok, ok := n.tokens[*adr]
+	if !ok {
+		return nil, fmt.Errorf("token %s not found", adr.String())
+	}
+	return tok, nil
+}
+
+// WithContext returns the node itself, the simulated chain calls can not be cancelled.
+func (n *Node) WithContext(context.Context) repository.Node {
+	return n
+}
+
+// Close does nothing, the simulated chain is closed by the harness.
+func (n *Node) Close() {}
+
+// Connection provides no connection, the simulated chain is not reachable over RPC.
+func (n *Node) Connection() *eth.Client {
+	return nil
+}
+
+// Latency provides the latency of the node; the simulated chain responds immediately.
+func (n *Node) Latency() time.Duration {
+	return 0
+}
+
+// BlockHeight returns the current height of the simulated chain.
+func (n *Node) BlockHeight() (*hexutil.Big, error) {
+	blk, err := n.chain.backend.BlockByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(blk.Number()), nil
+}
+
+// Block returns the block of the given number, or tag.
+func (n *Node) Block(numTag *string) (*types.Block, error) {
+	var bn *big.Int
+	switch *numTag {
+	case "latest", "pending":
+	case "earliest":
+		bn = new(big.Int)
+	default:
+		num, err := hexutil.DecodeUint64(*numTag)
+		if err != nil {
+			return nil, err
+		}
+		bn = new(big.Int).SetUint64(num)
+	}
+
+	// blocks above the head are not known yet
+	head, err := n.BlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	if bn != nil && bn.Cmp(head.ToInt()) > 0 {
+		return nil, eth.ErrNoResult
+	}
+
+	blk, err := n.chain.backend.BlockByNumber(context.Background(), bn)
+	if err != nil {
+		return nil, err
+	}
+	return block(blk), nil
+}
+
+// BlockByHash returns the block of the given hash.
+func (n *Node) BlockByHash(hash *string) (*types.Block, error) {
+	blk, err := n.chain.backend.BlockByHash(context.Background(), common.HexToHash(*hash))
+	if err == ethereum.NotFound {
+		return nil, eth.ErrNoResult
+	}
+	if err != nil {
+		return nil, err
+	}
+	return block(blk), nil
+}
+
+// block converts the simulated chain block to the API block.
+func block(blk *retypes.Block) *types.Block {
+	txs := make([]*common.Hash, len(blk.Transactions()))
+	for i, tx := range blk.Transactions() {
+		h := tx.Hash()
+		txs[i] = &h
+	}
+
+	return &types.Block{
+		Number:     hexutil.Uint64(blk.NumberU64()),
+		Hash:       blk.Hash(),
+		ParentHash: blk.ParentHash(),
+		Miner:      blk.Coinbase(),
+		Difficulty: hexutil.Uint64(blk.Difficulty().Uint64()),
+		Size:       hexutil.Uint64(blk.Size()),
+		GasLimit:   hexutil.Uint64(blk.GasLimit()),
+		GasUsed:    hexutil.Uint64(blk.GasUsed()),
+		TimeStamp:  hexutil.Uint64(blk.Time()),
+		Txs:        txs,
+	}
+}
+
+// AccountBalance returns the current balance of the account on the simulated chain.
+func (n *Node) AccountBalance(addr *common.Address) (*hexutil.Big, error) {
+	val, err := n.chain.backend.BalanceAt(context.Background(), *addr, nil)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(val), nil
+}
+
+// AccountNonce returns the current number of sent transactions of the account on the simulated chain.
+func (n *Node) AccountNonce(addr *common.Address) (uint64, error) {
+	return n.chain.backend.NonceAt(context.Background(), *addr, nil)
+}
+
+// AccountPendingNonce returns the number of sent transactions of the account including pending ones.
+func (n *Node) AccountPendingNonce(addr *common.Address) (uint64, error) {
+	return n.chain.backend.PendingNonceAt(context.Background(), *addr)
+}
+
+// IsContract checks if the address has a contract code deployed on the simulated chain.
+func (n *Node) IsContract(addr *common.Address) (bool, error) {
+	code, err := n.chain.backend.CodeAt(context.Background(), *addr, nil)
+	if err != nil {
+		return false, err
+	}
+	return len(code) > 0, nil
+}
+
+// Transaction returns the sealed transaction of the given hash from the simulated chain.
+// An unknown transaction is returned empty, as the node does.
+func (n *Node) Transaction(hash *common.Hash) (*types.Transaction, error) {
+	ctx := context.Background()
+	tx, _, err := n.chain.backend.TransactionByHash(ctx, *hash)
+	if err == ethereum.NotFound {
+		return new(types.Transaction), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := n.chain.backend.TransactionReceipt(ctx, *hash)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := retypes.Sender(n.chain.signer, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	blk, err := n.chain.backend.BlockByHash(ctx, rec.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	num := hexutil.Uint64(rec.BlockNumber.Uint64())
+	index := hexutil.Uint64(rec.TransactionIndex)
+	cumulative := hexutil.Uint64(rec.CumulativeGasUsed)
+	used := hexutil.Uint64(rec.GasUsed)
+	status := hexutil.Uint64(rec.Status)
+	trx := &types.Transaction{
+		BlockHash:         &rec.BlockHash,
+		BlockNumber:       &num,
+		TimeStamp:         time.Unix(int64(blk.Time()), 0),
+		From:              from,
+		Gas:               hexutil.Uint64(tx.Gas()),
+		GasUsed:           &used,
+		CumulativeGasUsed: &cumulative,
+		GasPrice:          hexutil.Big(*tx.GasPrice()),
+		Hash:              tx.Hash(),
+		Nonce:             hexutil.Uint64(tx.Nonce()),
+		To:                tx.To(),
+		Value:             hexutil.Big(*tx.Value()),
+		InputData:         tx.Data(),
+		Index:             &index,
+		Status:            &status,
+	}
+
+	// contract creation
+	if tx.To() == nil {
+		trx.ContractAddress = &rec.ContractAddress
+	}
+
+	for _, l := range rec.Logs {
+		trx.Logs = append(trx.Logs, *l)
+	}
+	return trx, nil
+}
+
+// LastValidatorId returns the highest validator ID registered.
+func (n *Node) LastValidatorId() (uint64, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	var last uint64
+	for id := range n.validators {
+		if id > last {
+			last = id
+		}
+	}
+	return last, nil
+}
+
+// ValidatorsCount returns the number of validators registered.
+func (n *Node) ValidatorsCount() (uint64, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return uint64(len(n.validators)), nil
+}
+
+// Validator returns the validator of the given ID.
+func (n *Node) Validator(valID *big.Int) (*types.Validator, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	val, ok := n.validators[valID.Uint64()]
+	if !ok {
+		return nil, fmt.Errorf("validator #%d not found", valID.Uint64())
+	}
+
+	res := *val
+	return &res, nil
+}
+
+// ValidatorByAddress returns the validator of the given address.
+func (n *Node) ValidatorByAddress(addr *common.Address) (*types.Validator, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, val := range n.validators {
+		if val.StakerAddress == *addr {
+			res := *val
+			return &res, nil
+		}
+	}
+	return nil, fmt.Errorf("validator %s not found", addr.String())
+}
+
+// ValidatorAddress returns the address of the validator of the given ID.
+func (n *Node) ValidatorAddress(valID *big.Int) (*common.Address, error) {
+	val, err := n.Validator(valID)
+	if err != nil {
+		return nil, err
+	}
+	return &val.StakerAddress, nil
+}
+
+// IsValidator checks if the address belongs to a validator.
+func (n *Node) IsValidator(addr *common.Address) (bool, error) {
+	_, err := n.ValidatorByAddress(addr)
+	return err == nil, nil
+}
+
+// AmountStaked returns the current amount staked by the address in the validator.
+func (n *Node) AmountStaked(addr *common.Address, valID *big.Int) (*big.Int, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	val, ok := n.stakes[stakeKey(*addr, valID.Uint64())]
+	if !ok {
+		return new(big.Int), nil
+	}
+	return new(big.Int).Set(val), nil
+}
+
+// Erc20Name returns the name of the ERC20 token.
+func (n *Node) Erc20Name(adr *common.Address) (string, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	tok, err := n.token(adr)
+	if err != nil {
+		return "", err
+	}
+	return tok.name, nil
+}
+
+// Erc20Symbol returns the symbol of the ERC20 token.
+func (n *Node) Erc20Symbol(adr *common.Address) (string, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	tok, err := n.token(adr)
+	if err != nil {
+		return "", err
+	}
+	return tok.symbol, nil
+}
+
+// Erc20Decimals returns the number of decimals of the ERC20 token.
+func (n *Node) Erc20Decimals(adr *common.Address) (int32, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	tok, err := n.token(adr)
+	if err != nil {
+		return 0, err
+	}
+	return tok.decimals, nil
+}
+
+// Erc20TotalSupply returns the total supply of the ERC20 token.
+func (n *Node) Erc20TotalSupply(adr *common.Address) (hexutil.Big, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	tok, err := n.token(adr)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*new(big.Int).Set(tok.supply)), nil
+}
+
+// Erc20BalanceOf returns the balance of the ERC20 token of the owner.
+func (n *Node) Erc20BalanceOf(adr *common.Address, owner *common.Address) (hexutil.Big, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	tok, err := n.token(adr)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+
+	bal, ok := tok.balances[*owner]
+	if !ok {
+		return hexutil.Big{}, nil
+	}
+	return hexutil.Big(*new(big.Int).Set(bal)), nil
+}
@@ -0,0 +1,153 @@
+package harness
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// scriptedMethod provides the outputs of a contract call for the given inputs.
+type scriptedMethod func(args []interface{}) []interface{}
+
+// scriptedContract represents a contract of the scripted node answering
+// calls of the listed methods.
+type scriptedContract struct {
+	abi     abi.ABI
+	methods map[string]scriptedMethod
+}
+
+// scriptedNode represents a JSON-RPC node answering contract calls by scripts.
+// It stands for the live node when the fixtures are recorded.
+type scriptedNode struct {
+	t         *testing.T
+	height    hexutil.Uint64
+	contracts map[common.Address]*scriptedContract
+}
+
+// rpcMessage represents a JSON-RPC message exchanged with the scripted node.
+type rpcMessage struct {
+	Version string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id,omitempty"`
+	Method  string            `json:"method,omitempty"`
+	Params  []json.RawMessage `json:"params,omitempty"`
+	Result  interface{}       `json:"result,omitempty"`
+	Error   *rpcError         `json:"error,omitempty"`
+}
+
+// rpcError represents a JSON-RPC error of the scripted node.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// newScriptedNode creates a new scripted node at the given block height.
+func newScriptedNode(t *testing.T, height uint64) *scriptedNode {
+	return &scriptedNode{t: t, height: hexutil.Uint64(height), contracts: make(map[common.Address]*scriptedContract)}
+}
+
+// contract adds a contract of the given ABI with the given method scripts.
+func (sn *scriptedNode) contract(adr common.Address, def string, methods map[string]scriptedMethod) {
+	ab, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		sn.t.Fatalf("invalid contract ABI; %s", err.Error())
+	}
+	sn.contracts[adr] = &scriptedContract{abi: ab, methods: methods}
+}
+
+// serve starts the HTTP server of the scripted node.
+func (sn *scriptedNode) serve() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		if _, err := buf.ReadFrom(r.Body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var out interface{}
+		if strings.HasPrefix(strings.TrimSpace(buf.String()), "[") {
+			var list []*rpcMessage
+			if err := json.Unmarshal(buf.Bytes(), &list); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for _, m := range list {
+				sn.answer(m)
+			}
+			out = list
+		} else {
+			var m rpcMessage
+			if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			sn.answer(&m)
+			out = &m
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	}))
+}
+
+// answer turns the call message into its response.
+func (sn *scriptedNode) answer(m *rpcMessage) {
+	var err error
+	switch m.Method {
+	case "eth_blockNumber", "ftm_blockNumber":
+		m.Result = sn.height
+	case "eth_call":
+		m.Result, err = sn.call(m.Params)
+	default:
+		err = fmt.Errorf("method %s not scripted", m.Method)
+	}
+
+	m.Version, m.Method, m.Params = "2.0", "", nil
+	if err != nil {
+		sn.t.Logf("scripted node; %s", err.Error())
+		m.Result, m.Error = nil, &rpcError{Code: -32000, Message: err.Error()}
+	}
+}
+
+// call executes the script of the called contract method.
+func (sn *scriptedNode) call(params []json.RawMessage) (hexutil.Bytes, error) {
+	if len(params) == 0 {
+		return nil, fmt.Errorf("call arguments missing")
+	}
+
+	var msg struct {
+		To   common.Address `json:"to"`
+		Data hexutil.Bytes  `json:"data"`
+	}
+	if err := json.Unmarshal(params[0], &msg); err != nil {
+		return nil, err
+	}
+
+	con, ok := sn.contracts[msg.To]
+	if !ok || len(msg.Data) < 4 {
+		return nil, fmt.Errorf("contract %s not scripted", msg.To.String())
+	}
+
+	method, err := con.abi.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+
+	script, ok := con.methods[method.Name]
+	if !ok {
+		return nil, fmt.Errorf("method %s of %s not scripted", method.Name, msg.To.String())
+	}
+
+	args, err := method.Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	return method.Outputs.Pack(script(args)...)
+}
@@ -22,8 +22,24 @@ const (
 
 	// AccountTypeERC721Token identifies a contract of type ERC721 token
 	AccountTypeERC721Token = "ERC721"
+
+	// AccountTypeERC1155Token identifies a contract of type ERC1155 multi token
+	AccountTypeERC1155Token = "ERC1155"
+
+	// AccountTypeGnosisSafe identifies a Gnosis Safe multisig wallet contract
+	AccountTypeGnosisSafe = "GnosisSafe"
+
+	// AccountTypeUniswapPair identifies a Uniswap compatible liquidity pair contract
+	AccountTypeUniswapPair = "UniswapPair"
+
+	// AccountTypeProxy identifies a proxy contract delegating calls to an implementation
+	AccountTypeProxy = "proxy"
 )
 
+// ContractInterfaceERC165 identifies contracts implementing ERC-165 interface detection;
+// other interfaces share names with the corresponding account types.
+const ContractInterfaceERC165 = "ERC165"
+
 // Account represents an Opera account at the blockchain.
 type Account struct {
 	Address      common.Address `json:"address"`
@@ -31,6 +47,10 @@ type Account struct {
 	Type         string         `json:"type"`
 	LastActivity hexutil.Uint64 `json:"ats"`
 	TrxCounter   hexutil.Uint64 `json:"trc"`
+
+	// Interfaces is the list of interfaces detected on a contract account;
+	// nil if the account has not been classified yet.
+	Interfaces []string `json:"ifs"`
 }
 
 // UnmarshalAccount parses the JSON-encoded account data.
@@ -0,0 +1,52 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// AddressBloomWords is the number of 64 bit words of the address bloom filter.
+const AddressBloomWords = 32
+
+// AddressBloom represents a 2048 bit bloom filter of addresses involved in a block,
+// organized in 64 bit words so it can be updated and matched by the database.
+type AddressBloom [AddressBloomWords]uint64
+
+// NewAddressBloom creates a bloom filter of the given address.
+func NewAddressBloom(addr common.Address) AddressBloom {
+	var bl AddressBloom
+	bl.Add(addr)
+	return bl
+}
+
+// Add adds the address to the bloom filter. The address is hashed the same way
+// as the Ethereum logs bloom does, three bits are set for each address.
+func (bl *AddressBloom) Add(addr common.Address) {
+	h := crypto.Keccak256(addr.Bytes())
+	for i := 0; i < 6; i += 2 {
+		bit := (uint(h[i])<<8 | uint(h[i+1])) & 2047
+		bl[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// AddTopic adds the address carried by the log topic to the bloom filter.
+// Topics not containing an address, e.g. with any of the top 12 bytes set, are ignored.
+func (bl *AddressBloom) AddTopic(topic common.Hash) {
+	for _, b := range topic[:common.HashLength-common.AddressLength] {
+		if b != 0 {
+			return
+		}
+	}
+	bl.Add(common.BytesToAddress(topic.Bytes()))
+}
+
+// IsEmpty checks if the bloom filter has no bits set.
+func (bl *AddressBloom) IsEmpty() bool {
+	for _, w := range bl {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
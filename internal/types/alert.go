@@ -0,0 +1,248 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiAlertRulePk    = "_id"
+	FiAlertRuleOwner = "owner"
+	FiAlertRuleState = "state"
+	FiAlertRuleBlock = "blk"
+
+	FiAlertPk        = "_id"
+	FiAlertOwner     = "owner"
+	FiAlertTime      = "stamp"
+	FiAlertDelivered = "sent"
+	FiAlertWebhook   = "hook"
+)
+
+// types of alert rules
+const (
+	AlertRuleBalanceBelow = "BALANCE_BELOW"
+	AlertRuleEventEmitted = "EVENT_EMITTED"
+	AlertRuleOwnerChanged = "OWNER_CHANGED"
+)
+
+// AlertRule represents a condition registered by an API client
+// evaluated on new blocks; an alert is raised when the condition is met.
+type AlertRule struct {
+	// Id is the unique identifier of the rule.
+	Id string
+
+	// Owner is the identifier of the API client owning the rule.
+	Owner string
+
+	// Type is the type of the rule condition.
+	Type string
+
+	// Address is the monitored account, or contract.
+	Address common.Address
+
+	// Threshold is the balance threshold of the BALANCE_BELOW rule.
+	Threshold *hexutil.Big
+
+	// Topic is the event signature of the EVENT_EMITTED rule.
+	Topic *common.Hash
+
+	// Webhook is an optional URL the alerts of the rule are posted to.
+	Webhook string
+
+	// Created is the time the rule was registered.
+	Created time.Time
+
+	// State is the last evaluated state of the monitored condition,
+	// e.g. the last known owner of the contract.
+	State string
+
+	// Block is the last block the rule was evaluated on.
+	Block uint64
+}
+
+// BsonAlertRule represents BSON structure of the alert rule.
+type BsonAlertRule struct {
+	ID        string    `bson:"_id"`
+	Owner     string    `bson:"owner"`
+	Type      string    `bson:"type"`
+	Addr      string    `bson:"adr"`
+	Threshold *string   `bson:"thr"`
+	Topic     *string   `bson:"topic"`
+	Webhook   string    `bson:"hook"`
+	Created   time.Time `bson:"created"`
+	State     string    `bson:"state"`
+	Block     uint64    `bson:"blk"`
+}
+
+// Alert represents an alert raised by an alert rule.
+type Alert struct {
+	// Rule is the identifier of the rule raising the alert.
+	Rule string
+
+	// Owner is the identifier of the API client owning the rule.
+	Owner string
+
+	// Type is the type of the rule raising the alert.
+	Type string
+
+	// Address is the monitored account, or contract.
+	Address common.Address
+
+	// Block is the number of the block the alert was raised on.
+	Block uint64
+
+	// Seq distinguishes alerts of the same rule raised on the same block, e.g. multiple events.
+	Seq uint
+
+	// Trx is the transaction causing the alert, if known.
+	Trx *common.Hash
+
+	// Message describes the alert.
+	Message string
+
+	// Time is the time the alert was raised.
+	Time time.Time
+
+	// Webhook is the URL of the rule the alert is posted to, if any.
+	Webhook string
+
+	// Delivered signals the alert has been posted to the webhook of the rule.
+	Delivered bool
+}
+
+// BsonAlert represents BSON structure of the alert.
+type BsonAlert struct {
+	ID        string    `bson:"_id"`
+	Rule      string    `bson:"rule"`
+	Owner     string    `bson:"owner"`
+	Type      string    `bson:"type"`
+	Addr      string    `bson:"adr"`
+	Block     uint64    `bson:"blk"`
+	Seq       uint      `bson:"seq"`
+	Trx       *string   `bson:"trx"`
+	Message   string    `bson:"msg"`
+	Time      time.Time `bson:"stamp"`
+	Webhook   string    `bson:"hook"`
+	Delivered bool      `bson:"sent"`
+}
+
+// MarshalBSON creates a BSON representation of the alert rule record.
+func (ar *AlertRule) MarshalBSON() ([]byte, error) {
+	row := BsonAlertRule{
+		ID:      ar.Id,
+		Owner:   ar.Owner,
+		Type:    ar.Type,
+		Addr:    ar.Address.String(),
+		Webhook: ar.Webhook,
+		Created: ar.Created,
+		State:   ar.State,
+		Block:   ar.Block,
+	}
+	if ar.Threshold != nil {
+		thr := ar.Threshold.String()
+		row.Threshold = &thr
+	}
+	if ar.Topic != nil {
+		topic := ar.Topic.String()
+		row.Topic = &topic
+	}
+	return bson.Marshal(row)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (ar *AlertRule) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row BsonAlertRule
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	ar.Id = row.ID
+	ar.Owner = row.Owner
+	ar.Type = row.Type
+	ar.Address = common.HexToAddress(row.Addr)
+	ar.Webhook = row.Webhook
+	ar.Created = row.Created
+	ar.State = row.State
+	ar.Block = row.Block
+	if row.Threshold != nil {
+		ar.Threshold = (*hexutil.Big)(hexutil.MustDecodeBig(*row.Threshold))
+	}
+	if row.Topic != nil {
+		topic := common.HexToHash(*row.Topic)
+		ar.Topic = &topic
+	}
+	return nil
+}
+
+// Pk returns a unique primary key of the alert.
+func (al *Alert) Pk() string {
+	return fmt.Sprintf("%s:%d:%d", al.Rule, al.Block, al.Seq)
+}
+
+// MarshalBSON creates a BSON representation of the alert record.
+func (al *Alert) MarshalBSON() ([]byte, error) {
+	row := BsonAlert{
+		ID:        al.Pk(),
+		Rule:      al.Rule,
+		Owner:     al.Owner,
+		Type:      al.Type,
+		Addr:      al.Address.String(),
+		Block:     al.Block,
+		Seq:       al.Seq,
+		Message:   al.Message,
+		Time:      al.Time,
+		Webhook:   al.Webhook,
+		Delivered: al.Delivered,
+	}
+	if al.Trx != nil {
+		trx := al.Trx.String()
+		row.Trx = &trx
+	}
+	return bson.Marshal(row)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (al *Alert) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row BsonAlert
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	al.Rule = row.Rule
+	al.Owner = row.Owner
+	al.Type = row.Type
+	al.Address = common.HexToAddress(row.Addr)
+	al.Block = row.Block
+	al.Seq = row.Seq
+	al.Message = row.Message
+	al.Time = row.Time
+	al.Webhook = row.Webhook
+	al.Delivered = row.Delivered
+	if row.Trx != nil {
+		trx := common.HexToHash(*row.Trx)
+		al.Trx = &trx
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiBallotVotePk       = "_id"
+	FiBallotVoteBallot   = "ballot"
+	FiBallotVoteVoter    = "voter"
+	FiBallotVoteProposal = "prop"
+	FiBallotVoteTrx      = "trx"
+	FiBallotVoteStamp    = "stamp"
+)
+
+// Ballot represents a simple community voting contract.
+type Ballot struct {
+	Address   common.Address
+	Name      string
+	Url       string
+	Start     hexutil.Uint64
+	End       hexutil.Uint64
+	Finalized bool
+	Winner    hexutil.Uint64
+	Proposals []BallotProposal
+}
+
+// BallotProposal represents a single option of a ballot
+// along with the on-chain votes received.
+type BallotProposal struct {
+	Name  string
+	Votes hexutil.Big
+}
+
+// BallotVote represents an indexed vote on a ballot.
+type BallotVote struct {
+	Ballot    common.Address
+	Voter     common.Address
+	Proposal  uint64
+	Trx       common.Hash
+	TimeStamp time.Time
+}
+
+// BsonBallotVote represents the BSON i/o struct for a ballot vote.
+type BsonBallotVote struct {
+	ID       string    `bson:"_id"`
+	Ballot   string    `bson:"ballot"`
+	Voter    string    `bson:"voter"`
+	Proposal int64     `bson:"prop"`
+	Trx      string    `bson:"trx"`
+	Stamp    time.Time `bson:"stamp"`
+}
+
+// Pk generates the primary key of the vote; each voter has a single vote on a ballot.
+func (bv *BallotVote) Pk() string {
+	return bv.Ballot.String() + bv.Voter.String()
+}
+
+// MarshalBSON creates a BSON representation of the ballot vote record.
+func (bv *BallotVote) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonBallotVote{
+		ID:       bv.Pk(),
+		Ballot:   bv.Ballot.String(),
+		Voter:    bv.Voter.String(),
+		Proposal: int64(bv.Proposal),
+		Trx:      bv.Trx.String(),
+		Stamp:    bv.TimeStamp,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (bv *BallotVote) UnmarshalBSON(data []byte) error {
+	// try to decode the BSON data
+	var row BsonBallotVote
+	if err := bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	bv.Ballot = common.HexToAddress(row.Ballot)
+	bv.Voter = common.HexToAddress(row.Voter)
+	bv.Proposal = uint64(row.Proposal)
+	bv.Trx = common.HexToHash(row.Trx)
+	bv.TimeStamp = row.Stamp
+	return nil
+}
@@ -0,0 +1,43 @@
+// Package types implements different core types of the API.
+package types
+
+import "time"
+
+const (
+	// FiBlockFinalityPk is the name of the primary key field of the block finality record.
+	FiBlockFinalityPk = "_id"
+
+	// FiBlockFinalityStamp is the name of the block time stamp field of the block finality record.
+	FiBlockFinalityStamp = "stamp"
+)
+
+// BlockFinality represents the finality latency of a block observed
+// by the API server when the block arrived as the new head.
+type BlockFinality struct {
+	// Block is the number of the block.
+	Block uint64 `bson:"_id"`
+
+	// TimeToFinality is the time between the block Atropos event
+	// creation and the block being observed as final, in milliseconds.
+	TimeToFinality int64 `bson:"ttf"`
+
+	// TimeStamp is the time stamp of the block.
+	TimeStamp time.Time `bson:"stamp"`
+}
+
+// FinalityStats represents aggregated finality latency of blocks in a time range.
+type FinalityStats struct {
+	// Blocks is the number of blocks with known finality in the range.
+	Blocks uint64
+
+	// AvgBlockTime is the average time between blocks in milliseconds.
+	AvgBlockTime int64
+
+	// Min, Max, Avg, Median and P95 represent the finality latency
+	// statistics in milliseconds.
+	Min    int64
+	Max    int64
+	Avg    int64
+	Median int64
+	P95    int64
+}
@@ -0,0 +1,21 @@
+// Package types implements different core types of the API.
+package types
+
+// ChainInfo represents the identification and capabilities of the block chain
+// network and the node the API server is connected to.
+type ChainInfo struct {
+	// ChainId is the identifier of the chain used to sign transactions.
+	ChainId uint64
+
+	// Network is the name of the network, if known.
+	Network string
+
+	// ClientVersion is the version of the node client software.
+	ClientVersion string
+
+	// Modules is the list of RPC API modules enabled on the node.
+	Modules []string
+
+	// Features is the list of API features enabled by the server configuration.
+	Features []string
+}
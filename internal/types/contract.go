@@ -11,6 +11,20 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 )
 
+const (
+	// ContractMatchExact represents validation with the compiled byte code
+	// identical to the deployed one, including the compiler metadata.
+	ContractMatchExact = "EXACT"
+
+	// ContractMatchMetadataStripped represents validation with the compiled byte code
+	// identical to the deployed one once the compiler metadata are removed.
+	ContractMatchMetadataStripped = "METADATA_STRIPPED"
+
+	// ContractMatchPartial represents validation with the compiled byte code
+	// matching the deployed one except for linked library addresses.
+	ContractMatchPartial = "PARTIAL"
+)
+
 // Contract represents an Opera smart contract at the blockchain.
 type Contract struct {
 	// Type represents a general type of the contract.
@@ -62,6 +76,23 @@ type Contract struct {
 	// Validated represents the unix timestamp
 	//of the contract source validation against deployed byte code.
 	Validated *hexutil.Uint64 `json:"ok,omitempty" bson:"is_ok,omitempty"`
+
+	// MatchLevel represents the level of the byte code match
+	// reached by the contract source validation, if validated.
+	MatchLevel string `json:"match,omitempty"`
+
+	// MetadataHash represents the compiler metadata hash (IPFS/Swarm)
+	// of the validated byte code, if available.
+	MetadataHash hexutil.Bytes `json:"mdh,omitempty"`
+
+	// CodeHash represents the Keccak256 hash of the deployed runtime byte code.
+	// Is nil if the byte code has not been hashed yet.
+	CodeHash *common.Hash `json:"codeh,omitempty"`
+
+	// VerifiedFrom represents the address of the validated contract with identical
+	// runtime byte code the validation was copied from. Is nil if the contract
+	// was validated directly.
+	VerifiedFrom *common.Address `json:"vfrom,omitempty"`
 }
 
 // BsonContract represents the contract data structure for BSON formatting.
@@ -82,6 +113,10 @@ type BsonContract struct {
 	Abi       string  `bson:"abi"`
 	SrcHash   *string `bson:"src_h"`
 	Validated *uint64 `bson:"val"`
+	Match     string  `bson:"match"`
+	MetaHash  *string `bson:"meta_h"`
+	CodeHash  *string `bson:"code_h"`
+	VerFrom   *string `bson:"vfrom"`
 }
 
 // UnmarshalContract parses the JSON-encoded smart contract data.
@@ -190,6 +225,7 @@ func (sc *Contract) MarshalBSON() ([]byte, error) {
 		OptRuns:  sc.OptimizeRuns,
 		Src:      sc.SourceCode,
 		Abi:      sc.Abi,
+		Match:    sc.MatchLevel,
 	}
 	// is validated?
 	if sc.Validated != nil {
@@ -200,6 +236,21 @@ func (sc *Contract) MarshalBSON() ([]byte, error) {
 		val := sc.SourceCodeHash.String()
 		row.SrcHash = &val
 	}
+	// do we have compiler metadata hash?
+	if sc.MetadataHash != nil {
+		val := sc.MetadataHash.String()
+		row.MetaHash = &val
+	}
+	// do we have the runtime code hash?
+	if sc.CodeHash != nil {
+		val := sc.CodeHash.String()
+		row.CodeHash = &val
+	}
+	// is verified by similarity?
+	if sc.VerifiedFrom != nil {
+		val := sc.VerifiedFrom.String()
+		row.VerFrom = &val
+	}
 	return bson.Marshal(row)
 }
 
@@ -231,6 +282,7 @@ func (sc *Contract) UnmarshalBSON(data []byte) (err error) {
 	sc.OptimizeRuns = row.OptRuns
 	sc.SourceCode = row.Src
 	sc.Abi = row.Abi
+	sc.MatchLevel = row.Match
 	if row.Validated != nil {
 		sc.Validated = (*hexutil.Uint64)(row.Validated)
 	}
@@ -238,5 +290,16 @@ func (sc *Contract) UnmarshalBSON(data []byte) (err error) {
 		val := common.HexToHash(*row.SrcHash)
 		sc.SourceCodeHash = &val
 	}
+	if row.MetaHash != nil {
+		sc.MetadataHash = common.FromHex(*row.MetaHash)
+	}
+	if row.CodeHash != nil {
+		val := common.HexToHash(*row.CodeHash)
+		sc.CodeHash = &val
+	}
+	if row.VerFrom != nil {
+		val := common.HexToAddress(*row.VerFrom)
+		sc.VerifiedFrom = &val
+	}
 	return nil
 }
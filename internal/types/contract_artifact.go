@@ -0,0 +1,114 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ContractArtifact represents the full compilation output of a validated
+// smart contract, so external tooling can consume the verified build directly.
+type ContractArtifact struct {
+	// Address represents the address of the validated contract.
+	Address common.Address `json:"address"`
+
+	// Name represents the name of the compiled contract.
+	Name string `json:"contractName"`
+
+	// Compiler represents the compiler language and version.
+	Compiler string `json:"compiler"`
+
+	// CompilerVersion represents the full version of the compiler used.
+	CompilerVersion string `json:"compilerVersion"`
+
+	// CompilerOptions represents the command line options of the compiler.
+	CompilerOptions string `json:"compilerOptions"`
+
+	// Abi represents the JSON encoded ABI definition of the contract.
+	Abi string `json:"abi"`
+
+	// Bytecode represents the hex encoded contract creation code.
+	// Linked libraries are kept as placeholders.
+	Bytecode string `json:"bytecode"`
+
+	// DeployedBytecode represents the hex encoded contract runtime code.
+	DeployedBytecode string `json:"deployedBytecode"`
+
+	// SourceMap represents the source map of the creation code.
+	SourceMap string `json:"sourceMap"`
+
+	// DeployedSourceMap represents the source map of the runtime code.
+	DeployedSourceMap string `json:"deployedSourceMap"`
+
+	// Metadata represents the JSON encoded compiler metadata.
+	Metadata string `json:"metadata"`
+
+	// Created represents the unix timestamp the artifact was created.
+	Created hexutil.Uint64 `json:"created"`
+}
+
+// BsonContractArtifact represents the contract artifact data structure for BSON formatting.
+type BsonContractArtifact struct {
+	Address     string `bson:"_id"`
+	Name        string `bson:"name"`
+	Compiler    string `bson:"sol"`
+	Version     string `bson:"ver"`
+	Options     string `bson:"opt"`
+	Abi         string `bson:"abi"`
+	Code        string `bson:"code"`
+	RuntimeCode string `bson:"rt_code"`
+	SrcMap      string `bson:"map"`
+	RuntimeMap  string `bson:"rt_map"`
+	Metadata    string `bson:"meta"`
+	Created     uint64 `bson:"ts"`
+}
+
+// MarshalBSON creates a BSON representation of the contract artifact record.
+func (ca *ContractArtifact) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonContractArtifact{
+		Address:     ca.Address.String(),
+		Name:        ca.Name,
+		Compiler:    ca.Compiler,
+		Version:     ca.CompilerVersion,
+		Options:     ca.CompilerOptions,
+		Abi:         ca.Abi,
+		Code:        ca.Bytecode,
+		RuntimeCode: ca.DeployedBytecode,
+		SrcMap:      ca.SourceMap,
+		RuntimeMap:  ca.DeployedSourceMap,
+		Metadata:    ca.Metadata,
+		Created:     uint64(ca.Created),
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (ca *ContractArtifact) UnmarshalBSON(data []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode stored contract artifact")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row BsonContractArtifact
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer data
+	ca.Address = common.HexToAddress(row.Address)
+	ca.Name = row.Name
+	ca.Compiler = row.Compiler
+	ca.CompilerVersion = row.Version
+	ca.CompilerOptions = row.Options
+	ca.Abi = row.Abi
+	ca.Bytecode = row.Code
+	ca.DeployedBytecode = row.RuntimeCode
+	ca.SourceMap = row.SrcMap
+	ca.DeployedSourceMap = row.RuntimeMap
+	ca.Metadata = row.Metadata
+	ca.Created = hexutil.Uint64(row.Created)
+	return nil
+}
@@ -0,0 +1,93 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiContractCreationPk      = "_id"
+	FiContractCreationCreator = "creator"
+	FiContractCreationStamp   = "stamp"
+
+	// ContractCreationMethodCreate identifies contracts deployed by CREATE opcode.
+	ContractCreationMethodCreate = "CREATE"
+
+	// ContractCreationMethodCreate2 identifies contracts deployed by CREATE2 opcode.
+	ContractCreationMethodCreate2 = "CREATE2"
+)
+
+// ContractCreation represents a contract deployed by another contract,
+// i.e. by a factory, as detected in the transaction call trace.
+type ContractCreation struct {
+	Address      common.Address
+	Creator      common.Address
+	Transaction  common.Hash
+	Method       string
+	Salt         *common.Hash
+	InitCodeHash common.Hash
+	TimeStamp    time.Time
+}
+
+// TransactionTrace represents the details of a transaction processing
+// collected from the node call trace.
+type TransactionTrace struct {
+	// Creations lists contracts deployed by other contracts.
+	Creations []*ContractCreation
+
+	// Touched lists accounts involved in internal value transfers;
+	// their balance changed, but it's not visible in the transaction itself.
+	Touched []common.Address
+}
+
+// BsonContractCreation represents the BSON i/o struct for a contract creation.
+type BsonContractCreation struct {
+	Address      string    `bson:"_id"`
+	Creator      string    `bson:"creator"`
+	Transaction  string    `bson:"trx"`
+	Method       string    `bson:"method"`
+	Salt         *string   `bson:"salt"`
+	InitCodeHash string    `bson:"init"`
+	TimeStamp    time.Time `bson:"stamp"`
+}
+
+// MarshalBSON creates a BSON representation of the contract creation record.
+func (cc *ContractCreation) MarshalBSON() ([]byte, error) {
+	row := BsonContractCreation{
+		Address:      cc.Address.String(),
+		Creator:      cc.Creator.String(),
+		Transaction:  cc.Transaction.String(),
+		Method:       cc.Method,
+		InitCodeHash: cc.InitCodeHash.String(),
+		TimeStamp:    cc.TimeStamp,
+	}
+	if cc.Salt != nil {
+		salt := cc.Salt.String()
+		row.Salt = &salt
+	}
+	return bson.Marshal(row)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (cc *ContractCreation) UnmarshalBSON(data []byte) error {
+	// try to decode the BSON data
+	var row BsonContractCreation
+	if err := bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	cc.Address = common.HexToAddress(row.Address)
+	cc.Creator = common.HexToAddress(row.Creator)
+	cc.Transaction = common.HexToHash(row.Transaction)
+	cc.Method = row.Method
+	cc.InitCodeHash = common.HexToHash(row.InitCodeHash)
+	cc.TimeStamp = row.TimeStamp
+	if row.Salt != nil {
+		salt := common.HexToHash(*row.Salt)
+		cc.Salt = &salt
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiContractEventPk        = "_id"
+	FiContractEventOrdinal   = "orx"
+	FiContractEventContract  = "con"
+	FiContractEventTopic     = "topic"
+	FiContractEventTimeStamp = "stamp"
+
+	FiContractEventStatContract = "con"
+	FiContractEventStatTopic    = "topic"
+	FiContractEventStatDay      = "day"
+	FiContractEventStatCount    = "cnt"
+)
+
+// ContractEvent represents a single event log record emitted by a contract.
+type ContractEvent struct {
+	Contract    common.Address
+	Topics      []common.Hash
+	Data        []byte
+	Trx         common.Hash
+	BlockNumber uint64
+	LogIndex    uint
+	TimeStamp   hexutil.Uint64
+}
+
+// BsonContractEvent represents BSON structure of the contract event.
+type BsonContractEvent struct {
+	ID        string    `bson:"_id"`
+	Ordinal   uint64    `bson:"orx"`
+	Contract  string    `bson:"con"`
+	Topic     string    `bson:"topic"`
+	Topics    []string  `bson:"top"`
+	Data      []byte    `bson:"data"`
+	Trx       string    `bson:"trx"`
+	Block     uint64    `bson:"blk"`
+	LogIndex  uint      `bson:"lix"`
+	Time      uint64    `bson:"when"`
+	TimeStamp time.Time `bson:"stamp"`
+}
+
+// ContractEventStat represents the number of events of the given signature
+// emitted by a contract on a single day.
+type ContractEventStat struct {
+	Topic common.Hash
+	Day   time.Time
+	Count uint64
+}
+
+// Pk returns a unique primary key of the contract event.
+func (ev *ContractEvent) Pk() string {
+	return fmt.Sprintf("%s:%d", ev.Trx.String(), ev.LogIndex)
+}
+
+// Topic returns the signature topic of the event; anonymous events don't have any.
+func (ev *ContractEvent) Topic() common.Hash {
+	if len(ev.Topics) == 0 {
+		return common.Hash{}
+	}
+	return ev.Topics[0]
+}
+
+// OrdinalIndex returns an ordinal index of the contract event.
+// The index is made of the block number and the index of the log in the block,
+// so events are sorted in the order they happened on the chain.
+func (ev *ContractEvent) OrdinalIndex() uint64 {
+	return (ev.BlockNumber&0xFFFFFFFFFF)<<24 | (uint64(ev.LogIndex) & 0xFFFFFF)
+}
+
+// MarshalBSON creates a BSON representation of the contract event record.
+func (ev *ContractEvent) MarshalBSON() ([]byte, error) {
+	row := BsonContractEvent{
+		ID:        ev.Pk(),
+		Ordinal:   ev.OrdinalIndex(),
+		Contract:  ev.Contract.String(),
+		Topic:     ev.Topic().String(),
+		Topics:    make([]string, len(ev.Topics)),
+		Data:      ev.Data,
+		Trx:       ev.Trx.String(),
+		Block:     ev.BlockNumber,
+		LogIndex:  ev.LogIndex,
+		Time:      uint64(ev.TimeStamp),
+		TimeStamp: time.Unix(int64(ev.TimeStamp), 0).UTC(),
+	}
+	for i, t := range ev.Topics {
+		row.Topics[i] = t.String()
+	}
+	return bson.Marshal(row)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (ev *ContractEvent) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row BsonContractEvent
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	ev.Contract = common.HexToAddress(row.Contract)
+	ev.Topics = make([]common.Hash, len(row.Topics))
+	for i, t := range row.Topics {
+		ev.Topics[i] = common.HexToHash(t)
+	}
+	ev.Data = row.Data
+	ev.Trx = common.HexToHash(row.Trx)
+	ev.BlockNumber = row.Block
+	ev.LogIndex = row.LogIndex
+	ev.TimeStamp = hexutil.Uint64(row.Time)
+	return nil
+}
@@ -0,0 +1,44 @@
+// Package types implements different core types of the API.
+package types
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// ContractEventList represents a list of contract events.
+type ContractEventList struct {
+	// List keeps the actual Collection.
+	Collection []*ContractEvent
+
+	// Total indicates total number of events in the whole collection.
+	Total uint64
+
+	// First is the index of the first item on the list
+	First uint64
+
+	// Last is the index of the last item on the list
+	Last uint64
+
+	// IsStart indicates there are no events available above the list currently.
+	IsStart bool
+
+	// IsEnd indicates there are no events available below the list currently.
+	IsEnd bool
+
+	// Filter represents the base filter used for filtering the list
+	Filter bson.D
+}
+
+// Reverse reverses the order of events in the list.
+func (c *ContractEventList) Reverse() {
+	// anything to swap at all?
+	if c.Collection == nil || len(c.Collection) < 2 {
+		return
+	}
+
+	// swap elements
+	for i, j := 0, len(c.Collection)-1; i < j; i, j = i+1, j-1 {
+		c.Collection[i], c.Collection[j] = c.Collection[j], c.Collection[i]
+	}
+
+	// swap indexes
+	c.First, c.Last = c.Last, c.First
+}
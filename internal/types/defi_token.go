@@ -34,6 +34,10 @@ type DefiToken struct {
 	// usually have 8 digits.
 	PriceDecimals int32 `json:"priceDecimals"`
 
+	// Oracle is the address of the on-chain price feed of the token
+	// aggregated by the fMint price oracle proxy.
+	Oracle common.Address `json:"oracle"`
+
 	// IsActive signals if the token can be used in the DeFi functions at all.
 	IsActive bool `json:"isActive"`
 
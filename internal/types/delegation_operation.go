@@ -0,0 +1,113 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiDelegationOperationPk          = "_id"
+	FiDelegationOperationOrdinal     = "orx"
+	FiDelegationOperationAddress     = "adr"
+	FiDelegationOperationToValidator = "to"
+	FiDelegationOperationType        = "type"
+	FiDelegationOperationTimeStamp   = "stamp"
+)
+
+// types of delegation operations
+const (
+	DelegationOperationDelegate   = "DELEGATE"
+	DelegationOperationIncrease   = "INCREASE"
+	DelegationOperationClaim      = "CLAIM"
+	DelegationOperationRestake    = "RESTAKE"
+	DelegationOperationUndelegate = "UNDELEGATE"
+	DelegationOperationWithdraw   = "WITHDRAW"
+)
+
+// DelegationOperation represents a single operation on a delegation
+// in the SFC contract, e.g. a new stake, claimed rewards, or un-delegation.
+type DelegationOperation struct {
+	Type          string
+	Delegator     common.Address
+	ToValidatorId hexutil.Big
+	Amount        hexutil.Big
+	Trx           common.Hash
+	BlockNumber   uint64
+	LogIndex      uint
+	TimeStamp     hexutil.Uint64
+}
+
+// BsonDelegationOperation represents BSON structure of the delegation operation.
+type BsonDelegationOperation struct {
+	ID        string    `bson:"_id"`
+	Ordinal   uint64    `bson:"orx"`
+	Type      string    `bson:"type"`
+	Addr      string    `bson:"adr"`
+	To        string    `bson:"to"`
+	Amount    string    `bson:"amo"`
+	Trx       string    `bson:"trx"`
+	Block     uint64    `bson:"blk"`
+	LogIndex  uint      `bson:"lix"`
+	Time      uint64    `bson:"when"`
+	TimeStamp time.Time `bson:"stamp"`
+}
+
+// Pk returns a unique primary key of the delegation operation.
+func (op *DelegationOperation) Pk() string {
+	return fmt.Sprintf("%s:%d", op.Trx.String(), op.LogIndex)
+}
+
+// OrdinalIndex returns an ordinal index of the delegation operation.
+// The index is made of the block number and the index of the log in the block,
+// so operations are sorted in the order they happened on the chain.
+func (op *DelegationOperation) OrdinalIndex() uint64 {
+	return (op.BlockNumber&0xFFFFFFFFFF)<<24 | (uint64(op.LogIndex) & 0xFFFFFF)
+}
+
+// MarshalBSON creates a BSON representation of the delegation operation record.
+func (op *DelegationOperation) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonDelegationOperation{
+		ID:        op.Pk(),
+		Ordinal:   op.OrdinalIndex(),
+		Type:      op.Type,
+		Addr:      op.Delegator.String(),
+		To:        op.ToValidatorId.String(),
+		Amount:    op.Amount.String(),
+		Trx:       op.Trx.String(),
+		Block:     op.BlockNumber,
+		LogIndex:  op.LogIndex,
+		Time:      uint64(op.TimeStamp),
+		TimeStamp: time.Unix(int64(op.TimeStamp), 0),
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (op *DelegationOperation) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row BsonDelegationOperation
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	op.Type = row.Type
+	op.Delegator = common.HexToAddress(row.Addr)
+	op.ToValidatorId = (hexutil.Big)(*hexutil.MustDecodeBig(row.To))
+	op.Amount = (hexutil.Big)(*hexutil.MustDecodeBig(row.Amount))
+	op.Trx = common.HexToHash(row.Trx)
+	op.BlockNumber = row.Block
+	op.LogIndex = row.LogIndex
+	op.TimeStamp = hexutil.Uint64(row.Time)
+	return nil
+}
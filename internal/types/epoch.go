@@ -87,3 +87,33 @@ func (e *Epoch) UnmarshalBSON(data []byte) (err error) {
 	e.TotalSupply = (hexutil.Big)(*hexutil.MustDecodeBig(row.TotalSupply))
 	return nil
 }
+
+// EpochStats represents aggregated statistics of a sealed epoch.
+type EpochStats struct {
+	// Duration is the length of the epoch in seconds.
+	Duration uint64 `json:"dur" bson:"dur"`
+
+	// TxCount is the number of transactions processed in the epoch.
+	TxCount uint64 `json:"txc" bson:"txc"`
+
+	// GasUsed is the total amount of gas consumed by the epoch transactions.
+	GasUsed uint64 `json:"gas" bson:"gas"`
+
+	// Validators is the number of validators participating on the epoch.
+	Validators uint64 `json:"val" bson:"val"`
+
+	// OfflineValidators is the number of participating validators being offline in the epoch.
+	OfflineValidators uint64 `json:"off" bson:"off"`
+}
+
+// UnmarshalEpochStats parses the JSON-encoded epoch statistics data.
+func UnmarshalEpochStats(data []byte) (*EpochStats, error) {
+	var st EpochStats
+	err := json.Unmarshal(data, &st)
+	return &st, err
+}
+
+// Marshal returns the JSON encoding of epoch statistics.
+func (st *EpochStats) Marshal() ([]byte, error) {
+	return json.Marshal(st)
+}
@@ -14,11 +14,13 @@ import (
 const (
 	FiErc20TransactionPk        = "_id"
 	FiErc20TransactionOrdinal   = "orx"
-	FiErc20TransactionToken     = "erc"
+	FiErc20TransactionToken     = "tok"
 	FiErc20TransactionSender    = "from"
 	FiErc20TransactionRecipient = "to"
 	FiErc20TransactionType      = "type"
 	FiErc20TransactionStamp     = "stamp"
+	FiErc20TransactionTokenType = "tty"
+	FiErc20TransactionValue     = "val"
 
 	// ERC20TrxTypeTransfer represents transaction for transfers.
 	ERC20TrxTypeTransfer     = 1
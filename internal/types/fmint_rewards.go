@@ -0,0 +1,45 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FMintRewardsSchedule represents the state of the fMint rewards distribution
+// relevant to the rewards claiming schedule of an fMint account.
+type FMintRewardsSchedule struct {
+	// Address of the DeFi account.
+	Address common.Address
+
+	// EpochLength represents the length of a reward epoch in seconds.
+	EpochLength hexutil.Big
+
+	// EpochEnds represents the time stamp of the current reward epoch end.
+	EpochEnds hexutil.Big
+
+	// LastPush represents the time stamp of the last rewards push.
+	LastPush hexutil.Big
+
+	// MinPushInterval represents the minimal time in seconds between rewards pushes.
+	MinPushInterval hexutil.Big
+
+	// RewardRate represents the amount of rewards distributed per second
+	// during the current reward epoch.
+	RewardRate hexutil.Big
+
+	// Updated represents the time stamp of the last rewards state update.
+	Updated hexutil.Big
+
+	// PrincipalTotal represents the total principal balance of all accounts.
+	PrincipalTotal hexutil.Big
+
+	// Principal represents the principal balance of the account.
+	Principal hexutil.Big
+
+	// Earned represents the amount of rewards earned by the account so far.
+	Earned hexutil.Big
+
+	// CanClaim informs if the account is allowed to claim earned rewards.
+	CanClaim bool
+}
@@ -0,0 +1,246 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiFMintStatsPk = "_id"
+
+	FiFMintAccountPk      = "_id"
+	FiFMintAccountActive  = "active"
+	FiFMintAccountRatio   = "ratio"
+	FiFMintAccountOrdinal = "orx"
+)
+
+// fMintRatioOrdinalCap is the max collateral ratio, in 4 digits, distinguished by the ordinal index.
+const fMintRatioOrdinalCap = 1<<39 - 1
+
+// FMintTokenTotal represents the total amount of a DeFi token
+// locked in an fMint pool along with its value in ref. denomination (fUSD).
+type FMintTokenTotal struct {
+	Token  common.Address
+	Amount hexutil.Big
+	Value  hexutil.Big
+}
+
+// FMintStats represents a snapshot of the protocol level fMint metrics
+// aggregated over the indexed fMint accounts.
+type FMintStats struct {
+	// Time is the time of the snapshot.
+	Time time.Time
+
+	// Collateral is the list of collateral totals by token.
+	Collateral []FMintTokenTotal
+
+	// Debt is the list of minted debt totals by token.
+	Debt []FMintTokenTotal
+
+	// CollateralValue is the total value of the collateral in fUSD.
+	CollateralValue hexutil.Big
+
+	// DebtValue is the total value of the debt in fUSD.
+	DebtValue hexutil.Big
+
+	// Accounts is the number of indexed fMint accounts.
+	Accounts int32
+
+	// ActiveAccounts is the number of accounts with a debt.
+	ActiveAccounts int32
+
+	// AccountsAtRisk is the number of accounts with the collateral ratio below the warning ratio.
+	AccountsAtRisk int32
+
+	// WarningRatio4 is the warning collateral ratio used, represented in 4 digits.
+	WarningRatio4 uint64
+}
+
+// FMintAccountState represents the last known state of an fMint account
+// with its collateral and debt positions.
+type FMintAccountState struct {
+	// Address is the address of the account owner.
+	Address common.Address
+
+	// Collateral is the list of the collateral positions of the account.
+	Collateral []FMintTokenTotal
+
+	// Debt is the list of the debt positions of the account.
+	Debt []FMintTokenTotal
+
+	// CollateralValue is the total value of the collateral in fUSD.
+	CollateralValue hexutil.Big
+
+	// DebtValue is the total value of the debt in fUSD.
+	DebtValue hexutil.Big
+
+	// Ratio4 is the ratio between the collateral and the debt value in 4 digits;
+	// zero if the account has no debt.
+	Ratio4 uint64
+
+	// Updated is the time the state was refreshed.
+	Updated time.Time
+}
+
+// FMintAccountStateList represents a page of fMint account states
+// sorted by the collateral ratio.
+type FMintAccountStateList struct {
+	// Collection contains the account states of the page.
+	Collection []*FMintAccountState
+
+	// Total is the total number of account states matching the filter.
+	Total uint64
+
+	// IsEnd signals there are no more account states after the page.
+	IsEnd bool
+}
+
+// OrdinalIndex returns an index used to sort the accounts by the collateral ratio.
+func (fa *FMintAccountState) OrdinalIndex() uint64 {
+	ratio := fa.Ratio4
+	if ratio > fMintRatioOrdinalCap {
+		ratio = fMintRatioOrdinalCap
+	}
+	return ratio<<24 | uint64(fa.Address[17])<<16 | uint64(fa.Address[18])<<8 | uint64(fa.Address[19])
+}
+
+// BsonFMintTokenTotal represents the token total data structure for BSON formatting.
+type BsonFMintTokenTotal struct {
+	Token  string `bson:"tok"`
+	Amount string `bson:"amo"`
+	Value  string `bson:"val"`
+}
+
+// BsonFMintStats represents the fMint stats snapshot data structure for BSON formatting.
+type BsonFMintStats struct {
+	Time            time.Time             `bson:"_id"`
+	Collateral      []BsonFMintTokenTotal `bson:"col"`
+	Debt            []BsonFMintTokenTotal `bson:"debt"`
+	CollateralValue string                `bson:"col_val"`
+	DebtValue       string                `bson:"debt_val"`
+	Accounts        int32                 `bson:"acc"`
+	ActiveAccounts  int32                 `bson:"active"`
+	AccountsAtRisk  int32                 `bson:"risk"`
+	WarningRatio4   int64                 `bson:"warn"`
+}
+
+// MarshalBSON creates a BSON representation of the fMint stats snapshot.
+func (fs *FMintStats) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonFMintStats{
+		Time:            fs.Time,
+		Collateral:      bsonFMintTokenTotals(fs.Collateral),
+		Debt:            bsonFMintTokenTotals(fs.Debt),
+		CollateralValue: fs.CollateralValue.String(),
+		DebtValue:       fs.DebtValue.String(),
+		Accounts:        fs.Accounts,
+		ActiveAccounts:  fs.ActiveAccounts,
+		AccountsAtRisk:  fs.AccountsAtRisk,
+		WarningRatio4:   int64(fs.WarningRatio4),
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (fs *FMintStats) UnmarshalBSON(data []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode stored fMint stats")
+		}
+	}()
+
+	// try to decode BSON data
+	var row BsonFMintStats
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer the data points
+	fs.Time = row.Time
+	fs.Collateral = decodeFMintTokenTotals(row.Collateral)
+	fs.Debt = decodeFMintTokenTotals(row.Debt)
+	fs.CollateralValue = (hexutil.Big)(*hexutil.MustDecodeBig(row.CollateralValue))
+	fs.DebtValue = (hexutil.Big)(*hexutil.MustDecodeBig(row.DebtValue))
+	fs.Accounts = row.Accounts
+	fs.ActiveAccounts = row.ActiveAccounts
+	fs.AccountsAtRisk = row.AccountsAtRisk
+	fs.WarningRatio4 = uint64(row.WarningRatio4)
+	return nil
+}
+
+// bsonFMintTokenTotals converts the token totals into the BSON structure.
+func bsonFMintTokenTotals(list []FMintTokenTotal) []BsonFMintTokenTotal {
+	out := make([]BsonFMintTokenTotal, len(list))
+	for i, t := range list {
+		out[i] = BsonFMintTokenTotal{Token: t.Token.String(), Amount: t.Amount.String(), Value: t.Value.String()}
+	}
+	return out
+}
+
+// decodeFMintTokenTotals converts the BSON structure into the token totals.
+func decodeFMintTokenTotals(list []BsonFMintTokenTotal) []FMintTokenTotal {
+	out := make([]FMintTokenTotal, len(list))
+	for i, t := range list {
+		out[i] = FMintTokenTotal{
+			Token:  common.HexToAddress(t.Token),
+			Amount: (hexutil.Big)(*hexutil.MustDecodeBig(t.Amount)),
+			Value:  (hexutil.Big)(*hexutil.MustDecodeBig(t.Value)),
+		}
+	}
+	return out
+}
+
+// BsonFMintAccountState represents the fMint account state data structure for BSON formatting.
+type BsonFMintAccountState struct {
+	Address         string                `bson:"_id"`
+	Collateral      []BsonFMintTokenTotal `bson:"col"`
+	Debt            []BsonFMintTokenTotal `bson:"debt"`
+	CollateralValue string                `bson:"col_val"`
+	DebtValue       string                `bson:"debt_val"`
+	Active          bool                  `bson:"active"`
+	Ratio4          int64                 `bson:"ratio"`
+	Ordinal         int64                 `bson:"orx"`
+	Updated         time.Time             `bson:"upd"`
+}
+
+// BsonState provides the BSON structure of the account state.
+func (fa *FMintAccountState) BsonState() BsonFMintAccountState {
+	return BsonFMintAccountState{
+		Address:         fa.Address.String(),
+		Collateral:      bsonFMintTokenTotals(fa.Collateral),
+		Debt:            bsonFMintTokenTotals(fa.Debt),
+		CollateralValue: fa.CollateralValue.String(),
+		DebtValue:       fa.DebtValue.String(),
+		Active:          fa.DebtValue.ToInt().Sign() > 0,
+		Ratio4:          int64(fa.Ratio4),
+		Ordinal:         int64(fa.OrdinalIndex()),
+		Updated:         fa.Updated,
+	}
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (fa *FMintAccountState) UnmarshalBSON(data []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode stored fMint account state")
+		}
+	}()
+
+	// try to decode BSON data
+	var row BsonFMintAccountState
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer the data points
+	fa.Address = common.HexToAddress(row.Address)
+	fa.Collateral = decodeFMintTokenTotals(row.Collateral)
+	fa.Debt = decodeFMintTokenTotals(row.Debt)
+	fa.CollateralValue = (hexutil.Big)(*hexutil.MustDecodeBig(row.CollateralValue))
+	fa.DebtValue = (hexutil.Big)(*hexutil.MustDecodeBig(row.DebtValue))
+	fa.Ratio4 = uint64(row.Ratio4)
+	fa.Updated = row.Updated
+	return nil
+}
@@ -0,0 +1,99 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiFtmSupplyPk        = "_id"
+	FiFtmSupplyTimeStamp = "stamp"
+)
+
+// FtmSupply represents the state of the native FTM token supply
+// on a sealed epoch along with the supply changes made by the epoch.
+type FtmSupply struct {
+	// Epoch is the id of the sealed epoch.
+	Epoch hexutil.Uint64
+
+	// EndTime is the time stamp of the epoch sealing.
+	EndTime hexutil.Uint64
+
+	// TotalSupply is the total supply of FTM tokens on the epoch end.
+	TotalSupply hexutil.Big
+
+	// Staked is the total amount of FTM tokens staked on the epoch end.
+	Staked hexutil.Big
+
+	// Burned is the amount of transaction fees removed from circulation by the epoch.
+	Burned hexutil.Big
+
+	// Minted is the amount of new tokens minted by the epoch, i.e. staking rewards.
+	Minted hexutil.Big
+
+	// TotalBurned is the cumulative amount of fees burned up to the epoch end.
+	TotalBurned hexutil.Big
+}
+
+// BsonFtmSupply represents the supply record data structure for BSON formatting.
+type BsonFtmSupply struct {
+	ID          int64     `bson:"_id"`
+	EndTime     int64     `bson:"et"`
+	TimeStamp   time.Time `bson:"stamp"`
+	TotalSupply string    `bson:"supply"`
+	Staked      string    `bson:"stake"`
+	Burned      string    `bson:"burn"`
+	Minted      string    `bson:"mint"`
+	TotalBurned string    `bson:"burned"`
+}
+
+// Circulating returns the amount of tokens in circulation, e.g. not staked.
+func (fs *FtmSupply) Circulating() hexutil.Big {
+	val := new(hexutil.Big)
+	if fs.TotalSupply.ToInt().Cmp(fs.Staked.ToInt()) > 0 {
+		val.ToInt().Sub(fs.TotalSupply.ToInt(), fs.Staked.ToInt())
+	}
+	return *val
+}
+
+// MarshalBSON creates a BSON representation of the supply record.
+func (fs *FtmSupply) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonFtmSupply{
+		ID:          int64(fs.Epoch),
+		EndTime:     int64(fs.EndTime),
+		TimeStamp:   time.Unix(int64(fs.EndTime), 0),
+		TotalSupply: fs.TotalSupply.String(),
+		Staked:      fs.Staked.String(),
+		Burned:      fs.Burned.String(),
+		Minted:      fs.Minted.String(),
+		TotalBurned: fs.TotalBurned.String(),
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (fs *FtmSupply) UnmarshalBSON(data []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode stored supply record")
+		}
+	}()
+
+	// try to decode BSON data
+	var row BsonFtmSupply
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer the data points
+	fs.Epoch = hexutil.Uint64(row.ID)
+	fs.EndTime = hexutil.Uint64(row.EndTime)
+	fs.TotalSupply = (hexutil.Big)(*hexutil.MustDecodeBig(row.TotalSupply))
+	fs.Staked = (hexutil.Big)(*hexutil.MustDecodeBig(row.Staked))
+	fs.Burned = (hexutil.Big)(*hexutil.MustDecodeBig(row.Burned))
+	fs.Minted = (hexutil.Big)(*hexutil.MustDecodeBig(row.Minted))
+	fs.TotalBurned = (hexutil.Big)(*hexutil.MustDecodeBig(row.TotalBurned))
+	return nil
+}
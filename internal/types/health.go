@@ -0,0 +1,59 @@
+// Package types implements different core types of the API.
+package types
+
+import "time"
+
+// BackendHealth represents the health of a backend the API server depends on.
+type BackendHealth struct {
+	// Healthy signals the backend responded successfully.
+	Healthy bool
+
+	// Latency is the time the backend took to respond.
+	Latency time.Duration
+
+	// Error is the reason the backend is not healthy, if any.
+	Error string
+}
+
+// NodeHealth represents the health of the block chain node the API server is connected to.
+type NodeHealth struct {
+	BackendHealth
+
+	// Height is the number of the most recent block known to the node.
+	Height uint64
+
+	// Peers is the number of peers the node is connected to.
+	Peers uint64
+}
+
+// CacheStats represents the statistics of the in-memory cache.
+type CacheStats struct {
+	Entries    int
+	Capacity   int
+	Hits       int64
+	Misses     int64
+	Collisions int64
+}
+
+// DbPoolStats represents the statistics of the database connection pool.
+type DbPoolStats struct {
+	Open          int64
+	InUse         int64
+	Idle          int64
+	MaxSize       int64
+	CheckOuts     int64
+	CheckOutFails int64
+	Cleared       int64
+}
+
+// StorageInfo describes the off-chain storage the API server keeps its data in.
+type StorageInfo struct {
+	// Driver is the name of the storage driver, e.g. mongo or memory.
+	Driver string
+
+	// Persistent signals the stored data survive restart of the API server.
+	Persistent bool
+
+	// Limits lists the features the storage does not provide.
+	Limits []string
+}
@@ -0,0 +1,98 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiLabelPk       = "_id"
+	FiLabelName     = "name"
+	FiLabelCategory = "cat"
+	FiLabelWebsite  = "web"
+	FiLabelNote     = "note"
+	FiLabelUpdated  = "upd"
+)
+
+// known address label categories
+const (
+	AddressLabelCategoryExchange = "EXCHANGE"
+	AddressLabelCategoryTeam     = "TEAM"
+	AddressLabelCategoryScam     = "SCAM"
+	AddressLabelCategoryOther    = "OTHER"
+)
+
+// AddressLabel represents a public tag assigned to an address
+// to identify well known parties of the block chain.
+type AddressLabel struct {
+	Address  common.Address `json:"address"`
+	Name     string         `json:"name"`
+	Category string         `json:"category"`
+	Website  string         `json:"website,omitempty"`
+	Note     string         `json:"note,omitempty"`
+	Updated  time.Time      `json:"updated"`
+}
+
+// BsonAddressLabel represents the BSON i/o struct for an address label.
+type BsonAddressLabel struct {
+	Address  string    `bson:"_id"`
+	Name     string    `bson:"name"`
+	Category string    `bson:"cat"`
+	Website  string    `bson:"web"`
+	Note     string    `bson:"note"`
+	Updated  time.Time `bson:"upd"`
+}
+
+// IsValidAddressLabelCategory checks if the given category is known.
+func IsValidAddressLabelCategory(cat string) bool {
+	switch cat {
+	case AddressLabelCategoryExchange, AddressLabelCategoryTeam, AddressLabelCategoryScam, AddressLabelCategoryOther:
+		return true
+	}
+	return false
+}
+
+// MarshalBSON creates a BSON representation of the address label record.
+func (al *AddressLabel) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonAddressLabel{
+		Address:  al.Address.String(),
+		Name:     al.Name,
+		Category: al.Category,
+		Website:  al.Website,
+		Note:     al.Note,
+		Updated:  al.Updated,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (al *AddressLabel) UnmarshalBSON(data []byte) error {
+	// try to decode the BSON data
+	var row BsonAddressLabel
+	if err := bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	al.Address = common.HexToAddress(row.Address)
+	al.Name = row.Name
+	al.Category = row.Category
+	al.Website = row.Website
+	al.Note = row.Note
+	al.Updated = row.Updated
+	return nil
+}
+
+// UnmarshalAddressLabel parses the JSON-encoded address label data.
+func UnmarshalAddressLabel(data []byte) (*AddressLabel, error) {
+	var al AddressLabel
+	err := json.Unmarshal(data, &al)
+	return &al, err
+}
+
+// Marshal returns the JSON encoding of address label.
+func (al *AddressLabel) Marshal() ([]byte, error) {
+	return json.Marshal(al)
+}
@@ -0,0 +1,50 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+)
+
+// listCursorVersion marks the opaque list cursor payload so it can be told apart
+// from legacy cursors (hashes, hex and decimal numbers) still used by older clients.
+const listCursorVersion = 0x01
+
+// listCursorLength represents the length of the opaque list cursor payload.
+const listCursorLength = 9
+
+// ListCursor encodes the ordinal index of a list item into an opaque cursor.
+// The ordinal index of an item never changes, so the lists paged by these cursors
+// are not shifted when new blocks arrive between the page requests.
+func ListCursor(ordinal uint64) string {
+	var data [listCursorLength]byte
+	data[0] = listCursorVersion
+	binary.BigEndian.PutUint64(data[1:], ordinal)
+	return base64.RawURLEncoding.EncodeToString(data[:])
+}
+
+// PositionCursor encodes the position of a transaction, or a block, in the chain
+// into an opaque cursor. The position uses the same layout as the transaction ordinal index.
+func PositionCursor(block uint64, index uint64) string {
+	return ListCursor((block << 14) | (index & 0x3fff))
+}
+
+// DecodeListCursor decodes the ordinal index from the opaque list cursor.
+// The second value is false if the cursor is not an opaque list cursor.
+func DecodeListCursor(cursor string) (uint64, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(data) != listCursorLength || data[0] != listCursorVersion {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data[1:]), true
+}
+
+// DecodePositionCursor decodes the block number and the index inside the block
+// from the opaque position cursor.
+func DecodePositionCursor(cursor string) (block uint64, index uint64, ok bool) {
+	ord, ok := DecodeListCursor(cursor)
+	if !ok {
+		return 0, 0, false
+	}
+	return ord >> 14, ord & 0x3fff, true
+}
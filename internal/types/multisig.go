@@ -0,0 +1,25 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Multisig represents the configuration of a Safe-style multisig wallet contract.
+type Multisig struct {
+	// Address is the address of the multisig wallet.
+	Address common.Address
+
+	// Owners is the list of owners of the wallet.
+	Owners []common.Address
+
+	// Threshold is the number of owners' confirmations needed to execute a transaction.
+	Threshold hexutil.Uint64
+
+	// Nonce is the nonce of the next transaction to be executed by the wallet.
+	Nonce hexutil.Uint64
+
+	// Version is the version of the wallet contract, if available.
+	Version string
+}
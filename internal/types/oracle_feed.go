@@ -0,0 +1,49 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// sources of on-chain price feeds
+const (
+	OracleSourceChainlink = "CHAINLINK"
+	OracleSourceBand      = "BAND"
+	OracleSourceUnknown   = "UNKNOWN"
+)
+
+// OracleFeed represents the state of an on-chain price feed
+// used by the fMint protocol to value a DeFi token.
+type OracleFeed struct {
+	// Token is the address of the priced DeFi token.
+	Token common.Address
+
+	// Symbol is the symbol of the priced DeFi token.
+	Symbol string
+
+	// Oracle is the address of the price feed contract of the token.
+	Oracle common.Address
+
+	// Source is the type of the price feed adapter.
+	Source string
+
+	// Description is the description of the feed provided by the feed contract, if any.
+	Description string
+
+	// Price is the price of the token as provided by the fMint price oracle proxy.
+	Price hexutil.Big
+
+	// PriceDecimals is the number of decimals of the price.
+	PriceDecimals int32
+
+	// Round is the identifier of the latest feed round, if known.
+	Round uint64
+
+	// Updated is the time of the last price update of the feed, zero if unknown.
+	Updated time.Time
+
+	// Reference is the USD price of the token from an off-chain source, if available.
+	Reference *float64
+}
@@ -0,0 +1,65 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"strings"
+	"time"
+)
+
+const (
+	FiPriceSamplePk     = "_id"
+	FiPriceSampleSymbol = "sym"
+	FiPriceSampleTime   = "ts"
+)
+
+// PriceSample represents the price of FTM token in a target symbol
+// recorded at the given time.
+type PriceSample struct {
+	// Symbol is the target symbol of the price, e.g. USD.
+	Symbol string
+
+	// Time is the time the price was recorded.
+	Time time.Time
+
+	// Price is the price of a single FTM token in the target symbol.
+	Price float64
+}
+
+// BsonPriceSample represents BSON structure of the price sample.
+type BsonPriceSample struct {
+	ID     string    `bson:"_id"`
+	Symbol string    `bson:"sym"`
+	Time   time.Time `bson:"ts"`
+	Price  float64   `bson:"price"`
+}
+
+// Pk returns a unique primary key of the price sample.
+// Samples are kept with hourly granularity, the last sample of an hour wins.
+func (ps *PriceSample) Pk() string {
+	return fmt.Sprintf("%s:%d", strings.ToUpper(ps.Symbol), ps.Time.Truncate(time.Hour).Unix())
+}
+
+// MarshalBSON creates a BSON representation of the price sample record.
+func (ps *PriceSample) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonPriceSample{
+		ID:     ps.Pk(),
+		Symbol: strings.ToUpper(ps.Symbol),
+		Time:   ps.Time,
+		Price:  ps.Price,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (ps *PriceSample) UnmarshalBSON(data []byte) (err error) {
+	var row BsonPriceSample
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	ps.Symbol = row.Symbol
+	ps.Time = row.Time
+	ps.Price = row.Price
+	return nil
+}
@@ -0,0 +1,48 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// RelationFunder identifies the address which sent the first native tokens to the account.
+	RelationFunder = "FUNDER"
+
+	// RelationFunded identifies an address which received its first native tokens from the account.
+	RelationFunded = "FUNDED"
+
+	// RelationSharedFunder identifies an address funded first by the same funder as the account.
+	RelationSharedFunder = "SHARED_FUNDER"
+
+	// RelationDeployer identifies the address which deployed the contract account.
+	RelationDeployer = "DEPLOYER"
+
+	// RelationDeployed identifies a contract deployed by the account.
+	RelationDeployed = "DEPLOYED"
+
+	// RelationSharedDeployer identifies a contract deployed by the same deployer as the contract account.
+	RelationSharedDeployer = "SHARED_DEPLOYER"
+
+	// RelationSharedCode identifies an address which deployed the same runtime byte code as the account.
+	RelationSharedCode = "SHARED_CODE"
+)
+
+// RelationConfidence maps the relations between addresses to the heuristic
+// confidence of the addresses being controlled by the same entity.
+var RelationConfidence = map[string]float64{
+	RelationFunder:         0.6,
+	RelationFunded:         0.5,
+	RelationSharedFunder:   0.4,
+	RelationDeployer:       0.9,
+	RelationDeployed:       0.9,
+	RelationSharedDeployer: 0.5,
+	RelationSharedCode:     0.3,
+}
+
+// RelatedAddress represents an address linked to an account by the clustering heuristics.
+type RelatedAddress struct {
+	Address    common.Address
+	Relations  []string
+	Confidence float64
+}
@@ -14,9 +14,9 @@ import (
 const (
 	FiRewardClaimPk          = "_id"
 	FiRewardClaimOrdinal     = "orx"
-	FiRewardClaimAddress     = "adr"
+	FiRewardClaimAddress     = "addr"
 	FiRewardClaimToValidator = "to"
-	FiRewardClaimedValue     = "val"
+	FiRewardClaimedValue     = "value"
 	FiRewardClaimedTimeStamp = "stamp"
 )
 
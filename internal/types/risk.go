@@ -0,0 +1,88 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiRiskFlagPk      = "_id"
+	FiRiskFlagLevel   = "lvl"
+	FiRiskFlagReason  = "why"
+	FiRiskFlagUpdated = "upd"
+)
+
+// known risk levels of flagged contracts and tokens
+const (
+	RiskLevelNone   = "NONE"
+	RiskLevelLow    = "LOW"
+	RiskLevelMedium = "MEDIUM"
+	RiskLevelHigh   = "HIGH"
+)
+
+// RiskFlag represents a moderation flag of a contract, or a token
+// known to be used for scam, or phishing.
+type RiskFlag struct {
+	Address common.Address `json:"address"`
+	Level   string         `json:"level"`
+	Reason  string         `json:"reason,omitempty"`
+	Updated time.Time      `json:"updated"`
+}
+
+// BsonRiskFlag represents the BSON i/o struct for a risk flag.
+type BsonRiskFlag struct {
+	Address string    `bson:"_id"`
+	Level   string    `bson:"lvl"`
+	Reason  string    `bson:"why"`
+	Updated time.Time `bson:"upd"`
+}
+
+// IsValidRiskLevel checks if the given risk level is known.
+func IsValidRiskLevel(lvl string) bool {
+	switch lvl {
+	case RiskLevelNone, RiskLevelLow, RiskLevelMedium, RiskLevelHigh:
+		return true
+	}
+	return false
+}
+
+// MarshalBSON creates a BSON representation of the risk flag record.
+func (rf *RiskFlag) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonRiskFlag{
+		Address: rf.Address.String(),
+		Level:   rf.Level,
+		Reason:  rf.Reason,
+		Updated: rf.Updated,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (rf *RiskFlag) UnmarshalBSON(data []byte) error {
+	// try to decode the BSON data
+	var row BsonRiskFlag
+	if err := bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	rf.Address = common.HexToAddress(row.Address)
+	rf.Level = row.Level
+	rf.Reason = row.Reason
+	rf.Updated = row.Updated
+	return nil
+}
+
+// UnmarshalRiskFlag parses the JSON-encoded risk flag data.
+func UnmarshalRiskFlag(data []byte) (*RiskFlag, error) {
+	var rf RiskFlag
+	err := json.Unmarshal(data, &rf)
+	return &rf, err
+}
+
+// Marshal returns the JSON encoding of risk flag.
+func (rf *RiskFlag) Marshal() ([]byte, error) {
+	return json.Marshal(rf)
+}
@@ -0,0 +1,49 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	FiDenyListPk     = "_id"
+	FiDenyListList   = "list"
+	FiDenyListReason = "why"
+)
+
+// ScreeningMatch represents an address matched by the compliance screening.
+type ScreeningMatch struct {
+	Address common.Address `json:"address"`
+	List    string         `json:"list"`
+	Reason  string         `json:"reason,omitempty"`
+}
+
+// BsonScreeningMatch represents the BSON i/o struct for a denied address record.
+type BsonScreeningMatch struct {
+	Address string `bson:"_id"`
+	List    string `bson:"list"`
+	Reason  string `bson:"why"`
+}
+
+// MarshalBSON creates a BSON representation of the denied address record.
+func (sm *ScreeningMatch) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonScreeningMatch{
+		Address: sm.Address.String(),
+		List:    sm.List,
+		Reason:  sm.Reason,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (sm *ScreeningMatch) UnmarshalBSON(data []byte) error {
+	var row BsonScreeningMatch
+	if err := bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	sm.Address = common.HexToAddress(row.Address)
+	sm.List = row.List
+	sm.Reason = row.Reason
+	return nil
+}
@@ -0,0 +1,33 @@
+// Package types implements different core types of the API.
+package types
+
+import "time"
+
+// ServiceState represents the state of an internal service of the API server.
+type ServiceState struct {
+	// Name is the name of the service.
+	Name string
+
+	// Running signals the service has not been signaled to stop.
+	Running bool
+
+	// QueueLength is the number of items waiting in the service queue,
+	// if the service processes a queue.
+	QueueLength int
+
+	// QueueCapacity is the capacity of the service queue, if the service processes a queue.
+	QueueCapacity int
+
+	// LastRun is the time of the last run of a periodic service; zero if not run yet.
+	LastRun time.Time
+
+	// Period is the scheduling period of a periodic service; zero for other services.
+	Period time.Duration
+
+	// Paused signals the periodic runs of the service are paused.
+	Paused bool
+
+	// Leader signals the service holds the lock of its exclusive jobs
+	// across API peers sharing the database.
+	Leader bool
+}
@@ -0,0 +1,33 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common"
+
+const (
+	// SignatureTypePersonal identifies EIP-191 personal_sign message signature.
+	SignatureTypePersonal = "EIP191"
+
+	// SignatureTypeTypedData identifies EIP-712 typed data signature.
+	SignatureTypeTypedData = "EIP712"
+
+	// SignatureTypeContract identifies EIP-1271 contract wallet signature.
+	SignatureTypeContract = "EIP1271"
+)
+
+// SignatureVerification represents the result of a message signature verification.
+type SignatureVerification struct {
+	// Address is the address expected to have signed the message.
+	Address common.Address
+
+	// Signer is the address recovered from the signature, if any.
+	Signer *common.Address
+
+	// MessageHash is the hash of the message the signature was validated against.
+	MessageHash common.Hash
+
+	// Type is the signature scheme used to validate the signature.
+	Type string
+
+	// IsValid signals the signature matches the expected address.
+	IsValid bool
+}
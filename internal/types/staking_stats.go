@@ -0,0 +1,48 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// StakingStats represents aggregated staking statistics of the network.
+type StakingStats struct {
+	// TotalStaked is the total amount of tokens staked in the SFC.
+	TotalStaked hexutil.Big
+
+	// TotalSelfStaked is the amount staked by validators on themselves.
+	TotalSelfStaked hexutil.Big
+
+	// TotalDelegated is the amount staked by delegators on validators.
+	TotalDelegated hexutil.Big
+
+	// TotalRewardsClaimed is the cumulative amount of claimed staking rewards.
+	TotalRewardsClaimed hexutil.Big
+
+	// TotalSupply is the total supply of native tokens on the latest sealed epoch.
+	TotalSupply hexutil.Big
+
+	// AverageApr is the stake weighted average annual rate of return of validators.
+	AverageApr float64
+
+	// ParticipationRate is the ratio of staked tokens to the total supply, 0.0 to 1.0
+	ParticipationRate float64
+
+	// ActiveValidators is the number of active validators.
+	ActiveValidators uint64
+
+	// ActiveDelegations is the number of delegations with non-zero stake.
+	ActiveDelegations uint64
+
+	// Updated is the time stamp of the statistics calculation.
+	Updated hexutil.Uint64
+}
+
+// DailyRewards represents an aggregation of reward claims on a single day.
+type DailyRewards struct {
+	Day            string    `bson:"_id"`
+	Stamp          time.Time `bson:"stamp"`
+	Claims         int64     `bson:"claims"`
+	AmountAdjusted int64     `bson:"value"`
+}
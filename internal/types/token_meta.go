@@ -0,0 +1,89 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiTokenMetaPk          = "_id"
+	FiTokenMetaWhitelisted = "wl"
+	FiTokenMetaUpdated     = "upd"
+)
+
+// TokenMeta represents curated metadata of an ERC20 token.
+type TokenMeta struct {
+	Address     common.Address `json:"address"`
+	Name        string         `json:"name"`
+	Symbol      string         `json:"symbol"`
+	Decimals    int32          `json:"decimals"`
+	LogoURL     string         `json:"logo,omitempty"`
+	Description string         `json:"desc,omitempty"`
+	Website     string         `json:"web,omitempty"`
+	Whitelisted bool           `json:"wl"`
+	Updated     time.Time      `json:"updated"`
+}
+
+// BsonTokenMeta represents the BSON i/o struct for a token metadata.
+type BsonTokenMeta struct {
+	Address     string    `bson:"_id"`
+	Name        string    `bson:"name"`
+	Symbol      string    `bson:"sym"`
+	Decimals    int32     `bson:"dec"`
+	LogoURL     string    `bson:"logo"`
+	Description string    `bson:"desc"`
+	Website     string    `bson:"web"`
+	Whitelisted bool      `bson:"wl"`
+	Updated     time.Time `bson:"upd"`
+}
+
+// MarshalBSON creates a BSON representation of the token metadata record.
+func (tm *TokenMeta) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonTokenMeta{
+		Address:     tm.Address.String(),
+		Name:        tm.Name,
+		Symbol:      tm.Symbol,
+		Decimals:    tm.Decimals,
+		LogoURL:     tm.LogoURL,
+		Description: tm.Description,
+		Website:     tm.Website,
+		Whitelisted: tm.Whitelisted,
+		Updated:     tm.Updated,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (tm *TokenMeta) UnmarshalBSON(data []byte) error {
+	// try to decode the BSON data
+	var row BsonTokenMeta
+	if err := bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	tm.Address = common.HexToAddress(row.Address)
+	tm.Name = row.Name
+	tm.Symbol = row.Symbol
+	tm.Decimals = row.Decimals
+	tm.LogoURL = row.LogoURL
+	tm.Description = row.Description
+	tm.Website = row.Website
+	tm.Whitelisted = row.Whitelisted
+	tm.Updated = row.Updated
+	return nil
+}
+
+// UnmarshalTokenMeta parses the JSON-encoded token metadata.
+func UnmarshalTokenMeta(data []byte) (*TokenMeta, error) {
+	var tm TokenMeta
+	err := json.Unmarshal(data, &tm)
+	return &tm, err
+}
+
+// Marshal returns the JSON encoding of token metadata.
+func (tm *TokenMeta) Marshal() ([]byte, error) {
+	return json.Marshal(tm)
+}
@@ -0,0 +1,33 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// UniswapPairReserves represents the state of an Uniswap pair
+// used to route token prices through the pairs.
+type UniswapPairReserves struct {
+	Pair     common.Address
+	Tokens   [2]common.Address
+	Decimals [2]int32
+	Reserves [2]hexutil.Big
+}
+
+// TokenPrice represents a price of an ERC20 token derived from Uniswap pairs.
+type TokenPrice struct {
+	// Symbol is the target symbol of the price, i.e. USD.
+	Symbol string
+
+	// Price is the value of a single whole token in the target symbol.
+	Price float64
+
+	// Route is the list of tokens the price has been routed through,
+	// starting with the priced token.
+	Route []common.Address
+
+	// Liquidity is the lowest liquidity along the route in the target symbol,
+	// zero if the token is priced directly.
+	Liquidity float64
+}
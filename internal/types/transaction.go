@@ -81,6 +81,9 @@ type Transaction struct {
 
 	// Logs represents a list of log records created along with the transaction
 	Logs []retypes.Log `json:"logs"`
+
+	// RevertReason represents the reason of a failed transaction, if known.
+	RevertReason *string `json:"revertReason,omitempty"`
 }
 
 // BsonLog represents the transaction log record data structure for BSON formatting.
@@ -115,6 +118,7 @@ type BsonTransaction struct {
 	Status     uint64    `bson:"stat"`
 	Stamp      time.Time `bson:"stamp"`
 	Logs       []BsonLog `bson:"logs"`
+	Revert     *string   `bson:"revert,omitempty"`
 }
 
 // TransactionIndex calculates an ordinal index a transaction
@@ -167,6 +171,7 @@ func (trx *Transaction) MarshalBSON() ([]byte, error) {
 		Amount:     val.Int64(),
 		LargeInput: len(trx.InputData) > trxLargeInputWall,
 		Stamp:      trx.TimeStamp,
+		Revert:     trx.RevertReason,
 	}
 
 	// store the input data along with the trx
@@ -261,6 +266,7 @@ func (trx *Transaction) UnmarshalBSON(data []byte) (err error) {
 	trx.InputData = row.Input
 	trx.LargeInput = row.LargeInput
 	trx.TimeStamp = row.Stamp
+	trx.RevertReason = row.Revert
 
 	// try to decode the value
 	tv, err := hexutil.DecodeBig(row.Value)
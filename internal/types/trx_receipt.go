@@ -0,0 +1,32 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// TransactionReceipt represents the receipt of a processed transaction as provided by the node.
+type TransactionReceipt struct {
+	// Status represents transaction status; value is either 1 (success) or 0 (failure)
+	Status hexutil.Uint64 `json:"status"`
+
+	// GasUsed represents the amount of gas used by the transaction.
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+
+	// CumulativeGasUsed represents the total amount of gas used in the block up to the transaction.
+	CumulativeGasUsed hexutil.Uint64 `json:"cumulativeGasUsed"`
+
+	// EffectiveGasPrice represents the price paid per unit of gas; nil if not provided by the node.
+	EffectiveGasPrice *hexutil.Big `json:"effectiveGasPrice,omitempty"`
+
+	// ContractAddress represents the address of contract created, if any.
+	ContractAddress *common.Address `json:"contractAddress,omitempty"`
+
+	// LogsBloom represents the bloom filter of the transaction logs.
+	LogsBloom hexutil.Bytes `json:"logsBloom"`
+
+	// Logs represents the list of log records created by the transaction.
+	Logs []retypes.Log `json:"logs"`
+}
@@ -0,0 +1,20 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common"
+
+// TransactionSubmission represents the result of a single transaction
+// submitted to the block chain as a part of a batch.
+type TransactionSubmission struct {
+	// Index is the position of the transaction in the batch.
+	Index int
+
+	// Hash is the hash of the transaction, if it could be decoded.
+	Hash *common.Hash
+
+	// Transaction is the submitted transaction; nil if the submission failed.
+	Transaction *Transaction
+
+	// Error describes why the transaction was not submitted, if so.
+	Error error
+}
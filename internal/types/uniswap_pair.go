@@ -0,0 +1,82 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiUniswapPairPk      = "_id"
+	FiUniswapPairCreated = "blk"
+)
+
+// UniswapPairCreated represents a pair registered by the Uniswap factory.
+type UniswapPairCreated struct {
+	// Pair is the address of the new pair contract.
+	Pair common.Address
+
+	// Token0 is the address of the first token of the pair.
+	Token0 common.Address
+
+	// Token1 is the address of the second token of the pair.
+	Token1 common.Address
+
+	// Block is the number of the block the pair was created in.
+	Block hexutil.Uint64
+
+	// Trx is the hash of the transaction creating the pair.
+	Trx common.Hash
+
+	// TimeStamp is the time the pair was registered.
+	TimeStamp time.Time
+}
+
+// BsonUniswapPairCreated represents the registered pair data structure for BSON formatting.
+type BsonUniswapPairCreated struct {
+	Pair   string    `bson:"_id"`
+	Token0 string    `bson:"tok0"`
+	Token1 string    `bson:"tok1"`
+	Block  int64     `bson:"blk"`
+	Trx    string    `bson:"trx"`
+	Stamp  time.Time `bson:"stamp"`
+}
+
+// MarshalBSON creates a BSON representation of the registered pair.
+func (up *UniswapPairCreated) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonUniswapPairCreated{
+		Pair:   up.Pair.String(),
+		Token0: up.Token0.String(),
+		Token1: up.Token1.String(),
+		Block:  int64(up.Block),
+		Trx:    up.Trx.String(),
+		Stamp:  up.TimeStamp,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (up *UniswapPairCreated) UnmarshalBSON(data []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode stored uniswap pair")
+		}
+	}()
+
+	// try to decode BSON data
+	var row BsonUniswapPairCreated
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer the data points
+	up.Pair = common.HexToAddress(row.Pair)
+	up.Token0 = common.HexToAddress(row.Token0)
+	up.Token1 = common.HexToAddress(row.Token1)
+	up.Block = hexutil.Uint64(row.Block)
+	up.Trx = common.HexToHash(row.Trx)
+	up.TimeStamp = row.Stamp
+	return nil
+}
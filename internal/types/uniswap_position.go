@@ -0,0 +1,27 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// UniswapPositionEntry represents the aggregated liquidity provided
+// and removed by a liquidity provider on a single Uniswap pair.
+type UniswapPositionEntry struct {
+	// Pair represents address of the pair.
+	Pair common.Address
+
+	// Deposited0 is the total amount of the first token added to the pool.
+	Deposited0 *big.Int
+
+	// Deposited1 is the total amount of the second token added to the pool.
+	Deposited1 *big.Int
+
+	// Withdrawn0 is the total amount of the first token removed from the pool.
+	Withdrawn0 *big.Int
+
+	// Withdrawn1 is the total amount of the second token removed from the pool.
+	Withdrawn1 *big.Int
+}
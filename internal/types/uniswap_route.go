@@ -0,0 +1,19 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// UniswapRoute represents a swap route over a path of Uniswap pairs.
+type UniswapRoute struct {
+	// Tokens is the list of tokens on the swap path, including the input and the output token.
+	Tokens []common.Address
+
+	// Pairs is the list of Uniswap pairs used on each step of the swap path.
+	Pairs []common.Address
+
+	// Amounts is the list of amounts of tokens on each step of the swap path.
+	Amounts []hexutil.Big
+}
@@ -0,0 +1,26 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"time"
+)
+
+const (
+	FiUsagePk       = "_id"
+	FiUsageOrigin   = "org"
+	FiUsageHour     = "hour"
+	FiUsageRequests = "req"
+	FiUsageBytes    = "bytes"
+	FiUsageCost     = "cost"
+)
+
+// UsageRecord represents the API usage of a client origin in a time window.
+// The compute cost is the execution time of the requests in milliseconds.
+type UsageRecord struct {
+	Origin   string    `json:"origin" bson:"org"`
+	From     time.Time `json:"from" bson:"hour"`
+	To       time.Time `json:"to" bson:"-"`
+	Requests uint64    `json:"requests" bson:"req"`
+	Bytes    uint64    `json:"bytes" bson:"bytes"`
+	Cost     uint64    `json:"cost" bson:"cost"`
+}
@@ -17,3 +17,18 @@ type Validator struct {
 	DeactivatedEpoch hexutil.Uint64 `json:"deactivatedEpoch"`
 	DeactivatedTime  hexutil.Uint64 `json:"deactivatedTime"`
 }
+
+// ValidatorSnapshot represents a validator along with its recent performance
+// figures used to serve sorted and filtered lists of validators.
+type ValidatorSnapshot struct {
+	Validator
+
+	// Uptime is the ratio of time the validator was online, 0.0 to 1.0
+	Uptime float64
+
+	// Apr is the estimated annual rate of return for unlocked delegations
+	Apr float64
+
+	// Commission is the ratio of rewards taken by the validator, 0.0 to 1.0
+	Commission float64
+}
@@ -0,0 +1,54 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	FiValidatorAprPk        = "_id"
+	FiValidatorAprValidator = "val"
+	FiValidatorAprEpoch     = "ep"
+)
+
+// ValidatorApr represents the realized annual percentage rate
+// of the rewards of a validator in a sealed epoch.
+type ValidatorApr struct {
+	// ValidatorId is the ID of the validator.
+	ValidatorId uint64 `bson:"val"`
+
+	// Epoch is the ID of the sealed epoch.
+	Epoch uint64 `bson:"ep"`
+
+	// Time is the time the epoch was sealed.
+	Time time.Time `bson:"ts"`
+
+	// Apr is the reward per token earned in the epoch extrapolated to the whole year.
+	Apr float64 `bson:"apr"`
+
+	// NetworkApr is the average realized APR of all the validators of the epoch.
+	NetworkApr float64 `bson:"net"`
+
+	// Commission is the ratio of rewards taken by the validator in the epoch, 0.0 to 1.0;
+	// nil if the commission was not recorded.
+	Commission *float64 `bson:"com,omitempty"`
+}
+
+// CommissionChange represents the commission of a validator
+// taking effect on a sealed epoch.
+type CommissionChange struct {
+	// Epoch is the ID of the first sealed epoch with the commission.
+	Epoch uint64
+
+	// Time is the time the epoch was sealed.
+	Time time.Time
+
+	// Commission is the ratio of rewards taken by the validator, 0.0 to 1.0.
+	Commission float64
+}
+
+// Pk returns a unique primary key of the validator APR record.
+func (va *ValidatorApr) Pk() string {
+	return fmt.Sprintf("%d:%d", va.ValidatorId, va.Epoch)
+}
@@ -0,0 +1,148 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiWatchedAddressPk    = "_id"
+	FiWatchedAddressOwner = "owner"
+	FiWatchedAddress      = "adr"
+	FiWatchedAddressAdded = "added"
+
+	FiWatchDigestPk      = "_id"
+	FiWatchDigestAddress = "adr"
+	FiWatchDigestFrom    = "from"
+)
+
+// WatchedAddress represents an address on the watch list of an API client.
+type WatchedAddress struct {
+	// Owner is the identifier of the API client owning the watch list.
+	Owner string
+
+	// Address is the watched address.
+	Address common.Address
+
+	// Label is an optional private label of the address given by the client.
+	Label string
+
+	// Added is the time the address was added to the watch list.
+	Added time.Time
+}
+
+// BsonWatchedAddress represents BSON structure of the watched address.
+type BsonWatchedAddress struct {
+	ID    string    `bson:"_id"`
+	Owner string    `bson:"owner"`
+	Addr  string    `bson:"adr"`
+	Label string    `bson:"label"`
+	Added time.Time `bson:"added"`
+}
+
+// Pk returns a unique primary key of the watched address.
+func (wa *WatchedAddress) Pk() string {
+	return fmt.Sprintf("%s:%s", wa.Owner, wa.Address.String())
+}
+
+// MarshalBSON creates a BSON representation of the watched address record.
+func (wa *WatchedAddress) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonWatchedAddress{
+		ID:    wa.Pk(),
+		Owner: wa.Owner,
+		Addr:  wa.Address.String(),
+		Label: wa.Label,
+		Added: wa.Added,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (wa *WatchedAddress) UnmarshalBSON(data []byte) (err error) {
+	var row BsonWatchedAddress
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	wa.Owner = row.Owner
+	wa.Address = common.HexToAddress(row.Addr)
+	wa.Label = row.Label
+	wa.Added = row.Added
+	return nil
+}
+
+// WatchDigest represents aggregated activity of a watched address in a time period.
+// FTM values are in units of 10^-9 FTM, same as the transaction amount
+// stored in the database.
+type WatchDigest struct {
+	Address       common.Address
+	From          time.Time
+	To            time.Time
+	TrxSent       int64
+	TrxReceived   int64
+	ValueSent     int64
+	ValueReceived int64
+	Erc20Sent     int64
+	Erc20Received int64
+}
+
+// BsonWatchDigest represents BSON structure of the watched address activity digest.
+type BsonWatchDigest struct {
+	ID            string    `bson:"_id"`
+	Addr          string    `bson:"adr"`
+	From          time.Time `bson:"from"`
+	To            time.Time `bson:"to"`
+	TrxSent       int64     `bson:"trx_out"`
+	TrxReceived   int64     `bson:"trx_in"`
+	ValueSent     int64     `bson:"val_out"`
+	ValueReceived int64     `bson:"val_in"`
+	Erc20Sent     int64     `bson:"erc_out"`
+	Erc20Received int64     `bson:"erc_in"`
+}
+
+// Pk returns a unique primary key of the activity digest.
+func (wd *WatchDigest) Pk() string {
+	return fmt.Sprintf("%s:%d", wd.Address.String(), wd.From.Unix())
+}
+
+// IsEmpty checks if there was any activity in the digest period.
+func (wd *WatchDigest) IsEmpty() bool {
+	return wd.TrxSent == 0 && wd.TrxReceived == 0 && wd.Erc20Sent == 0 && wd.Erc20Received == 0
+}
+
+// MarshalBSON creates a BSON representation of the activity digest record.
+func (wd *WatchDigest) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonWatchDigest{
+		ID:            wd.Pk(),
+		Addr:          wd.Address.String(),
+		From:          wd.From,
+		To:            wd.To,
+		TrxSent:       wd.TrxSent,
+		TrxReceived:   wd.TrxReceived,
+		ValueSent:     wd.ValueSent,
+		ValueReceived: wd.ValueReceived,
+		Erc20Sent:     wd.Erc20Sent,
+		Erc20Received: wd.Erc20Received,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (wd *WatchDigest) UnmarshalBSON(data []byte) (err error) {
+	var row BsonWatchDigest
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	wd.Address = common.HexToAddress(row.Addr)
+	wd.From = row.From
+	wd.To = row.To
+	wd.TrxSent = row.TrxSent
+	wd.TrxReceived = row.TrxReceived
+	wd.ValueSent = row.ValueSent
+	wd.ValueReceived = row.ValueReceived
+	wd.Erc20Sent = row.Erc20Sent
+	wd.Erc20Received = row.Erc20Received
+	return nil
+}